@@ -51,16 +51,19 @@ type ExprOption struct {
 	MaxRulesLimit uint
 	// MaxRulesDepth defines the maximum depth of rules an expression allows.
 	MaxRulesDepth uint
+	// MaxRegexLength defines the maximum length of the regex operator's regular expression.
+	MaxRegexLength uint
 }
 
 // NewDefaultExprOpt init an expression option with default limit option.
 func NewDefaultExprOpt(ruleFields map[string]enumor.FieldType) *ExprOption {
 	return &ExprOption{
-		RuleFields:    ruleFields,
-		MaxInLimit:    200,
-		MaxNotInLimit: 200,
-		MaxRulesLimit: 50,
-		MaxRulesDepth: MaxRulesDepth,
+		RuleFields:     ruleFields,
+		MaxInLimit:     200,
+		MaxNotInLimit:  200,
+		MaxRulesLimit:  50,
+		MaxRulesDepth:  MaxRulesDepth,
+		MaxRegexLength: 200,
 	}
 }
 
@@ -73,6 +76,7 @@ func cloneExprOption(opt *ExprOption) *ExprOption {
 		MaxNotInLimit:    opt.MaxNotInLimit,
 		MaxRulesLimit:    opt.MaxRulesLimit,
 		MaxRulesDepth:    opt.MaxRulesDepth,
+		MaxRegexLength:   opt.MaxRegexLength,
 	}
 }
 
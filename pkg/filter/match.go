@@ -0,0 +1,352 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package filter
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+)
+
+// fieldValue is the values that a field path resolves to in a data, it is used to match the data in memory
+// the same way as mongodb matches a document with the field's query condition.
+type fieldValue struct {
+	// values are the values of all the branches that the field path resolves to.
+	values []interface{}
+	// missing defines if any branch of the field path does not exist, which is regarded as null value by mongodb.
+	missing bool
+}
+
+// getFieldValue resolves the field path in the data. like mongodb, the field is split into paths by dot, and when
+// an array is met in the middle of the path, the path is resolved in all the array's object elements, if the path
+// is an index of the array, the array element of that index is also resolved.
+func getFieldValue(data mapstr.MapStr, field string) *fieldValue {
+	fv := new(fieldValue)
+	resolveFieldPath(data, strings.Split(field, "."), fv)
+	return fv
+}
+
+func resolveFieldPath(data interface{}, paths []string, fv *fieldValue) {
+	if len(paths) == 0 {
+		fv.values = append(fv.values, data)
+		return
+	}
+
+	if data == nil {
+		fv.missing = true
+		return
+	}
+
+	val := reflect.ValueOf(data)
+	switch val.Kind() {
+	case reflect.Map:
+		if val.Type().Key().Kind() != reflect.String {
+			fv.missing = true
+			return
+		}
+
+		elem := val.MapIndex(reflect.ValueOf(paths[0]).Convert(val.Type().Key()))
+		if !elem.IsValid() {
+			fv.missing = true
+			return
+		}
+		resolveFieldPath(elem.Interface(), paths[1:], fv)
+	case reflect.Slice, reflect.Array:
+		resolved := false
+		if idx, err := strconv.Atoi(paths[0]); err == nil && idx >= 0 && idx < val.Len() {
+			resolveFieldPath(val.Index(idx).Interface(), paths[1:], fv)
+			resolved = true
+		}
+
+		// only object elements of the array are traversed, scalar elements do not have any sub field
+		for i := 0; i < val.Len(); i++ {
+			elem := val.Index(i).Interface()
+			if elem == nil || reflect.ValueOf(elem).Kind() != reflect.Map {
+				continue
+			}
+			resolveFieldPath(elem, paths, fv)
+			resolved = true
+		}
+
+		if !resolved {
+			fv.missing = true
+		}
+	default:
+		fv.missing = true
+	}
+}
+
+// exists returns if the field path resolves to any value.
+func (fv *fieldValue) exists() bool {
+	return len(fv.values) > 0
+}
+
+// matchAny returns if any of the field's values or array value's elements satisfies the match function.
+func (fv *fieldValue) matchAny(match func(v interface{}) (bool, error)) (bool, error) {
+	for _, value := range fv.values {
+		matched, err := match(value)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+
+		if !isArrayValue(value) {
+			continue
+		}
+
+		arr := reflect.ValueOf(value)
+		for i := 0; i < arr.Len(); i++ {
+			matched, err := match(arr.Index(i).Interface())
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// matchArray returns if any of the field's array values satisfies the match function, the elements are not checked.
+func (fv *fieldValue) matchArray(match func(length int) bool) bool {
+	for _, value := range fv.values {
+		if isArrayValue(value) && match(reflect.ValueOf(value).Len()) {
+			return true
+		}
+	}
+	return false
+}
+
+func isArrayValue(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Slice, reflect.Array:
+		return true
+	default:
+		return false
+	}
+}
+
+// matchEqual checks if the data value equals the operator's value with the mongodb comparison semantics, which
+// means that values of different types are not equal except numeric values like int and float. arrays are equal
+// if they have the same elements in the same order, embedded documents are equal if they have the same fields
+// with equal values.
+// NOTE: mongodb also requires the fields of embedded documents to be in the same order, but the order of map keys
+// is not preserved in memory, so it is ignored here.
+func matchEqual(data, value interface{}) bool {
+	if data == nil || value == nil {
+		return data == nil && value == nil
+	}
+
+	if util.IsNumeric(data) && util.IsNumeric(value) {
+		dataVal, dataErr := util.GetFloat64ByInterface(data)
+		val, valErr := util.GetFloat64ByInterface(value)
+		return dataErr == nil && valErr == nil && dataVal == val
+	}
+
+	dataVal := reflect.ValueOf(data)
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.String:
+		return dataVal.Kind() == reflect.String && dataVal.String() == val.String()
+	case reflect.Bool:
+		return dataVal.Kind() == reflect.Bool && dataVal.Bool() == val.Bool()
+	case reflect.Slice, reflect.Array:
+		return matchArrayEqual(dataVal, val)
+	case reflect.Map:
+		return matchObjectEqual(dataVal, val)
+	default:
+		return false
+	}
+}
+
+// matchArrayEqual checks if the data array has the same elements in the same order as the value array.
+func matchArrayEqual(data, value reflect.Value) bool {
+	if data.Kind() != reflect.Slice && data.Kind() != reflect.Array {
+		return false
+	}
+
+	if data.Len() != value.Len() {
+		return false
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		if !matchEqual(data.Index(i).Interface(), value.Index(i).Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchObjectEqual checks if the data object has the same fields with equal values as the value object.
+func matchObjectEqual(data, value reflect.Value) bool {
+	if data.Kind() != reflect.Map || data.Type().Key().Kind() != reflect.String ||
+		value.Type().Key().Kind() != reflect.String {
+		return false
+	}
+
+	if data.Len() != value.Len() {
+		return false
+	}
+
+	iter := value.MapRange()
+	for iter.Next() {
+		dataElem := data.MapIndex(reflect.ValueOf(iter.Key().String()).Convert(data.Type().Key()))
+		if !dataElem.IsValid() || !matchEqual(dataElem.Interface(), iter.Value().Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchIn checks if the data value equals any of the operator's array value's elements.
+func matchIn(data, value interface{}) (bool, error) {
+	if !isArrayValue(value) {
+		return false, fmt.Errorf("value(%+v) is not of array type", value)
+	}
+
+	arr := reflect.ValueOf(value)
+	for i := 0; i < arr.Len(); i++ {
+		if matchEqual(data, arr.Index(i).Interface()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchNumeric compares the numeric data value with the operator's numeric value, non-numeric data never matches.
+func matchNumeric(data, value interface{}, compare func(data, value float64) bool) (bool, error) {
+	if !util.IsNumeric(value) {
+		return false, fmt.Errorf("value(%+v) is not of numeric type", value)
+	}
+
+	if !util.IsNumeric(data) {
+		return false, nil
+	}
+
+	dataVal, err := util.GetFloat64ByInterface(data)
+	if err != nil {
+		return false, nil
+	}
+
+	val, err := util.GetFloat64ByInterface(value)
+	if err != nil {
+		return false, fmt.Errorf("value(%+v) is not of numeric type, err: %v", value, err)
+	}
+
+	return compare(dataVal, val), nil
+}
+
+// convDatetime convert data value to time, since datetime values are encoded as time formatted strings in json,
+// time formatted strings are regarded as datetime values too. timestamps are numeric values, not datetime values.
+func convDatetime(data interface{}) (time.Time, bool) {
+	switch val := data.(type) {
+	case time.Time:
+		return val, true
+	case *time.Time:
+		if val == nil {
+			return time.Time{}, false
+		}
+		return *val, true
+	case string:
+		timeType, isTime := util.IsTime(val)
+		if !isTime {
+			return time.Time{}, false
+		}
+		return util.Str2Time(val, timeType), true
+	case driver.Valuer:
+		// compatible for time type that wraps the time.Time, like metadata.Time
+		v, err := val.Value()
+		if err != nil {
+			return time.Time{}, false
+		}
+		t, ok := v.(time.Time)
+		return t, ok
+	default:
+		return time.Time{}, false
+	}
+}
+
+// matchDatetime compares the datetime data value with the operator's value, non-datetime data never matches.
+func matchDatetime(field string, value interface{}, data mapstr.MapStr, compare func(data, value time.Time) bool) (
+	bool, error) {
+
+	if len(field) == 0 {
+		return false, fmt.Errorf("field is empty")
+	}
+
+	timeVal, err := util.ConvToTime(value)
+	if err != nil {
+		return false, fmt.Errorf("convert value to time failed, err: %v", err)
+	}
+
+	return getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		dataTime, ok := convDatetime(v)
+		if !ok {
+			return false, nil
+		}
+		return compare(dataTime, timeVal), nil
+	})
+}
+
+// matchRegex checks if the string data value matches the regular expression, non-string data never matches.
+func matchRegex(field, pattern string, insensitive bool, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, fmt.Errorf("field is empty")
+	}
+
+	if insensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	reg, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("compile regular expression %s failed, err: %v", pattern, err)
+	}
+
+	return getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		strVal, ok := v.(string)
+		if !ok {
+			return false, nil
+		}
+		return reg.MatchString(strVal), nil
+	})
+}
+
+// getRegexValue get the string operator's value that is used to generate the regular expression.
+func getRegexValue(value interface{}) (string, error) {
+	strVal, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("value(%+v) is not of string type", value)
+	}
+	return strVal, nil
+}
@@ -0,0 +1,643 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package filter
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/json"
+	"configcenter/src/common/mapstr"
+)
+
+var matchFixtures = []mapstr.MapStr{
+	{
+		"name":   "host-a",
+		"num":    1,
+		"price":  1.5,
+		"flag":   true,
+		"time":   time.Unix(100, 0),
+		"tags":   []interface{}{"a", "b"},
+		"nums":   []interface{}{1, 5, 10},
+		"obj":    map[string]interface{}{"str": "Abc", "int": 3},
+		"list":   []interface{}{map[string]interface{}{"str": "x", "int": 1}, map[string]interface{}{"str": "y"}},
+		"nil":    nil,
+		"upper":  "ABC-def",
+		"option": "2",
+	},
+	{
+		"name":  "Host-B",
+		"num":   int64(5),
+		"price": float64(10),
+		"flag":  false,
+		"time":  time.Unix(200, 0),
+		"tags":  []interface{}{},
+		"nums":  []interface{}{},
+		"obj":   mapstr.MapStr{"str": "abd"},
+		"list":  []interface{}{},
+		"upper": "abc.DEF",
+	},
+	{
+		"name":   "other",
+		"num":    "5",
+		"price":  nil,
+		"time":   "2006",
+		"tags":   []interface{}{"c", nil},
+		"nums":   []interface{}{20},
+		"obj":    map[string]interface{}{"int": 3.0},
+		"list":   []interface{}{map[string]interface{}{"str": "z", "int": 10}},
+		"option": 2,
+	},
+	{
+		"name": "",
+		"num":  int32(0),
+		"time": 100,
+		"tags": "a",
+		"obj":  nil,
+		"list": []interface{}{1, "x"},
+	},
+	{},
+}
+
+var matchAtomRules = []*AtomRule{
+	{Field: "num", Operator: Equal.Factory(), Value: 5},
+	{Field: "name", Operator: Equal.Factory(), Value: "host-a"},
+	{Field: "flag", Operator: Equal.Factory(), Value: false},
+	{Field: "tags", Operator: Equal.Factory(), Value: "a"},
+	{Field: "option", Operator: NotEqual.Factory(), Value: "2"},
+	{Field: "flag", Operator: NotEqual.Factory(), Value: true},
+	{Field: "nums", Operator: NotEqual.Factory(), Value: 10},
+	{Field: "num", Operator: In.Factory(), Value: []interface{}{1, 5}},
+	{Field: "tags", Operator: In.Factory(), Value: []interface{}{"b", "c"}},
+	{Field: "name", Operator: NotIn.Factory(), Value: []interface{}{"other", ""}},
+	{Field: "nums", Operator: NotIn.Factory(), Value: []interface{}{1, 20}},
+	{Field: "num", Operator: Less.Factory(), Value: 5},
+	{Field: "price", Operator: LessOrEqual.Factory(), Value: 10},
+	{Field: "nums", Operator: Greater.Factory(), Value: 9},
+	{Field: "num", Operator: GreaterOrEqual.Factory(), Value: 1.5},
+	{Field: "time", Operator: DatetimeLess.Factory(), Value: 200},
+	{Field: "time", Operator: DatetimeLessOrEqual.Factory(), Value: 200},
+	{Field: "time", Operator: DatetimeGreater.Factory(), Value: 150},
+	{Field: "time", Operator: DatetimeGreaterOrEqual.Factory(), Value: time.Unix(100, 0)},
+	{Field: "name", Operator: BeginsWith.Factory(), Value: "host"},
+	{Field: "name", Operator: BeginsWithInsensitive.Factory(), Value: "host"},
+	{Field: "name", Operator: NotBeginsWith.Factory(), Value: "Host"},
+	{Field: "name", Operator: NotBeginsWithInsensitive.Factory(), Value: "HOST"},
+	{Field: "upper", Operator: Contains.Factory(), Value: "c.d"},
+	{Field: "upper", Operator: ContainsSensitive.Factory(), Value: "c.D"},
+	{Field: "tags", Operator: NotContains.Factory(), Value: "b"},
+	{Field: "upper", Operator: NotContainsInsensitive.Factory(), Value: "C-D"},
+	{Field: "name", Operator: EndsWith.Factory(), Value: "a"},
+	{Field: "name", Operator: EndsWithInsensitive.Factory(), Value: "b"},
+	{Field: "upper", Operator: NotEndsWith.Factory(), Value: "DEF"},
+	{Field: "upper", Operator: NotEndsWithInsensitive.Factory(), Value: "DEF"},
+	{Field: "upper", Operator: Regex.Factory(), Value: "^(?i)abc[.-]"},
+	{Field: "tags", Operator: Regex.Factory(), Value: "[ac]"},
+	{Field: "tags", Operator: IsEmpty.Factory(), Value: ""},
+	{Field: "nums", Operator: IsNotEmpty.Factory(), Value: ""},
+	{Field: "nums", Operator: Size.Factory(), Value: 1},
+	{Field: "nums", Operator: All.Factory(), Value: []interface{}{1, 10}},
+	{Field: "list.str", Operator: All.Factory(), Value: []interface{}{"x", "y"}},
+	{Field: "price", Operator: IsNull.Factory(), Value: ""},
+	{Field: "obj.str", Operator: IsNull.Factory(), Value: ""},
+	{Field: "list.str", Operator: IsNotNull.Factory(), Value: ""},
+	{Field: "nil", Operator: Exist.Factory(), Value: ""},
+	{Field: "list.int", Operator: NotExist.Factory(), Value: ""},
+	{Field: "obj", Operator: Object.Factory(), Value: &AtomRule{Field: "str", Operator: BeginsWithInsensitive.Factory(),
+		Value: "ab"}},
+	{Field: "obj", Operator: Object.Factory(), Value: &CombinedRule{Condition: Or, Rules: []RuleFactory{
+		&AtomRule{Field: "int", Operator: Equal.Factory(), Value: 3},
+		&AtomRule{Field: "str", Operator: NotExist.Factory(), Value: ""},
+	}}},
+	{Field: "tags", Operator: Array.Factory(), Value: &AtomRule{Field: ArrayElement, Operator: In.Factory(),
+		Value: []interface{}{"a", "c"}}},
+	{Field: "list", Operator: Array.Factory(), Value: &AtomRule{Field: ArrayElement, Operator: Object.Factory(),
+		Value: &CombinedRule{Condition: And, Rules: []RuleFactory{
+			&AtomRule{Field: "int", Operator: Greater.Factory(), Value: 5},
+			&AtomRule{Field: "str", Operator: NotEqual.Factory(), Value: "x"},
+		}}}},
+}
+
+// matchSeed is the seed to generate the random combined rules, use it to reproduce a failed test, e.g.
+// go test -run TestMatchWithMongoCond -match-seed 1667382000000000000
+var matchSeed = flag.Int64("match-seed", 0, "seed of the random combined rules, default is the current time")
+
+// TestMatchWithMongoCond tests that in memory match results are the same as the results of the mongo conditions
+func TestMatchWithMongoCond(t *testing.T) {
+	for _, rule := range matchAtomRules {
+		testMatchWithMongoCond(t, rule)
+	}
+
+	seed := *matchSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	t.Logf("random combined rules seed: %d", seed)
+
+	r := rand.New(rand.NewSource(seed))
+	for i := 0; i < 500; i++ {
+		testMatchWithMongoCond(t, genRandomCombinedRule(r, 3))
+	}
+}
+
+// TestMatchFixtures tests the regex, all and nested field path operators with the fixtures that each rule matches,
+// the results are also checked with the mongo conditions.
+func TestMatchFixtures(t *testing.T) {
+	cases := []struct {
+		rule    RuleFactory
+		matched []int
+	}{
+		// regex matches the string value or any string element of the array
+		{&AtomRule{Field: "upper", Operator: Regex.Factory(), Value: "^(?i)abc[.-]"}, []int{0, 1}},
+		{&AtomRule{Field: "name", Operator: Regex.Factory(), Value: "^host"}, []int{0}},
+		{&AtomRule{Field: "tags", Operator: Regex.Factory(), Value: "[ac]"}, []int{0, 2, 3}},
+		{&AtomRule{Field: "num", Operator: Regex.Factory(), Value: "5"}, []int{2}},
+		{&AtomRule{Field: "list.str", Operator: Regex.Factory(), Value: "^[xz]$"}, []int{0, 2}},
+		// all matches the arrays that contain all the elements, or the non-array value equals the only element
+		{&AtomRule{Field: "nums", Operator: All.Factory(), Value: []interface{}{1, 10}}, []int{0}},
+		{&AtomRule{Field: "tags", Operator: All.Factory(), Value: []interface{}{"a"}}, []int{0, 3}},
+		{&AtomRule{Field: "list.str", Operator: All.Factory(), Value: []interface{}{"x", "y"}}, []int{0}},
+		{&AtomRule{Field: "obj.int", Operator: All.Factory(), Value: []interface{}{3}}, []int{0, 2}},
+		{&AtomRule{Field: "nums", Operator: All.Factory(), Value: []interface{}{}}, []int{}},
+		// nested field path matches the embedded document field and the array elements' fields
+		{&AtomRule{Field: "obj.str", Operator: Equal.Factory(), Value: "abd"}, []int{1}},
+		{&AtomRule{Field: "obj.int", Operator: GreaterOrEqual.Factory(), Value: 3}, []int{0, 2}},
+		{&AtomRule{Field: "obj.str", Operator: NotExist.Factory(), Value: ""}, []int{2, 3, 4}},
+		{&AtomRule{Field: "list.int", Operator: Equal.Factory(), Value: 10}, []int{2}},
+		{&AtomRule{Field: "list.str", Operator: In.Factory(), Value: []interface{}{"y", "z"}}, []int{0, 2}},
+		{&AtomRule{Field: "list.0.str", Operator: Equal.Factory(), Value: "x"}, []int{0}},
+	}
+
+	for _, c := range cases {
+		testMatchWithMongoCond(t, c.rule)
+
+		matched := make([]int, 0)
+		for idx, data := range matchFixtures {
+			ok, err := c.rule.Match(data)
+			if err != nil {
+				t.Fatalf("match rule %s with data[%d] failed, err: %v", marshalRule(c.rule), idx, err)
+			}
+			if ok {
+				matched = append(matched, idx)
+			}
+		}
+
+		if !reflect.DeepEqual(matched, c.matched) {
+			t.Errorf("rule %s matches data %v, expected %v", marshalRule(c.rule), matched, c.matched)
+		}
+	}
+}
+
+func testMatchWithMongoCond(t *testing.T, rule RuleFactory) {
+	cond, err := rule.ToMgo()
+	if err != nil {
+		t.Fatalf("convert rule %s to mongo failed, err: %v", marshalRule(rule), err)
+	}
+
+	for idx, data := range matchFixtures {
+		matched, err := rule.Match(data)
+		if err != nil {
+			t.Fatalf("match rule %s with data[%d] failed, err: %v", marshalRule(rule), idx, err)
+		}
+
+		expected := mgoMatch(cond, data)
+		if matched != expected {
+			t.Fatalf("rule %s match data[%d] %+v result %v, but mongo condition %+v result is %v",
+				marshalRule(rule), idx, data, matched, cond, expected)
+		}
+	}
+}
+
+func genRandomCombinedRule(r *rand.Rand, depth int) RuleFactory {
+	if depth <= 1 || r.Intn(3) == 0 {
+		return matchAtomRules[r.Intn(len(matchAtomRules))]
+	}
+
+	rule := &CombinedRule{Condition: And}
+	if r.Intn(2) == 0 {
+		rule.Condition = Or
+	}
+
+	cnt := r.Intn(3) + 1
+	for i := 0; i < cnt; i++ {
+		rule.Rules = append(rule.Rules, genRandomCombinedRule(r, depth-1))
+	}
+	return rule
+}
+
+func marshalRule(rule RuleFactory) string {
+	js, _ := json.Marshal(rule)
+	return string(js)
+}
+
+func TestMatchDatetime(t *testing.T) {
+	rule := &AtomRule{Field: "time", Operator: DatetimeGreater.Factory(), Value: time.Unix(100, 0)}
+
+	// datetime fields are encoded as time formatted strings in json, they should be matched as datetime values
+	data := []mapstr.MapStr{
+		{"time": time.Unix(200, 0)},
+		{"time": time.Unix(200, 0).Format("2006-01-02 15:04:05")},
+		{"time": time.Unix(200, 0).Format("2006-01-02T15:04:05+08:00")},
+	}
+
+	for _, d := range data {
+		matched, err := rule.Match(d)
+		if err != nil {
+			t.Errorf("match data %+v failed, err: %v", d, err)
+			continue
+		}
+		if !matched {
+			t.Errorf("data %+v should match datetime greater rule", d)
+		}
+	}
+
+	// timestamps are numeric values, they do not match the datetime operators
+	matched, err := rule.Match(mapstr.MapStr{"time": 200})
+	if err != nil {
+		t.Errorf("match timestamp data failed, err: %v", err)
+		return
+	}
+	if matched {
+		t.Errorf("timestamp data should not match datetime greater rule")
+	}
+}
+
+func TestMatchError(t *testing.T) {
+	rules := []RuleFactory{
+		&AtomRule{Field: "name", Operator: Unknown.Factory(), Value: 1},
+		&AtomRule{Field: "name", Operator: In.Factory(), Value: 1},
+		&AtomRule{Field: "num", Operator: Less.Factory(), Value: "1"},
+		&AtomRule{Field: "time", Operator: DatetimeLess.Factory(), Value: "a"},
+		&AtomRule{Field: "name", Operator: BeginsWith.Factory(), Value: 1},
+		&AtomRule{Field: "name", Operator: Contains.Factory(), Value: "(a"},
+		&AtomRule{Field: "name", Operator: Regex.Factory(), Value: 1},
+		&AtomRule{Field: "tags", Operator: All.Factory(), Value: "a"},
+		&AtomRule{Field: "obj", Operator: Object.Factory(), Value: 1},
+		&AtomRule{Field: "list", Operator: Array.Factory(), Value: &AtomRule{Field: "str", Operator: Exist.Factory()}},
+		&CombinedRule{Condition: "test", Rules: []RuleFactory{matchAtomRules[0]}},
+		&CombinedRule{Condition: And},
+	}
+
+	for _, rule := range rules {
+		if _, err := rule.Match(matchFixtures[0]); err == nil {
+			t.Errorf("match rule %s should fail", marshalRule(rule))
+		}
+	}
+}
+
+// mgoMatch is a simplified mongodb query engine, it is used to check if the document matches the mongo condition.
+func mgoMatch(cond map[string]interface{}, doc map[string]interface{}) bool {
+	for key, val := range cond {
+		switch key {
+		case common.BKDBAND:
+			for _, subCond := range val.([]map[string]interface{}) {
+				if !mgoMatch(subCond, doc) {
+					return false
+				}
+			}
+		case common.BKDBOR:
+			matched := false
+			for _, subCond := range val.([]map[string]interface{}) {
+				if mgoMatch(subCond, doc) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		default:
+			values, missing := mgoFieldValues(doc, strings.Split(key, "."))
+			if !mgoMatchField(val.(map[string]interface{}), values, missing) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func mgoFieldValues(doc interface{}, paths []string) ([]interface{}, bool) {
+	if len(paths) == 0 {
+		return []interface{}{doc}, false
+	}
+
+	switch val := doc.(type) {
+	case mapstr.MapStr:
+		return mgoFieldValues(map[string]interface{}(val), paths)
+	case map[string]interface{}:
+		sub, exists := val[paths[0]]
+		if !exists {
+			return nil, true
+		}
+		return mgoFieldValues(sub, paths[1:])
+	case []interface{}:
+		values, missing, found := make([]interface{}, 0), false, false
+		if idx, err := strconv.Atoi(paths[0]); err == nil && idx < len(val) {
+			subValues, subMissing := mgoFieldValues(val[idx], paths[1:])
+			values, missing, found = append(values, subValues...), subMissing, true
+		}
+		for _, elem := range val {
+			switch elem.(type) {
+			case map[string]interface{}, mapstr.MapStr:
+				subValues, subMissing := mgoFieldValues(elem, paths)
+				values, missing, found = append(values, subValues...), missing || subMissing, true
+			}
+		}
+		return values, missing || !found
+	default:
+		return nil, true
+	}
+}
+
+func mgoMatchField(cond map[string]interface{}, values []interface{}, missing bool) bool {
+	if regex, exists := cond[common.BKDBLIKE]; exists {
+		pattern := regex.(string)
+		if cond[common.BKDBOPTIONS] == "i" {
+			pattern = "(?i)" + pattern
+		}
+		reg := regexp.MustCompile(pattern)
+		return mgoAnyElement(values, func(v interface{}) bool {
+			str, ok := v.(string)
+			return ok && reg.MatchString(str)
+		})
+	}
+
+	for op, opVal := range cond {
+		var matched bool
+		switch op {
+		case common.BKDBEQ:
+			matched = mgoAnyElement(values, func(v interface{}) bool { return mgoCompare(v, opVal) == 0 }) ||
+				(opVal == nil && missing)
+		case common.BKDBNE:
+			matched = !mgoMatchField(map[string]interface{}{common.BKDBEQ: opVal}, values, missing)
+		case common.BKDBIN:
+			arr := reflect.ValueOf(opVal)
+			for i := 0; i < arr.Len() && !matched; i++ {
+				matched = mgoMatchField(map[string]interface{}{common.BKDBEQ: arr.Index(i).Interface()}, values,
+					missing)
+			}
+		case common.BKDBNIN:
+			matched = !mgoMatchField(map[string]interface{}{common.BKDBIN: opVal}, values, missing)
+		case common.BKDBLT, common.BKDBLTE, common.BKDBGT, common.BKDBGTE:
+			matched = mgoAnyElement(values, func(v interface{}) bool {
+				res := mgoCompare(v, opVal)
+				switch op {
+				case common.BKDBLT:
+					return res == -1
+				case common.BKDBLTE:
+					return res == -1 || res == 0
+				case common.BKDBGT:
+					return res == 1
+				default:
+					return res == 1 || res == 0
+				}
+			})
+		case common.BKDBAll:
+			arr := reflect.ValueOf(opVal)
+			matched = arr.Len() > 0
+			for i := 0; i < arr.Len() && matched; i++ {
+				matched = mgoMatchField(map[string]interface{}{common.BKDBEQ: arr.Index(i).Interface()}, values,
+					missing)
+			}
+		case common.BKDBNot:
+			matched = !mgoMatchField(opVal.(map[string]interface{}), values, missing)
+		case common.BKDBSize:
+			for _, v := range values {
+				arr, ok := v.([]interface{})
+				if !ok {
+					continue
+				}
+				sizeCond, isCond := opVal.(map[string]interface{})
+				if !isCond {
+					sizeCond = map[string]interface{}{common.BKDBEQ: opVal}
+				}
+				if mgoMatchField(sizeCond, []interface{}{len(arr)}, false) {
+					matched = true
+					break
+				}
+			}
+		case common.BKDBExists:
+			matched = (len(values) > 0) == opVal.(bool)
+		default:
+			panic(fmt.Sprintf("unsupported mongo operator %s", op))
+		}
+
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func mgoAnyElement(values []interface{}, match func(v interface{}) bool) bool {
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+		if arr, ok := v.([]interface{}); ok {
+			for _, elem := range arr {
+				if match(elem) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// mgoCompare compares two values of the same bson type, returns 0 for equal, -1 for less, 1 for greater and 2 for
+// values of different types that are not comparable.
+func mgoCompare(a, b interface{}) int {
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return 0
+		}
+		return 2
+	}
+
+	switch av := a.(type) {
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return 2
+		}
+		return strings.Compare(av, bv)
+	case bool:
+		bv, ok := b.(bool)
+		if !ok || av != bv {
+			return 2
+		}
+		return 0
+	case time.Time:
+		bv, ok := b.(time.Time)
+		if !ok {
+			return 2
+		}
+		return compareFloat(float64(av.UnixNano()), float64(bv.UnixNano()))
+	}
+
+	af, aok := mgoNumber(a)
+	bf, bok := mgoNumber(b)
+	if !aok || !bok {
+		return 2
+	}
+	return compareFloat(af, bf)
+}
+
+func mgoNumber(v interface{}) (float64, bool) {
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflect.ValueOf(v).Int()), true
+	case reflect.Float32, reflect.Float64:
+		return reflect.ValueOf(v).Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// TestMatchEqualArrayAndObject tests the equal operators with array and embedded document values, the expected
+// results are the results of the same condition in mongodb.
+func TestMatchEqualArrayAndObject(t *testing.T) {
+	data := mapstr.MapStr{
+		"tags":   []interface{}{"a", "b"},
+		"nested": []interface{}{[]interface{}{"a", "b"}, "c"},
+		"obj":    map[string]interface{}{"str": "Abc", "int": 3},
+		"list":   []interface{}{map[string]interface{}{"str": "x", "int": 1}, mapstr.MapStr{"str": "y"}},
+	}
+
+	cases := []struct {
+		rule     RuleFactory
+		expected bool
+	}{
+		// whole array equals
+		{&AtomRule{Field: "tags", Operator: Equal.Factory(), Value: []interface{}{"a", "b"}}, true},
+		{&AtomRule{Field: "tags", Operator: Equal.Factory(), Value: []string{"a", "b"}}, true},
+		// array order and length matters
+		{&AtomRule{Field: "tags", Operator: Equal.Factory(), Value: []interface{}{"b", "a"}}, false},
+		{&AtomRule{Field: "tags", Operator: Equal.Factory(), Value: []interface{}{"a"}}, false},
+		// array element equals
+		{&AtomRule{Field: "tags", Operator: Equal.Factory(), Value: "a"}, true},
+		{&AtomRule{Field: "nested", Operator: Equal.Factory(), Value: []interface{}{"a", "b"}}, true},
+		{&AtomRule{Field: "nested", Operator: Equal.Factory(), Value: []interface{}{"a"}}, false},
+		{&AtomRule{Field: "nested", Operator: NotEqual.Factory(), Value: []interface{}{"a", "b"}}, false},
+		{&AtomRule{Field: "tags", Operator: NotEqual.Factory(), Value: []interface{}{"a", "b"}}, false},
+		{&AtomRule{Field: "tags", Operator: NotEqual.Factory(), Value: []interface{}{"a", "c"}}, true},
+		// embedded document equals, numeric values of different types are equal
+		{&AtomRule{Field: "obj", Operator: Equal.Factory(), Value: map[string]interface{}{"str": "Abc", "int": 3.0}},
+			true},
+		{&AtomRule{Field: "obj", Operator: Equal.Factory(), Value: mapstr.MapStr{"str": "Abc"}}, false},
+		{&AtomRule{Field: "obj", Operator: Equal.Factory(), Value: mapstr.MapStr{"str": "Abc", "int": "3"}}, false},
+		// embedded document element of array equals
+		{&AtomRule{Field: "list", Operator: Equal.Factory(), Value: mapstr.MapStr{"str": "y"}}, true},
+		{&AtomRule{Field: "list", Operator: Equal.Factory(), Value: mapstr.MapStr{"str": "x"}}, false},
+		{&AtomRule{Field: "list", Operator: In.Factory(), Value: []interface{}{mapstr.MapStr{"str": "y"}, 1}}, true},
+		{&AtomRule{Field: "tags", Operator: In.Factory(), Value: []interface{}{[]interface{}{"a", "b"}}}, true},
+		{&AtomRule{Field: "tags", Operator: NotIn.Factory(), Value: []interface{}{[]interface{}{"a", "b"}}}, false},
+	}
+
+	for _, c := range cases {
+		matched, err := c.rule.Match(data)
+		if err != nil {
+			t.Fatalf("match rule %s failed, err: %v", marshalRule(c.rule), err)
+		}
+		if matched != c.expected {
+			t.Errorf("rule %s match result %v, expected %v", marshalRule(c.rule), matched, c.expected)
+		}
+	}
+}
+
+// TestMatchMongoSemantics tests the in memory match of the operators with fixed cases whose expected results are
+// the results of the same mongodb query conditions, instead of the results of the simplified query engine.
+func TestMatchMongoSemantics(t *testing.T) {
+	data := mapstr.MapStr{
+		"name":  "host-a",
+		"num":   1,
+		"nil":   nil,
+		"tags":  []interface{}{"a", "b"},
+		"empty": []interface{}{},
+		"nums":  []interface{}{1, 5, 10},
+		"list":  []interface{}{map[string]interface{}{"str": "x", "int": 1}, map[string]interface{}{"str": "y"}},
+	}
+
+	cases := []struct {
+		rule     RuleFactory
+		expected bool
+	}{
+		// {tags: {$all: []}} matches no documents
+		{&AtomRule{Field: "tags", Operator: All.Factory(), Value: []interface{}{}}, false},
+		// {nums: {$all: [1, 10]}}
+		{&AtomRule{Field: "nums", Operator: All.Factory(), Value: []interface{}{1, 10}}, true},
+		{&AtomRule{Field: "nums", Operator: All.Factory(), Value: []interface{}{1, 2}}, false},
+		// {"list.str": {$all: ["x", "y"]}} matches the values of all the array elements' sub field
+		{&AtomRule{Field: "list.str", Operator: All.Factory(), Value: []interface{}{"x", "y"}}, true},
+		// {name: {$all: ["host-a"]}} matches a non-array field that equals the only element
+		{&AtomRule{Field: "name", Operator: All.Factory(), Value: []interface{}{"host-a"}}, true},
+		// {tags: {$regex: "^b"}} matches any element of the array
+		{&AtomRule{Field: "tags", Operator: Regex.Factory(), Value: "^b"}, true},
+		// {num: {$regex: "1"}} never matches non-string values
+		{&AtomRule{Field: "num", Operator: Regex.Factory(), Value: "1"}, false},
+		// {"list.0.str": "x"} uses the array index in the field path
+		{&AtomRule{Field: "list.0.str", Operator: Equal.Factory(), Value: "x"}, true},
+		{&AtomRule{Field: "list.1.str", Operator: Equal.Factory(), Value: "x"}, false},
+		// {"list.int": {$eq: null}} matches because an element does not have the field
+		{&AtomRule{Field: "list.int", Operator: IsNull.Factory(), Value: ""}, true},
+		// {missing: {$eq: null}} and {missing: {$ne: 1}} match missing fields
+		{&AtomRule{Field: "missing", Operator: IsNull.Factory(), Value: ""}, true},
+		{&AtomRule{Field: "missing", Operator: NotEqual.Factory(), Value: 1}, true},
+		// {nil: {$exists: true}} matches a field with null value
+		{&AtomRule{Field: "nil", Operator: Exist.Factory(), Value: ""}, true},
+		{&AtomRule{Field: "missing", Operator: Exist.Factory(), Value: ""}, false},
+		// {empty: {$size: 0}}, {name: {$size: 0}}
+		{&AtomRule{Field: "empty", Operator: IsEmpty.Factory(), Value: ""}, true},
+		{&AtomRule{Field: "name", Operator: IsEmpty.Factory(), Value: ""}, false},
+		{&AtomRule{Field: "nums", Operator: Size.Factory(), Value: 3}, true},
+		// {nums: {$gt: 9}} matches any element of the array
+		{&AtomRule{Field: "nums", Operator: Greater.Factory(), Value: 9}, true},
+		// {nums: {$nin: [1]}} does not match because an element is in the array
+		{&AtomRule{Field: "nums", Operator: NotIn.Factory(), Value: []interface{}{1}}, false},
+	}
+
+	for _, c := range cases {
+		matched, err := c.rule.Match(data)
+		if err != nil {
+			t.Fatalf("match rule %s failed, err: %v", marshalRule(c.rule), err)
+		}
+		if matched != c.expected {
+			t.Errorf("rule %s match result %v, expected %v", marshalRule(c.rule), matched, c.expected)
+		}
+	}
+}
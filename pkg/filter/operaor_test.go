@@ -2123,6 +2123,87 @@ func TestNotEndsWithInsensitiveMongoCond(t *testing.T) {
 	}
 }
 
+func TestRegexValidate(t *testing.T) {
+	op := Regex.Factory().Operator()
+	opt := NewDefaultExprOpt(nil)
+
+	// test regex string type
+	err := op.ValidateValue("^a.*b$", opt)
+	if err != nil {
+		t.Errorf("validate failed, err: %v", err)
+		return
+	}
+
+	// test regex with case-insensitive flag
+	err = op.ValidateValue("(?i)^a[0-9]+", opt)
+	if err != nil {
+		t.Errorf("validate failed, err: %v", err)
+		return
+	}
+
+	// test invalid regex type
+	err = op.ValidateValue("", opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	err = op.ValidateValue(1, opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	err = op.ValidateValue("a", nil)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	// test invalid regular expression
+	err = op.ValidateValue("(a", opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	// test regular expression that is not safe
+	err = op.ValidateValue("(a+)+b", opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	err = op.ValidateValue("(a|b*){2,}", opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	// test regular expression that exceeds the length limit
+	opt.MaxRegexLength = 3
+	err = op.ValidateValue("abcd", opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+}
+
+func TestRegexMongoCond(t *testing.T) {
+	op := Regex.Factory().Operator()
+
+	cond, err := op.ToMgo("test", "^a.*b$")
+	if err != nil {
+		t.Errorf("to mongo failed, err: %v", err)
+		return
+	}
+
+	if !reflect.DeepEqual(cond, map[string]interface{}{"test": map[string]interface{}{common.BKDBLIKE: "^a.*b$"}}) {
+		t.Errorf("cond %+v is invalid", cond)
+		return
+	}
+}
+
 func TestIsEmptyValidate(t *testing.T) {
 	op := IsEmpty.Factory().Operator()
 
@@ -2267,6 +2348,75 @@ func TestSizeMongoCond(t *testing.T) {
 	}
 }
 
+func TestAllValidate(t *testing.T) {
+	op := All.Factory().Operator()
+
+	opt := &ExprOption{
+		MaxInLimit: 2,
+	}
+
+	// test all int type
+	err := op.ValidateValue([]int64{1, 2}, opt)
+	if err != nil {
+		t.Errorf("validate failed, err: %v", err)
+		return
+	}
+
+	// test all string type
+	err = op.ValidateValue([]interface{}{"a"}, opt)
+	if err != nil {
+		t.Errorf("validate failed, err: %v", err)
+		return
+	}
+
+	// test invalid all type
+	err = op.ValidateValue([]interface{}{"a", 1}, opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	err = op.ValidateValue([]int64{1, 2, 3}, opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	err = op.ValidateValue([]int64{}, opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	err = op.ValidateValue("a", opt)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+
+	err = op.ValidateValue([]int64{1}, nil)
+	if err == nil {
+		t.Errorf("validate should return error")
+		return
+	}
+}
+
+func TestAllMongoCond(t *testing.T) {
+	op := All.Factory().Operator()
+
+	cond, err := op.ToMgo("test", []string{"a", "b"})
+	if err != nil {
+		t.Errorf("to mongo failed, err: %v", err)
+		return
+	}
+
+	if !reflect.DeepEqual(cond, map[string]interface{}{"test": map[string]interface{}{
+		common.BKDBAll: []string{"a", "b"}}}) {
+		t.Errorf("cond %+v is invalid", cond)
+		return
+	}
+}
+
 func TestIsNullValidate(t *testing.T) {
 	op := IsNull.Factory().Operator()
 
@@ -20,6 +20,9 @@ package filter
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"regexp/syntax"
+	"time"
 
 	"configcenter/src/common"
 	"configcenter/src/common/criteria/enumor"
@@ -80,12 +83,16 @@ func init() {
 	opFactory[OpFactory(notEndsWith.Name())] = &notEndsWith
 	notEndsWithInsensitive := NotEndsWithInsensitiveOp(NotEndsWithInsensitive)
 	opFactory[OpFactory(notEndsWithInsensitive.Name())] = &notEndsWithInsensitive
+	regex := RegexOp(Regex)
+	opFactory[OpFactory(regex.Name())] = &regex
 	isEmpty := IsEmptyOp(IsEmpty)
 	opFactory[OpFactory(isEmpty.Name())] = &isEmpty
 	isNotEmpty := IsNotEmptyOp(IsNotEmpty)
 	opFactory[OpFactory(isNotEmpty.Name())] = &isNotEmpty
 	size := SizeOp(Size)
 	opFactory[OpFactory(size.Name())] = &size
+	all := AllOp(All)
+	opFactory[OpFactory(all.Name())] = &all
 	isNull := IsNullOp(IsNull)
 	opFactory[OpFactory(isNull.Name())] = &isNull
 	isNotNull := IsNotNullOp(IsNotNull)
@@ -208,6 +215,8 @@ const (
 	NotEndsWith OpType = "not_ends_with"
 	// NotEndsWithInsensitive operator with case-insensitive
 	NotEndsWithInsensitive OpType = "not_ends_with_i"
+	// Regex operator that matches the regular expression, use "(?i)" flag for case-insensitive
+	Regex OpType = "regex"
 
 	// array operator
 
@@ -217,6 +226,8 @@ const (
 	IsNotEmpty OpType = "is_not_empty"
 	// Size operator
 	Size OpType = "size"
+	// All operator that matches the array containing all the elements of the value
+	All OpType = "all"
 
 	// null check operator
 
@@ -249,7 +260,7 @@ func (op OpType) Validate() error {
 	case Equal, NotEqual, In, NotIn, Less, LessOrEqual, Greater, GreaterOrEqual, DatetimeLess, DatetimeLessOrEqual,
 		DatetimeGreater, DatetimeGreaterOrEqual, BeginsWith, BeginsWithInsensitive, NotBeginsWith,
 		NotBeginsWithInsensitive, Contains, ContainsSensitive, NotContains, NotContainsInsensitive, EndsWith,
-		EndsWithInsensitive, NotEndsWith, NotEndsWithInsensitive, IsEmpty, IsNotEmpty, Size, IsNull,
+		EndsWithInsensitive, NotEndsWith, NotEndsWithInsensitive, Regex, IsEmpty, IsNotEmpty, Size, All, IsNull,
 		IsNotNull, Exist, NotExist, Object, Array:
 	default:
 		return fmt.Errorf("unsupported operator: %s", op)
@@ -271,6 +282,9 @@ type Operator interface {
 	ValidateValue(v interface{}, opt *ExprOption) error
 	// ToMgo generate an operator's mongo condition with its field and value.
 	ToMgo(field string, value interface{}) (map[string]interface{}, error)
+	// Match checks if the data matches the operator's field and value in memory, the result is the same as the
+	// result of querying the data using the mongo condition generated by ToMgo.
+	Match(field string, value interface{}, data mapstr.MapStr) (bool, error)
 }
 
 // UnknownOp is unknown operator
@@ -291,6 +305,11 @@ func (o UnknownOp) ToMgo(_ string, _ interface{}) (map[string]interface{}, error
 	return nil, errors.New("unknown operator, can not gen mongo expression")
 }
 
+// Match checks if the data matches this operator's field and value.
+func (o UnknownOp) Match(_ string, _ interface{}, _ mapstr.MapStr) (bool, error) {
+	return false, errors.New("unknown operator, can not match data")
+}
+
 // EqualOp is equal operator type
 type EqualOp OpType
 
@@ -318,6 +337,17 @@ func (o EqualOp) ToMgo(field string, value interface{}) (map[string]interface{},
 	}, nil
 }
 
+// Match checks if the data matches the equal operator's field and value in memory.
+func (o EqualOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	return getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		return matchEqual(v, value), nil
+	})
+}
+
 // NotEqualOp is not equal operator type
 type NotEqualOp OpType
 
@@ -345,6 +375,21 @@ func (ne NotEqualOp) ToMgo(field string, value interface{}) (map[string]interfac
 	}, nil
 }
 
+// Match checks if the data matches the not equal operator's field and value in memory.
+func (ne NotEqualOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	matched, err := getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		return matchEqual(v, value), nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return !matched, nil
+}
+
 // InOp is in operator
 type InOp OpType
 
@@ -378,6 +423,17 @@ func (o InOp) ToMgo(field string, value interface{}) (map[string]interface{}, er
 	}, nil
 }
 
+// Match checks if the data matches the in operator's field and value in memory.
+func (o InOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	return getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		return matchIn(v, value)
+	})
+}
+
 // NotInOp is not in operator
 type NotInOp OpType
 
@@ -411,6 +467,21 @@ func (o NotInOp) ToMgo(field string, value interface{}) (map[string]interface{},
 	}, nil
 }
 
+// Match checks if the data matches the not in operator's field and value in memory.
+func (o NotInOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	matched, err := getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		return matchIn(v, value)
+	})
+	if err != nil {
+		return false, err
+	}
+	return !matched, nil
+}
+
 // LessOp is less than operator
 type LessOp OpType
 
@@ -438,6 +509,19 @@ func (o LessOp) ToMgo(field string, value interface{}) (map[string]interface{},
 	}, nil
 }
 
+// Match checks if the data matches the less than operator's field and value in memory.
+func (o LessOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	return getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		return matchNumeric(v, value, func(data, value float64) bool {
+			return data < value
+		})
+	})
+}
+
 // LessOrEqualOp is less than or equal operator
 type LessOrEqualOp OpType
 
@@ -465,6 +549,19 @@ func (o LessOrEqualOp) ToMgo(field string, value interface{}) (map[string]interf
 	}, nil
 }
 
+// Match checks if the data matches the less than or equal operator's field and value in memory.
+func (o LessOrEqualOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	return getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		return matchNumeric(v, value, func(data, value float64) bool {
+			return data <= value
+		})
+	})
+}
+
 // GreaterOp is greater than operator
 type GreaterOp OpType
 
@@ -492,6 +589,19 @@ func (o GreaterOp) ToMgo(field string, value interface{}) (map[string]interface{
 	}, nil
 }
 
+// Match checks if the data matches the greater than operator's field and value in memory.
+func (o GreaterOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	return getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		return matchNumeric(v, value, func(data, value float64) bool {
+			return data > value
+		})
+	})
+}
+
 // GreaterOrEqualOp is greater than or equal operator
 type GreaterOrEqualOp OpType
 
@@ -519,6 +629,19 @@ func (o GreaterOrEqualOp) ToMgo(field string, value interface{}) (map[string]int
 	}, nil
 }
 
+// Match checks if the data matches the greater than or equal operator's field and value in memory.
+func (o GreaterOrEqualOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	return getFieldValue(data, field).matchAny(func(v interface{}) (bool, error) {
+		return matchNumeric(v, value, func(data, value float64) bool {
+			return data >= value
+		})
+	})
+}
+
 // DatetimeLessOp is datetime less than operator
 type DatetimeLessOp OpType
 
@@ -552,6 +675,13 @@ func (o DatetimeLessOp) ToMgo(field string, value interface{}) (map[string]inter
 	}, nil
 }
 
+// Match checks if the data matches the datetime less than operator's field and value in memory.
+func (o DatetimeLessOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	return matchDatetime(field, value, data, func(data, value time.Time) bool {
+		return data.Before(value)
+	})
+}
+
 // DatetimeLessOrEqualOp is datetime less than or equal operator
 type DatetimeLessOrEqualOp OpType
 
@@ -585,6 +715,13 @@ func (o DatetimeLessOrEqualOp) ToMgo(field string, value interface{}) (map[strin
 	}, nil
 }
 
+// Match checks if the data matches the datetime less than or equal operator's field and value in memory.
+func (o DatetimeLessOrEqualOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	return matchDatetime(field, value, data, func(data, value time.Time) bool {
+		return !data.After(value)
+	})
+}
+
 // DatetimeGreaterOp is datetime greater than operator
 type DatetimeGreaterOp OpType
 
@@ -619,6 +756,13 @@ func (o DatetimeGreaterOp) ToMgo(field string, value interface{}) (map[string]in
 	}, nil
 }
 
+// Match checks if the data matches the datetime greater than operator's field and value in memory.
+func (o DatetimeGreaterOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	return matchDatetime(field, value, data, func(data, value time.Time) bool {
+		return data.After(value)
+	})
+}
+
 // DatetimeGreaterOrEqualOp is datetime greater than or equal operator
 type DatetimeGreaterOrEqualOp OpType
 
@@ -652,6 +796,13 @@ func (o DatetimeGreaterOrEqualOp) ToMgo(field string, value interface{}) (map[st
 	}, nil
 }
 
+// Match checks if the data matches the datetime greater than or equal operator's field and value in memory.
+func (o DatetimeGreaterOrEqualOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	return matchDatetime(field, value, data, func(data, value time.Time) bool {
+		return !data.Before(value)
+	})
+}
+
 // BeginsWithOp is begins with operator
 type BeginsWithOp OpType
 
@@ -683,6 +834,16 @@ func (o BeginsWithOp) ToMgo(field string, value interface{}) (map[string]interfa
 	}, nil
 }
 
+// Match checks if the data matches the begins with operator's field and value in memory.
+func (o BeginsWithOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	return matchRegex(field, fmt.Sprintf("^%s", pattern), false, data)
+}
+
 // BeginsWithInsensitiveOp is begins with insensitive operator
 type BeginsWithInsensitiveOp OpType
 
@@ -715,6 +876,16 @@ func (o BeginsWithInsensitiveOp) ToMgo(field string, value interface{}) (map[str
 	}, nil
 }
 
+// Match checks if the data matches the begins with insensitive operator's field and value in memory.
+func (o BeginsWithInsensitiveOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	return matchRegex(field, fmt.Sprintf("^%s", pattern), true, data)
+}
+
 // NotBeginsWithOp is not begins with operator
 type NotBeginsWithOp OpType
 
@@ -746,6 +917,20 @@ func (o NotBeginsWithOp) ToMgo(field string, value interface{}) (map[string]inte
 	}, nil
 }
 
+// Match checks if the data matches the not begins with operator's field and value in memory.
+func (o NotBeginsWithOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	matched, err := matchRegex(field, fmt.Sprintf("^%s", pattern), false, data)
+	if err != nil {
+		return false, err
+	}
+	return !matched, nil
+}
+
 // NotBeginsWithInsensitiveOp is not begins with insensitive operator
 type NotBeginsWithInsensitiveOp OpType
 
@@ -780,6 +965,20 @@ func (o NotBeginsWithInsensitiveOp) ToMgo(field string, value interface{}) (map[
 	}, nil
 }
 
+// Match checks if the data matches the not begins with insensitive operator's field and value in memory.
+func (o NotBeginsWithInsensitiveOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	matched, err := matchRegex(field, fmt.Sprintf("^%s", pattern), true, data)
+	if err != nil {
+		return false, err
+	}
+	return !matched, nil
+}
+
 // ContainsOp is contains operator
 type ContainsOp OpType
 
@@ -812,6 +1011,16 @@ func (o ContainsOp) ToMgo(field string, value interface{}) (map[string]interface
 	}, nil
 }
 
+// Match checks if the data matches the contains operator's field and value in memory.
+func (o ContainsOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	return matchRegex(field, pattern, true, data)
+}
+
 // ContainsSensitiveOp is contains sensitive operator
 type ContainsSensitiveOp OpType
 
@@ -843,6 +1052,16 @@ func (o ContainsSensitiveOp) ToMgo(field string, value interface{}) (map[string]
 	}, nil
 }
 
+// Match checks if the data matches the contains sensitive operator's field and value in memory.
+func (o ContainsSensitiveOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	return matchRegex(field, pattern, false, data)
+}
+
 // NotContainsOp is not contains operator
 type NotContainsOp OpType
 
@@ -874,6 +1093,20 @@ func (o NotContainsOp) ToMgo(field string, value interface{}) (map[string]interf
 	}, nil
 }
 
+// Match checks if the data matches the not contains operator's field and value in memory.
+func (o NotContainsOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	matched, err := matchRegex(field, pattern, false, data)
+	if err != nil {
+		return false, err
+	}
+	return !matched, nil
+}
+
 // NotContainsInsensitiveOp is not contains insensitive operator
 type NotContainsInsensitiveOp OpType
 
@@ -908,6 +1141,20 @@ func (o NotContainsInsensitiveOp) ToMgo(field string, value interface{}) (map[st
 	}, nil
 }
 
+// Match checks if the data matches the not contains insensitive operator's field and value in memory.
+func (o NotContainsInsensitiveOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	matched, err := matchRegex(field, pattern, true, data)
+	if err != nil {
+		return false, err
+	}
+	return !matched, nil
+}
+
 // EndsWithOp is ends with operator
 type EndsWithOp OpType
 
@@ -939,6 +1186,16 @@ func (o EndsWithOp) ToMgo(field string, value interface{}) (map[string]interface
 	}, nil
 }
 
+// Match checks if the data matches the ends with operator's field and value in memory.
+func (o EndsWithOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	return matchRegex(field, fmt.Sprintf("%s$", pattern), false, data)
+}
+
 // EndsWithInsensitiveOp is ends with insensitive operator
 type EndsWithInsensitiveOp OpType
 
@@ -971,6 +1228,16 @@ func (o EndsWithInsensitiveOp) ToMgo(field string, value interface{}) (map[strin
 	}, nil
 }
 
+// Match checks if the data matches the ends with insensitive operator's field and value in memory.
+func (o EndsWithInsensitiveOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	return matchRegex(field, fmt.Sprintf("%s$", pattern), true, data)
+}
+
 // NotEndsWithOp is not ends with operator
 type NotEndsWithOp OpType
 
@@ -1002,6 +1269,20 @@ func (o NotEndsWithOp) ToMgo(field string, value interface{}) (map[string]interf
 	}, nil
 }
 
+// Match checks if the data matches the not ends with operator's field and value in memory.
+func (o NotEndsWithOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	matched, err := matchRegex(field, fmt.Sprintf("%s$", pattern), false, data)
+	if err != nil {
+		return false, err
+	}
+	return !matched, nil
+}
+
 // NotEndsWithInsensitiveOp is not ends with insensitive operator
 type NotEndsWithInsensitiveOp OpType
 
@@ -1036,6 +1317,108 @@ func (o NotEndsWithInsensitiveOp) ToMgo(field string, value interface{}) (map[st
 	}, nil
 }
 
+// Match checks if the data matches the not ends with insensitive operator's field and value in memory.
+func (o NotEndsWithInsensitiveOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	matched, err := matchRegex(field, fmt.Sprintf("%s$", pattern), true, data)
+	if err != nil {
+		return false, err
+	}
+	return !matched, nil
+}
+
+// RegexOp is regular expression operator
+type RegexOp OpType
+
+// Name is regular expression operator name
+func (o RegexOp) Name() OpType {
+	return Regex
+}
+
+// ValidateValue validate regular expression operator's value
+func (o RegexOp) ValidateValue(v interface{}, opt *ExprOption) error {
+	if opt == nil {
+		return errors.New("validate option must be set")
+	}
+
+	err := util.ValidateNotEmptyStringType(v)
+	if err != nil {
+		return fmt.Errorf("regex operator's value is invalid, err: %v", err)
+	}
+
+	if err := validateRegex(v.(string), opt.MaxRegexLength); err != nil {
+		return fmt.Errorf("regex operator's value is invalid, err: %v", err)
+	}
+
+	return nil
+}
+
+// ToMgo convert the regular expression operator's field and value to a mongo query condition.
+func (o RegexOp) ToMgo(field string, value interface{}) (map[string]interface{}, error) {
+	if len(field) == 0 {
+		return nil, errors.New("field is empty")
+	}
+
+	return mapstr.MapStr{
+		field: map[string]interface{}{
+			common.BKDBLIKE: value,
+		},
+	}, nil
+}
+
+// Match checks if the data matches the regular expression operator's field and value in memory.
+func (o RegexOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	pattern, err := getRegexValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	return matchRegex(field, pattern, false, data)
+}
+
+// validateRegex validate the regular expression is valid and safe to be executed by mongodb. the expression must
+// be compatible with RE2 syntax, so back reference and lookaround that may cause exponential backtracking are not
+// supported, nested repetitions like (a+)+ that causes catastrophic backtracking in mongodb are not allowed either.
+func validateRegex(pattern string, maxLength uint) error {
+	if len(pattern) > int(maxLength) {
+		return fmt.Errorf("regular expression length %d exceeds maximum %d", len(pattern), maxLength)
+	}
+
+	reg, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return fmt.Errorf("regular expression %s is invalid, err: %v", pattern, err)
+	}
+
+	if hasNestedRepeat(reg, false) {
+		return fmt.Errorf("regular expression %s has nested repetition, which is not allowed", pattern)
+	}
+
+	return nil
+}
+
+// hasNestedRepeat checks if the regular expression has repetition operator inside another repetition operator
+func hasNestedRepeat(reg *syntax.Regexp, inRepeat bool) bool {
+	isRepeat := false
+	switch reg.Op {
+	case syntax.OpStar, syntax.OpPlus, syntax.OpRepeat:
+		if inRepeat {
+			return true
+		}
+		isRepeat = true
+	}
+
+	for _, sub := range reg.Sub {
+		if hasNestedRepeat(sub, inRepeat || isRepeat) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsEmptyOp is empty operator
 type IsEmptyOp OpType
 
@@ -1062,6 +1445,17 @@ func (o IsEmptyOp) ToMgo(field string, value interface{}) (map[string]interface{
 	}, nil
 }
 
+// Match checks if the data matches the empty operator's field and value in memory.
+func (o IsEmptyOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	return getFieldValue(data, field).matchArray(func(length int) bool {
+		return length == 0
+	}), nil
+}
+
 // IsNotEmptyOp is not empty operator
 type IsNotEmptyOp OpType
 
@@ -1088,6 +1482,17 @@ func (o IsNotEmptyOp) ToMgo(field string, value interface{}) (map[string]interfa
 	}, nil
 }
 
+// Match checks if the data matches the is not empty operator's field and value in memory.
+func (o IsNotEmptyOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	return getFieldValue(data, field).matchArray(func(length int) bool {
+		return length > 0
+	}), nil
+}
+
 // SizeOp size operator
 type SizeOp OpType
 
@@ -1122,6 +1527,85 @@ func (o SizeOp) ToMgo(field string, value interface{}) (map[string]interface{},
 	}, nil
 }
 
+// Match checks if the data matches the size operator's field and value in memory.
+func (o SizeOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	size, err := util.GetInt64ByInterface(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid size operator's value, should be a numeric value, err: %v", err)
+	}
+
+	return getFieldValue(data, field).matchArray(func(length int) bool {
+		return int64(length) == size
+	}), nil
+}
+
+// AllOp is all operator
+type AllOp OpType
+
+// Name is all operator name
+func (o AllOp) Name() OpType {
+	return All
+}
+
+// ValidateValue validate all operator's value
+func (o AllOp) ValidateValue(v interface{}, opt *ExprOption) error {
+	if opt == nil {
+		return errors.New("validate option must be set")
+	}
+
+	err := util.ValidateSliceOfBasicType(v, opt.MaxInLimit)
+	if err != nil {
+		return fmt.Errorf("all operator's value is invalid, err: %v", err)
+	}
+
+	return nil
+}
+
+// ToMgo convert the all operator's field and value to a mongo query condition.
+func (o AllOp) ToMgo(field string, value interface{}) (map[string]interface{}, error) {
+	if len(field) == 0 {
+		return nil, errors.New("field is empty")
+	}
+
+	return mapstr.MapStr{
+		field: map[string]interface{}{common.BKDBAll: value},
+	}, nil
+}
+
+// Match checks if the data matches the all operator's field and value in memory.
+func (o AllOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	if !isArrayValue(value) {
+		return false, fmt.Errorf("value(%+v) is not of array type", value)
+	}
+
+	// all operator is equivalent to an AND of the equal operator of each element
+	fieldValue := getFieldValue(data, field)
+	arr := reflect.ValueOf(value)
+	for i := 0; i < arr.Len(); i++ {
+		elem := arr.Index(i).Interface()
+		matched, err := fieldValue.matchAny(func(v interface{}) (bool, error) {
+			return matchEqual(v, elem), nil
+		})
+		if err != nil {
+			return false, err
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	// empty all operator's value matches nothing in mongodb
+	return arr.Len() > 0, nil
+}
+
 // IsNullOp is null operator
 type IsNullOp OpType
 
@@ -1148,6 +1632,26 @@ func (o IsNullOp) ToMgo(field string, value interface{}) (map[string]interface{}
 	}, nil
 }
 
+// Match checks if the data matches the null operator's field and value in memory.
+func (o IsNullOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is null")
+	}
+
+	fieldValue := getFieldValue(data, field)
+	if fieldValue.missing {
+		return true, nil
+	}
+
+	matched, err := fieldValue.matchAny(func(v interface{}) (bool, error) {
+		return v == nil, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return matched, nil
+}
+
 // IsNotNullOp is not null operator
 type IsNotNullOp OpType
 
@@ -1174,6 +1678,26 @@ func (o IsNotNullOp) ToMgo(field string, value interface{}) (map[string]interfac
 	}, nil
 }
 
+// Match checks if the data matches the is not null operator's field and value in memory.
+func (o IsNotNullOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is null")
+	}
+
+	fieldValue := getFieldValue(data, field)
+	if fieldValue.missing {
+		return false, nil
+	}
+
+	matched, err := fieldValue.matchAny(func(v interface{}) (bool, error) {
+		return v == nil, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return !matched, nil
+}
+
 // ExistOp is 'exist' operator
 type ExistOp OpType
 
@@ -1200,6 +1724,15 @@ func (o ExistOp) ToMgo(field string, value interface{}) (map[string]interface{},
 	}, nil
 }
 
+// Match checks if the data matches the 'exist' operator's field and value in memory.
+func (o ExistOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is null")
+	}
+
+	return getFieldValue(data, field).exists(), nil
+}
+
 // NotExistOp is not exist operator
 type NotExistOp OpType
 
@@ -1226,6 +1759,15 @@ func (o NotExistOp) ToMgo(field string, value interface{}) (map[string]interface
 	}, nil
 }
 
+// Match checks if the data matches the is not exist operator's field and value in memory.
+func (o NotExistOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is null")
+	}
+
+	return !getFieldValue(data, field).exists(), nil
+}
+
 // ObjectOp is filter object operator
 type ObjectOp OpType
 
@@ -1279,6 +1821,25 @@ func (o ObjectOp) ToMgo(field string, value interface{}) (map[string]interface{}
 	return subRule.ToMgo(parentOpt)
 }
 
+// Match checks if the data matches the filter object operator's field and value in memory.
+func (o ObjectOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	subRule, ok := value.(RuleFactory)
+	if !ok {
+		return false, fmt.Errorf("filter object operator's value(%+v) is not a rule type", value)
+	}
+
+	parentOpt := &RuleOption{
+		Parent:     field,
+		ParentType: enumor.Object,
+	}
+
+	return subRule.Match(data, parentOpt)
+}
+
 const (
 	ArrayElement = "element"
 )
@@ -1337,3 +1898,22 @@ func (o ArrayOp) ToMgo(field string, value interface{}) (map[string]interface{},
 
 	return subRule.ToMgo(parentOpt)
 }
+
+// Match checks if the data matches the filter array operator's field and value in memory.
+func (o ArrayOp) Match(field string, value interface{}, data mapstr.MapStr) (bool, error) {
+	if len(field) == 0 {
+		return false, errors.New("field is empty")
+	}
+
+	subRule, ok := value.(RuleFactory)
+	if !ok {
+		return false, fmt.Errorf("filter array operator's value(%+v) is not a rule type", value)
+	}
+
+	parentOpt := &RuleOption{
+		Parent:     field,
+		ParentType: enumor.Array,
+	}
+
+	return subRule.Match(data, parentOpt)
+}
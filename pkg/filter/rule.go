@@ -27,6 +27,7 @@ import (
 	"configcenter/src/common"
 	"configcenter/src/common/blog"
 	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/mapstr"
 	"configcenter/src/common/util"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -42,6 +43,8 @@ type RuleFactory interface {
 	RuleFields() []string
 	// ToMgo convert this rule to a mongo condition
 	ToMgo(opt ...*RuleOption) (map[string]interface{}, error)
+	// Match checks if the data matches this rule in memory, the result is the same as using the mongo condition
+	Match(data mapstr.MapStr, opt ...*RuleOption) (bool, error)
 }
 
 // RuleType is the expression rule's rule type.
@@ -109,9 +112,9 @@ func (ar *AtomRule) Validate(opt *ExprOption) error {
 		return errors.New("validate rule fields option must be set")
 	}
 
-	typ, exist := opt.RuleFields[ar.Field]
-	if !exist {
-		return fmt.Errorf("rule field: %s is not exist in the expr option", ar.Field)
+	typ, err := getRuleFieldType(ar.Field, opt.RuleFields)
+	if err != nil {
+		return err
 	}
 
 	childOpt := cloneExprOption(opt)
@@ -126,6 +129,21 @@ func (ar *AtomRule) Validate(opt *ExprOption) error {
 		if typ != enumor.Array {
 			return fmt.Errorf("%s is of %s type, should not use operator: %s", ar.Field, typ, ar.Operator)
 		}
+	case OpFactory(All):
+		if typ != enumor.Array {
+			return fmt.Errorf("%s is of %s type, should not use operator: %s", ar.Field, typ, ar.Operator)
+		}
+
+		// validate all operator's value using the array element type if it is set
+		if elemType, exist := opt.RuleFields[ar.Field+"."+ArrayElement]; exist {
+			if err := validateFieldValue(ar.Value, elemType); err != nil {
+				return fmt.Errorf("invalid %s's value, %v", ar.Field, err)
+			}
+		}
+	case OpFactory(Regex):
+		if typ != enumor.String && typ != enumor.Enum {
+			return fmt.Errorf("%s is of %s type, should not use operator: %s", ar.Field, typ, ar.Operator)
+		}
 	default:
 		if err := validateFieldValue(ar.Value, typ); err != nil {
 			return fmt.Errorf("invalid %s's value, %v", ar.Field, err)
@@ -157,6 +175,31 @@ func (ar *AtomRule) Validate(opt *ExprOption) error {
 	return nil
 }
 
+// getRuleFieldType get the rule field's type from the rule fields. besides the rule fields, the field can also be a
+// nested field of a map string field, like labels.key, whose type is string.
+func getRuleFieldType(field string, ruleFields map[string]enumor.FieldType) (enumor.FieldType, error) {
+	if typ, exist := ruleFields[field]; exist {
+		return typ, nil
+	}
+
+	// find the nearest parent field, map string's key may contain dot, so the parent field is searched backwards
+	for idx := strings.LastIndex(field, "."); idx > 0; idx = strings.LastIndex(field[:idx], ".") {
+		parentType, exist := ruleFields[field[:idx]]
+		if !exist {
+			continue
+		}
+
+		// the sub-fields of object and array type field must be in the rule fields, only map string field's keys
+		// are not predefined.
+		if parentType != enumor.MapString {
+			return "", fmt.Errorf("rule field: %s is not exist in the expr option", field)
+		}
+		return enumor.String, nil
+	}
+
+	return "", fmt.Errorf("rule field: %s is not exist in the expr option", field)
+}
+
 func validateFieldValue(v interface{}, typ enumor.FieldType) error {
 	switch reflect.TypeOf(v).Kind() {
 	case reflect.Array, reflect.Slice:
@@ -261,6 +304,34 @@ func (ar *AtomRule) ToMgo(opts ...*RuleOption) (map[string]interface{}, error) {
 	return ar.Operator.Operator().ToMgo(ar.Field, ar.Value)
 }
 
+// Match checks if the data matches this atom rule, the rule's field is parsed the same way as ToMgo.
+func (ar *AtomRule) Match(data mapstr.MapStr, opts ...*RuleOption) (bool, error) {
+	if len(opts) > 0 && opts[0] != nil {
+		opt := opts[0]
+		if len(opt.Parent) == 0 {
+			return false, errors.New("parent is empty")
+		}
+
+		switch opt.ParentType {
+		case enumor.Object:
+			// add object parent field as prefix to match object field
+			return ar.Operator.Operator().Match(opt.Parent+"."+ar.Field, ar.Value, data)
+		case enumor.Array:
+			switch ar.Field {
+			case ArrayElement:
+				// filter array element, matches if any of the elements matches the filter
+				return ar.Operator.Operator().Match(opt.Parent, ar.Value, data)
+			default:
+				return false, fmt.Errorf("filter array field %s is invalid", ar.Field)
+			}
+		default:
+			return false, fmt.Errorf("parent type %s is invalid", opt.ParentType)
+		}
+	}
+
+	return ar.Operator.Operator().Match(ar.Field, ar.Value, data)
+}
+
 type jsonAtomRuleBroker struct {
 	Field    string          `json:"field"`
 	Operator OpFactory       `json:"operator"`
@@ -278,8 +349,8 @@ func (ar *AtomRule) UnmarshalJSON(raw []byte) error {
 	ar.Field = br.Field
 	ar.Operator = br.Operator
 	switch br.Operator {
-	case OpFactory(In), OpFactory(NotIn):
-		// in and nin operator's value should be an array.
+	case OpFactory(In), OpFactory(NotIn), OpFactory(All):
+		// in, nin and all operator's value should be an array.
 		array := make([]interface{}, 0)
 		if err := json.Unmarshal(br.Value, &array); err != nil {
 			return err
@@ -345,8 +416,8 @@ func (ar *AtomRule) UnmarshalBSON(raw []byte) error {
 	ar.Field = br.Field
 	ar.Operator = br.Operator
 	switch br.Operator {
-	case OpFactory(In), OpFactory(NotIn):
-		// in and nin operator's value should be an array.
+	case OpFactory(In), OpFactory(NotIn), OpFactory(All):
+		// in, nin and all operator's value should be an array.
 		array := make([]interface{}, 0)
 		if err := br.Value.Unmarshal(&array); err != nil {
 			return err
@@ -459,6 +530,41 @@ func (cr *CombinedRule) ToMgo(opt ...*RuleOption) (map[string]interface{}, error
 	}
 }
 
+// Match checks if the data matches the combined rule, AND rule matches when all the rules match the data, OR rule
+// matches when any of the rules matches the data.
+func (cr *CombinedRule) Match(data mapstr.MapStr, opt ...*RuleOption) (bool, error) {
+	if err := cr.Condition.Validate(); err != nil {
+		return false, err
+	}
+
+	if len(cr.Rules) == 0 {
+		return false, errors.New("combined rules shouldn't be empty")
+	}
+
+	for idx, rule := range cr.Rules {
+		matched, err := rule.Match(data, opt...)
+		if err != nil {
+			return false, fmt.Errorf("rules[%d] is invalid, err: %v", idx, err)
+		}
+
+		switch cr.Condition {
+		case Or:
+			if matched {
+				return true, nil
+			}
+		case And:
+			if !matched {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unexpected operator %s", cr.Condition)
+		}
+	}
+
+	// all the rules matches the AND condition, or none of the rules matches the OR condition
+	return cr.Condition == And, nil
+}
+
 type jsonCombinedRuleBroker struct {
 	Condition LogicOperator     `json:"condition"`
 	Rules     []json.RawMessage `json:"rules"`
@@ -229,6 +229,50 @@ func TestRuleValidate(t *testing.T) {
 	opt.MaxRulesDepth = 0
 }
 
+func TestRuleValidateNestedField(t *testing.T) {
+	opt := NewDefaultExprOpt(map[string]enumor.FieldType{
+		"labels":        enumor.MapString,
+		"table":         enumor.Array,
+		"table.element": enumor.String,
+		"table.col":     enumor.Numeric,
+		"obj":           enumor.Object,
+		"obj.sub":       enumor.String,
+		"name":          enumor.String,
+	})
+
+	validRules := []RuleFactory{
+		&AtomRule{Field: "labels.app", Operator: Equal.Factory(), Value: "a"},
+		&AtomRule{Field: "labels.app.kubernetes.io/name", Operator: Regex.Factory(), Value: "^a"},
+		&AtomRule{Field: "labels.app", Operator: Exist.Factory(), Value: ""},
+		&AtomRule{Field: "table.col", Operator: Greater.Factory(), Value: 1},
+		&AtomRule{Field: "table", Operator: All.Factory(), Value: []interface{}{"a", "b"}},
+		&AtomRule{Field: "obj.sub", Operator: NotExist.Factory(), Value: ""},
+		&AtomRule{Field: "name", Operator: Regex.Factory(), Value: "^a.*b$"},
+		&AtomRule{Field: "name", Operator: IsEmpty.Factory(), Value: ""},
+	}
+
+	for _, rule := range validRules {
+		if err := rule.Validate(opt); err != nil {
+			t.Errorf("rule %s validate failed, err: %v", marshalRule(rule), err)
+		}
+	}
+
+	invalidRules := []RuleFactory{
+		&AtomRule{Field: "labels.app", Operator: Equal.Factory(), Value: 1},
+		&AtomRule{Field: "obj.test", Operator: Equal.Factory(), Value: "a"},
+		&AtomRule{Field: "name.test", Operator: Equal.Factory(), Value: "a"},
+		&AtomRule{Field: "table", Operator: All.Factory(), Value: []interface{}{1, 2}},
+		&AtomRule{Field: "table.col", Operator: Regex.Factory(), Value: "a"},
+		&AtomRule{Field: "name", Operator: Regex.Factory(), Value: "(a+)+"},
+	}
+
+	for _, rule := range invalidRules {
+		if err := rule.Validate(opt); err == nil {
+			t.Errorf("rule %s validate should fail", marshalRule(rule))
+		}
+	}
+}
+
 func TestRuleFields(t *testing.T) {
 	var rule RuleFactory
 
@@ -528,3 +572,33 @@ func testExampleRule(t *testing.T, r RuleFactory) {
 		return
 	}
 }
+
+// TestRuleValidateExistingOperators tests that the validation of the operators that exist before the nested field
+// paths are supported is not changed, so that the stored rules are still valid.
+func TestRuleValidateExistingOperators(t *testing.T) {
+	opt := NewDefaultExprOpt(map[string]enumor.FieldType{
+		"name":  enumor.String,
+		"num":   enumor.Numeric,
+		"table": enumor.Array,
+	})
+
+	cases := []struct {
+		rule  RuleFactory
+		valid bool
+	}{
+		{&AtomRule{Field: "name", Operator: IsEmpty.Factory(), Value: ""}, true},
+		{&AtomRule{Field: "name", Operator: IsNotEmpty.Factory(), Value: ""}, true},
+		{&AtomRule{Field: "name", Operator: Exist.Factory(), Value: ""}, true},
+		{&AtomRule{Field: "name", Operator: IsNull.Factory(), Value: ""}, true},
+		{&AtomRule{Field: "num", Operator: Size.Factory(), Value: 1}, true},
+		{&AtomRule{Field: "num", Operator: Exist.Factory(), Value: ""}, false},
+		{&AtomRule{Field: "table", Operator: Size.Factory(), Value: 1}, false},
+	}
+
+	for _, c := range cases {
+		err := c.rule.Validate(opt)
+		if (err == nil) != c.valid {
+			t.Errorf("rule %s validate result: %v, expected valid: %v", marshalRule(c.rule), err, c.valid)
+		}
+	}
+}
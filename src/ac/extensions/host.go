@@ -308,3 +308,32 @@ func (am *AuthManager) AuthorizeCreateHost(ctx context.Context, header http.Head
 
 	return am.AuthorizeResourceCreate(ctx, header, bizID, meta.HostInstance)
 }
+
+// AuthorizeHostLockOverride authorize if the user can override the host locks held by other users
+func (am *AuthManager) AuthorizeHostLockOverride(ctx context.Context, header http.Header) error {
+	if !am.Enabled() {
+		return nil
+	}
+
+	resource := meta.ResourceAttribute{
+		Basic: meta.Basic{
+			Type:   meta.HostLock,
+			Action: meta.Update,
+		},
+		SupplierAccount: util.GetOwnerID(header),
+	}
+	return am.batchAuthorize(ctx, header, resource)
+}
+
+// GenHostLockOverrideNoPermissionResp generate the response of no permission to override the host locks
+func (am *AuthManager) GenHostLockOverrideNoPermissionResp() *metadata.BaseResp {
+	permission := &metadata.IamPermission{
+		SystemID: iam.SystemIDCMDB,
+		Actions: []metadata.IamAction{{
+			ID:                   string(iam.OverrideHostLock),
+			RelatedResourceTypes: nil,
+		}},
+	}
+	resp := metadata.NewNoPermissionResp(permission)
+	return &resp
+}
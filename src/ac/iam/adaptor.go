@@ -120,10 +120,13 @@ func ConvertResourceType(resourceType meta.ResourceType, businessID int64) (*Typ
 	case meta.EventWatch:
 		iamResourceType = SysEventWatch
 	case meta.ConfigAdmin:
+	case meta.HostLock:
 	case meta.SystemConfig:
 	case meta.KubeCluster, meta.KubeNode, meta.KubeNamespace, meta.KubeWorkload, meta.KubeDeployment,
 		meta.KubeStatefulSet, meta.KubeDaemonSet, meta.KubeGameStatefulSet, meta.KubeGameDeployment, meta.KubeCronJob,
-		meta.KubeJob, meta.KubePodWorkload, meta.KubePod, meta.KubeContainer:
+		meta.KubeJob, meta.KubePodWorkload, meta.KubePod, meta.KubeContainer, meta.KubeService, meta.KubeIngress,
+		meta.KubeEndpoint, meta.KubePersistentVolumeClaim, meta.KubePersistentVolume, meta.KubeStorageClass,
+		meta.KubeServiceBinding, meta.KubeHorizontalPodAutoscaler, meta.KubeConfigMap, meta.KubeSecret:
 	default:
 		if IsCMDBSysInstance(resourceType) {
 			iamResourceType = TypeID(resourceType)
@@ -399,22 +402,23 @@ var resourceActionMap = map[meta.ResourceType]map[meta.Action]ActionID{
 		meta.ModelTopologyOperation: EditBusinessLayer,
 	},
 	meta.EventWatch: {
-		meta.WatchHost:             WatchHostEvent,
-		meta.WatchHostRelation:     WatchHostRelationEvent,
-		meta.WatchBiz:              WatchBizEvent,
-		meta.WatchSet:              WatchSetEvent,
-		meta.WatchModule:           WatchModuleEvent,
-		meta.WatchProcess:          WatchProcessEvent,
-		meta.WatchCommonInstance:   WatchCommonInstanceEvent,
-		meta.WatchMainlineInstance: WatchMainlineInstanceEvent,
-		meta.WatchInstAsst:         WatchInstAsstEvent,
-		meta.WatchBizSet:           WatchBizSetEvent,
-		meta.WatchPlat:             WatchPlatEvent,
-		meta.WatchKubeCluster:      WatchKubeClusterEvent,
-		meta.WatchKubeNode:         WatchKubeNodeEvent,
-		meta.WatchKubeNamespace:    WatchKubeNamespaceEvent,
-		meta.WatchKubeWorkload:     WatchKubeWorkloadEvent,
-		meta.WatchKubePod:          WatchKubePodEvent,
+		meta.WatchHost:                 WatchHostEvent,
+		meta.WatchHostRelation:         WatchHostRelationEvent,
+		meta.WatchBiz:                  WatchBizEvent,
+		meta.WatchSet:                  WatchSetEvent,
+		meta.WatchModule:               WatchModuleEvent,
+		meta.WatchProcess:              WatchProcessEvent,
+		meta.WatchCommonInstance:       WatchCommonInstanceEvent,
+		meta.WatchMainlineInstance:     WatchMainlineInstanceEvent,
+		meta.WatchInstAsst:             WatchInstAsstEvent,
+		meta.WatchBizSet:               WatchBizSetEvent,
+		meta.WatchPlat:                 WatchPlatEvent,
+		meta.WatchHostTransferSchedule: WatchHostTransferScheduleEvent,
+		meta.WatchKubeCluster:          WatchKubeClusterEvent,
+		meta.WatchKubeNode:             WatchKubeNodeEvent,
+		meta.WatchKubeNamespace:        WatchKubeNamespaceEvent,
+		meta.WatchKubeWorkload:         WatchKubeWorkloadEvent,
+		meta.WatchKubePod:              WatchKubePodEvent,
 	},
 	meta.UserCustom: {
 		meta.Find:   Skip,
@@ -486,6 +490,13 @@ var resourceActionMap = map[meta.ResourceType]map[meta.Action]ActionID{
 		meta.Delete: Unsupported,
 		meta.Create: Unsupported,
 	},
+	meta.HostLock: {
+		// update means overriding the host locks held by other users
+		meta.Find:   Skip,
+		meta.Update: OverrideHostLock,
+		meta.Delete: Unsupported,
+		meta.Create: Unsupported,
+	},
 	meta.KubeCluster: {
 		meta.Find:   Skip,
 		meta.Update: EditContainerCluster,
@@ -566,6 +577,66 @@ var resourceActionMap = map[meta.ResourceType]map[meta.Action]ActionID{
 	meta.KubeContainer: {
 		meta.Find: Skip,
 	},
+	meta.KubeService: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerNamespace,
+		meta.Delete: DeleteContainerNamespace,
+		meta.Create: CreateContainerNamespace,
+	},
+	meta.KubeIngress: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerNamespace,
+		meta.Delete: DeleteContainerNamespace,
+		meta.Create: CreateContainerNamespace,
+	},
+	meta.KubeEndpoint: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerNamespace,
+		meta.Delete: DeleteContainerNamespace,
+		meta.Create: CreateContainerNamespace,
+	},
+	meta.KubePersistentVolumeClaim: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerNamespace,
+		meta.Delete: DeleteContainerNamespace,
+		meta.Create: CreateContainerNamespace,
+	},
+	meta.KubePersistentVolume: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerCluster,
+		meta.Delete: DeleteContainerCluster,
+		meta.Create: CreateContainerCluster,
+	},
+	meta.KubeStorageClass: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerCluster,
+		meta.Delete: DeleteContainerCluster,
+		meta.Create: CreateContainerCluster,
+	},
+	meta.KubeServiceBinding: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerWorkload,
+		meta.Delete: EditContainerWorkload,
+		meta.Create: EditContainerWorkload,
+	},
+	meta.KubeHorizontalPodAutoscaler: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerWorkload,
+		meta.Delete: EditContainerWorkload,
+		meta.Create: EditContainerWorkload,
+	},
+	meta.KubeConfigMap: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerNamespace,
+		meta.Delete: DeleteContainerNamespace,
+		meta.Create: CreateContainerNamespace,
+	},
+	meta.KubeSecret: {
+		meta.Find:   Skip,
+		meta.Update: EditContainerNamespace,
+		meta.Delete: DeleteContainerNamespace,
+		meta.Create: CreateContainerNamespace,
+	},
 }
 
 // ParseIamPathToAncestors TODO
@@ -81,7 +81,7 @@ func GenIamResource(act ActionID, rscType TypeID, a *meta.ResourceAttribute) ([]
 		}
 	case meta.ModelInstanceTopology, meta.MainlineModelTopology, meta.UserCustom:
 		return genSkipResource(act, rscType, a)
-	case meta.ConfigAdmin:
+	case meta.ConfigAdmin, meta.HostLock:
 		return genGlobalConfigResource(act, rscType, a)
 	case meta.MainlineModel:
 		return genBusinessLayerResource(act, rscType, a)
@@ -95,7 +95,9 @@ func GenIamResource(act ActionID, rscType TypeID, a *meta.ResourceAttribute) ([]
 		return genProcessServiceCategoryResource(act, rscType, a)
 	case meta.KubeCluster, meta.KubeNode, meta.KubeNamespace, meta.KubeWorkload, meta.KubeDeployment,
 		meta.KubeStatefulSet, meta.KubeDaemonSet, meta.KubeGameStatefulSet, meta.KubeGameDeployment, meta.KubeCronJob,
-		meta.KubeJob, meta.KubePodWorkload, meta.KubePod, meta.KubeContainer:
+		meta.KubeJob, meta.KubePodWorkload, meta.KubePod, meta.KubeContainer, meta.KubeService, meta.KubeIngress,
+		meta.KubeEndpoint, meta.KubePersistentVolumeClaim, meta.KubePersistentVolume, meta.KubeStorageClass,
+		meta.KubeServiceBinding, meta.KubeHorizontalPodAutoscaler, meta.KubeConfigMap, meta.KubeSecret:
 		return make([]types.Resource, 0), nil
 	default:
 		if IsCMDBSysInstance(a.Basic.Type) {
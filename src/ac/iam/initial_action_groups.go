@@ -90,6 +90,9 @@ func genBusinessManageActionGroups() []ActionGroup {
 						{
 							ID: HostTransferAcrossBusiness,
 						},
+						{
+							ID: OverrideHostLock,
+						},
 					},
 				},
 				{
@@ -365,6 +368,9 @@ func genResourceManageActionGroups() []ActionGroup {
 						{
 							ID: WatchPlatEvent,
 						},
+						{
+							ID: WatchHostTransferScheduleEvent,
+						},
 						{
 							ID: WatchKubeClusterEvent,
 						},
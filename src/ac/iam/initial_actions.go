@@ -119,12 +119,14 @@ var ActionIDNameMap = map[ActionID]string{
 	WatchInstAsstEvent:                  "实例关联事件监听",
 	WatchBizSetEvent:                    "业务集事件监听",
 	WatchPlatEvent:                      "云区域事件监听",
+	WatchHostTransferScheduleEvent:      "主机定时转移事件监听",
 	WatchKubeClusterEvent:               "容器集群事件监听",
 	WatchKubeNodeEvent:                  "容器节点事件监听",
 	WatchKubeNamespaceEvent:             "容器命名空间事件监听",
 	WatchKubeWorkloadEvent:              "容器工作负载事件监听",
 	WatchKubePodEvent:                   "容器Pod事件监听",
 	GlobalSettings:                      "全局设置",
+	OverrideHostLock:                    "主机锁强制覆盖",
 	CreateContainerCluster:              "容器集群新建",
 	EditContainerCluster:                "容器集群编辑",
 	DeleteContainerCluster:              "容器集群删除",
@@ -161,6 +163,7 @@ func GenerateStaticActions() []ResourceAction {
 	resourceActionList = append(resourceActionList, genBusinessSetTemplateActions()...)
 	resourceActionList = append(resourceActionList, genBusinessTopologyActions()...)
 	resourceActionList = append(resourceActionList, genBusinessHostApplyActions()...)
+	resourceActionList = append(resourceActionList, genHostLockActions()...)
 
 	// add public resource actions
 	resourceActionList = append(resourceActionList, genResourcePoolHostActions()...)
@@ -252,6 +255,20 @@ func genBusinessHostActions() []ResourceAction {
 	return actions
 }
 
+func genHostLockActions() []ResourceAction {
+	actions := make([]ResourceAction, 0)
+	actions = append(actions, ResourceAction{
+		ID:                   OverrideHostLock,
+		Name:                 ActionIDNameMap[OverrideHostLock],
+		NameEn:               "Override Host Lock",
+		Type:                 Edit,
+		RelatedResourceTypes: nil,
+		RelatedActions:       nil,
+		Version:              1,
+	})
+	return actions
+}
+
 func genBusinessCustomQueryActions() []ResourceAction {
 	selection := []RelatedInstanceSelection{{
 		SystemID: SystemIDCMDB,
@@ -1271,6 +1288,14 @@ func genEventWatchActions() []ResourceAction {
 		Version: 1,
 	})
 
+	actions = append(actions, ResourceAction{
+		ID:      WatchHostTransferScheduleEvent,
+		Name:    ActionIDNameMap[WatchHostTransferScheduleEvent],
+		NameEn:  "Host Transfer Schedule Event Listen",
+		Type:    View,
+		Version: 1,
+	})
+
 	modelSelection := []RelatedInstanceSelection{{
 		SystemID: SystemIDCMDB,
 		ID:       SysModelEventSelection,
@@ -484,6 +484,8 @@ const (
 	WatchBizSetEvent ActionID = "watch_biz_set_event"
 	// WatchPlatEvent watch cloud area event action id
 	WatchPlatEvent ActionID = "watch_plat_event"
+	// WatchHostTransferScheduleEvent watch scheduled host transfer event action id
+	WatchHostTransferScheduleEvent ActionID = "watch_host_transfer_schedule_event"
 
 	// watch kube related event actions
 
@@ -501,6 +503,9 @@ const (
 	// GlobalSettings TODO
 	GlobalSettings ActionID = "global_settings"
 
+	// OverrideHostLock override the host locks held by other users when updating, transferring or deleting hosts
+	OverrideHostLock ActionID = "override_host_lock"
+
 	// Unsupported TODO
 	// Unknown is an action that can not be recognized
 	Unsupported ActionID = "unsupported"
@@ -176,6 +176,8 @@ const (
 	WatchBizSet Action = "biz_set"
 	// WatchPlat watch cloud area event cc action
 	WatchPlat Action = "plat"
+	// WatchHostTransferSchedule watch scheduled host transfer event cc action
+	WatchHostTransferSchedule Action = "host_transfer_schedule"
 
 	// kube related event watch cc actions
 
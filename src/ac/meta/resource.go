@@ -64,6 +64,7 @@ const (
 	CloudAccount             ResourceType = "cloudAccount"
 	CloudResourceTask        ResourceType = "cloudResourceTask"
 	ConfigAdmin              ResourceType = "configAdmin"
+	HostLock                 ResourceType = "hostLock"
 )
 
 // kube related auth resource in CMDB
@@ -86,6 +87,36 @@ const (
 	// KubeContainer auth resource type in CMDB
 	KubeContainer ResourceType = "kube_container"
 
+	// KubeService auth resource type in CMDB
+	KubeService ResourceType = "kube_service"
+
+	// KubeIngress auth resource type in CMDB
+	KubeIngress ResourceType = "kube_ingress"
+
+	// KubeEndpoint auth resource type in CMDB
+	KubeEndpoint ResourceType = "kube_endpoint"
+
+	// KubePersistentVolumeClaim auth resource type in CMDB
+	KubePersistentVolumeClaim ResourceType = "kube_pvc"
+
+	// KubePersistentVolume auth resource type in CMDB
+	KubePersistentVolume ResourceType = "kube_pv"
+
+	// KubeStorageClass auth resource type in CMDB
+	KubeStorageClass ResourceType = "kube_storage_class"
+
+	// KubeServiceBinding auth resource type in CMDB
+	KubeServiceBinding ResourceType = "kube_service_binding"
+
+	// KubeHorizontalPodAutoscaler auth resource type in CMDB
+	KubeHorizontalPodAutoscaler ResourceType = "kube_hpa"
+
+	// KubeConfigMap auth resource type in CMDB
+	KubeConfigMap ResourceType = "kube_config_map"
+
+	// KubeSecret auth resource type in CMDB
+	KubeSecret ResourceType = "kube_secret"
+
 	// below are specific workload auth resource types in CMDB, reserved for later use
 
 	// KubeDeployment auth resource type in CMDB
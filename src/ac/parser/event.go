@@ -64,6 +64,13 @@ func (ps *parseStream) watch() *parseStream {
 			resource = string(watch.BizSet)
 		}
 
+		switch watch.CursorType(resource) {
+		case watch.KubeService, watch.KubeIngress, watch.KubeEndpoint:
+			// redirect kube service, ingress and endpoint resources to namespace resource in iam, since they are
+			// all namespace scoped resources that are managed with namespace permissions.
+			resource = string(watch.KubeNamespace)
+		}
+
 		authResource := meta.ResourceAttribute{
 			Basic: meta.Basic{
 				Type:   meta.EventWatch,
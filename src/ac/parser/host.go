@@ -55,6 +55,7 @@ var (
 	getDynamicGroupRegexp     = regexp.MustCompile(`^/api/v3/dynamicgroup/[0-9]+/[^\s/]+/?$`)
 	searchDynamicGroupRegexp  = regexp.MustCompile(`^/api/v3/dynamicgroup/search/[0-9]+/?$`)
 	executeDynamicGroupRegexp = regexp.MustCompile(`^/api/v3/dynamicgroup/data/[0-9]+/[^\s/]+/?$`)
+	watchDynamicGroupRegexp   = regexp.MustCompile(`^/api/v3/dynamicgroup/watch/[0-9]+/[^\s/]+/?$`)
 )
 
 func (ps *parseStream) dynamicGrouping() *parseStream {
@@ -201,6 +202,31 @@ func (ps *parseStream) dynamicGrouping() *parseStream {
 		return ps
 	}
 
+	if ps.hitRegexp(watchDynamicGroupRegexp, http.MethodPost) {
+		if len(ps.RequestCtx.Elements) != 6 {
+			ps.err = errors.New("watch dynamic group, but got invalid uri")
+			return ps
+		}
+
+		bizID, err := strconv.ParseInt(ps.RequestCtx.Elements[4], 10, 64)
+		if err != nil {
+			ps.err = fmt.Errorf("watch dynamic group failed, err: %v", err)
+			return ps
+		}
+
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				BusinessID: bizID,
+				Basic: meta.Basic{
+					Type:   meta.DynamicGrouping,
+					Action: meta.Execute,
+					Name:   ps.RequestCtx.Elements[5],
+				},
+			},
+		}
+		return ps
+	}
+
 	return ps
 }
 
@@ -369,6 +395,38 @@ var (
 	findHostsByBizSetPattern = regexp.MustCompile(`^/api/v3/findmany/hosts/biz_set/[0-9]+/?$`)
 
 	findHostsTotalTopo = regexp.MustCompile(`^/api/v3/findmany/hosts/total_mainline_topo/biz/\d+$`)
+
+	// host snapshot history and change log are authorized by the host in host server
+	findHostSnapHistoryPattern   = "/api/v3/findmany/hosts/snapshot/history"
+	findHostSnapChangeLogPattern = "/api/v3/findmany/hosts/snapshot/change_log"
+
+	findBizHostSnapDriftRegexp        = regexp.MustCompile(`^/api/v3/findmany/hosts/snapshot/drift/biz/[0-9]+/?$`)
+	findBizHostSnapDriftSummaryRegexp = regexp.MustCompile(
+		`^/api/v3/findmany/hosts/snapshot/drift/summary/biz/[0-9]+/?$`)
+
+	// host lifecycle is a global config, it can be read by anyone
+	updateHostLifecyclePattern = "/api/v3/update/hosts/lifecycle"
+	findHostLifecyclePattern   = "/api/v3/find/hosts/lifecycle"
+
+	// stale host policy is a global config like the host lifecycle
+	updateStaleHostPolicyPattern = "/api/v3/update/hosts/stale/policy"
+	findStaleHostPolicyPattern   = "/api/v3/find/hosts/stale/policy"
+	findBizStaleHostRegexp       = regexp.MustCompile(`^/api/v3/findmany/hosts/stale/biz/[0-9]+/?$`)
+
+	// duplicate host merge is authorized by the merged hosts in host server
+	findDuplicateHostsPattern = "/api/v3/findmany/hosts/duplicate"
+	previewMergeHostPattern   = "/api/v3/find/hosts/merge/preview"
+	mergeHostPattern          = "/api/v3/update/hosts/merge"
+
+	// bulk host update by filter is authorized by the matched hosts in host server
+	bulkUpdateHostByFilterRegexp       = regexp.MustCompile(`^/api/v3/updatemany/hosts/by_filter/biz/[0-9]+(/preview)?/?$`)
+	findBulkUpdateHostTaskStatusRegexp = regexp.MustCompile(`^/api/v3/find/hosts/by_filter/biz/[0-9]+/task/[^\s/]+/?$`)
+
+	// scheduled host transfer is authorized the same as the host transfer with auto clear service instance
+	createHostTransferScheduleRegexp = regexp.MustCompile(`^/api/v3/create/hosts/transfer_schedule/biz/[0-9]+/?$`)
+	cancelHostTransferScheduleRegexp = regexp.MustCompile(
+		`^/api/v3/update/hosts/transfer_schedule/biz/[0-9]+/[0-9]+/cancel/?$`)
+	findHostTransferScheduleRegexp = regexp.MustCompile(`^/api/v3/findmany/hosts/transfer_schedule/biz/[0-9]+/?$`)
 )
 
 func (ps *parseStream) host() *parseStream {
@@ -388,6 +446,202 @@ func (ps *parseStream) host() *parseStream {
 		return ps
 	}
 
+	if ps.hitPattern(findDuplicateHostsPattern, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.HostInstance,
+					Action: meta.FindMany,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitPattern(previewMergeHostPattern, http.MethodPost) || ps.hitPattern(mergeHostPattern, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.HostInstance,
+					Action: meta.SkipAction,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(bulkUpdateHostByFilterRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.HostInstance,
+					Action: meta.SkipAction,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findBulkUpdateHostTaskStatusRegexp, http.MethodGet) {
+		bizID, err := strconv.ParseInt(ps.RequestCtx.Elements[6], 10, 64)
+		if err != nil {
+			ps.err = fmt.Errorf("find bulk update host task status, but got invalid business id: %s",
+				ps.RequestCtx.Elements[6])
+			return ps
+		}
+
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				BusinessID: bizID,
+				Basic: meta.Basic{
+					Type:   meta.HostInstance,
+					Action: meta.FindMany,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createHostTransferScheduleRegexp, http.MethodPost) ||
+		ps.hitRegexp(cancelHostTransferScheduleRegexp, http.MethodPut) {
+
+		bizID, err := strconv.ParseInt(ps.RequestCtx.Elements[6], 10, 64)
+		if err != nil {
+			ps.err = fmt.Errorf("operate host transfer schedule, but got invalid business id: %s",
+				ps.RequestCtx.Elements[6])
+			return ps
+		}
+
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				BusinessID: bizID,
+				Basic: meta.Basic{
+					Type:   meta.ProcessServiceInstance,
+					Action: meta.Create,
+				},
+			},
+			{
+				BusinessID: bizID,
+				Basic: meta.Basic{
+					Type:   meta.ProcessServiceInstance,
+					Action: meta.Update,
+				},
+			},
+			{
+				BusinessID: bizID,
+				Basic: meta.Basic{
+					Type:   meta.ProcessServiceInstance,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findHostTransferScheduleRegexp, http.MethodPost) {
+		bizID, err := strconv.ParseInt(ps.RequestCtx.Elements[6], 10, 64)
+		if err != nil {
+			ps.err = fmt.Errorf("find host transfer schedule, but got invalid business id: %s",
+				ps.RequestCtx.Elements[6])
+			return ps
+		}
+
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				BusinessID: bizID,
+				Basic: meta.Basic{
+					Type:   meta.HostInstance,
+					Action: meta.FindMany,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitPattern(updateHostLifecyclePattern, http.MethodPut) ||
+		ps.hitPattern(updateStaleHostPolicyPattern, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.ConfigAdmin,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitPattern(findHostLifecyclePattern, http.MethodGet) ||
+		ps.hitPattern(findStaleHostPolicyPattern, http.MethodGet) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.HostInstance,
+					Action: meta.SkipAction,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitPattern(findHostSnapHistoryPattern, http.MethodPost) ||
+		ps.hitPattern(findHostSnapChangeLogPattern, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.HostInstance,
+					Action: meta.SkipAction,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findBizHostSnapDriftRegexp, http.MethodPost) ||
+		ps.hitRegexp(findBizHostSnapDriftSummaryRegexp, http.MethodPost) {
+
+		bizIDStr := ps.RequestCtx.Elements[7]
+		if ps.hitRegexp(findBizHostSnapDriftSummaryRegexp, http.MethodPost) {
+			bizIDStr = ps.RequestCtx.Elements[8]
+		}
+
+		bizID, err := strconv.ParseInt(bizIDStr, 10, 64)
+		if err != nil {
+			ps.err = fmt.Errorf("find host snapshot drifts, but got invalid business id: %s", bizIDStr)
+			return ps
+		}
+
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				BusinessID: bizID,
+				Basic: meta.Basic{
+					Type:   meta.HostInstance,
+					Action: meta.FindMany,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findBizStaleHostRegexp, http.MethodPost) {
+		bizID, err := strconv.ParseInt(ps.RequestCtx.Elements[6], 10, 64)
+		if err != nil {
+			ps.err = fmt.Errorf("find stale hosts, but got invalid business id: %s", ps.RequestCtx.Elements[6])
+			return ps
+		}
+
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				BusinessID: bizID,
+				Basic: meta.Basic{
+					Type:   meta.HostInstance,
+					Action: meta.FindMany,
+				},
+			},
+		}
+		return ps
+	}
+
 	if ps.hitRegexp(findHostsByServiceTemplatesRegex, http.MethodPost) {
 		bizID, err := strconv.ParseInt(ps.RequestCtx.Elements[6], 10, 64)
 		if err != nil {
@@ -1166,8 +1166,9 @@ var (
 	findObjectInstancesUniqueFieldsRegexp = regexp.MustCompile(
 		`^/api/v3/find/instance/object/[^\s/]+/unique_fields/by/unique/[0-9]+/?$`)
 
-	searchObjectInstancesRegexp = regexp.MustCompile(`^/api/v3/search/instances/object/[^\s/]+/?$`)
-	countObjectInstancesRegexp  = regexp.MustCompile(`^/api/v3/count/instances/object/[^\s/]+/?$`)
+	searchObjectInstancesRegexp    = regexp.MustCompile(`^/api/v3/search/instances/object/[^\s/]+/?$`)
+	countObjectInstancesRegexp     = regexp.MustCompile(`^/api/v3/count/instances/object/[^\s/]+/?$`)
+	aggregateObjectInstancesRegexp = regexp.MustCompile(`^/api/v3/aggregate/instances/object/[^\s/]+/?$`)
 )
 
 func (ps *parseStream) objectInstanceLatest() *parseStream {
@@ -1670,6 +1671,41 @@ func (ps *parseStream) objectInstanceLatest() *parseStream {
 		return ps
 	}
 
+	// aggregate object instances operation.
+	if ps.hitRegexp(aggregateObjectInstancesRegexp, http.MethodPost) {
+		if len(ps.RequestCtx.Elements) != 6 {
+			ps.err = errors.New("aggregate object instances, got invalid url")
+			return ps
+		}
+
+		objID := ps.RequestCtx.Elements[5]
+		if len(objID) == 0 {
+			ps.err = fmt.Errorf("aggregate object instances failed, got empty object id")
+			return ps
+		}
+
+		model, err := ps.getOneModel(mapstr.MapStr{common.BKObjIDField: objID})
+		if err != nil {
+			ps.err = err
+			return ps
+		}
+		instanceType, err := ps.getInstanceTypeByObject(model.ObjectID, model.ID)
+		if err != nil {
+			ps.err = err
+			return ps
+		}
+
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   instanceType,
+					Action: meta.SkipAction,
+				},
+			},
+		}
+		return ps
+	}
+
 	// find object's instances' unique fields operation
 	if ps.hitRegexp(findObjectInstancesUniqueFieldsRegexp, http.MethodPost) {
 		if len(ps.RequestCtx.Elements) != 10 {
@@ -1495,11 +1495,23 @@ const (
 	createCloudAreaPattern        = "/api/v3/create/cloudarea"
 	createManyCloudAreaPattern    = "/api/v3/createmany/cloudarea"
 	findCloudAreaHostCountPattern = "/api/v3/findmany/cloudarea/hostcount"
+	findIPConflictPattern         = "/api/v3/findmany/cloudarea/ip_conflict"
 )
 
 var (
 	updateCloudAreaRegexp = regexp.MustCompile(`^/api/v3/update/cloudarea/[0-9]+/?$`)
 	deleteCloudAreaRegexp = regexp.MustCompile(`^/api/v3/delete/cloudarea/[0-9]+/?$`)
+
+	// the subnets and their ip reservations are managed as a part of the cloud area
+	createSubnetRegexp    = regexp.MustCompile(`^/api/v3/create/cloudarea/[0-9]+/subnet/?$`)
+	updateSubnetRegexp    = regexp.MustCompile(`^/api/v3/update/cloudarea/[0-9]+/subnet/[0-9]+/?$`)
+	deleteSubnetRegexp    = regexp.MustCompile(`^/api/v3/delete/cloudarea/[0-9]+/subnet/[0-9]+/?$`)
+	reserveSubnetIPRegexp = regexp.MustCompile(
+		`^/api/v3/createmany/cloudarea/[0-9]+/subnet/[0-9]+/ip/(reservation|allocation)/?$`)
+	releaseSubnetIPRegexp  = regexp.MustCompile(`^/api/v3/deletemany/cloudarea/[0-9]+/subnet/[0-9]+/ip/reservation/?$`)
+	findSubnetRegexp       = regexp.MustCompile(`^/api/v3/findmany/cloudarea/[0-9]+/subnet(/utilization)?/?$`)
+	findSubnetDetailRegexp = regexp.MustCompile(
+		`^/api/v3/findmany/cloudarea/[0-9]+/subnet/[0-9]+/(hosts|ip/reservation)/?$`)
 )
 
 func (ps *parseStream) cloudArea() *parseStream {
@@ -1581,6 +1593,41 @@ func (ps *parseStream) cloudArea() *parseStream {
 		return ps
 	}
 
+	if ps.hitRegexp(createSubnetRegexp, http.MethodPost) || ps.hitRegexp(updateSubnetRegexp, http.MethodPut) ||
+		ps.hitRegexp(deleteSubnetRegexp, http.MethodDelete) || ps.hitRegexp(reserveSubnetIPRegexp, http.MethodPost) ||
+		ps.hitRegexp(releaseSubnetIPRegexp, http.MethodDelete) {
+
+		id, err := strconv.ParseInt(ps.RequestCtx.Elements[4], 10, 64)
+		if err != nil {
+			ps.err = fmt.Errorf("parse cloud id %s failed", ps.RequestCtx.Elements[4])
+			return ps
+		}
+
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:       meta.CloudAreaInstance,
+					Action:     meta.Update,
+					InstanceID: id,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findSubnetRegexp, http.MethodPost) || ps.hitRegexp(findSubnetDetailRegexp, http.MethodPost) ||
+		ps.hitPattern(findIPConflictPattern, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.CloudAreaInstance,
+					Action: meta.SkipAction,
+				},
+			},
+		}
+		return ps
+	}
+
 	if ps.hitPattern(findCloudAreaHostCountPattern, http.MethodPost) {
 		ps.Attribute.Resources = []meta.ResourceAttribute{
 			{
@@ -1608,6 +1655,8 @@ var (
 	findKubeClusterRegexp       = regexp.MustCompile(`^/api/v3/findmany/kube/cluster/bk_biz_id/([0-9]+)$`)
 	updatemanyKubeClusterRegexp = regexp.MustCompile(`^/api/v3/updatemany/kube/cluster/bk_biz_id/([0-9]+)$`)
 
+	findKubeClusterSyncStatusRegexp = regexp.MustCompile(`^/api/v3/findmany/kube/cluster/sync_status/bk_biz_id/([0-9]+)$`)
+
 	createKubeNodeRegexp     = regexp.MustCompile(`^/api/v3/createmany/kube/node/bk_biz_id/([0-9]+)$`)
 	findKubeNodeRegexp       = regexp.MustCompile(`^/api/v3/findmany/kube/node/bk_biz_id/([0-9]+)$`)
 	deleteKubeNodeRegexp     = regexp.MustCompile(`^/api/v3/deletemany/kube/node/bk_biz_id/([0-9]+)$`)
@@ -1627,10 +1676,76 @@ var (
 	deleteWorkloadRegexp = regexp.MustCompile(`^/api/v3/deletemany/kube/workload/[^\s/]+/[0-9]+/?$`)
 	findWorkloadRegexp   = regexp.MustCompile(`^/api/v3/findmany/kube/workload/[^\s/]+/[0-9]+/?$`)
 
+	findWorkloadMatchedPodRegexp = regexp.MustCompile(`^/api/v3/findmany/kube/workload/matched_pod/[^\s/]+/[0-9]+/?$`)
+
 	findPodPathRegexp = regexp.MustCompile(`^/api/v3/find/kube/pod_path/bk_biz_id/([0-9]+)/?$`)
 	findPodRegexp     = regexp.MustCompile(`^/api/v3/findmany/kube/pod/bk_biz_id/([0-9]+)/?$`)
 
 	findContainerRegexp = regexp.MustCompile(`^/api/v3/findmany/kube/container/bk_biz_id/([0-9]+)/?$`)
+
+	createKubeServiceRegexp = regexp.MustCompile(`^/api/v3/createmany/kube/service/bk_biz_id/([0-9]+)/?$`)
+	updateKubeServiceRegexp = regexp.MustCompile(`^/api/v3/updatemany/kube/service/bk_biz_id/([0-9]+)/?$`)
+	deleteKubeServiceRegexp = regexp.MustCompile(`^/api/v3/deletemany/kube/service/bk_biz_id/([0-9]+)/?$`)
+	findKubeServiceRegexp   = regexp.MustCompile(`^/api/v3/findmany/kube/service/bk_biz_id/([0-9]+)/?$`)
+
+	createKubeIngressRegexp = regexp.MustCompile(`^/api/v3/createmany/kube/ingress/bk_biz_id/([0-9]+)/?$`)
+	updateKubeIngressRegexp = regexp.MustCompile(`^/api/v3/updatemany/kube/ingress/bk_biz_id/([0-9]+)/?$`)
+	deleteKubeIngressRegexp = regexp.MustCompile(`^/api/v3/deletemany/kube/ingress/bk_biz_id/([0-9]+)/?$`)
+	findKubeIngressRegexp   = regexp.MustCompile(`^/api/v3/findmany/kube/ingress/bk_biz_id/([0-9]+)/?$`)
+
+	createKubeEndpointRegexp = regexp.MustCompile(`^/api/v3/createmany/kube/endpoint/bk_biz_id/([0-9]+)/?$`)
+	updateKubeEndpointRegexp = regexp.MustCompile(`^/api/v3/updatemany/kube/endpoint/bk_biz_id/([0-9]+)/?$`)
+	deleteKubeEndpointRegexp = regexp.MustCompile(`^/api/v3/deletemany/kube/endpoint/bk_biz_id/([0-9]+)/?$`)
+	findKubeEndpointRegexp   = regexp.MustCompile(`^/api/v3/findmany/kube/endpoint/bk_biz_id/([0-9]+)/?$`)
+
+	createKubePVCRegexp         = regexp.MustCompile(`^/api/v3/createmany/kube/pvc/bk_biz_id/([0-9]+)/?$`)
+	updateKubePVCRegexp         = regexp.MustCompile(`^/api/v3/updatemany/kube/pvc/bk_biz_id/([0-9]+)/?$`)
+	deleteKubePVCRegexp         = regexp.MustCompile(`^/api/v3/deletemany/kube/pvc/bk_biz_id/([0-9]+)/?$`)
+	findKubePVCRegexp           = regexp.MustCompile(`^/api/v3/findmany/kube/pvc/bk_biz_id/([0-9]+)/?$`)
+	findKubePVCMountedPodRegexp = regexp.MustCompile(`^/api/v3/findmany/kube/pvc/mounted_pod/bk_biz_id/([0-9]+)/?$`)
+
+	createKubePVRegexp = regexp.MustCompile(`^/api/v3/createmany/kube/pv/bk_biz_id/([0-9]+)/?$`)
+	updateKubePVRegexp = regexp.MustCompile(`^/api/v3/updatemany/kube/pv/bk_biz_id/([0-9]+)/?$`)
+	deleteKubePVRegexp = regexp.MustCompile(`^/api/v3/deletemany/kube/pv/bk_biz_id/([0-9]+)/?$`)
+	findKubePVRegexp   = regexp.MustCompile(`^/api/v3/findmany/kube/pv/bk_biz_id/([0-9]+)/?$`)
+
+	createKubeStorageClassRegexp = regexp.MustCompile(`^/api/v3/createmany/kube/storage_class/bk_biz_id/([0-9]+)/?$`)
+	updateKubeStorageClassRegexp = regexp.MustCompile(`^/api/v3/updatemany/kube/storage_class/bk_biz_id/([0-9]+)/?$`)
+	deleteKubeStorageClassRegexp = regexp.MustCompile(`^/api/v3/deletemany/kube/storage_class/bk_biz_id/([0-9]+)/?$`)
+	findKubeStorageClassRegexp   = regexp.MustCompile(`^/api/v3/findmany/kube/storage_class/bk_biz_id/([0-9]+)/?$`)
+
+	findKubeStorageCapacityRegexp = regexp.MustCompile(`^/api/v3/find/kube/storage_capacity/bk_biz_id/([0-9]+)/?$`)
+	findKubeResourceUsageRegexp   = regexp.MustCompile(`^/api/v3/find/kube/resource_usage/bk_biz_id/([0-9]+)/?$`)
+
+	findKubeManifestImportPlanRegexp = regexp.MustCompile(
+		`^/api/v3/find/kube/manifest/import_plan/bk_biz_id/([0-9]+)/?$`)
+	importKubeManifestRegexp = regexp.MustCompile(`^/api/v3/import/kube/manifest/bk_biz_id/([0-9]+)/?$`)
+
+	createKubeServiceBindingRegexp = regexp.MustCompile(
+		`^/api/v3/createmany/kube/service_binding/bk_biz_id/([0-9]+)/?$`)
+	deleteKubeServiceBindingRegexp = regexp.MustCompile(
+		`^/api/v3/deletemany/kube/service_binding/bk_biz_id/([0-9]+)/?$`)
+	findKubeServiceBindingRegexp = regexp.MustCompile(`^/api/v3/findmany/kube/service_binding/bk_biz_id/([0-9]+)/?$`)
+
+	createKubeHPARegexp = regexp.MustCompile(`^/api/v3/createmany/kube/hpa/bk_biz_id/([0-9]+)/?$`)
+	updateKubeHPARegexp = regexp.MustCompile(`^/api/v3/updatemany/kube/hpa/bk_biz_id/([0-9]+)/?$`)
+	deleteKubeHPARegexp = regexp.MustCompile(`^/api/v3/deletemany/kube/hpa/bk_biz_id/([0-9]+)/?$`)
+	findKubeHPARegexp   = regexp.MustCompile(`^/api/v3/findmany/kube/hpa/bk_biz_id/([0-9]+)/?$`)
+
+	findKubeReplicaHistoryRegexp = regexp.MustCompile(`^/api/v3/findmany/kube/replica_history/bk_biz_id/([0-9]+)/?$`)
+
+	createKubeConfigMapRegexp       = regexp.MustCompile(`^/api/v3/createmany/kube/config_map/bk_biz_id/([0-9]+)/?$`)
+	updateKubeConfigMapRegexp       = regexp.MustCompile(`^/api/v3/updatemany/kube/config_map/bk_biz_id/([0-9]+)/?$`)
+	deleteKubeConfigMapRegexp       = regexp.MustCompile(`^/api/v3/deletemany/kube/config_map/bk_biz_id/([0-9]+)/?$`)
+	findKubeConfigMapRegexp         = regexp.MustCompile(`^/api/v3/findmany/kube/config_map/bk_biz_id/([0-9]+)/?$`)
+	findKubeConfigMapConsumerRegexp = regexp.MustCompile(
+		`^/api/v3/findmany/kube/config_map/consumer/bk_biz_id/([0-9]+)/?$`)
+
+	createKubeSecretRegexp       = regexp.MustCompile(`^/api/v3/createmany/kube/secret/bk_biz_id/([0-9]+)/?$`)
+	updateKubeSecretRegexp       = regexp.MustCompile(`^/api/v3/updatemany/kube/secret/bk_biz_id/([0-9]+)/?$`)
+	deleteKubeSecretRegexp       = regexp.MustCompile(`^/api/v3/deletemany/kube/secret/bk_biz_id/([0-9]+)/?$`)
+	findKubeSecretRegexp         = regexp.MustCompile(`^/api/v3/findmany/kube/secret/bk_biz_id/([0-9]+)/?$`)
+	findKubeSecretConsumerRegexp = regexp.MustCompile(`^/api/v3/findmany/kube/secret/consumer/bk_biz_id/([0-9]+)/?$`)
 )
 
 // NOCC:golint/fnsize(整体属于 container 操作需要放在一起)
@@ -1749,6 +1864,29 @@ func (ps *parseStream) kube() *parseStream {
 		return ps
 	}
 
+	if ps.hitRegexp(findKubeClusterSyncStatusRegexp, http.MethodPost) {
+		if len(ps.RequestCtx.Elements) != 8 {
+			ps.err = fmt.Errorf("get invalid url elements length %d", len(ps.RequestCtx.Elements))
+			return ps
+		}
+
+		bizID, err := strconv.ParseInt(ps.RequestCtx.Elements[7], 10, 64)
+		if err != nil {
+			ps.err = fmt.Errorf("get invalid business id %s, err: %v", ps.RequestCtx.Elements[7], err)
+			return ps
+		}
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				BusinessID: bizID,
+				Basic: meta.Basic{
+					Type:   meta.KubeCluster,
+					Action: meta.FindMany,
+				},
+			},
+		}
+		return ps
+	}
+
 	if ps.hitRegexp(createKubeNodeRegexp, http.MethodPost) {
 		if len(ps.RequestCtx.Elements) != 7 {
 			ps.err = fmt.Errorf("get invalid url elements length %d", len(ps.RequestCtx.Elements))
@@ -2029,6 +2167,18 @@ func (ps *parseStream) kube() *parseStream {
 		return ps
 	}
 
+	if ps.hitRegexp(findWorkloadMatchedPodRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeWorkload,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
 	if ps.hitRegexp(findPodPathRegexp, http.MethodPost) {
 		ps.Attribute.Resources = []meta.ResourceAttribute{
 			{
@@ -2065,5 +2215,606 @@ func (ps *parseStream) kube() *parseStream {
 		return ps
 	}
 
+	if ps.hitRegexp(createKubeServiceRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeService,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(updateKubeServiceRegexp, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeService,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubeServiceRegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeService,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeServiceRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeService,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createKubeIngressRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeIngress,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(updateKubeIngressRegexp, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeIngress,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubeIngressRegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeIngress,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeIngressRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeIngress,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createKubeEndpointRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeEndpoint,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(updateKubeEndpointRegexp, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeEndpoint,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubeEndpointRegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeEndpoint,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeEndpointRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeEndpoint,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createKubePVCRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePersistentVolumeClaim,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(updateKubePVCRegexp, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePersistentVolumeClaim,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubePVCRegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePersistentVolumeClaim,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubePVCRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePersistentVolumeClaim,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubePVCMountedPodRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePersistentVolumeClaim,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createKubePVRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePersistentVolume,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(updateKubePVRegexp, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePersistentVolume,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubePVRegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePersistentVolume,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubePVRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePersistentVolume,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createKubeStorageClassRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeStorageClass,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(updateKubeStorageClassRegexp, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeStorageClass,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubeStorageClassRegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeStorageClass,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeStorageClassRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeStorageClass,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeStorageCapacityRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeNamespace,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeResourceUsageRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeNamespace,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeManifestImportPlanRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeWorkload,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	// importing the manifests may create or update namespaces, create, update or delete workloads, and recreate pods
+	if ps.hitRegexp(importKubeManifestRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeNamespace,
+					Action: meta.Create,
+				},
+			},
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeNamespace,
+					Action: meta.Update,
+				},
+			},
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeWorkload,
+					Action: meta.Create,
+				},
+			},
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeWorkload,
+					Action: meta.Update,
+				},
+			},
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeWorkload,
+					Action: meta.Delete,
+				},
+			},
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePod,
+					Action: meta.Create,
+				},
+			},
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubePod,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createKubeServiceBindingRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeServiceBinding,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubeServiceBindingRegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeServiceBinding,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeServiceBindingRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeServiceBinding,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createKubeHPARegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeHorizontalPodAutoscaler,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(updateKubeHPARegexp, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeHorizontalPodAutoscaler,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubeHPARegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeHorizontalPodAutoscaler,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeHPARegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeHorizontalPodAutoscaler,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeReplicaHistoryRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeWorkload,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createKubeConfigMapRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeConfigMap,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(updateKubeConfigMapRegexp, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeConfigMap,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubeConfigMapRegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeConfigMap,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeConfigMapRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeConfigMap,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeConfigMapConsumerRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeConfigMap,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(createKubeSecretRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeSecret,
+					Action: meta.Create,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(updateKubeSecretRegexp, http.MethodPut) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeSecret,
+					Action: meta.Update,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(deleteKubeSecretRegexp, http.MethodDelete) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeSecret,
+					Action: meta.Delete,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeSecretRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeSecret,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
+	if ps.hitRegexp(findKubeSecretConsumerRegexp, http.MethodPost) {
+		ps.Attribute.Resources = []meta.ResourceAttribute{
+			{
+				Basic: meta.Basic{
+					Type:   meta.KubeSecret,
+					Action: meta.Find,
+				},
+			},
+		}
+		return ps
+	}
+
 	return ps
 }
@@ -0,0 +1,39 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kube
+
+import (
+	"context"
+	"net/http"
+
+	"configcenter/src/apimachinery/rest"
+	"configcenter/src/common/errors"
+	"configcenter/src/kube/types"
+)
+
+// Interface kube topology cache client interface
+type Interface interface {
+	SearchKubeTopoPath(ctx context.Context, h http.Header, bizID int64, opt *types.KubeTopoPathOption) (
+		*types.KubeTopoPathRsp, errors.CCErrorCoder)
+	CountKubeTopoHostsOrPods(ctx context.Context, h http.Header, bizID int64, kind string,
+		opt *types.KubeTopoCountOption) ([]types.KubeTopoCountRsp, errors.CCErrorCoder)
+}
+
+// NewCacheClient new kube topology cache client
+func NewCacheClient(client rest.ClientInterface) Interface {
+	return &kubeCache{client: client}
+}
+
+type kubeCache struct {
+	client rest.ClientInterface
+}
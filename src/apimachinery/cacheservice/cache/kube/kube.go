@@ -0,0 +1,69 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kube
+
+import (
+	"context"
+	"net/http"
+
+	"configcenter/src/common/errors"
+	"configcenter/src/kube/types"
+)
+
+// SearchKubeTopoPath search the next level nodes of the kube topology node in cache
+func (k *kubeCache) SearchKubeTopoPath(ctx context.Context, h http.Header, bizID int64,
+	opt *types.KubeTopoPathOption) (*types.KubeTopoPathRsp, errors.CCErrorCoder) {
+
+	result := new(types.KubeTopoPathResp)
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(opt).
+		SubResourcef("/find/cache/kube/topo_path/bk_biz_id/%d", bizID).
+		WithHeaders(h).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CountKubeTopoHostsOrPods count the number of hosts or pods of the kube topology nodes in cache
+func (k *kubeCache) CountKubeTopoHostsOrPods(ctx context.Context, h http.Header, bizID int64, kind string,
+	opt *types.KubeTopoCountOption) ([]types.KubeTopoCountRsp, errors.CCErrorCoder) {
+
+	result := new(types.KubeTopoCountResp)
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(opt).
+		SubResourcef("/find/cache/kube/topo_node/%s/count/bk_biz_id/%d", kind, bizID).
+		WithHeaders(h).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return result.Data, nil
+}
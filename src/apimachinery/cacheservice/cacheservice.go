@@ -18,6 +18,7 @@ import (
 
 	"configcenter/src/apimachinery/cacheservice/cache/event"
 	"configcenter/src/apimachinery/cacheservice/cache/host"
+	"configcenter/src/apimachinery/cacheservice/cache/kube"
 	"configcenter/src/apimachinery/cacheservice/cache/topology"
 	"configcenter/src/apimachinery/rest"
 	"configcenter/src/apimachinery/util"
@@ -28,6 +29,7 @@ type Cache interface {
 	Host() host.Interface
 	Topology() topology.Interface
 	Event() event.Interface
+	Kube() kube.Interface
 }
 
 // CacheServiceClientInterface TODO
@@ -72,3 +74,8 @@ func (c *cache) Topology() topology.Interface {
 func (c *cache) Event() event.Interface {
 	return event.NewCacheClient(c.restCli)
 }
+
+// Kube returns the kube topology cache client
+func (c *cache) Kube() kube.Interface {
+	return kube.NewCacheClient(c.restCli)
+}
@@ -39,6 +39,28 @@ type CloudInterface interface {
 	CreateSyncHistory(ctx context.Context, h http.Header, history *metadata.SyncHistory) (*metadata.SyncHistory, errors.CCErrorCoder)
 	SearchSyncHistory(ctx context.Context, h http.Header, option *metadata.SearchSyncHistoryOption) (*metadata.MultipleSyncHistory, errors.CCErrorCoder)
 	DeleteDestroyedHostRelated(ctx context.Context, h http.Header, option *metadata.DeleteDestroyedHostRelatedOption) errors.CCErrorCoder
+
+	// cloud area ip address management
+	CreateSubnet(ctx context.Context, h http.Header, subnet *metadata.Subnet) (*metadata.Subnet, errors.CCErrorCoder)
+	SearchSubnet(ctx context.Context, h http.Header, option *metadata.SearchSubnetOption) (*metadata.MultipleSubnet,
+		errors.CCErrorCoder)
+	UpdateSubnet(ctx context.Context, h http.Header, subnetID int64, option *metadata.UpdateSubnetOption) errors.
+		CCErrorCoder
+	DeleteSubnet(ctx context.Context, h http.Header, subnetID int64) errors.CCErrorCoder
+	FindSubnetHosts(ctx context.Context, h http.Header, subnetID int64, option *metadata.FindSubnetHostOption) (
+		*metadata.MultipleSubnetHost, errors.CCErrorCoder)
+	GetSubnetUtilization(ctx context.Context, h http.Header, option *metadata.SubnetUtilizationOption) (
+		[]metadata.SubnetUtilization, errors.CCErrorCoder)
+	SearchSubnetIPReservation(ctx context.Context, h http.Header, subnetID int64) ([]metadata.SubnetIPReservation,
+		errors.CCErrorCoder)
+	ReserveSubnetIP(ctx context.Context, h http.Header, subnetID int64, option *metadata.ReserveSubnetIPOption) (
+		[]metadata.SubnetIPReservation, errors.CCErrorCoder)
+	AllocateSubnetIP(ctx context.Context, h http.Header, subnetID int64, option *metadata.AllocateSubnetIPOption) (
+		[]metadata.SubnetIPReservation, errors.CCErrorCoder)
+	ReleaseSubnetIP(ctx context.Context, h http.Header, subnetID int64, option *metadata.ReserveSubnetIPOption) errors.
+		CCErrorCoder
+	FindIPConflicts(ctx context.Context, h http.Header, option *metadata.FindIPConflictOption) ([]metadata.IPConflict,
+		errors.CCErrorCoder)
 }
 
 // NewCloudInterfaceClient TODO
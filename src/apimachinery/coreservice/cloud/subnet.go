@@ -0,0 +1,234 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+
+	"configcenter/src/common/errors"
+	"configcenter/src/common/metadata"
+)
+
+// CreateSubnet create a subnet in the cloud area
+func (c *cloud) CreateSubnet(ctx context.Context, h http.Header, subnet *metadata.Subnet) (*metadata.Subnet,
+	errors.CCErrorCoder) {
+
+	resp := new(metadata.SubnetResponse)
+	subPath := "/create/cloud/subnet"
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(subnet).
+		SubResourcef(subPath).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// SearchSubnet search the subnets by cloud area ids or subnet ids
+func (c *cloud) SearchSubnet(ctx context.Context, h http.Header, option *metadata.SearchSubnetOption) (
+	*metadata.MultipleSubnet, errors.CCErrorCoder) {
+
+	resp := new(metadata.MultipleSubnetResponse)
+	subPath := "/findmany/cloud/subnet"
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// UpdateSubnet update the vlan, gateway or description of the subnet
+func (c *cloud) UpdateSubnet(ctx context.Context, h http.Header, subnetID int64,
+	option *metadata.UpdateSubnetOption) errors.CCErrorCoder {
+
+	resp := new(metadata.BaseResp)
+	subPath := "/update/cloud/subnet/%d"
+
+	err := c.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath, subnetID).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return errors.CCHttpError
+	}
+	return resp.CCError()
+}
+
+// DeleteSubnet delete the subnet and its ip reservations
+func (c *cloud) DeleteSubnet(ctx context.Context, h http.Header, subnetID int64) errors.CCErrorCoder {
+	resp := new(metadata.BaseResp)
+	subPath := "/delete/cloud/subnet/%d"
+
+	err := c.client.Delete().
+		WithContext(ctx).
+		SubResourcef(subPath, subnetID).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return errors.CCHttpError
+	}
+	return resp.CCError()
+}
+
+// FindSubnetHosts find the hosts whose inner ips are in the subnet
+func (c *cloud) FindSubnetHosts(ctx context.Context, h http.Header, subnetID int64,
+	option *metadata.FindSubnetHostOption) (*metadata.MultipleSubnetHost, errors.CCErrorCoder) {
+
+	resp := new(metadata.MultipleSubnetHostResponse)
+	subPath := "/findmany/cloud/subnet/%d/hosts"
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath, subnetID).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// GetSubnetUtilization get the ip utilization of the subnets in a cloud area
+func (c *cloud) GetSubnetUtilization(ctx context.Context, h http.Header, option *metadata.SubnetUtilizationOption) (
+	[]metadata.SubnetUtilization, errors.CCErrorCoder) {
+
+	resp := new(metadata.SubnetUtilizationResponse)
+	subPath := "/findmany/cloud/subnet/utilization"
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// SearchSubnetIPReservation search the reserved ips of the subnet
+func (c *cloud) SearchSubnetIPReservation(ctx context.Context, h http.Header, subnetID int64) (
+	[]metadata.SubnetIPReservation, errors.CCErrorCoder) {
+
+	return c.subnetIPReservation(ctx, h, "/findmany/cloud/subnet/%d/ip/reservation", subnetID, nil)
+}
+
+// ReserveSubnetIP reserve the specified ips of the subnet
+func (c *cloud) ReserveSubnetIP(ctx context.Context, h http.Header, subnetID int64,
+	option *metadata.ReserveSubnetIPOption) ([]metadata.SubnetIPReservation, errors.CCErrorCoder) {
+
+	return c.subnetIPReservation(ctx, h, "/createmany/cloud/subnet/%d/ip/reservation", subnetID, option)
+}
+
+// AllocateSubnetIP allocate and reserve the next free ips of the subnet
+func (c *cloud) AllocateSubnetIP(ctx context.Context, h http.Header, subnetID int64,
+	option *metadata.AllocateSubnetIPOption) ([]metadata.SubnetIPReservation, errors.CCErrorCoder) {
+
+	return c.subnetIPReservation(ctx, h, "/createmany/cloud/subnet/%d/ip/allocation", subnetID, option)
+}
+
+func (c *cloud) subnetIPReservation(ctx context.Context, h http.Header, subPath string, subnetID int64,
+	option interface{}) ([]metadata.SubnetIPReservation, errors.CCErrorCoder) {
+
+	resp := new(metadata.SubnetIPReservationResponse)
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath, subnetID).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// ReleaseSubnetIP release the reserved ips of the subnet
+func (c *cloud) ReleaseSubnetIP(ctx context.Context, h http.Header, subnetID int64,
+	option *metadata.ReserveSubnetIPOption) errors.CCErrorCoder {
+
+	resp := new(metadata.BaseResp)
+	subPath := "/deletemany/cloud/subnet/%d/ip/reservation"
+
+	err := c.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath, subnetID).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return errors.CCHttpError
+	}
+	return resp.CCError()
+}
+
+// FindIPConflicts find the ips that are claimed by more than one host in the same cloud area
+func (c *cloud) FindIPConflicts(ctx context.Context, h http.Header, option *metadata.FindIPConflictOption) (
+	[]metadata.IPConflict, errors.CCErrorCoder) {
+
+	resp := new(metadata.IPConflictResponse)
+	subPath := "/findmany/cloud/ip/conflict"
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
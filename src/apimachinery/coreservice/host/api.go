@@ -620,3 +620,309 @@ func (h *host) GetDistinctHostIDByTopology(ctx context.Context, header http.Head
 	}
 	return resp.Data.IDArr, nil
 }
+
+// SearchHostSnapHistory search the downsampled snapshot history of a host
+func (h *host) SearchHostSnapHistory(ctx context.Context, header http.Header,
+	option *metadata.SearchHostSnapHistoryOption) (*metadata.HostSnapHistoryResult, errors.CCErrorCoder) {
+
+	resp := new(metadata.HostSnapHistoryResponse)
+	subPath := "/findmany/host/snapshot/history"
+
+	err := h.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// SearchHostSnapChangeLog search the hardware and os field change logs of a host reported by snapshot
+func (h *host) SearchHostSnapChangeLog(ctx context.Context, header http.Header,
+	option *metadata.SearchHostSnapChangeLogOption) (*metadata.HostSnapChangeLogResult, errors.CCErrorCoder) {
+
+	resp := new(metadata.HostSnapChangeLogResponse)
+	subPath := "/findmany/host/snapshot/change_log"
+
+	err := h.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// SearchHostSnapDrift search the drifts between the snapshot and the host in cmdb of the specified hosts
+func (h *host) SearchHostSnapDrift(ctx context.Context, header http.Header,
+	option *metadata.SearchHostSnapDriftOption) (*metadata.HostSnapDriftResult, errors.CCErrorCoder) {
+
+	resp := new(metadata.HostSnapDriftResponse)
+	subPath := "/findmany/host/snapshot/drift"
+
+	err := h.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// SearchHostSnapStatus search the last snapshot time and the stale flag of the specified hosts
+func (h *host) SearchHostSnapStatus(ctx context.Context, header http.Header,
+	option *metadata.SearchHostSnapStatusOption) (*metadata.HostSnapStatusResult, errors.CCErrorCoder) {
+
+	resp := new(metadata.HostSnapStatusResponse)
+	subPath := "/findmany/host/snapshot/status"
+
+	err := h.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// SaveStaleHostPolicy save the stale host policy of the supplier account
+func (h *host) SaveStaleHostPolicy(ctx context.Context, header http.Header,
+	policy *metadata.StaleHostPolicy) errors.CCErrorCoder {
+
+	resp := new(metadata.BaseResp)
+	subPath := "/update/host/stale_policy"
+
+	err := h.client.Put().
+		WithContext(ctx).
+		Body(policy).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetStaleHostPolicy get the stale host policy of the supplier account
+func (h *host) GetStaleHostPolicy(ctx context.Context, header http.Header) (*metadata.StaleHostPolicy,
+	errors.CCErrorCoder) {
+
+	resp := new(metadata.StaleHostPolicyResponse)
+	subPath := "/find/host/stale_policy"
+
+	err := h.client.Post().
+		WithContext(ctx).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// SaveHostLifecycle save the host lifecycle of the supplier account
+func (h *host) SaveHostLifecycle(ctx context.Context, header http.Header,
+	lifecycle *metadata.HostLifecycle) errors.CCErrorCoder {
+
+	resp := new(metadata.BaseResp)
+	subPath := "/update/host/lifecycle"
+
+	err := h.client.Put().
+		WithContext(ctx).
+		Body(lifecycle).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetHostLifecycle get the host lifecycle of the supplier account
+func (h *host) GetHostLifecycle(ctx context.Context, header http.Header) (*metadata.HostLifecycle,
+	errors.CCErrorCoder) {
+
+	resp := new(metadata.HostLifecycleResponse)
+	subPath := "/find/host/lifecycle"
+
+	err := h.client.Post().
+		WithContext(ctx).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// FindDuplicateHosts find the hosts that share the same value of the matching fields
+func (h *host) FindDuplicateHosts(ctx context.Context, header http.Header,
+	option *metadata.FindDuplicateHostOption) ([]metadata.DuplicateHostGroup, errors.CCErrorCoder) {
+
+	resp := new(metadata.DuplicateHostResponse)
+	subPath := "/findmany/host/duplicate"
+
+	err := h.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// PreviewMergeHost preview the data that would be moved from the duplicate hosts to the kept host
+func (h *host) PreviewMergeHost(ctx context.Context, header http.Header, option *metadata.MergeHostOption) (
+	*metadata.MergeHostResult, errors.CCErrorCoder) {
+	return h.mergeHost(ctx, header, "/find/host/merge/preview", option)
+}
+
+// MergeHost merge the duplicate hosts into the kept host
+func (h *host) MergeHost(ctx context.Context, header http.Header, option *metadata.MergeHostOption) (
+	*metadata.MergeHostResult, errors.CCErrorCoder) {
+	return h.mergeHost(ctx, header, "/update/host/merge", option)
+}
+
+func (h *host) mergeHost(ctx context.Context, header http.Header, subPath string,
+	option *metadata.MergeHostOption) (*metadata.MergeHostResult, errors.CCErrorCoder) {
+
+	resp := new(metadata.MergeHostResponse)
+	err := h.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateHostTransferSchedule create a waiting host transfer schedule
+func (h *host) CreateHostTransferSchedule(ctx context.Context, header http.Header,
+	schedule *metadata.HostTransferSchedule) (*metadata.HostTransferSchedule, errors.CCErrorCoder) {
+
+	resp := new(metadata.HostTransferScheduleResponse)
+	subPath := "/create/host/transfer_schedule"
+
+	err := h.client.Post().
+		WithContext(ctx).
+		Body(schedule).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// ListHostTransferSchedule list the host transfer schedules
+func (h *host) ListHostTransferSchedule(ctx context.Context, header http.Header,
+	option *metadata.ListHostTransferScheduleOption) (*metadata.MultipleHostTransferSchedule, errors.CCErrorCoder) {
+
+	resp := new(metadata.MultipleHostTransferScheduleResponse)
+	subPath := "/findmany/host/transfer_schedule"
+
+	err := h.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// UpdateHostTransferScheduleStatus change the status of the host transfer schedule
+func (h *host) UpdateHostTransferScheduleStatus(ctx context.Context, header http.Header,
+	option *metadata.UpdateHostTransferScheduleStatusOption) errors.CCErrorCoder {
+
+	resp := new(metadata.BaseResp)
+	subPath := "/update/host/transfer_schedule/status"
+
+	err := h.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+	if err != nil {
+		return errors.CCHttpError
+	}
+	if err := resp.CCError(); err != nil {
+		return err
+	}
+	return nil
+}
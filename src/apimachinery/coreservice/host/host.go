@@ -102,6 +102,45 @@ type HostClientInterface interface {
 
 	TransferHostResourceDirectory(ctx context.Context, header http.Header,
 		option *metadata.TransferHostResourceDirectory) errors.CCErrorCoder
+
+	// SearchHostSnapHistory search the downsampled snapshot history of a host
+	SearchHostSnapHistory(ctx context.Context, header http.Header, option *metadata.SearchHostSnapHistoryOption) (
+		*metadata.HostSnapHistoryResult, errors.CCErrorCoder)
+	// SearchHostSnapChangeLog search the hardware and os field change logs of a host reported by snapshot
+	SearchHostSnapChangeLog(ctx context.Context, header http.Header, option *metadata.SearchHostSnapChangeLogOption) (
+		*metadata.HostSnapChangeLogResult, errors.CCErrorCoder)
+	// SearchHostSnapDrift search the drifts between the snapshot and the host in cmdb of the specified hosts
+	SearchHostSnapDrift(ctx context.Context, header http.Header, option *metadata.SearchHostSnapDriftOption) (
+		*metadata.HostSnapDriftResult, errors.CCErrorCoder)
+	// SearchHostSnapStatus search the last snapshot time and the stale flag of the specified hosts
+	SearchHostSnapStatus(ctx context.Context, header http.Header, option *metadata.SearchHostSnapStatusOption) (
+		*metadata.HostSnapStatusResult, errors.CCErrorCoder)
+	// SaveStaleHostPolicy save the stale host policy of the supplier account
+	SaveStaleHostPolicy(ctx context.Context, header http.Header, policy *metadata.StaleHostPolicy) errors.CCErrorCoder
+	// GetStaleHostPolicy get the stale host policy of the supplier account
+	GetStaleHostPolicy(ctx context.Context, header http.Header) (*metadata.StaleHostPolicy, errors.CCErrorCoder)
+	// SaveHostLifecycle save the host lifecycle of the supplier account
+	SaveHostLifecycle(ctx context.Context, header http.Header, lifecycle *metadata.HostLifecycle) errors.CCErrorCoder
+	// GetHostLifecycle get the host lifecycle of the supplier account
+	GetHostLifecycle(ctx context.Context, header http.Header) (*metadata.HostLifecycle, errors.CCErrorCoder)
+	// CreateHostTransferSchedule create a waiting host transfer schedule
+	CreateHostTransferSchedule(ctx context.Context, header http.Header, schedule *metadata.HostTransferSchedule) (
+		*metadata.HostTransferSchedule, errors.CCErrorCoder)
+	// ListHostTransferSchedule list the host transfer schedules
+	ListHostTransferSchedule(ctx context.Context, header http.Header, option *metadata.ListHostTransferScheduleOption) (
+		*metadata.MultipleHostTransferSchedule, errors.CCErrorCoder)
+	// UpdateHostTransferScheduleStatus change the status of the host transfer schedule
+	UpdateHostTransferScheduleStatus(ctx context.Context, header http.Header,
+		option *metadata.UpdateHostTransferScheduleStatusOption) errors.CCErrorCoder
+	// FindDuplicateHosts find the hosts that share the same value of the matching fields
+	FindDuplicateHosts(ctx context.Context, header http.Header, option *metadata.FindDuplicateHostOption) (
+		[]metadata.DuplicateHostGroup, errors.CCErrorCoder)
+	// PreviewMergeHost preview the data that would be moved from the duplicate hosts to the kept host
+	PreviewMergeHost(ctx context.Context, header http.Header, option *metadata.MergeHostOption) (
+		*metadata.MergeHostResult, errors.CCErrorCoder)
+	// MergeHost merge the duplicate hosts into the kept host
+	MergeHost(ctx context.Context, header http.Header, option *metadata.MergeHostOption) (
+		*metadata.MergeHostResult, errors.CCErrorCoder)
 }
 
 // NewHostClientInterface TODO
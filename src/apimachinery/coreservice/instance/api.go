@@ -232,6 +232,32 @@ func (inst *instance) CountInstances(ctx context.Context, header http.Header, ob
 	return &resp.Data, nil
 }
 
+// AggregateInstances groups model instances and calculates the metrics of each group.
+func (inst *instance) AggregateInstances(ctx context.Context, header http.Header, objID string,
+	input *metadata.InstAggregateOption) (*metadata.InstAggregateResult, errors.CCErrorCoder) {
+
+	resp := new(metadata.InstAggregateResp)
+	subPath := "/aggregate/model/%s/instances"
+
+	err := inst.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef(subPath, objID).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if err := resp.CCError(); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
 // GetInstanceObjectMapping get instance to bk_obj_id mapping by instance ids
 func (inst *instance) GetInstanceObjectMapping(ctx context.Context, header http.Header, ids []int64) (
 	[]metadata.ObjectMapping, errors.CCErrorCoder) {
@@ -45,6 +45,9 @@ type InstanceClientInterface interface {
 	// CountInstances counts model instances num.
 	CountInstances(ctx context.Context, header http.Header, objID string, input *metadata.Condition) (
 		*metadata.CountResponseContent, error)
+	// AggregateInstances groups model instances and calculates the metrics of each group.
+	AggregateInstances(ctx context.Context, header http.Header, objID string, input *metadata.InstAggregateOption) (
+		*metadata.InstAggregateResult, errors.CCErrorCoder)
 	GetInstanceObjectMapping(ctx context.Context, h http.Header, ids []int64) ([]metadata.ObjectMapping,
 		errors.CCErrorCoder)
 }
@@ -307,6 +307,30 @@ func (k *kube) DeletePods(ctx context.Context, h http.Header, opt *types.DeleteP
 	return nil
 }
 
+// UpdatePods update pods by ids, the containers of the pods are replaced
+func (k *kube) UpdatePods(ctx context.Context, h http.Header, opt *types.UpdatePodsOption) errors.CCErrorCoder {
+	resp := new(metadata.Response)
+	subPath := "/updatemany/kube/pod"
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(opt).
+		SubResourcef(subPath).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if err := resp.CCError(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // BatchCreateNode batch create nodes
 func (k *kube) BatchCreateNode(ctx context.Context, header http.Header, bizID int64,
 	data *types.CreateNodesOption) (*types.CreateNodesResult, errors.CCErrorCoder) {
@@ -515,3 +539,1080 @@ func (k *kube) BatchDeleteNode(ctx context.Context, header http.Header, bizID in
 
 	return nil
 }
+
+// SaveClusterSyncStatus create or update the sync status of a cluster
+func (k *kube) SaveClusterSyncStatus(ctx context.Context, header http.Header,
+	status *types.ClusterSyncStatus) errors.CCErrorCoder {
+
+	resp := new(metadata.Response)
+	subPath := "/update/kube/cluster/sync_status"
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(status).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(resp)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if err := resp.CCError(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListClusterSyncStatus list the sync status of clusters
+func (k *kube) ListClusterSyncStatus(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.ClusterSyncStatusData, errors.CCErrorCoder) {
+
+	result := new(types.ClusterSyncStatusResp)
+	subPath := "/findmany/kube/cluster/sync_status"
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateService create service
+func (k *kube) CreateService(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.ServiceCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/service/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateService update service
+func (k *kube) UpdateService(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/service/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteService delete service
+func (k *kube) DeleteService(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/service/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListService list service
+func (k *kube) ListService(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.ServiceDataResp, errors.CCErrorCoder) {
+
+	result := new(types.ServiceInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/service").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateIngress create ingress
+func (k *kube) CreateIngress(ctx context.Context, header http.Header, bizID int64,
+	option *types.IngressCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.IngressCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/ingress/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateIngress update ingress
+func (k *kube) UpdateIngress(ctx context.Context, header http.Header, bizID int64,
+	option *types.IngressUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/ingress/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteIngress delete ingress
+func (k *kube) DeleteIngress(ctx context.Context, header http.Header, bizID int64,
+	option *types.IngressDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/ingress/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListIngress list ingress
+func (k *kube) ListIngress(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.IngressDataResp, errors.CCErrorCoder) {
+
+	result := new(types.IngressInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/ingress").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateEndpoint create endpoint
+func (k *kube) CreateEndpoint(ctx context.Context, header http.Header, bizID int64,
+	option *types.EndpointCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.EndpointCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/endpoint/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateEndpoint update endpoint
+func (k *kube) UpdateEndpoint(ctx context.Context, header http.Header, bizID int64,
+	option *types.EndpointUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/endpoint/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteEndpoint delete endpoint
+func (k *kube) DeleteEndpoint(ctx context.Context, header http.Header, bizID int64,
+	option *types.EndpointDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/endpoint/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListEndpoint list endpoint
+func (k *kube) ListEndpoint(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.EndpointDataResp, errors.CCErrorCoder) {
+
+	result := new(types.EndpointInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/endpoint").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreatePersistentVolumeClaim create persistent volume claim
+func (k *kube) CreatePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeClaimCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.PersistentVolumeClaimCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/pvc/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdatePersistentVolumeClaim update persistent volume claim
+func (k *kube) UpdatePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeClaimUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/pvc/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeletePersistentVolumeClaim delete persistent volume claim
+func (k *kube) DeletePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeClaimDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/pvc/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListPersistentVolumeClaim list persistent volume claim
+func (k *kube) ListPersistentVolumeClaim(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.PersistentVolumeClaimDataResp, errors.CCErrorCoder) {
+
+	result := new(types.PersistentVolumeClaimInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/pvc").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreatePersistentVolume create persistent volume
+func (k *kube) CreatePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.PersistentVolumeCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/pv/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdatePersistentVolume update persistent volume
+func (k *kube) UpdatePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/pv/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeletePersistentVolume delete persistent volume
+func (k *kube) DeletePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/pv/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListPersistentVolume list persistent volume
+func (k *kube) ListPersistentVolume(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.PersistentVolumeDataResp, errors.CCErrorCoder) {
+
+	result := new(types.PersistentVolumeInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/pv").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateStorageClass create storage class
+func (k *kube) CreateStorageClass(ctx context.Context, header http.Header, bizID int64,
+	option *types.StorageClassCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.StorageClassCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/storage_class/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateStorageClass update storage class
+func (k *kube) UpdateStorageClass(ctx context.Context, header http.Header, bizID int64,
+	option *types.StorageClassUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/storage_class/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteStorageClass delete storage class
+func (k *kube) DeleteStorageClass(ctx context.Context, header http.Header, bizID int64,
+	option *types.StorageClassDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/storage_class/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListStorageClass list storage class
+func (k *kube) ListStorageClass(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.StorageClassDataResp, errors.CCErrorCoder) {
+
+	result := new(types.StorageClassInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/storage_class").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// AggregateStorageCapacity aggregate the storage capacity by namespace or cluster
+func (k *kube) AggregateStorageCapacity(ctx context.Context, header http.Header, bizID int64,
+	option *types.StorageCapacityOption) ([]types.StorageCapacity, errors.CCErrorCoder) {
+
+	result := new(types.StorageCapacityResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/find/storage_capacity/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return result.Data, nil
+}
+
+// CreateServiceBinding bind workloads to the service template of a module
+func (k *kube) CreateServiceBinding(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceBindingCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.ServiceBindingCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/service_binding/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// DeleteServiceBinding delete workload service bindings
+func (k *kube) DeleteServiceBinding(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceBindingDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/service_binding/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListServiceBinding list workload service bindings
+func (k *kube) ListServiceBinding(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.ServiceBindingDataResp, errors.CCErrorCoder) {
+
+	result := new(types.ServiceBindingInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/service_binding").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateHorizontalPodAutoscaler create horizontal pod autoscaler
+func (k *kube) CreateHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+	option *types.HorizontalPodAutoscalerCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.HorizontalPodAutoscalerCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/hpa/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateHorizontalPodAutoscaler update horizontal pod autoscaler
+func (k *kube) UpdateHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+	option *types.HorizontalPodAutoscalerUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/hpa/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteHorizontalPodAutoscaler delete horizontal pod autoscaler
+func (k *kube) DeleteHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+	option *types.HorizontalPodAutoscalerDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/hpa/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListHorizontalPodAutoscaler list horizontal pod autoscaler
+func (k *kube) ListHorizontalPodAutoscaler(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.HorizontalPodAutoscalerDataResp, errors.CCErrorCoder) {
+
+	result := new(types.HorizontalPodAutoscalerInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/hpa").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// ListReplicaHistory list workload replica history
+func (k *kube) ListReplicaHistory(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.ReplicaHistoryDataResp, errors.CCErrorCoder) {
+
+	result := new(types.ReplicaHistoryInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/replica_history").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateConfigMap create config map
+func (k *kube) CreateConfigMap(ctx context.Context, header http.Header, bizID int64,
+	option *types.ConfigMapCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.ConfigMapCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/config_map/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateConfigMap update config map
+func (k *kube) UpdateConfigMap(ctx context.Context, header http.Header, bizID int64,
+	option *types.ConfigMapUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/config_map/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteConfigMap delete config map
+func (k *kube) DeleteConfigMap(ctx context.Context, header http.Header, bizID int64,
+	option *types.ConfigMapDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/config_map/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListConfigMap list config map
+func (k *kube) ListConfigMap(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.ConfigMapDataResp, errors.CCErrorCoder) {
+
+	result := new(types.ConfigMapInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/config_map").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateSecret create secret
+func (k *kube) CreateSecret(ctx context.Context, header http.Header, bizID int64,
+	option *types.SecretCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.SecretCreateResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/secret/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateSecret update secret
+func (k *kube) UpdateSecret(ctx context.Context, header http.Header, bizID int64,
+	option *types.SecretUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/secret/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteSecret delete secret
+func (k *kube) DeleteSecret(ctx context.Context, header http.Header, bizID int64,
+	option *types.SecretDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := k.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/secret/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListSecret list secret
+func (k *kube) ListSecret(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+	*types.SecretDataResp, errors.CCErrorCoder) {
+
+	result := new(types.SecretInstResp)
+
+	err := k.client.Post().
+		WithContext(ctx).
+		Body(input).
+		SubResourcef("/findmany/secret").
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
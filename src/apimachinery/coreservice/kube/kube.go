@@ -73,6 +73,9 @@ type KubeClientInterface interface {
 	// DeletePods delete pods
 	DeletePods(ctx context.Context, h http.Header, opt *types.DeletePodsByIDsOption) errors.CCErrorCoder
 
+	// UpdatePods update pods by ids, the containers of the pods are replaced
+	UpdatePods(ctx context.Context, h http.Header, opt *types.UpdatePodsOption) errors.CCErrorCoder
+
 	CreateCluster(ctx context.Context, h http.Header, bizID int64, option *types.Cluster) (
 		*types.CreateClusterResult, errors.CCErrorCoder)
 	UpdateClusterFields(ctx context.Context, header http.Header, bizID int64,
@@ -91,6 +94,177 @@ type KubeClientInterface interface {
 		[]types.Pod, errors.CCErrorCoder)
 	SearchNode(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
 		*types.SearchNodeRsp, errors.CCErrorCoder)
+
+	// SaveClusterSyncStatus create or update the sync status of a cluster
+	SaveClusterSyncStatus(ctx context.Context, header http.Header, status *types.ClusterSyncStatus) errors.CCErrorCoder
+
+	// ListClusterSyncStatus list the sync status of clusters
+	ListClusterSyncStatus(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+		*types.ClusterSyncStatusData, errors.CCErrorCoder)
+
+	// CreateService create service
+	CreateService(ctx context.Context, header http.Header, bizID int64, option *types.ServiceCreateOption) (
+		*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateService update service
+	UpdateService(ctx context.Context, header http.Header, bizID int64,
+		option *types.ServiceUpdateOption) errors.CCErrorCoder
+
+	// DeleteService delete service
+	DeleteService(ctx context.Context, header http.Header, bizID int64,
+		option *types.ServiceDeleteOption) errors.CCErrorCoder
+
+	// ListService list service
+	ListService(ctx context.Context, header http.Header, input *metadata.QueryCondition) (*types.ServiceDataResp,
+		errors.CCErrorCoder)
+
+	// CreateIngress create ingress
+	CreateIngress(ctx context.Context, header http.Header, bizID int64, option *types.IngressCreateOption) (
+		*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateIngress update ingress
+	UpdateIngress(ctx context.Context, header http.Header, bizID int64,
+		option *types.IngressUpdateOption) errors.CCErrorCoder
+
+	// DeleteIngress delete ingress
+	DeleteIngress(ctx context.Context, header http.Header, bizID int64,
+		option *types.IngressDeleteOption) errors.CCErrorCoder
+
+	// ListIngress list ingress
+	ListIngress(ctx context.Context, header http.Header, input *metadata.QueryCondition) (*types.IngressDataResp,
+		errors.CCErrorCoder)
+
+	// CreateEndpoint create endpoint
+	CreateEndpoint(ctx context.Context, header http.Header, bizID int64, option *types.EndpointCreateOption) (
+		*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateEndpoint update endpoint
+	UpdateEndpoint(ctx context.Context, header http.Header, bizID int64,
+		option *types.EndpointUpdateOption) errors.CCErrorCoder
+
+	// DeleteEndpoint delete endpoint
+	DeleteEndpoint(ctx context.Context, header http.Header, bizID int64,
+		option *types.EndpointDeleteOption) errors.CCErrorCoder
+
+	// ListEndpoint list endpoint
+	ListEndpoint(ctx context.Context, header http.Header, input *metadata.QueryCondition) (*types.EndpointDataResp,
+		errors.CCErrorCoder)
+
+	// CreatePersistentVolumeClaim create persistent volume claim
+	CreatePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeClaimCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdatePersistentVolumeClaim update persistent volume claim
+	UpdatePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeClaimUpdateOption) errors.CCErrorCoder
+
+	// DeletePersistentVolumeClaim delete persistent volume claim
+	DeletePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeClaimDeleteOption) errors.CCErrorCoder
+
+	// ListPersistentVolumeClaim list persistent volume claim
+	ListPersistentVolumeClaim(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+		*types.PersistentVolumeClaimDataResp, errors.CCErrorCoder)
+
+	// CreatePersistentVolume create persistent volume
+	CreatePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdatePersistentVolume update persistent volume
+	UpdatePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeUpdateOption) errors.CCErrorCoder
+
+	// DeletePersistentVolume delete persistent volume
+	DeletePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeDeleteOption) errors.CCErrorCoder
+
+	// ListPersistentVolume list persistent volume
+	ListPersistentVolume(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+		*types.PersistentVolumeDataResp, errors.CCErrorCoder)
+
+	// CreateStorageClass create storage class
+	CreateStorageClass(ctx context.Context, header http.Header, bizID int64, option *types.StorageClassCreateOption) (
+		*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateStorageClass update storage class
+	UpdateStorageClass(ctx context.Context, header http.Header, bizID int64,
+		option *types.StorageClassUpdateOption) errors.CCErrorCoder
+
+	// DeleteStorageClass delete storage class
+	DeleteStorageClass(ctx context.Context, header http.Header, bizID int64,
+		option *types.StorageClassDeleteOption) errors.CCErrorCoder
+
+	// ListStorageClass list storage class
+	ListStorageClass(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+		*types.StorageClassDataResp, errors.CCErrorCoder)
+
+	// AggregateStorageCapacity aggregate the storage capacity by namespace or cluster
+	AggregateStorageCapacity(ctx context.Context, header http.Header, bizID int64,
+		option *types.StorageCapacityOption) ([]types.StorageCapacity, errors.CCErrorCoder)
+
+	// CreateServiceBinding bind workloads to the service template of a module
+	CreateServiceBinding(ctx context.Context, header http.Header, bizID int64,
+		option *types.ServiceBindingCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// DeleteServiceBinding delete workload service bindings
+	DeleteServiceBinding(ctx context.Context, header http.Header, bizID int64,
+		option *types.ServiceBindingDeleteOption) errors.CCErrorCoder
+
+	// ListServiceBinding list workload service bindings
+	ListServiceBinding(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+		*types.ServiceBindingDataResp, errors.CCErrorCoder)
+
+	// CreateHorizontalPodAutoscaler create horizontal pod autoscaler
+	CreateHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+		option *types.HorizontalPodAutoscalerCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateHorizontalPodAutoscaler update horizontal pod autoscaler
+	UpdateHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+		option *types.HorizontalPodAutoscalerUpdateOption) errors.CCErrorCoder
+
+	// DeleteHorizontalPodAutoscaler delete horizontal pod autoscaler
+	DeleteHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+		option *types.HorizontalPodAutoscalerDeleteOption) errors.CCErrorCoder
+
+	// ListHorizontalPodAutoscaler list horizontal pod autoscaler
+	ListHorizontalPodAutoscaler(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+		*types.HorizontalPodAutoscalerDataResp, errors.CCErrorCoder)
+
+	// ListReplicaHistory list workload replica history
+	ListReplicaHistory(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+		*types.ReplicaHistoryDataResp, errors.CCErrorCoder)
+
+	// CreateConfigMap create config map
+	CreateConfigMap(ctx context.Context, header http.Header, bizID int64,
+		option *types.ConfigMapCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateConfigMap update config map
+	UpdateConfigMap(ctx context.Context, header http.Header, bizID int64,
+		option *types.ConfigMapUpdateOption) errors.CCErrorCoder
+
+	// DeleteConfigMap delete config map
+	DeleteConfigMap(ctx context.Context, header http.Header, bizID int64,
+		option *types.ConfigMapDeleteOption) errors.CCErrorCoder
+
+	// ListConfigMap list config map
+	ListConfigMap(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+		*types.ConfigMapDataResp, errors.CCErrorCoder)
+
+	// CreateSecret create secret
+	CreateSecret(ctx context.Context, header http.Header, bizID int64,
+		option *types.SecretCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateSecret update secret
+	UpdateSecret(ctx context.Context, header http.Header, bizID int64,
+		option *types.SecretUpdateOption) errors.CCErrorCoder
+
+	// DeleteSecret delete secret
+	DeleteSecret(ctx context.Context, header http.Header, bizID int64,
+		option *types.SecretDeleteOption) errors.CCErrorCoder
+
+	// ListSecret list secret
+	ListSecret(ctx context.Context, header http.Header, input *metadata.QueryCondition) (
+		*types.SecretDataResp, errors.CCErrorCoder)
 }
 
 // NewKubeClientInterface new kube client interface
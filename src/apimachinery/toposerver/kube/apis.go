@@ -77,12 +77,19 @@ type KubeOperationInterface interface {
 	ListWorkload(ctx context.Context, header http.Header, bizID int64, kind types.WorkloadType,
 		option *types.WlQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder)
 
+	// FindWorkloadMatchedPods find the pods that the label selectors of the workloads match
+	FindWorkloadMatchedPods(ctx context.Context, header http.Header, bizID int64, kind types.WorkloadType,
+		option *types.WlMatchedPodsOption) ([]types.WlMatchedPods, errors.CCErrorCoder)
+
 	// ListPod list pod
 	ListPod(ctx context.Context, header http.Header, bizID int64, option *types.PodQueryOption) (
 		*metadata.InstDataInfo, errors.CCErrorCoder)
 
 	BatchCreatePod(ctx context.Context, header http.Header, data *types.CreatePodsOption) ([]int64, errors.CCErrorCoder)
 
+	// BatchDeletePod batch delete pods
+	BatchDeletePod(ctx context.Context, header http.Header, data *types.DeletePodsOption) errors.CCErrorCoder
+
 	// ListContainer list container
 	ListContainer(ctx context.Context, header http.Header, bizID int64, option *types.ContainerQueryOption) (
 		*metadata.InstDataInfo, errors.CCErrorCoder)
@@ -94,6 +101,178 @@ type KubeOperationInterface interface {
 	// FindPodPath find pod path
 	FindPodPath(ctx context.Context, header http.Header, bizID int64, option *types.PodPathOption) (*types.PodPathData,
 		errors.CCErrorCoder)
+
+	// CreateService create service
+	CreateService(ctx context.Context, header http.Header, bizID int64, option *types.ServiceCreateOption) (
+		*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateService update service
+	UpdateService(ctx context.Context, header http.Header, bizID int64,
+		option *types.ServiceUpdateOption) errors.CCErrorCoder
+
+	// DeleteService delete service
+	DeleteService(ctx context.Context, header http.Header, bizID int64,
+		option *types.ServiceDeleteOption) errors.CCErrorCoder
+
+	// ListService list service
+	ListService(ctx context.Context, header http.Header, bizID int64, option *types.ServiceQueryOption) (
+		*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// CreateIngress create ingress
+	CreateIngress(ctx context.Context, header http.Header, bizID int64, option *types.IngressCreateOption) (
+		*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateIngress update ingress
+	UpdateIngress(ctx context.Context, header http.Header, bizID int64,
+		option *types.IngressUpdateOption) errors.CCErrorCoder
+
+	// DeleteIngress delete ingress
+	DeleteIngress(ctx context.Context, header http.Header, bizID int64,
+		option *types.IngressDeleteOption) errors.CCErrorCoder
+
+	// ListIngress list ingress
+	ListIngress(ctx context.Context, header http.Header, bizID int64, option *types.IngressQueryOption) (
+		*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// CreateEndpoint create endpoint
+	CreateEndpoint(ctx context.Context, header http.Header, bizID int64, option *types.EndpointCreateOption) (
+		*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateEndpoint update endpoint
+	UpdateEndpoint(ctx context.Context, header http.Header, bizID int64,
+		option *types.EndpointUpdateOption) errors.CCErrorCoder
+
+	// DeleteEndpoint delete endpoint
+	DeleteEndpoint(ctx context.Context, header http.Header, bizID int64,
+		option *types.EndpointDeleteOption) errors.CCErrorCoder
+
+	// ListEndpoint list endpoint
+	ListEndpoint(ctx context.Context, header http.Header, bizID int64, option *types.EndpointQueryOption) (
+		*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// CreatePersistentVolumeClaim create persistent volume claim
+	CreatePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeClaimCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdatePersistentVolumeClaim update persistent volume claim
+	UpdatePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeClaimUpdateOption) errors.CCErrorCoder
+
+	// DeletePersistentVolumeClaim delete persistent volume claim
+	DeletePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeClaimDeleteOption) errors.CCErrorCoder
+
+	// ListPersistentVolumeClaim list persistent volume claim
+	ListPersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeClaimQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// CreatePersistentVolume create persistent volume
+	CreatePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdatePersistentVolume update persistent volume
+	UpdatePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeUpdateOption) errors.CCErrorCoder
+
+	// DeletePersistentVolume delete persistent volume
+	DeletePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+		option *types.PersistentVolumeDeleteOption) errors.CCErrorCoder
+
+	// ListPersistentVolume list persistent volume
+	ListPersistentVolume(ctx context.Context, header http.Header, bizID int64, option *types.PersistentVolumeQueryOption) (
+		*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// CreateStorageClass create storage class
+	CreateStorageClass(ctx context.Context, header http.Header, bizID int64, option *types.StorageClassCreateOption) (
+		*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateStorageClass update storage class
+	UpdateStorageClass(ctx context.Context, header http.Header, bizID int64,
+		option *types.StorageClassUpdateOption) errors.CCErrorCoder
+
+	// DeleteStorageClass delete storage class
+	DeleteStorageClass(ctx context.Context, header http.Header, bizID int64,
+		option *types.StorageClassDeleteOption) errors.CCErrorCoder
+
+	// ListStorageClass list storage class
+	ListStorageClass(ctx context.Context, header http.Header, bizID int64, option *types.StorageClassQueryOption) (
+		*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// AggregateResourceUsage aggregate the cpu and memory requests and limits of the containers
+	AggregateResourceUsage(ctx context.Context, header http.Header, bizID int64, option *types.ResourceUsageOption) (
+		[]types.ResourceUsage, errors.CCErrorCoder)
+
+	// PlanManifestImport get the changes to the kube resources of a cluster if the manifests are imported
+	PlanManifestImport(ctx context.Context, header http.Header, bizID int64, option *types.ManifestImportOption) (
+		*types.ManifestImportPlan, errors.CCErrorCoder)
+
+	// ImportManifest import the kube resources of a cluster from the manifests
+	ImportManifest(ctx context.Context, header http.Header, bizID int64, option *types.ManifestImportOption) (
+		*types.ManifestImportPlan, errors.CCErrorCoder)
+
+	// CreateServiceBinding bind workloads to the service template of a module
+	CreateServiceBinding(ctx context.Context, header http.Header, bizID int64,
+		option *types.ServiceBindingCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// DeleteServiceBinding delete workload service bindings
+	DeleteServiceBinding(ctx context.Context, header http.Header, bizID int64,
+		option *types.ServiceBindingDeleteOption) errors.CCErrorCoder
+
+	// ListServiceBinding list workload service bindings
+	ListServiceBinding(ctx context.Context, header http.Header, bizID int64,
+		option *types.ServiceBindingQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// CreateHorizontalPodAutoscaler create horizontal pod autoscaler
+	CreateHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+		option *types.HorizontalPodAutoscalerCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateHorizontalPodAutoscaler update horizontal pod autoscaler
+	UpdateHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+		option *types.HorizontalPodAutoscalerUpdateOption) errors.CCErrorCoder
+
+	// DeleteHorizontalPodAutoscaler delete horizontal pod autoscaler
+	DeleteHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+		option *types.HorizontalPodAutoscalerDeleteOption) errors.CCErrorCoder
+
+	// ListHorizontalPodAutoscaler list horizontal pod autoscaler
+	ListHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+		option *types.HorizontalPodAutoscalerQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// ListReplicaHistory list workload replica history
+	ListReplicaHistory(ctx context.Context, header http.Header, bizID int64,
+		option *types.ReplicaHistoryQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// CreateConfigMap create config map
+	CreateConfigMap(ctx context.Context, header http.Header, bizID int64,
+		option *types.ConfigMapCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateConfigMap update config map
+	UpdateConfigMap(ctx context.Context, header http.Header, bizID int64,
+		option *types.ConfigMapUpdateOption) errors.CCErrorCoder
+
+	// DeleteConfigMap delete config map
+	DeleteConfigMap(ctx context.Context, header http.Header, bizID int64,
+		option *types.ConfigMapDeleteOption) errors.CCErrorCoder
+
+	// ListConfigMap list config map
+	ListConfigMap(ctx context.Context, header http.Header, bizID int64,
+		option *types.ConfigMapQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder)
+
+	// CreateSecret create secret
+	CreateSecret(ctx context.Context, header http.Header, bizID int64,
+		option *types.SecretCreateOption) (*metadata.RspIDs, errors.CCErrorCoder)
+
+	// UpdateSecret update secret
+	UpdateSecret(ctx context.Context, header http.Header, bizID int64,
+		option *types.SecretUpdateOption) errors.CCErrorCoder
+
+	// DeleteSecret delete secret
+	DeleteSecret(ctx context.Context, header http.Header, bizID int64,
+		option *types.SecretDeleteOption) errors.CCErrorCoder
+
+	// ListSecret list secret
+	ListSecret(ctx context.Context, header http.Header, bizID int64,
+		option *types.SecretQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder)
 }
 
 // NewKubeOperationInterface initialize the container client object
@@ -76,6 +76,31 @@ func (st *Kube) BatchCreatePod(ctx context.Context, header http.Header,
 	return ret.Data.IDs, nil
 }
 
+// BatchDeletePod batch delete pods.
+func (st *Kube) BatchDeletePod(ctx context.Context, header http.Header,
+	data *types.DeletePodsOption) errors.CCErrorCoder {
+	ret := new(metadata.Response)
+	subPath := "/deletemany/kube/pod"
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(data).
+		SubResourcef(subPath).
+		WithHeaders(header).
+		Do().
+		Into(ret)
+
+	if err != nil {
+		blog.Errorf("batch delete pod failed, http request failed, err: %+v", err)
+		return errors.CCHttpError
+	}
+	if ret.CCError() != nil {
+		return ret.CCError()
+	}
+
+	return nil
+}
+
 // SearchCluster search cluster.
 func (st *Kube) SearchCluster(ctx context.Context, header http.Header, bizID int64, input *types.QueryClusterOption) (
 	*metadata.Response, errors.CCErrorCoder) {
@@ -437,6 +462,31 @@ func (st *Kube) ListWorkload(ctx context.Context, header http.Header, bizID int6
 	return &result.Data, nil
 }
 
+// FindWorkloadMatchedPods find the pods that the label selectors of the workloads match
+func (st *Kube) FindWorkloadMatchedPods(ctx context.Context, header http.Header, bizID int64,
+	kind types.WorkloadType, option *types.WlMatchedPodsOption) ([]types.WlMatchedPods, errors.CCErrorCoder) {
+
+	result := new(types.WlMatchedPodsResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/workload/matched_pod/%s/%d", kind, bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return result.Data, nil
+}
+
 // ListPod list pod
 func (st *Kube) ListPod(ctx context.Context, header http.Header, bizID int64, option *types.PodQueryOption) (
 	*metadata.InstDataInfo, errors.CCErrorCoder) {
@@ -536,3 +586,1078 @@ func (st *Kube) FindPodPath(ctx context.Context, header http.Header, bizID int64
 
 	return &result.Data, nil
 }
+
+// CreateService create service
+func (st *Kube) CreateService(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.ServiceCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/service/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateService update service
+func (st *Kube) UpdateService(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/kube/service/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteService delete service
+func (st *Kube) DeleteService(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/service/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListService list service
+func (st *Kube) ListService(ctx context.Context, header http.Header, bizID int64, option *types.ServiceQueryOption) (
+	*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/service/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateIngress create ingress
+func (st *Kube) CreateIngress(ctx context.Context, header http.Header, bizID int64,
+	option *types.IngressCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.IngressCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/ingress/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateIngress update ingress
+func (st *Kube) UpdateIngress(ctx context.Context, header http.Header, bizID int64,
+	option *types.IngressUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/kube/ingress/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteIngress delete ingress
+func (st *Kube) DeleteIngress(ctx context.Context, header http.Header, bizID int64,
+	option *types.IngressDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/ingress/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListIngress list ingress
+func (st *Kube) ListIngress(ctx context.Context, header http.Header, bizID int64, option *types.IngressQueryOption) (
+	*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/ingress/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateEndpoint create endpoint
+func (st *Kube) CreateEndpoint(ctx context.Context, header http.Header, bizID int64,
+	option *types.EndpointCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.EndpointCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/endpoint/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateEndpoint update endpoint
+func (st *Kube) UpdateEndpoint(ctx context.Context, header http.Header, bizID int64,
+	option *types.EndpointUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/kube/endpoint/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteEndpoint delete endpoint
+func (st *Kube) DeleteEndpoint(ctx context.Context, header http.Header, bizID int64,
+	option *types.EndpointDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/endpoint/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListEndpoint list endpoint
+func (st *Kube) ListEndpoint(ctx context.Context, header http.Header, bizID int64, option *types.EndpointQueryOption) (
+	*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/endpoint/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreatePersistentVolumeClaim create persistent volume claim
+func (st *Kube) CreatePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeClaimCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.PersistentVolumeClaimCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/pvc/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdatePersistentVolumeClaim update persistent volume claim
+func (st *Kube) UpdatePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeClaimUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/kube/pvc/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeletePersistentVolumeClaim delete persistent volume claim
+func (st *Kube) DeletePersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeClaimDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/pvc/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListPersistentVolumeClaim list persistent volume claim
+func (st *Kube) ListPersistentVolumeClaim(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeClaimQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/pvc/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreatePersistentVolume create persistent volume
+func (st *Kube) CreatePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.PersistentVolumeCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/pv/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdatePersistentVolume update persistent volume
+func (st *Kube) UpdatePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/kube/pv/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeletePersistentVolume delete persistent volume
+func (st *Kube) DeletePersistentVolume(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/pv/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListPersistentVolume list persistent volume
+func (st *Kube) ListPersistentVolume(ctx context.Context, header http.Header, bizID int64,
+	option *types.PersistentVolumeQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/pv/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateStorageClass create storage class
+func (st *Kube) CreateStorageClass(ctx context.Context, header http.Header, bizID int64,
+	option *types.StorageClassCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.StorageClassCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/storage_class/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateStorageClass update storage class
+func (st *Kube) UpdateStorageClass(ctx context.Context, header http.Header, bizID int64,
+	option *types.StorageClassUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/kube/storage_class/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteStorageClass delete storage class
+func (st *Kube) DeleteStorageClass(ctx context.Context, header http.Header, bizID int64,
+	option *types.StorageClassDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/storage_class/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListStorageClass list storage class
+func (st *Kube) ListStorageClass(ctx context.Context, header http.Header, bizID int64,
+	option *types.StorageClassQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/storage_class/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// AggregateResourceUsage aggregate the cpu and memory requests and limits of the containers
+func (st *Kube) AggregateResourceUsage(ctx context.Context, header http.Header, bizID int64,
+	option *types.ResourceUsageOption) ([]types.ResourceUsage, errors.CCErrorCoder) {
+
+	result := new(types.ResourceUsageResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/find/kube/resource_usage/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return result.Data, nil
+}
+
+// PlanManifestImport get the changes to the kube resources of a cluster if the manifests are imported
+func (st *Kube) PlanManifestImport(ctx context.Context, header http.Header, bizID int64,
+	option *types.ManifestImportOption) (*types.ManifestImportPlan, errors.CCErrorCoder) {
+
+	result := new(types.ManifestImportPlanResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/find/kube/manifest/import_plan/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return result.Data, nil
+}
+
+// ImportManifest import the kube resources of a cluster from the manifests
+func (st *Kube) ImportManifest(ctx context.Context, header http.Header, bizID int64,
+	option *types.ManifestImportOption) (*types.ManifestImportPlan, errors.CCErrorCoder) {
+
+	result := new(types.ManifestImportPlanResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/import/kube/manifest/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return result.Data, nil
+}
+
+// CreateServiceBinding bind workloads to the service template of a module
+func (st *Kube) CreateServiceBinding(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceBindingCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.ServiceBindingCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/service_binding/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// DeleteServiceBinding delete workload service bindings
+func (st *Kube) DeleteServiceBinding(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceBindingDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/service_binding/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListServiceBinding list workload service bindings
+func (st *Kube) ListServiceBinding(ctx context.Context, header http.Header, bizID int64,
+	option *types.ServiceBindingQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/service_binding/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateHorizontalPodAutoscaler create horizontal pod autoscaler
+func (st *Kube) CreateHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+	option *types.HorizontalPodAutoscalerCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.HorizontalPodAutoscalerCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/hpa/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateHorizontalPodAutoscaler update horizontal pod autoscaler
+func (st *Kube) UpdateHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+	option *types.HorizontalPodAutoscalerUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/kube/hpa/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteHorizontalPodAutoscaler delete horizontal pod autoscaler
+func (st *Kube) DeleteHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+	option *types.HorizontalPodAutoscalerDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/hpa/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListHorizontalPodAutoscaler list horizontal pod autoscaler
+func (st *Kube) ListHorizontalPodAutoscaler(ctx context.Context, header http.Header, bizID int64,
+	option *types.HorizontalPodAutoscalerQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/hpa/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// ListReplicaHistory list workload replica history
+func (st *Kube) ListReplicaHistory(ctx context.Context, header http.Header, bizID int64,
+	option *types.ReplicaHistoryQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/replica_history/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateConfigMap create config map
+func (st *Kube) CreateConfigMap(ctx context.Context, header http.Header, bizID int64,
+	option *types.ConfigMapCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.ConfigMapCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/config_map/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateConfigMap update config map
+func (st *Kube) UpdateConfigMap(ctx context.Context, header http.Header, bizID int64,
+	option *types.ConfigMapUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/kube/config_map/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteConfigMap delete config map
+func (st *Kube) DeleteConfigMap(ctx context.Context, header http.Header, bizID int64,
+	option *types.ConfigMapDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/config_map/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListConfigMap list config map
+func (st *Kube) ListConfigMap(ctx context.Context, header http.Header, bizID int64,
+	option *types.ConfigMapQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/config_map/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// CreateSecret create secret
+func (st *Kube) CreateSecret(ctx context.Context, header http.Header, bizID int64,
+	option *types.SecretCreateOption) (*metadata.RspIDs, errors.CCErrorCoder) {
+
+	result := new(types.SecretCreateResp)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/createmany/kube/secret/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateSecret update secret
+func (st *Kube) UpdateSecret(ctx context.Context, header http.Header, bizID int64,
+	option *types.SecretUpdateOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Put().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/updatemany/kube/secret/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// DeleteSecret delete secret
+func (st *Kube) DeleteSecret(ctx context.Context, header http.Header, bizID int64,
+	option *types.SecretDeleteOption) errors.CCErrorCoder {
+
+	result := new(metadata.BaseResp)
+
+	err := st.client.Delete().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/deletemany/kube/secret/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return ccErr
+	}
+
+	return nil
+}
+
+// ListSecret list secret
+func (st *Kube) ListSecret(ctx context.Context, header http.Header, bizID int64,
+	option *types.SecretQueryOption) (*metadata.InstDataInfo, errors.CCErrorCoder) {
+
+	result := new(metadata.ResponseInstData)
+
+	err := st.client.Post().
+		WithContext(ctx).
+		Body(option).
+		SubResourcef("/findmany/kube/secret/bk_biz_id/%d", bizID).
+		WithHeaders(header).
+		Do().
+		Into(result)
+
+	if err != nil {
+		return nil, errors.CCHttpError
+	}
+
+	if ccErr := result.CCError(); ccErr != nil {
+		return nil, ccErr
+	}
+
+	return &result.Data, nil
+}
@@ -80,6 +80,13 @@ func (s *service) URLFilterChan(req *restful.Request, resp *restful.Response, ch
 		return
 	}
 
+	// the host lock headers are trusted by the core service, so they can not be set by the requests outside of cmdb
+	// except the override header, which is checked by the host server if the user can override the host locks
+	req.Request.Header.Del(common.BKHTTPHostLockExempt)
+	if kind != HostType {
+		req.Request.Header.Del(common.BKHTTPHostLockOverride)
+	}
+
 	defer func() {
 		if err != nil {
 			blog.Errorf("proxy request url[%s] failed, err: %v, rid: %s", req.Request.RequestURI, err, rid)
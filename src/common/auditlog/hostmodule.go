@@ -26,10 +26,11 @@ import (
 )
 
 type hostModuleLog struct {
-	audit     audit
-	hostIDArr []int64
-	pre       []metadata.ModuleHost
-	cur       []metadata.ModuleHost
+	audit       audit
+	hostIDArr   []int64
+	pre         []metadata.ModuleHost
+	cur         []metadata.ModuleHost
+	operateFrom metadata.OperateFromType
 }
 
 // NewHostModuleLog TODO
@@ -42,6 +43,12 @@ func NewHostModuleLog(clientSet coreservice.CoreServiceClientInterface, hostID [
 	}
 }
 
+// WithOperateFrom set where the host transfer comes from, the default is from user
+func (h *hostModuleLog) WithOperateFrom(operateFrom metadata.OperateFromType) *hostModuleLog {
+	h.operateFrom = operateFrom
+	return h
+}
+
 // WithPrevious TODO
 func (h *hostModuleLog) WithPrevious(kit *rest.Kit) errors.CCError {
 	if h.pre != nil {
@@ -238,6 +245,7 @@ func (h *hostModuleLog) SaveAudit(kit *rest.Kit) errors.CCError {
 			AuditType:    metadata.HostType,
 			ResourceType: metadata.HostRes,
 			Action:       action,
+			OperateFrom:  h.operateFrom,
 			BusinessID:   bizID,
 			ResourceID:   hostID,
 			ResourceName: hostIP,
@@ -101,6 +101,206 @@ func (c *kubeAuditLog) GeneratePodAuditLog(param *generateAuditCommonParameter,
 	return auditLogs, nil
 }
 
+// GenerateKubeResourceAuditLog generate audit log of the kube resources, data is the slice of one kind of the kube
+// resources, it is used by the common kube resource handlers that do not know the actual kind.
+func (c *kubeAuditLog) GenerateKubeResourceAuditLog(param *generateAuditCommonParameter, data interface{}) (
+	[]metadata.AuditLog, errors.CCErrorCoder) {
+
+	switch res := data.(type) {
+	case []types.Service:
+		return c.GenerateServiceAuditLog(param, res)
+	case []types.Ingress:
+		return c.GenerateIngressAuditLog(param, res)
+	case []types.Endpoint:
+		return c.GenerateEndpointAuditLog(param, res)
+	case []types.PersistentVolumeClaim:
+		return c.GeneratePersistentVolumeClaimAuditLog(param, res)
+	case []types.PersistentVolume:
+		return c.GeneratePersistentVolumeAuditLog(param, res)
+	case []types.StorageClass:
+		return c.GenerateStorageClassAuditLog(param, res)
+	case []types.HorizontalPodAutoscaler:
+		return c.GenerateHorizontalPodAutoscalerAuditLog(param, res)
+	case []types.ConfigMap:
+		return c.GenerateConfigMapAuditLog(param, res)
+	case []types.Secret:
+		return c.GenerateSecretAuditLog(param, res)
+	default:
+		return nil, param.kit.CCError.CCError(common.CCErrAuditGenerateLogFailed)
+	}
+}
+
+// GenerateServiceAuditLog generate audit log of kube service.
+func (c *kubeAuditLog) GenerateServiceAuditLog(param *generateAuditCommonParameter, data []types.Service) (
+	[]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		auditLog, err := c.generateAuditLog(param, metadata.KubeService, d.ID, d.BizID, &d.Name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
+// GenerateIngressAuditLog generate audit log of kube ingress.
+func (c *kubeAuditLog) GenerateIngressAuditLog(param *generateAuditCommonParameter, data []types.Ingress) (
+	[]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		auditLog, err := c.generateAuditLog(param, metadata.KubeIngress, d.ID, d.BizID, &d.Name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
+// GenerateEndpointAuditLog generate audit log of kube endpoint, the endpoint is named by its service and pod.
+func (c *kubeAuditLog) GenerateEndpointAuditLog(param *generateAuditCommonParameter, data []types.Endpoint) (
+	[]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		name := d.ServiceName + "/" + d.PodName
+		auditLog, err := c.generateAuditLog(param, metadata.KubeEndpoint, d.ID, d.BizID, &name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
+// GeneratePersistentVolumeClaimAuditLog generate audit log of kube persistent volume claim.
+func (c *kubeAuditLog) GeneratePersistentVolumeClaimAuditLog(param *generateAuditCommonParameter,
+	data []types.PersistentVolumeClaim) ([]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		auditLog, err := c.generateAuditLog(param, metadata.KubePersistentVolumeClaim, d.ID, d.BizID, &d.Name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
+// GeneratePersistentVolumeAuditLog generate audit log of kube persistent volume.
+func (c *kubeAuditLog) GeneratePersistentVolumeAuditLog(param *generateAuditCommonParameter,
+	data []types.PersistentVolume) ([]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		auditLog, err := c.generateAuditLog(param, metadata.KubePersistentVolume, d.ID, d.BizID, &d.Name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
+// GenerateStorageClassAuditLog generate audit log of kube storage class.
+func (c *kubeAuditLog) GenerateStorageClassAuditLog(param *generateAuditCommonParameter, data []types.StorageClass) (
+	[]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		auditLog, err := c.generateAuditLog(param, metadata.KubeStorageClass, d.ID, d.BizID, &d.Name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
+// GenerateServiceBindingAuditLog generate audit log of kube workload service binding.
+func (c *kubeAuditLog) GenerateServiceBindingAuditLog(param *generateAuditCommonParameter,
+	data []types.WorkloadServiceBinding) ([]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		auditLog, err := c.generateAuditLog(param, metadata.KubeServiceBinding, d.ID, d.BizID, &d.Ref.Name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
+// GenerateHorizontalPodAutoscalerAuditLog generate audit log of kube horizontal pod autoscaler.
+func (c *kubeAuditLog) GenerateHorizontalPodAutoscalerAuditLog(param *generateAuditCommonParameter,
+	data []types.HorizontalPodAutoscaler) ([]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		auditLog, err := c.generateAuditLog(param, metadata.KubeHorizontalPodAutoscaler, d.ID, d.BizID, &d.Name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
+// GenerateConfigMapAuditLog generate audit log of kube config map.
+func (c *kubeAuditLog) GenerateConfigMapAuditLog(param *generateAuditCommonParameter,
+	data []types.ConfigMap) ([]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		auditLog, err := c.generateAuditLog(param, metadata.KubeConfigMap, d.ID, d.BizID, &d.Name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
+// GenerateSecretAuditLog generate audit log of kube secret.
+func (c *kubeAuditLog) GenerateSecretAuditLog(param *generateAuditCommonParameter,
+	data []types.Secret) ([]metadata.AuditLog, errors.CCErrorCoder) {
+
+	auditLogs := make([]metadata.AuditLog, len(data))
+
+	for index, d := range data {
+		auditLog, err := c.generateAuditLog(param, metadata.KubeSecret, d.ID, d.BizID, &d.Name, d)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs[index] = auditLog
+	}
+
+	return auditLogs, nil
+}
+
 // kubeWorkloadData kube workload audit data struct, including workload type and its actual data
 type kubeWorkloadData struct {
 	Kind types.WorkloadType      `json:"kind" bson:"kind"`
@@ -245,6 +245,24 @@ const (
 
 	// BKDBLimit the db operator to limit return number of doc
 	BKDBLimit = "$limit"
+
+	// BKDBMin the db operator to get the minimum value of a group
+	BKDBMin = "$min"
+
+	// BKDBMax the db operator to get the maximum value of a group
+	BKDBMax = "$max"
+
+	// BKDBLookUp the db operator to join documents from another collection
+	BKDBLookUp = "$lookup"
+
+	// BKDBUnwind the db operator to output a document for each element of an array field
+	BKDBUnwind = "$unwind"
+
+	// BKDBAddFields the db operator to add new fields to documents
+	BKDBAddFields = "$addFields"
+
+	// BKDBFacet the db operator to process multiple aggregation pipelines on the same input documents
+	BKDBFacet = "$facet"
 )
 
 const (
@@ -280,6 +298,9 @@ const (
 	// BKSNField  the sn  field
 	BKSNField = "bk_sn"
 
+	// BKHostMacField the host inner mac field
+	BKHostMacField = "bk_mac"
+
 	// BKHostInnerIPField the host innerip field
 	BKHostInnerIPField = "bk_host_innerip"
 
@@ -539,6 +560,31 @@ const (
 	// HostApplyRuleIDField TODO
 	HostApplyRuleIDField = "host_apply_rule_id"
 
+	// HostApplyConditionField the condition field of the conditional host apply rule
+	HostApplyConditionField = "condition"
+
+	// HostApplyPriorityField the priority field of the host apply rule
+	HostApplyPriorityField = "priority"
+
+	// HostSnapTimeField the time field of the host snapshot history and change log
+	HostSnapTimeField = "time"
+
+	// HostSnapExpireAtField the expiration time field of the host snapshot history and change log, the
+	// documents are removed by mongodb ttl index once they are expired.
+	HostSnapExpireAtField = "expire_at"
+
+	// HostSnapLastTimeField the last snapshot time field of the host snapshot status
+	HostSnapLastTimeField = "last_snapshot_time"
+
+	// HostSnapStaleField the stale flag field of the host snapshot status
+	HostSnapStaleField = "stale"
+
+	// HostLockExpireTimeField the expiration time field of the host lock, the lock without it never expires
+	HostLockExpireTimeField = "expire_time"
+
+	// HostLockUserField the field of the user who holds the host lock
+	HostLockUserField = "bk_user"
+
 	// BKParentIDField TODO
 	BKParentIDField = "bk_parent_id"
 	// BKRootIDField TODO
@@ -1053,6 +1099,10 @@ const (
 const (
 	EventCacheEventIDKey = BKCacheKeyV3Prefix + "event:inst_id"
 	RedisSnapKeyPrefix   = BKCacheKeyV3Prefix + "snapshot:"
+	// RedisSnapDriftKeyPrefix the prefix of the cached drifts between the host snapshot and the host in cmdb
+	RedisSnapDriftKeyPrefix = BKCacheKeyV3Prefix + "snapshot_drift:"
+	// RedisSnapStatusKeyPrefix the prefix of the key that marks the host's snapshot status is saved recently
+	RedisSnapStatusKeyPrefix = BKCacheKeyV3Prefix + "snapshot_status:"
 )
 const (
 	// RedisSentinelMode redis mode is sentinel
@@ -1097,6 +1147,11 @@ const (
 	BKHTTPReadReference = "Cc_Read_Preference"
 	// BKHTTPRequestFromWeb represents if request is from web server
 	BKHTTPRequestFromWeb = "Cc_Request_From_Web"
+	// BKHTTPHostLockOverride the reason to override the host locks held by other users, the override is audited
+	BKHTTPHostLockOverride = "Cc_Host_Lock_Override"
+	// BKHTTPHostLockExempt marks the request from the internal system writers like the host snapshot collector,
+	// which are not restricted by the host locks. api server removes it from the requests outside of cmdb.
+	BKHTTPHostLockExempt = "Cc_Host_Lock_Exempt"
 )
 
 // ReadPreferenceMode TODO
@@ -1469,6 +1524,12 @@ const (
 	SyncModuleHostApplyTaskFlag = "module_host_apply_sync"
 	// SyncServiceTemplateHostApplyTaskFlag  service template dimension host auto-apply async task flag.
 	SyncServiceTemplateHostApplyTaskFlag = "service_template_host_apply_sync"
+	// BulkUpdateHostTaskFlag update the hosts matched by a filter asynchronous task flag.
+	BulkUpdateHostTaskFlag = "host_bulk_update_by_filter"
+	// HostTransferScheduleTaskFlag execute the scheduled host transfer plan asynchronous task flag.
+	HostTransferScheduleTaskFlag = "host_transfer_schedule"
+	// StaleHostTaskFlag take the stale host policy action on the stale hosts asynchronous task flag.
+	StaleHostTaskFlag = "stale_host_handle"
 
 	// BKHostState TODO
 	BKHostState = "bk_state"
@@ -1509,6 +1570,25 @@ const (
 	IsDestroyedCloudHost = "is_destroyed_cloud_host"
 )
 
+// cloud area ip address management const
+const (
+	// BKSubnetIDField the subnet id field
+	BKSubnetIDField = "bk_subnet_id"
+	// BKSubnetCIDRField the cidr of the subnet, such as 10.0.0.0/24 or fd00::/64
+	BKSubnetCIDRField = "bk_cidr"
+	// BKSubnetVlanIDField the vlan id of the subnet, 0 means the subnet has no vlan
+	BKSubnetVlanIDField = "bk_vlan_id"
+	// BKSubnetGatewayField the gateway ip of the subnet
+	BKSubnetGatewayField = "bk_gateway"
+	// BKSubnetIPField the reserved ip field of the subnet ip reservation
+	BKSubnetIPField = "bk_ip"
+	// BKSubnetIPKeyField the sortable key of the host ip, it is used to find the host ips in the range of a subnet
+	BKSubnetIPKeyField = "bk_ip_key"
+)
+
+// BKExecuteTimeField the time when a scheduled operation is executed
+const BKExecuteTimeField = "execute_time"
+
 const (
 	// BKCloudHostStatusUnknown TODO
 	BKCloudHostStatusUnknown = "1"
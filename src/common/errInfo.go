@@ -497,6 +497,7 @@ const (
 	CCErrUpdateModuleAttributesFail                   = 1101117
 	CCErrorBizSetNameDuplicated                       = 1101118
 	CCErrorTopoIdentificationIllegal                  = 1101119
+	CCErrorTopoManifestPlanChanged                    = 1101120
 
 	// object controller 1102XXX
 
@@ -853,6 +854,35 @@ const (
 	CCErrCoreServiceSearchDBUniqueIndex = 1113037
 	// CCErrCoreServiceCreateDBUniqueIndex 创建唯一索引失败,现有数据有重复值
 	CCErrCoreServiceCreateDBUniqueIndexDuplicateValue = 1113039
+	// CCErrCoreServiceHostLocked 主机[%d]已被用户[%s]锁定, 锁定原因: %s
+	CCErrCoreServiceHostLocked = 1113054
+	// CCErrCoreServiceHostLockNotOwner 主机[%d]的锁属于用户[%s], 只有锁的持有者才能解锁
+	CCErrCoreServiceHostLockNotOwner = 1113055
+	// CCErrCoreServiceHostStateNotInLifecycle 主机状态[%s]不在主机生命周期中
+	CCErrCoreServiceHostStateNotInLifecycle = 1113056
+	// CCErrCoreServiceHostStateTransitionForbidden 主机[%d]的状态不允许从[%s]变更为[%s]
+	CCErrCoreServiceHostStateTransitionForbidden = 1113057
+	// CCErrCoreServiceHostStateRequiredFieldNotSet 主机[%d]变更为状态[%s]时必须设置字段[%s]
+	CCErrCoreServiceHostStateRequiredFieldNotSet = 1113058
+	// CCErrCoreServiceHostStateForbidTransfer 主机[%d]处于状态[%s], 不允许转移
+	CCErrCoreServiceHostStateForbidTransfer = 1113059
+	// CCErrCoreServiceHostMergeBizNotMatch 合并的主机必须属于同一个业务
+	CCErrCoreServiceHostMergeBizNotMatch = 1113060
+	// CCErrCoreServiceHostFieldManagedByApplyRule 主机[%d]的字段[%s]由主机属性自动应用规则[%d]管理, 不允许修改
+	CCErrCoreServiceHostFieldManagedByApplyRule = 1113061
+	// CCErrCoreServiceSubnetOverlap 子网[%s]与云区域下已有的子网[%s]重叠
+	CCErrCoreServiceSubnetOverlap = 1113062
+	// CCErrCoreServiceSubnetIPNotInSubnet IP[%s]不是子网[%s]中可分配的地址
+	CCErrCoreServiceSubnetIPNotInSubnet = 1113063
+	// CCErrCoreServiceSubnetIPReserved IP[%s]已被预留
+	CCErrCoreServiceSubnetIPReserved = 1113064
+	// CCErrCoreServiceSubnetIPUsedByHost IP[%s]已被主机[%d]使用
+	CCErrCoreServiceSubnetIPUsedByHost = 1113065
+	// CCErrCoreServiceSubnetNoFreeIP 子网[%s]没有足够的空闲IP
+	CCErrCoreServiceSubnetNoFreeIP = 1113066
+
+	// CCErrCoreServiceHostTransferScheduleStatusInvalid host transfer schedule status is not the expected status
+	CCErrCoreServiceHostTransferScheduleStatusInvalid = 1113067
 
 	// synchronize data core service  11139xx
 	CCErrCoreServiceSyncError = 1113900
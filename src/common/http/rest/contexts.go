@@ -140,6 +140,12 @@ func (c *Contexts) RespStringArray(jsonArray []string) {
 
 // RespCountInfoString TODO
 func (c *Contexts) RespCountInfoString(count int64, infoArray []string) {
+	c.RespCursorCountInfoString(count, infoArray, "")
+}
+
+// RespCursorCountInfoString response the count and info json string array, with the next cursor of the keyset
+// pagination if it is not empty.
+func (c *Contexts) RespCursorCountInfoString(count int64, infoArray []string, nextCursor string) {
 	if c.respStatusCode != 0 {
 		c.resp.WriteHeader(c.respStatusCode)
 	}
@@ -159,7 +165,12 @@ func (c *Contexts) RespCountInfoString(count int64, infoArray []string) {
 			dataBuffer.WriteByte(',')
 		}
 	}
-	dataBuffer.WriteString("]}")
+	dataBuffer.WriteByte(']')
+	if len(nextCursor) != 0 {
+		dataBuffer.WriteString(",\"next_cursor\":")
+		dataBuffer.WriteString(strconv.Quote(nextCursor))
+	}
+	dataBuffer.WriteByte('}')
 
 	jsonBuffer := bytes.Buffer{}
 	jsonBuffer.WriteString("{\"result\": true, \"bk_error_code\": 0, \"bk_error_msg\": \"success\", \"data\": ")
@@ -31,12 +31,13 @@ func init() {
 var commHostApplyRuleIndexes = []types.Index{
 
 	{
-		Name: common.CCLogicUniqueIdxNamePrefix + "bizID_ModuleID_serviceTemplateID_attrID",
+		Name: common.CCLogicUniqueIdxNamePrefix + "bizID_ModuleID_serviceTemplateID_attrID_priority",
 		Keys: bson.D{
 			{common.BKAppIDField, 1},
 			{common.BKModuleIDField, 1},
 			{common.BKServiceTemplateIDField, 1},
 			{common.BKAttributeIDField, 1},
+			{common.HostApplyPriorityField, 1},
 		},
 		Unique:     true,
 		Background: true,
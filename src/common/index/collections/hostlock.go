@@ -29,15 +29,23 @@ func init() {
 
 //  新加和修改后的索引,索引名字一定要用对应的前缀，CCLogicUniqueIdxNamePrefix|common.CCLogicIndexNamePrefix
 
-var commHostLockIndexes = []types.Index{}
-
-// deprecated 未规范化前的索引，只允许删除不允许新加和修改，
-var deprecatedHostLockIndexes = []types.Index{
+var commHostLockIndexes = []types.Index{
+	{
+		// the expired host locks are removed by mongodb, the locks without expire time are kept
+		Name:               common.CCLogicIndexNamePrefix + common.HostLockExpireTimeField,
+		Keys:               bson.D{{common.HostLockExpireTimeField, 1}},
+		Background:         true,
+		ExpireAfterSeconds: 1,
+	},
 	{
-		Name: "bk_host_id_1",
-		Keys: bson.D{{
-			"bk_host_id", 1},
-		},
+		// a host can only be locked once, so that the lock is taken atomically by the conditional upsert
+		Name:       common.CCLogicUniqueIdxNamePrefix + "bkHostID",
+		Keys:       bson.D{{common.BKHostIDField, 1}},
+		Unique:     true,
 		Background: true,
 	},
 }
+
+// deprecated 未规范化前的索引，只允许删除不允许新加和修改，
+// the host id index is replaced by the unique index, it is removed as a deprecated index name
+var deprecatedHostLockIndexes = []types.Index{}
@@ -0,0 +1,79 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collections
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/storage/dal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func init() {
+	registerIndexes(common.BKTableNameHostSnapHistory, commHostSnapHistoryIndexes)
+	registerIndexes(common.BKTableNameHostSnapChangeLog, commHostSnapChangeLogIndexes)
+	registerIndexes(common.BKTableNameHostSnapDrift, commHostSnapDriftIndexes)
+}
+
+// hostSnapExpireIndex the documents are removed by mongodb when the expire_at time is reached.
+var hostSnapExpireIndex = types.Index{
+	Name:               common.CCLogicIndexNamePrefix + common.HostSnapExpireAtField,
+	Keys:               bson.D{{common.HostSnapExpireAtField, 1}},
+	Background:         true,
+	ExpireAfterSeconds: 1,
+}
+
+var commHostSnapHistoryIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_host_id_time",
+		Keys: bson.D{
+			{common.BKHostIDField, 1},
+			{common.HostSnapTimeField, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	hostSnapExpireIndex,
+}
+
+var commHostSnapChangeLogIndexes = []types.Index{
+	{
+		Name: common.CCLogicIndexNamePrefix + "bk_host_id_time",
+		Keys: bson.D{
+			{common.BKHostIDField, 1},
+			{common.HostSnapTimeField, -1},
+		},
+		Background: true,
+	},
+	hostSnapExpireIndex,
+}
+
+var commHostSnapDriftIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_host_id_bk_property_id",
+		Keys: bson.D{
+			{common.BKHostIDField, 1},
+			{common.BKPropertyIDField, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "bk_property_id",
+		Keys: bson.D{
+			{common.BKPropertyIDField, 1},
+		},
+		Background: true,
+	},
+	hostSnapExpireIndex,
+}
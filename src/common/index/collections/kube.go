@@ -31,6 +31,18 @@ func init() {
 	registerIndexes(kubetypes.BKTableNameBaseNamespace, commNamespaceIndexes)
 	registerIndexes(kubetypes.BKTableNameBasePod, commPodIndexes)
 	registerIndexes(kubetypes.BKTableNameBaseContainer, commContainerIndexes)
+	registerIndexes(kubetypes.BKTableNameClusterSyncStatus, commClusterSyncStatusIndexes)
+	registerIndexes(kubetypes.BKTableNameBaseService, commServiceIndexes)
+	registerIndexes(kubetypes.BKTableNameBaseIngress, commIngressIndexes)
+	registerIndexes(kubetypes.BKTableNameBaseEndpoint, commEndpointIndexes)
+	registerIndexes(kubetypes.BKTableNameBasePersistentVolumeClaim, commPersistentVolumeClaimIndexes)
+	registerIndexes(kubetypes.BKTableNameBasePersistentVolume, commPersistentVolumeIndexes)
+	registerIndexes(kubetypes.BKTableNameBaseStorageClass, commStorageClassIndexes)
+	registerIndexes(kubetypes.BKTableNameWorkloadServiceBinding, commServiceBindingIndexes)
+	registerIndexes(kubetypes.BKTableNameBaseHorizontalPodAutoscaler, commHorizontalPodAutoscalerIndexes)
+	registerIndexes(kubetypes.BKTableNameWorkloadReplicaHistory, commReplicaHistoryIndexes)
+	registerIndexes(kubetypes.BKTableNameBaseConfigMap, commConfigMapIndexes)
+	registerIndexes(kubetypes.BKTableNameBaseSecret, commSecretIndexes)
 
 	workLoadTables := []string{
 		kubetypes.BKTableNameBaseDeployment, kubetypes.BKTableNameBaseDaemonSet,
@@ -341,3 +353,416 @@ var commClusterIndexes = []types.Index{
 		Background: true,
 	},
 }
+
+var commClusterSyncStatusIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + kubetypes.BKClusterIDFiled,
+		Keys: bson.D{
+			{kubetypes.BKClusterIDFiled, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + common.BKAppIDField,
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commServiceIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_namespace_id_name",
+		Keys: bson.D{
+			{kubetypes.BKNamespaceIDField, 1},
+			{common.BKFieldName, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_name",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{common.BKFieldName, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commIngressIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_namespace_id_name",
+		Keys: bson.D{
+			{kubetypes.BKNamespaceIDField, 1},
+			{common.BKFieldName, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_name",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{common.BKFieldName, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commEndpointIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_service_id_bk_pod_id",
+		Keys: bson.D{
+			{kubetypes.BKServiceIDField, 1},
+			{kubetypes.BKPodIDField, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "pod_id",
+		Keys: bson.D{
+			{kubetypes.BKPodIDField, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commPersistentVolumeClaimIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_namespace_id_name",
+		Keys: bson.D{
+			{kubetypes.BKNamespaceIDField, 1},
+			{common.BKFieldName, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "volume_name",
+		Keys: bson.D{
+			{kubetypes.VolumeNameField, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commPersistentVolumeIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_cluster_id_name",
+		Keys: bson.D{
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BKFieldName, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "node_id",
+		Keys: bson.D{
+			{kubetypes.BKNodeIDField, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commStorageClassIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_cluster_id_name",
+		Keys: bson.D{
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BKFieldName, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commServiceBindingIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "ref_kind_ref_id",
+		Keys: bson.D{
+			{kubetypes.RefKindField, 1},
+			{kubetypes.RefIDField, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_module_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{common.BKModuleIDField, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "service_template_id",
+		Keys: bson.D{
+			{common.BKServiceTemplateIDField, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commHorizontalPodAutoscalerIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_namespace_id_name",
+		Keys: bson.D{
+			{kubetypes.BKNamespaceIDField, 1},
+			{common.BKFieldName, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "ref_kind_ref_id",
+		Keys: bson.D{
+			{kubetypes.RefKindField, 1},
+			{kubetypes.RefIDField, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commReplicaHistoryIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "event_id",
+		Keys: bson.D{
+			{kubetypes.EventIDField, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "ref_kind_ref_id_time",
+		Keys: bson.D{
+			{kubetypes.RefKindField, 1},
+			{kubetypes.RefIDField, 1},
+			{kubetypes.TimeField, 1},
+		},
+		Background: true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + kubetypes.ExpireAtField,
+		Keys: bson.D{
+			{kubetypes.ExpireAtField, 1},
+		},
+		Background:         true,
+		ExpireAfterSeconds: 1,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commConfigMapIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_namespace_id_name",
+		Keys: bson.D{
+			{kubetypes.BKNamespaceIDField, 1},
+			{common.BKFieldName, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
+
+var commSecretIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + common.BKFieldID,
+		Keys: bson.D{
+			{common.BKFieldID, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_namespace_id_name",
+		Keys: bson.D{
+			{kubetypes.BKNamespaceIDField, 1},
+			{common.BKFieldName, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicIndexNamePrefix + "biz_id_cluster_id",
+		Keys: bson.D{
+			{common.BKAppIDField, 1},
+			{kubetypes.BKClusterIDFiled, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+	},
+}
@@ -0,0 +1,48 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collections
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/storage/dal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func init() {
+	registerIndexes(common.BKTableNameHostSnapStatus, commHostSnapStatusIndexes)
+	registerIndexes(common.BKTableNameStaleHostPolicy, commStaleHostPolicyIndexes)
+}
+
+var commHostSnapStatusIndexes = []types.Index{
+	{
+		Name:       common.CCLogicUniqueIdxNamePrefix + common.BKHostIDField,
+		Keys:       bson.D{{common.BKHostIDField, 1}},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name:       common.CCLogicIndexNamePrefix + "stale_last_snapshot_time",
+		Keys:       bson.D{{common.HostSnapStaleField, 1}, {common.HostSnapLastTimeField, 1}},
+		Background: true,
+	},
+}
+
+var commStaleHostPolicyIndexes = []types.Index{
+	{
+		Name:       common.CCLogicUniqueIdxNamePrefix + common.BkSupplierAccount,
+		Keys:       bson.D{{common.BkSupplierAccount, 1}},
+		Background: true,
+		Unique:     true,
+	},
+}
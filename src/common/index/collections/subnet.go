@@ -0,0 +1,79 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collections
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/storage/dal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func init() {
+	registerIndexes(common.BKTableNameSubnet, commSubnetIndexes)
+	registerIndexes(common.BKTableNameSubnetIPReservation, commSubnetIPReservationIndexes)
+	registerIndexes(common.BKTableNameSubnetHostIP, commSubnetHostIPIndexes)
+}
+
+var commSubnetIndexes = []types.Index{
+	{
+		Name:       common.CCLogicUniqueIdxNamePrefix + common.BKSubnetIDField,
+		Keys:       bson.D{{common.BKSubnetIDField, 1}},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_cloud_id_bk_cidr",
+		Keys: bson.D{
+			{common.BKCloudIDField, 1},
+			{common.BKSubnetCIDRField, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+}
+
+var commSubnetIPReservationIndexes = []types.Index{
+	{
+		Name: common.CCLogicUniqueIdxNamePrefix + "bk_cloud_id_bk_ip",
+		Keys: bson.D{
+			{common.BKCloudIDField, 1},
+			{common.BKSubnetIPField, 1},
+			{common.BkSupplierAccount, 1},
+		},
+		Background: true,
+		Unique:     true,
+	},
+	{
+		Name:       common.CCLogicIndexNamePrefix + common.BKSubnetIDField,
+		Keys:       bson.D{{common.BKSubnetIDField, 1}},
+		Background: true,
+	},
+}
+
+var commSubnetHostIPIndexes = []types.Index{
+	{
+		Name: common.CCLogicIndexNamePrefix + "bk_cloud_id_bk_ip_key",
+		Keys: bson.D{
+			{common.BKCloudIDField, 1},
+			{common.BKSubnetIPKeyField, 1},
+		},
+		Background: true,
+	},
+	{
+		Name:       common.CCLogicIndexNamePrefix + common.BKHostIDField,
+		Keys:       bson.D{{common.BKHostIDField, 1}},
+		Background: true,
+	},
+}
@@ -26,6 +26,9 @@ const (
 
 	// CheckSetTemplateSyncFormat  检测集群模板同步的状态
 	CheckSetTemplateSyncFormat = "topo:settemplate:sync:status:check:%d"
+
+	// CreateSubnetFormat create subnet in the cloud area format
+	CreateSubnetFormat = "coreservice:create:subnet:cloud:%d"
 )
 
 // StrFormat  build  lock key format
@@ -849,4 +849,7 @@ func (h *HostTopoPathReq) Validate() errors.RawErrorInfo {
 type HostTopoPath struct {
 	HostID int64                         `json:"id"`
 	Path   [][]*TopoInstanceNodeSimplify `json:"topo_path"`
+	// ContainerPath the topo paths of the modules that the pods running on the host belong to as the container
+	// backed service instances, through the service template bindings of their workloads
+	ContainerPath [][]*TopoInstanceNodeSimplify `json:"container_topo_path,omitempty"`
 }
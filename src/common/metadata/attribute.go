@@ -24,6 +24,7 @@ import (
 
 	"configcenter/src/common"
 	"configcenter/src/common/blog"
+	"configcenter/src/common/criteria/enumor"
 	"configcenter/src/common/errors"
 	"configcenter/src/common/mapstr"
 	"configcenter/src/common/util"
@@ -197,6 +198,53 @@ func (attribute *Attribute) Validate(ctx context.Context, data interface{}, key
 	return rawError
 }
 
+// FilterFieldType returns the field type of the attribute that is used to validate the attribute's filter rule.
+func (attribute *Attribute) FilterFieldType() (enumor.FieldType, bool) {
+	switch attribute.PropertyType {
+	case common.FieldTypeSingleChar, common.FieldTypeLongChar, common.FieldTypeUser, common.FieldTypeTimeZone,
+		common.FieldTypeList, common.FieldTypeDate:
+		return enumor.String, true
+	case common.FieldTypeEnum:
+		return enumor.Enum, true
+	case common.FieldTypeInt, common.FieldTypeFloat:
+		return enumor.Numeric, true
+	case common.FieldTypeTime:
+		return enumor.Time, true
+	case common.FieldTypeBool:
+		return enumor.Boolean, true
+	case common.FieldTypeTable, common.FieldTypeOrganization:
+		return enumor.Array, true
+	case common.FieldObject:
+		return enumor.Object, true
+	default:
+		return "", false
+	}
+}
+
+// GetInstFilterFieldTypes returns the filter field types of the object instance's attributes and common fields,
+// which is used to validate the filter of the object instances.
+func GetInstFilterFieldTypes(objID string, attributes []Attribute) map[string]enumor.FieldType {
+	fieldTypes := map[string]enumor.FieldType{
+		GetInstIDFieldByObjID(objID): enumor.Numeric,
+		common.CreateTimeField:       enumor.Time,
+		common.LastTimeField:         enumor.Time,
+	}
+
+	switch objID {
+	case common.BKInnerObjIDSet, common.BKInnerObjIDModule:
+		fieldTypes[common.BKAppIDField] = enumor.Numeric
+	case common.BKInnerObjIDHost:
+		fieldTypes[common.BKCloudIDField] = enumor.Numeric
+	}
+
+	for _, attr := range attributes {
+		if fieldType, ok := attr.FilterFieldType(); ok {
+			fieldTypes[attr.PropertyID] = fieldType
+		}
+	}
+	return fieldTypes
+}
+
 // validTime valid object Attribute that is time type
 func (attribute *Attribute) validTime(ctx context.Context, val interface{}, key string) (rawError errors.RawErrorInfo) {
 
@@ -725,6 +725,26 @@ const (
 	KubeWorkload ResourceType = "kube_workload"
 	// KubePod kube pod audit resource type
 	KubePod ResourceType = "kube_pod"
+	// KubeService kube service audit resource type
+	KubeService ResourceType = "kube_service"
+	// KubeIngress kube ingress audit resource type
+	KubeIngress ResourceType = "kube_ingress"
+	// KubeEndpoint kube endpoint audit resource type
+	KubeEndpoint ResourceType = "kube_endpoint"
+	// KubePersistentVolumeClaim kube persistent volume claim audit resource type
+	KubePersistentVolumeClaim ResourceType = "kube_pvc"
+	// KubePersistentVolume kube persistent volume audit resource type
+	KubePersistentVolume ResourceType = "kube_pv"
+	// KubeStorageClass kube storage class audit resource type
+	KubeStorageClass ResourceType = "kube_storage_class"
+	// KubeServiceBinding kube workload service binding audit resource type
+	KubeServiceBinding ResourceType = "kube_service_binding"
+	// KubeHorizontalPodAutoscaler kube horizontal pod autoscaler audit resource type
+	KubeHorizontalPodAutoscaler ResourceType = "kube_hpa"
+	// KubeConfigMap kube config map audit resource type
+	KubeConfigMap ResourceType = "kube_config_map"
+	// KubeSecret kube secret audit resource type
+	KubeSecret ResourceType = "kube_secret"
 )
 
 // OperateFromType TODO
@@ -741,6 +761,8 @@ const (
 	FromSynchronizer OperateFromType = "synchronizer"
 	// FromCloudSync means this audit is created by cloud sync.
 	FromCloudSync OperateFromType = "cloud_sync"
+	// FromScheduler means this audit is created by a scheduled operation on behalf of its requester.
+	FromScheduler OperateFromType = "scheduler"
 )
 
 // ActionType defines all the user's operation type
@@ -777,6 +799,12 @@ const (
 	// AuditResume TODO
 	// resume using an object
 	AuditResume ActionType = "resume"
+	// AuditOverrideHostLock override the host lock held by another user
+	AuditOverrideHostLock ActionType = "override_host_lock"
+	// AuditChangeHostState change the lifecycle state of a host
+	AuditChangeHostState ActionType = "change_host_state"
+	// AuditMergeHost merge the duplicate hosts into one host
+	AuditMergeHost ActionType = "merge_host"
 )
 
 // GetAuditTypeByObjID TODO
@@ -907,6 +935,9 @@ var auditDict = []resourceTypeInfo{
 			actionInfoMap[AuditAssignHost],
 			actionInfoMap[AuditUnassignHost],
 			actionInfoMap[AuditTransferHostModule],
+			actionInfoMap[AuditOverrideHostLock],
+			actionInfoMap[AuditChangeHostState],
+			actionInfoMap[AuditMergeHost],
 		},
 	},
 	{
@@ -1051,6 +1082,9 @@ var actionInfoMap = map[ActionType]actionTypeInfo{
 	AuditRecover:            {ID: AuditRecover, Name: "恢复"},
 	AuditPause:              {ID: AuditPause, Name: "停用"},
 	AuditResume:             {ID: AuditResume, Name: "启用"},
+	AuditOverrideHostLock:   {ID: AuditOverrideHostLock, Name: "强制操作锁定主机"},
+	AuditChangeHostState:    {ID: AuditChangeHostState, Name: "变更主机状态"},
+	AuditMergeHost:          {ID: AuditMergeHost, Name: "合并主机"},
 }
 
 type resourceTypeInfo struct {
@@ -1134,6 +1168,9 @@ var auditEnDict = []resourceTypeInfo{
 			actionInfoEnMap[AuditAssignHost],
 			actionInfoEnMap[AuditUnassignHost],
 			actionInfoEnMap[AuditTransferHostModule],
+			actionInfoEnMap[AuditOverrideHostLock],
+			actionInfoEnMap[AuditChangeHostState],
+			actionInfoEnMap[AuditMergeHost],
 		},
 	},
 	{
@@ -1277,4 +1314,7 @@ var actionInfoEnMap = map[ActionType]actionTypeInfo{
 	AuditRecover:            {ID: AuditRecover, Name: "Recover"},
 	AuditPause:              {ID: AuditPause, Name: "Pause"},
 	AuditResume:             {ID: AuditResume, Name: "Resume"},
+	AuditOverrideHostLock:   {ID: AuditOverrideHostLock, Name: "Override host lock"},
+	AuditChangeHostState:    {ID: AuditChangeHostState, Name: "Change host state"},
+	AuditMergeHost:          {ID: AuditMergeHost, Name: "Merge host"},
 }
@@ -133,6 +133,8 @@ type ResponseInstData struct {
 type InstDataInfo struct {
 	Count int             `json:"count"`
 	Info  []mapstr.MapStr `json:"info"`
+	// NextCursor is the cursor of the next page when using keyset pagination, it's empty if there is no more data.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ResponseDataMapStr TODO
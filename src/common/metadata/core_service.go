@@ -465,6 +465,9 @@ type ListServiceInstanceDetailOption struct {
 	ServiceInstanceIDs []int64            `json:"service_instance_ids"`
 	Selectors          selector.Selectors `json:"selectors,omitempty"`
 	Page               BasePage           `json:"page,omitempty"`
+	// WithContainer also returns the pods of the workloads bound to the service templates as the container backed
+	// service instances, they are placed after the host based service instances.
+	WithContainer bool `json:"with_container,omitempty"`
 }
 
 // ListProcessInstanceRelationOption TODO
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"time"
 
+	"configcenter/pkg/filter"
 	"configcenter/src/common"
 	"configcenter/src/common/blog"
 	"configcenter/src/common/util"
@@ -230,14 +231,74 @@ func (c *DynamicGroupInfoCondition) Validate(validatefunc Validatefunc) error {
 	return nil
 }
 
+// MaxDynamicGroupAsstFilters is the maximum number of the association filters of a dynamic group.
+const MaxDynamicGroupAsstFilters = 5
+
+// MaxDynamicGroupAsstInsts is the maximum number of the target instances that an association filter can match.
+const MaxDynamicGroupAsstInsts = 10000
+
+// DynamicGroupAsstFilter filters the dynamic group's target instances by the attributes of their associated instances.
+type DynamicGroupAsstFilter struct {
+	// ObjID is the object id of the associated instances.
+	ObjID string `json:"bk_obj_id" bson:"bk_obj_id"`
+
+	// Filter is the associated instances' attribute filter, target instances that are associated with any of the
+	// matched instances are matched.
+	// Example: bk_obj_id rack, filter datacenter equal sz1 index hosts which related rack is in datacenter sz1.
+	Filter *filter.Expression `json:"filter" bson:"filter"`
+}
+
+// Validate validates dynamic group association filter format.
+func (c *DynamicGroupAsstFilter) Validate(validatefunc Validatefunc) error {
+	if len(c.ObjID) == 0 {
+		return errors.New("empty association filter bk_obj_id")
+	}
+
+	if c.Filter == nil {
+		return fmt.Errorf("empty %s association filter", c.ObjID)
+	}
+
+	attributes, err := validatefunc(c.ObjID)
+	if err != nil {
+		return fmt.Errorf("validate dynamic group failed, %+v", err)
+	}
+
+	if err := c.Filter.Validate(filter.NewDefaultExprOpt(GetInstFilterFieldTypes(c.ObjID, attributes))); err != nil {
+		return fmt.Errorf("invalid %s association filter, %v", c.ObjID, err)
+	}
+	return nil
+}
+
 // DynamicGroupInfo is info field in DynamicGroup struct.
 type DynamicGroupInfo struct {
-	// Condition is dynamic group index conditions set.
+	// Condition is dynamic group index conditions set, it's only supported by host and set dynamic groups.
 	Condition []DynamicGroupInfoCondition `json:"condition" bson:"condition"`
+
+	// Filter is the target object's attribute filter, it's supported by dynamic groups of any object,
+	// and can not be used together with the condition.
+	Filter *filter.Expression `json:"filter,omitempty" bson:"filter,omitempty"`
+
+	// AsstFilters filters the target instances by the attributes of their associated instances, the target instances
+	// must match all of these filters, it can not be used together with the condition.
+	AsstFilters []DynamicGroupAsstFilter `json:"association_filters,omitempty" bson:"association_filters,omitempty"`
+}
+
+// IsFilterType returns if the dynamic group info uses the filters instead of the conditions.
+func (c *DynamicGroupInfo) IsFilterType() bool {
+	return c.Filter != nil || len(c.AsstFilters) > 0
+}
+
+// IsEmpty returns if the dynamic group info has no conditions or filters.
+func (c *DynamicGroupInfo) IsEmpty() bool {
+	return len(c.Condition) == 0 && !c.IsFilterType()
 }
 
 // Validate validates dynamic group info format, it's OK if conditions empty in this level.
 func (c *DynamicGroupInfo) Validate(objectID string, validatefunc Validatefunc) error {
+	if c.IsFilterType() {
+		return c.validateFilters(objectID, validatefunc)
+	}
+
 	types, isSupport := DynamicGroupConditionTypes[objectID]
 	if !isSupport {
 		return fmt.Errorf("not support dynamic group type, %s", objectID)
@@ -255,7 +316,41 @@ func (c *DynamicGroupInfo) Validate(objectID string, validatefunc Validatefunc)
 	return nil
 }
 
-// DynamicGroup is dynamic grouping of conditions for host/set data searching.
+func (c *DynamicGroupInfo) validateFilters(objectID string, validatefunc Validatefunc) error {
+	if len(c.Condition) > 0 {
+		return errors.New("condition and filters can not be used together")
+	}
+
+	switch objectID {
+	case common.BKInnerObjIDApp, common.BKInnerObjIDBizSet, common.BKInnerObjIDProc:
+		return fmt.Errorf("not support dynamic group type, %s", objectID)
+	}
+
+	if c.Filter != nil {
+		attributes, err := validatefunc(objectID)
+		if err != nil {
+			return fmt.Errorf("validate dynamic group failed, %+v", err)
+		}
+
+		opt := filter.NewDefaultExprOpt(GetInstFilterFieldTypes(objectID, attributes))
+		if err := c.Filter.Validate(opt); err != nil {
+			return fmt.Errorf("invalid filter, %v", err)
+		}
+	}
+
+	if len(c.AsstFilters) > MaxDynamicGroupAsstFilters {
+		return fmt.Errorf("association filters exceeds maximum length %d", MaxDynamicGroupAsstFilters)
+	}
+
+	for _, asstFilter := range c.AsstFilters {
+		if err := asstFilter.Validate(validatefunc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DynamicGroup is dynamic grouping of conditions for host/set data searching, or filters for any object's data.
 type DynamicGroup struct {
 	// AppID is application id which dynamic group belongs to.
 	AppID int64 `json:"bk_biz_id" bson:"bk_biz_id"`
@@ -266,7 +361,7 @@ type DynamicGroup struct {
 	// Name is dynamic group name.
 	Name string `json:"name" bson:"name"`
 
-	// ObjID is cmdb object id, could be host/set now.
+	// ObjID is cmdb object id, could be host/set or any other object that is not business when using filters.
 	ObjID string `json:"bk_obj_id" bson:"bk_obj_id"`
 
 	// Info is dynamic group core conditions information.
@@ -301,7 +396,7 @@ func (g *DynamicGroup) Validate(validatefunc Validatefunc) error {
 	}
 
 	// check conditions format.
-	if len(g.Info.Condition) == 0 {
+	if g.Info.IsEmpty() {
 		// it's not OK if conditions empty in this level.
 		return errors.New("empty info.condition")
 	}
@@ -329,6 +424,47 @@ type GetDynamicGroupResult struct {
 	Data     DynamicGroup `json:"data"`
 }
 
+// WatchDynamicGroupOption is the option to watch the membership changes of a dynamic group.
+type WatchDynamicGroupOption struct {
+	// StartFrom is the unix seconds time to where you want to watch from, it can not be used with cursor.
+	StartFrom int64 `json:"bk_start_from"`
+
+	// Cursor is the cursor you hold previous, means you want to watch event from here.
+	Cursor string `json:"bk_cursor"`
+}
+
+// Validate validates dynamic group watch option format.
+func (o *WatchDynamicGroupOption) Validate() error {
+	if o.StartFrom != 0 && len(o.Cursor) != 0 {
+		return errors.New("bk_start_from and bk_cursor can not use at the same time")
+	}
+	return nil
+}
+
+// DynamicGroupMemberEvent is the event of a target instance's change, and whether it's a member after the change.
+type DynamicGroupMemberEvent struct {
+	// Cursor is the cursor of the event, use it to watch the events after this event.
+	Cursor string `json:"bk_cursor"`
+
+	// EventType is the type of the target instance's change, eg create/update/delete.
+	EventType string `json:"bk_event_type"`
+
+	// InstID is the id of the changed target instance.
+	InstID int64 `json:"bk_inst_id"`
+
+	// IsMember defines if the instance is a member of the dynamic group after the change.
+	IsMember bool `json:"is_member"`
+}
+
+// WatchDynamicGroupResult is result struct for dynamic group watching action.
+type WatchDynamicGroupResult struct {
+	// Watched defines if any event is watched, if not, the events contains only one event with the latest cursor.
+	Watched bool `json:"bk_watched"`
+
+	// Events is the dynamic group's member events.
+	Events []DynamicGroupMemberEvent `json:"bk_events"`
+}
+
 // NewDynamicGroupID creates and returns a new dynamic group string unique ID.
 func NewDynamicGroupID() (string, error) {
 	uuid, err := uuid.NewUUID()
@@ -0,0 +1,104 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"testing"
+
+	"configcenter/src/common"
+	"configcenter/src/common/json"
+)
+
+func TestDynamicGroupInfoValidateFilters(t *testing.T) {
+	validatefunc := func(objectID string) ([]Attribute, error) {
+		switch objectID {
+		case "switch":
+			return []Attribute{
+				{PropertyID: "vendor", PropertyType: common.FieldTypeSingleChar},
+				{PropertyID: "port_num", PropertyType: common.FieldTypeInt},
+			}, nil
+		case "rack":
+			return []Attribute{{PropertyID: "datacenter", PropertyType: common.FieldTypeSingleChar}}, nil
+		default:
+			return []Attribute{}, nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		objID   string
+		info    string
+		isValid bool
+	}{
+		{
+			name:  "valid filter and association filter",
+			objID: "switch",
+			info: `{"filter":{"condition":"AND","rules":[{"field":"vendor","operator":"equal","value":"a"},
+{"field":"port_num","operator":"greater","value":24}]},
+"association_filters":[{"bk_obj_id":"rack","filter":{"field":"datacenter","operator":"equal","value":"sz1"}}]}`,
+			isValid: true,
+		},
+		{
+			name:    "filter with invalid field type",
+			objID:   "switch",
+			info:    `{"filter":{"field":"port_num","operator":"equal","value":"a"}}`,
+			isValid: false,
+		},
+		{
+			name:    "filter with not exist field",
+			objID:   "switch",
+			info:    `{"filter":{"field":"memory","operator":"equal","value":1}}`,
+			isValid: false,
+		},
+		{
+			name:  "condition used with filter",
+			objID: common.BKInnerObjIDHost,
+			info: `{"condition":[{"bk_obj_id":"host","condition":[]}],
+"filter":{"field":"bk_host_id","operator":"in","value":[1]}}`,
+			isValid: false,
+		},
+		{
+			name:    "business dynamic group",
+			objID:   common.BKInnerObjIDApp,
+			info:    `{"filter":{"field":"bk_biz_id","operator":"equal","value":1}}`,
+			isValid: false,
+		},
+		{
+			name:    "association filter without filter",
+			objID:   "switch",
+			info:    `{"association_filters":[{"bk_obj_id":"rack"}]}`,
+			isValid: false,
+		},
+		{
+			name:    "custom object condition",
+			objID:   "switch",
+			info:    `{"condition":[{"bk_obj_id":"switch","condition":[]}]}`,
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := new(DynamicGroupInfo)
+			if err := json.Unmarshal([]byte(tt.info), info); err != nil {
+				t.Errorf("unmarshal info failed, err: %v", err)
+				return
+			}
+
+			err := info.Validate(tt.objID, validatefunc)
+			if (err == nil) != tt.isValid {
+				t.Errorf("validate result err %v is not as expected", err)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"strings"
 	"time"
 
+	"configcenter/pkg/filter"
 	"configcenter/src/common"
 	"configcenter/src/common/errors"
 	"configcenter/src/common/json"
@@ -42,6 +43,14 @@ type HostApplyRule struct {
 	AttributeID   int64       `field:"bk_attribute_id" json:"bk_attribute_id" bson:"bk_attribute_id" mapstructure:"bk_attribute_id"`
 	PropertyValue interface{} `field:"bk_property_value" json:"bk_property_value" bson:"bk_property_value" mapstructure:"bk_property_value"`
 
+	// Condition is the optional host attribute filter of the rule, a conditional rule only applies to the hosts that
+	// match it, and takes precedence over the unconditional rule of the same attribute.
+	// NOCC:tosa/linelength(忽略长度)
+	Condition *filter.Expression `field:"condition" json:"condition,omitempty" bson:"condition,omitempty" mapstructure:"condition"`
+	// Priority is the priority of the conditional rule, when multiple conditional rules match one host, the ones
+	// with the highest priority take effect. unconditional rule's priority is always 0.
+	Priority int64 `field:"priority" json:"priority" bson:"priority" mapstructure:"priority"`
+
 	// 通用字段
 	Creator         string    `field:"creator" json:"creator" bson:"creator" mapstructure:"creator"`
 	Modifier        string    `field:"modifier" json:"modifier" bson:"modifier" mapstructure:"modifier"`
@@ -55,17 +64,64 @@ func (h *HostApplyRule) Validate() (string, error) {
 	return "", nil
 }
 
+// IsConditional returns if the rule only applies to the hosts that match its condition
+func (h *HostApplyRule) IsConditional() bool {
+	return h.Condition != nil && h.Condition.RuleFactory != nil
+}
+
+// ValidateHostApplyRuleCondition validate the condition and priority of the host apply rule, the condition's fields
+// must be host attributes, returns the invalid field key and the error.
+func ValidateHostApplyRuleCondition(condition *filter.Expression, priority int64, hostAttrs []Attribute) (string,
+	error) {
+
+	if condition == nil || condition.RuleFactory == nil {
+		if priority != 0 {
+			return "priority", fmt.Errorf("unconditional rule's priority must be 0")
+		}
+		return "", nil
+	}
+
+	if priority <= 0 {
+		return "priority", fmt.Errorf("conditional rule's priority must be greater than 0")
+	}
+
+	opt := filter.NewDefaultExprOpt(GetInstFilterFieldTypes(common.BKInnerObjIDHost, hostAttrs))
+	if err := condition.Validate(opt); err != nil {
+		return "condition", err
+	}
+	return "", nil
+}
+
 // CreateHostApplyRuleOption create host auto-apply rules.
 type CreateHostApplyRuleOption struct {
-	ModuleID          int64       `json:"bk_module_id,omitempty"`
-	ServiceTemplateID int64       `json:"service_template_id,omitempty"`
-	AttributeID       int64       `json:"bk_attribute_id"`
-	PropertyValue     interface{} `json:"bk_property_value"`
+	ModuleID          int64              `json:"bk_module_id,omitempty"`
+	ServiceTemplateID int64              `json:"service_template_id,omitempty"`
+	AttributeID       int64              `json:"bk_attribute_id"`
+	PropertyValue     interface{}        `json:"bk_property_value"`
+	Condition         *filter.Expression `json:"condition,omitempty"`
+	Priority          int64              `json:"priority"`
+}
+
+// IsConditional returns if the rule to be created is a conditional rule
+func (option *CreateHostApplyRuleOption) IsConditional() bool {
+	return option.Condition != nil && option.Condition.RuleFactory != nil
 }
 
-// UpdateHostApplyRuleOption TODO
+// HasConditionalHostApplyRule returns if there is any conditional rule in the rules to be created
+func HasConditionalHostApplyRule(rules []CreateHostApplyRuleOption) bool {
+	for _, rule := range rules {
+		if rule.IsConditional() {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateHostApplyRuleOption update host auto-apply rule, the condition and priority of the rule will be overwritten.
 type UpdateHostApplyRuleOption struct {
-	PropertyValue interface{} `field:"bk_property_value" json:"bk_property_value" bson:"bk_property_value" mapstructure:"bk_property_value"`
+	PropertyValue interface{}        `field:"bk_property_value" json:"bk_property_value" bson:"bk_property_value" mapstructure:"bk_property_value"`
+	Condition     *filter.Expression `field:"condition" json:"condition,omitempty" bson:"condition,omitempty" mapstructure:"condition"`
+	Priority      int64              `field:"priority" json:"priority" bson:"priority" mapstructure:"priority"`
 }
 
 // MultipleHostApplyRuleResult TODO
@@ -181,6 +237,9 @@ type CreateOrUpdateApplyRuleOption struct {
 	ServiceTemplateID int64       `json:"service_template_id,omitempty" bson:"service_template_id"`
 	AttributeID       int64       `json:"bk_attribute_id" bson:"bk_attribute_id"`
 	PropertyValue     interface{} `json:"bk_property_value" bson:"bk_property_value"`
+	// Condition and Priority together with the ids above identify one rule, the rule is updated if exists
+	Condition *filter.Expression `json:"condition,omitempty" bson:"condition,omitempty"`
+	Priority  int64              `json:"priority" bson:"priority"`
 }
 
 // BatchCreateOrUpdateHostApplyRuleResult TODO
@@ -204,13 +263,13 @@ type HostAttribute struct {
 // HostApplyConflictResolver define a resolution to a single conflict.
 type HostApplyConflictResolver struct {
 	HostID        int64 `json:"bk_host_id" bson:"bk_host_id"`
-	HostAttribute `json:",inline"`
+	HostAttribute `json:",inline" bson:",inline"`
 }
 
 // HostApplyTransRules module attribute value setting in the host transfer scenario.
 type HostApplyTransRules struct {
-	Changed    bool            `json:"changed"`
-	FinalRules []HostAttribute `json:"final_rules"`
+	Changed    bool            `json:"changed" bson:"changed"`
+	FinalRules []HostAttribute `json:"final_rules" bson:"final_rules"`
 }
 
 // Host2Modules TODO
@@ -244,6 +303,9 @@ type HostApplyConflictField struct {
 	// UnresolvedConflictExist show whether conflict still exist after use possible conflict resolver
 	// if there is a conflict, but has a resolver for it, ConflictedStillExist will be false
 	UnresolvedConflictExist bool `field:"unresolved_conflict_exist" json:"unresolved_conflict_exist" mapstructure:"unresolved_conflict_exist"`
+	// ConditionConflict show whether the conflict is caused by multiple conditional rules with the same highest
+	// priority and different values matching the host, in which case the Rules are the matched conditional rules
+	ConditionConflict bool `field:"condition_conflict" json:"condition_conflict" mapstructure:"condition_conflict"`
 }
 
 // HostApplyUpdateField TODO
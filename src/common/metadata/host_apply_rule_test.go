@@ -0,0 +1,90 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"testing"
+
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/json"
+)
+
+func TestValidateHostApplyRuleCondition(t *testing.T) {
+	hostAttrs := []Attribute{
+		{PropertyID: common.BKOSTypeField, PropertyType: common.FieldTypeEnum},
+		{PropertyID: "bk_cpu", PropertyType: common.FieldTypeInt},
+	}
+
+	tests := []struct {
+		name      string
+		condition string
+		priority  int64
+		key       string
+	}{
+		{
+			name:     "unconditional rule",
+			priority: 0,
+		},
+		{
+			name:     "unconditional rule with priority",
+			priority: 1,
+			key:      "priority",
+		},
+		{
+			name: "conditional rule",
+			condition: `{"condition":"AND","rules":[{"field":"bk_os_type","operator":"equal","value":"2"},
+{"field":"bk_cpu","operator":"greater","value":8}]}`,
+			priority: 10,
+		},
+		{
+			name:      "conditional rule without priority",
+			condition: `{"field":"bk_os_type","operator":"equal","value":"2"}`,
+			priority:  0,
+			key:       "priority",
+		},
+		{
+			name:      "conditional rule with not exist field",
+			condition: `{"field":"bk_mem","operator":"equal","value":1}`,
+			priority:  1,
+			key:       "condition",
+		},
+		{
+			name:      "conditional rule with invalid value type",
+			condition: `{"field":"bk_cpu","operator":"equal","value":"a"}`,
+			priority:  1,
+			key:       "condition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var condition *filter.Expression
+			if tt.condition != "" {
+				condition = new(filter.Expression)
+				if err := json.Unmarshal([]byte(tt.condition), condition); err != nil {
+					t.Errorf("unmarshal condition failed, err: %v", err)
+					return
+				}
+			}
+
+			key, err := ValidateHostApplyRuleCondition(condition, tt.priority, hostAttrs)
+			if key != tt.key {
+				t.Errorf("validate result key %s is not as expected %s, err: %v", key, tt.key, err)
+			}
+			if (err == nil) != (tt.key == "") {
+				t.Errorf("validate result err %v is not as expected", err)
+			}
+		})
+	}
+}
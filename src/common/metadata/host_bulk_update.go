@@ -0,0 +1,192 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"fmt"
+	"sort"
+
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+)
+
+const (
+	// BulkUpdateHostMaxCount is the max number of the hosts that can be updated by a filter at a time
+	BulkUpdateHostMaxCount = 10000
+	// BulkUpdateHostBatchSize is the number of the hosts updated in a sub task of the bulk update task
+	BulkUpdateHostBatchSize = 100
+	// BulkUpdateHostSampleSize is the number of the sample hosts returned in the bulk update preview
+	BulkUpdateHostSampleSize = 10
+)
+
+// BulkUpdateHostOption update the hosts in a business that match the filter with the same data
+type BulkUpdateHostOption struct {
+	// SetIDs and ModuleIDs limit the hosts to those in the sets or modules of the business
+	SetIDs    []int64            `json:"bk_set_ids"`
+	ModuleIDs []int64            `json:"bk_module_ids"`
+	Filter    *filter.Expression `json:"filter"`
+	Data      mapstr.MapStr      `json:"data"`
+}
+
+// Validate BulkUpdateHostOption, the filter fields and the update data fields must be host attributes, and the
+// update data fields must be editable.
+func (o *BulkUpdateHostOption) Validate(hostAttrs []Attribute) errors.RawErrorInfo {
+	if o.Filter == nil || o.Filter.RuleFactory == nil {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"filter"}}
+	}
+
+	opt := filter.NewDefaultExprOpt(GetInstFilterFieldTypes(common.BKInnerObjIDHost, hostAttrs))
+	if err := o.Filter.Validate(opt); err != nil {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"filter"}}
+	}
+
+	if len(o.Data) == 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"data"}}
+	}
+
+	attrMap := make(map[string]Attribute)
+	for _, attr := range hostAttrs {
+		attrMap[attr.PropertyID] = attr
+	}
+
+	for field := range o.Data {
+		attr, exists := attrMap[field]
+		if !exists || !attr.IsEditable || field == common.BKHostIDField || field == common.BKCloudIDField {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid,
+				Args: []interface{}{fmt.Sprintf("data.%s", field)}}
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// BulkUpdateHostPreview is the dry-run result of the bulk host update
+type BulkUpdateHostPreview struct {
+	// Count is the number of the hosts that will be updated, the blocked hosts are not included
+	Count      int                    `json:"count"`
+	Samples    []BulkUpdateHostSample `json:"samples"`
+	FieldDiffs []BulkUpdateFieldDiff  `json:"field_diffs"`
+	Blocked    []BulkUpdateHostError  `json:"blocked_hosts"`
+}
+
+// NewBulkUpdateHostPreview generate the bulk host update preview of the hosts that are not blocked. the field values
+// are compared by their string representation, since the numbers in the update data are decoded from json.
+func NewBulkUpdateHostPreview(hosts []mapstr.MapStr, data mapstr.MapStr,
+	blocked []BulkUpdateHostError) *BulkUpdateHostPreview {
+
+	if blocked == nil {
+		blocked = make([]BulkUpdateHostError, 0)
+	}
+
+	preview := &BulkUpdateHostPreview{
+		Count:      len(hosts),
+		Samples:    make([]BulkUpdateHostSample, 0),
+		FieldDiffs: make([]BulkUpdateFieldDiff, 0),
+		Blocked:    blocked,
+	}
+
+	fields := make([]string, 0)
+	for field := range data {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	changedCount := make(map[string]int)
+	for _, host := range hosts {
+		changes := make(map[string]BulkUpdateFieldChange)
+		for _, field := range fields {
+			if fmt.Sprint(host[field]) == fmt.Sprint(data[field]) {
+				continue
+			}
+			changedCount[field]++
+			changes[field] = BulkUpdateFieldChange{Before: host[field], After: data[field]}
+		}
+
+		if len(preview.Samples) < BulkUpdateHostSampleSize {
+			hostID, _ := host.Int64(common.BKHostIDField)
+			preview.Samples = append(preview.Samples, BulkUpdateHostSample{
+				HostID:  hostID,
+				InnerIP: host[common.BKHostInnerIPField],
+				Changes: changes,
+			})
+		}
+	}
+
+	for _, field := range fields {
+		preview.FieldDiffs = append(preview.FieldDiffs, BulkUpdateFieldDiff{
+			Field:          field,
+			ChangedCount:   changedCount[field],
+			UnchangedCount: len(hosts) - changedCount[field],
+		})
+	}
+
+	return preview
+}
+
+// BulkUpdateHostSample is the changes of a sample host in the bulk host update
+type BulkUpdateHostSample struct {
+	HostID  int64                            `json:"bk_host_id"`
+	InnerIP interface{}                      `json:"bk_host_innerip"`
+	Changes map[string]BulkUpdateFieldChange `json:"changes"`
+}
+
+// BulkUpdateFieldChange is the value of a host field before and after the bulk host update
+type BulkUpdateFieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// BulkUpdateFieldDiff is the number of the hosts whose field value is changed or unchanged by the bulk host update
+type BulkUpdateFieldDiff struct {
+	Field          string `json:"field"`
+	ChangedCount   int    `json:"changed_count"`
+	UnchangedCount int    `json:"unchanged_count"`
+}
+
+// BulkUpdateHostError is a host that is blocked or failed in the bulk host update and the reason
+type BulkUpdateHostError struct {
+	HostID  int64  `json:"bk_host_id"`
+	Message string `json:"message"`
+}
+
+// BulkUpdateHostTaskData is the data of a sub task of the bulk host update task
+type BulkUpdateHostTaskData struct {
+	BizID   int64         `json:"bk_biz_id"`
+	HostIDs []int64       `json:"bk_host_ids"`
+	Data    mapstr.MapStr `json:"data"`
+}
+
+// BulkUpdateHostTaskResult is the result of a sub task of the bulk host update task
+type BulkUpdateHostTaskResult struct {
+	SuccessCount int                   `json:"success_count"`
+	Failed       []BulkUpdateHostError `json:"failed"`
+}
+
+// BulkUpdateHostTaskStatus is the progress and the per host errors of the bulk host update task
+type BulkUpdateHostTaskStatus struct {
+	TaskID        string                `json:"task_id"`
+	Status        APITaskStatus         `json:"status"`
+	TotalCount    int                   `json:"total_count"`
+	FinishedCount int                   `json:"finished_count"`
+	SuccessCount  int                   `json:"success_count"`
+	Failed        []BulkUpdateHostError `json:"failed"`
+}
+
+// BulkUpdateHostTaskResponse is the response of creating the bulk host update task
+type BulkUpdateHostTaskResponse struct {
+	TaskID       string                `json:"task_id"`
+	Count        int                   `json:"count"`
+	BlockedHosts []BulkUpdateHostError `json:"blocked_hosts"`
+}
@@ -0,0 +1,135 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"testing"
+
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/mapstr"
+)
+
+func TestBulkUpdateHostOptionValidate(t *testing.T) {
+	attrs := []Attribute{
+		{PropertyID: common.BKOSTypeField, PropertyType: common.FieldTypeSingleChar, IsEditable: true},
+		{PropertyID: common.BKHostOuterIPField, PropertyType: common.FieldTypeSingleChar, IsEditable: true},
+		{PropertyID: common.BKHostInnerIPField, PropertyType: common.FieldTypeSingleChar, IsEditable: false},
+	}
+
+	osFilter := &filter.Expression{
+		RuleFactory: &filter.AtomRule{
+			Field:    common.BKOSTypeField,
+			Operator: filter.OpFactory(filter.Equal),
+			Value:    "1",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		option  BulkUpdateHostOption
+		errCode int
+	}{
+		{
+			name:   "valid option",
+			option: BulkUpdateHostOption{Filter: osFilter, Data: mapstr.MapStr{common.BKHostOuterIPField: "1.1.1.1"}},
+		},
+		{
+			name:    "no filter",
+			option:  BulkUpdateHostOption{Data: mapstr.MapStr{common.BKHostOuterIPField: "1.1.1.1"}},
+			errCode: common.CCErrCommParamsNeedSet,
+		},
+		{
+			name: "filter field is not host attribute",
+			option: BulkUpdateHostOption{
+				Filter: &filter.Expression{
+					RuleFactory: &filter.AtomRule{
+						Field:    "not_exist",
+						Operator: filter.OpFactory(filter.Equal),
+						Value:    "1",
+					},
+				},
+				Data: mapstr.MapStr{common.BKHostOuterIPField: "1.1.1.1"},
+			},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "no data",
+			option:  BulkUpdateHostOption{Filter: osFilter},
+			errCode: common.CCErrCommParamsNeedSet,
+		},
+		{
+			name:    "data field is not editable",
+			option:  BulkUpdateHostOption{Filter: osFilter, Data: mapstr.MapStr{common.BKHostInnerIPField: "1.1.1.1"}},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "data field is cloud id",
+			option:  BulkUpdateHostOption{Filter: osFilter, Data: mapstr.MapStr{common.BKCloudIDField: 1}},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		if rawErr := test.option.Validate(attrs); rawErr.ErrCode != test.errCode {
+			t.Errorf("%s: expect error code %d, but got %d", test.name, test.errCode, rawErr.ErrCode)
+		}
+	}
+}
+
+func TestNewBulkUpdateHostPreview(t *testing.T) {
+	hosts := make([]mapstr.MapStr, 0)
+	for idx := 0; idx < BulkUpdateHostSampleSize+2; idx++ {
+		outerIP := "1.1.1.1"
+		if idx%2 == 0 {
+			outerIP = "2.2.2.2"
+		}
+		hosts = append(hosts, mapstr.MapStr{
+			common.BKHostIDField:      int64(idx + 1),
+			common.BKHostOuterIPField: outerIP,
+			common.BKOSTypeField:      "1",
+		})
+	}
+
+	data := mapstr.MapStr{common.BKHostOuterIPField: "2.2.2.2", common.BKOSTypeField: "2"}
+	preview := NewBulkUpdateHostPreview(hosts, data, nil)
+
+	if preview.Count != len(hosts) {
+		t.Errorf("expect count %d, but got %d", len(hosts), preview.Count)
+	}
+
+	if len(preview.Samples) != BulkUpdateHostSampleSize {
+		t.Errorf("expect %d samples, but got %d", BulkUpdateHostSampleSize, len(preview.Samples))
+	}
+
+	if preview.Blocked == nil {
+		t.Errorf("blocked hosts should not be nil")
+	}
+
+	if len(preview.Samples[0].Changes) != 1 || len(preview.Samples[1].Changes) != 2 {
+		t.Errorf("sample changes are not right, samples: %+v", preview.Samples[:2])
+	}
+
+	expected := []BulkUpdateFieldDiff{
+		{Field: common.BKHostOuterIPField, ChangedCount: len(hosts) / 2, UnchangedCount: len(hosts) / 2},
+		{Field: common.BKOSTypeField, ChangedCount: len(hosts), UnchangedCount: 0},
+	}
+	if len(preview.FieldDiffs) != len(expected) {
+		t.Fatalf("expect %d field diffs, but got %d", len(expected), len(preview.FieldDiffs))
+	}
+	for idx, diff := range expected {
+		if preview.FieldDiffs[idx] != diff {
+			t.Errorf("expect field diff %+v, but got %+v", diff, preview.FieldDiffs[idx])
+		}
+	}
+}
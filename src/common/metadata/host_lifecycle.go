@@ -0,0 +1,148 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+)
+
+const (
+	// hostLifecycleMaxStates is the max number of the states in a host lifecycle
+	hostLifecycleMaxStates = 50
+)
+
+// HostLifecycle is the lifecycle state machine of the hosts in a supplier account, the states are the values of the
+// host bk_state field, the state of a host can only be changed along the transitions. the lifecycle is disabled if
+// no state is defined.
+type HostLifecycle struct {
+	States          []HostLifecycleState      `json:"states" bson:"states"`
+	Transitions     []HostStateTransitionRule `json:"transitions" bson:"transitions"`
+	SupplierAccount string                    `json:"bk_supplier_account" bson:"bk_supplier_account"`
+	Modifier        string                    `json:"modifier" bson:"modifier"`
+	LastTime        time.Time                 `json:"last_time" bson:"last_time"`
+}
+
+// HostLifecycleState is a state of the host lifecycle and the rules of the state
+type HostLifecycleState struct {
+	// Name is the value of the host bk_state field
+	Name string `json:"name" bson:"name"`
+	// RequiredFields are the host fields that must be set when the host changes to the state
+	RequiredFields []string `json:"required_fields" bson:"required_fields"`
+	// MoveToIdle transfer the host to the idle module of its business when the host changes to the state
+	MoveToIdle bool `json:"move_to_idle" bson:"move_to_idle"`
+	// ForbidTransfer forbid transferring the host to other modules or businesses when the host is in the state
+	ForbidTransfer bool `json:"forbid_transfer" bson:"forbid_transfer"`
+}
+
+// HostStateTransitionRule is an allowed transition between two states of the host lifecycle
+type HostStateTransitionRule struct {
+	From string `json:"from" bson:"from"`
+	To   string `json:"to" bson:"to"`
+}
+
+// Validate HostLifecycle
+func (h *HostLifecycle) Validate() errors.RawErrorInfo {
+	if len(h.States) > hostLifecycleMaxStates {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit,
+			Args: []interface{}{"states", hostLifecycleMaxStates}}
+	}
+
+	stateMap := make(map[string]struct{})
+	for _, state := range h.States {
+		if len(state.Name) == 0 {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"states.name"}}
+		}
+
+		if _, exists := stateMap[state.Name]; exists {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"states.name"}}
+		}
+		stateMap[state.Name] = struct{}{}
+
+		for _, field := range state.RequiredFields {
+			if len(field) == 0 {
+				return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid,
+					Args: []interface{}{"states.required_fields"}}
+			}
+		}
+	}
+
+	transitionMap := make(map[HostStateTransitionRule]struct{})
+	for _, transition := range h.Transitions {
+		_, fromExists := stateMap[transition.From]
+		_, toExists := stateMap[transition.To]
+		if !fromExists || !toExists || transition.From == transition.To {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"transitions"}}
+		}
+
+		if _, exists := transitionMap[transition]; exists {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"transitions"}}
+		}
+		transitionMap[transition] = struct{}{}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// IsEnabled returns if the host lifecycle is enabled
+func (h *HostLifecycle) IsEnabled() bool {
+	return len(h.States) > 0
+}
+
+// GetState get the state of the host lifecycle by name, returns nil if the state is not in the lifecycle
+func (h *HostLifecycle) GetState(name string) *HostLifecycleState {
+	for idx := range h.States {
+		if h.States[idx].Name == name {
+			return &h.States[idx]
+		}
+	}
+	return nil
+}
+
+// CanTransit returns if the host can change from one state to another. the host whose state is not in the
+// lifecycle, e.g. the host created before the lifecycle is enabled, can change to any state of the lifecycle.
+func (h *HostLifecycle) CanTransit(from, to string) bool {
+	if from == to {
+		return true
+	}
+
+	if h.GetState(to) == nil {
+		return false
+	}
+
+	if h.GetState(from) == nil {
+		return true
+	}
+
+	for _, transition := range h.Transitions {
+		if transition.From == from && transition.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// HostStateTransition is a change of the lifecycle state of a host
+type HostStateTransition struct {
+	HostID int64  `json:"bk_host_id"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// HostLifecycleResponse host lifecycle response
+type HostLifecycleResponse struct {
+	BaseResp `json:",inline"`
+	Data     HostLifecycle `json:"data"`
+}
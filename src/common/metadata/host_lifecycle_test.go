@@ -0,0 +1,103 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"testing"
+
+	"configcenter/src/common"
+)
+
+func newTestHostLifecycle() HostLifecycle {
+	return HostLifecycle{
+		States: []HostLifecycleState{
+			{Name: "purchasing"},
+			{Name: "online", RequiredFields: []string{"operator"}},
+			{Name: "decommissioned", MoveToIdle: true, ForbidTransfer: true},
+		},
+		Transitions: []HostStateTransitionRule{
+			{From: "purchasing", To: "online"},
+			{From: "online", To: "decommissioned"},
+		},
+	}
+}
+
+func TestHostLifecycleValidate(t *testing.T) {
+	duplicateState := newTestHostLifecycle()
+	duplicateState.States = append(duplicateState.States, HostLifecycleState{Name: "online"})
+
+	emptyRequiredField := newTestHostLifecycle()
+	emptyRequiredField.States[1].RequiredFields = []string{""}
+
+	unknownState := newTestHostLifecycle()
+	unknownState.Transitions = append(unknownState.Transitions, HostStateTransitionRule{From: "online", To: "x"})
+
+	selfTransition := newTestHostLifecycle()
+	selfTransition.Transitions = append(selfTransition.Transitions,
+		HostStateTransitionRule{From: "online", To: "online"})
+
+	duplicateTransition := newTestHostLifecycle()
+	duplicateTransition.Transitions = append(duplicateTransition.Transitions,
+		HostStateTransitionRule{From: "online", To: "decommissioned"})
+
+	tooManyStates := HostLifecycle{States: make([]HostLifecycleState, hostLifecycleMaxStates+1)}
+
+	tests := []struct {
+		name      string
+		lifecycle HostLifecycle
+		errCode   int
+	}{
+		{name: "valid lifecycle", lifecycle: newTestHostLifecycle()},
+		{name: "disabled lifecycle", lifecycle: HostLifecycle{}},
+		{name: "too many states", lifecycle: tooManyStates, errCode: common.CCErrCommXXExceedLimit},
+		{name: "empty state name", lifecycle: HostLifecycle{States: []HostLifecycleState{{}}},
+			errCode: common.CCErrCommParamsNeedSet},
+		{name: "duplicate state", lifecycle: duplicateState, errCode: common.CCErrCommParamsInvalid},
+		{name: "empty required field", lifecycle: emptyRequiredField, errCode: common.CCErrCommParamsInvalid},
+		{name: "transition to unknown state", lifecycle: unknownState, errCode: common.CCErrCommParamsInvalid},
+		{name: "transition to itself", lifecycle: selfTransition, errCode: common.CCErrCommParamsInvalid},
+		{name: "duplicate transition", lifecycle: duplicateTransition, errCode: common.CCErrCommParamsInvalid},
+	}
+
+	for _, test := range tests {
+		if rawErr := test.lifecycle.Validate(); rawErr.ErrCode != test.errCode {
+			t.Errorf("%s: expect error code %d, but got %d", test.name, test.errCode, rawErr.ErrCode)
+		}
+	}
+}
+
+func TestHostLifecycleCanTransit(t *testing.T) {
+	lifecycle := newTestHostLifecycle()
+
+	tests := []struct {
+		from   string
+		to     string
+		expect bool
+	}{
+		{from: "purchasing", to: "online", expect: true},
+		{from: "online", to: "decommissioned", expect: true},
+		{from: "purchasing", to: "decommissioned", expect: false},
+		{from: "decommissioned", to: "online", expect: false},
+		{from: "online", to: "online", expect: true},
+		{from: "unknown", to: "unknown", expect: true},
+		{from: "unknown", to: "purchasing", expect: true},
+		{from: "", to: "online", expect: true},
+		{from: "online", to: "unknown", expect: false},
+	}
+
+	for _, test := range tests {
+		if result := lifecycle.CanTransit(test.from, test.to); result != test.expect {
+			t.Errorf("transit from %s to %s: expect %v, but got %v", test.from, test.to, test.expect, result)
+		}
+	}
+}
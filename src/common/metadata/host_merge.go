@@ -0,0 +1,156 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+)
+
+const (
+	// duplicateHostDefaultLimit is the default number of the duplicate host groups returned for each matching field
+	duplicateHostDefaultLimit = 100
+	// duplicateHostMaxLimit is the max number of the duplicate host groups returned for each matching field
+	duplicateHostMaxLimit = 500
+	// mergeHostMaxDuplicates is the max number of the duplicate hosts that can be merged into one host at a time
+	mergeHostMaxDuplicates = 20
+)
+
+// DuplicateHostMatchFields are the host fields that can be used to find the duplicate hosts
+var DuplicateHostMatchFields = []string{common.BKSNField, common.BKHostMacField, common.BKAssetIDField,
+	common.BKCloudInstIDField}
+
+// FindDuplicateHostOption find the hosts that share the same value of any of the matching fields
+type FindDuplicateHostOption struct {
+	// MatchFields are the host fields used to find the duplicate hosts, use all DuplicateHostMatchFields if not set
+	MatchFields []string `json:"match_fields"`
+	// Limit is the max number of the duplicate host groups returned for each matching field
+	Limit int `json:"limit"`
+}
+
+// Validate FindDuplicateHostOption, and set the default matching fields and limit
+func (o *FindDuplicateHostOption) Validate() errors.RawErrorInfo {
+	if len(o.MatchFields) == 0 {
+		o.MatchFields = DuplicateHostMatchFields
+	}
+
+	fieldMap := make(map[string]struct{})
+	for _, field := range DuplicateHostMatchFields {
+		fieldMap[field] = struct{}{}
+	}
+
+	for _, field := range o.MatchFields {
+		if _, exists := fieldMap[field]; !exists {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"match_fields"}}
+		}
+	}
+
+	if o.Limit < 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"limit"}}
+	}
+
+	if o.Limit == 0 {
+		o.Limit = duplicateHostDefaultLimit
+	}
+
+	if o.Limit > duplicateHostMaxLimit {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit,
+			Args: []interface{}{"limit", duplicateHostMaxLimit}}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// DuplicateHostGroup is a group of the hosts that share the same value of a matching field
+type DuplicateHostGroup struct {
+	Field   string      `json:"field"`
+	Value   interface{} `json:"value"`
+	HostIDs []int64     `json:"bk_host_ids"`
+}
+
+// DuplicateHostResponse find duplicate host response
+type DuplicateHostResponse struct {
+	BaseResp `json:",inline"`
+	Data     []DuplicateHostGroup `json:"data"`
+}
+
+// MergeHostOption merge the duplicate hosts into the host, the duplicate hosts are deleted after the merge
+type MergeHostOption struct {
+	// HostID is the id of the host that is kept
+	HostID int64 `json:"bk_host_id"`
+	// DuplicateHostIDs are the ids of the hosts that are merged into the kept host
+	DuplicateHostIDs []int64 `json:"duplicate_host_ids"`
+}
+
+// Validate MergeHostOption
+func (o *MergeHostOption) Validate() errors.RawErrorInfo {
+	if o.HostID <= 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{common.BKHostIDField}}
+	}
+
+	if len(o.DuplicateHostIDs) == 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"duplicate_host_ids"}}
+	}
+
+	if len(o.DuplicateHostIDs) > mergeHostMaxDuplicates {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit,
+			Args: []interface{}{"duplicate_host_ids", mergeHostMaxDuplicates}}
+	}
+
+	hostIDMap := map[int64]struct{}{o.HostID: {}}
+	for _, hostID := range o.DuplicateHostIDs {
+		if hostID <= 0 {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"duplicate_host_ids"}}
+		}
+
+		if _, exists := hostIDMap[hostID]; exists {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"duplicate_host_ids"}}
+		}
+		hostIDMap[hostID] = struct{}{}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// MergeHostResult is the data moved from the duplicate hosts to the kept host, it's also the preview of a merge
+type MergeHostResult struct {
+	HostID           int64   `json:"bk_host_id"`
+	DuplicateHostIDs []int64 `json:"duplicate_host_ids"`
+	BizID            int64   `json:"bk_biz_id"`
+	// AddedModuleIDs are the modules that the kept host is added to
+	AddedModuleIDs []int64 `json:"added_module_ids"`
+	// RemovedModuleIDs are the inner modules that the kept host is removed from, because it's added to normal modules
+	RemovedModuleIDs []int64 `json:"removed_module_ids"`
+	// HostApplyModuleIDs are the added modules that have host apply enabled, whose rules are applied to the kept host
+	HostApplyModuleIDs []int64 `json:"host_apply_module_ids"`
+	// ServiceInstanceIDs are the service instances that are moved to the kept host
+	ServiceInstanceIDs []int64 `json:"service_instance_ids"`
+	// ProcessIDs are the processes that are moved to the kept host
+	ProcessIDs []int64 `json:"process_ids"`
+	// DroppedServiceInstanceIDs are the service instances that are deleted with their processes, because the kept
+	// host or another duplicate host already has service instances in the same module
+	DroppedServiceInstanceIDs []int64 `json:"dropped_service_instance_ids"`
+	// DroppedProcessIDs are the processes of the dropped service instances
+	DroppedProcessIDs []int64 `json:"dropped_process_ids"`
+	// AssociationIDs are the instance associations that are moved to the kept host
+	AssociationIDs []int64 `json:"association_ids"`
+	// DroppedAssociationIDs are the instance associations that are deleted, because the kept host already has the
+	// same association, or the association becomes an association between the kept host and itself
+	DroppedAssociationIDs []int64 `json:"dropped_association_ids"`
+}
+
+// MergeHostResponse merge host response
+type MergeHostResponse struct {
+	BaseResp `json:",inline"`
+	Data     MergeHostResult `json:"data"`
+}
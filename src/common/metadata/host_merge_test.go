@@ -0,0 +1,116 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"testing"
+
+	"configcenter/src/common"
+)
+
+func TestFindDuplicateHostOptionValidate(t *testing.T) {
+	option := FindDuplicateHostOption{}
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		t.Fatalf("validate empty option failed, error code: %d", rawErr.ErrCode)
+	}
+
+	if len(option.MatchFields) != len(DuplicateHostMatchFields) || option.Limit != duplicateHostDefaultLimit {
+		t.Errorf("default match fields or limit is not set, option: %+v", option)
+	}
+
+	tests := []struct {
+		name    string
+		option  FindDuplicateHostOption
+		errCode int
+	}{
+		{
+			name:   "valid option",
+			option: FindDuplicateHostOption{MatchFields: []string{common.BKSNField}, Limit: 10},
+		},
+		{
+			name:    "invalid match field",
+			option:  FindDuplicateHostOption{MatchFields: []string{common.BKHostInnerIPField}},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "negative limit",
+			option:  FindDuplicateHostOption{Limit: -1},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "limit exceeded",
+			option:  FindDuplicateHostOption{Limit: duplicateHostMaxLimit + 1},
+			errCode: common.CCErrCommXXExceedLimit,
+		},
+	}
+
+	for _, test := range tests {
+		if rawErr := test.option.Validate(); rawErr.ErrCode != test.errCode {
+			t.Errorf("%s: expect error code %d, but got %d", test.name, test.errCode, rawErr.ErrCode)
+		}
+	}
+}
+
+func TestMergeHostOptionValidate(t *testing.T) {
+	tooManyHosts := make([]int64, mergeHostMaxDuplicates+1)
+	for idx := range tooManyHosts {
+		tooManyHosts[idx] = int64(idx + 2)
+	}
+
+	tests := []struct {
+		name    string
+		option  MergeHostOption
+		errCode int
+	}{
+		{
+			name:   "valid option",
+			option: MergeHostOption{HostID: 1, DuplicateHostIDs: []int64{2, 3}},
+		},
+		{
+			name:    "no kept host",
+			option:  MergeHostOption{DuplicateHostIDs: []int64{2}},
+			errCode: common.CCErrCommParamsNeedSet,
+		},
+		{
+			name:    "no duplicate host",
+			option:  MergeHostOption{HostID: 1},
+			errCode: common.CCErrCommParamsNeedSet,
+		},
+		{
+			name:    "too many duplicate hosts",
+			option:  MergeHostOption{HostID: 1, DuplicateHostIDs: tooManyHosts},
+			errCode: common.CCErrCommXXExceedLimit,
+		},
+		{
+			name:    "merge into itself",
+			option:  MergeHostOption{HostID: 1, DuplicateHostIDs: []int64{1}},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "repeated duplicate host",
+			option:  MergeHostOption{HostID: 1, DuplicateHostIDs: []int64{2, 2}},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "invalid duplicate host",
+			option:  MergeHostOption{HostID: 1, DuplicateHostIDs: []int64{0}},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		if rawErr := test.option.Validate(); rawErr.ErrCode != test.errCode {
+			t.Errorf("%s: expect error code %d, but got %d", test.name, test.errCode, rawErr.ErrCode)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+)
+
+// HostSnapDrift is a host field whose value reported by the agent snapshot differs from the value recorded in cmdb
+type HostSnapDrift struct {
+	HostID     int64       `json:"bk_host_id" bson:"bk_host_id"`
+	PropertyID string      `json:"bk_property_id" bson:"bk_property_id"`
+	AgentData  interface{} `json:"agent_data" bson:"agent_data"`
+	CmdbData   interface{} `json:"cmdb_data" bson:"cmdb_data"`
+	// Ignored defines if the field is configured not to be overwritten by the snapshot
+	Ignored bool `json:"ignored" bson:"ignored"`
+	// LastTime is the last time the drift is reported by the snapshot
+	LastTime        time.Time `json:"last_time" bson:"last_time"`
+	SupplierAccount string    `json:"bk_supplier_account" bson:"bk_supplier_account"`
+	ExpireAt        time.Time `json:"-" bson:"expire_at"`
+}
+
+// SearchHostSnapDriftOption search the drifts of the specified hosts option
+type SearchHostSnapDriftOption struct {
+	HostIDs     []int64  `json:"bk_host_ids"`
+	PropertyIDs []string `json:"bk_property_ids"`
+	Page        BasePage `json:"page"`
+}
+
+// Validate SearchHostSnapDriftOption
+func (s *SearchHostSnapDriftOption) Validate() errors.RawErrorInfo {
+	if len(s.HostIDs) == 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"bk_host_ids"}}
+	}
+
+	if s.Page.IsCursorPage() {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"page.cursor"}}
+	}
+
+	return s.Page.ValidateWithEnableCount(false, hostSnapMaxQueryLimit)
+}
+
+// ListBizHostSnapDriftOption list the drifts of the hosts in a business option
+type ListBizHostSnapDriftOption struct {
+	ModuleIDs   []int64  `json:"bk_module_ids"`
+	PropertyIDs []string `json:"bk_property_ids"`
+	Page        BasePage `json:"page"`
+}
+
+// Validate ListBizHostSnapDriftOption
+func (l *ListBizHostSnapDriftOption) Validate() errors.RawErrorInfo {
+	if len(l.ModuleIDs) > common.BKMaxLimitSize {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit,
+			Args: []interface{}{"bk_module_ids", common.BKMaxLimitSize}}
+	}
+
+	if l.Page.IsCursorPage() {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"page.cursor"}}
+	}
+
+	return l.Page.ValidateWithEnableCount(false, hostSnapMaxQueryLimit)
+}
+
+// HostSnapDriftSummaryOption summarize the drifts of the hosts in a business option
+type HostSnapDriftSummaryOption struct {
+	PropertyIDs []string `json:"bk_property_ids"`
+}
+
+// ModuleHostSnapDriftSummary the summary of the drifts of the hosts in a module
+type ModuleHostSnapDriftSummary struct {
+	ModuleID int64 `json:"bk_module_id"`
+	// HostCount is the number of hosts that have drifts in the module
+	HostCount int64 `json:"host_count"`
+	// DriftCount is the number of drifted fields of all the hosts in the module
+	DriftCount int64 `json:"drift_count"`
+	// PropertyCount is the number of hosts that have drifts in the module for each field
+	PropertyCount map[string]int64 `json:"property_count"`
+}
+
+// HostSnapDriftResult host snapshot drift query result
+type HostSnapDriftResult struct {
+	Count uint64          `json:"count"`
+	Info  []HostSnapDrift `json:"info"`
+}
+
+// HostSnapDriftResponse host snapshot drift query response
+type HostSnapDriftResponse struct {
+	BaseResp `json:",inline"`
+	Data     HostSnapDriftResult `json:"data"`
+}
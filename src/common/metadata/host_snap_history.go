@@ -0,0 +1,226 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/util"
+)
+
+// host snapshot history metrics, the value units are the same with the host snapshot in redis,
+// which means that the usages are percentages multiplied by 100, the used memory is in MB.
+const (
+	HostSnapMetricCPUUsage  = "cpu_usage"
+	HostSnapMetricMemUsage  = "mem_usage"
+	HostSnapMetricMemUsed   = "mem_used"
+	HostSnapMetricDiskUsage = "disk_usage"
+	HostSnapMetricLoad1     = "load1"
+	HostSnapMetricRcvRate   = "rcv_rate"
+	HostSnapMetricSendRate  = "send_rate"
+)
+
+// HostSnapMetrics all the metrics that are stored in the host snapshot history
+var HostSnapMetrics = []string{HostSnapMetricCPUUsage, HostSnapMetricMemUsage, HostSnapMetricMemUsed,
+	HostSnapMetricDiskUsage, HostSnapMetricLoad1, HostSnapMetricRcvRate, HostSnapMetricSendRate}
+
+const (
+	// hostSnapMaxQueryLimit is the max number of history points or change logs that can be queried at once
+	hostSnapMaxQueryLimit = 1000
+)
+
+// HostSnapMetricValue is the aggregated value of a metric in a downsampling window, the average is calculated
+// by the sum and count when the history point is queried.
+type HostSnapMetricValue struct {
+	Avg float64 `json:"avg" bson:"-"`
+	Min float64 `json:"min" bson:"min"`
+	Max float64 `json:"max" bson:"max"`
+	Sum float64 `json:"-" bson:"sum"`
+	// Count is the number of the samples that have this metric in the window
+	Count int64 `json:"count" bson:"count"`
+}
+
+// HostSnapHistory is a downsampled host snapshot, all the snapshots of a host reported in the same
+// downsampling window are aggregated into one history point.
+type HostSnapHistory struct {
+	HostID int64 `json:"bk_host_id" bson:"bk_host_id"`
+	// Time is the start time of the downsampling window
+	Time        time.Time `json:"time" bson:"time"`
+	SampleCount int64     `json:"sample_count" bson:"sample_count"`
+	// the hardware, os and ip inventory of the host in the last snapshot of the window
+	CPU      int64    `json:"bk_cpu" bson:"bk_cpu"`
+	Mem      int64    `json:"bk_mem" bson:"bk_mem"`
+	Disk     int64    `json:"bk_disk" bson:"bk_disk"`
+	OsName   string   `json:"os_name" bson:"os_name"`
+	HostName string   `json:"host_name" bson:"host_name"`
+	IPs      []string `json:"ips" bson:"ips"`
+	// Metrics is the aggregated metrics of the window, key is the metric name
+	Metrics         map[string]HostSnapMetricValue `json:"metrics" bson:"metrics"`
+	SupplierAccount string                         `json:"bk_supplier_account" bson:"bk_supplier_account"`
+	ExpireAt        time.Time                      `json:"-" bson:"expire_at"`
+}
+
+// CalcAvg calculate the average values of the metrics by their sums and counts
+func (h *HostSnapHistory) CalcAvg() {
+	for metric, val := range h.Metrics {
+		if val.Count > 0 {
+			val.Avg = val.Sum / float64(val.Count)
+		}
+		h.Metrics[metric] = val
+	}
+}
+
+// GenHostSnapSampleUpdate generate the update data of the operators that aggregate the metrics of a snapshot into
+// its history point atomically, the sample count and the metrics' sums and counts are increased, the minimums and
+// maximums are compared with the stored ones by the database.
+func GenHostSnapSampleUpdate(metrics map[string]float64) (inc, min, max map[string]interface{}) {
+	inc = map[string]interface{}{"sample_count": 1}
+	min = make(map[string]interface{})
+	max = make(map[string]interface{})
+
+	for metric, value := range metrics {
+		prefix := "metrics." + metric + "."
+		inc[prefix+"sum"] = value
+		inc[prefix+"count"] = 1
+		min[prefix+"min"] = value
+		max[prefix+"max"] = value
+	}
+	return inc, min, max
+}
+
+// HostSnapChangeLog is the change log of a hardware or os field of a host reported by the host snapshot
+type HostSnapChangeLog struct {
+	HostID          int64       `json:"bk_host_id" bson:"bk_host_id"`
+	PropertyID      string      `json:"bk_property_id" bson:"bk_property_id"`
+	PreData         interface{} `json:"pre_data" bson:"pre_data"`
+	CurData         interface{} `json:"cur_data" bson:"cur_data"`
+	Time            time.Time   `json:"time" bson:"time"`
+	SupplierAccount string      `json:"bk_supplier_account" bson:"bk_supplier_account"`
+	ExpireAt        time.Time   `json:"-" bson:"expire_at"`
+}
+
+// SearchHostSnapHistoryOption search host snapshot history option
+type SearchHostSnapHistoryOption struct {
+	HostID    int64      `json:"bk_host_id"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	// Metrics is the metrics to be returned, returns all metrics if not set
+	Metrics []string `json:"metrics"`
+	Page    BasePage `json:"page"`
+}
+
+// Validate SearchHostSnapHistoryOption
+func (s *SearchHostSnapHistoryOption) Validate() errors.RawErrorInfo {
+	if rawErr := validateHostSnapQuery(s.HostID, s.StartTime, s.EndTime, s.Page); rawErr.ErrCode != 0 {
+		return rawErr
+	}
+
+	for _, metric := range s.Metrics {
+		if !util.InStrArr(HostSnapMetrics, metric) {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"metrics"}}
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// GetFields returns the fields of the history points to be returned
+func (s *SearchHostSnapHistoryOption) GetFields() []string {
+	if len(s.Metrics) == 0 {
+		return make([]string, 0)
+	}
+
+	fields := []string{common.BKHostIDField, common.HostSnapTimeField, "sample_count", "bk_cpu", "bk_mem",
+		"bk_disk", "os_name", "host_name", "ips"}
+	for _, metric := range s.Metrics {
+		fields = append(fields, "metrics."+metric)
+	}
+	return fields
+}
+
+// SearchHostSnapChangeLogOption search host snapshot change log option
+type SearchHostSnapChangeLogOption struct {
+	HostID    int64      `json:"bk_host_id"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	// PropertyIDs is the changed host fields to be returned, returns all changed fields if not set
+	PropertyIDs []string `json:"bk_property_ids"`
+	Page        BasePage `json:"page"`
+}
+
+// Validate SearchHostSnapChangeLogOption
+func (s *SearchHostSnapChangeLogOption) Validate() errors.RawErrorInfo {
+	return validateHostSnapQuery(s.HostID, s.StartTime, s.EndTime, s.Page)
+}
+
+// validateHostSnapQuery validate the common parameters of the host snapshot history and change log query
+func validateHostSnapQuery(hostID int64, start, end *time.Time, page BasePage) errors.RawErrorInfo {
+	if hostID <= 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{common.BKHostIDField}}
+	}
+
+	if start != nil && end != nil && start.After(*end) {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"start_time"}}
+	}
+
+	if page.IsCursorPage() {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"page.cursor"}}
+	}
+
+	return page.ValidateWithEnableCount(false, hostSnapMaxQueryLimit)
+}
+
+// HostSnapTimeCond returns the query condition of a host's snapshot history or change log in a time range
+func HostSnapTimeCond(hostID int64, start, end *time.Time) map[string]interface{} {
+	cond := map[string]interface{}{
+		common.BKHostIDField: hostID,
+	}
+
+	timeCond := make(map[string]interface{})
+	if start != nil {
+		timeCond[common.BKDBGTE] = *start
+	}
+	if end != nil {
+		timeCond[common.BKDBLTE] = *end
+	}
+	if len(timeCond) > 0 {
+		cond[common.HostSnapTimeField] = timeCond
+	}
+	return cond
+}
+
+// HostSnapHistoryResult host snapshot history query result
+type HostSnapHistoryResult struct {
+	Count uint64            `json:"count"`
+	Info  []HostSnapHistory `json:"info"`
+}
+
+// HostSnapHistoryResponse host snapshot history query response
+type HostSnapHistoryResponse struct {
+	BaseResp `json:",inline"`
+	Data     HostSnapHistoryResult `json:"data"`
+}
+
+// HostSnapChangeLogResult host snapshot change log query result
+type HostSnapChangeLogResult struct {
+	Count uint64              `json:"count"`
+	Info  []HostSnapChangeLog `json:"info"`
+}
+
+// HostSnapChangeLogResponse host snapshot change log query response
+type HostSnapChangeLogResponse struct {
+	BaseResp `json:",inline"`
+	Data     HostSnapChangeLogResult `json:"data"`
+}
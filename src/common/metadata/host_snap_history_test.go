@@ -0,0 +1,104 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	"configcenter/src/common"
+)
+
+func TestGenHostSnapSampleUpdate(t *testing.T) {
+	inc, min, max := GenHostSnapSampleUpdate(map[string]float64{HostSnapMetricCPUUsage: 10})
+
+	if inc["sample_count"] != 1 || inc["metrics.cpu_usage.sum"] != float64(10) || inc["metrics.cpu_usage.count"] != 1 {
+		t.Fatalf("inc update %v is invalid", inc)
+	}
+
+	if min["metrics.cpu_usage.min"] != float64(10) || max["metrics.cpu_usage.max"] != float64(10) {
+		t.Fatalf("min update %v or max update %v is invalid", min, max)
+	}
+
+	// the sample without metrics only increases the sample count
+	inc, min, max = GenHostSnapSampleUpdate(nil)
+	if len(inc) != 1 || len(min) != 0 || len(max) != 0 {
+		t.Fatalf("update of the sample without metrics is invalid, inc: %v, min: %v, max: %v", inc, min, max)
+	}
+}
+
+func TestHostSnapHistoryCalcAvg(t *testing.T) {
+	history := &HostSnapHistory{
+		SampleCount: 3,
+		Metrics: map[string]HostSnapMetricValue{
+			HostSnapMetricCPUUsage: {Min: 10, Max: 30, Sum: 60, Count: 3},
+			HostSnapMetricMemUsage: {Min: 20, Max: 20, Sum: 20, Count: 1},
+		},
+	}
+	history.CalcAvg()
+
+	if cpu := history.Metrics[HostSnapMetricCPUUsage]; cpu.Avg != 20 {
+		t.Fatalf("cpu usage %+v is invalid", cpu)
+	}
+
+	// the average is calculated by the metric's own count, the samples without the metric are not counted in
+	if mem := history.Metrics[HostSnapMetricMemUsage]; mem.Avg != 20 {
+		t.Fatalf("mem usage %+v is invalid", mem)
+	}
+}
+
+func TestSearchHostSnapHistoryOptionValidate(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		option  SearchHostSnapHistoryOption
+		errCode int
+	}{
+		{
+			name:   "valid option",
+			option: SearchHostSnapHistoryOption{HostID: 1, StartTime: &before, EndTime: &now, Page: BasePage{Limit: 10}},
+		},
+		{
+			name:   "count option",
+			option: SearchHostSnapHistoryOption{HostID: 1, Page: BasePage{EnableCount: true}},
+		},
+		{
+			name:    "no host id",
+			option:  SearchHostSnapHistoryOption{Page: BasePage{Limit: 10}},
+			errCode: common.CCErrCommParamsNeedSet,
+		},
+		{
+			name:    "invalid time range",
+			option:  SearchHostSnapHistoryOption{HostID: 1, StartTime: &now, EndTime: &before, Page: BasePage{Limit: 10}},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "invalid metric",
+			option:  SearchHostSnapHistoryOption{HostID: 1, Metrics: []string{"xxx"}, Page: BasePage{Limit: 10}},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "exceed limit",
+			option:  SearchHostSnapHistoryOption{HostID: 1, Page: BasePage{Limit: hostSnapMaxQueryLimit + 1}},
+			errCode: common.CCErrCommPageLimitIsExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		if rawErr := test.option.Validate(); rawErr.ErrCode != test.errCode {
+			t.Errorf("%s: expect error code %d, but got %d", test.name, test.errCode, rawErr.ErrCode)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+)
+
+// HostTransferScheduleStatus is the status of a scheduled host transfer plan
+type HostTransferScheduleStatus string
+
+const (
+	// HostTransferScheduleWaiting the schedule is waiting for its execute time
+	HostTransferScheduleWaiting HostTransferScheduleStatus = "waiting"
+	// HostTransferScheduleExecuting the schedule is dispatched to the task server and is being executed
+	HostTransferScheduleExecuting HostTransferScheduleStatus = "executing"
+	// HostTransferScheduleSuccess the hosts are transferred successfully
+	HostTransferScheduleSuccess HostTransferScheduleStatus = "success"
+	// HostTransferScheduleFailed the host transfer failed, the reason is recorded in the message of the schedule
+	HostTransferScheduleFailed HostTransferScheduleStatus = "failed"
+	// HostTransferScheduleCanceled the schedule is canceled before it is executed
+	HostTransferScheduleCanceled HostTransferScheduleStatus = "canceled"
+)
+
+// Validate HostTransferScheduleStatus
+func (s HostTransferScheduleStatus) Validate() errors.RawErrorInfo {
+	switch s {
+	case HostTransferScheduleWaiting, HostTransferScheduleExecuting, HostTransferScheduleSuccess,
+		HostTransferScheduleFailed, HostTransferScheduleCanceled:
+		return errors.RawErrorInfo{}
+	}
+
+	return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{common.BKStatusField}}
+}
+
+// HostTransferSchedule is a host transfer plan with auto clear service instance that is executed at a future time
+type HostTransferSchedule struct {
+	ID    int64 `json:"id" bson:"id"`
+	BizID int64 `json:"bk_biz_id" bson:"bk_biz_id"`
+	// Option is the option of the host transfer with auto clear service instance api
+	Option      TransferHostWithAutoClearServiceInstanceOption `json:"option" bson:"option"`
+	ExecuteTime time.Time                                      `json:"execute_time" bson:"execute_time"`
+	Status      HostTransferScheduleStatus                     `json:"status" bson:"status"`
+	// TaskID is the id of the task server task that executes the schedule
+	TaskID string `json:"task_id" bson:"task_id"`
+	// Message is the failure reason of the schedule
+	Message string `json:"message" bson:"message"`
+	// Creator is the original requester of the schedule, the transfer is executed on behalf of the requester
+	Creator    string    `json:"bk_creator" bson:"bk_creator"`
+	LastEditor string    `json:"bk_last_editor" bson:"bk_last_editor"`
+	OwnerID    string    `json:"bk_supplier_account" bson:"bk_supplier_account"`
+	CreateTime time.Time `json:"create_time" bson:"create_time"`
+	LastTime   time.Time `json:"last_time" bson:"last_time"`
+}
+
+// CreateHostTransferScheduleOption schedule a host transfer with auto clear service instance plan at the execute time
+type CreateHostTransferScheduleOption struct {
+	TransferHostWithAutoClearServiceInstanceOption `json:",inline"`
+	ExecuteTime                                    time.Time `json:"execute_time"`
+}
+
+// Validate CreateHostTransferScheduleOption, the transfer option is validated by the host server
+func (o *CreateHostTransferScheduleOption) Validate() errors.RawErrorInfo {
+	if o.ExecuteTime.IsZero() {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"execute_time"}}
+	}
+
+	if !o.ExecuteTime.After(time.Now()) {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"execute_time"}}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// CreateHostTransferScheduleResult is the created schedule with the preview of the transfer at the creation time
+type CreateHostTransferScheduleResult struct {
+	ID       int64                 `json:"id"`
+	Previews []HostTransferPreview `json:"previews"`
+}
+
+// ListHostTransferScheduleOption list the host transfer schedules of a business
+type ListHostTransferScheduleOption struct {
+	BizID    int64                        `json:"bk_biz_id"`
+	IDs      []int64                      `json:"ids"`
+	Statuses []HostTransferScheduleStatus `json:"statuses"`
+	Page     BasePage                     `json:"page"`
+}
+
+// Validate ListHostTransferScheduleOption
+func (o *ListHostTransferScheduleOption) Validate() errors.RawErrorInfo {
+	for _, status := range o.Statuses {
+		if rawErr := status.Validate(); rawErr.ErrCode != 0 {
+			return rawErr
+		}
+	}
+
+	if o.Page.Limit == 0 {
+		o.Page.Limit = common.BKMaxPageSize
+	}
+
+	if o.Page.Start < 0 || o.Page.Limit < 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"page"}}
+	}
+
+	if o.Page.Limit > common.BKMaxPageSize {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommPageLimitIsExceeded}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// MultipleHostTransferSchedule is the host transfer schedules search result
+type MultipleHostTransferSchedule struct {
+	Count int64                  `json:"count"`
+	Info  []HostTransferSchedule `json:"info"`
+}
+
+// UpdateHostTransferScheduleStatusOption change the status of a schedule if its current status is one of the from
+// statuses, so that the concurrent status changes of a schedule are serialized.
+type UpdateHostTransferScheduleStatusOption struct {
+	ID      int64                        `json:"id"`
+	From    []HostTransferScheduleStatus `json:"from"`
+	To      HostTransferScheduleStatus   `json:"to"`
+	TaskID  string                       `json:"task_id"`
+	Message string                       `json:"message"`
+}
+
+// Validate UpdateHostTransferScheduleStatusOption
+func (o *UpdateHostTransferScheduleStatusOption) Validate() errors.RawErrorInfo {
+	if o.ID <= 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{common.BKFieldID}}
+	}
+
+	if len(o.From) == 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"from"}}
+	}
+
+	for _, status := range o.From {
+		if rawErr := status.Validate(); rawErr.ErrCode != 0 {
+			return rawErr
+		}
+	}
+
+	return o.To.Validate()
+}
+
+// HostTransferScheduleTaskData is the data of the task that executes a schedule
+type HostTransferScheduleTaskData struct {
+	BizID int64 `json:"bk_biz_id"`
+	ID    int64 `json:"id"`
+}
+
+// HostTransferScheduleResponse is the host transfer schedule response
+type HostTransferScheduleResponse struct {
+	BaseResp `json:",inline"`
+	Data     HostTransferSchedule `json:"data"`
+}
+
+// MultipleHostTransferScheduleResponse is the host transfer schedules search response
+type MultipleHostTransferScheduleResponse struct {
+	BaseResp `json:",inline"`
+	Data     MultipleHostTransferSchedule `json:"data"`
+}
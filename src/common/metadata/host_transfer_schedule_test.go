@@ -0,0 +1,95 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	"configcenter/src/common"
+)
+
+func TestCreateHostTransferScheduleOptionValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		executeTime time.Time
+		errCode     int
+	}{
+		{name: "future execute time", executeTime: time.Now().Add(time.Hour)},
+		{name: "no execute time", errCode: common.CCErrCommParamsNeedSet},
+		{name: "past execute time", executeTime: time.Now().Add(-time.Minute), errCode: common.CCErrCommParamsInvalid},
+	}
+
+	for _, tt := range tests {
+		opt := CreateHostTransferScheduleOption{ExecuteTime: tt.executeTime}
+		if rawErr := opt.Validate(); rawErr.ErrCode != tt.errCode {
+			t.Errorf("%s: expect error code %d, got %d", tt.name, tt.errCode, rawErr.ErrCode)
+		}
+	}
+}
+
+func TestListHostTransferScheduleOptionValidate(t *testing.T) {
+	opt := ListHostTransferScheduleOption{Statuses: []HostTransferScheduleStatus{HostTransferScheduleWaiting}}
+	if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+		t.Fatalf("expect valid option, got error code %d", rawErr.ErrCode)
+	}
+	if opt.Page.Limit != common.BKMaxPageSize {
+		t.Errorf("expect default page limit %d, got %d", common.BKMaxPageSize, opt.Page.Limit)
+	}
+
+	opt = ListHostTransferScheduleOption{Statuses: []HostTransferScheduleStatus{"unknown"}}
+	if rawErr := opt.Validate(); rawErr.ErrCode != common.CCErrCommParamsInvalid {
+		t.Errorf("expect invalid status error, got error code %d", rawErr.ErrCode)
+	}
+
+	opt = ListHostTransferScheduleOption{Page: BasePage{Limit: common.BKMaxPageSize + 1}}
+	if rawErr := opt.Validate(); rawErr.ErrCode != common.CCErrCommPageLimitIsExceeded {
+		t.Errorf("expect page limit exceeded error, got error code %d", rawErr.ErrCode)
+	}
+}
+
+func TestUpdateHostTransferScheduleStatusOptionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     UpdateHostTransferScheduleStatusOption
+		errCode int
+	}{
+		{
+			name: "valid option",
+			opt: UpdateHostTransferScheduleStatusOption{ID: 1,
+				From: []HostTransferScheduleStatus{HostTransferScheduleWaiting}, To: HostTransferScheduleCanceled},
+		},
+		{
+			name:    "invalid id",
+			opt:     UpdateHostTransferScheduleStatusOption{To: HostTransferScheduleCanceled},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "no from status",
+			opt:     UpdateHostTransferScheduleStatusOption{ID: 1, To: HostTransferScheduleCanceled},
+			errCode: common.CCErrCommParamsNeedSet,
+		},
+		{
+			name: "invalid to status",
+			opt: UpdateHostTransferScheduleStatusOption{ID: 1,
+				From: []HostTransferScheduleStatus{HostTransferScheduleWaiting}, To: "done"},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		if rawErr := tt.opt.Validate(); rawErr.ErrCode != tt.errCode {
+			t.Errorf("%s: expect error code %d, got %d", tt.name, tt.errCode, rawErr.ErrCode)
+		}
+	}
+}
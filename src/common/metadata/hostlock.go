@@ -14,13 +14,51 @@ package metadata
 
 import (
 	"time"
+	"unicode/utf8"
 
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
 	"configcenter/src/common/mapstr"
 )
 
+const (
+	// hostLockReasonMaxLength is the max length of the host lock reason
+	hostLockReasonMaxLength = 256
+)
+
 // HostLockRequest TODO
 type HostLockRequest struct {
 	IDS []int64 `json:"id_list"`
+	// Reason is the reason to lock the hosts, it is optional
+	Reason string `json:"reason"`
+	// ExpireTime is the time when the locks expire, the locks never expire if not set
+	ExpireTime *time.Time `json:"expire_time"`
+}
+
+// ValidateLock validate the request of locking hosts
+func (h *HostLockRequest) ValidateLock() errors.RawErrorInfo {
+	if len(h.IDS) == 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"id_list"}}
+	}
+
+	if rawErr := ValidateHostLockReason("reason", h.Reason); rawErr.ErrCode != 0 {
+		return rawErr
+	}
+
+	if h.ExpireTime != nil && !h.ExpireTime.After(time.Now()) {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"expire_time"}}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// ValidateHostLockReason validate the length of the host lock reason or the host lock override reason in runes
+func ValidateHostLockReason(field, reason string) errors.RawErrorInfo {
+	if utf8.RuneCountInString(reason) > hostLockReasonMaxLength {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommValExceedMaxFailed,
+			Args: []interface{}{field, hostLockReasonMaxLength}}
+	}
+	return errors.RawErrorInfo{}
 }
 
 // QueryHostLockRequest TODO
@@ -36,10 +74,12 @@ type HostLockResultResponse struct {
 
 // HostLockData TODO
 type HostLockData struct {
-	User       string    `json:"bk_user" bson:"bk_user"`
-	ID         int64     `json:"bk_host_id" bson:"bk_host_id"`
-	CreateTime time.Time `json:"create_time" bson:"create_time"`
-	OwnerID    string    `json:"-" bson:"bk_supplier_account"`
+	User       string     `json:"bk_user" bson:"bk_user"`
+	ID         int64      `json:"bk_host_id" bson:"bk_host_id"`
+	Reason     string     `json:"reason" bson:"reason"`
+	ExpireTime *time.Time `json:"expire_time,omitempty" bson:"expire_time,omitempty"`
+	CreateTime time.Time  `json:"create_time" bson:"create_time"`
+	OwnerID    string     `json:"-" bson:"bk_supplier_account"`
 }
 
 // HostLockQueryResponse TODO
@@ -0,0 +1,70 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"configcenter/src/common"
+)
+
+func TestHostLockRequestValidateLock(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		request HostLockRequest
+		errCode int
+	}{
+		{
+			name:    "valid request",
+			request: HostLockRequest{IDS: []int64{1}, Reason: "maintenance"},
+		},
+		{
+			name:    "valid request with expire time",
+			request: HostLockRequest{IDS: []int64{1}, Reason: "maintenance", ExpireTime: &future},
+		},
+		{
+			name:    "no host id",
+			request: HostLockRequest{Reason: "maintenance"},
+			errCode: common.CCErrCommParamsNeedSet,
+		},
+		{
+			name:    "no reason",
+			request: HostLockRequest{IDS: []int64{1}},
+		},
+		{
+			name:    "multi-byte reason in max length",
+			request: HostLockRequest{IDS: []int64{1}, Reason: strings.Repeat("维", hostLockReasonMaxLength)},
+		},
+		{
+			name:    "reason too long",
+			request: HostLockRequest{IDS: []int64{1}, Reason: strings.Repeat("a", hostLockReasonMaxLength+1)},
+			errCode: common.CCErrCommValExceedMaxFailed,
+		},
+		{
+			name:    "expired",
+			request: HostLockRequest{IDS: []int64{1}, Reason: "maintenance", ExpireTime: &past},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		if rawErr := test.request.ValidateLock(); rawErr.ErrCode != test.errCode {
+			t.Errorf("%s: expect error code %d, but got %d", test.name, test.errCode, rawErr.ErrCode)
+		}
+	}
+}
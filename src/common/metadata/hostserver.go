@@ -656,6 +656,8 @@ type ListHostResp struct {
 type ListHostResult struct {
 	Count int                      `json:"count"`
 	Info  []map[string]interface{} `json:"info"`
+	// NextCursor is the cursor of the next page when using keyset pagination, it's empty if there is no more data.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // HostTopoResult TODO
@@ -885,29 +887,29 @@ type TopoNodeHostCount struct {
 
 // TransferHostWithAutoClearServiceInstanceOption TODO
 type TransferHostWithAutoClearServiceInstanceOption struct {
-	HostIDs []int64 `json:"bk_host_ids"`
+	HostIDs []int64 `json:"bk_host_ids" bson:"bk_host_ids"`
 
-	RemoveFromModules []int64 `json:"remove_from_modules,omitempty"`
-	AddToModules      []int64 `json:"add_to_modules,omitempty"`
+	RemoveFromModules []int64 `json:"remove_from_modules,omitempty" bson:"remove_from_modules,omitempty"`
+	AddToModules      []int64 `json:"add_to_modules,omitempty" bson:"add_to_modules,omitempty"`
 	// 主机从 RemoveFromModules 移除后如果不再属于其它模块， 默认转移到空闲机模块
 	// DefaultInternalModule 支持调整这种默认行为，可设置成待回收模块或者故障机模块
-	DefaultInternalModule int64 `json:"default_internal_module,omitempty"`
+	DefaultInternalModule int64 `json:"default_internal_module,omitempty" bson:"default_internal_module,omitempty"`
 	// IsRemoveFromAll if set, remove host from all of its current modules, if not, use remove_from_modules
-	IsRemoveFromAll bool `json:"is_remove_from_all"`
+	IsRemoveFromAll bool `json:"is_remove_from_all" bson:"is_remove_from_all"`
 
-	Options TransferOptions `json:"options,omitempty"`
+	Options TransferOptions `json:"options,omitempty" bson:"options"`
 }
 
 // TransferOptions TODO
 type TransferOptions struct {
-	ServiceInstanceOptions ServiceInstanceOptions `json:"service_instance_options"`
+	ServiceInstanceOptions ServiceInstanceOptions `json:"service_instance_options" bson:"service_instance_options"`
 
 	// HostApplyConflictResolvers update the attribute value of the host with the host as the dimension.
-	HostApplyConflictResolvers []HostApplyConflictResolver `json:"host_apply_conflict_resolvers"`
+	HostApplyConflictResolvers []HostApplyConflictResolver `json:"host_apply_conflict_resolvers" bson:"host_apply_conflict_resolvers"`
 
 	// HostApplyTransPropertyRule update attributes with the dimension of the rule, which is used to update the host
 	// attribute value in the host transfer scenario。
-	HostApplyTransPropertyRule HostApplyTransRules `json:"host_apply_trans_rule"`
+	HostApplyTransPropertyRule HostApplyTransRules `json:"host_apply_trans_rule" bson:"host_apply_trans_rule"`
 }
 
 // HostTransferPlan TODO
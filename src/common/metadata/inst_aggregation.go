@@ -0,0 +1,304 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/mapstr"
+)
+
+const (
+	// MaxInstAggregateGroupKeys is the maximum number of the group by keys of an instance aggregation.
+	MaxInstAggregateGroupKeys = 3
+	// MaxInstAggregateMetrics is the maximum number of the metrics of an instance aggregation.
+	MaxInstAggregateMetrics = 5
+	// MaxInstAggregateLimit is the maximum number of the groups that an instance aggregation can return.
+	MaxInstAggregateLimit = 500
+	// MaxInstAggregateGroups is the maximum number of the groups that an instance aggregation can generate,
+	// the aggregation is rejected if the group by keys have a higher cardinality to protect the db.
+	MaxInstAggregateGroups = 10000
+	// MaxInstAggregateInputs is the maximum number of the instances that an instance aggregation can group, the
+	// aggregation is rejected if the filter matches more instances to protect the db.
+	MaxInstAggregateInputs = 200000
+	// DefaultInstAggregateTimeZone is the time zone that the time values are bucketed in if it is not specified.
+	DefaultInstAggregateTimeZone = "UTC"
+)
+
+// HostRelationAggregateFields are the host's topology relation fields that can be used in host aggregation.
+var HostRelationAggregateFields = []string{common.BKAppIDField, common.BKSetIDField, common.BKModuleIDField}
+
+// AggregateMetricType is the type of the instance aggregation metric.
+type AggregateMetricType string
+
+const (
+	// AggregateCount counts the instances in the group.
+	AggregateCount AggregateMetricType = "count"
+	// AggregateSum sums the field's values of the instances in the group.
+	AggregateSum AggregateMetricType = "sum"
+	// AggregateMin gets the minimum field value of the instances in the group.
+	AggregateMin AggregateMetricType = "min"
+	// AggregateMax gets the maximum field value of the instances in the group.
+	AggregateMax AggregateMetricType = "max"
+	// AggregateDistinctCount counts the distinct field values of the instances in the group.
+	AggregateDistinctCount AggregateMetricType = "distinct_count"
+)
+
+// AggregateTimeUnit is the time unit used to bucket the time or date field values of the group by key.
+type AggregateTimeUnit string
+
+const (
+	// AggregateByDay buckets the field values by day.
+	AggregateByDay AggregateTimeUnit = "day"
+	// AggregateByMonth buckets the field values by month.
+	AggregateByMonth AggregateTimeUnit = "month"
+	// AggregateByYear buckets the field values by year.
+	AggregateByYear AggregateTimeUnit = "year"
+)
+
+// Validate validates the time unit.
+func (t AggregateTimeUnit) Validate() error {
+	switch t {
+	case AggregateByDay, AggregateByMonth, AggregateByYear:
+		return nil
+	default:
+		return fmt.Errorf("unsupported time unit: %s", t)
+	}
+}
+
+// AggregateGroupKey is the group by key of the instance aggregation.
+type AggregateGroupKey struct {
+	// Field is the field that instances are grouped by.
+	Field string `json:"field"`
+	// TimeUnit defines the time unit to bucket the time or date field values, the values are not bucketed if not set.
+	TimeUnit AggregateTimeUnit `json:"time_unit,omitempty"`
+}
+
+// AggregateMetric is the metric that is calculated for each group of the instance aggregation.
+type AggregateMetric struct {
+	Type AggregateMetricType `json:"type"`
+	// Field is the field to calculate the metric, it is not needed for count metric.
+	Field string `json:"field,omitempty"`
+}
+
+// Name returns the metric's name in the aggregation result, which is the metric type joined by the field.
+func (m AggregateMetric) Name() string {
+	if m.Type == AggregateCount {
+		return string(m.Type)
+	}
+	return string(m.Type) + "_" + m.Field
+}
+
+// InstAggregateOption is the option to aggregate model instances.
+type InstAggregateOption struct {
+	// Filter is used to filter the instances that are aggregated, all instances are aggregated if not set.
+	Filter *filter.Expression `json:"filter,omitempty"`
+	// GroupBy is the keys to group the instances by.
+	GroupBy []AggregateGroupKey `json:"group_by"`
+	// Metrics is the metrics that is calculated for each group.
+	Metrics []AggregateMetric `json:"metrics"`
+	// Sort is the metric name or the group by field that the groups are sorted by, use "-" prefix for descending
+	// order. default is the first metric in descending order, which can be used with limit to get the top N groups.
+	Sort string `json:"sort,omitempty"`
+	// Limit is the maximum number of the groups that is returned.
+	Limit int `json:"limit"`
+	// TimeZone is the IANA time zone name (e.g. Asia/Shanghai) that the time values are bucketed in when the group
+	// by key has a time unit, default is UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// GetTimeZone returns the time zone that the time values are bucketed in.
+func (o *InstAggregateOption) GetTimeZone() string {
+	if len(o.TimeZone) == 0 {
+		return DefaultInstAggregateTimeZone
+	}
+	return o.TimeZone
+}
+
+// Validate validates the instance aggregation option's structure,
+// return the key and error if any one of keys is invalid.
+func (o *InstAggregateOption) Validate() (string, error) {
+	if len(o.GroupBy) == 0 {
+		return "group_by", errors.New("group_by is not set")
+	}
+
+	if len(o.GroupBy) > MaxInstAggregateGroupKeys {
+		return "group_by", fmt.Errorf("group_by exceeds maximum length %d", MaxInstAggregateGroupKeys)
+	}
+
+	groupFields := make(map[string]struct{})
+	for _, key := range o.GroupBy {
+		if len(key.Field) == 0 {
+			return "group_by.field", errors.New("group by field is not set")
+		}
+
+		if _, exists := groupFields[key.Field]; exists {
+			return "group_by.field", fmt.Errorf("group by field %s is duplicated", key.Field)
+		}
+		groupFields[key.Field] = struct{}{}
+
+		if len(key.TimeUnit) == 0 {
+			continue
+		}
+
+		if err := key.TimeUnit.Validate(); err != nil {
+			return "group_by.time_unit", err
+		}
+	}
+
+	if len(o.Metrics) == 0 {
+		return "metrics", errors.New("metrics is not set")
+	}
+
+	if len(o.Metrics) > MaxInstAggregateMetrics {
+		return "metrics", fmt.Errorf("metrics exceeds maximum length %d", MaxInstAggregateMetrics)
+	}
+
+	metricNames := make(map[string]struct{})
+	for _, metric := range o.Metrics {
+		switch metric.Type {
+		case AggregateCount:
+			if len(metric.Field) != 0 {
+				return "metrics.field", errors.New("count metric does not need field")
+			}
+		case AggregateSum, AggregateMin, AggregateMax, AggregateDistinctCount:
+			if len(metric.Field) == 0 {
+				return "metrics.field", fmt.Errorf("%s metric field is not set", metric.Type)
+			}
+		default:
+			return "metrics.type", fmt.Errorf("unsupported metric type: %s", metric.Type)
+		}
+
+		if _, exists := metricNames[metric.Name()]; exists {
+			return "metrics", fmt.Errorf("metric %s is duplicated", metric.Name())
+		}
+		metricNames[metric.Name()] = struct{}{}
+	}
+
+	if len(o.Sort) > 0 {
+		sortField := strings.TrimPrefix(o.Sort, "-")
+		_, isMetric := metricNames[sortField]
+		_, isGroupField := groupFields[sortField]
+		if !isMetric && !isGroupField {
+			return "sort", fmt.Errorf("sort field %s is neither a metric nor a group by field", sortField)
+		}
+	}
+
+	if o.Limit <= 0 || o.Limit > MaxInstAggregateLimit {
+		return "limit", fmt.Errorf("limit should be in the range of (0, %d]", MaxInstAggregateLimit)
+	}
+
+	if len(o.TimeZone) > 0 {
+		if _, err := time.LoadLocation(o.TimeZone); err != nil {
+			return "time_zone", fmt.Errorf("time zone %s is invalid, err: %v", o.TimeZone, err)
+		}
+	}
+
+	return "", nil
+}
+
+// ValidateFields validates the fields used in the instance aggregation option by the property types of the fields,
+// return the key and error if any one of keys is invalid.
+func (o *InstAggregateOption) ValidateFields(propertyTypes map[string]string) (string, error) {
+	if o.Filter != nil {
+		ruleFields := make(map[string]enumor.FieldType)
+		for field, propertyType := range propertyTypes {
+			attr := Attribute{PropertyType: propertyType}
+			if fieldType, ok := attr.FilterFieldType(); ok {
+				ruleFields[field] = fieldType
+			}
+		}
+
+		if err := o.Filter.Validate(filter.NewDefaultExprOpt(ruleFields)); err != nil {
+			return "filter", err
+		}
+	}
+
+	for _, key := range o.GroupBy {
+		propertyType, exists := propertyTypes[key.Field]
+		if !exists {
+			return "group_by.field", fmt.Errorf("group by field %s is not exist", key.Field)
+		}
+
+		switch propertyType {
+		case common.FieldTypeTable, common.FieldTypeOrganization, common.FieldObject:
+			return "group_by.field", fmt.Errorf("can not group by %s type field %s", propertyType, key.Field)
+		case common.FieldTypeTime, common.FieldTypeDate:
+		default:
+			if len(key.TimeUnit) != 0 {
+				return "group_by.time_unit", fmt.Errorf("group by field %s is not time or date type", key.Field)
+			}
+		}
+	}
+
+	for _, metric := range o.Metrics {
+		if metric.Type == AggregateCount {
+			continue
+		}
+
+		propertyType, exists := propertyTypes[metric.Field]
+		if !exists {
+			return "metrics.field", fmt.Errorf("metric field %s is not exist", metric.Field)
+		}
+
+		switch metric.Type {
+		case AggregateSum:
+			if propertyType != common.FieldTypeInt && propertyType != common.FieldTypeFloat {
+				return "metrics.field", fmt.Errorf("sum metric field %s is not numeric type", metric.Field)
+			}
+		case AggregateMin, AggregateMax:
+			switch propertyType {
+			case common.FieldTypeInt, common.FieldTypeFloat, common.FieldTypeTime, common.FieldTypeDate:
+			default:
+				return "metrics.field", fmt.Errorf("%s metric field %s is not numeric or time type", metric.Type,
+					metric.Field)
+			}
+		case AggregateDistinctCount:
+			switch propertyType {
+			case common.FieldTypeTable, common.FieldTypeOrganization, common.FieldObject:
+				return "metrics.field", fmt.Errorf("can not count distinct %s type field %s", propertyType,
+					metric.Field)
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// InstAggregateGroup is one group of the instance aggregation result.
+type InstAggregateGroup struct {
+	// Keys is the group by fields and their values of the group.
+	Keys mapstr.MapStr `json:"keys" bson:"keys"`
+	// Metrics is the metric names and their values of the group.
+	Metrics mapstr.MapStr `json:"metrics" bson:"metrics"`
+}
+
+// InstAggregateResult is the instance aggregation result.
+type InstAggregateResult struct {
+	// Total is the total number of the groups.
+	Total int64 `json:"total"`
+	// Groups is the sorted groups limited by the aggregation option's limit.
+	Groups []InstAggregateGroup `json:"groups"`
+}
+
+// InstAggregateResp is the instance aggregation response.
+type InstAggregateResp struct {
+	BaseResp `json:",inline"`
+	Data     *InstAggregateResult `json:"data"`
+}
@@ -0,0 +1,142 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"testing"
+
+	"configcenter/src/common"
+	"configcenter/src/common/json"
+)
+
+func TestInstAggregateOptionValidate(t *testing.T) {
+	propertyTypes := map[string]string{
+		"bk_inst_name":         common.FieldTypeSingleChar,
+		"cpu":                  common.FieldTypeInt,
+		"online_date":          common.FieldTypeDate,
+		common.CreateTimeField: common.FieldTypeTime,
+		"tags":                 common.FieldTypeTable,
+		common.BKModuleIDField: common.FieldTypeInt,
+	}
+
+	tests := []struct {
+		name       string
+		option     string
+		invalidKey string
+	}{
+		{
+			name: "valid option",
+			option: `{"filter":{"field":"cpu","operator":"greater","value":2},
+"group_by":[{"field":"online_date","time_unit":"month"},{"field":"bk_module_id"}],
+"metrics":[{"type":"count"},{"type":"sum","field":"cpu"},{"type":"distinct_count","field":"bk_inst_name"}],
+"sort":"-sum_cpu","limit":10,"time_zone":"Asia/Shanghai"}`,
+		},
+		{
+			name:       "no group by keys",
+			option:     `{"metrics":[{"type":"count"}],"limit":10}`,
+			invalidKey: "group_by",
+		},
+		{
+			name: "too many group by keys",
+			option: `{"group_by":[{"field":"a"},{"field":"b"},{"field":"c"},{"field":"d"}],
+"metrics":[{"type":"count"}],"limit":10}`,
+			invalidKey: "group_by",
+		},
+		{
+			name:       "invalid time unit",
+			option:     `{"group_by":[{"field":"create_time","time_unit":"hour"}],"metrics":[{"type":"count"}],"limit":10}`,
+			invalidKey: "group_by.time_unit",
+		},
+		{
+			name:       "count metric with field",
+			option:     `{"group_by":[{"field":"cpu"}],"metrics":[{"type":"count","field":"cpu"}],"limit":10}`,
+			invalidKey: "metrics.field",
+		},
+		{
+			name:       "duplicate metrics",
+			option:     `{"group_by":[{"field":"cpu"}],"metrics":[{"type":"count"},{"type":"count"}],"limit":10}`,
+			invalidKey: "metrics",
+		},
+		{
+			name:       "invalid sort field",
+			option:     `{"group_by":[{"field":"cpu"}],"metrics":[{"type":"count"}],"sort":"-max_cpu","limit":10}`,
+			invalidKey: "sort",
+		},
+		{
+			name:       "limit exceeds maximum",
+			option:     `{"group_by":[{"field":"cpu"}],"metrics":[{"type":"count"}],"limit":501}`,
+			invalidKey: "limit",
+		},
+		{
+			name: "invalid time zone",
+			option: `{"group_by":[{"field":"online_date","time_unit":"day"}],"metrics":[{"type":"count"}],"limit":10,
+"time_zone":"+08:00"}`,
+			invalidKey: "time_zone",
+		},
+		{
+			name: "filter with invalid field type",
+			option: `{"filter":{"field":"cpu","operator":"equal","value":"a"},"group_by":[{"field":"cpu"}],
+"metrics":[{"type":"count"}],"limit":10}`,
+			invalidKey: "filter",
+		},
+		{
+			name:       "group by not exist field",
+			option:     `{"group_by":[{"field":"memory"}],"metrics":[{"type":"count"}],"limit":10}`,
+			invalidKey: "group_by.field",
+		},
+		{
+			name:       "group by table field",
+			option:     `{"group_by":[{"field":"tags"}],"metrics":[{"type":"count"}],"limit":10}`,
+			invalidKey: "group_by.field",
+		},
+		{
+			name:       "bucket non-time field",
+			option:     `{"group_by":[{"field":"cpu","time_unit":"day"}],"metrics":[{"type":"count"}],"limit":10}`,
+			invalidKey: "group_by.time_unit",
+		},
+		{
+			name:       "sum non-numeric field",
+			option:     `{"group_by":[{"field":"cpu"}],"metrics":[{"type":"sum","field":"bk_inst_name"}],"limit":10}`,
+			invalidKey: "metrics.field",
+		},
+		{
+			name:       "max time field",
+			option:     `{"group_by":[{"field":"cpu"}],"metrics":[{"type":"max","field":"create_time"}],"limit":10}`,
+			invalidKey: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			option := new(InstAggregateOption)
+			if err := json.Unmarshal([]byte(tt.option), option); err != nil {
+				t.Errorf("unmarshal option failed, err: %v", err)
+				return
+			}
+
+			invalidKey, err := option.Validate()
+			if err == nil {
+				invalidKey, err = option.ValidateFields(propertyTypes)
+			}
+
+			if invalidKey != tt.invalidKey {
+				t.Errorf("invalid key %s is not as expected %s, err: %v", invalidKey, tt.invalidKey, err)
+				return
+			}
+
+			if (err != nil) != (len(tt.invalidKey) != 0) {
+				t.Errorf("validate result err %v is not as expected", err)
+			}
+		})
+	}
+}
@@ -154,6 +154,8 @@ type QueryObjectAssociationResult struct {
 type InstResult struct {
 	Count int             `json:"count"`
 	Info  []mapstr.MapStr `json:"info"`
+	// NextCursor is the cursor of the next page when using keyset pagination, it's empty if there is no more data.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // QueryInstResult query inst result
@@ -42,13 +42,24 @@ type BasePage struct {
 	Limit       int    `json:"limit,omitempty" mapstructure:"limit"`
 	Start       int    `json:"start" mapstructure:"start"`
 	EnableCount bool   `json:"enable_count,omitempty" mapstructure:"enable_count,omitempty"`
+	// EnableCursor enables the keyset pagination for the first page, the data is sorted by the stable key and the
+	// next_cursor is returned if there are more data, can not be used with start.
+	EnableCursor bool `json:"enable_cursor,omitempty" mapstructure:"enable_cursor,omitempty"`
+	// Cursor is the next_cursor returned by the previous page of the keyset pagination, it's used to get the next
+	// page instead of the start, which keeps fast and stable when paging through a large amount of data.
+	Cursor string `json:"cursor,omitempty" mapstructure:"cursor,omitempty"`
+}
+
+// IsCursorPage returns if the page uses keyset pagination.
+func (page BasePage) IsCursorPage() bool {
+	return page.EnableCursor || len(page.Cursor) > 0
 }
 
 // Validate TODO
 func (page BasePage) Validate(allowNoLimit bool) (string, error) {
 	// 此场景下如果仅仅是获取查询对象的数量，page的其余参数只能是初始化值
 	if page.EnableCount {
-		if page.Start > 0 || page.Limit > 0 || page.Sort != "" {
+		if page.Start > 0 || page.Limit > 0 || page.Sort != "" || page.IsCursorPage() {
 			return "page", errors.New("params page can not be set")
 		}
 		return "", nil
@@ -91,7 +102,7 @@ func (page BasePage) ValidateLimit(maxLimit int) error {
 // ValidateWithEnableCount validate if page has only one of enable count and other param, and if limit is set and valid
 func (page BasePage) ValidateWithEnableCount(allowNoLimit bool, maxLimit ...int) ccErr.RawErrorInfo {
 	if page.EnableCount {
-		if page.Start != 0 || page.Limit != 0 || page.Sort != "" {
+		if page.Start != 0 || page.Limit != 0 || page.Sort != "" || page.IsCursorPage() {
 			return ccErr.RawErrorInfo{
 				ErrCode: common.CCErrCommParamsInvalid,
 				Args:    []interface{}{"page.enable_count"},
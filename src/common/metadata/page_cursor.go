@@ -0,0 +1,133 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"configcenter/src/common"
+	"configcenter/src/common/json"
+	"configcenter/src/common/mapstr"
+)
+
+// PageCursor is the decoded continuation token of the keyset pagination, the data is sorted by the stable key field,
+// which is the unique and immutable instance id field, and the next page starts after the last id of previous page.
+type PageCursor struct {
+	// Field is the stable key field that the data is sorted by.
+	Field string `json:"f"`
+	// Desc defines if the data is sorted in descending order.
+	Desc bool `json:"d,omitempty"`
+	// LastID is the stable key value of the last data in the previous page, it's zero for the first page.
+	LastID int64 `json:"l,omitempty"`
+}
+
+// ParsePageCursor validates the keyset pagination options of the page, and parses the page cursor of the stable key
+// field, the sort of the page can only be the stable key field, ascending order is used if the sort is not set.
+func (page BasePage) ParsePageCursor(field string) (*PageCursor, error) {
+	if page.Start != 0 {
+		return nil, errors.New("page start can not be used with cursor")
+	}
+
+	if page.Limit <= 0 || page.Limit > common.BKMaxPageSize {
+		return nil, fmt.Errorf("page limit should be in the range of (0, %d] when using cursor", common.BKMaxPageSize)
+	}
+
+	cursor := &PageCursor{Field: field}
+	hasSort := len(page.Sort) > 0
+	if hasSort {
+		// the sort can be like "-field", "+field", "field:-1" or "field:1"
+		sort := strings.TrimSpace(page.Sort)
+		switch {
+		case strings.HasPrefix(sort, "-"):
+			sort, cursor.Desc = strings.TrimPrefix(sort, "-"), true
+		case strings.HasPrefix(sort, "+"):
+			sort = strings.TrimPrefix(sort, "+")
+		case strings.HasSuffix(sort, ":-1"):
+			sort, cursor.Desc = strings.TrimSuffix(sort, ":-1"), true
+		default:
+			sort = strings.TrimSuffix(sort, ":1")
+		}
+
+		if sort != field {
+			return nil, fmt.Errorf("page sort can only be %s when using cursor", field)
+		}
+	}
+
+	if len(page.Cursor) == 0 {
+		return cursor, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(page.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page cursor, err: %v", err)
+	}
+
+	decoded := new(PageCursor)
+	if err := json.Unmarshal(raw, decoded); err != nil {
+		return nil, fmt.Errorf("invalid page cursor, err: %v", err)
+	}
+
+	if decoded.Field != field || decoded.LastID <= 0 {
+		return nil, errors.New("invalid page cursor, cursor is not generated by this search")
+	}
+
+	if hasSort && decoded.Desc != cursor.Desc {
+		return nil, errors.New("page sort is not the same with the cursor's sort")
+	}
+
+	return decoded, nil
+}
+
+// Sort returns the sort of the keyset pagination.
+func (c *PageCursor) Sort() string {
+	if c.Desc {
+		return "-" + c.Field
+	}
+	return c.Field
+}
+
+// Condition returns the condition that matches the data after the cursor, returns nil for the first page.
+func (c *PageCursor) Condition() mapstr.MapStr {
+	if c.LastID <= 0 {
+		return nil
+	}
+
+	if c.Desc {
+		return mapstr.MapStr{c.Field: mapstr.MapStr{common.BKDBLT: c.LastID}}
+	}
+	return mapstr.MapStr{c.Field: mapstr.MapStr{common.BKDBGT: c.LastID}}
+}
+
+// MergeCondition merges the condition that matches the data after the cursor with the search condition.
+func (c *PageCursor) MergeCondition(cond mapstr.MapStr) mapstr.MapStr {
+	cursorCond := c.Condition()
+	if cursorCond == nil {
+		return cond
+	}
+
+	if len(cond) == 0 {
+		return cursorCond
+	}
+	return mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, cursorCond}}
+}
+
+// Next returns the next cursor token of the page whose last data's stable key value is the lastID.
+func (c *PageCursor) Next(lastID int64) string {
+	next := PageCursor{Field: c.Field, Desc: c.Desc, LastID: lastID}
+	// the error can be ignored because the cursor is a simple struct.
+	raw, _ := json.Marshal(next)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
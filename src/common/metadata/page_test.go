@@ -15,6 +15,8 @@ package metadata
 import (
 	"reflect"
 	"testing"
+
+	"configcenter/src/common/mapstr"
 )
 
 func TestParsePage(t *testing.T) {
@@ -36,3 +38,50 @@ func TestParsePage(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePageCursor(t *testing.T) {
+	first, err := BasePage{Limit: 10, EnableCursor: true}.ParsePageCursor("bk_host_id")
+	if err != nil {
+		t.Fatalf("parse first page cursor failed, err: %v", err)
+	}
+
+	if first.Condition() != nil || first.Sort() != "bk_host_id" {
+		t.Errorf("first page cursor %+v is not as expected", first)
+	}
+
+	next, err := BasePage{Limit: 10, Cursor: first.Next(100)}.ParsePageCursor("bk_host_id")
+	if err != nil {
+		t.Fatalf("parse next page cursor failed, err: %v", err)
+	}
+
+	if !reflect.DeepEqual(*next, PageCursor{Field: "bk_host_id", LastID: 100}) {
+		t.Errorf("next page cursor %+v is not as expected", next)
+	}
+
+	descCursor := PageCursor{Field: "bk_host_id", Desc: true}
+	descNext, err := BasePage{Limit: 10, Sort: "-bk_host_id", Cursor: descCursor.Next(50)}.ParsePageCursor("bk_host_id")
+	if err != nil {
+		t.Fatalf("parse descending page cursor failed, err: %v", err)
+	}
+
+	cond := descNext.MergeCondition(map[string]interface{}{"bk_cloud_id": 0})
+	expectCond := map[string]interface{}{"$and": []mapstr.MapStr{{"bk_cloud_id": 0},
+		{"bk_host_id": mapstr.MapStr{"$lt": int64(50)}}}}
+	if !reflect.DeepEqual(map[string]interface{}(cond), expectCond) {
+		t.Errorf("descending page cursor condition %+v is not as expected", cond)
+	}
+
+	invalidPages := []BasePage{
+		{Limit: 10, Start: 10, EnableCursor: true},
+		{Limit: 0, EnableCursor: true},
+		{Limit: 10, Sort: "bk_host_innerip", EnableCursor: true},
+		{Limit: 10, Cursor: "invalid"},
+		{Limit: 10, Cursor: first.Next(100), Sort: "-bk_host_id"},
+		{Limit: 10, Cursor: (&PageCursor{Field: "bk_set_id"}).Next(100)},
+	}
+	for _, page := range invalidPages {
+		if _, err := page.ParsePageCursor("bk_host_id"); err == nil {
+			t.Errorf("parse invalid page %+v cursor should fail", page)
+		}
+	}
+}
@@ -462,16 +462,16 @@ type SrvInstBriefInfo struct {
 
 // ServiceInstanceOptions create or update service instance option
 type ServiceInstanceOptions struct {
-	Created []UpsertServiceInstanceInfo `json:"created,omitempty"`
-	Updated []UpsertServiceInstanceInfo `json:"updated,omitempty"`
+	Created []UpsertServiceInstanceInfo `json:"created,omitempty" bson:"created,omitempty"`
+	Updated []UpsertServiceInstanceInfo `json:"updated,omitempty" bson:"updated,omitempty"`
 }
 
 // UpsertServiceInstanceInfo update or insert service instance info
 type UpsertServiceInstanceInfo struct {
-	ModuleID int64 `json:"bk_module_id"`
-	HostID   int64 `json:"bk_host_id"`
+	ModuleID int64 `json:"bk_module_id" bson:"bk_module_id"`
+	HostID   int64 `json:"bk_host_id" bson:"bk_host_id"`
 	// Processes parameter usable only when create instance with raw
-	Processes []ProcessInstanceDetail `json:"processes,omitempty"`
+	Processes []ProcessInstanceDetail `json:"processes,omitempty" bson:"processes,omitempty"`
 }
 
 // CreateServiceInstanceDetail TODO
@@ -485,8 +485,8 @@ type CreateServiceInstanceDetail struct {
 // ProcessInstanceDetail TODO
 type ProcessInstanceDetail struct {
 	// ProcessTemplateID indicate which process to update if service instance bound with a template
-	ProcessTemplateID int64                  `json:"process_template_id"`
-	ProcessData       map[string]interface{} `json:"process_info"`
+	ProcessTemplateID int64                  `json:"process_template_id" bson:"process_template_id"`
+	ProcessData       map[string]interface{} `json:"process_info" bson:"process_info"`
 }
 
 // ListProcessTemplateWithServiceTemplateInput TODO
@@ -2059,6 +2059,9 @@ func (si *ServiceInstance) Validate() (field string, err error) {
 type ServiceInstanceDetail struct {
 	ServiceInstance
 	ProcessInstances []ProcessInstanceNG `field:"process_instances" json:"process_instances" bson:"process_instances"`
+	// PodID the pod that backs the service instance, it is only set for the container backed service instances,
+	// which are derived from the pods of the workloads bound to the service template and have no id.
+	PodID int64 `field:"bk_pod_id" json:"bk_pod_id,omitempty" bson:"bk_pod_id,omitempty"`
 }
 
 // ServiceInstanceWithTopoPath TODO
@@ -2118,6 +2121,8 @@ type ProcessInstance struct {
 type ProcessInstanceNG struct {
 	Process  Process                 `json:"process"`
 	Relation ProcessInstanceRelation `json:"relation"`
+	// ContainerID the container that the process is derived from, only set for container backed service instances
+	ContainerID int64 `json:"bk_container_id,omitempty"`
 }
 
 // Proc2Module TODO
@@ -562,6 +562,21 @@ func (pbi ProcPropertyBindInfo) NewProcBindInfo(cErr cErr.DefaultCCErrorIf,
 	return procBindInfoArr, nil
 }
 
+// NewContainerProcBindInfo generate the bind info of the process derived from a container port, it has no
+// process template row.
+func NewContainerProcBindInfo(ip, port string, protocol ProtocolType) ProcBindInfo {
+	protocolStr := string(protocol)
+	enable := true
+	return ProcBindInfo{
+		Std: &stdProcBindInfo{
+			IP:       &ip,
+			Port:     &port,
+			Protocol: &protocolStr,
+			Enable:   &enable,
+		},
+	}
+}
+
 // allFieldValIsNil 判断所有的字段是否为nil
 func allFieldValIsNil(extra map[string]interface{}) bool {
 	isValAllNil := true
@@ -65,6 +65,8 @@ func (qc *QueryCondition) IsIllegal() bool {
 type QueryResult struct {
 	Count uint64          `json:"count"`
 	Info  []mapstr.MapStr `json:"info"`
+	// NextCursor is the cursor of the next page when using keyset pagination, it's empty if there is no more data.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // QueryConditionResult TODO
@@ -0,0 +1,213 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+)
+
+const (
+	// staleHostMaxHours is the max stale hours of the stale host policy, which is one year
+	staleHostMaxHours = 24 * 366
+	// staleHostMaxExcludeBiz is the max number of the businesses that are excluded by the stale host policy
+	staleHostMaxExcludeBiz = 1000
+	// StaleHostTaskBatchSize is the max number of the stale hosts that are handled in one sub task
+	StaleHostTaskBatchSize = 100
+)
+
+// HostSnapStatus is the snapshot status of a host whose agent has reported the snapshot, a host is stale if its
+// agent stopped reporting for longer than the stale hours of the stale host policy.
+type HostSnapStatus struct {
+	HostID           int64     `json:"bk_host_id" bson:"bk_host_id"`
+	LastSnapshotTime time.Time `json:"last_snapshot_time" bson:"last_snapshot_time"`
+	Stale            bool      `json:"stale" bson:"stale"`
+	// StaleTime is the time when the host is flagged as stale
+	StaleTime       time.Time `json:"stale_time" bson:"stale_time"`
+	SupplierAccount string    `json:"bk_supplier_account" bson:"bk_supplier_account"`
+}
+
+// StaleHostAction is the action taken on the hosts that are flagged as stale
+type StaleHostAction string
+
+const (
+	// StaleHostActionNone only flag the stale hosts
+	StaleHostActionNone StaleHostAction = "none"
+	// StaleHostActionTransfer transfer the stale hosts to a default module of their business
+	StaleHostActionTransfer StaleHostAction = "transfer"
+	// StaleHostActionUpdateState change the bk_state of the stale hosts
+	StaleHostActionUpdateState StaleHostAction = "update_state"
+)
+
+// StaleHostPolicy is the stale host detection policy of a supplier account, the hosts whose agent stopped reporting
+// the snapshot for the stale hours are flagged as stale, and the action is taken on them once when they are flagged.
+type StaleHostPolicy struct {
+	Enabled    bool            `json:"enabled" bson:"enabled"`
+	StaleHours int64           `json:"stale_hours" bson:"stale_hours"`
+	Action     StaleHostAction `json:"action" bson:"action"`
+	// DefaultModule is the bk_default flag of the module in the host's business that the stale host is transferred
+	// to by the transfer action, e.g. the fault module.
+	DefaultModule int `json:"default_module" bson:"default_module"`
+	// State is the bk_state value the stale host changes to by the update state action
+	State string `json:"state" bson:"state"`
+	// ExcludeBizIDs are the businesses whose hosts are neither flagged nor handled
+	ExcludeBizIDs   []int64   `json:"exclude_biz_ids" bson:"exclude_biz_ids"`
+	SupplierAccount string    `json:"bk_supplier_account" bson:"bk_supplier_account"`
+	Modifier        string    `json:"modifier" bson:"modifier"`
+	LastTime        time.Time `json:"last_time" bson:"last_time"`
+}
+
+// Validate StaleHostPolicy
+func (p *StaleHostPolicy) Validate() errors.RawErrorInfo {
+	if !p.Enabled {
+		return errors.RawErrorInfo{}
+	}
+
+	if p.StaleHours <= 0 || p.StaleHours > staleHostMaxHours {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"stale_hours"}}
+	}
+
+	if len(p.ExcludeBizIDs) > staleHostMaxExcludeBiz {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit,
+			Args: []interface{}{"exclude_biz_ids", staleHostMaxExcludeBiz}}
+	}
+
+	switch p.Action {
+	case "":
+		p.Action = StaleHostActionNone
+	case StaleHostActionNone:
+	case StaleHostActionTransfer:
+		switch p.DefaultModule {
+		case common.DefaultResModuleFlag, common.DefaultFaultModuleFlag, common.DefaultRecycleModuleFlag:
+		default:
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"default_module"}}
+		}
+	case StaleHostActionUpdateState:
+		if len(p.State) == 0 {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"state"}}
+		}
+	default:
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"action"}}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// IsExcluded returns if the hosts of the business are excluded by the policy
+func (p *StaleHostPolicy) IsExcluded(bizID int64) bool {
+	for _, id := range p.ExcludeBizIDs {
+		if id == bizID {
+			return true
+		}
+	}
+	return false
+}
+
+// StaleHostPolicyResponse stale host policy response
+type StaleHostPolicyResponse struct {
+	BaseResp `json:",inline"`
+	Data     StaleHostPolicy `json:"data"`
+}
+
+// SearchHostSnapStatusOption search the snapshot status of the specified hosts option
+type SearchHostSnapStatusOption struct {
+	HostIDs []int64 `json:"bk_host_ids"`
+	// Stale returns the stale or not stale hosts only if set
+	Stale *bool    `json:"stale"`
+	Page  BasePage `json:"page"`
+}
+
+// Validate SearchHostSnapStatusOption
+func (s *SearchHostSnapStatusOption) Validate() errors.RawErrorInfo {
+	if len(s.HostIDs) == 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"bk_host_ids"}}
+	}
+
+	if s.Page.IsCursorPage() {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"page.cursor"}}
+	}
+
+	return s.Page.ValidateWithEnableCount(false, hostSnapMaxQueryLimit)
+}
+
+// ListBizStaleHostOption list the stale hosts in a business option
+type ListBizStaleHostOption struct {
+	ModuleIDs []int64  `json:"bk_module_ids"`
+	Page      BasePage `json:"page"`
+}
+
+// Validate ListBizStaleHostOption
+func (l *ListBizStaleHostOption) Validate() errors.RawErrorInfo {
+	if len(l.ModuleIDs) > common.BKMaxLimitSize {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit,
+			Args: []interface{}{"bk_module_ids", common.BKMaxLimitSize}}
+	}
+
+	if l.Page.IsCursorPage() {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"page.cursor"}}
+	}
+
+	return l.Page.ValidateWithEnableCount(false, hostSnapMaxQueryLimit)
+}
+
+// HostSnapStatusResult host snapshot status query result
+type HostSnapStatusResult struct {
+	Count uint64           `json:"count"`
+	Info  []HostSnapStatus `json:"info"`
+}
+
+// HostSnapStatusResponse host snapshot status query response
+type HostSnapStatusResponse struct {
+	BaseResp `json:",inline"`
+	Data     HostSnapStatusResult `json:"data"`
+}
+
+// StaleHostTaskData is the data of the sub task that takes the stale host policy action on the stale hosts of a
+// business, the action is decided by the policy when the task is created.
+type StaleHostTaskData struct {
+	BizID         int64           `json:"bk_biz_id"`
+	HostIDs       []int64         `json:"bk_host_ids"`
+	Action        StaleHostAction `json:"action"`
+	DefaultModule int             `json:"default_module"`
+	State         string          `json:"state"`
+}
+
+// Validate StaleHostTaskData
+func (s *StaleHostTaskData) Validate() errors.RawErrorInfo {
+	if s.BizID <= 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{common.BKAppIDField}}
+	}
+
+	if len(s.HostIDs) == 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"bk_host_ids"}}
+	}
+
+	if len(s.HostIDs) > StaleHostTaskBatchSize {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit,
+			Args: []interface{}{"bk_host_ids", StaleHostTaskBatchSize}}
+	}
+
+	policy := StaleHostPolicy{Enabled: true, StaleHours: 1, Action: s.Action, DefaultModule: s.DefaultModule,
+		State: s.State}
+	if rawErr := policy.Validate(); rawErr.ErrCode != 0 {
+		return rawErr
+	}
+
+	if policy.Action == StaleHostActionNone {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"action"}}
+	}
+
+	return errors.RawErrorInfo{}
+}
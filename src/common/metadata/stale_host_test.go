@@ -0,0 +1,132 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"testing"
+
+	"configcenter/src/common"
+)
+
+func TestStaleHostPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  StaleHostPolicy
+		errCode int
+		action  StaleHostAction
+	}{
+		{
+			name:   "disabled policy is not validated",
+			policy: StaleHostPolicy{Action: "unknown"},
+			action: "unknown",
+		},
+		{
+			name:   "default action",
+			policy: StaleHostPolicy{Enabled: true, StaleHours: 24},
+			action: StaleHostActionNone,
+		},
+		{
+			name:    "invalid stale hours",
+			policy:  StaleHostPolicy{Enabled: true},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "too many stale hours",
+			policy:  StaleHostPolicy{Enabled: true, StaleHours: staleHostMaxHours + 1},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name: "transfer to fault module",
+			policy: StaleHostPolicy{Enabled: true, StaleHours: 24, Action: StaleHostActionTransfer,
+				DefaultModule: common.DefaultFaultModuleFlag},
+			action: StaleHostActionTransfer,
+		},
+		{
+			name:    "transfer to normal module",
+			policy:  StaleHostPolicy{Enabled: true, StaleHours: 24, Action: StaleHostActionTransfer},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "update state without state",
+			policy:  StaleHostPolicy{Enabled: true, StaleHours: 24, Action: StaleHostActionUpdateState},
+			errCode: common.CCErrCommParamsNeedSet,
+		},
+		{
+			name:    "invalid action",
+			policy:  StaleHostPolicy{Enabled: true, StaleHours: 24, Action: "delete"},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		rawErr := tt.policy.Validate()
+		if rawErr.ErrCode != tt.errCode {
+			t.Errorf("%s: expect error code %d, got %d", tt.name, tt.errCode, rawErr.ErrCode)
+			continue
+		}
+		if tt.errCode == 0 && tt.policy.Action != tt.action {
+			t.Errorf("%s: expect action %s, got %s", tt.name, tt.action, tt.policy.Action)
+		}
+	}
+}
+
+func TestStaleHostPolicyIsExcluded(t *testing.T) {
+	policy := StaleHostPolicy{ExcludeBizIDs: []int64{2, 3}}
+	if !policy.IsExcluded(2) {
+		t.Errorf("expect biz 2 is excluded")
+	}
+	if policy.IsExcluded(4) {
+		t.Errorf("expect biz 4 is not excluded")
+	}
+}
+
+func TestStaleHostTaskDataValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    StaleHostTaskData
+		errCode int
+	}{
+		{
+			name: "valid update state task",
+			data: StaleHostTaskData{BizID: 2, HostIDs: []int64{1}, Action: StaleHostActionUpdateState,
+				State: "offline"},
+		},
+		{
+			name:    "no hosts",
+			data:    StaleHostTaskData{BizID: 2, Action: StaleHostActionUpdateState, State: "offline"},
+			errCode: common.CCErrCommParamsNeedSet,
+		},
+		{
+			name: "too many hosts",
+			data: StaleHostTaskData{BizID: 2, HostIDs: make([]int64, StaleHostTaskBatchSize+1),
+				Action: StaleHostActionUpdateState, State: "offline"},
+			errCode: common.CCErrCommXXExceedLimit,
+		},
+		{
+			name:    "none action",
+			data:    StaleHostTaskData{BizID: 2, HostIDs: []int64{1}, Action: StaleHostActionNone},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "empty action",
+			data:    StaleHostTaskData{BizID: 2, HostIDs: []int64{1}},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		if rawErr := tt.data.Validate(); rawErr.ErrCode != tt.errCode {
+			t.Errorf("%s: expect error code %d, got %d", tt.name, tt.errCode, rawErr.ErrCode)
+		}
+	}
+}
@@ -0,0 +1,519 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"encoding/hex"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+)
+
+const (
+	// subnetVlanIDMax is the max valid vlan id, 0 means the subnet has no vlan
+	subnetVlanIDMax = 4094
+	// subnetIPMaxCount is the max number of the ips that can be reserved or allocated at a time
+	subnetIPMaxCount = 100
+	// ipConflictDefaultLimit and ipConflictMaxLimit is the default and max number of the returned ip conflicts
+	ipConflictDefaultLimit = 100
+	ipConflictMaxLimit     = 500
+)
+
+// Subnet is a subnet of a cloud area, the hosts in the cloud area whose inner ips are in the cidr of the subnet are
+// regarded as the hosts of the subnet.
+type Subnet struct {
+	SubnetID    int64     `json:"bk_subnet_id" bson:"bk_subnet_id"`
+	CloudID     int64     `json:"bk_cloud_id" bson:"bk_cloud_id"`
+	CIDR        string    `json:"bk_cidr" bson:"bk_cidr"`
+	VlanID      int64     `json:"bk_vlan_id" bson:"bk_vlan_id"`
+	Gateway     string    `json:"bk_gateway" bson:"bk_gateway"`
+	Description string    `json:"description" bson:"description"`
+	OwnerID     string    `json:"bk_supplier_account" bson:"bk_supplier_account"`
+	Creator     string    `json:"bk_creator" bson:"bk_creator"`
+	LastEditor  string    `json:"bk_last_editor" bson:"bk_last_editor"`
+	CreateTime  time.Time `json:"create_time" bson:"create_time"`
+	LastTime    time.Time `json:"last_time" bson:"last_time"`
+}
+
+// Validate Subnet, the cidr must be a network address such as 10.0.0.0/24, the gateway must be an assignable ip of
+// the subnet if it is set. the cidr and gateway are formatted in their canonical form after validation.
+func (s *Subnet) Validate() errors.RawErrorInfo {
+	if s.CloudID < 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{common.BKCloudIDField}}
+	}
+
+	ip, ipNet, err := net.ParseCIDR(strings.TrimSpace(s.CIDR))
+	if err != nil || !ip.Equal(ipNet.IP) {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{common.BKSubnetCIDRField}}
+	}
+	s.CIDR = ipNet.String()
+
+	if s.VlanID < 0 || s.VlanID > subnetVlanIDMax {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid,
+			Args: []interface{}{common.BKSubnetVlanIDField}}
+	}
+
+	if len(s.Gateway) > 0 {
+		gateway := net.ParseIP(strings.TrimSpace(s.Gateway))
+		if gateway == nil || !IsSubnetAssignableIP(ipNet, gateway) {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid,
+				Args: []interface{}{common.BKSubnetGatewayField}}
+		}
+		s.Gateway = gateway.String()
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// IPNet returns the parsed cidr of the subnet
+func (s *Subnet) IPNet() (*net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(s.CIDR)
+	return ipNet, err
+}
+
+// IsSubnetAssignableIP check if the ip can be assigned to a host in the subnet. for ipv4, the network and broadcast
+// addresses are not assignable unless the prefix is /31 or /32. for ipv6, the subnet-router anycast address is not
+// assignable unless the prefix is /127 or /128.
+func IsSubnetAssignableIP(ipNet *net.IPNet, ip net.IP) bool {
+	if !ipNet.Contains(ip) {
+		return false
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if bits-ones <= 1 {
+		return true
+	}
+
+	if ip.Equal(ipNet.IP) {
+		return false
+	}
+
+	if ipNet.IP.To4() != nil {
+		return !ip.Equal(lastSubnetIP(ipNet))
+	}
+	return true
+}
+
+// SubnetAssignableIPCount returns the number of the assignable ips of the subnet, the count is capped at MaxInt64
+// for the large ipv6 subnets.
+func SubnetAssignableIPCount(ipNet *net.IPNet) int64 {
+	ones, bits := ipNet.Mask.Size()
+	hostBits := bits - ones
+	if hostBits >= 63 {
+		return math.MaxInt64
+	}
+
+	count := int64(1) << uint(hostBits)
+	switch {
+	case hostBits <= 1:
+		return count
+	case ipNet.IP.To4() != nil:
+		return count - 2
+	default:
+		return count - 1
+	}
+}
+
+// NextFreeSubnetIPs returns at most count assignable ips of the subnet in ascending order that are not in the used
+// ips, the used ips must be in their canonical form.
+func NextFreeSubnetIPs(ipNet *net.IPNet, used map[string]struct{}, count int) []string {
+	result := make([]string, 0)
+	ip := make(net.IP, len(ipNet.IP))
+	copy(ip, ipNet.IP)
+
+	for len(result) < count {
+		if IsSubnetAssignableIP(ipNet, ip) {
+			if _, exists := used[ip.String()]; !exists {
+				result = append(result, ip.String())
+			}
+		}
+
+		ip = nextIP(ip)
+		// stops at the end of the subnet, or when the ip overflows to the start of the subnet
+		if !ipNet.Contains(ip) || ip.Equal(ipNet.IP) {
+			break
+		}
+	}
+	return result
+}
+
+// nextIP returns the ip next to the ip, it overflows to the zero ip after the last ip
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for idx := len(next) - 1; idx >= 0; idx-- {
+		next[idx]++
+		if next[idx] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// lastSubnetIP returns the last ip of the subnet, which is the broadcast address of an ipv4 subnet
+func lastSubnetIP(ipNet *net.IPNet) net.IP {
+	ip := make(net.IP, len(ipNet.IP))
+	for idx := range ipNet.IP {
+		ip[idx] = ipNet.IP[idx] | ^ipNet.Mask[idx]
+	}
+	return ip
+}
+
+// ParseHostIPs returns the inner ipv4 and ipv6 addresses of the host in their canonical form, the ip fields are
+// stored as arrays in db and returned as comma separated strings by the host search apis, both are supported.
+func ParseHostIPs(host map[string]interface{}) []string {
+	ips := make([]string, 0)
+	for _, field := range []string{common.BKHostInnerIPField, common.BKHostInnerIPv6Field} {
+		values := make([]string, 0)
+		switch value := host[field].(type) {
+		case string:
+			values = strings.Split(value, ",")
+		case []string:
+			values = value
+		case []interface{}:
+			for _, item := range value {
+				if str, ok := item.(string); ok {
+					values = append(values, str)
+				}
+			}
+		}
+
+		for _, value := range values {
+			if ip := net.ParseIP(strings.TrimSpace(value)); ip != nil {
+				ips = append(ips, ip.String())
+			}
+		}
+	}
+	return ips
+}
+
+// SubnetHostIP is an inner ip of a host in the cloud area, it is synced from the host events by the cache service,
+// so that the hosts in a subnet can be found by the ip key range of the subnet without scanning all the hosts.
+type SubnetHostIP struct {
+	HostID  int64  `json:"bk_host_id" bson:"bk_host_id"`
+	CloudID int64  `json:"bk_cloud_id" bson:"bk_cloud_id"`
+	IP      string `json:"bk_ip" bson:"bk_ip"`
+	IPKey   string `json:"bk_ip_key" bson:"bk_ip_key"`
+	OwnerID string `json:"bk_supplier_account" bson:"bk_supplier_account"`
+}
+
+// NewSubnetHostIPs returns the distinct inner ipv4 and ipv6 addresses of the host
+func NewSubnetHostIPs(hostID, cloudID int64, ownerID string, host map[string]interface{}) []SubnetHostIP {
+	ipMap := make(map[string]struct{})
+	hostIPs := make([]SubnetHostIP, 0)
+	for _, ip := range ParseHostIPs(host) {
+		if _, exists := ipMap[ip]; exists {
+			continue
+		}
+		ipMap[ip] = struct{}{}
+
+		hostIPs = append(hostIPs, SubnetHostIP{
+			HostID:  hostID,
+			CloudID: cloudID,
+			IP:      ip,
+			IPKey:   SubnetIPKey(net.ParseIP(ip)),
+			OwnerID: ownerID,
+		})
+	}
+	return hostIPs
+}
+
+// SubnetIPKey returns the key of the ip whose lexical order is the same as the numeric order of the ip, the ipv4
+// ips are keyed in their ipv4-mapped ipv6 form, so that the ipv4 and ipv6 ips share one key space.
+func SubnetIPKey(ip net.IP) string {
+	return hex.EncodeToString(ip.To16())
+}
+
+// SubnetIPKeyRange returns the keys of the first and the last ip of the subnet
+func SubnetIPKeyRange(ipNet *net.IPNet) (string, string) {
+	return SubnetIPKey(ipNet.IP), SubnetIPKey(lastSubnetIP(ipNet))
+}
+
+// UpdateSubnetOption update the vlan, gateway or description of a subnet, the cidr of a subnet can not be changed
+type UpdateSubnetOption struct {
+	VlanID      *int64  `json:"bk_vlan_id"`
+	Gateway     *string `json:"bk_gateway"`
+	Description *string `json:"description"`
+}
+
+// Validate UpdateSubnetOption, the gateway is validated with the cidr of the subnet by core service
+func (o *UpdateSubnetOption) Validate() errors.RawErrorInfo {
+	if o.VlanID == nil && o.Gateway == nil && o.Description == nil {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"data"}}
+	}
+
+	if o.VlanID != nil && (*o.VlanID < 0 || *o.VlanID > subnetVlanIDMax) {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid,
+			Args: []interface{}{common.BKSubnetVlanIDField}}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// SearchSubnetOption search the subnets by cloud area ids or subnet ids
+type SearchSubnetOption struct {
+	CloudIDs  []int64  `json:"bk_cloud_ids"`
+	SubnetIDs []int64  `json:"bk_subnet_ids"`
+	Page      BasePage `json:"page"`
+}
+
+// Validate SearchSubnetOption
+func (o *SearchSubnetOption) Validate() errors.RawErrorInfo {
+	if o.Page.Limit == 0 {
+		o.Page.Limit = common.BKMaxPageSize
+	}
+
+	if o.Page.Start < 0 || o.Page.Limit < 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"page"}}
+	}
+
+	if o.Page.Limit > common.BKMaxPageSize {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommPageLimitIsExceeded}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// MultipleSubnet is the subnets search result
+type MultipleSubnet struct {
+	Count int64    `json:"count"`
+	Info  []Subnet `json:"info"`
+}
+
+// SubnetIPReservation is an ip of a subnet that is reserved, the reserved ips are not allocated again
+type SubnetIPReservation struct {
+	SubnetID    int64     `json:"bk_subnet_id" bson:"bk_subnet_id"`
+	CloudID     int64     `json:"bk_cloud_id" bson:"bk_cloud_id"`
+	IP          string    `json:"bk_ip" bson:"bk_ip"`
+	Description string    `json:"description" bson:"description"`
+	OwnerID     string    `json:"bk_supplier_account" bson:"bk_supplier_account"`
+	Creator     string    `json:"bk_creator" bson:"bk_creator"`
+	CreateTime  time.Time `json:"create_time" bson:"create_time"`
+}
+
+// ReserveSubnetIPOption reserve or release the specified ips of a subnet
+type ReserveSubnetIPOption struct {
+	IPs         []string `json:"bk_ips"`
+	Description string   `json:"description"`
+}
+
+// Validate ReserveSubnetIPOption, the ips are formatted in their canonical form after validation
+func (o *ReserveSubnetIPOption) Validate() errors.RawErrorInfo {
+	if len(o.IPs) == 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"bk_ips"}}
+	}
+
+	if len(o.IPs) > subnetIPMaxCount {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit,
+			Args: []interface{}{"bk_ips", subnetIPMaxCount}}
+	}
+
+	ipMap := make(map[string]struct{})
+	ips := make([]string, 0)
+	for _, value := range o.IPs {
+		ip := net.ParseIP(strings.TrimSpace(value))
+		if ip == nil {
+			return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{value}}
+		}
+
+		if _, exists := ipMap[ip.String()]; exists {
+			continue
+		}
+		ipMap[ip.String()] = struct{}{}
+		ips = append(ips, ip.String())
+	}
+	o.IPs = ips
+
+	return errors.RawErrorInfo{}
+}
+
+// AllocateSubnetIPOption allocate and reserve the next free ips of a subnet
+type AllocateSubnetIPOption struct {
+	Count       int    `json:"count"`
+	Description string `json:"description"`
+}
+
+// Validate AllocateSubnetIPOption, allocate one ip by default
+func (o *AllocateSubnetIPOption) Validate() errors.RawErrorInfo {
+	if o.Count == 0 {
+		o.Count = 1
+	}
+
+	if o.Count < 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"count"}}
+	}
+
+	if o.Count > subnetIPMaxCount {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit, Args: []interface{}{"count",
+			subnetIPMaxCount}}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// FindSubnetHostOption find the hosts of a subnet by page
+type FindSubnetHostOption struct {
+	Page BasePage `json:"page"`
+}
+
+// Validate FindSubnetHostOption
+func (o *FindSubnetHostOption) Validate() errors.RawErrorInfo {
+	if o.Page.Limit == 0 {
+		o.Page.Limit = common.BKMaxPageSize
+	}
+
+	if o.Page.Start < 0 || o.Page.Limit < 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"page"}}
+	}
+
+	if o.Page.Limit > common.BKMaxPageSize {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommPageLimitIsExceeded}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// SubnetHost is a host of the subnet and its ips that are in the subnet
+type SubnetHost struct {
+	HostID int64    `json:"bk_host_id"`
+	IPs    []string `json:"bk_ips"`
+}
+
+// MultipleSubnetHost is the subnet hosts search result
+type MultipleSubnetHost struct {
+	Count int64        `json:"count"`
+	Info  []SubnetHost `json:"info"`
+}
+
+// SubnetUtilizationOption get the utilization of the subnets in a cloud area, all the subnets of the cloud area are
+// returned if the subnet ids are not set.
+type SubnetUtilizationOption struct {
+	CloudID   int64   `json:"bk_cloud_id"`
+	SubnetIDs []int64 `json:"bk_subnet_ids"`
+}
+
+// SubnetUtilization is the ip utilization of a subnet
+type SubnetUtilization struct {
+	SubnetID int64  `json:"bk_subnet_id"`
+	CloudID  int64  `json:"bk_cloud_id"`
+	CIDR     string `json:"bk_cidr"`
+	// Total is the number of the assignable ips, excluding the gateway
+	Total int64 `json:"total"`
+	// HostUsed is the number of the ips used by hosts, Reserved is the number of the reserved ips not used by hosts
+	HostUsed int64   `json:"host_used"`
+	Reserved int64   `json:"reserved"`
+	Free     int64   `json:"free"`
+	Usage    float64 `json:"usage"`
+}
+
+// NewSubnetUtilization calculate the utilization of the subnet by its used and reserved ips
+func NewSubnetUtilization(subnet *Subnet, ipNet *net.IPNet, hostUsed, reserved int64) SubnetUtilization {
+	total := SubnetAssignableIPCount(ipNet)
+	if len(subnet.Gateway) > 0 && total != math.MaxInt64 {
+		total--
+	}
+
+	utilization := SubnetUtilization{
+		SubnetID: subnet.SubnetID,
+		CloudID:  subnet.CloudID,
+		CIDR:     subnet.CIDR,
+		Total:    total,
+		HostUsed: hostUsed,
+		Reserved: reserved,
+		Free:     total - hostUsed - reserved,
+	}
+
+	if utilization.Free < 0 {
+		utilization.Free = 0
+	}
+
+	if total > 0 {
+		utilization.Usage = float64(hostUsed+reserved) / float64(total)
+	}
+	return utilization
+}
+
+// FindIPConflictOption find the ips that are claimed by more than one host in the same cloud area
+type FindIPConflictOption struct {
+	CloudIDs []int64 `json:"bk_cloud_ids"`
+	Limit    int     `json:"limit"`
+}
+
+// Validate FindIPConflictOption
+func (o *FindIPConflictOption) Validate() errors.RawErrorInfo {
+	if len(o.CloudIDs) == 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsNeedSet, Args: []interface{}{"bk_cloud_ids"}}
+	}
+
+	if o.Limit == 0 {
+		o.Limit = ipConflictDefaultLimit
+	}
+
+	if o.Limit < 0 {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommParamsInvalid, Args: []interface{}{"limit"}}
+	}
+
+	if o.Limit > ipConflictMaxLimit {
+		return errors.RawErrorInfo{ErrCode: common.CCErrCommXXExceedLimit, Args: []interface{}{"limit",
+			ipConflictMaxLimit}}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// IPConflict is an ip that is claimed by more than one host in the same cloud area
+type IPConflict struct {
+	CloudID int64   `json:"bk_cloud_id" bson:"bk_cloud_id"`
+	IP      string  `json:"bk_ip" bson:"bk_ip"`
+	HostIDs []int64 `json:"bk_host_ids" bson:"bk_host_ids"`
+}
+
+// SubnetResponse is the response of creating a subnet
+type SubnetResponse struct {
+	BaseResp `json:",inline"`
+	Data     Subnet `json:"data"`
+}
+
+// MultipleSubnetResponse is the response of searching the subnets
+type MultipleSubnetResponse struct {
+	BaseResp `json:",inline"`
+	Data     MultipleSubnet `json:"data"`
+}
+
+// MultipleSubnetHostResponse is the response of finding the subnet hosts
+type MultipleSubnetHostResponse struct {
+	BaseResp `json:",inline"`
+	Data     MultipleSubnetHost `json:"data"`
+}
+
+// SubnetUtilizationResponse is the response of getting the subnet utilization
+type SubnetUtilizationResponse struct {
+	BaseResp `json:",inline"`
+	Data     []SubnetUtilization `json:"data"`
+}
+
+// SubnetIPReservationResponse is the response of searching, reserving or allocating the subnet ips
+type SubnetIPReservationResponse struct {
+	BaseResp `json:",inline"`
+	Data     []SubnetIPReservation `json:"data"`
+}
+
+// IPConflictResponse is the response of finding the ip conflicts
+type IPConflictResponse struct {
+	BaseResp `json:",inline"`
+	Data     []IPConflict `json:"data"`
+}
@@ -0,0 +1,201 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"math"
+	"net"
+	"reflect"
+	"testing"
+
+	"configcenter/src/common"
+)
+
+func TestSubnetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		subnet  Subnet
+		errCode int
+		cidr    string
+		gateway string
+	}{
+		{
+			name:    "valid ipv4 subnet",
+			subnet:  Subnet{CIDR: "10.0.0.0/24", VlanID: 100, Gateway: "10.0.0.1"},
+			cidr:    "10.0.0.0/24",
+			gateway: "10.0.0.1",
+		},
+		{
+			name:    "valid ipv6 subnet",
+			subnet:  Subnet{CIDR: "fd00:0::/64", Gateway: "fd00:0::1"},
+			cidr:    "fd00::/64",
+			gateway: "fd00::1",
+		},
+		{
+			name:    "cidr is not a network address",
+			subnet:  Subnet{CIDR: "10.0.0.1/24"},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "invalid cidr",
+			subnet:  Subnet{CIDR: "10.0.0.0"},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "invalid vlan",
+			subnet:  Subnet{CIDR: "10.0.0.0/24", VlanID: subnetVlanIDMax + 1},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "gateway is not in the subnet",
+			subnet:  Subnet{CIDR: "10.0.0.0/24", Gateway: "10.0.1.1"},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+		{
+			name:    "gateway is the broadcast address",
+			subnet:  Subnet{CIDR: "10.0.0.0/24", Gateway: "10.0.0.255"},
+			errCode: common.CCErrCommParamsInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		rawErr := test.subnet.Validate()
+		if rawErr.ErrCode != test.errCode {
+			t.Errorf("%s: expect error code %d, but got %d", test.name, test.errCode, rawErr.ErrCode)
+			continue
+		}
+
+		if test.errCode == 0 && (test.subnet.CIDR != test.cidr || test.subnet.Gateway != test.gateway) {
+			t.Errorf("%s: expect cidr %s and gateway %s, but got %s and %s", test.name, test.cidr, test.gateway,
+				test.subnet.CIDR, test.subnet.Gateway)
+		}
+	}
+}
+
+func TestSubnetAssignableIP(t *testing.T) {
+	tests := []struct {
+		cidr       string
+		count      int64
+		assignable []string
+		reserved   []string
+	}{
+		{
+			cidr:       "10.0.0.0/30",
+			count:      2,
+			assignable: []string{"10.0.0.1", "10.0.0.2"},
+			reserved:   []string{"10.0.0.0", "10.0.0.3", "10.0.0.4"},
+		},
+		{
+			cidr:       "10.0.0.0/31",
+			count:      2,
+			assignable: []string{"10.0.0.0", "10.0.0.1"},
+		},
+		{
+			cidr:       "fd00::/126",
+			count:      3,
+			assignable: []string{"fd00::1", "fd00::3"},
+			reserved:   []string{"fd00::", "fd00::4"},
+		},
+		{
+			cidr:  "fd00::/64",
+			count: math.MaxInt64,
+		},
+	}
+
+	for _, test := range tests {
+		_, ipNet, _ := net.ParseCIDR(test.cidr)
+		if count := SubnetAssignableIPCount(ipNet); count != test.count {
+			t.Errorf("%s: expect %d assignable ips, but got %d", test.cidr, test.count, count)
+		}
+
+		for _, ip := range test.assignable {
+			if !IsSubnetAssignableIP(ipNet, net.ParseIP(ip)) {
+				t.Errorf("%s: ip %s should be assignable", test.cidr, ip)
+			}
+		}
+
+		for _, ip := range test.reserved {
+			if IsSubnetAssignableIP(ipNet, net.ParseIP(ip)) {
+				t.Errorf("%s: ip %s should not be assignable", test.cidr, ip)
+			}
+		}
+	}
+}
+
+func TestNextFreeSubnetIPs(t *testing.T) {
+	_, ipNet, _ := net.ParseCIDR("10.0.0.0/29")
+	used := map[string]struct{}{"10.0.0.1": {}, "10.0.0.3": {}}
+
+	ips := NextFreeSubnetIPs(ipNet, used, 3)
+	if expected := []string{"10.0.0.2", "10.0.0.4", "10.0.0.5"}; !reflect.DeepEqual(ips, expected) {
+		t.Errorf("expect free ips %v, but got %v", expected, ips)
+	}
+
+	ips = NextFreeSubnetIPs(ipNet, used, 10)
+	if expected := []string{"10.0.0.2", "10.0.0.4", "10.0.0.5", "10.0.0.6"}; !reflect.DeepEqual(ips, expected) {
+		t.Errorf("expect all the free ips %v, but got %v", expected, ips)
+	}
+
+	_, ipNet, _ = net.ParseCIDR("fd00::/64")
+	ips = NextFreeSubnetIPs(ipNet, map[string]struct{}{"fd00::1": {}}, 2)
+	if expected := []string{"fd00::2", "fd00::3"}; !reflect.DeepEqual(ips, expected) {
+		t.Errorf("expect free ipv6 ips %v, but got %v", expected, ips)
+	}
+}
+
+func TestParseHostIPs(t *testing.T) {
+	host := map[string]interface{}{
+		common.BKHostInnerIPField:   "10.0.0.1, 10.0.0.2,invalid",
+		common.BKHostInnerIPv6Field: []interface{}{"fd00:0:0::1"},
+	}
+
+	ips := ParseHostIPs(host)
+	if expected := []string{"10.0.0.1", "10.0.0.2", "fd00::1"}; !reflect.DeepEqual(ips, expected) {
+		t.Errorf("expect host ips %v, but got %v", expected, ips)
+	}
+}
+
+func TestSubnetIPKeyRange(t *testing.T) {
+	subnet := &Subnet{CIDR: "10.0.1.0/24"}
+	ipNet, _ := subnet.IPNet()
+
+	first, last := SubnetIPKeyRange(ipNet)
+	for ip, in := range map[string]bool{"10.0.1.0": true, "10.0.1.9": true, "10.0.1.255": true, "10.0.0.255": false,
+		"10.0.2.0": false, "9.0.1.9": false, "fd00::1": false} {
+
+		key := SubnetIPKey(net.ParseIP(ip))
+		if (key >= first && key <= last) != in {
+			t.Errorf("expect ip %s in subnet %s to be %v, but not", ip, subnet.CIDR, in)
+		}
+	}
+
+	hostIPs := NewSubnetHostIPs(1, 0, "0", map[string]interface{}{
+		common.BKHostInnerIPField: "10.0.1.2,10.0.1.2", common.BKHostInnerIPv6Field: "fd00::1"})
+	if len(hostIPs) != 2 || hostIPs[0].IP != "10.0.1.2" || hostIPs[1].IP != "fd00::1" {
+		t.Errorf("expect host ips 10.0.1.2 and fd00::1, but got %+v", hostIPs)
+	}
+}
+
+func TestNewSubnetUtilization(t *testing.T) {
+	subnet := &Subnet{SubnetID: 1, CIDR: "10.0.0.0/28", Gateway: "10.0.0.1"}
+	ipNet, _ := subnet.IPNet()
+
+	utilization := NewSubnetUtilization(subnet, ipNet, 5, 2)
+	if utilization.Total != 13 || utilization.Free != 6 {
+		t.Errorf("expect total 13 and free 6, but got %+v", utilization)
+	}
+
+	if utilization.Usage < 0.538 || utilization.Usage > 0.539 {
+		t.Errorf("expect usage 7/13, but got %f", utilization.Usage)
+	}
+}
@@ -73,6 +73,19 @@ const (
 
 	BKTableNameHostLock = "cc_HostLock"
 
+	// BKTableNameHostLifecycle the host lifecycle state machine table, each supplier account has one lifecycle
+	BKTableNameHostLifecycle = "cc_HostLifecycle"
+
+	// host snapshot downsampled history, hardware/os field change log and drift tables
+	BKTableNameHostSnapHistory   = "cc_HostSnapHistory"
+	BKTableNameHostSnapChangeLog = "cc_HostSnapChangeLog"
+	BKTableNameHostSnapDrift     = "cc_HostSnapDrift"
+
+	// BKTableNameHostSnapStatus the last snapshot time and the stale flag of the hosts whose agent has reported
+	BKTableNameHostSnapStatus = "cc_HostSnapStatus"
+	// BKTableNameStaleHostPolicy the stale host detection policy table, each supplier account has one policy
+	BKTableNameStaleHostPolicy = "cc_StaleHostPolicy"
+
 	// Operation tables
 	BKTableNameChartConfig   = "cc_ChartConfig"
 	BKTableNameChartPosition = "cc_ChartPosition"
@@ -100,6 +113,14 @@ const (
 	BKTableNameCloudAccount     = "cc_CloudAccount"
 	BKTableNameCloudSyncHistory = "cc_CloudSyncHistory"
 
+	// cloud area ip address management tables, the subnets, the reserved ips of the subnets and the host ips
+	BKTableNameSubnet              = "cc_Subnet"
+	BKTableNameSubnetIPReservation = "cc_SubnetIPReservation"
+	BKTableNameSubnetHostIP        = "cc_SubnetHostIP"
+
+	// BKTableNameHostTransferSchedule the host transfer plans that are scheduled to be executed at a future time
+	BKTableNameHostTransferSchedule = "cc_HostTransferSchedule"
+
 	// BKTableNameWatchToken the table to store the latest watch token for collections
 	BKTableNameWatchToken = "cc_WatchToken"
 
@@ -140,6 +161,12 @@ var AllTables = []string{
 	BKTableNameTransaction,
 	BKTableNameIDgenerator,
 	BKTableNameHostLock,
+	BKTableNameHostLifecycle,
+	BKTableNameHostSnapHistory,
+	BKTableNameHostSnapChangeLog,
+	BKTableNameHostSnapDrift,
+	BKTableNameHostSnapStatus,
+	BKTableNameStaleHostPolicy,
 	BKTableNameObjUnique,
 	BKTableNameAsstDes,
 	BKTableNameServiceCategory,
@@ -158,6 +185,10 @@ var AllTables = []string{
 	BKTableNameCloudSyncTask,
 	BKTableNameCloudAccount,
 	BKTableNameCloudSyncHistory,
+	BKTableNameSubnet,
+	BKTableNameSubnetIPReservation,
+	BKTableNameSubnetHostIP,
+	BKTableNameHostTransferSchedule,
 }
 
 // TableSpecifier is table specifier type which describes the metadata
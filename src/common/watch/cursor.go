@@ -101,6 +101,14 @@ const (
 	KubeWorkload CursorType = "kube_workload"
 	// KubePod cursor type, its event detail is pod info with containers in it
 	KubePod CursorType = "kube_pod"
+	// HostTransferSchedule scheduled host transfer cursor type, its events notify the status changes of the schedules
+	HostTransferSchedule CursorType = "host_transfer_schedule"
+	// KubeService cursor type
+	KubeService CursorType = "kube_service"
+	// KubeIngress cursor type
+	KubeIngress CursorType = "kube_ingress"
+	// KubeEndpoint cursor type, its events notify the membership changes between the services and the pods
+	KubeEndpoint CursorType = "kube_endpoint"
 )
 
 // ToInt TODO
@@ -146,6 +154,14 @@ func (ct CursorType) ToInt() int {
 		return 20
 	case KubePod:
 		return 21
+	case HostTransferSchedule:
+		return 22
+	case KubeService:
+		return 23
+	case KubeIngress:
+		return 24
+	case KubeEndpoint:
+		return 25
 	default:
 		return -1
 	}
@@ -194,6 +210,14 @@ func (ct *CursorType) ParseInt(typ int) {
 		*ct = KubeWorkload
 	case 21:
 		*ct = KubePod
+	case 22:
+		*ct = HostTransferSchedule
+	case 23:
+		*ct = KubeService
+	case 24:
+		*ct = KubeIngress
+	case 25:
+		*ct = KubeEndpoint
 	default:
 		*ct = UnknownType
 	}
@@ -203,7 +227,7 @@ func (ct *CursorType) ParseInt(typ int) {
 func ListCursorTypes() []CursorType {
 	return []CursorType{Host, ModuleHostRelation, Biz, Set, Module, ObjectBase, Process, ProcessInstanceRelation,
 		HostIdentifier, MainlineInstance, InstAsst, BizSet, BizSetRelation, Plat, KubeCluster, KubeNode, KubeNamespace,
-		KubeWorkload, KubePod}
+		KubeWorkload, KubePod, HostTransferSchedule, KubeService, KubeIngress, KubeEndpoint}
 }
 
 // Cursor is a self-defined token which is corresponding to the mongodb's resume token.
@@ -405,6 +429,14 @@ func GetEventCursor(coll string, e *types.Event, instID int64) (string, error) {
 		curType = KubeWorkload
 	case kubetypes.BKTableNameBasePod:
 		curType = KubePod
+	case common.BKTableNameHostTransferSchedule:
+		curType = HostTransferSchedule
+	case kubetypes.BKTableNameBaseService:
+		curType = KubeService
+	case kubetypes.BKTableNameBaseIngress:
+		curType = KubeIngress
+	case kubetypes.BKTableNameBaseEndpoint:
+		curType = KubeEndpoint
 	default:
 		blog.Errorf("unsupported cursor type collection: %s, oid: %s", e.ID())
 		return "", fmt.Errorf("unsupported cursor type collection: %s", coll)
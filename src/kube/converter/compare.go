@@ -0,0 +1,106 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package converter
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"configcenter/src/kube/types"
+)
+
+// NeedUpdate checks if the desired fields differ from the current fields, the empty and null values are regarded
+// as equal, since the unset fields may be saved in either way.
+func NeedUpdate(desired, current interface{}) bool {
+	desiredFields, err := normalizeFields(desired)
+	if err != nil {
+		return true
+	}
+	currentFields, err := normalizeFields(current)
+	if err != nil {
+		return true
+	}
+	return !reflect.DeepEqual(desiredFields, currentFields)
+}
+
+func normalizeFields(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return pruneEmpty(fields), nil
+}
+
+// pruneEmpty removes the empty strings, arrays and objects in the json value
+func pruneEmpty(value interface{}) interface{} {
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for key, item := range val {
+			pruned := pruneEmpty(item)
+			if pruned == nil {
+				delete(val, key)
+				continue
+			}
+			val[key] = pruned
+		}
+		if len(val) == 0 {
+			return nil
+		}
+		return val
+	case []interface{}:
+		if len(val) == 0 {
+			return nil
+		}
+		for idx := range val {
+			val[idx] = pruneEmpty(val[idx])
+		}
+		return val
+	case string:
+		if val == "" {
+			return nil
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+// SamePod checks if the cmdb pod is the same as the desired pod, the pod is recreated if its node, workload or
+// containers changed, since they can not be updated.
+func SamePod(current *types.Pod, containers []types.Container, desired *types.PodsInfo) bool {
+	if current.NodeID != desired.Spec.NodeID || current.Ref.Kind != desired.Spec.Ref.Kind ||
+		current.Ref.ID != desired.Spec.Ref.ID {
+		return false
+	}
+
+	if len(containers) != len(desired.Containers) {
+		return false
+	}
+	containerIDs := make(map[string]struct{}, len(containers))
+	for _, container := range containers {
+		if container.ContainerID != nil {
+			containerIDs[*container.ContainerID] = struct{}{}
+		}
+	}
+	for _, container := range desired.Containers {
+		if _, ok := containerIDs[*container.ContainerID]; !ok {
+			return false
+		}
+	}
+	return true
+}
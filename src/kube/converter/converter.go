@@ -0,0 +1,408 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package converter converts the kubernetes api objects to the cmdb kube types
+package converter
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/kube/types"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NodeRoleLabelPrefix is the label prefix of the node roles, e.g. node-role.kubernetes.io/master
+const NodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// convertByJSON converts the kubernetes api object to the cmdb kube type that has the same json structure
+func convertByJSON(src, dst interface{}) bool {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, dst) == nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+// resourceList converts the kubernetes resource list to cmdb resource list with the serialized quantities
+func resourceList(list corev1.ResourceList) *types.ResourceList {
+	result := make(types.ResourceList, len(list))
+	for name, quantity := range list {
+		result[types.ResourceName(name)] = types.Quantity(quantity.String())
+	}
+	return &result
+}
+
+func int32PtrToInt64(i *int32) *int64 {
+	if i == nil {
+		return nil
+	}
+	return int64Ptr(int64(*i))
+}
+
+// labelsPtr always returns a non nil labels, so that the removed labels are also updated
+func labelsPtr(labels map[string]string) *types.Labels {
+	result := make(types.Labels, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	return &result
+}
+
+func convertSelector(selector *metav1.LabelSelector) *types.LabelSelector {
+	if selector == nil {
+		return nil
+	}
+
+	result := &types.LabelSelector{MatchLabels: selector.MatchLabels}
+	for _, expr := range selector.MatchExpressions {
+		result.MatchExpressions = append(result.MatchExpressions, types.LabelSelectorRequirement{
+			Key:      expr.Key,
+			Operator: types.LabelSelectorOperator(expr.Operator),
+			Values:   expr.Values,
+		})
+	}
+	return result
+}
+
+func convertIntOrString(value *intstr.IntOrString) *types.IntOrString {
+	if value == nil {
+		return nil
+	}
+	return &types.IntOrString{Type: types.Type(value.Type), IntVal: value.IntVal, StrVal: value.StrVal}
+}
+
+// Node converts the kubernetes node to cmdb node without the host and cluster info
+func Node(node *corev1.Node) *types.Node {
+	roles := make([]string, 0)
+	for label := range node.Labels {
+		if strings.HasPrefix(label, NodeRoleLabelPrefix) {
+			roles = append(roles, strings.TrimPrefix(label, NodeRoleLabelPrefix))
+		}
+	}
+	sort.Strings(roles)
+
+	taints := make(enumor.MapStringType, len(node.Spec.Taints))
+	for _, taint := range node.Spec.Taints {
+		taints[taint.Key] = taint.Value
+	}
+
+	internalIPs, externalIPs := make([]string, 0), make([]string, 0)
+	var hostName string
+	for _, address := range node.Status.Addresses {
+		switch address.Type {
+		case corev1.NodeInternalIP:
+			internalIPs = append(internalIPs, address.Address)
+		case corev1.NodeExternalIP:
+			externalIPs = append(externalIPs, address.Address)
+		case corev1.NodeHostName:
+			hostName = address.Address
+		}
+	}
+
+	unschedulable := node.Spec.Unschedulable
+	return &types.Node{
+		Name:             stringPtr(node.Name),
+		Roles:            stringPtr(strings.Join(roles, ",")),
+		Labels:           labelsPtr(node.Labels),
+		Taints:           &taints,
+		Unschedulable:    &unschedulable,
+		InternalIP:       &internalIPs,
+		ExternalIP:       &externalIPs,
+		HostName:         stringPtr(hostName),
+		RuntimeComponent: stringPtr(node.Status.NodeInfo.ContainerRuntimeVersion),
+		PodCidr:          stringPtr(node.Spec.PodCIDR),
+		Allocatable:      resourceList(node.Status.Allocatable),
+	}
+}
+
+// Namespace converts the kubernetes namespace and its resource quotas to cmdb namespace without the cluster info
+func Namespace(namespace *corev1.Namespace, quotas []*corev1.ResourceQuota) *types.Namespace {
+	resourceQuotas := make([]types.ResourceQuota, 0, len(quotas))
+	for _, quota := range quotas {
+		hard := make(map[string]string, len(quota.Spec.Hard))
+		for name, quantity := range quota.Spec.Hard {
+			hard[string(name)] = quantity.String()
+		}
+
+		resourceQuota := types.ResourceQuota{Hard: hard}
+		for _, scope := range quota.Spec.Scopes {
+			resourceQuota.Scopes = append(resourceQuota.Scopes, types.ResourceQuotaScope(scope))
+		}
+		if quota.Spec.ScopeSelector != nil {
+			resourceQuota.ScopeSelector = new(types.ScopeSelector)
+			for _, expr := range quota.Spec.ScopeSelector.MatchExpressions {
+				resourceQuota.ScopeSelector.MatchExpressions = append(resourceQuota.ScopeSelector.MatchExpressions,
+					types.ScopedResourceSelectorRequirement{
+						ScopeName: types.ResourceQuotaScope(expr.ScopeName),
+						Operator:  types.ScopeSelectorOperator(expr.Operator),
+						Values:    expr.Values,
+					})
+			}
+		}
+		resourceQuotas = append(resourceQuotas, resourceQuota)
+	}
+
+	return &types.Namespace{
+		Name:           namespace.Name,
+		Labels:         labelsPtr(namespace.Labels),
+		ResourceQuotas: &resourceQuotas,
+	}
+}
+
+// Deployment converts the kubernetes deployment to cmdb deployment without the base info
+func Deployment(deploy *appsv1.Deployment) *types.Deployment {
+	strategyType := types.DeploymentStrategyType(deploy.Spec.Strategy.Type)
+	workload := &types.Deployment{
+		Labels:          labelsPtr(deploy.Labels),
+		Selector:        convertSelector(deploy.Spec.Selector),
+		Replicas:        int32PtrToInt64(deploy.Spec.Replicas),
+		MinReadySeconds: int64Ptr(int64(deploy.Spec.MinReadySeconds)),
+		StrategyType:    &strategyType,
+	}
+
+	if rollingUpdate := deploy.Spec.Strategy.RollingUpdate; rollingUpdate != nil {
+		workload.RollingUpdateStrategy = &types.RollingUpdateDeployment{
+			MaxUnavailable: convertIntOrString(rollingUpdate.MaxUnavailable),
+			MaxSurge:       convertIntOrString(rollingUpdate.MaxSurge),
+		}
+	}
+	return workload
+}
+
+// StatefulSet converts the kubernetes statefulset to cmdb statefulset without the base info
+func StatefulSet(sts *appsv1.StatefulSet) *types.StatefulSet {
+	strategyType := types.StatefulSetUpdateStrategyType(sts.Spec.UpdateStrategy.Type)
+	workload := &types.StatefulSet{
+		Labels:          labelsPtr(sts.Labels),
+		Selector:        convertSelector(sts.Spec.Selector),
+		Replicas:        int32PtrToInt64(sts.Spec.Replicas),
+		MinReadySeconds: int64Ptr(int64(sts.Spec.MinReadySeconds)),
+		StrategyType:    &strategyType,
+	}
+
+	if rollingUpdate := sts.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		workload.RollingUpdateStrategy = &types.RollingUpdateStatefulSetStrategy{
+			Partition:      rollingUpdate.Partition,
+			MaxUnavailable: convertIntOrString(rollingUpdate.MaxUnavailable),
+		}
+	}
+	return workload
+}
+
+// DaemonSet converts the kubernetes daemonset to cmdb daemonset without the base info
+func DaemonSet(ds *appsv1.DaemonSet) *types.DaemonSet {
+	strategyType := types.DaemonSetUpdateStrategyType(ds.Spec.UpdateStrategy.Type)
+	workload := &types.DaemonSet{
+		Labels:          labelsPtr(ds.Labels),
+		Selector:        convertSelector(ds.Spec.Selector),
+		MinReadySeconds: int64Ptr(int64(ds.Spec.MinReadySeconds)),
+		StrategyType:    &strategyType,
+	}
+
+	if rollingUpdate := ds.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		workload.RollingUpdateStrategy = &types.RollingUpdateDaemonSet{
+			MaxUnavailable: convertIntOrString(rollingUpdate.MaxUnavailable),
+			MaxSurge:       convertIntOrString(rollingUpdate.MaxSurge),
+		}
+	}
+	return workload
+}
+
+// Job converts the kubernetes job to cmdb job without the base info
+func Job(job *batchv1.Job) *types.Job {
+	return &types.Job{
+		Labels:   labelsPtr(job.Labels),
+		Selector: convertSelector(job.Spec.Selector),
+		Replicas: int32PtrToInt64(job.Spec.Parallelism),
+	}
+}
+
+// CronJob converts the kubernetes cronjob to cmdb cronjob without the base info
+func CronJob(cronJob *batchv1.CronJob) *types.CronJob {
+	return &types.CronJob{
+		Labels:   labelsPtr(cronJob.Labels),
+		Selector: convertSelector(cronJob.Spec.JobTemplate.Spec.Selector),
+		Replicas: int32PtrToInt64(cronJob.Spec.JobTemplate.Spec.Parallelism),
+	}
+}
+
+// gameWorkloadSpec is the spec fields of the game deployment and game statefulset that are saved in cmdb, the game
+// deployment defines the rolling update params in the update strategy, and the game statefulset defines them in the
+// rolling update of the update strategy.
+type gameWorkloadSpec struct {
+	Replicas        *int32                `json:"replicas"`
+	MinReadySeconds int32                 `json:"minReadySeconds"`
+	Selector        *metav1.LabelSelector `json:"selector"`
+	UpdateStrategy  struct {
+		Type string `json:"type"`
+		gameRollingUpdate
+		RollingUpdate *gameRollingUpdate `json:"rollingUpdate"`
+	} `json:"updateStrategy"`
+}
+
+type gameRollingUpdate struct {
+	Partition      *int32              `json:"partition"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable"`
+	MaxSurge       *intstr.IntOrString `json:"maxSurge"`
+}
+
+func parseGameWorkloadSpec(obj *unstructured.Unstructured) *gameWorkloadSpec {
+	spec := new(gameWorkloadSpec)
+	if !convertByJSON(obj.Object["spec"], spec) {
+		return new(gameWorkloadSpec)
+	}
+	return spec
+}
+
+// GameDeployment converts the game deployment custom resource to cmdb game deployment without the base info
+func GameDeployment(obj *unstructured.Unstructured) *types.GameDeployment {
+	spec := parseGameWorkloadSpec(obj)
+	strategyType := types.GameDeploymentUpdateStrategyType(spec.UpdateStrategy.Type)
+	rollingUpdate := spec.UpdateStrategy.gameRollingUpdate
+	return &types.GameDeployment{
+		Labels:          labelsPtr(obj.GetLabels()),
+		Selector:        convertSelector(spec.Selector),
+		Replicas:        int32PtrToInt64(spec.Replicas),
+		MinReadySeconds: int64Ptr(int64(spec.MinReadySeconds)),
+		StrategyType:    &strategyType,
+		RollingUpdateStrategy: &types.RollingUpdateGameDeployment{
+			Partition:      rollingUpdate.Partition,
+			MaxUnavailable: convertIntOrString(rollingUpdate.MaxUnavailable),
+			MaxSurge:       convertIntOrString(rollingUpdate.MaxSurge),
+		},
+	}
+}
+
+// GameStatefulSet converts the game statefulset custom resource to cmdb game statefulset without the base info
+func GameStatefulSet(obj *unstructured.Unstructured) *types.GameStatefulSet {
+	spec := parseGameWorkloadSpec(obj)
+	strategyType := types.GameStatefulSetUpdateStrategyType(spec.UpdateStrategy.Type)
+	workload := &types.GameStatefulSet{
+		Labels:          labelsPtr(obj.GetLabels()),
+		Selector:        convertSelector(spec.Selector),
+		Replicas:        int32PtrToInt64(spec.Replicas),
+		MinReadySeconds: int64Ptr(int64(spec.MinReadySeconds)),
+		StrategyType:    &strategyType,
+	}
+
+	if rollingUpdate := spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		workload.RollingUpdateStrategy = &types.RollingUpdateGameStatefulSetStrategy{
+			Partition:      rollingUpdate.Partition,
+			MaxUnavailable: convertIntOrString(rollingUpdate.MaxUnavailable),
+			MaxSurge:       convertIntOrString(rollingUpdate.MaxSurge),
+		}
+	}
+	return workload
+}
+
+// Pod converts the kubernetes pod to cmdb pod and its containers without the cmdb relations, only the
+// containers that have been created, which means that they have a container id, are returned.
+func Pod(pod *corev1.Pod) (types.Pod, []types.Container) {
+	ips := make([]types.PodIP, 0, len(pod.Status.PodIPs))
+	for _, ip := range pod.Status.PodIPs {
+		ips = append(ips, types.PodIP{IP: ip.IP})
+	}
+	qosClass := types.PodQOSClass(pod.Status.QOSClass)
+
+	result := types.Pod{
+		Name:          stringPtr(pod.Name),
+		Priority:      pod.Spec.Priority,
+		Labels:        labelsPtr(pod.Labels),
+		IP:            stringPtr(pod.Status.PodIP),
+		IPs:           &ips,
+		QOSClass:      &qosClass,
+		NodeSelectors: (*map[string]string)(labelsPtr(pod.Spec.NodeSelector)),
+	}
+
+	volumes := make([]types.Volume, 0)
+	if convertByJSON(pod.Spec.Volumes, &volumes) {
+		result.Volumes = &volumes
+	}
+	tolerations := make([]types.Toleration, 0)
+	if convertByJSON(pod.Spec.Tolerations, &tolerations) {
+		result.Tolerations = &tolerations
+	}
+
+	statuses := make(map[string]corev1.ContainerStatus, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	containers := make([]types.Container, 0, len(pod.Spec.Containers))
+	for idx := range pod.Spec.Containers {
+		status, exists := statuses[pod.Spec.Containers[idx].Name]
+		if !exists || status.ContainerID == "" {
+			continue
+		}
+		containers = append(containers, convertContainer(&pod.Spec.Containers[idx], &status))
+	}
+	return result, containers
+}
+
+func convertContainer(container *corev1.Container, status *corev1.ContainerStatus) types.Container {
+	result := types.Container{
+		Name:        stringPtr(container.Name),
+		ContainerID: stringPtr(status.ContainerID),
+		Image:       stringPtr(container.Image),
+		Args:        &container.Args,
+		Limits:      resourceList(container.Resources.Limits),
+		// the request of a resource is recorded as it is, it defaults to the limit when it is aggregated
+		ReqSysSpecuests: resourceList(container.Resources.Requests),
+	}
+	if status.State.Running != nil {
+		result.Started = int64Ptr(status.State.Running.StartedAt.Unix())
+	}
+
+	ports, hostPorts := make([]types.ContainerPort, 0), make([]types.ContainerPort, 0)
+	if convertByJSON(container.Ports, &ports) {
+		result.Ports = &ports
+		for _, port := range ports {
+			if port.HostPort != 0 {
+				hostPorts = append(hostPorts, port)
+			}
+		}
+		result.HostPorts = &hostPorts
+	}
+
+	if container.LivenessProbe != nil {
+		liveness := new(types.Probe)
+		if convertByJSON(container.LivenessProbe, liveness) {
+			result.Liveness = liveness
+		}
+	}
+	environment := make([]types.EnvVar, 0)
+	if convertByJSON(container.Env, &environment) {
+		result.Environment = &environment
+	}
+	mounts := make([]types.VolumeMount, 0)
+	if convertByJSON(container.VolumeMounts, &mounts) {
+		result.Mounts = &mounts
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+)
+
+// ConfigConsumersOption find the workloads and pods that consume the config maps or secrets request
+type ConfigConsumersOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate ConfigConsumersOption
+func (opt *ConfigConsumersOption) Validate() errors.RawErrorInfo {
+	return validateIDs(opt.IDs, ConfigMapQueryLimit)
+}
+
+// ConfigConsumers the workloads and pods that consume the config map or secret
+type ConfigConsumers struct {
+	// ID config map or secret id
+	ID        int64           `json:"id"`
+	Workloads []Reference     `json:"workloads"`
+	Pods      []PodSimpleInfo `json:"pods"`
+}
+
+// BuildConfigPodCond build the condition of the pods whose volumes refer to the config maps or secrets, the names
+// is the map of the namespace id to the config map or secret names in it.
+func BuildConfigPodCond(kind string, names map[int64][]string) mapstr.MapStr {
+	volumeField, projectionField := ConfigMapVolumeNameField, ConfigMapProjectionNameField
+	if kind == KubeSecret {
+		volumeField, projectionField = SecretVolumeNameField, SecretProjectionNameField
+	}
+
+	orCond := make([]mapstr.MapStr, 0)
+	for nsID, nsNames := range names {
+		orCond = append(orCond, mapstr.MapStr{
+			BKNamespaceIDField: nsID,
+			common.BKDBOR: []mapstr.MapStr{
+				{volumeField: mapstr.MapStr{common.BKDBIN: nsNames}},
+				{projectionField: mapstr.MapStr{common.BKDBIN: nsNames}},
+			},
+		})
+	}
+
+	return mapstr.MapStr{common.BKDBOR: orCond}
+}
+
+// BuildConfigContainerCond build the condition of the containers whose environments refer to the config maps or
+// secrets with the names, the containers do not have namespace, so their pods need to be checked by the caller.
+func BuildConfigContainerCond(kind string, names []string) mapstr.MapStr {
+	envField := ConfigMapEnvNameField
+	if kind == KubeSecret {
+		envField = SecretEnvNameField
+	}
+
+	return mapstr.MapStr{envField: mapstr.MapStr{common.BKDBIN: util.StrArrayUnique(names)}}
+}
+
+// GetVolumeConfigNames get the names of the config maps or secrets that the pod volumes refer to, including the
+// ones in the projected volumes.
+func GetVolumeConfigNames(kind string, volumes []Volume) []string {
+	names := make([]string, 0)
+	for _, volume := range volumes {
+		switch kind {
+		case KubeConfigMap:
+			if volume.ConfigMap != nil {
+				names = append(names, volume.ConfigMap.Name)
+			}
+		case KubeSecret:
+			if volume.Secret != nil {
+				names = append(names, volume.Secret.SecretName)
+			}
+		}
+
+		if volume.Projected == nil {
+			continue
+		}
+
+		for _, source := range volume.Projected.Sources {
+			if kind == KubeConfigMap && source.ConfigMap != nil {
+				names = append(names, source.ConfigMap.Name)
+			}
+			if kind == KubeSecret && source.Secret != nil {
+				names = append(names, source.Secret.Name)
+			}
+		}
+	}
+
+	return util.StrArrayUnique(names)
+}
+
+// GetEnvConfigNames get the names of the config maps or secrets that the container environments refer to
+func GetEnvConfigNames(kind string, envs []EnvVar) []string {
+	names := make([]string, 0)
+	for _, env := range envs {
+		if env.ValueFrom == nil {
+			continue
+		}
+
+		if kind == KubeConfigMap && env.ValueFrom.ConfigMapKeyRef != nil {
+			names = append(names, env.ValueFrom.ConfigMapKeyRef.Name)
+		}
+		if kind == KubeSecret && env.ValueFrom.SecretKeyRef != nil {
+			names = append(names, env.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+
+	return util.StrArrayUnique(names)
+}
@@ -0,0 +1,69 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestGetVolumeConfigNames config map and secret names in pod volumes unit test
+func TestGetVolumeConfigNames(t *testing.T) {
+	volumes := []Volume{
+		{Name: "conf", VolumeSource: VolumeSource{
+			ConfigMap: &ConfigMapVolumeSource{LocalObjectReference: LocalObjectReference{Name: "app-conf"}}}},
+		{Name: "cert", VolumeSource: VolumeSource{Secret: &SecretVolumeSource{SecretName: "app-cert"}}},
+		{Name: "all", VolumeSource: VolumeSource{Projected: &ProjectedVolumeSource{Sources: []VolumeProjection{
+			{ConfigMap: &ConfigMapProjection{LocalObjectReference: LocalObjectReference{Name: "app-conf"}}},
+			{ConfigMap: &ConfigMapProjection{LocalObjectReference: LocalObjectReference{Name: "common-conf"}}},
+			{Secret: &SecretProjection{LocalObjectReference: LocalObjectReference{Name: "app-token"}}},
+		}}}},
+	}
+
+	names := GetVolumeConfigNames(KubeConfigMap, volumes)
+	if !reflect.DeepEqual(names, []string{"app-conf", "common-conf"}) {
+		t.Fatalf("config map names %v is not as expected", names)
+	}
+
+	names = GetVolumeConfigNames(KubeSecret, volumes)
+	if !reflect.DeepEqual(names, []string{"app-cert", "app-token"}) {
+		t.Fatalf("secret names %v is not as expected", names)
+	}
+}
+
+// TestGetEnvConfigNames config map and secret names in container environments unit test
+func TestGetEnvConfigNames(t *testing.T) {
+	envs := []EnvVar{
+		{Name: "MODE", Value: "prod"},
+		{Name: "LOG_LEVEL", ValueFrom: &EnvVarSource{ConfigMapKeyRef: &ConfigMapKeySelector{
+			LocalObjectReference: LocalObjectReference{Name: "app-conf"}, Key: "log_level"}}},
+		{Name: "DB_PASSWORD", ValueFrom: &EnvVarSource{SecretKeyRef: &SecretKeySelector{
+			LocalObjectReference: LocalObjectReference{Name: "db-auth"}, Key: "password"}}},
+		{Name: "POD_IP", ValueFrom: &EnvVarSource{FieldRef: &ObjectFieldSelector{FieldPath: "status.podIP"}}},
+	}
+
+	names := GetEnvConfigNames(KubeConfigMap, envs)
+	if !reflect.DeepEqual(names, []string{"app-conf"}) {
+		t.Fatalf("config map names %v is not as expected", names)
+	}
+
+	names = GetEnvConfigNames(KubeSecret, envs)
+	if !reflect.DeepEqual(names, []string{"db-auth"}) {
+		t.Fatalf("secret names %v is not as expected", names)
+	}
+}
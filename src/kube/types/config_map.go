@@ -0,0 +1,188 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// ConfigMapFields merge the fields of the config map and the details corresponding to the fields together.
+var ConfigMapFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor, ClusterBaseRefDescriptor,
+	NamespaceBaseRefDescriptor, ConfigMapSpecFieldsDescriptor)
+
+// ConfigMapSpecFieldsDescriptor config map spec's fields descriptors.
+var ConfigMapSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: KubeNameField, Type: enumor.String, IsRequired: true, IsEditable: false},
+	{Field: LabelsField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+	{Field: KeysField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+	{Field: ContentHashField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: ImmutableField, Type: enumor.Boolean, IsRequired: false, IsEditable: true},
+}
+
+const (
+	// ConfigMapUpdateLimit limit on the number of config map updates
+	ConfigMapUpdateLimit = 200
+	// ConfigMapDeleteLimit limit on the number of config map delete
+	ConfigMapDeleteLimit = 200
+	// ConfigMapCreateLimit limit on the number of config map create
+	ConfigMapCreateLimit = 200
+	// ConfigMapQueryLimit limit on the number of config map query
+	ConfigMapQueryLimit = 500
+)
+
+// ConfigMap define the config map struct, only the metadata of the config map is recorded, the data is not saved.
+type ConfigMap struct {
+	NamespaceSpec `json:",inline" bson:",inline"`
+	ID            int64   `json:"id,omitempty" bson:"id"`
+	Name          string  `json:"name,omitempty" bson:"name"`
+	Labels        *Labels `json:"labels,omitempty" bson:"labels"`
+	// Keys the keys of the data and the binary data in the config map
+	Keys *[]string `json:"keys,omitempty" bson:"keys"`
+	// ContentHash the hash of the data in the config map, it is used to find out whether the content is changed
+	ContentHash     *string `json:"content_hash,omitempty" bson:"content_hash"`
+	Immutable       *bool   `json:"immutable,omitempty" bson:"immutable"`
+	SupplierAccount string  `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// validateCreate validate create config map
+func (cm *ConfigMap) validateCreate() errors.RawErrorInfo {
+	if cm.NamespaceID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKNamespaceIDField},
+		}
+	}
+
+	if cm.Name == "" {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{common.BKFieldName},
+		}
+	}
+
+	return ValidateCreate(*cm, ConfigMapFields)
+}
+
+// BuildUpdateData build config map update data
+func (cm *ConfigMap) BuildUpdateData(user string) (map[string]interface{}, error) {
+	return buildUpdateData(cm, user)
+}
+
+// ConfigMapCreateOption create config map request
+type ConfigMapCreateOption struct {
+	Data []ConfigMap `json:"data"`
+}
+
+// Validate validate ConfigMapCreateOption
+func (opt *ConfigMapCreateOption) Validate() errors.RawErrorInfo {
+	if len(opt.Data) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(opt.Data) > ConfigMapCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", ConfigMapCreateLimit},
+		}
+	}
+
+	for _, data := range opt.Data {
+		if err := data.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// ConfigMapUpdateOption update config map request
+type ConfigMapUpdateOption struct {
+	IDs  []int64    `json:"ids"`
+	Data *ConfigMap `json:"data"`
+}
+
+// Validate validate ConfigMapUpdateOption
+func (opt *ConfigMapUpdateOption) Validate() errors.RawErrorInfo {
+	if err := validateIDs(opt.IDs, ConfigMapUpdateLimit); err.ErrCode != 0 {
+		return err
+	}
+
+	if opt.Data == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	return ValidateUpdate(*opt.Data, ConfigMapFields)
+}
+
+// ConfigMapDeleteOption delete config map request
+type ConfigMapDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate ConfigMapDeleteOption
+func (opt *ConfigMapDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(opt.IDs, ConfigMapDeleteLimit)
+}
+
+// ConfigMapQueryOption config map query request
+type ConfigMapQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate ConfigMapQueryOption
+func (opt *ConfigMapQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(opt.Filter, opt.Page, ConfigMapQueryLimit, ConfigMapFields)
+}
+
+// BuildCond build query config map condition
+func (opt *ConfigMapQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, opt.Filter)
+}
+
+// ConfigMapCreateResp create config map response
+type ConfigMapCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// ConfigMapInstResp config map instance response
+type ConfigMapInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              ConfigMapDataResp `json:"data"`
+}
+
+// ConfigMapDataResp config map data
+type ConfigMapDataResp struct {
+	Data []ConfigMap `json:"data"`
+}
@@ -71,7 +71,7 @@ type RollingUpdateDaemonSet struct {
 // DaemonSet define the daemonSet struct.
 type DaemonSet struct {
 	WorkloadBase          `json:",inline" bson:",inline"`
-	Labels                *map[string]string           `json:"labels,omitempty" bson:"labels"`
+	Labels                *Labels                      `json:"labels,omitempty" bson:"labels"`
 	Selector              *LabelSelector               `json:"selector,omitempty" bson:"selector"`
 	Replicas              *int64                       `json:"replicas,omitempty" bson:"replicas"`
 	MinReadySeconds       *int64                       `json:"min_ready_seconds,omitempty" bson:"min_ready_seconds"`
@@ -0,0 +1,201 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// EndpointFields merge the fields of the endpoint and the details corresponding to the fields together.
+var EndpointFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor, ClusterBaseRefDescriptor,
+	NamespaceBaseRefDescriptor, ServiceBaseRefDescriptor, EndpointSpecFieldsDescriptor)
+
+// EndpointSpecFieldsDescriptor endpoint spec's fields descriptors.
+var EndpointSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: BKPodIDField, Type: enumor.Numeric, IsRequired: true, IsEditable: false},
+	{Field: PodNameField, Type: enumor.String, IsRequired: false, IsEditable: false},
+	{Field: AddressesField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+	{Field: ReadyField, Type: enumor.Boolean, IsRequired: false, IsEditable: true},
+	{Field: PortsField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+}
+
+const (
+	// EpUpdateLimit limit on the number of endpoint updates
+	EpUpdateLimit = 200
+	// EpDeleteLimit limit on the number of endpoint delete
+	EpDeleteLimit = 200
+	// EpCreateLimit limit on the number of endpoint create
+	EpCreateLimit = 200
+	// EpQueryLimit limit on the number of endpoint query
+	EpQueryLimit = 500
+)
+
+// Endpoint define the endpoint struct, it represents that a pod is a member of a service, the membership is
+// derived from the endpoint slices of the service, so a pod that is selected by multiple services has an endpoint
+// for each of the services.
+type Endpoint struct {
+	NamespaceSpec   `json:",inline" bson:",inline"`
+	ID              int64           `json:"id,omitempty" bson:"id"`
+	ServiceID       int64           `json:"bk_service_id,omitempty" bson:"bk_service_id"`
+	ServiceName     string          `json:"service_name,omitempty" bson:"service_name"`
+	PodID           int64           `json:"bk_pod_id,omitempty" bson:"bk_pod_id"`
+	PodName         string          `json:"pod_name,omitempty" bson:"pod_name"`
+	Addresses       *[]string       `json:"addresses,omitempty" bson:"addresses"`
+	Ready           *bool           `json:"ready,omitempty" bson:"ready"`
+	Ports           *[]EndpointPort `json:"ports,omitempty" bson:"ports"`
+	SupplierAccount string          `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// EndpointPort represents a port used by an endpoint.
+type EndpointPort struct {
+	// Name the name of this port, it corresponds to the name of a service port.
+	Name string `json:"name" bson:"name"`
+	// Protocol the IP protocol for this port, supports "TCP", "UDP", and "SCTP".
+	Protocol Protocol `json:"protocol" bson:"protocol"`
+	// Port the port number of the endpoint.
+	Port int32 `json:"port" bson:"port"`
+}
+
+// validateCreate validate create endpoint
+func (ep *Endpoint) validateCreate() errors.RawErrorInfo {
+	if ep.ServiceID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKServiceIDField},
+		}
+	}
+
+	if ep.PodID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKPodIDField},
+		}
+	}
+
+	return ValidateCreate(*ep, EndpointFields)
+}
+
+// BuildUpdateData build endpoint update data
+func (ep *Endpoint) BuildUpdateData(user string) (map[string]interface{}, error) {
+	return buildUpdateData(ep, user)
+}
+
+// EndpointCreateOption create endpoint request
+type EndpointCreateOption struct {
+	Data []Endpoint `json:"data"`
+}
+
+// Validate validate EndpointCreateOption
+func (e *EndpointCreateOption) Validate() errors.RawErrorInfo {
+	if len(e.Data) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(e.Data) > EpCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", EpCreateLimit},
+		}
+	}
+
+	for _, data := range e.Data {
+		if err := data.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// EndpointUpdateOption update endpoint request
+type EndpointUpdateOption struct {
+	IDs  []int64   `json:"ids"`
+	Data *Endpoint `json:"data"`
+}
+
+// Validate validate EndpointUpdateOption
+func (e *EndpointUpdateOption) Validate() errors.RawErrorInfo {
+	if err := validateIDs(e.IDs, EpUpdateLimit); err.ErrCode != 0 {
+		return err
+	}
+
+	if e.Data == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	return ValidateUpdate(*e.Data, EndpointFields)
+}
+
+// EndpointDeleteOption delete endpoint request
+type EndpointDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate EndpointDeleteOption
+func (e *EndpointDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(e.IDs, EpDeleteLimit)
+}
+
+// EndpointQueryOption endpoint query request, the pods of a service can be found by bk_service_id, and the services
+// that a pod belongs to can be found by bk_pod_id.
+type EndpointQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate EndpointQueryOption
+func (e *EndpointQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(e.Filter, e.Page, EpQueryLimit, EndpointFields)
+}
+
+// BuildCond build query endpoint condition
+func (e *EndpointQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, e.Filter)
+}
+
+// EndpointCreateResp create endpoint response
+type EndpointCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// EndpointInstResp endpoint instance response
+type EndpointInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              EndpointDataResp `json:"data"`
+}
+
+// EndpointDataResp endpoint data
+type EndpointDataResp struct {
+	Data []Endpoint `json:"data"`
+}
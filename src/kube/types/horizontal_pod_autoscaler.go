@@ -0,0 +1,261 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// HorizontalPodAutoscalerFields merge the fields of the horizontal pod autoscaler and the details corresponding to
+// the fields together.
+var HorizontalPodAutoscalerFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor,
+	ClusterBaseRefDescriptor, NamespaceBaseRefDescriptor, WorkLoadRefDescriptor,
+	HorizontalPodAutoscalerSpecFieldsDescriptor)
+
+// HorizontalPodAutoscalerSpecFieldsDescriptor horizontal pod autoscaler spec's fields descriptors.
+var HorizontalPodAutoscalerSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: KubeNameField, Type: enumor.String, IsRequired: true, IsEditable: false},
+	{Field: LabelsField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+	{Field: MinReplicasField, Type: enumor.Numeric, IsRequired: false, IsEditable: true},
+	{Field: MaxReplicasField, Type: enumor.Numeric, IsRequired: true, IsEditable: true},
+	{Field: MetricsField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+	{Field: CurrentReplicasField, Type: enumor.Numeric, IsRequired: false, IsEditable: true},
+	{Field: DesiredReplicasField, Type: enumor.Numeric, IsRequired: false, IsEditable: true},
+}
+
+const (
+	// HpaUpdateLimit limit on the number of horizontal pod autoscaler updates
+	HpaUpdateLimit = 200
+	// HpaDeleteLimit limit on the number of horizontal pod autoscaler delete
+	HpaDeleteLimit = 200
+	// HpaCreateLimit limit on the number of horizontal pod autoscaler create
+	HpaCreateLimit = 200
+	// HpaQueryLimit limit on the number of horizontal pod autoscaler query
+	HpaQueryLimit = 500
+)
+
+// HorizontalPodAutoscaler define the horizontal pod autoscaler struct, the ref is the workload it scales.
+type HorizontalPodAutoscaler struct {
+	WorkloadSpec `json:",inline" bson:",inline"`
+	ID           int64        `json:"id,omitempty" bson:"id"`
+	Name         string       `json:"name,omitempty" bson:"name"`
+	Labels       *Labels      `json:"labels,omitempty" bson:"labels"`
+	MinReplicas  *int64       `json:"min_replicas,omitempty" bson:"min_replicas"`
+	MaxReplicas  *int64       `json:"max_replicas,omitempty" bson:"max_replicas"`
+	Metrics      *[]HPAMetric `json:"metrics,omitempty" bson:"metrics"`
+	// CurrentReplicas the current number of the replicas of the pods managed by this autoscaler
+	CurrentReplicas *int64 `json:"current_replicas,omitempty" bson:"current_replicas"`
+	// DesiredReplicas the desired number of the replicas of the pods calculated by this autoscaler
+	DesiredReplicas *int64 `json:"desired_replicas,omitempty" bson:"desired_replicas"`
+	SupplierAccount string `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// HPAMetric the metric used to calculate the desired replica count of the horizontal pod autoscaler
+type HPAMetric struct {
+	// Type the metric source type, like "Resource", "Pods", "Object", "External" and "ContainerResource"
+	Type string `json:"type" bson:"type"`
+	// Name the name of the metric, it is the resource name for the resource metrics, like "cpu"
+	Name string `json:"name" bson:"name"`
+	// TargetType the type of the target value, like "Utilization", "Value" and "AverageValue"
+	TargetType string `json:"target_type" bson:"target_type"`
+	// TargetValue the target value of the metric, it is the percentage for "Utilization" type, or the quantity
+	TargetValue string `json:"target_value" bson:"target_value"`
+}
+
+// validateReplicas validate the min and max replicas of the horizontal pod autoscaler
+func validateReplicas(minReplicas, maxReplicas *int64) errors.RawErrorInfo {
+	if minReplicas != nil && *minReplicas < 1 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{MinReplicasField},
+		}
+	}
+
+	if maxReplicas != nil && *maxReplicas < 1 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{MaxReplicasField},
+		}
+	}
+
+	if minReplicas != nil && maxReplicas != nil && *minReplicas > *maxReplicas {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{MinReplicasField},
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// validateCreate validate create horizontal pod autoscaler
+func (hpa *HorizontalPodAutoscaler) validateCreate() errors.RawErrorInfo {
+	if hpa.NamespaceID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKNamespaceIDField},
+		}
+	}
+
+	if hpa.Name == "" {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{common.BKFieldName},
+		}
+	}
+
+	if err := hpa.Ref.Kind.Validate(); err != nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{RefKindField},
+		}
+	}
+
+	if hpa.Ref.Name == "" {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{RefNameField},
+		}
+	}
+
+	if hpa.MaxReplicas == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{MaxReplicasField},
+		}
+	}
+
+	if err := validateReplicas(hpa.MinReplicas, hpa.MaxReplicas); err.ErrCode != 0 {
+		return err
+	}
+
+	return ValidateCreate(*hpa, HorizontalPodAutoscalerFields)
+}
+
+// BuildUpdateData build horizontal pod autoscaler update data
+func (hpa *HorizontalPodAutoscaler) BuildUpdateData(user string) (map[string]interface{}, error) {
+	return buildUpdateData(hpa, user)
+}
+
+// HorizontalPodAutoscalerCreateOption create horizontal pod autoscaler request
+type HorizontalPodAutoscalerCreateOption struct {
+	Data []HorizontalPodAutoscaler `json:"data"`
+}
+
+// Validate validate HorizontalPodAutoscalerCreateOption
+func (opt *HorizontalPodAutoscalerCreateOption) Validate() errors.RawErrorInfo {
+	if len(opt.Data) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(opt.Data) > HpaCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", HpaCreateLimit},
+		}
+	}
+
+	for _, data := range opt.Data {
+		if err := data.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// HorizontalPodAutoscalerUpdateOption update horizontal pod autoscaler request
+type HorizontalPodAutoscalerUpdateOption struct {
+	IDs  []int64                  `json:"ids"`
+	Data *HorizontalPodAutoscaler `json:"data"`
+}
+
+// Validate validate HorizontalPodAutoscalerUpdateOption
+func (opt *HorizontalPodAutoscalerUpdateOption) Validate() errors.RawErrorInfo {
+	if err := validateIDs(opt.IDs, HpaUpdateLimit); err.ErrCode != 0 {
+		return err
+	}
+
+	if opt.Data == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if err := validateReplicas(opt.Data.MinReplicas, opt.Data.MaxReplicas); err.ErrCode != 0 {
+		return err
+	}
+
+	return ValidateUpdate(*opt.Data, HorizontalPodAutoscalerFields)
+}
+
+// HorizontalPodAutoscalerDeleteOption delete horizontal pod autoscaler request
+type HorizontalPodAutoscalerDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate HorizontalPodAutoscalerDeleteOption
+func (opt *HorizontalPodAutoscalerDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(opt.IDs, HpaDeleteLimit)
+}
+
+// HorizontalPodAutoscalerQueryOption horizontal pod autoscaler query request
+type HorizontalPodAutoscalerQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate HorizontalPodAutoscalerQueryOption
+func (opt *HorizontalPodAutoscalerQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(opt.Filter, opt.Page, HpaQueryLimit, HorizontalPodAutoscalerFields)
+}
+
+// BuildCond build query horizontal pod autoscaler condition
+func (opt *HorizontalPodAutoscalerQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, opt.Filter)
+}
+
+// HorizontalPodAutoscalerCreateResp create horizontal pod autoscaler response
+type HorizontalPodAutoscalerCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// HorizontalPodAutoscalerInstResp horizontal pod autoscaler instance response
+type HorizontalPodAutoscalerInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              HorizontalPodAutoscalerDataResp `json:"data"`
+}
+
+// HorizontalPodAutoscalerDataResp horizontal pod autoscaler data
+type HorizontalPodAutoscalerDataResp struct {
+	Data []HorizontalPodAutoscaler `json:"data"`
+}
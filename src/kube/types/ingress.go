@@ -0,0 +1,221 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// IngressFields merge the fields of the ingress and the details corresponding to the fields together.
+var IngressFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor, ClusterBaseRefDescriptor,
+	NamespaceBaseRefDescriptor, IngressSpecFieldsDescriptor)
+
+// IngressSpecFieldsDescriptor ingress spec's fields descriptors.
+var IngressSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: KubeNameField, Type: enumor.String, IsRequired: true, IsEditable: false},
+	{Field: LabelsField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+	{Field: IngressClassNameField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: RulesField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+	{Field: TLSField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+}
+
+const (
+	// IngUpdateLimit limit on the number of ingress updates
+	IngUpdateLimit = 200
+	// IngDeleteLimit limit on the number of ingress delete
+	IngDeleteLimit = 200
+	// IngCreateLimit limit on the number of ingress create
+	IngCreateLimit = 200
+	// IngQueryLimit limit on the number of ingress query
+	IngQueryLimit = 500
+)
+
+// Ingress define the ingress struct.
+type Ingress struct {
+	NamespaceSpec    `json:",inline" bson:",inline"`
+	ID               int64          `json:"id,omitempty" bson:"id"`
+	Name             string         `json:"name,omitempty" bson:"name"`
+	Labels           *Labels        `json:"labels,omitempty" bson:"labels"`
+	IngressClassName *string        `json:"ingress_class_name,omitempty" bson:"ingress_class_name"`
+	Rules            *[]IngressRule `json:"rules,omitempty" bson:"rules"`
+	TLS              *[]IngressTLS  `json:"tls,omitempty" bson:"tls"`
+	SupplierAccount  string         `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// IngressRule represents the rules mapping the paths under a specified host to the related backend services.
+type IngressRule struct {
+	// Host the fully qualified domain name of a network host, empty host means all the inbound traffic.
+	Host string `json:"host" bson:"host"`
+	// Paths a collection of paths that map requests to backends.
+	Paths []IngressPath `json:"paths" bson:"paths"`
+}
+
+// IngressPath associates a path with a backend.
+type IngressPath struct {
+	// Path is matched against the path of an incoming request.
+	Path string `json:"path" bson:"path"`
+	// PathType determines the interpretation of the path matching, it can be Exact, Prefix or
+	// ImplementationSpecific.
+	PathType string `json:"path_type" bson:"path_type"`
+	// Backend defines the referenced service endpoint to which the traffic will be forwarded to.
+	Backend IngressBackend `json:"backend" bson:"backend"`
+}
+
+// IngressBackend describes the service in the same namespace that the traffic is forwarded to.
+type IngressBackend struct {
+	// ServiceName the referenced service name.
+	ServiceName string `json:"service_name" bson:"service_name"`
+	// ServicePort the port name or number of the referenced service.
+	ServicePort IntOrString `json:"service_port" bson:"service_port"`
+}
+
+// IngressTLS describes the transport layer security associated with an Ingress.
+type IngressTLS struct {
+	// Hosts a list of hosts included in the TLS certificate.
+	Hosts []string `json:"hosts" bson:"hosts"`
+	// SecretName the name of the secret used to terminate TLS traffic.
+	SecretName string `json:"secret_name" bson:"secret_name"`
+}
+
+// validateCreate validate create ingress
+func (ing *Ingress) validateCreate() errors.RawErrorInfo {
+	if ing.NamespaceID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKNamespaceIDField},
+		}
+	}
+
+	if ing.Name == "" {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{common.BKFieldName},
+		}
+	}
+
+	return ValidateCreate(*ing, IngressFields)
+}
+
+// BuildUpdateData build ingress update data
+func (ing *Ingress) BuildUpdateData(user string) (map[string]interface{}, error) {
+	return buildUpdateData(ing, user)
+}
+
+// IngressCreateOption create ingress request
+type IngressCreateOption struct {
+	Data []Ingress `json:"data"`
+}
+
+// Validate validate IngressCreateOption
+func (i *IngressCreateOption) Validate() errors.RawErrorInfo {
+	if len(i.Data) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(i.Data) > IngCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", IngCreateLimit},
+		}
+	}
+
+	for _, data := range i.Data {
+		if err := data.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// IngressUpdateOption update ingress request
+type IngressUpdateOption struct {
+	IDs  []int64  `json:"ids"`
+	Data *Ingress `json:"data"`
+}
+
+// Validate validate IngressUpdateOption
+func (i *IngressUpdateOption) Validate() errors.RawErrorInfo {
+	if err := validateIDs(i.IDs, IngUpdateLimit); err.ErrCode != 0 {
+		return err
+	}
+
+	if i.Data == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	return ValidateUpdate(*i.Data, IngressFields)
+}
+
+// IngressDeleteOption delete ingress request
+type IngressDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate IngressDeleteOption
+func (i *IngressDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(i.IDs, IngDeleteLimit)
+}
+
+// IngressQueryOption ingress query request
+type IngressQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate IngressQueryOption
+func (i *IngressQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(i.Filter, i.Page, IngQueryLimit, IngressFields)
+}
+
+// BuildCond build query ingress condition
+func (i *IngressQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, i.Filter)
+}
+
+// IngressCreateResp create ingress response
+type IngressCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// IngressInstResp ingress instance response
+type IngressInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              IngressDataResp `json:"data"`
+}
+
+// IngressDataResp ingress data
+type IngressDataResp struct {
+	Data []Ingress `json:"data"`
+}
@@ -46,10 +46,10 @@ var JobSpecFieldsDescriptor = table.FieldsDescriptors{
 // Job define the job struct.
 type Job struct {
 	WorkloadBase    `json:",inline" bson:",inline"`
-	Labels          *map[string]string `json:"labels,omitempty" bson:"labels"`
-	Selector        *LabelSelector     `json:"selector,omitempty" bson:"selector"`
-	Replicas        *int64             `json:"replicas,omitempty" bson:"replicas"`
-	MinReadySeconds *int64             `json:"min_ready_seconds,omitempty" bson:"min_ready_seconds"`
+	Labels          *Labels        `json:"labels,omitempty" bson:"labels"`
+	Selector        *LabelSelector `json:"selector,omitempty" bson:"selector"`
+	Replicas        *int64         `json:"replicas,omitempty" bson:"replicas"`
+	MinReadySeconds *int64         `json:"min_ready_seconds,omitempty" bson:"min_ready_seconds"`
 }
 
 // GetWorkloadBase get workload base
@@ -0,0 +1,211 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"fmt"
+
+	"configcenter/src/common"
+	ccErr "configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/selector"
+	"configcenter/src/common/util"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// LabelSelectorField the kubernetes style label selector field of the query request
+const LabelSelectorField = "label_selector"
+
+// labelSelectorOperators the label selector operators that can be converted to the selector operators
+var labelSelectorOperators = map[selection.Operator]selector.Operator{
+	selection.Equals:       selector.Equals,
+	selection.DoubleEquals: selector.Equals,
+	selection.NotEquals:    selector.NotEquals,
+	selection.In:           selector.In,
+	selection.NotIn:        selector.NotIn,
+	selection.Exists:       selector.Exists,
+	selection.DoesNotExist: selector.DoesNotExist,
+}
+
+// ParseLabelSelector parse the kubernetes style label selector string, like "app=payments,tier!=canary",
+// "env in (prod,test)", "release" or "!canary", to the selectors of the labels field.
+// the gt and lt operators are not supported, since the label values are stored as strings.
+func ParseLabelSelector(labelSelector string) (selector.Selectors, error) {
+	parsed, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements, _ := parsed.Requirements()
+	selectors := make(selector.Selectors, 0, len(requirements))
+	for _, requirement := range requirements {
+		operator, exists := labelSelectorOperators[requirement.Operator()]
+		if !exists {
+			return nil, fmt.Errorf("label selector operator %s is not supported", requirement.Operator())
+		}
+
+		selectors = append(selectors, selector.Selector{
+			Key:      requirement.Key(),
+			Operator: operator,
+			Values:   requirement.Values().List(),
+		})
+	}
+	return selectors, nil
+}
+
+// validateLabelSelector validate the label selector string of the query request
+func validateLabelSelector(labelSelector string) ccErr.RawErrorInfo {
+	if labelSelector == "" {
+		return ccErr.RawErrorInfo{}
+	}
+
+	if _, err := ParseLabelSelector(labelSelector); err != nil {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{LabelSelectorField + ": " + err.Error()},
+		}
+	}
+	return ccErr.RawErrorInfo{}
+}
+
+// addLabelSelectorCond add the condition of the label selector string to the query condition
+func addLabelSelectorCond(cond mapstr.MapStr, labelSelector string) (mapstr.MapStr, error) {
+	if labelSelector == "" {
+		return cond, nil
+	}
+
+	selectors, err := ParseLabelSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	labelCond, err := LabelSelectorsCond(selectors)
+	if err != nil {
+		return nil, err
+	}
+	if len(labelCond) == 0 {
+		return cond, nil
+	}
+	return mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, labelCond}}, nil
+}
+
+// LabelSelectorsCond convert the selectors of the labels field to the db condition with the escaped label keys
+func LabelSelectorsCond(selectors selector.Selectors) (mapstr.MapStr, error) {
+	labelCond, err := selectors.ToMgoFilter()
+	if err != nil {
+		return nil, err
+	}
+	return escapeLabelCond(labelCond), nil
+}
+
+// ToSelectors convert the label selector of the workload to the selectors of the pod labels field.
+// an empty label selector matches all the pods, a nil label selector matches no pods, so it returns nil.
+func (s *LabelSelector) ToSelectors() (selector.Selectors, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	selectors := make(selector.Selectors, 0, len(s.MatchLabels)+len(s.MatchExpressions))
+	for key, value := range s.MatchLabels {
+		selectors = append(selectors, selector.Selector{Key: key, Operator: selector.Equals, Values: []string{value}})
+	}
+
+	for _, expression := range s.MatchExpressions {
+		var operator selector.Operator
+		switch expression.Operator {
+		case LabelSelectorOpIn:
+			operator = selector.In
+		case LabelSelectorOpNotIn:
+			operator = selector.NotIn
+		case LabelSelectorOpExists:
+			operator = selector.Exists
+		case LabelSelectorOpDoesNotExist:
+			operator = selector.DoesNotExist
+		default:
+			return nil, fmt.Errorf("label selector operator %s is invalid", expression.Operator)
+		}
+
+		selectors = append(selectors, selector.Selector{Key: expression.Key, Operator: operator,
+			Values: expression.Values})
+	}
+	return selectors, nil
+}
+
+// WlMatchedPodsOption find the pods that the label selectors of the workloads match request
+type WlMatchedPodsOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate WlMatchedPodsOption
+func (opt *WlMatchedPodsOption) Validate() ccErr.RawErrorInfo {
+	return validateIDs(opt.IDs, WlQueryLimit)
+}
+
+// WlMatchedPods the pods that the label selector of the workload matches
+type WlMatchedPods struct {
+	// ID workload id
+	ID   int64           `json:"id"`
+	Pods []PodSimpleInfo `json:"pods"`
+}
+
+// Matches checks if the labels match the label selector, a nil label selector matches nothing
+func (s *LabelSelector) Matches(labels map[string]string) bool {
+	if s == nil {
+		return false
+	}
+
+	for key, value := range s.MatchLabels {
+		if labelValue, exists := labels[key]; !exists || labelValue != value {
+			return false
+		}
+	}
+
+	for _, expression := range s.MatchExpressions {
+		value, exists := labels[expression.Key]
+		switch expression.Operator {
+		case LabelSelectorOpIn:
+			if !exists || !util.InArray(value, expression.Values) {
+				return false
+			}
+		case LabelSelectorOpNotIn:
+			if exists && util.InArray(value, expression.Values) {
+				return false
+			}
+		case LabelSelectorOpExists:
+			if !exists {
+				return false
+			}
+		case LabelSelectorOpDoesNotExist:
+			if exists {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// WlMatchedPodsResp find the pods that the label selectors of the workloads match response
+type WlMatchedPodsResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              []WlMatchedPods `json:"data"`
+}
@@ -0,0 +1,118 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"configcenter/src/common"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/selector"
+)
+
+// TestParseLabelSelector label selector string parse unit test
+func TestParseLabelSelector(t *testing.T) {
+	selectors, err := ParseLabelSelector("app=payments,tier!=canary,env in (test,prod),release,!legacy")
+	if err != nil {
+		t.Fatalf("parse label selector failed, err: %v", err)
+	}
+
+	expected := selector.Selectors{
+		{Key: "app", Operator: selector.Equals, Values: []string{"payments"}},
+		{Key: "env", Operator: selector.In, Values: []string{"prod", "test"}},
+		{Key: "legacy", Operator: selector.DoesNotExist, Values: []string{}},
+		{Key: "release", Operator: selector.Exists, Values: []string{}},
+		{Key: "tier", Operator: selector.NotEquals, Values: []string{"canary"}},
+	}
+	if !reflect.DeepEqual(selectors, expected) {
+		t.Fatalf("parsed selectors %+v is not as expected %+v", selectors, expected)
+	}
+
+	for _, invalid := range []string{"app=(x)", "env in test", "replicas>1"} {
+		if _, err := ParseLabelSelector(invalid); err == nil {
+			t.Fatalf("parse invalid label selector %s should fail", invalid)
+		}
+	}
+}
+
+// TestAddLabelSelectorCond label selector query condition unit test
+func TestAddLabelSelectorCond(t *testing.T) {
+	cond := mapstr.MapStr{common.BKAppIDField: 1}
+	result, err := addLabelSelectorCond(cond, "")
+	if err != nil || !reflect.DeepEqual(result, cond) {
+		t.Fatalf("empty label selector should not change the condition, result: %v, err: %v", result, err)
+	}
+
+	result, err = addLabelSelectorCond(cond, "app=payments")
+	if err != nil {
+		t.Fatalf("add label selector condition failed, err: %v", err)
+	}
+	expected := mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, {"labels.app": "payments"}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("label selector condition %v is not as expected %v", result, expected)
+	}
+
+	// the dots in the label keys are escaped as the label keys stored in db
+	result, err = addLabelSelectorCond(cond, "app.kubernetes.io/name=web,!app.kubernetes.io/version")
+	if err != nil {
+		t.Fatalf("add label selector condition failed, err: %v", err)
+	}
+	expected = mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, {common.BKDBAND: []map[string]interface{}{
+		{"labels.app\uff0ekubernetes\uff0eio/name": "web"},
+		{"labels.app\uff0ekubernetes\uff0eio/version": map[string]interface{}{common.BKDBExists: false}},
+	}}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("label selector condition %v is not as expected %v", result, expected)
+	}
+}
+
+// TestLabelSelectorMatches workload label selector match unit test
+func TestLabelSelectorMatches(t *testing.T) {
+	labelSelector := &LabelSelector{
+		MatchLabels: map[string]string{"app": "payments"},
+		MatchExpressions: []LabelSelectorRequirement{
+			{Key: "tier", Operator: LabelSelectorOpNotIn, Values: []string{"canary"}},
+			{Key: "env", Operator: LabelSelectorOpExists},
+		},
+	}
+
+	cases := []struct {
+		labels  map[string]string
+		matches bool
+	}{
+		{labels: map[string]string{"app": "payments", "env": "prod"}, matches: true},
+		{labels: map[string]string{"app": "payments", "env": "prod", "tier": "web"}, matches: true},
+		{labels: map[string]string{"app": "payments", "env": "prod", "tier": "canary"}, matches: false},
+		{labels: map[string]string{"app": "payments"}, matches: false},
+		{labels: map[string]string{"app": "orders", "env": "prod"}, matches: false},
+	}
+	for _, c := range cases {
+		if labelSelector.Matches(c.labels) != c.matches {
+			t.Fatalf("label selector match labels %v should be %v", c.labels, c.matches)
+		}
+	}
+
+	var nilSelector *LabelSelector
+	if nilSelector.Matches(map[string]string{}) {
+		t.Fatalf("nil label selector should match nothing")
+	}
+	if !new(LabelSelector).Matches(map[string]string{"app": "payments"}) {
+		t.Fatalf("empty label selector should match everything")
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"configcenter/src/common"
+	"configcenter/src/common/mapstr"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// labelKeyDotEscape the escaped dot of the label keys stored in db, the fullwidth full stop is used since it is
+// not a valid character of the kubernetes label keys.
+const labelKeyDotEscape = "\uff0e"
+
+// Labels the labels of the kube resources. the dots in the label keys, like "app.kubernetes.io/name", are escaped
+// when the labels are stored in db, since the dot is the separator of the db field path, and the labels with these
+// keys can not be queried by the "labels.<key>" field otherwise.
+type Labels map[string]string
+
+// EscapeLabelKey escape the label key to the key stored in db
+func EscapeLabelKey(key string) string {
+	return strings.ReplaceAll(key, ".", labelKeyDotEscape)
+}
+
+// UnescapeLabelKey unescape the label key stored in db to the original label key
+func UnescapeLabelKey(key string) string {
+	return strings.ReplaceAll(key, labelKeyDotEscape, ".")
+}
+
+// MarshalBSONValue marshal the labels to db with the escaped keys
+func (l Labels) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if l == nil {
+		return bsontype.Null, nil, nil
+	}
+
+	escaped := make(map[string]string, len(l))
+	for key, value := range l {
+		escaped[EscapeLabelKey(key)] = value
+	}
+	return bson.MarshalValue(escaped)
+}
+
+// UnmarshalBSONValue unmarshal the labels from db and unescape the keys
+func (l *Labels) UnmarshalBSONValue(typo bsontype.Type, raw []byte) error {
+	switch typo {
+	case bsontype.Null, bsontype.Undefined:
+		*l = nil
+		return nil
+	case bsontype.EmbeddedDocument:
+	default:
+		return fmt.Errorf("cannot decode %v into labels", typo)
+	}
+
+	escaped := make(map[string]string)
+	if err := bson.Unmarshal(raw, &escaped); err != nil {
+		return err
+	}
+
+	labels := make(Labels, len(escaped))
+	for key, value := range escaped {
+		labels[UnescapeLabelKey(key)] = value
+	}
+	*l = labels
+	return nil
+}
+
+// labelFieldPrefix the prefix of the db field of a label key
+const labelFieldPrefix = LabelsField + "."
+
+// escapeLabelCond escape the label keys of the "labels.<key>" fields in the query condition, the logical
+// conditions are escaped recursively.
+func escapeLabelCond(cond map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(cond))
+	for field, value := range cond {
+		if strings.HasPrefix(field, labelFieldPrefix) {
+			result[labelFieldPrefix+EscapeLabelKey(strings.TrimPrefix(field, labelFieldPrefix))] = value
+			continue
+		}
+
+		if field == common.BKDBAND || field == common.BKDBOR {
+			value = escapeLabelSubConds(value)
+		}
+		result[field] = value
+	}
+	return result
+}
+
+// escapeLabelSubConds escape the label keys of the sub conditions of a logical condition, the sub conditions can be
+// decoded from json as []interface{} or built in code as []map[string]interface{} or []mapstr.MapStr, the value is
+// returned as it is if it is not a valid sub conditions array.
+func escapeLabelSubConds(value interface{}) interface{} {
+	switch subConds := value.(type) {
+	case []map[string]interface{}:
+		escaped := make([]map[string]interface{}, len(subConds))
+		for idx, subCond := range subConds {
+			escaped[idx] = escapeLabelCond(subCond)
+		}
+		return escaped
+	case []mapstr.MapStr:
+		escaped := make([]mapstr.MapStr, len(subConds))
+		for idx, subCond := range subConds {
+			escaped[idx] = escapeLabelCond(subCond)
+		}
+		return escaped
+	case []interface{}:
+		escaped := make([]interface{}, len(subConds))
+		for idx, subCond := range subConds {
+			switch cond := subCond.(type) {
+			case map[string]interface{}:
+				escaped[idx] = escapeLabelCond(cond)
+			case mapstr.MapStr:
+				escaped[idx] = mapstr.MapStr(escapeLabelCond(cond))
+			default:
+				escaped[idx] = subCond
+			}
+		}
+		return escaped
+	default:
+		return value
+	}
+}
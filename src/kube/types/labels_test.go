@@ -0,0 +1,170 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestLabelsBSON labels db encoding unit test
+func TestLabelsBSON(t *testing.T) {
+	type labelsDoc struct {
+		Labels *Labels `bson:"labels"`
+	}
+
+	labels := Labels{"app.kubernetes.io/name": "web", "tier": "frontend"}
+	raw, err := bson.Marshal(labelsDoc{Labels: &labels})
+	if err != nil {
+		t.Fatalf("marshal labels failed, err: %v", err)
+	}
+
+	stored := make(map[string]map[string]string)
+	if err := bson.Unmarshal(raw, &stored); err != nil {
+		t.Fatalf("unmarshal stored labels failed, err: %v", err)
+	}
+	expected := map[string]string{"app\uff0ekubernetes\uff0eio/name": "web", "tier": "frontend"}
+	if !reflect.DeepEqual(stored[LabelsField], expected) {
+		t.Fatalf("stored labels %v is not as expected %v", stored[LabelsField], expected)
+	}
+
+	doc := new(labelsDoc)
+	if err := bson.Unmarshal(raw, doc); err != nil {
+		t.Fatalf("unmarshal labels failed, err: %v", err)
+	}
+	if doc.Labels == nil || !reflect.DeepEqual(*doc.Labels, labels) {
+		t.Fatalf("unmarshalled labels %v is not as expected %v", doc.Labels, labels)
+	}
+
+	// the labels stored before the keys are escaped can also be decoded
+	raw, err = bson.Marshal(map[string]interface{}{LabelsField: map[string]string{"app.kubernetes.io/name": "web"}})
+	if err != nil {
+		t.Fatalf("marshal unescaped labels failed, err: %v", err)
+	}
+	doc = new(labelsDoc)
+	if err := bson.Unmarshal(raw, doc); err != nil {
+		t.Fatalf("unmarshal unescaped labels failed, err: %v", err)
+	}
+	if doc.Labels == nil || (*doc.Labels)["app.kubernetes.io/name"] != "web" {
+		t.Fatalf("unmarshalled unescaped labels %v is invalid", doc.Labels)
+	}
+
+	var nilLabels *Labels
+	raw, err = bson.Marshal(labelsDoc{Labels: nilLabels})
+	if err != nil {
+		t.Fatalf("marshal nil labels failed, err: %v", err)
+	}
+	doc = &labelsDoc{Labels: &labels}
+	if err := bson.Unmarshal(raw, doc); err != nil {
+		t.Fatalf("unmarshal nil labels failed, err: %v", err)
+	}
+	if doc.Labels != nil && len(*doc.Labels) != 0 {
+		t.Fatalf("unmarshalled nil labels %v should be empty", doc.Labels)
+	}
+}
+
+// TestBuildCondWithDottedLabelKey the query condition of the label filter with dotted key unit test
+func TestBuildCondWithDottedLabelKey(t *testing.T) {
+	opt := &PodQueryOption{
+		Filter: &filter.Expression{RuleFactory: &filter.CombinedRule{
+			Condition: filter.Or,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "labels.app.kubernetes.io/name", Operator: filter.Equal.Factory(),
+					Value: "web"},
+				&filter.AtomRule{Field: "labels.tier", Operator: filter.Equal.Factory(), Value: "frontend"},
+			},
+		}},
+		LabelSelector: "app.kubernetes.io/part-of=shop",
+		Page:          metadata.BasePage{Limit: 10},
+	}
+	if err := opt.Validate(); err.ErrCode != 0 {
+		t.Fatalf("validate pod query option failed, err: %v", err)
+	}
+
+	cond, err := opt.BuildCond(1)
+	if err != nil {
+		t.Fatalf("build pod query condition failed, err: %v", err)
+	}
+
+	filterCond := mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{{common.BKAppIDField: int64(1)},
+		{common.BKDBOR: []map[string]interface{}{
+			{"labels.app\uff0ekubernetes\uff0eio/name": map[string]interface{}{common.BKDBEQ: "web"}},
+			{"labels.tier": map[string]interface{}{common.BKDBEQ: "frontend"}},
+		}},
+	}}
+	expected := mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{filterCond,
+		{"labels.app\uff0ekubernetes\uff0eio/part-of": "shop"}}}
+	if !reflect.DeepEqual(cond, expected) {
+		t.Fatalf("pod query condition %v is not as expected %v", cond, expected)
+	}
+}
+
+// TestEscapeLabelCond the label key escaping of the logical sub conditions in different types unit test
+func TestEscapeLabelCond(t *testing.T) {
+	const field, escapedField = "labels.app.kubernetes.io/name", "labels.app\uff0ekubernetes\uff0eio/name"
+
+	cond := map[string]interface{}{
+		common.BKDBAND: []interface{}{
+			map[string]interface{}{field: "web"},
+			mapstr.MapStr{common.BKDBOR: []mapstr.MapStr{{field: "api"}, {"labels.tier": "backend"}}},
+			"invalid",
+		},
+		common.BKDBOR: []map[string]interface{}{{field: "db"}},
+		field:         "shop",
+		"name":        "pod",
+	}
+
+	expected := map[string]interface{}{
+		common.BKDBAND: []interface{}{
+			map[string]interface{}{escapedField: "web"},
+			mapstr.MapStr{common.BKDBOR: []mapstr.MapStr{{escapedField: "api"}, {"labels.tier": "backend"}}},
+			"invalid",
+		},
+		common.BKDBOR: []map[string]interface{}{{escapedField: "db"}},
+		escapedField:  "shop",
+		"name":        "pod",
+	}
+
+	escaped := escapeLabelCond(cond)
+	if !reflect.DeepEqual(escaped, expected) {
+		t.Fatalf("escaped label condition %v is not as expected %v", escaped, expected)
+	}
+
+	// the logical condition decoded from json is escaped too
+	jsonCond := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(`{"$or":[{"labels.app.kubernetes.io/name":"web"},{"$and":[`+
+		`{"labels.app.kubernetes.io/name":"api"}]}]}`), &jsonCond); err != nil {
+		t.Fatalf("unmarshal json condition failed, err: %v", err)
+	}
+
+	expected = map[string]interface{}{common.BKDBOR: []interface{}{
+		map[string]interface{}{escapedField: "web"},
+		map[string]interface{}{common.BKDBAND: []interface{}{map[string]interface{}{escapedField: "api"}}},
+	}}
+	escaped = escapeLabelCond(jsonCond)
+	if !reflect.DeepEqual(escaped, expected) {
+		t.Fatalf("escaped json label condition %v is not as expected %v", escaped, expected)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+package types
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/metadata"
+)
+
+const (
+	// ManifestSizeLimit limit on the size of the manifests in one import request
+	ManifestSizeLimit = 10 << 20
+	// ManifestObjectLimit limit on the number of the kubernetes objects in the manifests of one import request
+	ManifestObjectLimit = 5000
+
+	// ManifestField the manifest field of the import request
+	ManifestField = "manifest"
+	// ManifestPlanHashField the plan hash field of the import request
+	ManifestPlanHashField = "plan_hash"
+)
+
+// ManifestImportOption import the kube resources of a cluster from kubernetes manifests request
+type ManifestImportOption struct {
+	ClusterID int64 `json:"bk_cluster_id"`
+	// Manifest the kubernetes manifests in yaml or json, it can be multiple yaml documents separated by "---",
+	// or the list returned by "kubectl get -o yaml" or "kubectl get -o json".
+	Manifest string `json:"manifest"`
+	// PlanHash the hash of the previewed import plan, it is required by the import so that only the previewed
+	// changes are applied, the import is rejected if the plan is changed since it is previewed.
+	PlanHash string `json:"plan_hash,omitempty"`
+}
+
+// Validate validate ManifestImportOption
+func (opt *ManifestImportOption) Validate() errors.RawErrorInfo {
+	if opt.ClusterID <= 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{BKClusterIDFiled},
+		}
+	}
+
+	if len(opt.Manifest) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{ManifestField},
+		}
+	}
+
+	if len(opt.Manifest) > ManifestSizeLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{ManifestField, ManifestSizeLimit},
+		}
+	}
+	return errors.RawErrorInfo{}
+}
+
+// ManifestResource a kube resource in the manifest import plan
+type ManifestResource struct {
+	// Kind the kind of the resource, it is the cmdb kind of the supported resources, e.g. namespace, deployment,
+	// pod, and the kubernetes kind of the skipped unsupported resources.
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// ID the id of the cmdb resource, it is not set for the resources to create
+	ID int64 `json:"id,omitempty"`
+	// Reason the reason why the resource is skipped
+	Reason string `json:"reason,omitempty"`
+}
+
+// ManifestImportPlan the changes to the cmdb kube resources of a cluster to import the manifests.
+// the namespaces, workloads and pods in the manifests are created or updated,
+// the workloads and pods of a kind in a namespace that are not in the manifests are deleted only if the manifests
+// contain the resources of the kind in the namespace, and the namespaces are never deleted.
+type ManifestImportPlan struct {
+	Create []ManifestResource `json:"create"`
+	Update []ManifestResource `json:"update"`
+	Delete []ManifestResource `json:"delete"`
+	Skip   []ManifestResource `json:"skip"`
+	// Hash the hash of the plan, it is passed to the import to apply the previewed plan
+	Hash string `json:"plan_hash"`
+}
+
+// ManifestImportPlanResp manifest import plan response
+type ManifestImportPlanResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              *ManifestImportPlan `json:"data"`
+}
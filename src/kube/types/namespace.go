@@ -104,11 +104,11 @@ type NamespaceSpec struct {
 // Namespace define the namespace struct.
 type Namespace struct {
 	ClusterSpec     `json:",inline" bson:",inline"`
-	ID              int64              `json:"id,omitempty" bson:"id"`
-	Name            string             `json:"name,omitempty" bson:"name"`
-	Labels          *map[string]string `json:"labels,omitempty" bson:"labels"`
-	ResourceQuotas  *[]ResourceQuota   `json:"resource_quotas,omitempty" bson:"resource_quotas"`
-	SupplierAccount string             `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	ID              int64            `json:"id,omitempty" bson:"id"`
+	Name            string           `json:"name,omitempty" bson:"name"`
+	Labels          *Labels          `json:"labels,omitempty" bson:"labels"`
+	ResourceQuotas  *[]ResourceQuota `json:"resource_quotas,omitempty" bson:"resource_quotas"`
+	SupplierAccount string           `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
 	// Revision record this app's revision information
 	table.Revision `json:",inline" bson:",inline"`
 }
@@ -258,8 +258,10 @@ type NsCreateResp struct {
 // NsQueryOption namespace query request
 type NsQueryOption struct {
 	Filter *filter.Expression `json:"filter"`
-	Fields []string           `json:"fields,omitempty"`
-	Page   metadata.BasePage  `json:"page,omitempty"`
+	// LabelSelector kubernetes style label selector of the namespace labels, like "app=payments,tier!=canary"
+	LabelSelector string            `json:"label_selector,omitempty"`
+	Fields        []string          `json:"fields,omitempty"`
+	Page          metadata.BasePage `json:"page,omitempty"`
 }
 
 // Validate validate NsQueryReq
@@ -268,6 +270,10 @@ func (ns *NsQueryOption) Validate() errors.RawErrorInfo {
 		return err
 	}
 
+	if err := validateLabelSelector(ns.LabelSelector); err.ErrCode != 0 {
+		return err
+	}
+
 	if ns.Filter == nil {
 		return errors.RawErrorInfo{}
 	}
@@ -293,9 +299,9 @@ func (ns *NsQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
 		if err != nil {
 			return nil, err
 		}
-		cond = mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, filterCond}}
+		cond = mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, escapeLabelCond(filterCond)}}
 	}
-	return cond, nil
+	return addLabelSelectorCond(cond, ns.LabelSelector)
 }
 
 // NsInstResp namespace instance response
@@ -51,6 +51,7 @@ var NodeSpecFieldsDescriptor = table.FieldsDescriptors{
 	{Field: RuntimeComponentField, Type: enumor.String, IsRequired: false, IsEditable: true},
 	{Field: KubeProxyModeField, Type: enumor.String, IsRequired: false, IsEditable: true},
 	{Field: PodCidrField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: AllocatableField, Type: enumor.Object, IsRequired: false, IsEditable: true},
 }
 
 // NodeBaseRefDescriptor the description used when other resources refer to the node.
@@ -80,7 +81,7 @@ type Node struct {
 	HasPod           *bool                 `json:"has_pod,omitempty" bson:"has_pod"`
 	Name             *string               `json:"name,omitempty" bson:"name"`
 	Roles            *string               `json:"roles,omitempty" bson:"roles"`
-	Labels           *enumor.MapStringType `json:"labels,omitempty" bson:"labels"`
+	Labels           *Labels               `json:"labels,omitempty" bson:"labels"`
 	Taints           *enumor.MapStringType `json:"taints,omitempty" bson:"taints"`
 	Unschedulable    *bool                 `json:"unschedulable,omitempty" bson:"unschedulable"`
 	InternalIP       *[]string             `json:"internal_ip,omitempty" bson:"internal_ip"`
@@ -89,6 +90,8 @@ type Node struct {
 	RuntimeComponent *string               `json:"runtime_component,omitempty" bson:"runtime_component"`
 	KubeProxyMode    *string               `json:"kube_proxy_mode,omitempty" bson:"kube_proxy_mode"`
 	PodCidr          *string               `json:"pod_cidr,omitempty" bson:"pod_cidr"`
+	// Allocatable the compute resources of the node that are available for scheduling
+	Allocatable *ResourceList `json:"allocatable,omitempty" bson:"allocatable"`
 	// Revision record this app's revision information
 	table.Revision `json:",inline" bson:",inline"`
 }
@@ -0,0 +1,105 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"errors"
+	"time"
+
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	ccErr "configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/orm"
+	"configcenter/src/storage/dal/table"
+)
+
+// validateIDs validate the ids of the resources to be updated or deleted
+func validateIDs(ids []int64, limit int) ccErr.RawErrorInfo {
+	if len(ids) == 0 {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"ids"},
+		}
+	}
+
+	if len(ids) > limit {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"ids", limit},
+		}
+	}
+
+	return ccErr.RawErrorInfo{}
+}
+
+// validateQuery validate the filter and page of the resource query request
+func validateQuery(expr *filter.Expression, page metadata.BasePage, limit int,
+	fields *table.Fields) ccErr.RawErrorInfo {
+
+	if err := page.ValidateWithEnableCount(false, limit); err.ErrCode != 0 {
+		return err
+	}
+
+	if expr == nil {
+		return ccErr.RawErrorInfo{}
+	}
+
+	op := filter.NewDefaultExprOpt(fields.FieldsType())
+	if err := expr.Validate(op); err != nil {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{err.Error()},
+		}
+	}
+	return ccErr.RawErrorInfo{}
+}
+
+// buildBizQueryCond build the condition to query the resources in the business with the filter
+func buildBizQueryCond(bizID int64, expr *filter.Expression) (mapstr.MapStr, error) {
+	cond := mapstr.MapStr{
+		common.BKAppIDField: bizID,
+	}
+
+	if expr != nil {
+		filterCond, err := expr.ToMgo()
+		if err != nil {
+			return nil, err
+		}
+		cond = mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, filterCond}}
+	}
+	return cond, nil
+}
+
+// buildUpdateData build the update data of the namespaced resource, the fields that refer to the upper level
+// resources and the fields that can not be changed are ignored
+func buildUpdateData(data interface{}, user string) (map[string]interface{}, error) {
+	if data == nil {
+		return nil, errors.New("update param is invalid")
+	}
+
+	opts := orm.NewFieldOptions().AddIgnoredFields(wlIgnoreField...)
+	updateData, err := orm.GetUpdateFieldsWithOption(data, opts)
+	if err != nil {
+		return nil, err
+	}
+	updateData[common.LastTimeField] = time.Now().Unix()
+	updateData[common.ModifierField] = user
+	return updateData, nil
+}
@@ -0,0 +1,207 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// PersistentVolumeFields merge the fields of the persistent volume and the details corresponding to the fields
+// together.
+var PersistentVolumeFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor, ClusterBaseRefDescriptor,
+	PersistentVolumeSpecFieldsDescriptor)
+
+// PersistentVolumeSpecFieldsDescriptor persistent volume spec's fields descriptors.
+var PersistentVolumeSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: KubeNameField, Type: enumor.String, IsRequired: true, IsEditable: false},
+	{Field: LabelsField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+	{Field: CapacityField, Type: enumor.Numeric, IsRequired: false, IsEditable: true},
+	{Field: AccessModesField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+	{Field: VolumeModeField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: ReclaimPolicyField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: StorageClassNameField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: PhaseField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: BKNodeIDField, Type: enumor.Numeric, IsRequired: false, IsEditable: false},
+	{Field: NodeField, Type: enumor.String, IsRequired: false, IsEditable: false},
+}
+
+const (
+	// PvUpdateLimit limit on the number of persistent volume updates
+	PvUpdateLimit = 200
+	// PvDeleteLimit limit on the number of persistent volume delete
+	PvDeleteLimit = 200
+	// PvCreateLimit limit on the number of persistent volume create
+	PvCreateLimit = 200
+	// PvQueryLimit limit on the number of persistent volume query
+	PvQueryLimit = 500
+)
+
+// PersistentVolume define the persistent volume struct.
+type PersistentVolume struct {
+	ClusterSpec      `json:",inline" bson:",inline"`
+	ID               int64                         `json:"id,omitempty" bson:"id"`
+	Name             string                        `json:"name,omitempty" bson:"name"`
+	Labels           *Labels                       `json:"labels,omitempty" bson:"labels"`
+	Capacity         *int64                        `json:"capacity,omitempty" bson:"capacity"`
+	AccessModes      *[]PersistentVolumeAccessMode `json:"access_modes,omitempty" bson:"access_modes"`
+	VolumeMode       *PersistentVolumeMode         `json:"volume_mode,omitempty" bson:"volume_mode"`
+	ReclaimPolicy    *string                       `json:"reclaim_policy,omitempty" bson:"reclaim_policy"`
+	StorageClassName *string                       `json:"storage_class_name,omitempty" bson:"storage_class_name"`
+	Phase            *string                       `json:"phase,omitempty" bson:"phase"`
+	// NodeID the node that the local persistent volume is attached to, it is 0 if the volume is not node local
+	NodeID int64 `json:"bk_node_id,omitempty" bson:"bk_node_id"`
+	// NodeName redundant node name
+	NodeName        string `json:"node_name,omitempty" bson:"node_name"`
+	SupplierAccount string `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// validateCreate validate create persistent volume
+func (pv *PersistentVolume) validateCreate() errors.RawErrorInfo {
+	if pv.ClusterID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKClusterIDFiled},
+		}
+	}
+
+	if pv.Name == "" {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{common.BKFieldName},
+		}
+	}
+
+	if err := validateCapacity(pv.Capacity); err.ErrCode != 0 {
+		return err
+	}
+
+	return ValidateCreate(*pv, PersistentVolumeFields)
+}
+
+// BuildUpdateData build persistent volume update data
+func (pv *PersistentVolume) BuildUpdateData(user string) (map[string]interface{}, error) {
+	return buildUpdateData(pv, user)
+}
+
+// PersistentVolumeCreateOption create persistent volume request
+type PersistentVolumeCreateOption struct {
+	Data []PersistentVolume `json:"data"`
+}
+
+// Validate validate PersistentVolumeCreateOption
+func (opt *PersistentVolumeCreateOption) Validate() errors.RawErrorInfo {
+	if len(opt.Data) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(opt.Data) > PvCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", PvCreateLimit},
+		}
+	}
+
+	for _, data := range opt.Data {
+		if err := data.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// PersistentVolumeUpdateOption update persistent volume request
+type PersistentVolumeUpdateOption struct {
+	IDs  []int64           `json:"ids"`
+	Data *PersistentVolume `json:"data"`
+}
+
+// Validate validate PersistentVolumeUpdateOption
+func (opt *PersistentVolumeUpdateOption) Validate() errors.RawErrorInfo {
+	if err := validateIDs(opt.IDs, PvUpdateLimit); err.ErrCode != 0 {
+		return err
+	}
+
+	if opt.Data == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if err := validateCapacity(opt.Data.Capacity); err.ErrCode != 0 {
+		return err
+	}
+
+	return ValidateUpdate(*opt.Data, PersistentVolumeFields)
+}
+
+// PersistentVolumeDeleteOption delete persistent volume request
+type PersistentVolumeDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate PersistentVolumeDeleteOption
+func (opt *PersistentVolumeDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(opt.IDs, PvDeleteLimit)
+}
+
+// PersistentVolumeQueryOption persistent volume query request
+type PersistentVolumeQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate PersistentVolumeQueryOption
+func (opt *PersistentVolumeQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(opt.Filter, opt.Page, PvQueryLimit, PersistentVolumeFields)
+}
+
+// BuildCond build query persistent volume condition
+func (opt *PersistentVolumeQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, opt.Filter)
+}
+
+// PersistentVolumeCreateResp create persistent volume response
+type PersistentVolumeCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// PersistentVolumeInstResp persistent volume instance response
+type PersistentVolumeInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              PersistentVolumeDataResp `json:"data"`
+}
+
+// PersistentVolumeDataResp persistent volume data
+type PersistentVolumeDataResp struct {
+	Data []PersistentVolume `json:"data"`
+}
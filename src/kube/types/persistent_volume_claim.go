@@ -0,0 +1,226 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// PersistentVolumeClaimFields merge the fields of the persistent volume claim and the details corresponding to the
+// fields together.
+var PersistentVolumeClaimFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor,
+	ClusterBaseRefDescriptor, NamespaceBaseRefDescriptor, PersistentVolumeClaimSpecFieldsDescriptor)
+
+// PersistentVolumeClaimSpecFieldsDescriptor persistent volume claim spec's fields descriptors.
+var PersistentVolumeClaimSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: KubeNameField, Type: enumor.String, IsRequired: true, IsEditable: false},
+	{Field: LabelsField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+	{Field: AccessModesField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+	{Field: StorageClassNameField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: VolumeNameField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: VolumeModeField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: CapacityField, Type: enumor.Numeric, IsRequired: false, IsEditable: true},
+	{Field: PhaseField, Type: enumor.String, IsRequired: false, IsEditable: true},
+}
+
+const (
+	// PvcUpdateLimit limit on the number of persistent volume claim updates
+	PvcUpdateLimit = 200
+	// PvcDeleteLimit limit on the number of persistent volume claim delete
+	PvcDeleteLimit = 200
+	// PvcCreateLimit limit on the number of persistent volume claim create
+	PvcCreateLimit = 200
+	// PvcQueryLimit limit on the number of persistent volume claim query
+	PvcQueryLimit = 500
+)
+
+// PersistentVolumeClaim define the persistent volume claim struct.
+type PersistentVolumeClaim struct {
+	NamespaceSpec    `json:",inline" bson:",inline"`
+	ID               int64                         `json:"id,omitempty" bson:"id"`
+	Name             string                        `json:"name,omitempty" bson:"name"`
+	Labels           *Labels                       `json:"labels,omitempty" bson:"labels"`
+	AccessModes      *[]PersistentVolumeAccessMode `json:"access_modes,omitempty" bson:"access_modes"`
+	StorageClassName *string                       `json:"storage_class_name,omitempty" bson:"storage_class_name"`
+	// VolumeName the name of the persistent volume that is bound to this claim
+	VolumeName *string               `json:"volume_name,omitempty" bson:"volume_name"`
+	VolumeMode *PersistentVolumeMode `json:"volume_mode,omitempty" bson:"volume_mode"`
+	// Capacity the actual capacity of the bound volume in bytes, it is the requested storage if not bound yet
+	Capacity        *int64  `json:"capacity,omitempty" bson:"capacity"`
+	Phase           *string `json:"phase,omitempty" bson:"phase"`
+	SupplierAccount string  `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// validateCreate validate create persistent volume claim
+func (pvc *PersistentVolumeClaim) validateCreate() errors.RawErrorInfo {
+	if pvc.NamespaceID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKNamespaceIDField},
+		}
+	}
+
+	if pvc.Name == "" {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{common.BKFieldName},
+		}
+	}
+
+	if err := validateCapacity(pvc.Capacity); err.ErrCode != 0 {
+		return err
+	}
+
+	return ValidateCreate(*pvc, PersistentVolumeClaimFields)
+}
+
+// BuildUpdateData build persistent volume claim update data
+func (pvc *PersistentVolumeClaim) BuildUpdateData(user string) (map[string]interface{}, error) {
+	return buildUpdateData(pvc, user)
+}
+
+// PersistentVolumeClaimCreateOption create persistent volume claim request
+type PersistentVolumeClaimCreateOption struct {
+	Data []PersistentVolumeClaim `json:"data"`
+}
+
+// Validate validate PersistentVolumeClaimCreateOption
+func (opt *PersistentVolumeClaimCreateOption) Validate() errors.RawErrorInfo {
+	if len(opt.Data) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(opt.Data) > PvcCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", PvcCreateLimit},
+		}
+	}
+
+	for _, data := range opt.Data {
+		if err := data.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// PersistentVolumeClaimUpdateOption update persistent volume claim request
+type PersistentVolumeClaimUpdateOption struct {
+	IDs  []int64                `json:"ids"`
+	Data *PersistentVolumeClaim `json:"data"`
+}
+
+// Validate validate PersistentVolumeClaimUpdateOption
+func (opt *PersistentVolumeClaimUpdateOption) Validate() errors.RawErrorInfo {
+	if err := validateIDs(opt.IDs, PvcUpdateLimit); err.ErrCode != 0 {
+		return err
+	}
+
+	if opt.Data == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if err := validateCapacity(opt.Data.Capacity); err.ErrCode != 0 {
+		return err
+	}
+
+	return ValidateUpdate(*opt.Data, PersistentVolumeClaimFields)
+}
+
+// PersistentVolumeClaimDeleteOption delete persistent volume claim request
+type PersistentVolumeClaimDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate PersistentVolumeClaimDeleteOption
+func (opt *PersistentVolumeClaimDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(opt.IDs, PvcDeleteLimit)
+}
+
+// PersistentVolumeClaimQueryOption persistent volume claim query request
+type PersistentVolumeClaimQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate PersistentVolumeClaimQueryOption
+func (opt *PersistentVolumeClaimQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(opt.Filter, opt.Page, PvcQueryLimit, PersistentVolumeClaimFields)
+}
+
+// BuildCond build query persistent volume claim condition
+func (opt *PersistentVolumeClaimQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, opt.Filter)
+}
+
+// PersistentVolumeClaimCreateResp create persistent volume claim response
+type PersistentVolumeClaimCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// PersistentVolumeClaimInstResp persistent volume claim instance response
+type PersistentVolumeClaimInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              PersistentVolumeClaimDataResp `json:"data"`
+}
+
+// PersistentVolumeClaimDataResp persistent volume claim data
+type PersistentVolumeClaimDataResp struct {
+	Data []PersistentVolumeClaim `json:"data"`
+}
+
+// PVCMountedPodsOption find the pods that mount the persistent volume claims request
+type PVCMountedPodsOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate PVCMountedPodsOption
+func (opt *PVCMountedPodsOption) Validate() errors.RawErrorInfo {
+	return validateIDs(opt.IDs, PvcQueryLimit)
+}
+
+// PVCMountedPods the pods that mount the persistent volume claim
+type PVCMountedPods struct {
+	// ID persistent volume claim id
+	ID   int64           `json:"id"`
+	Pods []PodSimpleInfo `json:"pods"`
+}
+
+// PodSimpleInfo the brief information of the pod
+type PodSimpleInfo struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
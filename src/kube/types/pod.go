@@ -85,8 +85,10 @@ const (
 // PodQueryOption pod query request
 type PodQueryOption struct {
 	Filter *filter.Expression `json:"filter"`
-	Fields []string           `json:"fields,omitempty"`
-	Page   metadata.BasePage  `json:"page,omitempty"`
+	// LabelSelector kubernetes style label selector of the pod labels, like "app=payments,tier!=canary"
+	LabelSelector string            `json:"label_selector,omitempty"`
+	Fields        []string          `json:"fields,omitempty"`
+	Page          metadata.BasePage `json:"page,omitempty"`
 }
 
 // Validate validate PodQueryOption
@@ -95,6 +97,10 @@ func (p *PodQueryOption) Validate() ccErr.RawErrorInfo {
 		return err
 	}
 
+	if err := validateLabelSelector(p.LabelSelector); err.ErrCode != 0 {
+		return err
+	}
+
 	if p.Filter == nil {
 		return ccErr.RawErrorInfo{}
 	}
@@ -121,9 +127,9 @@ func (p *PodQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
 		if err != nil {
 			return nil, err
 		}
-		cond = mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, filterCond}}
+		cond = mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, escapeLabelCond(filterCond)}}
 	}
-	return cond, nil
+	return addLabelSelectorCond(cond, p.LabelSelector)
 }
 
 // Pod pod details
@@ -133,7 +139,7 @@ type Pod struct {
 	SysSpec       `json:",inline" bson:",inline"`
 	Name          *string            `json:"name,omitempty" bson:"name"`
 	Priority      *int32             `json:"priority,omitempty" bson:"priority"`
-	Labels        *map[string]string `json:"labels,omitempty"  bson:"labels"`
+	Labels        *Labels            `json:"labels,omitempty"  bson:"labels"`
 	IP            *string            `json:"ip,omitempty"  bson:"ip"`
 	IPs           *[]PodIP           `json:"ips,omitempty"  bson:"ips"`
 	Volumes       *[]Volume          `json:"volumes,omitempty"  bson:"volumes"`
@@ -289,29 +295,87 @@ func (option *CreatePodsOption) Validate() ccErr.RawErrorInfo {
 
 	for _, data := range option.Data {
 		for _, pod := range data.Pods {
-			if err := pod.Spec.validate(); err != nil {
-				return ccErr.RawErrorInfo{
-					ErrCode: common.CCErrCommParamsIsInvalid,
-					Args:    []interface{}{err.Error()},
-				}
-			}
-			if pod.HostID == 0 {
-				return ccErr.RawErrorInfo{
-					ErrCode: common.CCErrCommParamsNeedSet,
-					Args:    []interface{}{errors.New("host id")},
-				}
-			}
-
-			if err := pod.createValidate(); err.ErrCode != 0 {
+			if err := pod.validate(); err.ErrCode != 0 {
 				return err
 			}
+		}
+	}
+	return ccErr.RawErrorInfo{}
+}
 
-			for _, container := range pod.Containers {
-				if err := container.validateCreate(); err.ErrCode != 0 {
-					return err
-				}
+// validate validate the pod and its containers to be created or updated
+func (option *PodsInfo) validate() ccErr.RawErrorInfo {
+	if err := option.Spec.validate(); err != nil {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsIsInvalid,
+			Args:    []interface{}{err.Error()},
+		}
+	}
+	if option.HostID == 0 {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{errors.New("host id")},
+		}
+	}
+
+	if err := option.createValidate(); err.ErrCode != 0 {
+		return err
+	}
+
+	for _, container := range option.Containers {
+		if err := container.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+	return ccErr.RawErrorInfo{}
+}
+
+// UpdatePodInfo the pod to be updated, the pod fields and containers are replaced by the info
+type UpdatePodInfo struct {
+	ID   int64    `json:"id"`
+	Info PodsInfo `json:"info"`
+}
+
+// UpdatePodsOption update pods by ids option
+type UpdatePodsOption struct {
+	BizID int64           `json:"bk_biz_id"`
+	Data  []UpdatePodInfo `json:"data"`
+}
+
+// Validate validate the UpdatePodsOption
+func (option *UpdatePodsOption) Validate() ccErr.RawErrorInfo {
+	if option.BizID <= 0 {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsIsInvalid,
+			Args:    []interface{}{common.BKAppIDField},
+		}
+	}
+
+	if len(option.Data) == 0 {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(option.Data) > createPodsLimit {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", createPodsLimit},
+		}
+	}
+
+	for _, data := range option.Data {
+		if data.ID <= 0 {
+			return ccErr.RawErrorInfo{
+				ErrCode: common.CCErrCommParamsIsInvalid,
+				Args:    []interface{}{BKIDField},
 			}
 		}
+
+		if err := data.Info.validate(); err.ErrCode != 0 {
+			return err
+		}
 	}
 	return ccErr.RawErrorInfo{}
 }
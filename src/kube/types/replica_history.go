@@ -0,0 +1,147 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+)
+
+const (
+	// ReplicaHistoryWorkloadLimit limit on the number of workloads whose replica history is queried at one time
+	ReplicaHistoryWorkloadLimit = 200
+	// ReplicaHistoryQueryLimit limit on the number of workload replica history query
+	ReplicaHistoryQueryLimit = 500
+	// ReplicaHistoryRetentionDays the retention days of the workload replica history, the expired records are
+	// removed by the ttl index of the replica history table
+	ReplicaHistoryRetentionDays = 180
+)
+
+// WorkloadReplicaHistory define the replica count of a workload at a point in time, it is recorded by the event
+// watch of the workloads whenever the replica count of the workload is set or changed.
+type WorkloadReplicaHistory struct {
+	WorkloadSpec `json:",inline" bson:",inline"`
+	ID           int64 `json:"id,omitempty" bson:"id"`
+	Replicas     int64 `json:"replicas" bson:"replicas"`
+	// Time the time when the replica count changes, in unix seconds
+	Time int64 `json:"time" bson:"time"`
+	// EventID the id of the workload event that produces this record, it is used to avoid duplicate records
+	EventID         string `json:"event_id,omitempty" bson:"event_id"`
+	SupplierAccount string `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// ExpireAt the expiration time of this record, it is used by the ttl index of the replica history table
+	ExpireAt time.Time `json:"-" bson:"expire_at"`
+}
+
+// IsReplicaHistoryKind check if the workload kind has replica history, only the deployment and statefulset whose
+// replica count can be scaled are recorded.
+func IsReplicaHistoryKind(kind WorkloadType) bool {
+	switch kind {
+	case KubeDeployment, KubeStatefulSet:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReplicaHistoryQueryOption workload replica history query request
+type ReplicaHistoryQueryOption struct {
+	Kind        WorkloadType `json:"kind"`
+	WorkloadIDs []int64      `json:"workload_ids"`
+	// StartTime the start of the time range, in unix seconds
+	StartTime int64 `json:"start_time"`
+	// EndTime the end of the time range, in unix seconds
+	EndTime int64             `json:"end_time"`
+	Fields  []string          `json:"fields,omitempty"`
+	Page    metadata.BasePage `json:"page,omitempty"`
+}
+
+// Validate validate ReplicaHistoryQueryOption
+func (opt *ReplicaHistoryQueryOption) Validate() errors.RawErrorInfo {
+	if !IsReplicaHistoryKind(opt.Kind) {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{KindField},
+		}
+	}
+
+	if len(opt.WorkloadIDs) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"workload_ids"},
+		}
+	}
+
+	if len(opt.WorkloadIDs) > ReplicaHistoryWorkloadLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"workload_ids", ReplicaHistoryWorkloadLimit},
+		}
+	}
+
+	if opt.StartTime <= 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"start_time"},
+		}
+	}
+
+	if opt.EndTime <= 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"end_time"},
+		}
+	}
+
+	if opt.EndTime < opt.StartTime {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{"end_time"},
+		}
+	}
+
+	return opt.Page.ValidateWithEnableCount(false, ReplicaHistoryQueryLimit)
+}
+
+// BuildCond build query workload replica history condition
+func (opt *ReplicaHistoryQueryOption) BuildCond(bizID int64) mapstr.MapStr {
+	return mapstr.MapStr{
+		common.BKAppIDField: bizID,
+		RefKindField:        opt.Kind,
+		RefIDField:          mapstr.MapStr{common.BKDBIN: util.IntArrayUnique(opt.WorkloadIDs)},
+		TimeField: mapstr.MapStr{
+			common.BKDBGTE: opt.StartTime,
+			common.BKDBLTE: opt.EndTime,
+		},
+	}
+}
+
+// ReplicaHistoryInstResp workload replica history response
+type ReplicaHistoryInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              ReplicaHistoryDataResp `json:"data"`
+}
+
+// ReplicaHistoryDataResp workload replica history data
+type ReplicaHistoryDataResp struct {
+	Data []WorkloadReplicaHistory `json:"data"`
+}
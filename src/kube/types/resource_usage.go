@@ -0,0 +1,178 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/metadata"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// ResourceCPU cpu resource name, in cores
+	ResourceCPU ResourceName = "cpu"
+	// ResourceMemory memory resource name, in bytes
+	ResourceMemory ResourceName = "memory"
+)
+
+// ParseQuantity parse the kubernetes serialized quantity string
+func ParseQuantity(quantity Quantity) (resource.Quantity, error) {
+	return resource.ParseQuantity(string(quantity))
+}
+
+// UnmarshalBSONValue unmarshal the quantity from db, the quantity stored in the legacy struct form has no value,
+// so it is decoded as an empty quantity.
+func (q *Quantity) UnmarshalBSONValue(typo bsontype.Type, raw []byte) error {
+	switch typo {
+	case bsontype.String:
+		value, ok := bson.RawValue{Type: typo, Value: raw}.StringValueOK()
+		if !ok {
+			return fmt.Errorf("invalid quantity string value")
+		}
+		*q = Quantity(value)
+	case bsontype.EmbeddedDocument, bsontype.Null, bsontype.Undefined:
+		*q = ""
+	default:
+		return fmt.Errorf("cannot decode %v into quantity", typo)
+	}
+	return nil
+}
+
+// UnmarshalJSON unmarshal the quantity from the serialized string or the number like kubernetes does, the quantity
+// in the legacy struct form has no value, so it is unmarshalled as an empty quantity.
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0, bytes.Equal(data, []byte("null")), data[0] == '{':
+		*q = ""
+		return nil
+	case data[0] == '"':
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*q = Quantity(value)
+		return nil
+	default:
+		var value json.Number
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*q = Quantity(value)
+		return nil
+	}
+}
+
+// ResourceAmount the amount of the compute resources, cpu is in millicores and memory is in bytes
+type ResourceAmount struct {
+	CPU    int64 `json:"cpu"`
+	Memory int64 `json:"memory"`
+}
+
+// Add add the cpu and memory of the resource list to the resource amount, the invalid quantities are ignored.
+func (r *ResourceAmount) Add(list ResourceList) {
+	if cpu, exists := list[ResourceCPU]; exists {
+		if quantity, err := ParseQuantity(cpu); err == nil {
+			r.CPU += quantity.MilliValue()
+		}
+	}
+
+	if memory, exists := list[ResourceMemory]; exists {
+		if quantity, err := ParseQuantity(memory); err == nil {
+			r.Memory += quantity.Value()
+		}
+	}
+}
+
+// Exceeds checks if the cpu or memory of the resource amount exceeds the other one
+func (r ResourceAmount) Exceeds(other ResourceAmount) bool {
+	return r.CPU > other.CPU || r.Memory > other.Memory
+}
+
+// ContainerRequests returns the resource requests of the container, like kubernetes, the request of a resource
+// defaults to its limit if the request is omitted and the limit is set.
+func ContainerRequests(container *Container) ResourceList {
+	requests := make(ResourceList)
+	if container.Limits != nil {
+		for name, quantity := range *container.Limits {
+			requests[name] = quantity
+		}
+	}
+
+	if container.ReqSysSpecuests != nil {
+		for name, quantity := range *container.ReqSysSpecuests {
+			requests[name] = quantity
+		}
+	}
+	return requests
+}
+
+// ResourceUsageLimit limit on the number of the resources to aggregate the requests and limits
+const ResourceUsageLimit = 200
+
+// ResourceUsageOption aggregate the compute resource requests and limits of the containers request
+type ResourceUsageOption struct {
+	// Kind the kind of the resources to aggregate the requests and limits by, it can be container, pod, the
+	// workload kinds, namespace, node, cluster or biz. the ids are not needed for biz, the path biz is used.
+	Kind string  `json:"kind"`
+	IDs  []int64 `json:"ids"`
+}
+
+// Validate validate ResourceUsageOption
+func (opt *ResourceUsageOption) Validate() errors.RawErrorInfo {
+	switch opt.Kind {
+	case KubeBusiness:
+		return errors.RawErrorInfo{}
+	case KubeContainer, KubePod, KubeNamespace, KubeNode, KubeCluster:
+	default:
+		if err := WorkloadType(opt.Kind).Validate(); err != nil {
+			return errors.RawErrorInfo{
+				ErrCode: common.CCErrCommParamsInvalid,
+				Args:    []interface{}{KindField},
+			}
+		}
+	}
+
+	return validateIDs(opt.IDs, ResourceUsageLimit)
+}
+
+// ResourceUsage the aggregated compute resource requests and limits of the containers in a resource.
+// the allocatable resources are the sum of the allocatable resources of the nodes that the resource uses, they
+// are set for node, namespace, cluster and biz. the resource is over committed if the limits exceed them.
+type ResourceUsage struct {
+	ID             int64           `json:"id"`
+	ContainerCount int64           `json:"container_count"`
+	Requests       ResourceAmount  `json:"requests"`
+	Limits         ResourceAmount  `json:"limits"`
+	Allocatable    *ResourceAmount `json:"allocatable,omitempty"`
+	OverCommitted  bool            `json:"over_committed"`
+}
+
+// ResourceUsageResp aggregate resource usage response
+type ResourceUsageResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              []ResourceUsage `json:"data"`
+}
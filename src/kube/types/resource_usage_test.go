@@ -0,0 +1,104 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestResourceAmountAdd resource quantity aggregation unit test
+func TestResourceAmountAdd(t *testing.T) {
+	amount := new(ResourceAmount)
+	amount.Add(ResourceList{ResourceCPU: "500m", ResourceMemory: "128Mi"})
+	amount.Add(ResourceList{ResourceCPU: "1.5", ResourceMemory: "1G"})
+	amount.Add(ResourceList{ResourceCPU: "2", ResourceMemory: "1e3"})
+	// invalid quantities and other resources are ignored
+	amount.Add(ResourceList{ResourceCPU: "abc", "nvidia.com/gpu": "1"})
+
+	expected := ResourceAmount{CPU: 4000, Memory: 128*1024*1024 + 1000*1000*1000 + 1000}
+	if *amount != expected {
+		t.Fatalf("resource amount %+v is not as expected %+v", *amount, expected)
+	}
+
+	if !amount.Exceeds(ResourceAmount{CPU: 3000, Memory: expected.Memory}) {
+		t.Fatalf("resource amount %+v should exceed cpu 3000m", *amount)
+	}
+	if amount.Exceeds(expected) {
+		t.Fatalf("resource amount %+v should not exceed itself", *amount)
+	}
+}
+
+// TestContainerRequests container resource requests unit test
+func TestContainerRequests(t *testing.T) {
+	container := &Container{
+		Limits:          &ResourceList{ResourceCPU: "1", ResourceMemory: "1Gi"},
+		ReqSysSpecuests: &ResourceList{ResourceCPU: "200m"},
+	}
+
+	requests := ContainerRequests(container)
+	if requests[ResourceCPU] != "200m" || requests[ResourceMemory] != "1Gi" {
+		t.Fatalf("container requests %v should default to the limits when they are omitted", requests)
+	}
+
+	if len(ContainerRequests(new(Container))) != 0 {
+		t.Fatalf("container without resources should have no requests")
+	}
+}
+
+// TestQuantityUnmarshal quantity unmarshal unit test, including the quantities in the legacy struct form
+func TestQuantityUnmarshal(t *testing.T) {
+	raw, err := bson.Marshal(map[string]interface{}{
+		"limits":   map[string]interface{}{"cpu": "500m", "memory": map[string]interface{}{"format": ""}},
+		"requests": map[string]interface{}{"cpu": nil},
+	})
+	if err != nil {
+		t.Fatalf("marshal resources failed, err: %v", err)
+	}
+
+	resources := new(struct {
+		Limits   ResourceList `bson:"limits"`
+		Requests ResourceList `bson:"requests"`
+	})
+	if err := bson.Unmarshal(raw, resources); err != nil {
+		t.Fatalf("unmarshal resources failed, err: %v", err)
+	}
+	if !reflect.DeepEqual(resources.Limits, ResourceList{ResourceCPU: "500m", ResourceMemory: ""}) {
+		t.Fatalf("unmarshalled limits %v is invalid", resources.Limits)
+	}
+	if !reflect.DeepEqual(resources.Requests, ResourceList{ResourceCPU: ""}) {
+		t.Fatalf("unmarshalled requests %v is invalid", resources.Requests)
+	}
+
+	list := make(ResourceList)
+	if err := json.Unmarshal([]byte(`{"cpu": "1.5", "memory": 1e3, "ephemeral-storage": {"Format": ""}}`),
+		&list); err != nil {
+		t.Fatalf("unmarshal resource list json failed, err: %v", err)
+	}
+	expected := ResourceList{ResourceCPU: "1.5", ResourceMemory: "1e3", "ephemeral-storage": ""}
+	if !reflect.DeepEqual(list, expected) {
+		t.Fatalf("unmarshalled resource list %v is not as expected %v", list, expected)
+	}
+
+	if err := json.Unmarshal([]byte(`{"cpu": true}`), &list); err == nil {
+		t.Fatalf("unmarshal invalid quantity should fail")
+	}
+}
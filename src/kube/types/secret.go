@@ -0,0 +1,192 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// SecretFields merge the fields of the secret and the details corresponding to the fields together.
+var SecretFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor, ClusterBaseRefDescriptor,
+	NamespaceBaseRefDescriptor, SecretSpecFieldsDescriptor)
+
+// SecretSpecFieldsDescriptor secret spec's fields descriptors.
+var SecretSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: KubeNameField, Type: enumor.String, IsRequired: true, IsEditable: false},
+	{Field: LabelsField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+	{Field: KeysField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+	{Field: ContentHashField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: ImmutableField, Type: enumor.Boolean, IsRequired: false, IsEditable: true},
+	{Field: TypeField, Type: enumor.String, IsRequired: false, IsEditable: false},
+}
+
+const (
+	// SecretUpdateLimit limit on the number of secret updates
+	SecretUpdateLimit = 200
+	// SecretDeleteLimit limit on the number of secret delete
+	SecretDeleteLimit = 200
+	// SecretCreateLimit limit on the number of secret create
+	SecretCreateLimit = 200
+	// SecretQueryLimit limit on the number of secret query
+	SecretQueryLimit = 500
+)
+
+// Secret define the secret struct, only the metadata of the secret is recorded, the secret values are never saved,
+// the content hash should be calculated by the caller with a one-way hash algorithm.
+type Secret struct {
+	NamespaceSpec `json:",inline" bson:",inline"`
+	ID            int64   `json:"id,omitempty" bson:"id"`
+	Name          string  `json:"name,omitempty" bson:"name"`
+	Labels        *Labels `json:"labels,omitempty" bson:"labels"`
+	// Keys the keys of the data and the binary data in the secret
+	Keys *[]string `json:"keys,omitempty" bson:"keys"`
+	// ContentHash the hash of the data in the secret, it is used to find out whether the content is changed
+	ContentHash *string `json:"content_hash,omitempty" bson:"content_hash"`
+	Immutable   *bool   `json:"immutable,omitempty" bson:"immutable"`
+	// Type the type of the secret, like "Opaque" and "kubernetes.io/tls"
+	Type            *string `json:"type,omitempty" bson:"type"`
+	SupplierAccount string  `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// validateCreate validate create secret
+func (s *Secret) validateCreate() errors.RawErrorInfo {
+	if s.NamespaceID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKNamespaceIDField},
+		}
+	}
+
+	if s.Name == "" {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{common.BKFieldName},
+		}
+	}
+
+	return ValidateCreate(*s, SecretFields)
+}
+
+// BuildUpdateData build secret update data
+func (s *Secret) BuildUpdateData(user string) (map[string]interface{}, error) {
+	return buildUpdateData(s, user)
+}
+
+// SecretCreateOption create secret request
+type SecretCreateOption struct {
+	Data []Secret `json:"data"`
+}
+
+// Validate validate SecretCreateOption
+func (opt *SecretCreateOption) Validate() errors.RawErrorInfo {
+	if len(opt.Data) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(opt.Data) > SecretCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", SecretCreateLimit},
+		}
+	}
+
+	for _, data := range opt.Data {
+		if err := data.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// SecretUpdateOption update secret request
+type SecretUpdateOption struct {
+	IDs  []int64 `json:"ids"`
+	Data *Secret `json:"data"`
+}
+
+// Validate validate SecretUpdateOption
+func (opt *SecretUpdateOption) Validate() errors.RawErrorInfo {
+	if err := validateIDs(opt.IDs, SecretUpdateLimit); err.ErrCode != 0 {
+		return err
+	}
+
+	if opt.Data == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	return ValidateUpdate(*opt.Data, SecretFields)
+}
+
+// SecretDeleteOption delete secret request
+type SecretDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate SecretDeleteOption
+func (opt *SecretDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(opt.IDs, SecretDeleteLimit)
+}
+
+// SecretQueryOption secret query request
+type SecretQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate SecretQueryOption
+func (opt *SecretQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(opt.Filter, opt.Page, SecretQueryLimit, SecretFields)
+}
+
+// BuildCond build query secret condition
+func (opt *SecretQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, opt.Filter)
+}
+
+// SecretCreateResp create secret response
+type SecretCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// SecretInstResp secret instance response
+type SecretInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              SecretDataResp `json:"data"`
+}
+
+// SecretDataResp secret data
+type SecretDataResp struct {
+	Data []Secret `json:"data"`
+}
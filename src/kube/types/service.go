@@ -0,0 +1,228 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// ServiceFields merge the fields of the service and the details corresponding to the fields together.
+var ServiceFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor, ClusterBaseRefDescriptor,
+	NamespaceBaseRefDescriptor, ServiceSpecFieldsDescriptor)
+
+// ServiceSpecFieldsDescriptor service spec's fields descriptors.
+var ServiceSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: KubeNameField, Type: enumor.String, IsRequired: true, IsEditable: false},
+	{Field: LabelsField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+	{Field: SelectorField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+	{Field: TypeField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: ClusterIPsField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+	{Field: ExternalIPsField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+	{Field: ExternalNameField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: PortsField, Type: enumor.Array, IsRequired: false, IsEditable: true},
+}
+
+// ServiceBaseRefDescriptor the description used when other resources refer to the service.
+var ServiceBaseRefDescriptor = table.FieldsDescriptors{
+	{Field: BKServiceIDField, Type: enumor.Numeric, IsRequired: true, IsEditable: false},
+	{Field: ServiceNameField, Type: enumor.String, IsRequired: false, IsEditable: false},
+}
+
+// ServiceType describes ingress methods for a service
+type ServiceType string
+
+const (
+	// ServiceTypeClusterIP means a service will only be accessible inside the cluster, via the cluster IP.
+	ServiceTypeClusterIP ServiceType = "ClusterIP"
+	// ServiceTypeNodePort means a service will be exposed on one port of every node, in addition to 'ClusterIP' type.
+	ServiceTypeNodePort ServiceType = "NodePort"
+	// ServiceTypeLoadBalancer means a service will be exposed via an external load balancer, in addition to
+	// 'NodePort' type.
+	ServiceTypeLoadBalancer ServiceType = "LoadBalancer"
+	// ServiceTypeExternalName means a service consists of only a reference to an external name that kubedns or
+	// equivalent will return as a CNAME record, with no exposing or proxying of any pods involved.
+	ServiceTypeExternalName ServiceType = "ExternalName"
+)
+
+const (
+	// SvcUpdateLimit limit on the number of service updates
+	SvcUpdateLimit = 200
+	// SvcDeleteLimit limit on the number of service delete
+	SvcDeleteLimit = 200
+	// SvcCreateLimit limit on the number of service create
+	SvcCreateLimit = 200
+	// SvcQueryLimit limit on the number of service query
+	SvcQueryLimit = 500
+)
+
+// Service define the service struct.
+type Service struct {
+	NamespaceSpec   `json:",inline" bson:",inline"`
+	ID              int64              `json:"id,omitempty" bson:"id"`
+	Name            string             `json:"name,omitempty" bson:"name"`
+	Labels          *Labels            `json:"labels,omitempty" bson:"labels"`
+	Selector        *map[string]string `json:"selector,omitempty" bson:"selector"`
+	Type            *ServiceType       `json:"type,omitempty" bson:"type"`
+	ClusterIPs      *[]string          `json:"cluster_ips,omitempty" bson:"cluster_ips"`
+	ExternalIPs     *[]string          `json:"external_ips,omitempty" bson:"external_ips"`
+	ExternalName    *string            `json:"external_name,omitempty" bson:"external_name"`
+	Ports           *[]ServicePort     `json:"ports,omitempty" bson:"ports"`
+	SupplierAccount string             `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// ServicePort contains information on service's port.
+type ServicePort struct {
+	// Name the name of this port within the service.
+	Name string `json:"name" bson:"name"`
+	// Protocol the IP protocol for this port, supports "TCP", "UDP", and "SCTP".
+	Protocol Protocol `json:"protocol" bson:"protocol"`
+	// Port the port that will be exposed by this service.
+	Port int32 `json:"port" bson:"port"`
+	// TargetPort number or name of the port to access on the pods targeted by the service.
+	TargetPort IntOrString `json:"target_port" bson:"target_port"`
+	// NodePort the port on each node on which this service is exposed when type is NodePort or LoadBalancer.
+	NodePort int32 `json:"node_port" bson:"node_port"`
+}
+
+// validateCreate validate create service
+func (svc *Service) validateCreate() errors.RawErrorInfo {
+	if svc.NamespaceID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKNamespaceIDField},
+		}
+	}
+
+	if svc.Name == "" {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{common.BKFieldName},
+		}
+	}
+
+	return ValidateCreate(*svc, ServiceFields)
+}
+
+// BuildUpdateData build service update data
+func (svc *Service) BuildUpdateData(user string) (map[string]interface{}, error) {
+	return buildUpdateData(svc, user)
+}
+
+// ServiceCreateOption create service request
+type ServiceCreateOption struct {
+	Data []Service `json:"data"`
+}
+
+// Validate validate ServiceCreateOption
+func (s *ServiceCreateOption) Validate() errors.RawErrorInfo {
+	if len(s.Data) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(s.Data) > SvcCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", SvcCreateLimit},
+		}
+	}
+
+	for _, data := range s.Data {
+		if err := data.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// ServiceUpdateOption update service request
+type ServiceUpdateOption struct {
+	IDs  []int64  `json:"ids"`
+	Data *Service `json:"data"`
+}
+
+// Validate validate ServiceUpdateOption
+func (s *ServiceUpdateOption) Validate() errors.RawErrorInfo {
+	if err := validateIDs(s.IDs, SvcUpdateLimit); err.ErrCode != 0 {
+		return err
+	}
+
+	if s.Data == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	return ValidateUpdate(*s.Data, ServiceFields)
+}
+
+// ServiceDeleteOption delete service request
+type ServiceDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate ServiceDeleteOption
+func (s *ServiceDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(s.IDs, SvcDeleteLimit)
+}
+
+// ServiceQueryOption service query request
+type ServiceQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate ServiceQueryOption
+func (s *ServiceQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(s.Filter, s.Page, SvcQueryLimit, ServiceFields)
+}
+
+// BuildCond build query service condition
+func (s *ServiceQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, s.Filter)
+}
+
+// ServiceCreateResp create service response
+type ServiceCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// ServiceInstResp service instance response
+type ServiceInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              ServiceDataResp `json:"data"`
+}
+
+// ServiceDataResp service data
+type ServiceDataResp struct {
+	Data []Service `json:"data"`
+}
@@ -0,0 +1,146 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// ServiceBindingFields merge the fields of the workload service binding and the details corresponding to the fields.
+var ServiceBindingFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor, ClusterBaseRefDescriptor,
+	NamespaceBaseRefDescriptor, ServiceBindingSpecFieldsDescriptor)
+
+// ServiceBindingSpecFieldsDescriptor workload service binding spec's fields descriptors.
+var ServiceBindingSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: RefKindField, Type: enumor.String, IsRequired: true, IsEditable: false},
+	{Field: RefIDField, Type: enumor.Numeric, IsRequired: true, IsEditable: false},
+	{Field: RefNameField, Type: enumor.String, IsRequired: false, IsEditable: false},
+	{Field: common.BKServiceTemplateIDField, Type: enumor.Numeric, IsRequired: true, IsEditable: false},
+	{Field: common.BKModuleIDField, Type: enumor.Numeric, IsRequired: true, IsEditable: false},
+}
+
+const (
+	// ServiceBindingCreateLimit limit on the number of workloads to be bound at one time
+	ServiceBindingCreateLimit = 200
+	// ServiceBindingDeleteLimit limit on the number of workload service bindings delete
+	ServiceBindingDeleteLimit = 200
+	// ServiceBindingQueryLimit limit on the number of workload service bindings query
+	ServiceBindingQueryLimit = 500
+)
+
+// WorkloadServiceBinding binds a workload to a service template in a module, the pods of the workload are treated
+// as the container backed service instances of the module, and their containers as the processes.
+type WorkloadServiceBinding struct {
+	WorkloadSpec      `json:",inline" bson:",inline"`
+	ID                int64  `json:"id,omitempty" bson:"id"`
+	ServiceTemplateID int64  `json:"service_template_id,omitempty" bson:"service_template_id"`
+	ModuleID          int64  `json:"bk_module_id,omitempty" bson:"bk_module_id"`
+	SupplierAccount   string `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// ServiceBindingCreateOption bind workloads of the same kind to the service template of a module
+type ServiceBindingCreateOption struct {
+	Kind        WorkloadType `json:"kind"`
+	WorkloadIDs []int64      `json:"workload_ids"`
+	ModuleID    int64        `json:"bk_module_id"`
+}
+
+// Validate validate ServiceBindingCreateOption
+func (opt *ServiceBindingCreateOption) Validate() errors.RawErrorInfo {
+	if err := opt.Kind.Validate(); err != nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{KindField},
+		}
+	}
+
+	if len(opt.WorkloadIDs) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"workload_ids"},
+		}
+	}
+
+	if len(opt.WorkloadIDs) > ServiceBindingCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"workload_ids", ServiceBindingCreateLimit},
+		}
+	}
+
+	if opt.ModuleID <= 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{common.BKModuleIDField},
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// ServiceBindingDeleteOption delete workload service bindings request
+type ServiceBindingDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate ServiceBindingDeleteOption
+func (opt *ServiceBindingDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(opt.IDs, ServiceBindingDeleteLimit)
+}
+
+// ServiceBindingQueryOption workload service bindings query request
+type ServiceBindingQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate ServiceBindingQueryOption
+func (opt *ServiceBindingQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(opt.Filter, opt.Page, ServiceBindingQueryLimit, ServiceBindingFields)
+}
+
+// BuildCond build query workload service bindings condition
+func (opt *ServiceBindingQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, opt.Filter)
+}
+
+// ServiceBindingCreateResp create workload service bindings response
+type ServiceBindingCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// ServiceBindingInstResp workload service bindings response
+type ServiceBindingInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              ServiceBindingDataResp `json:"data"`
+}
+
+// ServiceBindingDataResp workload service bindings data
+type ServiceBindingDataResp struct {
+	Data []WorkloadServiceBinding `json:"data"`
+}
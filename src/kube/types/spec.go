@@ -166,6 +166,21 @@ func GetWorkLoadTables() []string {
 	}
 }
 
+// GetTopoStorageTables get the table names of the storage resources that are displayed in the topology path
+// together with the specified next-level topology resource object.
+func GetTopoStorageTables(subObject string) []string {
+	switch subObject {
+	case KubeNamespace:
+		// persistent volumes are cluster scoped, they are displayed under the cluster with the namespaces.
+		return []string{BKTableNameBasePersistentVolume}
+	case KubeWorkload:
+		// persistent volume claims are namespace scoped, they are displayed under the namespace with the workloads.
+		return []string{BKTableNameBasePersistentVolumeClaim}
+	default:
+		return make([]string, 0)
+	}
+}
+
 // IsKubeTopoResource determine whether it is a container object type.
 func IsKubeTopoResource(object string) bool {
 	switch object {
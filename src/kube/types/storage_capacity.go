@@ -0,0 +1,73 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/metadata"
+)
+
+// validateCapacity validate the storage capacity, it must not be negative
+func validateCapacity(capacity *int64) errors.RawErrorInfo {
+	if capacity != nil && *capacity < 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{CapacityField},
+		}
+	}
+	return errors.RawErrorInfo{}
+}
+
+// StorageCapacityLimit limit on the number of namespaces or clusters to aggregate the storage capacity
+const StorageCapacityLimit = 200
+
+// StorageCapacityOption aggregate the storage capacity of the namespaces or clusters request
+type StorageCapacityOption struct {
+	// Kind the kind of the resources to aggregate the storage capacity by, it can be namespace or cluster
+	Kind string  `json:"kind"`
+	IDs  []int64 `json:"ids"`
+}
+
+// Validate validate StorageCapacityOption
+func (opt *StorageCapacityOption) Validate() errors.RawErrorInfo {
+	if opt.Kind != KubeNamespace && opt.Kind != KubeCluster {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsInvalid,
+			Args:    []interface{}{KindField},
+		}
+	}
+
+	return validateIDs(opt.IDs, StorageCapacityLimit)
+}
+
+// StorageCapacity the storage capacity of a namespace or cluster, the capacities are in bytes.
+// persistent volumes are cluster scoped, so their statistics are only set for the clusters.
+type StorageCapacity struct {
+	ID          int64 `json:"id"`
+	PVCCount    int64 `json:"pvc_count"`
+	PVCCapacity int64 `json:"pvc_capacity"`
+	PVCount     int64 `json:"pv_count,omitempty"`
+	PVCapacity  int64 `json:"pv_capacity,omitempty"`
+}
+
+// StorageCapacityResp aggregate storage capacity response
+type StorageCapacityResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              []StorageCapacity `json:"data"`
+}
@@ -0,0 +1,190 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/criteria/enumor"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/table"
+)
+
+// StorageClassFields merge the fields of the storage class and the details corresponding to the fields together.
+var StorageClassFields = table.MergeFields(CommonSpecFieldsDescriptor, BizIDDescriptor, ClusterBaseRefDescriptor,
+	StorageClassSpecFieldsDescriptor)
+
+// StorageClassSpecFieldsDescriptor storage class spec's fields descriptors.
+var StorageClassSpecFieldsDescriptor = table.FieldsDescriptors{
+	{Field: KubeNameField, Type: enumor.String, IsRequired: true, IsEditable: false},
+	{Field: LabelsField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+	{Field: ProvisionerField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: ReclaimPolicyField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: VolumeBindingModeField, Type: enumor.String, IsRequired: false, IsEditable: true},
+	{Field: AllowVolumeExpansionField, Type: enumor.Boolean, IsRequired: false, IsEditable: true},
+	{Field: ParametersField, Type: enumor.MapString, IsRequired: false, IsEditable: true},
+}
+
+const (
+	// ScUpdateLimit limit on the number of storage class updates
+	ScUpdateLimit = 200
+	// ScDeleteLimit limit on the number of storage class delete
+	ScDeleteLimit = 200
+	// ScCreateLimit limit on the number of storage class create
+	ScCreateLimit = 200
+	// ScQueryLimit limit on the number of storage class query
+	ScQueryLimit = 500
+)
+
+// StorageClass define the storage class struct.
+type StorageClass struct {
+	ClusterSpec          `json:",inline" bson:",inline"`
+	ID                   int64              `json:"id,omitempty" bson:"id"`
+	Name                 string             `json:"name,omitempty" bson:"name"`
+	Labels               *Labels            `json:"labels,omitempty" bson:"labels"`
+	Provisioner          *string            `json:"provisioner,omitempty" bson:"provisioner"`
+	ReclaimPolicy        *string            `json:"reclaim_policy,omitempty" bson:"reclaim_policy"`
+	VolumeBindingMode    *string            `json:"volume_binding_mode,omitempty" bson:"volume_binding_mode"`
+	AllowVolumeExpansion *bool              `json:"allow_volume_expansion,omitempty" bson:"allow_volume_expansion"`
+	Parameters           *map[string]string `json:"parameters,omitempty" bson:"parameters"`
+	SupplierAccount      string             `json:"bk_supplier_account,omitempty" bson:"bk_supplier_account"`
+	// Revision record this app's revision information
+	table.Revision `json:",inline" bson:",inline"`
+}
+
+// validateCreate validate create storage class
+func (sc *StorageClass) validateCreate() errors.RawErrorInfo {
+	if sc.ClusterID == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{BKClusterIDFiled},
+		}
+	}
+
+	if sc.Name == "" {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{common.BKFieldName},
+		}
+	}
+
+	return ValidateCreate(*sc, StorageClassFields)
+}
+
+// BuildUpdateData build storage class update data
+func (sc *StorageClass) BuildUpdateData(user string) (map[string]interface{}, error) {
+	return buildUpdateData(sc, user)
+}
+
+// StorageClassCreateOption create storage class request
+type StorageClassCreateOption struct {
+	Data []StorageClass `json:"data"`
+}
+
+// Validate validate StorageClassCreateOption
+func (opt *StorageClassCreateOption) Validate() errors.RawErrorInfo {
+	if len(opt.Data) == 0 {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	if len(opt.Data) > ScCreateLimit {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"data", ScCreateLimit},
+		}
+	}
+
+	for _, data := range opt.Data {
+		if err := data.validateCreate(); err.ErrCode != 0 {
+			return err
+		}
+	}
+
+	return errors.RawErrorInfo{}
+}
+
+// StorageClassUpdateOption update storage class request
+type StorageClassUpdateOption struct {
+	IDs  []int64       `json:"ids"`
+	Data *StorageClass `json:"data"`
+}
+
+// Validate validate StorageClassUpdateOption
+func (opt *StorageClassUpdateOption) Validate() errors.RawErrorInfo {
+	if err := validateIDs(opt.IDs, ScUpdateLimit); err.ErrCode != 0 {
+		return err
+	}
+
+	if opt.Data == nil {
+		return errors.RawErrorInfo{
+			ErrCode: common.CCErrCommParamsNeedSet,
+			Args:    []interface{}{"data"},
+		}
+	}
+
+	return ValidateUpdate(*opt.Data, StorageClassFields)
+}
+
+// StorageClassDeleteOption delete storage class request
+type StorageClassDeleteOption struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Validate validate StorageClassDeleteOption
+func (opt *StorageClassDeleteOption) Validate() errors.RawErrorInfo {
+	return validateIDs(opt.IDs, ScDeleteLimit)
+}
+
+// StorageClassQueryOption storage class query request
+type StorageClassQueryOption struct {
+	Filter *filter.Expression `json:"filter"`
+	Fields []string           `json:"fields,omitempty"`
+	Page   metadata.BasePage  `json:"page,omitempty"`
+}
+
+// Validate validate StorageClassQueryOption
+func (opt *StorageClassQueryOption) Validate() errors.RawErrorInfo {
+	return validateQuery(opt.Filter, opt.Page, ScQueryLimit, StorageClassFields)
+}
+
+// BuildCond build query storage class condition
+func (opt *StorageClassQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
+	return buildBizQueryCond(bizID, opt.Filter)
+}
+
+// StorageClassCreateResp create storage class response
+type StorageClassCreateResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              metadata.RspIDs `json:"data"`
+}
+
+// StorageClassInstResp storage class instance response
+type StorageClassInstResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              StorageClassDataResp `json:"data"`
+}
+
+// StorageClassDataResp storage class data
+type StorageClassDataResp struct {
+	Data []StorageClass `json:"data"`
+}
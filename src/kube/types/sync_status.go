@@ -0,0 +1,107 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	ccErr "configcenter/src/common/errors"
+	"configcenter/src/common/metadata"
+)
+
+const (
+	// SyncStatusField the sync status field of the cluster sync status
+	SyncStatusField = "status"
+
+	// LastSyncTimeField the last sync time field of the cluster sync status
+	LastSyncTimeField = "last_sync_time"
+)
+
+// SyncStatus the status of the cluster synchronized by the kube collector
+type SyncStatus string
+
+const (
+	// SyncStatusSyncing the collector is listing the cluster resources and has not finished the first sync
+	SyncStatusSyncing SyncStatus = "syncing"
+	// SyncStatusSuccess the last sync of the cluster succeeded
+	SyncStatusSuccess SyncStatus = "success"
+	// SyncStatusFailed the last sync of the cluster failed, the reason is recorded in the message
+	SyncStatusFailed SyncStatus = "failed"
+)
+
+// SyncStats the number of the kube resources changed by one sync
+type SyncStats struct {
+	Created int64 `json:"created" bson:"created"`
+	Updated int64 `json:"updated" bson:"updated"`
+	Deleted int64 `json:"deleted" bson:"deleted"`
+	// Skipped is the number of the resources that can not be saved to cmdb, e.g. the nodes whose host is not
+	// found in the business of the cluster and the pods on them.
+	Skipped int64 `json:"skipped" bson:"skipped"`
+}
+
+// Add adds the stats of another sync step to the stats
+func (s *SyncStats) Add(stats SyncStats) {
+	s.Created += stats.Created
+	s.Updated += stats.Updated
+	s.Deleted += stats.Deleted
+	s.Skipped += stats.Skipped
+}
+
+// ClusterSyncStatus the sync status of a cluster that is collected by the kube collector
+type ClusterSyncStatus struct {
+	ClusterSpec `json:",inline" bson:",inline"`
+	Status      SyncStatus `json:"status" bson:"status"`
+	// Message is the error message of the last failed sync
+	Message string    `json:"message" bson:"message"`
+	Stats   SyncStats `json:"stats" bson:"stats"`
+	// LastSyncTime is the time when the last sync finished
+	LastSyncTime time.Time `json:"last_sync_time" bson:"last_sync_time"`
+	// LastSuccessTime is the time when the last successful sync finished
+	LastSuccessTime time.Time `json:"last_success_time" bson:"last_success_time"`
+	SupplierAccount string    `json:"bk_supplier_account" bson:"bk_supplier_account"`
+}
+
+// ClusterSyncStatusQueryOption query the sync status of the clusters in a business option
+type ClusterSyncStatusQueryOption struct {
+	ClusterIDs []int64           `json:"bk_cluster_ids"`
+	Page       metadata.BasePage `json:"page"`
+}
+
+// Validate validate ClusterSyncStatusQueryOption
+func (c *ClusterSyncStatusQueryOption) Validate() ccErr.RawErrorInfo {
+	if len(c.ClusterIDs) > common.BKMaxLimitSize {
+		return ccErr.RawErrorInfo{
+			ErrCode: common.CCErrCommXXExceedLimit,
+			Args:    []interface{}{"bk_cluster_ids", common.BKMaxLimitSize},
+		}
+	}
+
+	return c.Page.ValidateWithEnableCount(false, common.BKMaxLimitSize)
+}
+
+// ClusterSyncStatusData the cluster sync status query result
+type ClusterSyncStatusData struct {
+	Info []ClusterSyncStatus `json:"info"`
+}
+
+// ClusterSyncStatusResp the cluster sync status query response
+type ClusterSyncStatusResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              ClusterSyncStatusData `json:"data"`
+}
@@ -179,6 +179,12 @@ type KubeTopoCountRsp struct {
 	Count int64  `json:"count"`
 }
 
+// KubeTopoCountResp the response of the node host or the number of pods
+type KubeTopoCountResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              []KubeTopoCountRsp `json:"data"`
+}
+
 // KubeTopoPathOption get container topology path request.
 type KubeTopoPathOption struct {
 	ReferenceObjID string            `json:"bk_reference_obj_id"`
@@ -223,6 +229,12 @@ type KubeTopoPathRsp struct {
 	Count int              `json:"count"`
 }
 
+// KubeTopoPathResp get topology path response.
+type KubeTopoPathResp struct {
+	metadata.BaseResp `json:",inline"`
+	Data              KubeTopoPathRsp `json:"data"`
+}
+
 // SearchHostOption search host request
 type SearchHostOption struct {
 	BizID       int64                    `json:"bk_biz_id"`
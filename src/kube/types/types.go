@@ -48,6 +48,39 @@ const (
 
 	// KubeContainer k8s container type
 	KubeContainer = "container"
+
+	// KubeService k8s service type
+	KubeService = "service"
+
+	// KubeIngress k8s ingress type
+	KubeIngress = "ingress"
+
+	// KubeEndpoint k8s endpoint type, it is the pod membership of a service derived from its endpoint slices
+	KubeEndpoint = "endpoint"
+
+	// KubePersistentVolumeClaim k8s persistent volume claim type
+	KubePersistentVolumeClaim = "pvc"
+
+	// KubePersistentVolume k8s persistent volume type
+	KubePersistentVolume = "pv"
+
+	// KubeStorageClass k8s storage class type
+	KubeStorageClass = "storage_class"
+
+	// KubeServiceBinding the binding between a workload and a service template
+	KubeServiceBinding = "service_binding"
+
+	// KubeHorizontalPodAutoscaler k8s horizontal pod autoscaler type
+	KubeHorizontalPodAutoscaler = "hpa"
+
+	// KubeReplicaHistory the replica count history of the workloads
+	KubeReplicaHistory = "replica_history"
+
+	// KubeConfigMap k8s config map type
+	KubeConfigMap = "config_map"
+
+	// KubeSecret k8s secret type
+	KubeSecret = "secret"
 )
 
 // WorkloadType workload type enum
@@ -233,6 +266,42 @@ const (
 
 	// BKTableNameBaseContainer the table name of the Container
 	BKTableNameBaseContainer = "cc_ContainerBase"
+
+	// BKTableNameBaseService the table name of the Service
+	BKTableNameBaseService = "cc_ServiceBase"
+
+	// BKTableNameBaseIngress the table name of the Ingress
+	BKTableNameBaseIngress = "cc_IngressBase"
+
+	// BKTableNameBaseEndpoint the table name of the Endpoint
+	BKTableNameBaseEndpoint = "cc_EndpointBase"
+
+	// BKTableNameBasePersistentVolumeClaim the table name of the PersistentVolumeClaim
+	BKTableNameBasePersistentVolumeClaim = "cc_PersistentVolumeClaimBase"
+
+	// BKTableNameBasePersistentVolume the table name of the PersistentVolume
+	BKTableNameBasePersistentVolume = "cc_PersistentVolumeBase"
+
+	// BKTableNameBaseStorageClass the table name of the StorageClass
+	BKTableNameBaseStorageClass = "cc_StorageClassBase"
+
+	// BKTableNameWorkloadServiceBinding the table name of the bindings between workloads and service templates
+	BKTableNameWorkloadServiceBinding = "cc_WorkloadServiceBinding"
+
+	// BKTableNameBaseHorizontalPodAutoscaler the table name of the HorizontalPodAutoscaler
+	BKTableNameBaseHorizontalPodAutoscaler = "cc_HorizontalPodAutoscalerBase"
+
+	// BKTableNameWorkloadReplicaHistory the table name of the replica count history of the workloads
+	BKTableNameWorkloadReplicaHistory = "cc_WorkloadReplicaHistory"
+
+	// BKTableNameBaseConfigMap the table name of the ConfigMap
+	BKTableNameBaseConfigMap = "cc_ConfigMapBase"
+
+	// BKTableNameBaseSecret the table name of the Secret
+	BKTableNameBaseSecret = "cc_SecretBase"
+
+	// BKTableNameClusterSyncStatus the table name of the sync status of the clusters collected by the kube collector
+	BKTableNameClusterSyncStatus = "cc_ClusterSyncStatus"
 )
 
 // common field names
@@ -356,6 +425,9 @@ const (
 	// PodCidrField pod address allocation range
 	PodCidrField = "pod_cidr"
 
+	// AllocatableField node allocatable compute resources field
+	AllocatableField = "allocatable"
+
 	// BKNodeIDField cluster unique id field in cc
 	BKNodeIDField = "bk_node_id"
 
@@ -483,3 +555,147 @@ const (
 	// MountsField container mounts field
 	MountsField = "mounts"
 )
+
+// service field names
+const (
+	// BKServiceIDField service unique id field in cc
+	BKServiceIDField = "bk_service_id"
+
+	// ServiceNameField service name field in third party platform
+	ServiceNameField = "service_name"
+
+	// ClusterIPsField service cluster ips field
+	ClusterIPsField = "cluster_ips"
+
+	// ExternalIPsField service external ips field
+	ExternalIPsField = "external_ips"
+
+	// ExternalNameField service external name field
+	ExternalNameField = "external_name"
+)
+
+// ingress field names
+const (
+	// IngressClassNameField ingress class name field
+	IngressClassNameField = "ingress_class_name"
+
+	// RulesField ingress rules field
+	RulesField = "rules"
+
+	// TLSField ingress tls field
+	TLSField = "tls"
+)
+
+// endpoint field names
+const (
+	// PodNameField endpoint pod name field
+	PodNameField = "pod_name"
+
+	// AddressesField endpoint addresses field
+	AddressesField = "addresses"
+
+	// ReadyField endpoint ready field
+	ReadyField = "ready"
+)
+
+// storage field names
+const (
+	// CapacityField storage capacity field, in bytes
+	CapacityField = "capacity"
+
+	// AccessModesField storage access modes field
+	AccessModesField = "access_modes"
+
+	// StorageClassNameField storage class name field
+	StorageClassNameField = "storage_class_name"
+
+	// VolumeNameField the name of the persistent volume bound to the claim
+	VolumeNameField = "volume_name"
+
+	// VolumeModeField storage volume mode field
+	VolumeModeField = "volume_mode"
+
+	// PhaseField storage phase field
+	PhaseField = "phase"
+
+	// ReclaimPolicyField storage reclaim policy field
+	ReclaimPolicyField = "reclaim_policy"
+
+	// ProvisionerField storage class provisioner field
+	ProvisionerField = "provisioner"
+
+	// VolumeBindingModeField storage class volume binding mode field
+	VolumeBindingModeField = "volume_binding_mode"
+
+	// AllowVolumeExpansionField storage class allow volume expansion field
+	AllowVolumeExpansionField = "allow_volume_expansion"
+
+	// ParametersField storage class parameters field
+	ParametersField = "parameters"
+
+	// PVCClaimNameField the field of the pod volumes that refers to the persistent volume claim name
+	PVCClaimNameField = "volumes.persistentVolumeClaim.claimName"
+)
+
+// horizontal pod autoscaler field names
+const (
+	// MinReplicasField horizontal pod autoscaler min replicas field
+	MinReplicasField = "min_replicas"
+
+	// MaxReplicasField horizontal pod autoscaler max replicas field
+	MaxReplicasField = "max_replicas"
+
+	// MetricsField horizontal pod autoscaler metrics field
+	MetricsField = "metrics"
+
+	// CurrentReplicasField horizontal pod autoscaler current replicas field
+	CurrentReplicasField = "current_replicas"
+
+	// DesiredReplicasField horizontal pod autoscaler desired replicas field
+	DesiredReplicasField = "desired_replicas"
+)
+
+// replica history field names
+const (
+	// EventIDField the id of the event that produces the replica history
+	EventIDField = "event_id"
+
+	// TimeField the time when the replica count changes, in unix seconds
+	TimeField = "time"
+
+	// ExpireAtField the expiration time of the replica history
+	ExpireAtField = "expire_at"
+)
+
+// config map and secret field names
+const (
+	// KeysField the keys of the data in the config map or secret
+	KeysField = "keys"
+
+	// ContentHashField the hash of the data in the config map or secret
+	ContentHashField = "content_hash"
+
+	// ImmutableField whether the data in the config map or secret can not be updated
+	ImmutableField = "immutable"
+)
+
+// the fields of the pods and containers that refer to the config maps and secrets
+const (
+	// ConfigMapVolumeNameField the field of the pod volumes that refers to the config map name
+	ConfigMapVolumeNameField = "volumes.configMap.name"
+
+	// ConfigMapProjectionNameField the field of the pod projected volumes that refers to the config map name
+	ConfigMapProjectionNameField = "volumes.projected.sources.configMap.name"
+
+	// ConfigMapEnvNameField the field of the container environments that refers to the config map name
+	ConfigMapEnvNameField = "environment.valueFrom.configMapKeyRef.name"
+
+	// SecretVolumeNameField the field of the pod volumes that refers to the secret name
+	SecretVolumeNameField = "volumes.secret.secretName"
+
+	// SecretProjectionNameField the field of the pod projected volumes that refers to the secret name
+	SecretProjectionNameField = "volumes.projected.sources.secret.name"
+
+	// SecretEnvNameField the field of the container environments that refers to the secret name
+	SecretEnvNameField = "environment.valueFrom.secretKeyRef.name"
+)
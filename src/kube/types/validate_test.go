@@ -48,7 +48,7 @@ func TestValidateNumeric(t *testing.T) {
 	return
 }
 
-//TestValidateBoolen validation function unit test for numeric bool
+// TestValidateBoolen validation function unit test for numeric bool
 func TestValidateBoolen(t *testing.T) {
 	a := false
 	if err := ValidateBoolen(a); err != nil {
@@ -504,8 +504,10 @@ var wlIgnoreField = []string{
 // WlQueryOption workload query request
 type WlQueryOption struct {
 	Filter *filter.Expression `json:"filter"`
-	Fields []string           `json:"fields,omitempty"`
-	Page   metadata.BasePage  `json:"page,omitempty"`
+	// LabelSelector kubernetes style label selector of the workload labels, like "app=payments,tier!=canary"
+	LabelSelector string            `json:"label_selector,omitempty"`
+	Fields        []string          `json:"fields,omitempty"`
+	Page          metadata.BasePage `json:"page,omitempty"`
 }
 
 // Validate validate WlQueryReq
@@ -514,6 +516,10 @@ func (wl *WlQueryOption) Validate(kind WorkloadType) errors.RawErrorInfo {
 		return err
 	}
 
+	if err := validateLabelSelector(wl.LabelSelector); err.ErrCode != 0 {
+		return err
+	}
+
 	fields, err := kind.Fields()
 	if err != nil {
 		return errors.RawErrorInfo{
@@ -546,7 +552,7 @@ func (wl *WlQueryOption) BuildCond(bizID int64) (mapstr.MapStr, error) {
 		if err != nil {
 			return nil, err
 		}
-		cond = mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, filterCond}}
+		cond = mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{cond, escapeLabelCond(filterCond)}}
 	}
-	return cond, nil
+	return addLabelSelectorCond(cond, wl.LabelSelector)
 }
@@ -81,4 +81,20 @@ import (
 	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202208032125"
 	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202209231617"
 	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202209281408"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210191530"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210191600"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210201000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210211000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210221000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210241000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210251000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210261000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210271000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210281000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210291000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202210301000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202211011000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202211021000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202211031000"
+	_ "configcenter/src/scene_server/admin_server/upgrader/y3.10.202211041000"
 )
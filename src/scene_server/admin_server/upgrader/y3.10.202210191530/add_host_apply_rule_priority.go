@@ -0,0 +1,37 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package y3_10_202210191530
+
+import (
+	"context"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/storage/dal"
+)
+
+// addHostApplyRulePriorityColumn add priority column to the existing host apply rules, they are all unconditional
+// rules whose priority is 0, so that they can be found by the priority field.
+func addHostApplyRulePriorityColumn(ctx context.Context, db dal.RDB) error {
+	err := db.Table(common.BKTableNameHostApplyRule).AddColumn(ctx, common.HostApplyPriorityField, 0)
+	if err != nil {
+		blog.Errorf("add priority column to host apply rule table failed, err: %v", err)
+		return err
+	}
+	return nil
+}
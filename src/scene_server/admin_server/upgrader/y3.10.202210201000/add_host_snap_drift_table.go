@@ -0,0 +1,91 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package y3_10_202210201000
+
+import (
+	"context"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/storage/dal"
+	"configcenter/src/storage/dal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// addHostSnapDriftTable add the host snapshot drift table and its indexes
+func addHostSnapDriftTable(ctx context.Context, db dal.RDB) error {
+	table := common.BKTableNameHostSnapDrift
+
+	exists, err := db.HasTable(ctx, table)
+	if err != nil {
+		blog.Errorf("check if %s table exists failed, err: %v", table, err)
+		return err
+	}
+
+	if !exists {
+		if err := db.CreateTable(ctx, table); err != nil {
+			blog.Errorf("create %s table failed, err: %v", table, err)
+			return err
+		}
+	}
+
+	indexes := []types.Index{
+		{
+			Name:       common.CCLogicUniqueIdxNamePrefix + "bk_host_id_bk_property_id",
+			Keys:       bson.D{{common.BKHostIDField, 1}, {common.BKPropertyIDField, 1}},
+			Background: true,
+			Unique:     true,
+		},
+		{
+			Name:       common.CCLogicIndexNamePrefix + "bk_property_id",
+			Keys:       bson.D{{common.BKPropertyIDField, 1}},
+			Background: true,
+		},
+		{
+			Name:               common.CCLogicIndexNamePrefix + common.HostSnapExpireAtField,
+			Keys:               bson.D{{common.HostSnapExpireAtField, 1}},
+			Background:         true,
+			ExpireAfterSeconds: 1,
+		},
+	}
+
+	existIndexes, err := db.Table(table).Indexes(ctx)
+	if err != nil {
+		blog.Errorf("get %s table indexes failed, err: %v", table, err)
+		return err
+	}
+
+	existIndexMap := make(map[string]struct{})
+	for _, index := range existIndexes {
+		existIndexMap[index.Name] = struct{}{}
+	}
+
+	for _, index := range indexes {
+		if _, exists := existIndexMap[index.Name]; exists {
+			continue
+		}
+
+		if err := db.Table(table).CreateIndex(ctx, index); err != nil && !db.IsDuplicatedError(err) {
+			blog.Errorf("create %s table index %s failed, err: %v", table, index.Name, err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package y3_10_202210221000
+
+import (
+	"context"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/storage/dal"
+	"configcenter/src/storage/dal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// addSubnetTables add the cloud area subnet, subnet ip reservation and subnet host ip tables and their indexes
+func addSubnetTables(ctx context.Context, db dal.RDB) error {
+	tableIndexes := map[string][]types.Index{
+		common.BKTableNameSubnet: {
+			{
+				Name:       common.CCLogicUniqueIdxNamePrefix + common.BKSubnetIDField,
+				Keys:       bson.D{{common.BKSubnetIDField, 1}},
+				Background: true,
+				Unique:     true,
+			},
+			{
+				Name: common.CCLogicUniqueIdxNamePrefix + "bk_cloud_id_bk_cidr",
+				Keys: bson.D{{common.BKCloudIDField, 1}, {common.BKSubnetCIDRField, 1},
+					{common.BkSupplierAccount, 1}},
+				Background: true,
+				Unique:     true,
+			},
+		},
+		common.BKTableNameSubnetIPReservation: {
+			{
+				Name: common.CCLogicUniqueIdxNamePrefix + "bk_cloud_id_bk_ip",
+				Keys: bson.D{{common.BKCloudIDField, 1}, {common.BKSubnetIPField, 1},
+					{common.BkSupplierAccount, 1}},
+				Background: true,
+				Unique:     true,
+			},
+			{
+				Name:       common.CCLogicIndexNamePrefix + common.BKSubnetIDField,
+				Keys:       bson.D{{common.BKSubnetIDField, 1}},
+				Background: true,
+			},
+		},
+		common.BKTableNameSubnetHostIP: {
+			{
+				Name:       common.CCLogicIndexNamePrefix + "bk_cloud_id_bk_ip_key",
+				Keys:       bson.D{{common.BKCloudIDField, 1}, {common.BKSubnetIPKeyField, 1}},
+				Background: true,
+			},
+			{
+				Name:       common.CCLogicIndexNamePrefix + common.BKHostIDField,
+				Keys:       bson.D{{common.BKHostIDField, 1}},
+				Background: true,
+			},
+		},
+	}
+
+	for table, indexes := range tableIndexes {
+		exists, err := db.HasTable(ctx, table)
+		if err != nil {
+			blog.Errorf("check if %s table exists failed, err: %v", table, err)
+			return err
+		}
+
+		if !exists {
+			if err := db.CreateTable(ctx, table); err != nil {
+				blog.Errorf("create %s table failed, err: %v", table, err)
+				return err
+			}
+		}
+
+		existIndexes, err := db.Table(table).Indexes(ctx)
+		if err != nil {
+			blog.Errorf("get %s table indexes failed, err: %v", table, err)
+			return err
+		}
+
+		existIndexMap := make(map[string]struct{})
+		for _, index := range existIndexes {
+			existIndexMap[index.Name] = struct{}{}
+		}
+
+		for _, index := range indexes {
+			if _, exists := existIndexMap[index.Name]; exists {
+				continue
+			}
+
+			if err := db.Table(table).CreateIndex(ctx, index); err != nil && !db.IsDuplicatedError(err) {
+				blog.Errorf("create %s table index %s failed, err: %v", table, index.Name, err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
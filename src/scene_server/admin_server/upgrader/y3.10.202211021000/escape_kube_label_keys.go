@@ -0,0 +1,110 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+package y3_10_202211021000
+
+import (
+	"context"
+	"strings"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	kubetypes "configcenter/src/kube/types"
+	"configcenter/src/storage/dal"
+)
+
+// kubeLabelTables the tables of the kube resources that have labels
+var kubeLabelTables = []string{
+	kubetypes.BKTableNameBaseNode,
+	kubetypes.BKTableNameBaseNamespace,
+	kubetypes.BKTableNameBaseDeployment,
+	kubetypes.BKTableNameBaseStatefulSet,
+	kubetypes.BKTableNameBaseDaemonSet,
+	kubetypes.BKTableNameGameDeployment,
+	kubetypes.BKTableNameGameStatefulSet,
+	kubetypes.BKTableNameBaseCronJob,
+	kubetypes.BKTableNameBaseJob,
+	kubetypes.BKTableNameBasePodWorkload,
+	kubetypes.BKTableNameBasePod,
+	kubetypes.BKTableNameBaseService,
+	kubetypes.BKTableNameBaseIngress,
+	kubetypes.BKTableNameBasePersistentVolumeClaim,
+	kubetypes.BKTableNameBasePersistentVolume,
+	kubetypes.BKTableNameBaseStorageClass,
+	kubetypes.BKTableNameBaseHorizontalPodAutoscaler,
+	kubetypes.BKTableNameBaseConfigMap,
+	kubetypes.BKTableNameBaseSecret,
+}
+
+type kubeLabels struct {
+	ID     int64             `bson:"id"`
+	Labels map[string]string `bson:"labels"`
+}
+
+// escapeKubeLabelKeys escape the dots in the label keys of the kube resources, so that they can be queried
+func escapeKubeLabelKeys(ctx context.Context, db dal.RDB) error {
+	for _, table := range kubeLabelTables {
+		if err := escapeTableLabelKeys(ctx, db, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func escapeTableLabelKeys(ctx context.Context, db dal.RDB, table string) error {
+	var lastID int64
+	for {
+		cond := map[string]interface{}{
+			common.BKFieldID:      map[string]interface{}{common.BKDBGT: lastID},
+			kubetypes.LabelsField: map[string]interface{}{common.BKDBType: "object"},
+		}
+
+		docs := make([]kubeLabels, 0)
+		err := db.Table(table).Find(cond).Fields(common.BKFieldID, kubetypes.LabelsField).Sort(common.BKFieldID).
+			Limit(common.BKMaxPageSize).All(ctx, &docs)
+		if err != nil {
+			blog.Errorf("get %s labels failed, cond: %v, err: %v", table, cond, err)
+			return err
+		}
+
+		for _, doc := range docs {
+			if !hasDottedKey(doc.Labels) {
+				continue
+			}
+
+			updateCond := map[string]interface{}{common.BKFieldID: doc.ID}
+			updateData := map[string]interface{}{kubetypes.LabelsField: kubetypes.Labels(doc.Labels)}
+			if err := db.Table(table).Update(ctx, updateCond, updateData); err != nil {
+				blog.Errorf("escape %s labels failed, id: %d, err: %v", table, doc.ID, err)
+				return err
+			}
+		}
+
+		if len(docs) < common.BKMaxPageSize {
+			return nil
+		}
+		lastID = docs[len(docs)-1].ID
+	}
+}
+
+func hasDottedKey(labels map[string]string) bool {
+	for key := range labels {
+		if strings.Contains(key, ".") {
+			return true
+		}
+	}
+	return false
+}
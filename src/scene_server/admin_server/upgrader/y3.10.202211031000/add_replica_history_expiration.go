@@ -0,0 +1,96 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+package y3_10_202211031000
+
+import (
+	"context"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	kubetypes "configcenter/src/kube/types"
+	"configcenter/src/storage/dal"
+	"configcenter/src/storage/dal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type replicaHistoryTime struct {
+	ID   int64 `bson:"id"`
+	Time int64 `bson:"time"`
+}
+
+// addReplicaHistoryExpiration set the expiration time of the existing workload replica history and add the ttl
+// index, so that the replica history is removed after the retention days
+func addReplicaHistoryExpiration(ctx context.Context, db dal.RDB) error {
+	table := kubetypes.BKTableNameWorkloadReplicaHistory
+	var lastID int64
+	for {
+		cond := map[string]interface{}{
+			common.BKFieldID:        map[string]interface{}{common.BKDBGT: lastID},
+			kubetypes.ExpireAtField: map[string]interface{}{common.BKDBExists: false},
+		}
+
+		histories := make([]replicaHistoryTime, 0)
+		err := db.Table(table).Find(cond).Fields(common.BKFieldID, kubetypes.TimeField).Sort(common.BKFieldID).
+			Limit(common.BKMaxPageSize).All(ctx, &histories)
+		if err != nil {
+			blog.Errorf("get replica history failed, cond: %v, err: %v", cond, err)
+			return err
+		}
+
+		for _, history := range histories {
+			expireAt := time.Unix(history.Time, 0).UTC().AddDate(0, 0, kubetypes.ReplicaHistoryRetentionDays)
+			updateCond := map[string]interface{}{common.BKFieldID: history.ID}
+			updateData := map[string]interface{}{kubetypes.ExpireAtField: expireAt}
+			if err := db.Table(table).Update(ctx, updateCond, updateData); err != nil {
+				blog.Errorf("set replica history expire time failed, id: %d, err: %v", history.ID, err)
+				return err
+			}
+		}
+
+		if len(histories) < common.BKMaxPageSize {
+			break
+		}
+		lastID = histories[len(histories)-1].ID
+	}
+
+	expireIndex := types.Index{
+		Name:               common.CCLogicIndexNamePrefix + kubetypes.ExpireAtField,
+		Keys:               bson.D{{kubetypes.ExpireAtField, 1}},
+		Background:         true,
+		ExpireAfterSeconds: 1,
+	}
+
+	existIndexes, err := db.Table(table).Indexes(ctx)
+	if err != nil {
+		blog.Errorf("get %s table indexes failed, err: %v", table, err)
+		return err
+	}
+
+	for _, index := range existIndexes {
+		if index.Name == expireIndex.Name {
+			return nil
+		}
+	}
+
+	if err := db.Table(table).CreateIndex(ctx, expireIndex); err != nil && !db.IsDuplicatedError(err) {
+		blog.Errorf("create %s table index %s failed, err: %v", table, expireIndex.Name, err)
+		return err
+	}
+	return nil
+}
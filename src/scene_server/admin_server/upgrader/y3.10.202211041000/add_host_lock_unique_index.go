@@ -0,0 +1,109 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+package y3_10_202211041000
+
+import (
+	"context"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/storage/dal"
+	"configcenter/src/storage/dal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type hostLock struct {
+	MongoID primitive.ObjectID `bson:"_id"`
+	HostID  int64              `bson:"bk_host_id"`
+}
+
+// addHostLockUniqueIndex removes the duplicate locks of a host that are left by the concurrent lock requests, and
+// replaces the host id index of the host lock table with the unique index, so that a host can only be locked once
+func addHostLockUniqueIndex(ctx context.Context, db dal.RDB) error {
+	table := common.BKTableNameHostLock
+
+	// the latest lock of each host is kept, the locks are sorted by the create time in descending order
+	locks := make([]hostLock, 0)
+	err := db.Table(table).Find(nil).Fields("_id", common.BKHostIDField).Sort(common.CreateTimeField+":-1").
+		All(ctx, &locks)
+	if err != nil {
+		blog.Errorf("get host locks failed, err: %v", err)
+		return err
+	}
+
+	hostIDMap := make(map[int64]struct{})
+	delMongoIDs := make([]primitive.ObjectID, 0)
+	for _, lock := range locks {
+		if _, exists := hostIDMap[lock.HostID]; exists {
+			delMongoIDs = append(delMongoIDs, lock.MongoID)
+			continue
+		}
+		hostIDMap[lock.HostID] = struct{}{}
+	}
+
+	for start := 0; start < len(delMongoIDs); start += common.BKMaxPageSize {
+		end := start + common.BKMaxPageSize
+		if end > len(delMongoIDs) {
+			end = len(delMongoIDs)
+		}
+
+		delCond := map[string]interface{}{"_id": map[string]interface{}{common.BKDBIN: delMongoIDs[start:end]}}
+		if err := db.Table(table).Delete(ctx, delCond); err != nil {
+			blog.Errorf("delete duplicate host locks failed, err: %v", err)
+			return err
+		}
+	}
+
+	uniqueIndex := types.Index{
+		Name:       common.CCLogicUniqueIdxNamePrefix + "bkHostID",
+		Keys:       bson.D{{common.BKHostIDField, 1}},
+		Unique:     true,
+		Background: true,
+	}
+
+	existIndexes, err := db.Table(table).Indexes(ctx)
+	if err != nil {
+		blog.Errorf("get %s table indexes failed, err: %v", table, err)
+		return err
+	}
+
+	for _, index := range existIndexes {
+		if index.Name == uniqueIndex.Name {
+			return nil
+		}
+	}
+
+	// the deprecated index has the same keys with the unique index, it must be removed before the creation
+	for _, index := range existIndexes {
+		if index.Name != "bk_host_id_1" {
+			continue
+		}
+
+		if err := db.Table(table).DropIndex(ctx, index.Name); err != nil && !db.IsNotFoundError(err) {
+			blog.Errorf("drop %s table index %s failed, err: %v", table, index.Name, err)
+			return err
+		}
+	}
+
+	if err := db.Table(table).CreateIndex(ctx, uniqueIndex); err != nil && !db.IsDuplicatedError(err) {
+		blog.Errorf("create %s table index %s failed, err: %v", table, uniqueIndex.Name, err)
+		return err
+	}
+	return nil
+}
@@ -54,4 +54,18 @@ type Config struct {
 	SecretsEnv     string
 	// sync period of cloud sync task, unit is second
 	SyncPeriodMinutes int
+	// KubeCollector is the config of the built-in kubernetes cluster collector
+	KubeCollector KubeCollectorConfig
+}
+
+// KubeCollectorConfig is the config of the built-in kubernetes cluster collector
+type KubeCollectorConfig struct {
+	Enabled bool
+	// KubeconfigDir is the directory of the kubeconfig files of the clusters, each file is named by the cluster uid
+	KubeconfigDir string
+	// ResyncPeriodMinutes is the period of the full reconciliation of each cluster
+	ResyncPeriodMinutes int
+	// QPS and Burst limit the rate of the requests to cmdb of the collector
+	QPS   int64
+	Burst int64
 }
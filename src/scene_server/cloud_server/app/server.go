@@ -29,6 +29,7 @@ import (
 	"configcenter/src/common/util"
 	"configcenter/src/scene_server/cloud_server/app/options"
 	"configcenter/src/scene_server/cloud_server/cloudsync"
+	"configcenter/src/scene_server/cloud_server/kubecollector"
 	"configcenter/src/scene_server/cloud_server/logics"
 	svc "configcenter/src/scene_server/cloud_server/service"
 	"configcenter/src/thirdparty/secrets"
@@ -113,6 +114,8 @@ func Run(ctx context.Context, cancel context.CancelFunc, op *options.ServerOptio
 		return fmt.Errorf("ProcessTask failed: %v", err)
 	}
 
+	process.runKubeCollector(ctx)
+
 	err = backbone.StartServer(ctx, cancel, engine, service.WebService(), true)
 	if err != nil {
 		return err
@@ -144,6 +147,11 @@ func (c *CloudServer) onCloudConfigUpdate(previous, current cc.ProcessConfig) {
 	c.Config.SecretsProject, _ = cc.String("cloudServer.cryptor.secretsProject")
 	c.Config.SecretsEnv, _ = cc.String("cloudServer.cryptor.secretsEnv")
 	c.Config.SyncPeriodMinutes, _ = cc.Int("cloudServer.syncTask.syncPeriodMinutes")
+	c.Config.KubeCollector.Enabled, _ = cc.Bool("cloudServer.kubeCollector.enabled")
+	c.Config.KubeCollector.KubeconfigDir, _ = cc.String("cloudServer.kubeCollector.kubeconfigDir")
+	c.Config.KubeCollector.ResyncPeriodMinutes, _ = cc.Int("cloudServer.kubeCollector.resyncPeriodMinutes")
+	c.Config.KubeCollector.QPS, _ = cc.Int64("cloudServer.kubeCollector.rateLimiter.qps")
+	c.Config.KubeCollector.Burst, _ = cc.Int64("cloudServer.kubeCollector.rateLimiter.burst")
 }
 
 // getSecretKey get the secret key from bk-secrets service
@@ -194,3 +202,23 @@ func (c *CloudServer) setSyncPeriod() {
 	}
 	blog.Infof("sync period is %d minutes", cloudsync.SyncPeriodMinutes)
 }
+
+// runKubeCollector run the kubernetes cluster collector if it is enabled
+func (c *CloudServer) runKubeCollector(ctx context.Context) {
+	conf := c.Config.KubeCollector
+	if !conf.Enabled {
+		blog.Infof("kube collector is disabled")
+		return
+	}
+
+	collectorConf := kubecollector.Config{
+		KubeconfigDir: conf.KubeconfigDir,
+		ResyncPeriod:  time.Duration(conf.ResyncPeriodMinutes) * time.Minute,
+		QPS:           conf.QPS,
+		Burst:         conf.Burst,
+	}
+	collector := kubecollector.NewCollector(collectorConf, kubecollector.NewStore(c.Core.CoreAPI),
+		kubecollector.NewKubeconfigClientGetter(conf.KubeconfigDir), c.Core.ServiceManageInterface.IsMaster,
+		c.Core.CCErr)
+	go collector.Run(ctx)
+}
@@ -0,0 +1,79 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubecollector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"configcenter/src/kube/types"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ErrKubeconfigNotFound the kubeconfig of the cluster is not provided, the cluster is not collected
+var ErrKubeconfigNotFound = errors.New("kubeconfig not found")
+
+// ClientSet is the clients of a kubernetes cluster, the dynamic client is used for the custom workloads
+type ClientSet struct {
+	Kube    kubernetes.Interface
+	Dynamic dynamic.Interface
+}
+
+// ClientGetter gets the clients of a registered cluster, returns ErrKubeconfigNotFound if the cluster is not
+// collected.
+type ClientGetter func(cluster *types.Cluster) (*ClientSet, error)
+
+// NewKubeconfigClientGetter new a ClientGetter that builds the clients by the kubeconfig file named by the cluster uid
+// in the kubeconfig directory
+func NewKubeconfigClientGetter(dir string) ClientGetter {
+	return func(cluster *types.Cluster) (*ClientSet, error) {
+		if dir == "" || cluster.Uid == nil || *cluster.Uid == "" {
+			return nil, ErrKubeconfigNotFound
+		}
+
+		// the uid is used as the file name, it must not escape from the kubeconfig directory
+		if filepath.Base(*cluster.Uid) != *cluster.Uid {
+			return nil, fmt.Errorf("cluster uid %s can not be used as kubeconfig file name", *cluster.Uid)
+		}
+
+		path := filepath.Join(dir, *cluster.Uid)
+		if _, err := os.Stat(path); err != nil {
+			if os.IsNotExist(err) {
+				return nil, ErrKubeconfigNotFound
+			}
+			return nil, fmt.Errorf("stat kubeconfig file %s failed, err: %v", path, err)
+		}
+
+		config, err := clientcmd.BuildConfigFromFlags("", path)
+		if err != nil {
+			return nil, fmt.Errorf("load kubeconfig file %s failed, err: %v", path, err)
+		}
+
+		kubeClient, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("new kubernetes client failed, err: %v", err)
+		}
+
+		dynamicClient, err := dynamic.NewForConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("new kubernetes dynamic client failed, err: %v", err)
+		}
+
+		return &ClientSet{Kube: kubeClient, Dynamic: dynamicClient}, nil
+	}
+}
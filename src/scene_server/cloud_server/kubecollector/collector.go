@@ -0,0 +1,214 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package kubecollector collects the resources of the registered kubernetes clusters whose kubeconfig is provided,
+// it list-watches the cluster resources with informers and reconciles them into cmdb by the kube apis.
+package kubecollector
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"configcenter/src/apimachinery/flowctrl"
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	ccErr "configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+)
+
+const (
+	// ResyncPeriodMinutesMin is the min period of the full reconciliation of a cluster
+	ResyncPeriodMinutesMin = 5
+	// clusterRefreshInterval is the interval to refresh the collected clusters
+	clusterRefreshInterval = time.Minute
+	// minSyncInterval is the min interval between two reconciliations of a cluster, the resource events in the
+	// interval are merged into one reconciliation.
+	minSyncInterval = 30 * time.Second
+	// syncBatchSize is the max number of the resources created, updated or deleted by one request
+	syncBatchSize = 100
+	// defaultQPS and defaultBurst is the default rate limit of the requests to cmdb
+	defaultQPS   = 50
+	defaultBurst = 100
+)
+
+// Config is the kube collector config
+type Config struct {
+	// KubeconfigDir is the directory of the kubeconfig files, the kubeconfig file of a cluster is named by its uid,
+	// only the clusters whose kubeconfig file exists are collected.
+	KubeconfigDir string
+	// ResyncPeriod is the period of the full reconciliation of a cluster, the reconciliation is also triggered by
+	// the cluster resource events in the period.
+	ResyncPeriod time.Duration
+	// QPS and Burst limit the rate of the requests to cmdb of all the clusters
+	QPS   int64
+	Burst int64
+}
+
+// Collector collects the resources of the registered clusters that have a kubeconfig, only the master collects.
+type Collector struct {
+	conf      Config
+	store     Store
+	getClient ClientGetter
+	isMaster  func() bool
+	errIf     ccErr.CCErrorIf
+	limiter   flowctrl.RateLimiter
+
+	// syncers is the running cluster syncers, the key is the cluster id
+	syncers map[int64]*clusterSyncer
+	lock    sync.Mutex
+}
+
+// NewCollector new kube collector
+func NewCollector(conf Config, store Store, getClient ClientGetter, isMaster func() bool,
+	errIf ccErr.CCErrorIf) *Collector {
+	if conf.ResyncPeriod < ResyncPeriodMinutesMin*time.Minute {
+		conf.ResyncPeriod = ResyncPeriodMinutesMin * time.Minute
+	}
+	if conf.QPS <= 0 {
+		conf.QPS = defaultQPS
+	}
+	if conf.Burst <= 0 {
+		conf.Burst = defaultBurst
+	}
+
+	return &Collector{
+		conf:      conf,
+		store:     store,
+		getClient: getClient,
+		isMaster:  isMaster,
+		errIf:     errIf,
+		limiter:   flowctrl.NewRateLimiter(conf.QPS, conf.Burst),
+		syncers:   make(map[int64]*clusterSyncer),
+	}
+}
+
+// Run refreshes the collected clusters periodically until the context is done
+func (c *Collector) Run(ctx context.Context) {
+	blog.Infof("start kube collector, kubeconfig dir: %s, resync period: %s", c.conf.KubeconfigDir,
+		c.conf.ResyncPeriod)
+
+	ticker := time.NewTicker(clusterRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		c.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			c.stopAll()
+			blog.Infof("kube collector stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh starts the syncers of the newly registered clusters and stops the syncers of the removed clusters
+func (c *Collector) refresh(ctx context.Context) {
+	if !c.isMaster() {
+		c.stopAll()
+		return
+	}
+
+	// list the clusters of all the supplier accounts, each cluster is synchronized with its own supplier account
+	kit := c.newKit(common.BKSuperOwnerID)
+	clusters, err := c.store.ListClusters(kit)
+	if err != nil {
+		blog.Errorf("list clusters failed, err: %v, rid: %s", err, kit.Rid)
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	exists := make(map[int64]struct{})
+	for idx := range clusters {
+		cluster := clusters[idx]
+		if cluster.Uid == nil || *cluster.Uid == "" {
+			continue
+		}
+		exists[cluster.ID] = struct{}{}
+
+		syncer, running := c.syncers[cluster.ID]
+		if running && syncer.clusterUID == *cluster.Uid && syncer.bizID == cluster.BizID {
+			continue
+		}
+
+		if running {
+			syncer.stop()
+			delete(c.syncers, cluster.ID)
+		}
+
+		clientSet, err := c.getClient(&cluster)
+		if err != nil {
+			if errors.Is(err, ErrKubeconfigNotFound) {
+				continue
+			}
+
+			blog.Errorf("get cluster %d client failed, err: %v, rid: %s", cluster.ID, err, kit.Rid)
+			c.saveFailedStatus(&cluster, err)
+			continue
+		}
+
+		syncer = newClusterSyncer(&cluster, clientSet, c.store, c.limiter, c.conf.ResyncPeriod, c.newKit)
+		syncer.start(ctx)
+		c.syncers[cluster.ID] = syncer
+	}
+
+	for clusterID, syncer := range c.syncers {
+		if _, ok := exists[clusterID]; ok {
+			continue
+		}
+		syncer.stop()
+		delete(c.syncers, clusterID)
+	}
+}
+
+// saveFailedStatus records the cluster whose collection can not be started as failed
+func (c *Collector) saveFailedStatus(cluster *types.Cluster, reason error) {
+	kit := c.newKit(cluster.SupplierAccount)
+	status := &types.ClusterSyncStatus{
+		ClusterSpec: types.ClusterSpec{
+			BizID:      cluster.BizID,
+			ClusterID:  cluster.ID,
+			ClusterUID: *cluster.Uid,
+		},
+		Status:       types.SyncStatusFailed,
+		Message:      reason.Error(),
+		LastSyncTime: time.Now(),
+	}
+
+	if err := c.store.SaveSyncStatus(kit, status); err != nil {
+		blog.Errorf("save cluster %d sync status failed, err: %v, rid: %s", cluster.ID, err, kit.Rid)
+	}
+}
+
+// stopAll stops all the running cluster syncers
+func (c *Collector) stopAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for clusterID, syncer := range c.syncers {
+		syncer.stop()
+		delete(c.syncers, clusterID)
+	}
+}
+
+// newKit new a kit of the system user of the supplier account for the requests to cmdb
+func (c *Collector) newKit(supplierAccount string) *rest.Kit {
+	header := util.BuildHeader(common.CCSystemOperatorUserName, supplierAccount)
+	return rest.NewKitFromHeader(header, c.errIf)
+}
@@ -0,0 +1,461 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubecollector
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"configcenter/src/apimachinery/flowctrl"
+	"configcenter/src/common"
+	ccErr "configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/converter"
+	"configcenter/src/kube/types"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// memStore is an in-memory Store for tests
+type memStore struct {
+	lock       sync.Mutex
+	nextID     int64
+	clusters   []types.Cluster
+	hosts      map[string]int64
+	statuses   map[int64]types.ClusterSyncStatus
+	nodes      map[int64]types.Node
+	namespaces map[int64]types.Namespace
+	workloads  map[types.WorkloadType]map[int64]types.WorkloadInterface
+	pods       map[int64]types.Pod
+	containers map[int64][]types.Container
+}
+
+func newMemStore(hosts map[string]int64) *memStore {
+	return &memStore{
+		hosts:      hosts,
+		statuses:   make(map[int64]types.ClusterSyncStatus),
+		nodes:      make(map[int64]types.Node),
+		namespaces: make(map[int64]types.Namespace),
+		workloads:  make(map[types.WorkloadType]map[int64]types.WorkloadInterface),
+		pods:       make(map[int64]types.Pod),
+		containers: make(map[int64][]types.Container),
+	}
+}
+
+func (m *memStore) newID() int64 {
+	m.nextID++
+	return m.nextID
+}
+
+func (m *memStore) ListClusters(*rest.Kit) ([]types.Cluster, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]types.Cluster{}, m.clusters...), nil
+}
+
+func (m *memStore) SaveSyncStatus(_ *rest.Kit, status *types.ClusterSyncStatus) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.statuses[status.ClusterID] = *status
+	return nil
+}
+
+func (m *memStore) ListHostIDsByIP(_ *rest.Kit, _ int64, ips []string) (map[string]int64, error) {
+	result := make(map[string]int64)
+	for _, ip := range ips {
+		if id, ok := m.hosts[ip]; ok {
+			result[ip] = id
+		}
+	}
+	return result, nil
+}
+
+func (m *memStore) ListNodes(*rest.Kit, int64, int64) ([]types.Node, error) {
+	nodes := make([]types.Node, 0)
+	for _, node := range m.nodes {
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (m *memStore) CreateNodes(_ *rest.Kit, bizID int64, nodes []types.OneNodeCreateOption) error {
+	for _, opt := range nodes {
+		node := opt.Node
+		node.ID, node.BizID, node.HostID, node.ClusterID = m.newID(), bizID, opt.HostID, opt.ClusterID
+		m.nodes[node.ID] = node
+	}
+	return nil
+}
+
+func (m *memStore) UpdateNode(_ *rest.Kit, _, id int64, data *types.Node) error {
+	node := m.nodes[id]
+	node.Labels, node.Taints, node.Roles = data.Labels, data.Taints, data.Roles
+	node.InternalIP, node.ExternalIP, node.Unschedulable = data.InternalIP, data.ExternalIP, data.Unschedulable
+	node.HostName, node.RuntimeComponent, node.PodCidr = data.HostName, data.RuntimeComponent, data.PodCidr
+	m.nodes[id] = node
+	return nil
+}
+
+func (m *memStore) DeleteNodes(_ *rest.Kit, _ int64, ids []int64) error {
+	for _, id := range ids {
+		delete(m.nodes, id)
+	}
+	return nil
+}
+
+func (m *memStore) ListNamespaces(*rest.Kit, int64, int64) ([]types.Namespace, error) {
+	namespaces := make([]types.Namespace, 0)
+	for _, namespace := range m.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces, nil
+}
+
+func (m *memStore) CreateNamespaces(_ *rest.Kit, _ int64, namespaces []types.Namespace) error {
+	for _, namespace := range namespaces {
+		namespace.ID = m.newID()
+		m.namespaces[namespace.ID] = namespace
+	}
+	return nil
+}
+
+func (m *memStore) UpdateNamespace(_ *rest.Kit, _, id int64, data *types.Namespace) error {
+	namespace := m.namespaces[id]
+	namespace.Labels, namespace.ResourceQuotas = data.Labels, data.ResourceQuotas
+	m.namespaces[id] = namespace
+	return nil
+}
+
+func (m *memStore) DeleteNamespaces(_ *rest.Kit, _ int64, ids []int64) error {
+	for _, id := range ids {
+		delete(m.namespaces, id)
+	}
+	return nil
+}
+
+func (m *memStore) ListWorkloads(_ *rest.Kit, _, _ int64, kind types.WorkloadType) ([]types.WorkloadInterface,
+	error) {
+
+	workloads := make([]types.WorkloadInterface, 0)
+	for _, workload := range m.workloads[kind] {
+		workloads = append(workloads, workload)
+	}
+
+	// copy the workloads, since the collector resets the base of the listed workloads
+	data, err := json.Marshal(map[string]interface{}{"info": workloads})
+	if err != nil {
+		return nil, err
+	}
+	copied := &types.WlDataResp{Kind: kind}
+	if err := json.Unmarshal(data, copied); err != nil {
+		return nil, err
+	}
+	return copied.Info, nil
+}
+
+func (m *memStore) CreateWorkloads(_ *rest.Kit, _ int64, kind types.WorkloadType,
+	workloads []types.WorkloadInterface) error {
+
+	if m.workloads[kind] == nil {
+		m.workloads[kind] = make(map[int64]types.WorkloadInterface)
+	}
+	for _, workload := range workloads {
+		base := workload.GetWorkloadBase()
+		base.ID = m.newID()
+		workload.SetWorkloadBase(base)
+		m.workloads[kind][base.ID] = workload
+	}
+	return nil
+}
+
+func (m *memStore) UpdateWorkload(_ *rest.Kit, _ int64, kind types.WorkloadType, id int64,
+	workload types.WorkloadInterface) error {
+
+	workload.SetWorkloadBase(m.workloads[kind][id].GetWorkloadBase())
+	m.workloads[kind][id] = workload
+	return nil
+}
+
+func (m *memStore) DeleteWorkloads(_ *rest.Kit, _ int64, kind types.WorkloadType, ids []int64) error {
+	for _, id := range ids {
+		delete(m.workloads[kind], id)
+	}
+	return nil
+}
+
+func (m *memStore) ListPods(*rest.Kit, int64, int64) ([]types.Pod, error) {
+	pods := make([]types.Pod, 0)
+	for _, pod := range m.pods {
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+func (m *memStore) ListContainers(_ *rest.Kit, podIDs []int64) (map[int64][]types.Container, error) {
+	result := make(map[int64][]types.Container)
+	for _, id := range podIDs {
+		result[id] = m.containers[id]
+	}
+	return result, nil
+}
+
+func (m *memStore) CreatePods(_ *rest.Kit, _ int64, pods []types.PodsInfo) error {
+	for _, info := range pods {
+		pod := info.Pod
+		pod.ID = m.newID()
+		pod.HostID, pod.NodeID, pod.Ref = info.HostID, info.Spec.NodeID, info.Spec.Ref
+		pod.ClusterID, pod.NamespaceID = info.Spec.ClusterID, info.Spec.NamespaceID
+		pod.Namespace = m.namespaces[info.Spec.NamespaceID].Name
+		m.pods[pod.ID] = pod
+		m.containers[pod.ID] = info.Containers
+	}
+	return nil
+}
+
+func (m *memStore) DeletePods(_ *rest.Kit, _ int64, ids []int64) error {
+	for _, id := range ids {
+		delete(m.pods, id)
+		delete(m.containers, id)
+	}
+	return nil
+}
+
+// podRefs returns the workload reference of the pods in the store by the pod name
+func (m *memStore) podRefs() map[string]types.Reference {
+	refs := make(map[string]types.Reference)
+	for _, pod := range m.pods {
+		refs[*pod.Name] = pod.Ref
+	}
+	return refs
+}
+
+var gameDeploymentGVR = gameWorkloadGroupVersion.WithResource("gamedeployments")
+
+func newTestKit(supplierAccount string) *rest.Kit {
+	header := util.BuildHeader(common.CCSystemOperatorUserName, supplierAccount)
+	return rest.NewKitFromHeader(header, ccErr.NewFromCtx(map[string]ccErr.ErrorCode{}))
+}
+
+func newTestClientSet(objects ...runtime.Object) *ClientSet {
+	kube := fake.NewSimpleClientset(objects...)
+	kube.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "batch/v1", APIResources: []metav1.APIResource{{Name: "jobs"}, {Name: cronJobResource}}},
+		{GroupVersion: gameWorkloadGroupVersion.String(), APIResources: []metav1.APIResource{{Name: "gamedeployments"}}},
+	}
+
+	gameDeployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": gameWorkloadGroupVersion.String(),
+		"kind":       "GameDeployment",
+		"metadata":   map[string]interface{}{"name": "game", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"replicas":       int64(2),
+			"updateStrategy": map[string]interface{}{"type": "InplaceUpdate", "partition": int64(1)},
+		},
+	}}
+	dynamic := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gameDeploymentGVR: "GameDeploymentList"}, gameDeployment)
+
+	return &ClientSet{Kube: kube, Dynamic: dynamic}
+}
+
+func newTestObjects() []runtime.Object {
+	controller := true
+	replicas := int32(1)
+	return []runtime.Object{
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1",
+				Labels: map[string]string{converter.NodeRoleLabelPrefix + "master": ""}},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "127.0.0.1"},
+			}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "127.0.0.2"},
+			}},
+		},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "default"},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Deployment", Name: "web", Controller: &controller},
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1-a", Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-1", Controller: &controller}}},
+			Spec: corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "web", Image: "web"}}},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "web", ContainerID: "docker://web-1-a"},
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "game-a", Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "GameDeployment", Name: "game", Controller: &controller}}},
+			Spec: corev1.PodSpec{NodeName: "node-1"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "static", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+		},
+		// the pod on the node without a host in cmdb is skipped
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "skipped", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "node-2"},
+		},
+		// the pod that is not scheduled is not collected
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"}},
+	}
+}
+
+func newTestSyncer(clientSet *ClientSet, store Store) *clusterSyncer {
+	uid := "BCS-K8S-00001"
+	cluster := &types.Cluster{ID: 100, BizID: 2, Uid: &uid, SupplierAccount: common.BKDefaultOwnerID}
+	return newClusterSyncer(cluster, clientSet, store, flowctrl.NewRateLimiter(1000, 1000), time.Hour, newTestKit)
+}
+
+func TestSync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newMemStore(map[string]int64{"127.0.0.1": 1})
+	clientSet := newTestClientSet(newTestObjects()...)
+	syncer := newTestSyncer(clientSet, store)
+	require.NoError(t, syncer.startInformers(ctx))
+
+	stats, err := syncer.sync(ctx)
+	require.NoError(t, err)
+	// the node, namespace, deployment, game deployment, pods workload and 3 pods are created
+	require.Equal(t, types.SyncStats{Created: 8, Skipped: 2}, stats)
+
+	require.Len(t, store.nodes, 1)
+	for _, node := range store.nodes {
+		require.Equal(t, int64(1), node.HostID)
+		require.Equal(t, "master", *node.Roles)
+	}
+	require.Len(t, store.namespaces, 1)
+	for _, namespace := range store.namespaces {
+		require.Len(t, *namespace.ResourceQuotas, 1)
+	}
+
+	refs := store.podRefs()
+	require.Len(t, refs, 3)
+	require.Equal(t, types.KubeDeployment, refs["web-1-a"].Kind)
+	require.Equal(t, "web", refs["web-1-a"].Name)
+	require.Equal(t, types.KubeGameDeployment, refs["game-a"].Kind)
+	require.Equal(t, types.KubePodWorkload, refs["static"].Kind)
+	require.Equal(t, podsWorkloadName, refs["static"].Name)
+	for id, pod := range store.pods {
+		if *pod.Name == "web-1-a" {
+			require.Len(t, store.containers[id], 1)
+		}
+	}
+
+	// nothing changes in the second sync
+	stats, err = syncer.sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.SyncStats{Skipped: 2}, stats)
+
+	// update the deployment and remove the pods, the pods workload without pods is deleted
+	kube := clientSet.Kube
+	deploy, err := kube.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	deploy.Labels["version"] = "v2"
+	_, err = kube.AppsV1().Deployments("default").Update(ctx, deploy, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, kube.CoreV1().Pods("default").Delete(ctx, "static", metav1.DeleteOptions{}))
+	require.NoError(t, kube.CoreV1().Pods("default").Delete(ctx, "web-1-a", metav1.DeleteOptions{}))
+	require.NoError(t, kube.CoreV1().Pods("default").Delete(ctx, "skipped", metav1.DeleteOptions{}))
+
+	err = wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		pods, err := syncer.listers.pod.Pods("default").List(labels.Everything())
+		if err != nil {
+			return false, err
+		}
+		deploy, err := syncer.listers.deployment.Deployments("default").Get("web")
+		if err != nil {
+			return false, err
+		}
+		return len(pods) == 2 && deploy.Labels["version"] == "v2", nil
+	})
+	require.NoError(t, err)
+
+	stats, err = syncer.sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.SyncStats{Updated: 1, Deleted: 3, Skipped: 1}, stats)
+	require.Len(t, store.pods, 1)
+	require.Empty(t, store.workloads[types.KubePodWorkload])
+	for _, workload := range store.workloads[types.KubeDeployment] {
+		require.Equal(t, "v2", (*workload.(*types.Deployment).Labels)["version"])
+	}
+}
+
+func TestCollectorRefresh(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uid := "BCS-K8S-00001"
+	store := newMemStore(map[string]int64{"127.0.0.1": 1})
+	store.clusters = []types.Cluster{
+		{ID: 100, BizID: 2, Uid: &uid, SupplierAccount: common.BKDefaultOwnerID},
+		// the cluster without kubeconfig is not collected
+		{ID: 101, BizID: 2, Uid: new(string), SupplierAccount: common.BKDefaultOwnerID},
+	}
+
+	isMaster := true
+	getClient := func(cluster *types.Cluster) (*ClientSet, error) {
+		if cluster.ID != 100 {
+			return nil, ErrKubeconfigNotFound
+		}
+		return newTestClientSet(newTestObjects()...), nil
+	}
+	collector := NewCollector(Config{QPS: 100, Burst: 100}, store, getClient, func() bool { return isMaster },
+		ccErr.NewFromCtx(map[string]ccErr.ErrorCode{}))
+
+	collector.refresh(ctx)
+	require.Len(t, collector.syncers, 1)
+
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		store.lock.Lock()
+		defer store.lock.Unlock()
+		return store.statuses[100].Status == types.SyncStatusSuccess, nil
+	})
+	require.NoError(t, err)
+
+	// the syncers are stopped when the collector is not master
+	isMaster = false
+	collector.refresh(ctx)
+	require.Empty(t, collector.syncers)
+}
@@ -0,0 +1,38 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubecollector
+
+import (
+	"configcenter/src/kube/types"
+)
+
+// nodeUpdateData returns the editable fields of the node
+func nodeUpdateData(node *types.Node) *types.Node {
+	return &types.Node{
+		Roles:            node.Roles,
+		Labels:           node.Labels,
+		Taints:           node.Taints,
+		Unschedulable:    node.Unschedulable,
+		InternalIP:       node.InternalIP,
+		ExternalIP:       node.ExternalIP,
+		HostName:         node.HostName,
+		RuntimeComponent: node.RuntimeComponent,
+		PodCidr:          node.PodCidr,
+		Allocatable:      node.Allocatable,
+	}
+}
+
+// namespaceUpdateData returns the editable fields of the namespace
+func namespaceUpdateData(namespace *types.Namespace) *types.Namespace {
+	return &types.Namespace{Labels: namespace.Labels, ResourceQuotas: namespace.ResourceQuotas}
+}
@@ -0,0 +1,698 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubecollector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"configcenter/src/common/http/rest"
+	"configcenter/src/kube/converter"
+	"configcenter/src/kube/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// podsWorkloadName is the name of the pods workload that the pods without a supported workload owner belong to
+const podsWorkloadName = "pods"
+
+// workloadKinds is the workload kinds in the order that they are synchronized
+var workloadKinds = []types.WorkloadType{types.KubeDeployment, types.KubeStatefulSet, types.KubeDaemonSet,
+	types.KubeGameDeployment, types.KubeGameStatefulSet, types.KubeCronJob, types.KubeJob, types.KubePodWorkload}
+
+// reconciler reconciles the cluster resources in the informer caches into cmdb, the resources are created and
+// updated from top to bottom, and the stale resources are deleted from bottom to top.
+type reconciler struct {
+	*clusterSyncer
+	kit   *rest.Kit
+	stats types.SyncStats
+
+	// nodes is the cmdb nodes of the cluster by name
+	nodes map[string]types.Node
+	// namespaces is the cmdb namespaces of the cluster by name
+	namespaces map[string]types.Namespace
+	// workloads is the cmdb workload ids of each kind by the workload key
+	workloads map[types.WorkloadType]map[string]int64
+	// kubePods is the scheduled pods of the cluster
+	kubePods []*corev1.Pod
+	// podRefs is the workload of each kube pod by the pod key
+	podRefs map[string]types.Reference
+
+	staleNodes      []int64
+	staleNamespaces []int64
+	staleWorkloads  map[types.WorkloadType][]int64
+}
+
+// sync reconciles the cluster resources into cmdb once
+func (s *clusterSyncer) sync(ctx context.Context) (types.SyncStats, error) {
+	r := &reconciler{
+		clusterSyncer:  s,
+		kit:            s.newKit(s.cluster.SupplierAccount),
+		workloads:      make(map[types.WorkloadType]map[string]int64),
+		podRefs:        make(map[string]types.Reference),
+		staleWorkloads: make(map[types.WorkloadType][]int64),
+	}
+
+	steps := []func() error{r.syncNodes, r.syncNamespaces, r.syncWorkloads, r.syncPods, r.deleteStale}
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return r.stats, err
+		}
+		if err := step(); err != nil {
+			return r.stats, err
+		}
+	}
+	return r.stats, nil
+}
+
+// workloadKey returns the key of the namespaced resource
+func workloadKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// forEachBatch calls the handler with the [start, end) of each batch of the total items
+func forEachBatch(total int, handler func(start, end int) error) error {
+	for start := 0; start < total; start += syncBatchSize {
+		end := start + syncBatchSize
+		if end > total {
+			end = total
+		}
+		if err := handler(start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reconciler) syncNodes() error {
+	kubeNodes, err := r.listers.node.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	if err := r.listNodes(); err != nil {
+		return err
+	}
+
+	exists := make(map[string]struct{})
+	toCreate := make([]*types.Node, 0)
+	ips := make([]string, 0)
+	for _, kubeNode := range kubeNodes {
+		exists[kubeNode.Name] = struct{}{}
+		desired := converter.Node(kubeNode)
+
+		current, ok := r.nodes[kubeNode.Name]
+		if !ok {
+			toCreate = append(toCreate, desired)
+			ips = append(ips, *desired.InternalIP...)
+			continue
+		}
+
+		data := nodeUpdateData(desired)
+		if !converter.NeedUpdate(data, nodeUpdateData(&current)) {
+			continue
+		}
+		r.limiter.Accept()
+		if err := r.store.UpdateNode(r.kit, r.bizID, current.ID, data); err != nil {
+			return fmt.Errorf("update node %s failed, err: %v", kubeNode.Name, err)
+		}
+		r.stats.Updated++
+	}
+
+	for name, node := range r.nodes {
+		if _, ok := exists[name]; !ok {
+			r.staleNodes = append(r.staleNodes, node.ID)
+		}
+	}
+
+	if len(toCreate) == 0 {
+		return nil
+	}
+
+	// the nodes are related to the hosts in the business of the cluster by their internal ips
+	hostIDs, err := r.store.ListHostIDsByIP(r.kit, r.bizID, ips)
+	if err != nil {
+		return fmt.Errorf("list hosts by node ips failed, err: %v", err)
+	}
+
+	options := make([]types.OneNodeCreateOption, 0)
+	for _, node := range toCreate {
+		var hostID int64
+		for _, ip := range *node.InternalIP {
+			if id, ok := hostIDs[ip]; ok {
+				hostID = id
+				break
+			}
+		}
+		if hostID == 0 {
+			r.stats.Skipped++
+			continue
+		}
+		options = append(options, types.OneNodeCreateOption{HostID: hostID, ClusterID: r.cluster.ID, Node: *node})
+	}
+
+	if len(options) == 0 {
+		return nil
+	}
+
+	err = forEachBatch(len(options), func(start, end int) error {
+		r.limiter.Accept()
+		if err := r.store.CreateNodes(r.kit, r.bizID, options[start:end]); err != nil {
+			return fmt.Errorf("create nodes failed, err: %v", err)
+		}
+		r.stats.Created += int64(end - start)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.listNodes()
+}
+
+func (r *reconciler) listNodes() error {
+	nodes, err := r.store.ListNodes(r.kit, r.bizID, r.cluster.ID)
+	if err != nil {
+		return fmt.Errorf("list nodes failed, err: %v", err)
+	}
+
+	r.nodes = make(map[string]types.Node, len(nodes))
+	for _, node := range nodes {
+		if node.Name != nil {
+			r.nodes[*node.Name] = node
+		}
+	}
+	return nil
+}
+
+func (r *reconciler) syncNamespaces() error {
+	kubeNamespaces, err := r.listers.namespace.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	kubeQuotas, err := r.listers.resourceQuota.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	quotas := make(map[string][]*corev1.ResourceQuota)
+	for _, quota := range kubeQuotas {
+		quotas[quota.Namespace] = append(quotas[quota.Namespace], quota)
+	}
+
+	if err := r.listNamespaces(); err != nil {
+		return err
+	}
+
+	exists := make(map[string]struct{})
+	toCreate := make([]types.Namespace, 0)
+	for _, kubeNamespace := range kubeNamespaces {
+		exists[kubeNamespace.Name] = struct{}{}
+		desired := converter.Namespace(kubeNamespace, quotas[kubeNamespace.Name])
+
+		current, ok := r.namespaces[kubeNamespace.Name]
+		if !ok {
+			desired.ClusterSpec = types.ClusterSpec{BizID: r.bizID, ClusterID: r.cluster.ID, ClusterUID: r.clusterUID}
+			toCreate = append(toCreate, *desired)
+			continue
+		}
+
+		data := namespaceUpdateData(desired)
+		if !converter.NeedUpdate(data, namespaceUpdateData(&current)) {
+			continue
+		}
+		r.limiter.Accept()
+		if err := r.store.UpdateNamespace(r.kit, r.bizID, current.ID, data); err != nil {
+			return fmt.Errorf("update namespace %s failed, err: %v", kubeNamespace.Name, err)
+		}
+		r.stats.Updated++
+	}
+
+	for name, namespace := range r.namespaces {
+		if _, ok := exists[name]; !ok {
+			r.staleNamespaces = append(r.staleNamespaces, namespace.ID)
+		}
+	}
+
+	if len(toCreate) == 0 {
+		return nil
+	}
+
+	err = forEachBatch(len(toCreate), func(start, end int) error {
+		r.limiter.Accept()
+		if err := r.store.CreateNamespaces(r.kit, r.bizID, toCreate[start:end]); err != nil {
+			return fmt.Errorf("create namespaces failed, err: %v", err)
+		}
+		r.stats.Created += int64(end - start)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.listNamespaces()
+}
+
+func (r *reconciler) listNamespaces() error {
+	namespaces, err := r.store.ListNamespaces(r.kit, r.bizID, r.cluster.ID)
+	if err != nil {
+		return fmt.Errorf("list namespaces failed, err: %v", err)
+	}
+
+	r.namespaces = make(map[string]types.Namespace, len(namespaces))
+	for _, namespace := range namespaces {
+		r.namespaces[namespace.Name] = namespace
+	}
+	return nil
+}
+
+// supportsKind checks if the workload kind is collected from the cluster
+func (r *reconciler) supportsKind(kind types.WorkloadType) bool {
+	switch kind {
+	case types.KubeCronJob:
+		return r.listers.cronJob != nil
+	case types.KubeGameDeployment, types.KubeGameStatefulSet:
+		_, ok := r.listers.game[kind]
+		return ok
+	default:
+		return true
+	}
+}
+
+// resolvePodRef returns the workload that the pod belongs to, the pods whose owner is not a collected workload
+// belong to the pods workload of their namespace.
+func (r *reconciler) resolvePodRef(pod *corev1.Pod) types.Reference {
+	podsWorkload := types.Reference{Kind: types.KubePodWorkload, Name: podsWorkloadName}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return podsWorkload
+	}
+
+	var kind types.WorkloadType
+	name := owner.Name
+	switch owner.Kind {
+	case "ReplicaSet":
+		replicaSet, err := r.listers.replicaSet.ReplicaSets(pod.Namespace).Get(owner.Name)
+		if err != nil {
+			return podsWorkload
+		}
+		rsOwner := metav1.GetControllerOf(replicaSet)
+		if rsOwner == nil || rsOwner.Kind != "Deployment" {
+			return podsWorkload
+		}
+		kind, name = types.KubeDeployment, rsOwner.Name
+	case "StatefulSet":
+		kind = types.KubeStatefulSet
+	case "DaemonSet":
+		kind = types.KubeDaemonSet
+	case "Job":
+		kind = types.KubeJob
+	case "GameDeployment":
+		kind = types.KubeGameDeployment
+	case "GameStatefulSet":
+		kind = types.KubeGameStatefulSet
+	default:
+		return podsWorkload
+	}
+
+	if !r.supportsKind(kind) {
+		return podsWorkload
+	}
+	return types.Reference{Kind: kind, Name: name}
+}
+
+// desiredWorkloads returns the workloads of each kind in the cluster by the workload key
+func (r *reconciler) desiredWorkloads() (map[types.WorkloadType]map[string]types.WorkloadInterface, error) {
+	desired := make(map[types.WorkloadType]map[string]types.WorkloadInterface)
+	for _, kind := range workloadKinds {
+		desired[kind] = make(map[string]types.WorkloadInterface)
+	}
+
+	deployments, err := r.listers.deployment.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, deploy := range deployments {
+		desired[types.KubeDeployment][workloadKey(deploy.Namespace, deploy.Name)] = converter.Deployment(deploy)
+	}
+
+	statefulSets, err := r.listers.statefulSet.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, sts := range statefulSets {
+		desired[types.KubeStatefulSet][workloadKey(sts.Namespace, sts.Name)] = converter.StatefulSet(sts)
+	}
+
+	daemonSets, err := r.listers.daemonSet.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range daemonSets {
+		desired[types.KubeDaemonSet][workloadKey(ds.Namespace, ds.Name)] = converter.DaemonSet(ds)
+	}
+
+	jobs, err := r.listers.job.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		desired[types.KubeJob][workloadKey(job.Namespace, job.Name)] = converter.Job(job)
+	}
+
+	if r.listers.cronJob != nil {
+		cronJobs, err := r.listers.cronJob.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, cronJob := range cronJobs {
+			desired[types.KubeCronJob][workloadKey(cronJob.Namespace, cronJob.Name)] = converter.CronJob(cronJob)
+		}
+	}
+
+	for kind, lister := range r.listers.game {
+		objects, err := lister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			obj, ok := object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+
+			key := workloadKey(obj.GetNamespace(), obj.GetName())
+			if kind == types.KubeGameDeployment {
+				desired[kind][key] = converter.GameDeployment(obj)
+			} else {
+				desired[kind][key] = converter.GameStatefulSet(obj)
+			}
+		}
+	}
+
+	// the pods workload of a namespace exists when there are pods that belong to it
+	for _, pod := range r.kubePods {
+		ref := r.resolvePodRef(pod)
+		r.podRefs[workloadKey(pod.Namespace, pod.Name)] = ref
+		if ref.Kind == types.KubePodWorkload {
+			desired[types.KubePodWorkload][workloadKey(pod.Namespace, ref.Name)] = new(types.PodsWorkload)
+		}
+	}
+	return desired, nil
+}
+
+func (r *reconciler) syncWorkloads() error {
+	pods, err := r.listers.pod.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			r.kubePods = append(r.kubePods, pod)
+		}
+	}
+
+	desired, err := r.desiredWorkloads()
+	if err != nil {
+		return err
+	}
+
+	for _, kind := range workloadKinds {
+		if !r.supportsKind(kind) {
+			continue
+		}
+		if err := r.syncWorkloadKind(kind, desired[kind]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reconciler) syncWorkloadKind(kind types.WorkloadType, desired map[string]types.WorkloadInterface) error {
+	current, err := r.listWorkloads(kind)
+	if err != nil {
+		return err
+	}
+
+	r.workloads[kind] = make(map[string]int64, len(current))
+	for key, workload := range current {
+		if _, ok := desired[key]; !ok {
+			r.staleWorkloads[kind] = append(r.staleWorkloads[kind], workload.GetWorkloadBase().ID)
+			continue
+		}
+		r.workloads[kind][key] = workload.GetWorkloadBase().ID
+	}
+
+	toCreate := make([]types.WorkloadInterface, 0)
+	for key, workload := range desired {
+		if cur, ok := current[key]; ok {
+			// compare the fields except the base info, which can not be updated
+			id := cur.GetWorkloadBase().ID
+			cur.SetWorkloadBase(types.WorkloadBase{})
+			if !converter.NeedUpdate(workload, cur) {
+				continue
+			}
+
+			r.limiter.Accept()
+			if err := r.store.UpdateWorkload(r.kit, r.bizID, kind, id, workload); err != nil {
+				return fmt.Errorf("update %s %s failed, err: %v", kind, key, err)
+			}
+			r.stats.Updated++
+			continue
+		}
+
+		namespaceName, name := splitWorkloadKey(key)
+		namespace, ok := r.namespaces[namespaceName]
+		if !ok {
+			r.stats.Skipped++
+			continue
+		}
+		workload.SetWorkloadBase(types.WorkloadBase{
+			NamespaceSpec: types.NamespaceSpec{
+				ClusterSpec: types.ClusterSpec{BizID: r.bizID, ClusterID: r.cluster.ID, ClusterUID: r.clusterUID},
+				NamespaceID: namespace.ID,
+				Namespace:   namespace.Name,
+			},
+			Name: name,
+		})
+		toCreate = append(toCreate, workload)
+	}
+
+	if len(toCreate) == 0 {
+		return nil
+	}
+
+	err = forEachBatch(len(toCreate), func(start, end int) error {
+		r.limiter.Accept()
+		if err := r.store.CreateWorkloads(r.kit, r.bizID, kind, toCreate[start:end]); err != nil {
+			return fmt.Errorf("create %s failed, err: %v", kind, err)
+		}
+		r.stats.Created += int64(end - start)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	created, err := r.listWorkloads(kind)
+	if err != nil {
+		return err
+	}
+	for key, workload := range created {
+		if _, ok := desired[key]; ok {
+			r.workloads[kind][key] = workload.GetWorkloadBase().ID
+		}
+	}
+	return nil
+}
+
+// listWorkloads returns the cmdb workloads of the kind in the cluster by the workload key
+func (r *reconciler) listWorkloads(kind types.WorkloadType) (map[string]types.WorkloadInterface, error) {
+	workloads, err := r.store.ListWorkloads(r.kit, r.bizID, r.cluster.ID, kind)
+	if err != nil {
+		return nil, fmt.Errorf("list %s failed, err: %v", kind, err)
+	}
+
+	result := make(map[string]types.WorkloadInterface, len(workloads))
+	for _, workload := range workloads {
+		base := workload.GetWorkloadBase()
+		result[workloadKey(base.Namespace, base.Name)] = workload
+	}
+	return result, nil
+}
+
+// splitWorkloadKey returns the namespace and name of the workload key
+func splitWorkloadKey(key string) (string, string) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return "", key
+	}
+	return parts[0], parts[1]
+}
+
+// buildPodInfo builds the pod create info with its cmdb relations, returns false if the relations are not in cmdb
+func (r *reconciler) buildPodInfo(pod *corev1.Pod) (types.PodsInfo, bool) {
+	node, ok := r.nodes[pod.Spec.NodeName]
+	if !ok {
+		return types.PodsInfo{}, false
+	}
+
+	namespace, ok := r.namespaces[pod.Namespace]
+	if !ok {
+		return types.PodsInfo{}, false
+	}
+
+	ref := r.podRefs[workloadKey(pod.Namespace, pod.Name)]
+	ref.ID, ok = r.workloads[ref.Kind][workloadKey(pod.Namespace, ref.Name)]
+	if !ok {
+		return types.PodsInfo{}, false
+	}
+
+	kubePod, containers := converter.Pod(pod)
+	return types.PodsInfo{
+		Spec: types.SpecSimpleInfo{
+			ClusterID:   r.cluster.ID,
+			NamespaceID: namespace.ID,
+			Ref:         ref,
+			NodeID:      node.ID,
+		},
+		HostID:     node.HostID,
+		Pod:        kubePod,
+		Containers: containers,
+	}, true
+}
+
+func (r *reconciler) syncPods() error {
+	pods, err := r.store.ListPods(r.kit, r.bizID, r.cluster.ID)
+	if err != nil {
+		return fmt.Errorf("list pods failed, err: %v", err)
+	}
+
+	current := make(map[string]*types.Pod, len(pods))
+	podIDs := make([]int64, 0, len(pods))
+	for idx := range pods {
+		if pods[idx].Name == nil {
+			continue
+		}
+		current[workloadKey(pods[idx].Namespace, *pods[idx].Name)] = &pods[idx]
+		podIDs = append(podIDs, pods[idx].ID)
+	}
+
+	containers, err := r.store.ListContainers(r.kit, podIDs)
+	if err != nil {
+		return fmt.Errorf("list containers failed, err: %v", err)
+	}
+
+	exists := make(map[string]struct{})
+	toCreate := make([]types.PodsInfo, 0)
+	toDelete := make([]int64, 0)
+	var recreated int64
+	for _, pod := range r.kubePods {
+		info, ok := r.buildPodInfo(pod)
+		if !ok {
+			r.stats.Skipped++
+			continue
+		}
+
+		key := workloadKey(pod.Namespace, pod.Name)
+		exists[key] = struct{}{}
+		cur, ok := current[key]
+		if ok {
+			if converter.SamePod(cur, containers[cur.ID], &info) {
+				continue
+			}
+			toDelete = append(toDelete, cur.ID)
+			recreated++
+		}
+		toCreate = append(toCreate, info)
+	}
+
+	for key, pod := range current {
+		if _, ok := exists[key]; !ok {
+			toDelete = append(toDelete, pod.ID)
+		}
+	}
+
+	// delete the pods to be recreated before creating them, since the pods are unique in the workload
+	err = forEachBatch(len(toDelete), func(start, end int) error {
+		r.limiter.Accept()
+		if err := r.store.DeletePods(r.kit, r.bizID, toDelete[start:end]); err != nil {
+			return fmt.Errorf("delete pods failed, err: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = forEachBatch(len(toCreate), func(start, end int) error {
+		r.limiter.Accept()
+		if err := r.store.CreatePods(r.kit, r.bizID, toCreate[start:end]); err != nil {
+			return fmt.Errorf("create pods failed, err: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.stats.Created += int64(len(toCreate)) - recreated
+	r.stats.Updated += recreated
+	r.stats.Deleted += int64(len(toDelete)) - recreated
+	return nil
+}
+
+// deleteStale deletes the stale workloads, namespaces and nodes, the stale pods are deleted when syncing pods
+func (r *reconciler) deleteStale() error {
+	for idx := len(workloadKinds) - 1; idx >= 0; idx-- {
+		kind := workloadKinds[idx]
+		ids := r.staleWorkloads[kind]
+		err := forEachBatch(len(ids), func(start, end int) error {
+			r.limiter.Accept()
+			if err := r.store.DeleteWorkloads(r.kit, r.bizID, kind, ids[start:end]); err != nil {
+				return fmt.Errorf("delete %s failed, err: %v", kind, err)
+			}
+			r.stats.Deleted += int64(end - start)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := forEachBatch(len(r.staleNamespaces), func(start, end int) error {
+		r.limiter.Accept()
+		if err := r.store.DeleteNamespaces(r.kit, r.bizID, r.staleNamespaces[start:end]); err != nil {
+			return fmt.Errorf("delete namespaces failed, err: %v", err)
+		}
+		r.stats.Deleted += int64(end - start)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return forEachBatch(len(r.staleNodes), func(start, end int) error {
+		r.limiter.Accept()
+		if err := r.store.DeleteNodes(r.kit, r.bizID, r.staleNodes[start:end]); err != nil {
+			return fmt.Errorf("delete nodes failed, err: %v", err)
+		}
+		r.stats.Deleted += int64(end - start)
+		return nil
+	})
+}
@@ -0,0 +1,356 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubecollector
+
+import (
+	"strings"
+
+	"configcenter/src/apimachinery"
+	"configcenter/src/common"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/querybuilder"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+)
+
+// Store is the cmdb operations used by the collector, the resources of a cluster are read from core service and
+// written by the topo server kube apis, so that they are validated and audited as the resources written by users.
+type Store interface {
+	// ListClusters list all the registered clusters
+	ListClusters(kit *rest.Kit) ([]types.Cluster, error)
+	// SaveSyncStatus save the sync status of a cluster
+	SaveSyncStatus(kit *rest.Kit, status *types.ClusterSyncStatus) error
+	// ListHostIDsByIP list the ids of the hosts in the business by their inner ips, returns the map of ip to host id
+	ListHostIDsByIP(kit *rest.Kit, bizID int64, ips []string) (map[string]int64, error)
+
+	ListNodes(kit *rest.Kit, bizID, clusterID int64) ([]types.Node, error)
+	CreateNodes(kit *rest.Kit, bizID int64, nodes []types.OneNodeCreateOption) error
+	UpdateNode(kit *rest.Kit, bizID, id int64, node *types.Node) error
+	DeleteNodes(kit *rest.Kit, bizID int64, ids []int64) error
+
+	ListNamespaces(kit *rest.Kit, bizID, clusterID int64) ([]types.Namespace, error)
+	CreateNamespaces(kit *rest.Kit, bizID int64, namespaces []types.Namespace) error
+	UpdateNamespace(kit *rest.Kit, bizID, id int64, namespace *types.Namespace) error
+	DeleteNamespaces(kit *rest.Kit, bizID int64, ids []int64) error
+
+	ListWorkloads(kit *rest.Kit, bizID, clusterID int64, kind types.WorkloadType) ([]types.WorkloadInterface, error)
+	CreateWorkloads(kit *rest.Kit, bizID int64, kind types.WorkloadType, workloads []types.WorkloadInterface) error
+	UpdateWorkload(kit *rest.Kit, bizID int64, kind types.WorkloadType, id int64,
+		workload types.WorkloadInterface) error
+	DeleteWorkloads(kit *rest.Kit, bizID int64, kind types.WorkloadType, ids []int64) error
+
+	ListPods(kit *rest.Kit, bizID, clusterID int64) ([]types.Pod, error)
+	// ListContainers list the containers of the pods, returns the map of pod id to its containers
+	ListContainers(kit *rest.Kit, podIDs []int64) (map[int64][]types.Container, error)
+	CreatePods(kit *rest.Kit, bizID int64, pods []types.PodsInfo) error
+	DeletePods(kit *rest.Kit, bizID int64, ids []int64) error
+}
+
+// NewStore new a Store by the api client set
+func NewStore(clientSet apimachinery.ClientSetInterface) Store {
+	return &cmdbStore{clientSet: clientSet}
+}
+
+type cmdbStore struct {
+	clientSet apimachinery.ClientSetInterface
+}
+
+// pageLimit is the page size of the list requests to core service
+const pageLimit = 500
+
+// clusterCond returns the condition of the resources in a cluster
+func clusterCond(bizID, clusterID int64) mapstr.MapStr {
+	return mapstr.MapStr{common.BKAppIDField: bizID, types.BKClusterIDFiled: clusterID}
+}
+
+// ListClusters list all the registered clusters
+func (s *cmdbStore) ListClusters(kit *rest.Kit) ([]types.Cluster, error) {
+	fields := []string{types.BKIDField, types.BKBizIDField, types.UidField, common.BkSupplierAccount}
+	clusters := make([]types.Cluster, 0)
+	for start := 0; ; start += pageLimit {
+		query := &metadata.QueryCondition{
+			Condition:      mapstr.MapStr{},
+			Fields:         fields,
+			Page:           metadata.BasePage{Start: start, Limit: pageLimit, Sort: types.BKIDField},
+			DisableCounter: true,
+		}
+		result, err := s.clientSet.CoreService().Kube().SearchCluster(kit.Ctx, kit.Header, query)
+		if err != nil {
+			return nil, err
+		}
+
+		clusters = append(clusters, result.Data...)
+		if len(result.Data) < pageLimit {
+			return clusters, nil
+		}
+	}
+}
+
+// SaveSyncStatus save the sync status of a cluster
+func (s *cmdbStore) SaveSyncStatus(kit *rest.Kit, status *types.ClusterSyncStatus) error {
+	return s.clientSet.CoreService().Kube().SaveClusterSyncStatus(kit.Ctx, kit.Header, status)
+}
+
+// ListHostIDsByIP list the ids of the hosts in the business by their inner ips
+func (s *cmdbStore) ListHostIDsByIP(kit *rest.Kit, bizID int64, ips []string) (map[string]int64, error) {
+	hostIDs := make(map[string]int64)
+	for start := 0; start < len(ips); start += common.BKMaxPageSize {
+		end := start + common.BKMaxPageSize
+		if end > len(ips) {
+			end = len(ips)
+		}
+
+		opt := &metadata.ListHosts{
+			BizID: bizID,
+			HostPropertyFilter: &querybuilder.QueryFilter{
+				Rule: querybuilder.AtomRule{
+					Field:    common.BKHostInnerIPField,
+					Operator: querybuilder.OperatorIn,
+					Value:    ips[start:end],
+				},
+			},
+			Fields: []string{common.BKHostIDField, common.BKHostInnerIPField},
+			Page:   metadata.BasePage{Limit: common.BKMaxPageSize},
+		}
+		result, err := s.clientSet.CoreService().Host().ListHosts(kit.Ctx, kit.Header, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, host := range result.Info {
+			hostID, err := util.GetInt64ByInterface(host[common.BKHostIDField])
+			if err != nil {
+				return nil, err
+			}
+			for _, ip := range parseHostIPs(host[common.BKHostInnerIPField]) {
+				hostIDs[ip] = hostID
+			}
+		}
+	}
+	return hostIDs, nil
+}
+
+// parseHostIPs parse the ips of the host ip field, which is an array in db and a comma separated string in api
+func parseHostIPs(value interface{}) []string {
+	switch ips := value.(type) {
+	case string:
+		return strings.Split(ips, ",")
+	case []string:
+		return ips
+	case []interface{}:
+		result := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			result = append(result, util.GetStrByInterface(ip))
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// ListNodes list the nodes of a cluster
+func (s *cmdbStore) ListNodes(kit *rest.Kit, bizID, clusterID int64) ([]types.Node, error) {
+	nodes := make([]types.Node, 0)
+	for start := 0; ; start += pageLimit {
+		query := &metadata.QueryCondition{
+			Condition:      clusterCond(bizID, clusterID),
+			Page:           metadata.BasePage{Start: start, Limit: pageLimit, Sort: types.BKIDField},
+			DisableCounter: true,
+		}
+		result, err := s.clientSet.CoreService().Kube().SearchNode(kit.Ctx, kit.Header, query)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, result.Data...)
+		if len(result.Data) < pageLimit {
+			return nodes, nil
+		}
+	}
+}
+
+// CreateNodes create nodes
+func (s *cmdbStore) CreateNodes(kit *rest.Kit, bizID int64, nodes []types.OneNodeCreateOption) error {
+	_, err := s.clientSet.TopoServer().Kube().BatchCreateNode(kit.Ctx, kit.Header, bizID,
+		&types.CreateNodesOption{Nodes: nodes})
+	return err
+}
+
+// UpdateNode update a node
+func (s *cmdbStore) UpdateNode(kit *rest.Kit, bizID, id int64, node *types.Node) error {
+	_, err := s.clientSet.TopoServer().Kube().UpdateNodeFields(kit.Ctx, kit.Header, bizID,
+		&types.UpdateNodeOption{IDs: []int64{id}, Data: *node})
+	return err
+}
+
+// DeleteNodes delete nodes
+func (s *cmdbStore) DeleteNodes(kit *rest.Kit, bizID int64, ids []int64) error {
+	_, err := s.clientSet.TopoServer().Kube().BatchDeleteNode(kit.Ctx, kit.Header, bizID,
+		&types.BatchDeleteNodeOption{IDs: ids})
+	return err
+}
+
+// ListNamespaces list the namespaces of a cluster
+func (s *cmdbStore) ListNamespaces(kit *rest.Kit, bizID, clusterID int64) ([]types.Namespace, error) {
+	namespaces := make([]types.Namespace, 0)
+	for start := 0; ; start += pageLimit {
+		query := &metadata.QueryCondition{
+			Condition:      clusterCond(bizID, clusterID),
+			Page:           metadata.BasePage{Start: start, Limit: pageLimit, Sort: types.BKIDField},
+			DisableCounter: true,
+		}
+		result, err := s.clientSet.CoreService().Kube().ListNamespace(kit.Ctx, kit.Header, query)
+		if err != nil {
+			return nil, err
+		}
+
+		namespaces = append(namespaces, result.Data...)
+		if len(result.Data) < pageLimit {
+			return namespaces, nil
+		}
+	}
+}
+
+// CreateNamespaces create namespaces
+func (s *cmdbStore) CreateNamespaces(kit *rest.Kit, bizID int64, namespaces []types.Namespace) error {
+	_, err := s.clientSet.TopoServer().Kube().CreateNamespace(kit.Ctx, kit.Header, bizID,
+		&types.NsCreateOption{Data: namespaces})
+	return err
+}
+
+// UpdateNamespace update a namespace
+func (s *cmdbStore) UpdateNamespace(kit *rest.Kit, bizID, id int64, namespace *types.Namespace) error {
+	return s.clientSet.TopoServer().Kube().UpdateNamespace(kit.Ctx, kit.Header, bizID,
+		&types.NsUpdateOption{IDs: []int64{id}, Data: namespace})
+}
+
+// DeleteNamespaces delete namespaces
+func (s *cmdbStore) DeleteNamespaces(kit *rest.Kit, bizID int64, ids []int64) error {
+	return s.clientSet.TopoServer().Kube().DeleteNamespace(kit.Ctx, kit.Header, bizID,
+		&types.NsDeleteOption{IDs: ids})
+}
+
+// ListWorkloads list the workloads of a kind in a cluster
+func (s *cmdbStore) ListWorkloads(kit *rest.Kit, bizID, clusterID int64, kind types.WorkloadType) (
+	[]types.WorkloadInterface, error) {
+
+	workloads := make([]types.WorkloadInterface, 0)
+	for start := 0; ; start += pageLimit {
+		query := &metadata.QueryCondition{
+			Condition:      clusterCond(bizID, clusterID),
+			Page:           metadata.BasePage{Start: start, Limit: pageLimit, Sort: types.BKIDField},
+			DisableCounter: true,
+		}
+		result, err := s.clientSet.CoreService().Kube().ListWorkload(kit.Ctx, kit.Header, query, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		workloads = append(workloads, result.Info...)
+		if len(result.Info) < pageLimit {
+			return workloads, nil
+		}
+	}
+}
+
+// CreateWorkloads create workloads of a kind
+func (s *cmdbStore) CreateWorkloads(kit *rest.Kit, bizID int64, kind types.WorkloadType,
+	workloads []types.WorkloadInterface) error {
+
+	_, err := s.clientSet.TopoServer().Kube().CreateWorkload(kit.Ctx, kit.Header, bizID, kind,
+		&types.WlCreateOption{Kind: kind, Data: workloads})
+	return err
+}
+
+// UpdateWorkload update a workload
+func (s *cmdbStore) UpdateWorkload(kit *rest.Kit, bizID int64, kind types.WorkloadType, id int64,
+	workload types.WorkloadInterface) error {
+
+	return s.clientSet.TopoServer().Kube().UpdateWorkload(kit.Ctx, kit.Header, bizID, kind,
+		&types.WlUpdateOption{Kind: kind, IDs: []int64{id}, Data: workload})
+}
+
+// DeleteWorkloads delete workloads of a kind
+func (s *cmdbStore) DeleteWorkloads(kit *rest.Kit, bizID int64, kind types.WorkloadType, ids []int64) error {
+	return s.clientSet.TopoServer().Kube().DeleteWorkload(kit.Ctx, kit.Header, bizID, kind,
+		&types.WlDeleteOption{IDs: ids})
+}
+
+// ListPods list the pods of a cluster
+func (s *cmdbStore) ListPods(kit *rest.Kit, bizID, clusterID int64) ([]types.Pod, error) {
+	pods := make([]types.Pod, 0)
+	for start := 0; ; start += pageLimit {
+		query := &metadata.QueryCondition{
+			Condition:      clusterCond(bizID, clusterID),
+			Page:           metadata.BasePage{Start: start, Limit: pageLimit, Sort: types.BKIDField},
+			DisableCounter: true,
+		}
+		result, err := s.clientSet.CoreService().Kube().ListPod(kit.Ctx, kit.Header, query)
+		if err != nil {
+			return nil, err
+		}
+
+		pods = append(pods, result.Info...)
+		if len(result.Info) < pageLimit {
+			return pods, nil
+		}
+	}
+}
+
+// ListContainers list the containers of the pods
+func (s *cmdbStore) ListContainers(kit *rest.Kit, podIDs []int64) (map[int64][]types.Container, error) {
+	containers := make(map[int64][]types.Container)
+	for start := 0; start < len(podIDs); start += pageLimit {
+		end := start + pageLimit
+		if end > len(podIDs) {
+			end = len(podIDs)
+		}
+
+		// page by container id, the pods in one page may have more containers than the page limit
+		for containerStart := 0; ; containerStart += pageLimit {
+			query := &metadata.QueryCondition{
+				Condition: mapstr.MapStr{types.BKPodIDField: mapstr.MapStr{common.BKDBIN: podIDs[start:end]}},
+				Page: metadata.BasePage{Start: containerStart, Limit: pageLimit,
+					Sort: types.BKIDField},
+				DisableCounter: true,
+			}
+			result, err := s.clientSet.CoreService().Kube().ListContainer(kit.Ctx, kit.Header, query)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, container := range result.Info {
+				containers[container.PodID] = append(containers[container.PodID], container)
+			}
+			if len(result.Info) < pageLimit {
+				break
+			}
+		}
+	}
+	return containers, nil
+}
+
+// CreatePods create pods with their containers
+func (s *cmdbStore) CreatePods(kit *rest.Kit, bizID int64, pods []types.PodsInfo) error {
+	opt := &types.CreatePodsOption{Data: []types.PodsInfoArray{{BizID: bizID, Pods: pods}}}
+	_, err := s.clientSet.TopoServer().Kube().BatchCreatePod(kit.Ctx, kit.Header, opt)
+	return err
+}
+
+// DeletePods delete pods with their containers
+func (s *cmdbStore) DeletePods(kit *rest.Kit, bizID int64, ids []int64) error {
+	opt := &types.DeletePodsOption{Data: []types.DeletePodData{{BizID: bizID, PodIDs: ids}}}
+	return s.clientSet.TopoServer().Kube().BatchDeletePod(kit.Ctx, kit.Header, opt)
+}
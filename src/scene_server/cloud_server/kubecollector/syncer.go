@@ -0,0 +1,272 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubecollector
+
+import (
+	"context"
+	"time"
+
+	"configcenter/src/apimachinery/flowctrl"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/kube/types"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	// gameWorkloadGroupVersion is the group version of the game workloads, they are custom resources of bcs
+	gameWorkloadGroupVersion = schema.GroupVersion{Group: "tkex.tencent.com", Version: "v1alpha1"}
+	// gameWorkloadResources is the resource names of the game workloads
+	gameWorkloadResources = map[types.WorkloadType]string{
+		types.KubeGameDeployment:  "gamedeployments",
+		types.KubeGameStatefulSet: "gamestatefulsets",
+	}
+)
+
+const cronJobResource = "cronjobs"
+
+// clusterSyncer list-watches the resources of one cluster and reconciles them into cmdb
+type clusterSyncer struct {
+	cluster    types.Cluster
+	clusterUID string
+	bizID      int64
+
+	clientSet    *ClientSet
+	store        Store
+	limiter      flowctrl.RateLimiter
+	resyncPeriod time.Duration
+	newKit       func(supplierAccount string) *rest.Kit
+
+	listers *listers
+	// trigger is notified by the resource events, multiple events before the next reconciliation are merged
+	trigger chan struct{}
+	// lastSuccessTime is the time when the last successful reconciliation finished
+	lastSuccessTime time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// listers is the listers of the cluster resources that are used in the reconciliation
+type listers struct {
+	node          corelisters.NodeLister
+	namespace     corelisters.NamespaceLister
+	resourceQuota corelisters.ResourceQuotaLister
+	pod           corelisters.PodLister
+	deployment    appslisters.DeploymentLister
+	replicaSet    appslisters.ReplicaSetLister
+	statefulSet   appslisters.StatefulSetLister
+	daemonSet     appslisters.DaemonSetLister
+	job           batchlisters.JobLister
+	// cronJob is nil when the cluster does not support the batch/v1 cronjobs
+	cronJob batchlisters.CronJobLister
+	// game is the listers of the game workloads that the cluster supports
+	game map[types.WorkloadType]cache.GenericLister
+}
+
+func newClusterSyncer(cluster *types.Cluster, clientSet *ClientSet, store Store, limiter flowctrl.RateLimiter,
+	resyncPeriod time.Duration, newKit func(supplierAccount string) *rest.Kit) *clusterSyncer {
+
+	return &clusterSyncer{
+		cluster:      *cluster,
+		clusterUID:   *cluster.Uid,
+		bizID:        cluster.BizID,
+		clientSet:    clientSet,
+		store:        store,
+		limiter:      limiter,
+		resyncPeriod: resyncPeriod,
+		newKit:       newKit,
+		trigger:      make(chan struct{}, 1),
+		done:         make(chan struct{}),
+	}
+}
+
+// start starts to sync the cluster in background
+func (s *clusterSyncer) start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	go s.run(ctx)
+}
+
+// stop stops the syncing and waits for the running reconciliation to exit
+func (s *clusterSyncer) stop() {
+	s.cancel()
+	<-s.done
+}
+
+// notify triggers a reconciliation of the cluster
+func (s *clusterSyncer) notify() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (s *clusterSyncer) run(ctx context.Context) {
+	defer close(s.done)
+
+	blog.Infof("start syncing cluster %d(%s) of biz %d", s.cluster.ID, s.clusterUID, s.bizID)
+	s.saveStatus(types.SyncStatusSyncing, types.SyncStats{}, nil)
+
+	if err := s.startInformers(ctx); err != nil {
+		blog.Errorf("start cluster %d informers failed, err: %v", s.cluster.ID, err)
+		s.saveStatus(types.SyncStatusFailed, types.SyncStats{}, err)
+		return
+	}
+
+	ticker := time.NewTicker(s.resyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		stats, err := s.sync(ctx)
+		if ctx.Err() != nil {
+			blog.Infof("stop syncing cluster %d(%s)", s.cluster.ID, s.clusterUID)
+			return
+		}
+
+		if err != nil {
+			blog.Errorf("sync cluster %d failed, stats: %+v, err: %v", s.cluster.ID, stats, err)
+			s.saveStatus(types.SyncStatusFailed, stats, err)
+		} else {
+			s.saveStatus(types.SyncStatusSuccess, stats, nil)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(minSyncInterval):
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.trigger:
+		case <-ticker.C:
+		}
+	}
+}
+
+// startInformers starts the informers of the cluster resources and waits for their caches to be synced
+func (s *clusterSyncer) startInformers(ctx context.Context) error {
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { s.notify() },
+		UpdateFunc: func(interface{}, interface{}) { s.notify() },
+		DeleteFunc: func(interface{}) { s.notify() },
+	}
+
+	factory := informers.NewSharedInformerFactory(s.clientSet.Kube, 0)
+	s.listers = &listers{
+		node:          factory.Core().V1().Nodes().Lister(),
+		namespace:     factory.Core().V1().Namespaces().Lister(),
+		resourceQuota: factory.Core().V1().ResourceQuotas().Lister(),
+		pod:           factory.Core().V1().Pods().Lister(),
+		deployment:    factory.Apps().V1().Deployments().Lister(),
+		replicaSet:    factory.Apps().V1().ReplicaSets().Lister(),
+		statefulSet:   factory.Apps().V1().StatefulSets().Lister(),
+		daemonSet:     factory.Apps().V1().DaemonSets().Lister(),
+		job:           factory.Batch().V1().Jobs().Lister(),
+		game:          make(map[types.WorkloadType]cache.GenericLister),
+	}
+	if s.supportsResource("batch/v1", cronJobResource) {
+		s.listers.cronJob = factory.Batch().V1().CronJobs().Lister()
+	}
+
+	informerList := []cache.SharedIndexInformer{
+		factory.Core().V1().Nodes().Informer(),
+		factory.Core().V1().Namespaces().Informer(),
+		factory.Core().V1().ResourceQuotas().Informer(),
+		factory.Core().V1().Pods().Informer(),
+		factory.Apps().V1().Deployments().Informer(),
+		factory.Apps().V1().ReplicaSets().Informer(),
+		factory.Apps().V1().StatefulSets().Informer(),
+		factory.Apps().V1().DaemonSets().Informer(),
+		factory.Batch().V1().Jobs().Informer(),
+	}
+	if s.listers.cronJob != nil {
+		informerList = append(informerList, factory.Batch().V1().CronJobs().Informer())
+	}
+
+	dynamicFactory := dynamicinformer.NewDynamicSharedInformerFactory(s.clientSet.Dynamic, 0)
+	for kind, resource := range gameWorkloadResources {
+		if !s.supportsResource(gameWorkloadGroupVersion.String(), resource) {
+			continue
+		}
+		informer := dynamicFactory.ForResource(gameWorkloadGroupVersion.WithResource(resource))
+		s.listers.game[kind] = informer.Lister()
+		informerList = append(informerList, informer.Informer())
+	}
+
+	synced := make([]cache.InformerSynced, 0, len(informerList))
+	for _, informer := range informerList {
+		informer.AddEventHandler(handler)
+		synced = append(synced, informer.HasSynced)
+	}
+
+	factory.Start(ctx.Done())
+	dynamicFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// supportsResource checks if the cluster serves the resource of the group version
+func (s *clusterSyncer) supportsResource(groupVersion, resource string) bool {
+	resources, err := s.clientSet.Kube.Discovery().ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		blog.V(4).Infof("cluster %d does not support %s, err: %v", s.cluster.ID, groupVersion, err)
+		return false
+	}
+
+	for _, res := range resources.APIResources {
+		if res.Name == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// saveStatus saves the sync status of the cluster
+func (s *clusterSyncer) saveStatus(status types.SyncStatus, stats types.SyncStats, syncErr error) {
+
+	now := time.Now()
+	if status == types.SyncStatusSuccess {
+		s.lastSuccessTime = now
+	}
+
+	syncStatus := &types.ClusterSyncStatus{
+		ClusterSpec: types.ClusterSpec{
+			BizID:      s.bizID,
+			ClusterID:  s.cluster.ID,
+			ClusterUID: s.clusterUID,
+		},
+		Status:          status,
+		Stats:           stats,
+		LastSyncTime:    now,
+		LastSuccessTime: s.lastSuccessTime,
+	}
+	if syncErr != nil {
+		syncStatus.Message = syncErr.Error()
+	}
+
+	kit := s.newKit(s.cluster.SupplierAccount)
+	if err := s.store.SaveSyncStatus(kit, syncStatus); err != nil {
+		blog.Errorf("save cluster %d sync status failed, err: %v, rid: %s", s.cluster.ID, err, kit.Rid)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hostsnap
+
+import (
+	"strconv"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/json"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/redis"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// driftCacheExpire is the expiration of the cached drifts of a host, the drifts are refreshed in db when the
+	// cache is expired even if they are not changed, so that the last time of the drifts can be updated.
+	driftCacheExpire = time.Hour
+	// driftExpire is the expiration of the drifts in db, the drifts are removed if they are not refreshed by the
+	// snapshot for a long time, for example the host is deleted or the agent is uninstalled.
+	driftExpire = 24 * time.Hour
+)
+
+// getHostSnapDrifts compare the hardware and os fields of the host in cmdb with the setter parsed from the snapshot,
+// returns the fields whose value in cmdb differs from the agent reported value, including the fields that are
+// configured not to be overwritten by the snapshot.
+func getHostSnapDrifts(hostID int64, host string, setter map[string]interface{}) []metadata.HostSnapDrift {
+	drifts := make([]metadata.HostSnapDrift, 0)
+	elements := gjson.GetMany(host, compareFields...)
+	for idx, field := range compareFields {
+		agentData, exists := setter[field]
+		if !exists {
+			continue
+		}
+
+		cmdbData := elements[idx].Value()
+		if formatCompareValue(cmdbData) == formatCompareValue(agentData) {
+			continue
+		}
+
+		_, ignored := ignoreCompareField[field]
+		drifts = append(drifts, metadata.HostSnapDrift{
+			HostID:     hostID,
+			PropertyID: field,
+			AgentData:  agentData,
+			CmdbData:   cmdbData,
+			Ignored:    ignored,
+		})
+	}
+	return drifts
+}
+
+// getIgnoredDrifts returns the drifts of the fields that are not overwritten when the host is updated by snapshot
+func getIgnoredDrifts(drifts []metadata.HostSnapDrift) []metadata.HostSnapDrift {
+	ignoredDrifts := make([]metadata.HostSnapDrift, 0)
+	for _, drift := range drifts {
+		if drift.Ignored {
+			ignoredDrifts = append(ignoredDrifts, drift)
+		}
+	}
+	return ignoredDrifts
+}
+
+// saveHostSnapDrifts save the drifts of the host, the drifts that no longer exist are removed. the drifts are
+// cached in redis, so that the db is not written when the drifts are not changed.
+func (h *HostSnap) saveHostSnapDrifts(rid string, hostID int64, drifts []metadata.HostSnapDrift) error {
+	data, err := json.Marshal(drifts)
+	if err != nil {
+		blog.Errorf("marshal host %d drifts failed, drifts: %+v, err: %v, rid: %s", hostID, drifts, err, rid)
+		return err
+	}
+
+	key := common.RedisSnapDriftKeyPrefix + strconv.FormatInt(hostID, 10)
+	cached, err := h.redisCli.Get(h.ctx, key).Result()
+	if err != nil && !redis.IsNilErr(err) {
+		blog.Errorf("get host %d cached drifts from redis failed, key: %s, err: %v, rid: %s", hostID, key, err, rid)
+	}
+
+	if err == nil && cached == string(data) {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	propertyIDs := make([]string, 0)
+	for _, drift := range drifts {
+		drift.LastTime = now
+		drift.ExpireAt = now.Add(driftExpire)
+		drift.SupplierAccount = common.BKDefaultOwnerID
+
+		cond := mapstr.MapStr{
+			common.BKHostIDField:     hostID,
+			common.BKPropertyIDField: drift.PropertyID,
+		}
+		if err := h.db.Table(common.BKTableNameHostSnapDrift).Upsert(h.ctx, cond, drift); err != nil {
+			blog.Errorf("save host %d drift failed, drift: %+v, err: %v, rid: %s", hostID, drift, err, rid)
+			return err
+		}
+		propertyIDs = append(propertyIDs, drift.PropertyID)
+	}
+
+	// remove the drifts that are resolved
+	cond := mapstr.MapStr{
+		common.BKHostIDField:     hostID,
+		common.BKPropertyIDField: mapstr.MapStr{common.BKDBNIN: propertyIDs},
+	}
+	if err := h.db.Table(common.BKTableNameHostSnapDrift).Delete(h.ctx, cond); err != nil {
+		blog.Errorf("delete host %d resolved drifts failed, cond: %v, err: %v, rid: %s", hostID, cond, err, rid)
+		return err
+	}
+
+	if err := h.redisCli.Set(h.ctx, key, string(data), driftCacheExpire).Err(); err != nil {
+		blog.Errorf("set host %d drifts to redis failed, key: %s, err: %v, rid: %s", hostID, key, err, rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hostsnap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hostsnap drift", func() {
+	Context("test drifts", func() {
+		It("", func() {
+			ignoreCompareField["bk_os_version"] = struct{}{}
+			defer delete(ignoreCompareField, "bk_os_version")
+
+			host := `{"bk_host_id":1,"bk_cpu":4,"bk_mem":1000,"bk_os_version":"7.2","bk_host_name":"host-1"}`
+			setter := map[string]interface{}{"bk_cpu": int64(4), "bk_mem": uint64(997), "bk_os_version": "7.9",
+				"bk_host_name": "host-1", "bk_os_bit": "64-bit"}
+
+			drifts := getHostSnapDrifts(1, host, setter)
+			Expect(drifts).To(HaveLen(3))
+			Expect(drifts[0].PropertyID).To(Equal("bk_mem"))
+			Expect(drifts[0].CmdbData).To(Equal(float64(1000)))
+			Expect(drifts[0].Ignored).To(BeFalse())
+			Expect(drifts[1].PropertyID).To(Equal("bk_os_version"))
+			Expect(drifts[1].Ignored).To(BeTrue())
+			Expect(drifts[2].PropertyID).To(Equal("bk_os_bit"))
+			Expect(drifts[2].CmdbData).To(BeNil())
+
+			ignoredDrifts := getIgnoredDrifts(drifts)
+			Expect(ignoredDrifts).To(HaveLen(1))
+			Expect(ignoredDrifts[0].PropertyID).To(Equal("bk_os_version"))
+		})
+	})
+})
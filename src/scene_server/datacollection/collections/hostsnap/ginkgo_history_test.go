@@ -0,0 +1,75 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hostsnap
+
+import (
+	"time"
+
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tidwall/gjson"
+)
+
+const historySnapshot = `{"Cpu":4,"cpuUsage":2000,"Disk":50,"diskUsage":440,"memUsage":2286,"Mem":997,` +
+	`"memUsed":228,"loadavg":"0.50 0.30 0.10","HostName":"host-1","OsName":"linux","rcvRate":10,"sendRate":20,` +
+	`"bk_all_ips":{"interface":[{"mac":"52:54:00:19:2e:e8","addrs":[{"ip":"127.0.0.2"},{"ip":"127.0.0.3"}]}]}}`
+
+var _ = Describe("Hostsnap history", func() {
+	Context("test parse snapshot sample", func() {
+		It("", func() {
+			sample := parseHostSnapshot(historySnapshot)
+
+			Expect(sample.cpu).To(Equal(int64(4)))
+			Expect(sample.hostName).To(Equal("host-1"))
+			Expect(sample.mem).To(Equal(int64(997)))
+			Expect(sample.ips).To(Equal([]string{"127.0.0.2", "127.0.0.3"}))
+			Expect(sample.metrics[metadata.HostSnapMetricCPUUsage]).To(Equal(float64(2000)))
+			Expect(sample.metrics[metadata.HostSnapMetricLoad1]).To(Equal(0.5))
+
+			inc, min, max := metadata.GenHostSnapSampleUpdate(sample.metrics)
+			Expect(inc).To(HaveKeyWithValue("metrics.cpu_usage.sum", float64(2000)))
+			Expect(min).To(HaveKeyWithValue("metrics.cpu_usage.min", float64(2000)))
+			Expect(max).To(HaveKeyWithValue("metrics.load1.max", 0.5))
+		})
+	})
+
+	Context("test parse v1.0 snapshot sample", func() {
+		It("", func() {
+			val := gjson.Parse(`{"data":{"apiVer":"v1.0","cpu":{"total":8}}}`)
+			setter := map[string]interface{}{"bk_cpu": int64(8), "bk_host_name": "host-2"}
+
+			sample, err := parseHostSnapSample(&val, setter, []string{"127.0.0.2", "127.0.0.2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sample.cpu).To(Equal(int64(8)))
+			Expect(sample.hostName).To(Equal("host-2"))
+			Expect(sample.ips).To(Equal([]string{"127.0.0.2"}))
+			Expect(sample.metrics).To(BeEmpty())
+		})
+	})
+
+	Context("test change logs", func() {
+		It("", func() {
+			host := mapstr.MapStr{"bk_cpu": 4, "bk_os_name": "linux centos", "bk_host_name": nil}
+			setter := map[string]interface{}{"bk_cpu": int64(4), "bk_os_name": "linux ubuntu", "bk_host_name": "",
+				"bk_mem": uint64(997)}
+
+			changeLogs := getHostSnapChangeLogs(1, host, setter, time.Now())
+			Expect(changeLogs).To(HaveLen(2))
+			Expect(changeLogs[0].PropertyID).To(Equal("bk_mem"))
+			Expect(changeLogs[1].PropertyID).To(Equal("bk_os_name"))
+		})
+	})
+})
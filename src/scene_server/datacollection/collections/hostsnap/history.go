@@ -0,0 +1,239 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hostsnap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/dal/types"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// defaultHistoryResolutionMinutes is the default downsampling window of the host snapshot history
+	defaultHistoryResolutionMinutes = 5
+	// minHistoryResolutionMinutes is the minimum downsampling window of the host snapshot history
+	minHistoryResolutionMinutes = 1
+	// defaultHistoryRetentionDays is the default retention days of the host snapshot history
+	defaultHistoryRetentionDays = 30
+	// defaultChangeLogRetentionDays is the default retention days of the host snapshot change log
+	defaultChangeLogRetentionDays = 180
+	// minRetentionDays is the minimum retention days of the host snapshot history and change log
+	minRetentionDays = 1
+)
+
+// snapshotMetricPaths the path of the history metrics in the parsed host snapshot, see ParseHostSnap
+var snapshotMetricPaths = map[string]string{
+	metadata.HostSnapMetricCPUUsage:  "cpuUsage",
+	metadata.HostSnapMetricMemUsage:  "memUsage",
+	metadata.HostSnapMetricMemUsed:   "memUsed",
+	metadata.HostSnapMetricDiskUsage: "diskUsage",
+	metadata.HostSnapMetricRcvRate:   "rcvRate",
+	metadata.HostSnapMetricSendRate:  "sendRate",
+}
+
+// hostSnapSample is a host snapshot sample that is aggregated into its history point
+type hostSnapSample struct {
+	metrics  map[string]float64
+	cpu      int64
+	mem      int64
+	disk     int64
+	osName   string
+	hostName string
+	ips      []string
+}
+
+// saveHostSnapHistory aggregate the host snapshot into the downsampled history point of its window atomically,
+// so that the concurrent snapshots of the same host in the same window are all counted in.
+func (h *HostSnap) saveHostSnapHistory(rid string, hostID int64, val *gjson.Result, setter map[string]interface{},
+	ips []string, now time.Time) error {
+
+	resolution := getLimitConfig("datacollection.hostsnap.history.resolutionMinutes",
+		defaultHistoryResolutionMinutes, minHistoryResolutionMinutes)
+	retention := getLimitConfig("datacollection.hostsnap.history.retentionDays", defaultHistoryRetentionDays,
+		minRetentionDays)
+
+	sample, err := parseHostSnapSample(val, setter, ips)
+	if err != nil {
+		blog.Errorf("parse host %d snapshot sample failed, err: %v, rid: %s", hostID, err, rid)
+		return err
+	}
+
+	windowTime := now.UTC().Truncate(time.Duration(resolution) * time.Minute)
+	cond := mapstr.MapStr{
+		common.BKHostIDField:     hostID,
+		common.HostSnapTimeField: windowTime,
+	}
+
+	inc, min, max := metadata.GenHostSnapSampleUpdate(sample.metrics)
+	updates := []types.ModeUpdate{
+		{Op: types.UpdateOpInc, Doc: inc},
+		{Op: types.UpdateOpSet, Doc: mapstr.MapStr{
+			"bk_cpu":                     sample.cpu,
+			"bk_mem":                     sample.mem,
+			"bk_disk":                    sample.disk,
+			"os_name":                    sample.osName,
+			"host_name":                  sample.hostName,
+			"ips":                        sample.ips,
+			common.BKOwnerIDField:        common.BKDefaultOwnerID,
+			common.HostSnapExpireAtField: windowTime.AddDate(0, 0, retention),
+		}},
+	}
+	if len(min) > 0 {
+		updates = append(updates, types.ModeUpdate{Op: types.UpdateOpMin, Doc: min},
+			types.ModeUpdate{Op: types.UpdateOpMax, Doc: max})
+	}
+
+	err = h.db.Table(common.BKTableNameHostSnapHistory).UpsertMultiModel(h.ctx, cond, updates...)
+	// the concurrent upserts of a new history point may conflict on the unique index, retry the update then
+	if err != nil && h.db.IsDuplicatedError(err) {
+		err = h.db.Table(common.BKTableNameHostSnapHistory).UpsertMultiModel(h.ctx, cond, updates...)
+	}
+	if err != nil {
+		blog.Errorf("save host %d snapshot history failed, cond: %v, err: %v, rid: %s", hostID, cond, err, rid)
+		return err
+	}
+
+	return nil
+}
+
+// parseHostSnapSample parse the metrics and inventory of the host snapshot message. the v1.0 message has no usage
+// metrics, its inventory is parsed from the host setter, the other messages are parsed like the snapshot in redis.
+func parseHostSnapSample(val *gjson.Result, setter map[string]interface{}, ips []string) (*hostSnapSample, error) {
+	if val.Get("data.apiVer").String() != "v1.0" {
+		snapshot, err := ParseHostSnap(val)
+		if err != nil {
+			return nil, err
+		}
+		return parseHostSnapshot(*snapshot), nil
+	}
+
+	sample := &hostSnapSample{
+		metrics: make(map[string]float64),
+		ips:     util.StrArrayUnique(ips),
+	}
+	sample.cpu, _ = util.GetInt64ByInterface(setter["bk_cpu"])
+	sample.mem, _ = util.GetInt64ByInterface(setter["bk_mem"])
+	sample.disk, _ = util.GetInt64ByInterface(setter["bk_disk"])
+	sample.osName = util.GetStrByInterface(setter["bk_os_name"])
+	sample.hostName = util.GetStrByInterface(setter["bk_host_name"])
+	return sample, nil
+}
+
+// parseHostSnapshot parse the metrics and inventory of the parsed host snapshot
+func parseHostSnapshot(snapshot string) *hostSnapSample {
+	val := gjson.Parse(snapshot)
+
+	metrics := make(map[string]float64)
+	for metric, path := range snapshotMetricPaths {
+		if field := val.Get(path); field.Exists() {
+			metrics[metric] = field.Float()
+		}
+	}
+
+	// loadavg is in the format of "load1 load5 load15"
+	if loads := strings.Fields(val.Get("loadavg").String()); len(loads) > 0 {
+		if load1, err := strconv.ParseFloat(loads[0], 64); err == nil {
+			metrics[metadata.HostSnapMetricLoad1] = load1
+		}
+	}
+
+	sample := &hostSnapSample{
+		metrics:  metrics,
+		cpu:      val.Get("Cpu").Int(),
+		mem:      val.Get("Mem").Int(),
+		disk:     val.Get("Disk").Int(),
+		osName:   val.Get("OsName").String(),
+		hostName: val.Get("HostName").String(),
+		ips:      make([]string, 0),
+	}
+
+	for _, inter := range val.Get("bk_all_ips.interface").Array() {
+		for _, addr := range inter.Get("addrs").Array() {
+			sample.ips = append(sample.ips, addr.Get("ip").String())
+		}
+	}
+	sample.ips = util.StrArrayUnique(sample.ips)
+	return sample
+}
+
+// saveHostSnapChangeLog record the changes of the host's hardware and os fields which are updated by the snapshot
+func (h *HostSnap) saveHostSnapChangeLog(rid string, hostID int64, host mapstr.MapStr, setter map[string]interface{},
+	now time.Time) error {
+
+	retention := getLimitConfig("datacollection.hostsnap.history.changeLogRetentionDays",
+		defaultChangeLogRetentionDays, minRetentionDays)
+
+	changeLogs := getHostSnapChangeLogs(hostID, host, setter, now)
+	if len(changeLogs) == 0 {
+		return nil
+	}
+
+	for idx := range changeLogs {
+		changeLogs[idx].ExpireAt = now.UTC().AddDate(0, 0, retention)
+	}
+
+	if err := h.db.Table(common.BKTableNameHostSnapChangeLog).Insert(h.ctx, changeLogs); err != nil {
+		blog.Errorf("save host %d snapshot change logs failed, logs: %+v, err: %v, rid: %s", hostID, changeLogs,
+			err, rid)
+		return err
+	}
+
+	return nil
+}
+
+// getHostSnapChangeLogs compare the hardware and os fields of the host with the setter parsed from the snapshot
+func getHostSnapChangeLogs(hostID int64, host mapstr.MapStr, setter map[string]interface{},
+	now time.Time) []metadata.HostSnapChangeLog {
+
+	changeLogs := make([]metadata.HostSnapChangeLog, 0)
+	for _, field := range compareFields {
+		curData, exists := setter[field]
+		if !exists {
+			continue
+		}
+
+		preData := host[field]
+		if formatCompareValue(preData) == formatCompareValue(curData) {
+			continue
+		}
+
+		changeLogs = append(changeLogs, metadata.HostSnapChangeLog{
+			HostID:          hostID,
+			PropertyID:      field,
+			PreData:         preData,
+			CurData:         curData,
+			Time:            now.UTC(),
+			SupplierAccount: common.BKDefaultOwnerID,
+		})
+	}
+	return changeLogs
+}
+
+// formatCompareValue format the value to string so that the values of different types can be compared,
+// the nil value is regarded as empty string.
+func formatCompareValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -191,11 +191,34 @@ func (h *HostSnap) Analyze(msg *string) (bool, error) {
 	innerIP := elements[1].String()
 	outerIP := elements[2].String()
 
+	// record the last snapshot time of every reported host for the stale host detection, the failure does not
+	// affect the snapshot handling.
+	h.saveHostSnapStatus(rid, hostID, time.Now())
+
 	// save host snapshot in redis
 	if !val.Get("data.apiVer").Exists() {
 		h.saveHostsnap(header, &val, hostID)
 	}
 
+	// skip the old message, only the message with apiVer is checked, so it can be done before the window
+	// restriction which only applies to the message without apiVer.
+	if h.skipMsg(val, innerIP, rid, hostID, cloudID) {
+		return false, nil
+	}
+
+	setter, raw := parseSetter(&val, innerIP, outerIP)
+
+	// record the snapshot in the downsampled history whether it has apiVer or not, the failure does not affect
+	// the snapshot handling.
+	h.saveHostSnapHistory(rid, hostID, &val, setter, ips, time.Now())
+
+	// record the drifts between the snapshot and the host in cmdb when the snapshot is handled, whether the host
+	// is updated or not.
+	drifts := getHostSnapDrifts(hostID, host, setter)
+	defer func() {
+		h.saveHostSnapDrifts(rid, hostID, drifts)
+	}()
+
 	// window restriction on request when no apiVer information reported
 	if !val.Get("data.apiVer").Exists() && !h.window.canPassWindow() {
 		if blog.V(4) {
@@ -205,11 +228,6 @@ func (h *HostSnap) Analyze(msg *string) (bool, error) {
 		return false, nil
 	}
 
-	if h.skipMsg(val, innerIP, rid, hostID, cloudID) {
-		return false, nil
-	}
-
-	setter, raw := parseSetter(&val, innerIP, outerIP)
 	// no need to update
 	if !needToUpdate(raw, host) {
 		return false, nil
@@ -277,6 +295,13 @@ func (h *HostSnap) Analyze(msg *string) (bool, error) {
 			hostID, innerIP, err, rid)
 		return true, err
 	}
+
+	// record the changes of the hardware and os fields, the failure does not affect the host update.
+	h.saveHostSnapChangeLog(rid, hostID, hostData, setter, time.Now())
+
+	// the fields updated by the snapshot are no longer drifted, except for the ignored fields
+	drifts = getIgnoredDrifts(drifts)
+
 	// save audit log.
 	if err := audit.SaveAuditLog(kit, auditLog...); err != nil {
 		blog.Errorf("save host snap audit log failed after update host, host %d/%s, err: %v, rid: %s", hostID,
@@ -868,6 +893,7 @@ func newHeaderWithRid() (http.Header, string) {
 	header := http.Header{}
 	header.Add(common.BKHTTPOwnerID, common.BKDefaultOwnerID)
 	header.Add(common.BKHTTPHeaderUser, common.CCSystemCollectorUserName)
+	header.Add(common.BKHTTPHostLockExempt, "true")
 	rid := util.GenerateRID()
 	header.Add(common.BKHTTPCCRequestID, rid)
 	return header, rid
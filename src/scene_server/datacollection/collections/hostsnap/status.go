@@ -0,0 +1,63 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hostsnap
+
+import (
+	"strconv"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/storage/dal/redis"
+)
+
+// snapStatusSaveInterval is the interval to save the host's snapshot status, it is much shorter than the stale hours
+// of the policies. the key of a stale host is expired since it stops reporting, so it is un-flagged once it reports.
+const snapStatusSaveInterval = 10 * time.Minute
+
+// saveHostSnapStatus record the time of the host's last snapshot, the host is no longer stale once it reports
+func (h *HostSnap) saveHostSnapStatus(rid string, hostID int64, now time.Time) error {
+	key := common.RedisSnapStatusKeyPrefix + strconv.FormatInt(hostID, 10)
+	_, err := h.redisCli.Get(h.ctx, key).Result()
+	if err == nil {
+		return nil
+	}
+
+	if !redis.IsNilErr(err) {
+		blog.Errorf("get host %d snapshot status key from redis failed, key: %s, err: %v, rid: %s", hostID, key, err,
+			rid)
+	}
+
+	cond := mapstr.MapStr{common.BKHostIDField: hostID}
+	status := metadata.HostSnapStatus{
+		HostID:           hostID,
+		LastSnapshotTime: now.UTC(),
+		Stale:            false,
+		SupplierAccount:  common.BKDefaultOwnerID,
+	}
+
+	if err := h.db.Table(common.BKTableNameHostSnapStatus).Upsert(h.ctx, cond, status); err != nil {
+		blog.Errorf("save host %d snapshot status failed, err: %v, rid: %s", hostID, err, rid)
+		return err
+	}
+
+	if err := h.redisCli.Set(h.ctx, key, now.Unix(), snapStatusSaveInterval).Err(); err != nil {
+		blog.Errorf("set host %d snapshot status key to redis failed, key: %s, err: %v, rid: %s", hostID, key, err,
+			rid)
+		return err
+	}
+
+	return nil
+}
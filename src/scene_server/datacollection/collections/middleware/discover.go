@@ -41,6 +41,7 @@ func NewDiscover(ctx context.Context, redisCli redis.Client, backbone *backbone.
 	header := http.Header{}
 	header.Add(bkc.BKHTTPOwnerID, bkc.BKDefaultOwnerID)
 	header.Add(bkc.BKHTTPHeaderUser, bkc.CCSystemCollectorUserName)
+	header.Add(bkc.BKHTTPHostLockExempt, "true")
 
 	discover := &Discover{
 		redisCli:    redisCli,
@@ -0,0 +1,209 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"strconv"
+
+	"configcenter/src/ac/iam"
+	"configcenter/src/ac/meta"
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+)
+
+// dynamicGroupObjAuth is the user's read permission of an object's instances that the dynamic group uses.
+type dynamicGroupObjAuth struct {
+	resource meta.ResourceAttribute
+	// isAny is true if the user can read all instances of the object, otherwise ids are the readable instance ids.
+	isAny bool
+	ids   []int64
+}
+
+// getDynamicGroupObjAuths returns the user's read permissions of the target object's and the association filter
+// objects' instances of the filter type dynamic group, returns nil if authorization is disabled. condition type
+// groups only use the business topology objects, whose instances are authorized by the business view permission.
+func (lgc *Logics) getDynamicGroupObjAuths(kit *rest.Kit, group *metadata.DynamicGroup) (
+	map[string]*dynamicGroupObjAuth, error) {
+
+	if !lgc.AuthManager.Enabled() || !group.Info.IsFilterType() {
+		return nil, nil
+	}
+
+	objIDs := []string{group.ObjID}
+	for _, asstFilter := range group.Info.AsstFilters {
+		objIDs = append(objIDs, asstFilter.ObjID)
+	}
+
+	auths := make(map[string]*dynamicGroupObjAuth)
+	for _, objID := range objIDs {
+		if _, exists := auths[objID]; exists {
+			continue
+		}
+
+		auth, err := lgc.getObjInstReadAuth(kit, group.AppID, objID)
+		if err != nil {
+			return nil, err
+		}
+		auths[objID] = auth
+	}
+
+	return auths, nil
+}
+
+// getObjInstReadAuth returns the user's read permission of the object's instances.
+func (lgc *Logics) getObjInstReadAuth(kit *rest.Kit, bizID int64, objID string) (*dynamicGroupObjAuth, error) {
+	resType, err := lgc.getObjInstResourceType(kit, objID)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := &dynamicGroupObjAuth{
+		resource: meta.ResourceAttribute{
+			Basic:           meta.Basic{Type: resType, Action: meta.Find},
+			SupplierAccount: kit.SupplierAccount,
+			BusinessID:      bizID,
+		},
+	}
+
+	actionID, err := iam.ConvertResourceAction(resType, meta.Find, bizID)
+	if err != nil {
+		blog.Errorf("convert %s read action failed, err: %v, rid: %s", objID, err, kit.Rid)
+		return nil, err
+	}
+
+	if actionID == iam.Skip {
+		auth.isAny = true
+		return auth, nil
+	}
+
+	authInput := meta.ListAuthorizedResourcesParam{
+		UserName:     kit.User,
+		BizID:        bizID,
+		ResourceType: resType,
+		Action:       meta.Find,
+	}
+	authorized, err := lgc.AuthManager.Authorizer.ListAuthorizedResources(kit.Ctx, kit.Header, authInput)
+	if err != nil {
+		blog.Errorf("list authorized %s instances failed, input: %+v, err: %v, rid: %s", objID, authInput, err,
+			kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommCheckAuthorizeFailed)
+	}
+
+	if authorized.IsAny {
+		auth.isAny = true
+		return auth, nil
+	}
+
+	auth.ids = make([]int64, 0)
+	for _, id := range authorized.Ids {
+		instID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			blog.Errorf("parse authorized %s instance id %s failed, err: %v, rid: %s", objID, id, err, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommParamsNeedInt, common.BKInstIDField)
+		}
+		auth.ids = append(auth.ids, instID)
+	}
+
+	return auth, nil
+}
+
+// getObjInstResourceType returns the auth resource type of the object's instances.
+func (lgc *Logics) getObjInstResourceType(kit *rest.Kit, objID string) (meta.ResourceType, error) {
+	switch objID {
+	case common.BKInnerObjIDPlat:
+		return meta.CloudAreaInstance, nil
+	case common.BKInnerObjIDHost:
+		return meta.HostInstance, nil
+	case common.BKInnerObjIDModule:
+		return meta.ModelModule, nil
+	case common.BKInnerObjIDSet:
+		return meta.ModelSet, nil
+	case common.BKInnerObjIDApp:
+		return meta.Business, nil
+	case common.BKInnerObjIDProc:
+		return meta.Process, nil
+	case common.BKInnerObjIDBizSet:
+		return meta.BizSet, nil
+	}
+
+	isMainline, err := lgc.isMainlineObject(kit, objID)
+	if err != nil {
+		return "", err
+	}
+	if isMainline {
+		return meta.MainlineInstance, nil
+	}
+
+	query := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{common.BKObjIDField: objID},
+		Fields:         []string{common.BKFieldID},
+		DisableCounter: true,
+	}
+	models, err := lgc.CoreAPI.CoreService().Model().ReadModel(kit.Ctx, kit.Header, query)
+	if err != nil {
+		blog.Errorf("get model %s failed, err: %v, rid: %s", objID, err, kit.Rid)
+		return "", err
+	}
+
+	if len(models.Info) == 0 {
+		blog.Errorf("model %s is not exist, rid: %s", objID, kit.Rid)
+		return "", kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKObjIDField)
+	}
+
+	return iam.GenCMDBDynamicResType(models.Info[0].ID), nil
+}
+
+// AuthorizeDynamicGroup checks if the user can read the instances of the dynamic group's target object and the
+// association filter objects, returns the permission to apply if the user can not read any instance of one of them.
+func (lgc *Logics) AuthorizeDynamicGroup(kit *rest.Kit, group *metadata.DynamicGroup) (*metadata.BaseResp, error) {
+	auths, err := lgc.getDynamicGroupObjAuths(kit, group)
+	if err != nil {
+		return nil, err
+	}
+
+	noAuthResources := make([]meta.ResourceAttribute, 0)
+	for _, auth := range auths {
+		if !auth.isAny && len(auth.ids) == 0 {
+			noAuthResources = append(noAuthResources, auth.resource)
+		}
+	}
+
+	if len(noAuthResources) == 0 {
+		return nil, nil
+	}
+
+	permission, err := lgc.AuthManager.Authorizer.GetPermissionToApply(kit.Ctx, kit.Header, noAuthResources)
+	if err != nil {
+		blog.Errorf("get permission to apply failed, resources: %+v, err: %v, rid: %s", noAuthResources, err,
+			kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommCheckAuthorizeFailed)
+	}
+
+	resp := metadata.NewNoPermissionResp(permission)
+	return &resp, nil
+}
+
+// genDynamicGroupAuthCond returns the condition that restricts the object's instances to the readable ones, returns
+// nil if all instances are readable.
+func genDynamicGroupAuthCond(auths map[string]*dynamicGroupObjAuth, objID string) map[string]interface{} {
+	auth, exists := auths[objID]
+	if !exists || auth.isAny {
+		return nil
+	}
+
+	idField := metadata.GetInstIDFieldByObjID(objID)
+	return map[string]interface{}{idField: map[string]interface{}{common.BKDBIN: auth.ids}}
+}
@@ -0,0 +1,504 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+)
+
+// ExecuteDynamicGroup searches the target instances of the dynamic group, the instances are restricted to the ones
+// in the instIDs if it is not nil, which is used to check if these instances are members.
+func (lgc *Logics) ExecuteDynamicGroup(kit *rest.Kit, group *metadata.DynamicGroup, query *metadata.QueryCondition,
+	instIDs []int64) (*metadata.InstDataInfo, error) {
+
+	if group.Info.IsFilterType() {
+		return lgc.ExecuteDynamicGroupByFilter(kit, group, query, instIDs)
+	}
+
+	// parse all dynamic group conditions to search condition.
+	searchConditions := make([]metadata.SearchCondition, 0)
+	for _, cond := range group.Info.Condition {
+		searchCondition := metadata.SearchCondition{ObjectID: cond.ObjID, Condition: []metadata.ConditionItem{}}
+		for _, item := range cond.Condition {
+			condItem := metadata.ConditionItem{Field: item.Field, Operator: item.Operator, Value: item.Value}
+			searchCondition.Condition = append(searchCondition.Condition, condItem)
+		}
+		searchCondition.TimeCondition = cond.TimeCondition
+		searchConditions = append(searchConditions, searchCondition)
+	}
+
+	if instIDs != nil {
+		if len(instIDs) == 0 {
+			return &metadata.InstDataInfo{Info: make([]mapstr.MapStr, 0)}, nil
+		}
+
+		var restricted bool
+		searchConditions, restricted = restrictDynamicGroupConds(group.ObjID, searchConditions, instIDs)
+		if !restricted {
+			// the group's condition on the id field can not be merged with the restriction, so get all of the
+			// group's instances and pick the ones in the instIDs after searching.
+			query.Page = metadata.BasePage{Limit: common.BKNoLimit}
+		}
+	}
+
+	var result *metadata.InstDataInfo
+	switch group.ObjID {
+	case common.BKInnerObjIDHost:
+		searchHostCondition := metadata.HostCommonSearch{AppID: group.AppID, Condition: searchConditions,
+			Page: query.Page}
+
+		data, err := lgc.ExecuteHostDynamicGroup(kit, &searchHostCondition, query.Fields, query.DisableCounter)
+		if err != nil {
+			return nil, err
+		}
+		result = &metadata.InstDataInfo{Count: data.Count, Info: data.Info}
+
+	case common.BKInnerObjIDSet:
+		searchSetCondition := metadata.SetCommonSearch{AppID: group.AppID, Condition: searchConditions,
+			Page: query.Page}
+
+		data, err := lgc.ExecuteSetDynamicGroup(kit, &searchSetCondition, query.Fields, query.DisableCounter)
+		if err != nil {
+			return nil, err
+		}
+		result = data
+
+	default:
+		blog.Errorf("unknown dynamic group %s object type %s, rid: %s", group.ID, group.ObjID, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCSystemUnknownError)
+	}
+
+	if instIDs == nil {
+		return result, nil
+	}
+	return filterDynamicGroupResult(kit, group.ObjID, result, instIDs)
+}
+
+// restrictDynamicGroupConds restricts the target instances of the condition type dynamic group to the ones in the
+// instIDs, returns false if the group's own condition on the id field makes the restriction impossible.
+func restrictDynamicGroupConds(objID string, conds []metadata.SearchCondition, instIDs []int64) (
+	[]metadata.SearchCondition, bool) {
+
+	idField := metadata.GetInstIDFieldByObjID(objID)
+	idItem := metadata.ConditionItem{Field: idField, Operator: common.BKDBIN, Value: instIDs}
+
+	for idx, cond := range conds {
+		if cond.ObjectID != objID {
+			continue
+		}
+
+		for _, item := range cond.Condition {
+			if item.Field == idField {
+				return conds, false
+			}
+		}
+
+		conds[idx].Condition = append(conds[idx].Condition, idItem)
+		return conds, true
+	}
+
+	return append(conds, metadata.SearchCondition{ObjectID: objID, Condition: []metadata.ConditionItem{idItem}}), true
+}
+
+// filterDynamicGroupResult picks the instances in the instIDs from the dynamic group's search result.
+func filterDynamicGroupResult(kit *rest.Kit, objID string, result *metadata.InstDataInfo, instIDs []int64) (
+	*metadata.InstDataInfo, error) {
+
+	idField := metadata.GetInstIDFieldByObjID(objID)
+	idMap := make(map[int64]struct{})
+	for _, id := range instIDs {
+		idMap[id] = struct{}{}
+	}
+
+	infos := make([]mapstr.MapStr, 0)
+	for _, info := range result.Info {
+		id, err := info.Int64(idField)
+		if err != nil {
+			blog.Errorf("parse %s instance id failed, err: %v, inst: %+v, rid: %s", objID, err, info, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommInstFieldConvertFail, objID, idField, "int",
+				err.Error())
+		}
+
+		if _, exists := idMap[id]; exists {
+			infos = append(infos, info)
+		}
+	}
+
+	return &metadata.InstDataInfo{Count: len(infos), Info: infos}, nil
+}
+
+// dynamicGroupBatchSize is the number of instances that are joined with the relations or associations in a batch.
+const dynamicGroupBatchSize = 500
+
+// ExecuteDynamicGroupByFilter searches the target instances of the dynamic group that uses filters, the instances are
+// restricted to the ones in the instIDs if it is not nil, which is used to check if these instances are members.
+func (lgc *Logics) ExecuteDynamicGroupByFilter(kit *rest.Kit, group *metadata.DynamicGroup,
+	query *metadata.QueryCondition, instIDs []int64) (*metadata.InstDataInfo, error) {
+
+	idField := metadata.GetInstIDFieldByObjID(group.ObjID)
+	emptyResult := &metadata.InstDataInfo{Info: make([]mapstr.MapStr, 0)}
+
+	conds := make([]map[string]interface{}, 0)
+	if group.Info.Filter != nil {
+		cond, err := group.Info.Filter.ToMgo()
+		if err != nil {
+			blog.Errorf("parse dynamic group %s filter failed, err: %v, rid: %s", group.ID, err, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "info.filter")
+		}
+		conds = append(conds, cond)
+	}
+
+	if instIDs != nil {
+		if len(instIDs) == 0 {
+			return emptyResult, nil
+		}
+		conds = append(conds, map[string]interface{}{idField: map[string]interface{}{common.BKDBIN: instIDs}})
+	}
+
+	// restrict the instances to the ones that the user can read
+	auths, err := lgc.getDynamicGroupObjAuths(kit, group)
+	if err != nil {
+		return nil, err
+	}
+	if authCond := genDynamicGroupAuthCond(auths, group.ObjID); authCond != nil {
+		conds = append(conds, authCond)
+	}
+
+	for idx := range group.Info.AsstFilters {
+		ids, err := lgc.getDynamicGroupAsstInstIDs(kit, group.ObjID, &group.Info.AsstFilters[idx], auths)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(ids) == 0 {
+			return emptyResult, nil
+		}
+		conds = append(conds, map[string]interface{}{idField: map[string]interface{}{common.BKDBIN: ids}})
+	}
+
+	if len(query.Page.Sort) == 0 {
+		query.Page.Sort = idField
+	}
+
+	if group.ObjID == common.BKInnerObjIDHost {
+		return lgc.executeBizHostDynamicGroup(kit, group, query, conds)
+	}
+
+	isMainline, err := lgc.isMainlineObject(kit, group.ObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	// instances of the non-mainline objects do not belong to a business, so they are not restricted
+	if isMainline {
+		conds = append(conds, map[string]interface{}{common.BKAppIDField: group.AppID})
+	}
+
+	query.Condition = mapstr.MapStr{common.BKDBAND: conds}
+	result, err := lgc.CoreAPI.CoreService().Instance().ReadInstance(kit.Ctx, kit.Header, group.ObjID, query)
+	if err != nil {
+		blog.Errorf("search dynamic group %s instances failed, err: %v, query: %#v, rid: %s", group.ID, err, query,
+			kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// executeBizHostDynamicGroup searches the target hosts of the dynamic group in its business. the hosts matching the
+// dynamic group's conditions are read by keyset pagination on the host id in batches, and the hosts of each batch that
+// belong to the business are paged into the result, so that the hosts are not restricted by all the host ids of the
+// business at once. the page can also use the cursor to continue from the last host of the previous page.
+func (lgc *Logics) executeBizHostDynamicGroup(kit *rest.Kit, group *metadata.DynamicGroup,
+	query *metadata.QueryCondition, conds []map[string]interface{}) (*metadata.InstDataInfo, error) {
+
+	if query.Page.Sort != common.BKHostIDField {
+		blog.Errorf("host dynamic group %s can only be sorted by host id, sort: %s, rid: %s", group.ID,
+			query.Page.Sort, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "page.sort")
+	}
+
+	cursor := &metadata.PageCursor{Field: common.BKHostIDField}
+	if query.Page.IsCursorPage() {
+		var err error
+		cursor, err = query.Page.ParsePageCursor(common.BKHostIDField)
+		if err != nil {
+			blog.Errorf("parse dynamic group %s page cursor failed, err: %v, page: %+v, rid: %s", group.ID, err,
+				query.Page, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "page")
+		}
+	}
+
+	hostQuery := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{},
+		Fields:         query.Fields,
+		Page:           metadata.BasePage{EnableCursor: true, Cursor: query.Page.Cursor, Limit: dynamicGroupBatchSize},
+		DisableCounter: true,
+	}
+	if len(conds) > 0 {
+		hostQuery.Condition = mapstr.MapStr{common.BKDBAND: conds}
+	}
+
+	result := &metadata.InstDataInfo{Info: make([]mapstr.MapStr, 0)}
+	var lastHostID int64
+	hasMore := false
+	for {
+		hosts, err := lgc.CoreAPI.CoreService().Instance().ReadInstance(kit.Ctx, kit.Header,
+			common.BKInnerObjIDHost, hostQuery)
+		if err != nil {
+			blog.Errorf("search dynamic group %s hosts failed, err: %v, query: %#v, rid: %s", group.ID, err,
+				hostQuery, kit.Rid)
+			return nil, err
+		}
+
+		bizHosts, err := lgc.filterBizHosts(kit, group.AppID, hosts.Info)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, host := range bizHosts {
+			if result.Count >= query.Page.Start && len(result.Info) < query.Page.Limit {
+				result.Info = append(result.Info, host)
+				// the host id is already parsed when the hosts are filtered by the business
+				lastHostID, _ = host.Int64(common.BKHostIDField)
+			} else if len(result.Info) >= query.Page.Limit {
+				hasMore = true
+			}
+			result.Count++
+		}
+
+		if len(hosts.NextCursor) == 0 || (query.DisableCounter && hasMore) {
+			break
+		}
+		hostQuery.Page = metadata.BasePage{Cursor: hosts.NextCursor, Limit: dynamicGroupBatchSize}
+	}
+
+	if hasMore && query.Page.IsCursorPage() {
+		result.NextCursor = cursor.Next(lastHostID)
+	}
+
+	if query.DisableCounter {
+		result.Count = 0
+	}
+	return result, nil
+}
+
+// filterBizHosts picks the hosts that belong to the business, the hosts are kept in the original order.
+func (lgc *Logics) filterBizHosts(kit *rest.Kit, bizID int64, hosts []mapstr.MapStr) ([]mapstr.MapStr, error) {
+	if len(hosts) == 0 {
+		return hosts, nil
+	}
+
+	hostIDs := make([]int64, len(hosts))
+	for idx, host := range hosts {
+		hostID, err := host.Int64(common.BKHostIDField)
+		if err != nil {
+			blog.Errorf("parse host id failed, err: %v, host: %+v, rid: %s", err, host, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommInstFieldConvertFail, common.BKInnerObjIDHost,
+				common.BKHostIDField, "int", err.Error())
+		}
+		hostIDs[idx] = hostID
+	}
+
+	relReq := metadata.HostModuleRelationRequest{
+		ApplicationID: bizID,
+		HostIDArr:     hostIDs,
+		Fields:        []string{common.BKHostIDField},
+	}
+	relations, err := lgc.GetHostRelations(kit, relReq)
+	if err != nil {
+		blog.Errorf("get biz %d host relations failed, err: %v, rid: %s", bizID, err, kit.Rid)
+		return nil, err
+	}
+
+	bizHostIDs := make(map[int64]struct{})
+	for _, relation := range relations {
+		bizHostIDs[relation.HostID] = struct{}{}
+	}
+
+	bizHosts := make([]mapstr.MapStr, 0)
+	for idx, host := range hosts {
+		if _, exists := bizHostIDs[hostIDs[idx]]; exists {
+			bizHosts = append(bizHosts, host)
+		}
+	}
+	return bizHosts, nil
+}
+
+// getDynamicGroupAsstInstIDs returns the ids of the target instances that are associated with the instances
+// matching the association filter and readable by the user. the matching instances are joined with their
+// associations by batches, and the number of the associated target instances is limited.
+func (lgc *Logics) getDynamicGroupAsstInstIDs(kit *rest.Kit, objID string, asstFilter *metadata.DynamicGroupAsstFilter,
+	auths map[string]*dynamicGroupObjAuth) ([]int64, error) {
+
+	cond, err := asstFilter.Filter.ToMgo()
+	if err != nil {
+		blog.Errorf("parse %s association filter failed, err: %v, rid: %s", asstFilter.ObjID, err, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "info.association_filters")
+	}
+
+	if authCond := genDynamicGroupAuthCond(auths, asstFilter.ObjID); authCond != nil {
+		cond = map[string]interface{}{common.BKDBAND: []map[string]interface{}{cond, authCond}}
+	}
+
+	asstIDField := metadata.GetInstIDFieldByObjID(asstFilter.ObjID)
+	query := &metadata.QueryCondition{
+		Condition:      cond,
+		Fields:         []string{asstIDField},
+		Page:           metadata.BasePage{Sort: asstIDField, Limit: dynamicGroupBatchSize},
+		DisableCounter: true,
+	}
+
+	instIDs := make([]int64, 0)
+	instIDMap := make(map[int64]struct{})
+	for {
+		insts, err := lgc.CoreAPI.CoreService().Instance().ReadInstance(kit.Ctx, kit.Header, asstFilter.ObjID, query)
+		if err != nil {
+			blog.Errorf("search %s instances by filter failed, err: %v, rid: %s", asstFilter.ObjID, err, kit.Rid)
+			return nil, err
+		}
+
+		asstIDs := make([]int64, 0)
+		for _, inst := range insts.Info {
+			id, err := inst.Int64(asstIDField)
+			if err != nil {
+				blog.Errorf("parse %s instance id failed, err: %v, inst: %+v, rid: %s", asstFilter.ObjID, err, inst,
+					kit.Rid)
+				return nil, kit.CCError.CCErrorf(common.CCErrCommInstFieldConvertFail, asstFilter.ObjID, asstIDField,
+					"int", err.Error())
+			}
+			asstIDs = append(asstIDs, id)
+		}
+
+		if len(asstIDs) > 0 {
+			ids, err := lgc.getAsstInstIDsByBatch(kit, objID, asstFilter.ObjID, asstIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, id := range ids {
+				if _, exists := instIDMap[id]; exists {
+					continue
+				}
+				instIDMap[id] = struct{}{}
+				instIDs = append(instIDs, id)
+			}
+
+			if len(instIDs) > metadata.MaxDynamicGroupAsstInsts {
+				blog.Errorf("%s association filter matches more than %d %s instances, rid: %s", asstFilter.ObjID,
+					metadata.MaxDynamicGroupAsstInsts, objID, kit.Rid)
+				return nil, kit.CCError.CCErrorf(common.CCErrCommXXExceedLimit, "info.association_filters",
+					metadata.MaxDynamicGroupAsstInsts)
+			}
+		}
+
+		if len(insts.Info) < dynamicGroupBatchSize {
+			break
+		}
+		query.Page.Start += dynamicGroupBatchSize
+	}
+
+	return instIDs, nil
+}
+
+// getAsstInstIDsByBatch returns the ids of the target instances that are associated with the batch of the
+// association filter object's instances, the associations are also read by batches.
+func (lgc *Logics) getAsstInstIDsByBatch(kit *rest.Kit, objID, asstObjID string, asstIDs []int64) ([]int64, error) {
+	asstIDMap := make(map[int64]struct{})
+	for _, id := range asstIDs {
+		asstIDMap[id] = struct{}{}
+	}
+
+	// the associated instances can be either the source or the target of the associations
+	asstCond := &metadata.InstAsstQueryCondition{
+		ObjID: objID,
+		Cond: metadata.QueryCondition{
+			Condition: mapstr.MapStr{
+				common.BKDBOR: []mapstr.MapStr{
+					{
+						common.BKObjIDField:      objID,
+						common.BKAsstObjIDField:  asstObjID,
+						common.BKAsstInstIDField: mapstr.MapStr{common.BKDBIN: asstIDs},
+					},
+					{
+						common.BKObjIDField:     asstObjID,
+						common.BKAsstObjIDField: objID,
+						common.BKInstIDField:    mapstr.MapStr{common.BKDBIN: asstIDs},
+					},
+				},
+			},
+			Fields: []string{common.BKObjIDField, common.BKInstIDField, common.BKAsstObjIDField,
+				common.BKAsstInstIDField},
+			Page:           metadata.BasePage{Sort: common.BKFieldID, Limit: common.BKMaxPageSize},
+			DisableCounter: true,
+		},
+	}
+
+	instIDs := make([]int64, 0)
+	for {
+		assts, err := lgc.CoreAPI.CoreService().Association().ReadInstAssociation(kit.Ctx, kit.Header, asstCond)
+		if err != nil {
+			blog.Errorf("search %s instance associations failed, err: %v, rid: %s", objID, err, kit.Rid)
+			return nil, err
+		}
+
+		for _, asst := range assts.Info {
+			if asst.ObjectID == objID && asst.AsstObjectID == asstObjID {
+				if _, exists := asstIDMap[asst.AsstInstID]; exists {
+					instIDs = append(instIDs, asst.InstID)
+				}
+			}
+
+			if asst.AsstObjectID == objID && asst.ObjectID == asstObjID {
+				if _, exists := asstIDMap[asst.InstID]; exists {
+					instIDs = append(instIDs, asst.AsstInstID)
+				}
+			}
+		}
+
+		if len(assts.Info) < common.BKMaxPageSize || len(instIDs) > metadata.MaxDynamicGroupAsstInsts {
+			break
+		}
+		asstCond.Cond.Page.Start += common.BKMaxPageSize
+	}
+
+	return instIDs, nil
+}
+
+// isMainlineObject checks if the object is a mainline object, whose instances belong to a business.
+func (lgc *Logics) isMainlineObject(kit *rest.Kit, objID string) (bool, error) {
+	switch objID {
+	case common.BKInnerObjIDApp, common.BKInnerObjIDSet, common.BKInnerObjIDModule, common.BKInnerObjIDHost:
+		return true, nil
+	}
+
+	objChildMap, err := lgc.searchMainlineRelationMap(kit)
+	if err != nil {
+		return false, err
+	}
+
+	if _, exists := objChildMap[objID]; exists {
+		return true, nil
+	}
+
+	for _, child := range objChildMap {
+		if child == objID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,141 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/watch"
+
+	"github.com/tidwall/gjson"
+)
+
+// WatchDynamicGroup watches the changes of the dynamic group's target instances, and checks if the changed instances
+// are members of the dynamic group after the changes.
+// NOTICE: only the changes of the target instances generate events, the changes of the other instances that the
+// group's conditions or association filters rely on do not generate events, eg. host's topology changes.
+func (lgc *Logics) WatchDynamicGroup(kit *rest.Kit, group *metadata.DynamicGroup,
+	opt *metadata.WatchDynamicGroupOption) (*metadata.WatchDynamicGroupResult, error) {
+
+	idField := metadata.GetInstIDFieldByObjID(group.ObjID)
+	watchOpt := &watch.WatchEventOptions{
+		Fields:    []string{idField},
+		StartFrom: opt.StartFrom,
+		Cursor:    opt.Cursor,
+	}
+
+	switch group.ObjID {
+	case common.BKInnerObjIDHost:
+		watchOpt.Resource = watch.Host
+	case common.BKInnerObjIDSet:
+		watchOpt.Resource = watch.Set
+	case common.BKInnerObjIDModule:
+		watchOpt.Resource = watch.Module
+	case common.BKInnerObjIDPlat:
+		watchOpt.Resource = watch.Plat
+	default:
+		isMainline, err := lgc.isMainlineObject(kit, group.ObjID)
+		if err != nil {
+			return nil, err
+		}
+
+		watchOpt.Resource = watch.ObjectBase
+		if isMainline {
+			watchOpt.Resource = watch.MainlineInstance
+		}
+		watchOpt.Filter.SubResource = group.ObjID
+	}
+
+	events, watchErr := lgc.CoreAPI.CacheService().Cache().Event().WatchEvent(kit.Ctx, kit.Header, watchOpt)
+	if watchErr != nil {
+		blog.Errorf("watch dynamic group %s events failed, err: %v, opt: %#v, rid: %s", group.ID, watchErr, watchOpt,
+			kit.Rid)
+		return nil, watchErr
+	}
+
+	if !gjson.Get(*events, "bk_watched").Bool() {
+		return &metadata.WatchDynamicGroupResult{
+			Watched: false,
+			Events: []metadata.DynamicGroupMemberEvent{
+				{Cursor: gjson.Get(*events, "bk_events.0.bk_cursor").String()},
+			},
+		}, nil
+	}
+
+	rawEvents := gjson.Get(*events, "bk_events").Array()
+	memberEvents := make([]metadata.DynamicGroupMemberEvent, len(rawEvents))
+	checkIDs := make([]int64, 0)
+	for idx, rawEvent := range rawEvents {
+		memberEvents[idx] = metadata.DynamicGroupMemberEvent{
+			Cursor:    rawEvent.Get("bk_cursor").String(),
+			EventType: rawEvent.Get("bk_event_type").String(),
+			InstID:    rawEvent.Get("bk_detail." + idField).Int(),
+		}
+
+		if memberEvents[idx].EventType != string(watch.Delete) {
+			checkIDs = append(checkIDs, memberEvents[idx].InstID)
+		}
+	}
+
+	memberMap, err := lgc.getDynamicGroupMemberMap(kit, group, checkIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range memberEvents {
+		if memberEvents[idx].EventType == string(watch.Delete) {
+			continue
+		}
+		_, memberEvents[idx].IsMember = memberMap[memberEvents[idx].InstID]
+	}
+
+	return &metadata.WatchDynamicGroupResult{Watched: true, Events: memberEvents}, nil
+}
+
+// getDynamicGroupMemberMap returns the ids of the instances in the instIDs that are members of the dynamic group.
+func (lgc *Logics) getDynamicGroupMemberMap(kit *rest.Kit, group *metadata.DynamicGroup, instIDs []int64) (
+	map[int64]struct{}, error) {
+
+	memberMap := make(map[int64]struct{})
+	if len(instIDs) == 0 {
+		return memberMap, nil
+	}
+
+	idField := metadata.GetInstIDFieldByObjID(group.ObjID)
+	query := &metadata.QueryCondition{
+		Fields:         []string{idField},
+		Page:           metadata.BasePage{Limit: len(instIDs)},
+		DisableCounter: true,
+	}
+
+	result, err := lgc.ExecuteDynamicGroup(kit, group, query, instIDs)
+	if err != nil {
+		blog.Errorf("check dynamic group %s members failed, err: %v, ids: %v, rid: %s", group.ID, err, instIDs,
+			kit.Rid)
+		return nil, err
+	}
+
+	for _, inst := range result.Info {
+		id, err := inst.Int64(idField)
+		if err != nil {
+			blog.Errorf("parse %s instance id failed, err: %v, inst: %+v, rid: %s", group.ObjID, err, inst, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommInstFieldConvertFail, group.ObjID, idField, "int",
+				err.Error())
+		}
+		memberMap[id] = struct{}{}
+	}
+
+	return memberMap, nil
+}
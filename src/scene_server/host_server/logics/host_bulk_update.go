@@ -0,0 +1,83 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+)
+
+// FindBizHostsByFilter find the hosts in the business, and in the sets or modules if they are set, that match the
+// filter of the bulk host update option. the number of the matched hosts can not exceed BulkUpdateHostMaxCount,
+// which is checked before the hosts are read.
+func (lgc *Logics) FindBizHostsByFilter(kit *rest.Kit, bizID int64, option *metadata.BulkUpdateHostOption) (
+	[]mapstr.MapStr, errors.CCErrorCoder) {
+
+	relReq := metadata.HostModuleRelationRequest{
+		ApplicationID: bizID,
+		SetIDArr:      option.SetIDs,
+		ModuleIDArr:   option.ModuleIDs,
+		Page:          metadata.BasePage{Limit: common.BKNoLimit},
+		Fields:        []string{common.BKHostIDField},
+	}
+	relations, err := lgc.GetHostRelations(kit, relReq)
+	if err != nil {
+		blog.Errorf("get biz host relations failed, req: %+v, err: %v, rid: %s", relReq, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommHTTPDoRequestFailed)
+	}
+
+	if len(relations) == 0 {
+		return make([]mapstr.MapStr, 0), nil
+	}
+
+	hostIDs := make([]int64, len(relations))
+	for idx, relation := range relations {
+		hostIDs[idx] = relation.HostID
+	}
+
+	filterCond, err := option.Filter.ToMgo()
+	if err != nil {
+		blog.Errorf("parse bulk update host filter failed, filter: %v, err: %v, rid: %s", option.Filter, err, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "filter")
+	}
+
+	cond := map[string]interface{}{
+		common.BKDBAND: []map[string]interface{}{
+			{common.BKHostIDField: map[string]interface{}{common.BKDBIN: util.IntArrayUnique(hostIDs)}},
+			filterCond,
+		},
+	}
+	// count the matched hosts first, so that the hosts are not read if there are too many of them
+	counts, err := lgc.CoreAPI.CoreService().Instance().CountInstances(kit.Ctx, kit.Header, common.BKInnerObjIDHost,
+		&metadata.Condition{Condition: cond})
+	if err != nil {
+		blog.Errorf("count bulk update hosts failed, cond: %+v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommHTTPDoRequestFailed)
+	}
+
+	if counts.Count > metadata.BulkUpdateHostMaxCount {
+		blog.Errorf("%d hosts matched the filter, exceeds the limit, rid: %s", counts.Count, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommXXExceedLimit, "hosts", metadata.BulkUpdateHostMaxCount)
+	}
+
+	if counts.Count == 0 {
+		return make([]mapstr.MapStr, 0), nil
+	}
+
+	return lgc.GetHostInfoByConds(kit, cond)
+}
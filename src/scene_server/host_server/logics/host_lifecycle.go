@@ -0,0 +1,30 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// GetHostLifecycle get the host lifecycle of the supplier account
+func (lgc *Logics) GetHostLifecycle(kit *rest.Kit) (*metadata.HostLifecycle, errors.CCErrorCoder) {
+	lifecycle, err := lgc.CoreAPI.CoreService().Host().GetHostLifecycle(kit.Ctx, kit.Header)
+	if err != nil {
+		blog.Errorf("get host lifecycle failed, err: %v, rid: %s", err, kit.Rid)
+		return nil, err
+	}
+	return lifecycle, nil
+}
@@ -72,3 +72,28 @@ func (lgc *Logics) QueryHostLock(kit *rest.Kit, input *metadata.QueryHostLockReq
 
 	return hostLockMap, nil
 }
+
+// GetOthersHostLocks get the unexpired locks of the hosts that are held by other users, returns map[hostID]lock
+func (lgc *Logics) GetOthersHostLocks(kit *rest.Kit, hostIDs []int64) (map[int64]metadata.HostLockData,
+	errors.CCError) {
+
+	input := &metadata.QueryHostLockRequest{IDS: hostIDs}
+	hostLockResult, err := lgc.CoreAPI.CoreService().Host().QueryHostLock(kit.Ctx, kit.Header, input)
+	if err != nil {
+		blog.Errorf("query host lock failed, err: %v, input: %+v, rid: %s", err, input, kit.Rid)
+		return nil, kit.CCError.Error(common.CCErrCommHTTPDoRequestFailed)
+	}
+	if !hostLockResult.Result {
+		blog.Errorf("query host lock failed, code: %d, msg: %s, input: %+v, rid: %s", hostLockResult.Code,
+			hostLockResult.ErrMsg, input, kit.Rid)
+		return nil, kit.CCError.New(hostLockResult.Code, hostLockResult.ErrMsg)
+	}
+
+	hostLockMap := make(map[int64]metadata.HostLockData)
+	for _, hostLock := range hostLockResult.Data.Info {
+		if hostLock.User != kit.User {
+			hostLockMap[hostLock.ID] = hostLock
+		}
+	}
+	return hostLockMap, nil
+}
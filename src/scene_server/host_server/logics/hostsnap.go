@@ -0,0 +1,124 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"sort"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// SummarizeBizHostSnapDrift summarize the drifts between the snapshot and the host in cmdb of the hosts in the
+// business by module, the modules without drifted hosts are not returned.
+func (lgc *Logics) SummarizeBizHostSnapDrift(kit *rest.Kit, bizID int64, option *metadata.HostSnapDriftSummaryOption) (
+	[]metadata.ModuleHostSnapDriftSummary, errors.CCErrorCoder) {
+
+	relCond := metadata.HostModuleRelationRequest{
+		ApplicationID: bizID,
+		Fields:        []string{common.BKHostIDField, common.BKModuleIDField},
+		Page:          metadata.BasePage{Limit: common.BKMaxPageSize},
+	}
+
+	hostModules := make(map[int64][]int64)
+	hostIDs := make([]int64, 0)
+	for {
+		relations, err := lgc.CoreAPI.CoreService().Host().GetHostModuleRelation(kit.Ctx, kit.Header, &relCond)
+		if err != nil {
+			blog.Errorf("get host module relations failed, cond: %+v, err: %v, rid: %s", relCond, err, kit.Rid)
+			return nil, kit.CCError.CCError(common.CCErrCommHTTPDoRequestFailed)
+		}
+
+		for _, relation := range relations.Info {
+			if _, exists := hostModules[relation.HostID]; !exists {
+				hostIDs = append(hostIDs, relation.HostID)
+			}
+			hostModules[relation.HostID] = append(hostModules[relation.HostID], relation.ModuleID)
+		}
+
+		if len(relations.Info) < relCond.Page.Limit {
+			break
+		}
+		relCond.Page.Start += relCond.Page.Limit
+	}
+
+	drifts := make([]metadata.HostSnapDrift, 0)
+	for start := 0; start < len(hostIDs); start += common.BKMaxLimitSize {
+		end := start + common.BKMaxLimitSize
+		if end > len(hostIDs) {
+			end = len(hostIDs)
+		}
+
+		driftOpt := &metadata.SearchHostSnapDriftOption{
+			HostIDs:     hostIDs[start:end],
+			PropertyIDs: option.PropertyIDs,
+			Page:        metadata.BasePage{Limit: common.BKMaxPageSize},
+		}
+		for {
+			result, err := lgc.CoreAPI.CoreService().Host().SearchHostSnapDrift(kit.Ctx, kit.Header, driftOpt)
+			if err != nil {
+				blog.Errorf("search host snapshot drifts failed, option: %+v, err: %v, rid: %s", driftOpt, err,
+					kit.Rid)
+				return nil, err
+			}
+
+			drifts = append(drifts, result.Info...)
+			if len(result.Info) < driftOpt.Page.Limit {
+				break
+			}
+			driftOpt.Page.Start += driftOpt.Page.Limit
+		}
+	}
+
+	return summarizeHostSnapDrifts(hostModules, drifts), nil
+}
+
+// summarizeHostSnapDrifts summarize the drifts by the modules of the drifted hosts, sorted by module id
+func summarizeHostSnapDrifts(hostModules map[int64][]int64,
+	drifts []metadata.HostSnapDrift) []metadata.ModuleHostSnapDriftSummary {
+
+	summaryMap := make(map[int64]*metadata.ModuleHostSnapDriftSummary)
+	moduleHosts := make(map[int64]map[int64]struct{})
+	for _, drift := range drifts {
+		for _, moduleID := range hostModules[drift.HostID] {
+			summary, exists := summaryMap[moduleID]
+			if !exists {
+				summary = &metadata.ModuleHostSnapDriftSummary{
+					ModuleID:      moduleID,
+					PropertyCount: make(map[string]int64),
+				}
+				summaryMap[moduleID] = summary
+				moduleHosts[moduleID] = make(map[int64]struct{})
+			}
+
+			summary.DriftCount++
+			summary.PropertyCount[drift.PropertyID]++
+			if _, exists := moduleHosts[moduleID][drift.HostID]; !exists {
+				moduleHosts[moduleID][drift.HostID] = struct{}{}
+				summary.HostCount++
+			}
+		}
+	}
+
+	summaries := make([]metadata.ModuleHostSnapDriftSummary, 0)
+	for _, summary := range summaryMap {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ModuleID < summaries[j].ModuleID
+	})
+	return summaries
+}
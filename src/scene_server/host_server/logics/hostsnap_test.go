@@ -0,0 +1,49 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"testing"
+
+	"configcenter/src/common/metadata"
+)
+
+func TestSummarizeHostSnapDrifts(t *testing.T) {
+	hostModules := map[int64][]int64{
+		1: {10},
+		2: {10, 20},
+		3: {30},
+	}
+	drifts := []metadata.HostSnapDrift{
+		{HostID: 1, PropertyID: "bk_cpu"},
+		{HostID: 1, PropertyID: "bk_mem"},
+		{HostID: 2, PropertyID: "bk_cpu"},
+		// the host is no longer in the business
+		{HostID: 4, PropertyID: "bk_cpu"},
+	}
+
+	summaries := summarizeHostSnapDrifts(hostModules, drifts)
+	if len(summaries) != 2 {
+		t.Fatalf("summaries %+v should contains module 10 and 20", summaries)
+	}
+
+	if summaries[0].ModuleID != 10 || summaries[0].HostCount != 2 || summaries[0].DriftCount != 3 ||
+		summaries[0].PropertyCount["bk_cpu"] != 2 || summaries[0].PropertyCount["bk_mem"] != 1 {
+		t.Errorf("module 10 summary %+v is invalid", summaries[0])
+	}
+
+	if summaries[1].ModuleID != 20 || summaries[1].HostCount != 1 || summaries[1].DriftCount != 1 ||
+		summaries[1].PropertyCount["bk_cpu"] != 1 {
+		t.Errorf("module 20 summary %+v is invalid", summaries[1])
+	}
+}
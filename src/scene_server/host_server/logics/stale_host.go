@@ -0,0 +1,56 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// ValidateStaleHostPolicyState check if the state that the stale hosts change to is an option of the host bk_state
+// field, the state transitions of the stale hosts are still checked by the host lifecycle when they are updated.
+func (lgc *Logics) ValidateStaleHostPolicyState(kit *rest.Kit, policy *metadata.StaleHostPolicy) error {
+	if !policy.Enabled || policy.Action != metadata.StaleHostActionUpdateState {
+		return nil
+	}
+
+	attributes, err := lgc.GetHostAttributes(kit, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, attribute := range attributes {
+		if attribute.PropertyID != common.BKHostState {
+			continue
+		}
+
+		options, err := metadata.ParseEnumOption(kit.Ctx, attribute.Option)
+		if err != nil {
+			blog.Errorf("parse host state options failed, option: %v, err: %v, rid: %s", attribute.Option, err,
+				kit.Rid)
+			return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKHostState)
+		}
+
+		for _, option := range options {
+			if option.ID == policy.State {
+				return nil
+			}
+		}
+		break
+	}
+
+	blog.Errorf("stale host state %s is not an option of %s, rid: %s", policy.State, common.BKHostState, kit.Rid)
+	return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "state")
+}
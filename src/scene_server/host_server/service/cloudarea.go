@@ -351,6 +351,10 @@ func (s *Service) DeletePlat(ctx *rest.Contexts) {
 			return ctx.Kit.CCError.Errorf(common.CCErrTopoInstDeleteFailed)
 		}
 
+		if err := s.deletePlatSubnets(ctx.Kit, platID); err != nil {
+			return err
+		}
+
 		// save audit log.
 		if err := audit.SaveAuditLog(ctx.Kit, logs...); err != nil {
 			blog.Errorf("save audit log failed after delete cloud area, err: %v, rid: %s", err, ctx.Kit.Rid)
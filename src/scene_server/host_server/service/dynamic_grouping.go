@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"time"
 
+	"configcenter/src/ac"
 	"configcenter/src/ac/iam"
 	"configcenter/src/common"
 	"configcenter/src/common/auditlog"
@@ -48,6 +49,11 @@ func (s *Service) CreateDynamicGroup(ctx *rest.Contexts) {
 		ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrCommParamsIsInvalid, err.Error()))
 		return
 	}
+
+	if !s.authorizeDynamicGroup(ctx, &newDynamicGroup) {
+		return
+	}
+
 	newDynamicGroup.CreateUser = ctx.Kit.User
 	newDynamicGroup.CreateTime = time.Now().UTC()
 	response := &meta.IDResult{}
@@ -186,6 +192,11 @@ func (s *Service) UpdateDynamicGroup(ctx *rest.Contexts) {
 			ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrCommParamsIsInvalid, err.Error()))
 			return
 		}
+
+		group := &meta.DynamicGroup{AppID: bizIDInt64, ObjID: objectID, Info: *dynamicGroupInfo}
+		if !s.authorizeDynamicGroup(ctx, group) {
+			return
+		}
 		updates[common.BKObjIDField] = objectID
 		updates["info"] = dynamicGroupInfo
 
@@ -447,92 +458,125 @@ func (s *Service) ExecuteDynamicGroup(ctx *rest.Contexts) {
 		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommJSONUnmarshalFailed))
 		return
 	}
-	searchPage := input.Page
 
 	// query target dynamic group.
-	result, err := s.CoreAPI.CoreService().Host().GetDynamicGroup(ctx.Kit.Ctx, bizID, targetID, ctx.Kit.Header)
+	targetDynamicGroup, err := s.getDynamicGroup(ctx.Kit, bizID, targetID)
 	if err != nil {
-		blog.Errorf("execute dynamic group failed, err: %+v, bizID: %s, ID: %s, rid: %s", err, bizID, targetID, ctx.Kit.Rid)
-		ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrGetUserCustomQueryDetailFailed, err.Error()))
+		ctx.RespAutoError(err)
 		return
 	}
-	if !result.Result {
-		blog.Errorf("execute dynamic group failed, errcode: %d, errmsg: %s, bizID: %s, ID: %s, rid: %s",
-			result.Code, result.ErrMsg, bizID, targetID, ctx.Kit.Rid)
-		ctx.RespAutoError(result.CCError())
+	targetDynamicGroup.AppID = bizIDInt64
+
+	if !s.authorizeDynamicGroup(ctx, targetDynamicGroup) {
 		return
 	}
-	if len(result.Data.Name) == 0 {
-		blog.Errorf("execute dynamic group failed, group not found, bizID: %s, ID: %s, rid: %s", bizID, targetID, ctx.Kit.Rid)
-		ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrCommNotFound))
+
+	// execute dynamic group with target object type.
+	data, err := logics.NewLogics(s.Engine, s.CacheDB, s.AuthManager).ExecuteDynamicGroup(ctx.Kit,
+		targetDynamicGroup, input, nil)
+	if err != nil {
+		blog.Errorf("execute dynamic group failed, search %s instances, err: %+v, bizID: %s, ID: %s, rid: %s",
+			targetDynamicGroup.ObjID, err, bizID, targetID, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrGetUserCustomQueryDetailFailed, err.Error()))
 		return
 	}
 
-	// target dynamic group.
-	targetDynamicGroup := result.Data
+	ctx.RespEntity(meta.InstDataInfo{
+		Count:      data.Count,
+		Info:       data.Info,
+		NextCursor: data.NextCursor,
+	})
+}
 
-	// build search conditions.
-	searchConditions := []meta.SearchCondition{}
+// WatchDynamicGroup watches the membership changes of target dynamic group.
+func (s *Service) WatchDynamicGroup(ctx *rest.Contexts) {
+	req := ctx.Request
 
-	// parse all dynamic group conditions to search condition.
-	for _, cond := range targetDynamicGroup.Info.Condition {
-		searchCondition := meta.SearchCondition{ObjectID: cond.ObjID, Condition: []meta.ConditionItem{}}
+	// application ID.
+	bizID := req.PathParameter("bk_biz_id")
 
-		// build condition items.
-		for _, item := range cond.Condition {
-			condItem := meta.ConditionItem{Field: item.Field, Operator: item.Operator, Value: item.Value}
-			searchCondition.Condition = append(searchCondition.Condition, condItem)
-		}
-		searchCondition.TimeCondition = cond.TimeCondition
-		searchConditions = append(searchConditions, searchCondition)
-	}
+	// target dynamic group ID.
+	targetID := req.PathParameter("id")
 
-	// execute dynamic group with target object type.
-	if targetDynamicGroup.ObjID == common.BKInnerObjIDHost {
-		// build host search conditions.
-		searchHostCondition := meta.HostCommonSearch{AppID: bizIDInt64, Condition: searchConditions, Page: searchPage}
+	bizIDInt64, err := strconv.ParseInt(bizID, 10, 64)
+	if err != nil {
+		blog.Errorf("watch dynamic group failed, invalid bizID from path, bizID: %s, rid: %s", bizID, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrCommParamsIsInvalid, "bk_biz_id"))
+		return
+	}
 
-		// execute host object dynamic group.
-		data, err := logics.NewLogics(s.Engine, s.CacheDB, s.AuthManager).
-			ExecuteHostDynamicGroup(ctx.Kit, &searchHostCondition, input.Fields, input.DisableCounter)
-		if err != nil {
-			blog.Errorf("execute dynamic group failed, search hosts, err: %+v, bizID: %s, ID: %s, rid: %s",
-				err, bizID, targetID, ctx.Kit.Rid)
-			ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrGetUserCustomQueryDetailFailed, err.Error()))
-			return
-		}
+	input := new(meta.WatchDynamicGroupOption)
+	if err := ctx.DecodeInto(input); err != nil {
+		blog.Errorf("watch dynamic group failed, decode request body err: %+v, rid: %s", err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommJSONUnmarshalFailed))
+		return
+	}
 
-		ctx.RespEntity(meta.InstDataInfo{
-			Count: data.Count,
-			Info:  data.Info,
-		})
+	if err := input.Validate(); err != nil {
+		blog.Errorf("watch dynamic group failed, invalid input: %+v, err: %v, rid: %s", input, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrCommParamsIsInvalid, err.Error()))
 		return
+	}
 
-	} else if targetDynamicGroup.ObjID == common.BKInnerObjIDSet {
-		// build host search conditions.
-		searchSetCondition := meta.SetCommonSearch{AppID: bizIDInt64, Condition: searchConditions, Page: searchPage}
+	// query target dynamic group.
+	targetDynamicGroup, err := s.getDynamicGroup(ctx.Kit, bizID, targetID)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	targetDynamicGroup.AppID = bizIDInt64
 
-		// execute set object dynamic group.
-		data, err := logics.NewLogics(s.Engine, s.CacheDB, s.AuthManager).
-			ExecuteSetDynamicGroup(ctx.Kit, &searchSetCondition, input.Fields, input.DisableCounter)
-		if err != nil {
-			blog.Errorf("execute dynamic group failed, search sets, err: %+v, bizID: %s, ID: %s, rid: %s",
-				err, bizID, targetID, ctx.Kit.Rid)
-			ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrGetUserCustomQueryDetailFailed, err.Error()))
-			return
-		}
+	if !s.authorizeDynamicGroup(ctx, targetDynamicGroup) {
+		return
+	}
 
-		ctx.RespEntity(meta.InstDataInfo{
-			Count: data.Count,
-			Info:  data.Info,
-		})
+	result, err := logics.NewLogics(s.Engine, s.CacheDB, s.AuthManager).WatchDynamicGroup(ctx.Kit,
+		targetDynamicGroup, input)
+	if err != nil {
+		blog.Errorf("watch dynamic group failed, err: %+v, bizID: %s, ID: %s, rid: %s", err, bizID, targetID,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(err)
 		return
 	}
 
-	// unknown group object type, should no-reach here.
-	blog.Errorf("execute dynamic group failed, unknown group object type[%s], bizID: %s, ID: %s, rid: %s",
-		targetDynamicGroup.ObjID, bizID, targetID, ctx.Kit.Rid)
-	ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCSystemUnknownError))
+	ctx.RespEntity(result)
+}
+
+// getDynamicGroup gets target dynamic group, returns not found error if the group is not exist.
+func (s *Service) getDynamicGroup(kit *rest.Kit, bizID, id string) (*meta.DynamicGroup, error) {
+	result, err := s.CoreAPI.CoreService().Host().GetDynamicGroup(kit.Ctx, bizID, id, kit.Header)
+	if err != nil {
+		blog.Errorf("get dynamic group failed, err: %+v, bizID: %s, ID: %s, rid: %s", err, bizID, id, kit.Rid)
+		return nil, kit.CCError.Errorf(common.CCErrGetUserCustomQueryDetailFailed, err.Error())
+	}
+	if !result.Result {
+		blog.Errorf("get dynamic group failed, errcode: %d, errmsg: %s, bizID: %s, ID: %s, rid: %s",
+			result.Code, result.ErrMsg, bizID, id, kit.Rid)
+		return nil, result.CCError()
+	}
+	if len(result.Data.Name) == 0 {
+		blog.Errorf("get dynamic group failed, group not found, bizID: %s, ID: %s, rid: %s", bizID, id, kit.Rid)
+		return nil, kit.CCError.Errorf(common.CCErrCommNotFound)
+	}
+
+	return &result.Data, nil
+}
+
+// authorizeDynamicGroup checks if the user can read the instances that the dynamic group uses, responds the
+// permission to apply and returns false if the user can not.
+func (s *Service) authorizeDynamicGroup(ctx *rest.Contexts, group *meta.DynamicGroup) bool {
+	perm, err := logics.NewLogics(s.Engine, s.CacheDB, s.AuthManager).AuthorizeDynamicGroup(ctx.Kit, group)
+	if err != nil {
+		blog.Errorf("authorize dynamic group %s failed, err: %v, rid: %s", group.ID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return false
+	}
+
+	if perm != nil {
+		ctx.RespEntityWithError(perm, ac.NoAuthorizeError)
+		return false
+	}
+	return true
 }
 
 // changeTimeToMatchLocalZone TODO
@@ -538,6 +538,10 @@ func (s *Service) listBizHosts(ctx *rest.Contexts, bizID int64, parameter meta.L
 		return result, defErr.CCErrorf(common.CCErrCommParamsInvalid, "page.limit")
 	}
 
+	if ccErr := validateHostCursorPage(ctx.Kit, parameter.Page); ccErr != nil {
+		return result, ccErr
+	}
+
 	if len(parameter.SetIDs) != 0 && len(parameter.SetCond) != 0 {
 		blog.Errorf("ListBizHosts failed, bk_set_ids and set_cond can't both be set, rid:%s", ctx.Kit.Rid)
 		return result, defErr.CCErrorf(common.CCErrCommParamsInvalid, "bk_set_ids and set_cond can't both be set")
@@ -631,6 +635,11 @@ func (s *Service) ListHostsWithNoBiz(ctx *rest.Contexts) {
 	}
 
 	parameter.Page.Sort = common.BKHostIDField
+	if ccErr := validateHostCursorPage(ctx.Kit, parameter.Page); ccErr != nil {
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
 	option := &meta.ListHosts{
 		HostPropertyFilter: parameter.HostPropertyFilter,
 		Fields:             parameter.Fields,
@@ -1072,3 +1081,17 @@ func (s *Service) ListHostTotalMainlineTopo(ctx *rest.Contexts) {
 
 	ctx.RespEntityWithCount(int64(len(rsp)), rsp)
 }
+
+// validateHostCursorPage validates the keyset pagination options of the host search page, the hosts are sorted by
+// the host id when using cursor.
+func validateHostCursorPage(kit *rest.Kit, page meta.BasePage) errors.CCErrorCoder {
+	if !page.IsCursorPage() {
+		return nil
+	}
+
+	if _, err := page.ParsePageCursor(common.BKHostIDField); err != nil {
+		blog.Errorf("host search page cursor is invalid, err: %v, page: %+v, rid: %s", err, page, kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "page.cursor")
+	}
+	return nil
+}
@@ -1386,6 +1386,32 @@ func (s *Service) UpdateImportHosts(ctx *rest.Contexts) {
 		return
 	}
 
+	// skip the hosts that are locked by other users, unless the caller overrides the host locks
+	if len(ctx.Kit.Header.Get(common.BKHTTPHostLockOverride)) == 0 {
+		hostLocks, err := s.Logic.GetOthersHostLocks(ctx.Kit, hostIDArr)
+		if err != nil {
+			ctx.RespAutoError(err)
+			return
+		}
+
+		for _, index := range util.SortedMapInt64Keys(hosts) {
+			lock, exists := hostLocks[indexHostIDMap[index]]
+			if !exists {
+				continue
+			}
+			errMsg = append(errMsg, CCLang.Languagef("import_host_locked", index, lock.User, lock.Reason))
+			delete(hosts, index)
+		}
+
+		if len(hosts) == 0 {
+			ctx.RespEntity(map[string]interface{}{
+				"error":   errMsg,
+				"success": []string{},
+			})
+			return
+		}
+	}
+
 	// audit interface of host audit log.
 	audit := auditlog.NewHostAudit(s.CoreAPI.CoreService())
 	auditContexts := make([]meta.AuditLog, 0)
@@ -0,0 +1,374 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	authmeta "configcenter/src/ac/meta"
+	"configcenter/src/common"
+	"configcenter/src/common/auditlog"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/json"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+)
+
+// PreviewBulkUpdateHost preview the bulk update of the hosts in the business that match the filter, returns the
+// number of the hosts to be updated, the sample host changes, the per field diffs and the blocked hosts.
+func (s *Service) PreviewBulkUpdateHost(ctx *rest.Contexts) {
+	bizID, option, hosts, blocked, ok := s.prepareBulkUpdateHost(ctx)
+	if !ok {
+		return
+	}
+
+	blog.V(4).Infof("preview bulk update %d hosts of biz %d, rid: %s", len(hosts), bizID, ctx.Kit.Rid)
+	ctx.RespEntity(metadata.NewBulkUpdateHostPreview(hosts, option.Data, blocked))
+}
+
+// BulkUpdateHostByFilter update the hosts in the business that match the filter asynchronously, the blocked hosts are
+// skipped, and the other hosts are updated in batches by the task server.
+func (s *Service) BulkUpdateHostByFilter(ctx *rest.Contexts) {
+	bizID, option, hosts, blocked, ok := s.prepareBulkUpdateHost(ctx)
+	if !ok {
+		return
+	}
+
+	result := metadata.BulkUpdateHostTaskResponse{Count: len(hosts), BlockedHosts: blocked}
+	if len(hosts) == 0 {
+		ctx.RespEntity(result)
+		return
+	}
+
+	hostIDs := make([]int64, len(hosts))
+	for idx, host := range hosts {
+		hostIDs[idx], _ = host.Int64(common.BKHostIDField)
+	}
+
+	taskData := make([]interface{}, 0)
+	for start := 0; start < len(hostIDs); start += metadata.BulkUpdateHostBatchSize {
+		end := start + metadata.BulkUpdateHostBatchSize
+		if end > len(hostIDs) {
+			end = len(hostIDs)
+		}
+		taskData = append(taskData, metadata.BulkUpdateHostTaskData{
+			BizID:   bizID,
+			HostIDs: hostIDs[start:end],
+			Data:    option.Data,
+		})
+	}
+
+	// the task uses biz id as its instance id, so that the concurrent bulk update in a business is rejected
+	task, err := s.CoreAPI.TaskServer().Task().Create(ctx.Kit.Ctx, ctx.Kit.Header, common.BulkUpdateHostTaskFlag,
+		bizID, taskData)
+	if err != nil {
+		blog.Errorf("create bulk update host task failed, biz: %d, err: %v, rid: %s", bizID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result.TaskID = task.TaskID
+	ctx.RespEntity(result)
+}
+
+// prepareBulkUpdateHost decode and validate the bulk update host option, find the matched hosts and check if the user
+// can update them. the hosts that are locked by others or whose updated fields are managed by host apply rules are
+// returned as the blocked hosts, and the remaining hosts are returned to be updated.
+func (s *Service) prepareBulkUpdateHost(ctx *rest.Contexts) (int64, *metadata.BulkUpdateHostOption, []mapstr.MapStr,
+	[]metadata.BulkUpdateHostError, bool) {
+
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil || bizID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsIsInvalid, common.BKAppIDField))
+		return 0, nil, nil, nil, false
+	}
+
+	option := new(metadata.BulkUpdateHostOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return 0, nil, nil, nil, false
+	}
+
+	attrCond := make(map[string]interface{})
+	util.AddModelBizIDCondition(attrCond, bizID)
+	attrs, err := s.Logic.GetHostAttributes(ctx.Kit, attrCond)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return 0, nil, nil, nil, false
+	}
+
+	if rawErr := option.Validate(attrs); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return 0, nil, nil, nil, false
+	}
+
+	hosts, ccErr := s.Logic.FindBizHostsByFilter(ctx.Kit, bizID, option)
+	if ccErr != nil {
+		ctx.RespAutoError(ccErr)
+		return 0, nil, nil, nil, false
+	}
+
+	if len(hosts) == 0 {
+		return bizID, option, hosts, make([]metadata.BulkUpdateHostError, 0), true
+	}
+
+	hostIDs := make([]int64, len(hosts))
+	for idx, host := range hosts {
+		hostIDs[idx], _ = host.Int64(common.BKHostIDField)
+	}
+
+	if err := s.AuthManager.AuthorizeByHostsIDs(ctx.Kit.Ctx, ctx.Kit.Header, authmeta.Update,
+		hostIDs...); err != nil {
+		blog.Errorf("check host authorization failed, biz: %d, err: %v, rid: %s", bizID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommAuthorizeFailed))
+		return 0, nil, nil, nil, false
+	}
+
+	blockedMsg, ok := s.getBulkUpdateBlockedHosts(ctx, bizID, hostIDs, hosts, option.Data, attrs)
+	if !ok {
+		return 0, nil, nil, nil, false
+	}
+
+	allowed := make([]mapstr.MapStr, 0)
+	blocked := make([]metadata.BulkUpdateHostError, 0)
+	for idx, host := range hosts {
+		msg, exists := blockedMsg[hostIDs[idx]]
+		if !exists {
+			allowed = append(allowed, host)
+			continue
+		}
+		blocked = append(blocked, metadata.BulkUpdateHostError{HostID: hostIDs[idx], Message: msg})
+	}
+
+	return bizID, option, allowed, blocked, true
+}
+
+// getBulkUpdateBlockedHosts returns the hosts that can not be updated and the reason, a host is blocked if it is
+// locked by another user, or if one of the updated fields is managed by a host apply rule that takes effect on it.
+func (s *Service) getBulkUpdateBlockedHosts(ctx *rest.Contexts, bizID int64, hostIDs []int64, hosts []mapstr.MapStr,
+	data mapstr.MapStr, attrs []metadata.Attribute) (map[int64]string, bool) {
+
+	blocked := make(map[int64]string)
+
+	// the override header is only kept if the user is authorized to override host locks by hostLockOverrideFilter
+	if len(ctx.Kit.Header.Get(common.BKHTTPHostLockOverride)) == 0 {
+		hostLocks, err := s.Logic.GetOthersHostLocks(ctx.Kit, hostIDs)
+		if err != nil {
+			ctx.RespAutoError(err)
+			return nil, false
+		}
+
+		for hostID, lock := range hostLocks {
+			blocked[hostID] = ctx.Kit.CCError.CCErrorf(common.CCErrCoreServiceHostLocked, hostID, lock.User,
+				lock.Reason).Error()
+		}
+	}
+
+	attrPropertyMap := make(map[int64]string)
+	for _, attr := range attrs {
+		attrPropertyMap[attr.ID] = attr.PropertyID
+	}
+
+	hostRules, ccErr := s.listHostRelatedApplyRule(ctx, bizID,
+		metadata.ListHostRelatedApplyRuleOption{HostIDs: hostIDs})
+	if ccErr != nil {
+		ctx.RespAutoError(ccErr)
+		return nil, false
+	}
+
+	for idx, host := range hosts {
+		if _, exists := blocked[hostIDs[idx]]; exists {
+			continue
+		}
+
+		for _, rule := range hostRules[hostIDs[idx]] {
+			field := attrPropertyMap[rule.AttributeID]
+			if _, exists := data[field]; !exists {
+				continue
+			}
+
+			if rule.IsConditional() {
+				matched, err := rule.Condition.Match(host)
+				if err != nil {
+					blog.Errorf("match host apply rule %d failed, host: %d, err: %v, rid: %s", rule.ID, hostIDs[idx],
+						err, ctx.Kit.Rid)
+					ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommParamsInvalid))
+					return nil, false
+				}
+				if !matched {
+					continue
+				}
+			}
+
+			blocked[hostIDs[idx]] = ctx.Kit.CCError.CCErrorf(common.CCErrCoreServiceHostFieldManagedByApplyRule,
+				hostIDs[idx], field, rule.ID).Error()
+			break
+		}
+	}
+
+	return blocked, true
+}
+
+// ExecBulkUpdateHostTask execute a sub task of the bulk host update task, the hosts are updated one by one so that
+// the failure of a host does not affect the others, and the failed hosts are returned with the reasons.
+func (s *Service) ExecBulkUpdateHostTask(ctx *rest.Contexts) {
+	taskData := new(metadata.BulkUpdateHostTaskData)
+	if err := ctx.DecodeInto(taskData); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result, err := s.updateHostsOneByOne(ctx.Kit, taskData.BizID, taskData.HostIDs, taskData.Data)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// updateHostsOneByOne update the hosts one by one so that the failure of a host does not affect the others, the
+// failed hosts are returned with the reasons, and the audit logs of the updated hosts are saved.
+func (s *Service) updateHostsOneByOne(kit *rest.Kit, bizID int64, hostIDs []int64, data mapstr.MapStr) (
+	*metadata.BulkUpdateHostTaskResult, error) {
+
+	result := &metadata.BulkUpdateHostTaskResult{Failed: make([]metadata.BulkUpdateHostError, 0)}
+	if len(hostIDs) == 0 {
+		return result, nil
+	}
+
+	audit := auditlog.NewHostAudit(s.CoreAPI.CoreService())
+	genAuditParam := auditlog.NewGenerateAuditCommonParameter(kit, metadata.AuditUpdate).WithUpdateFields(data)
+	auditCond := map[string]interface{}{common.BKHostIDField: map[string]interface{}{common.BKDBIN: hostIDs}}
+	auditLogs, err := audit.GenerateAuditLogByCond(genAuditParam, bizID, auditCond)
+	if err != nil {
+		blog.Errorf("generate host audit log failed, hosts: %v, err: %v, rid: %s", hostIDs, err, kit.Rid)
+		return nil, err
+	}
+
+	succeeded := make(map[int64]struct{})
+	for _, hostID := range hostIDs {
+		opt := &metadata.UpdateOption{
+			Condition: mapstr.MapStr{common.BKHostIDField: hostID},
+			Data:      data,
+		}
+		_, err := s.CoreAPI.CoreService().Instance().UpdateInstance(kit.Ctx, kit.Header, common.BKInnerObjIDHost, opt)
+		if err != nil {
+			blog.Errorf("update host %d failed, data: %v, err: %v, rid: %s", hostID, data, err, kit.Rid)
+			result.Failed = append(result.Failed, metadata.BulkUpdateHostError{HostID: hostID, Message: err.Error()})
+			continue
+		}
+		succeeded[hostID] = struct{}{}
+	}
+	result.SuccessCount = len(succeeded)
+
+	succeededLogs := make([]metadata.AuditLog, 0)
+	for _, auditLog := range auditLogs {
+		hostID, err := util.GetInt64ByInterface(auditLog.ResourceID)
+		if err != nil {
+			continue
+		}
+		if _, exists := succeeded[hostID]; exists {
+			succeededLogs = append(succeededLogs, auditLog)
+		}
+	}
+
+	if err := audit.SaveAuditLog(kit, succeededLogs...); err != nil {
+		blog.Errorf("save host audit log failed after update host, err: %v, rid: %s", err, kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetBulkUpdateHostTaskStatus get the progress and the per host errors of the bulk host update task in the business
+func (s *Service) GetBulkUpdateHostTaskStatus(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil || bizID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsIsInvalid, common.BKAppIDField))
+		return
+	}
+	taskID := ctx.Request.PathParameter(common.BKTaskIDField)
+
+	resp, err := s.CoreAPI.TaskServer().Task().TaskDetail(ctx.Kit.Ctx, ctx.Kit.Header, taskID)
+	if err != nil {
+		blog.Errorf("get bulk update host task %s failed, err: %v, rid: %s", taskID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommHTTPDoRequestFailed))
+		return
+	}
+	if ccErr := resp.CCError(); ccErr != nil {
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	task := resp.Data.Info
+	if task.TaskType != common.BulkUpdateHostTaskFlag || task.InstID != bizID {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommNotFound))
+		return
+	}
+
+	status := metadata.BulkUpdateHostTaskStatus{
+		TaskID: task.TaskID,
+		Status: task.Status,
+		Failed: make([]metadata.BulkUpdateHostError, 0),
+	}
+
+	for _, subTask := range task.Detail {
+		taskData := new(metadata.BulkUpdateHostTaskData)
+		if err := decodeBulkUpdateHostTaskField(subTask.Data, taskData); err != nil {
+			blog.Errorf("decode sub task %s data failed, err: %v, rid: %s", subTask.SubTaskID, err, ctx.Kit.Rid)
+			ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommJSONUnmarshalFailed))
+			return
+		}
+		status.TotalCount += len(taskData.HostIDs)
+
+		switch subTask.Status {
+		case metadata.APITaskStatusSuccess:
+			if subTask.Response == nil {
+				continue
+			}
+			result := new(metadata.BulkUpdateHostTaskResult)
+			if err := decodeBulkUpdateHostTaskField(subTask.Response.Data, result); err != nil {
+				blog.Errorf("decode sub task %s result failed, err: %v, rid: %s", subTask.SubTaskID, err, ctx.Kit.Rid)
+				ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommJSONUnmarshalFailed))
+				return
+			}
+			status.FinishedCount += len(taskData.HostIDs)
+			status.SuccessCount += result.SuccessCount
+			status.Failed = append(status.Failed, result.Failed...)
+		case metadata.APITAskStatusFail:
+			// the sub task failed as a whole, all of its hosts are regarded as failed
+			status.FinishedCount += len(taskData.HostIDs)
+			msg := ""
+			if subTask.Response != nil {
+				msg = subTask.Response.ErrMsg
+			}
+			for _, hostID := range taskData.HostIDs {
+				status.Failed = append(status.Failed, metadata.BulkUpdateHostError{HostID: hostID, Message: msg})
+			}
+		}
+	}
+
+	ctx.RespEntity(status)
+}
+
+// decodeBulkUpdateHostTaskField decode the sub task data or response data that is stored as a generic value
+func decodeBulkUpdateHostTaskField(value interface{}, result interface{}) error {
+	js, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(js, result)
+}
@@ -0,0 +1,54 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// UpdateHostLifecycle update the host lifecycle state machine of the supplier account, the lifecycle is disabled
+// if no state is set.
+func (s *Service) UpdateHostLifecycle(ctx *rest.Contexts) {
+	lifecycle := new(metadata.HostLifecycle)
+	if err := ctx.DecodeInto(lifecycle); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := lifecycle.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	err := s.CoreAPI.CoreService().Host().SaveHostLifecycle(ctx.Kit.Ctx, ctx.Kit.Header, lifecycle)
+	if err != nil {
+		blog.Errorf("save host lifecycle failed, lifecycle: %+v, err: %v, rid: %s", lifecycle, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// FindHostLifecycle find the host lifecycle state machine of the supplier account
+func (s *Service) FindHostLifecycle(ctx *rest.Contexts) {
+	lifecycle, err := s.Logic.GetHostLifecycle(ctx.Kit)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(lifecycle)
+}
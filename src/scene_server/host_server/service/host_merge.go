@@ -0,0 +1,113 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	authmeta "configcenter/src/ac/meta"
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// FindDuplicateHosts find the candidate duplicate hosts that share the same value of the matching fields, such as
+// bk_sn, bk_mac, bk_asset_id and bk_cloud_inst_id.
+func (s *Service) FindDuplicateHosts(ctx *rest.Contexts) {
+	option := new(metadata.FindDuplicateHostOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	groups, err := s.CoreAPI.CoreService().Host().FindDuplicateHosts(ctx.Kit.Ctx, ctx.Kit.Header, option)
+	if err != nil {
+		blog.Errorf("find duplicate hosts failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(groups)
+}
+
+// PreviewMergeHost preview the module relations, service instances, processes, associations and host apply modules
+// that would be moved from the duplicate hosts to the kept host.
+func (s *Service) PreviewMergeHost(ctx *rest.Contexts) {
+	option, ok := s.decodeMergeHostOption(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := s.CoreAPI.CoreService().Host().PreviewMergeHost(ctx.Kit.Ctx, ctx.Kit.Header, option)
+	if err != nil {
+		blog.Errorf("preview merge host failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// MergeHost merge the duplicate hosts into the kept host, the duplicate hosts are deleted after their data is moved
+func (s *Service) MergeHost(ctx *rest.Contexts) {
+	option, ok := s.decodeMergeHostOption(ctx)
+	if !ok {
+		return
+	}
+
+	var result *metadata.MergeHostResult
+	txnErr := s.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
+		var err error
+		result, err = s.CoreAPI.CoreService().Host().MergeHost(ctx.Kit.Ctx, ctx.Kit.Header, option)
+		if err != nil {
+			blog.Errorf("merge host failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+			return err
+		}
+		return nil
+	})
+
+	if txnErr != nil {
+		ctx.RespAutoError(txnErr)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// decodeMergeHostOption decode and validate the merge host option, and check if the user can update all the hosts
+func (s *Service) decodeMergeHostOption(ctx *rest.Contexts) (*metadata.MergeHostOption, bool) {
+	option := new(metadata.MergeHostOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return nil, false
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return nil, false
+	}
+
+	hostIDs := append([]int64{option.HostID}, option.DuplicateHostIDs...)
+	if err := s.AuthManager.AuthorizeByHostsIDs(ctx.Kit.Ctx, ctx.Kit.Header, authmeta.Update,
+		hostIDs...); err != nil {
+		blog.Errorf("check host authorization failed, hosts: %v, err: %v, rid: %s", hostIDs, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommAuthorizeFailed))
+		return nil, false
+	}
+
+	return option, true
+}
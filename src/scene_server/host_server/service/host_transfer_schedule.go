@@ -0,0 +1,224 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// CreateHostTransferSchedule schedule a host transfer with auto clear service instance plan at a future time, the
+// plan is validated and previewed with the current topology, and executed by the task server at the execute time.
+func (s *Service) CreateHostTransferSchedule(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil || bizID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsIsInvalid, common.BKAppIDField))
+		return
+	}
+
+	option := new(metadata.CreateHostTransferScheduleOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	transferOpt := option.TransferHostWithAutoClearServiceInstanceOption
+	if ccErr := s.validateTransferHostWithAutoClearServiceInstanceOption(ctx.Kit, bizID, &transferOpt); ccErr != nil {
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	previews, err := s.generateTransferPreviews(ctx, bizID, transferOpt)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	schedule := &metadata.HostTransferSchedule{
+		BizID:       bizID,
+		Option:      option.TransferHostWithAutoClearServiceInstanceOption,
+		ExecuteTime: option.ExecuteTime,
+	}
+	schedule, ccErr := s.CoreAPI.CoreService().Host().CreateHostTransferSchedule(ctx.Kit.Ctx, ctx.Kit.Header, schedule)
+	if ccErr != nil {
+		blog.Errorf("create host transfer schedule failed, biz: %d, err: %v, rid: %s", bizID, ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	ctx.RespEntity(metadata.CreateHostTransferScheduleResult{ID: schedule.ID, Previews: previews})
+}
+
+// ListHostTransferSchedule list the host transfer schedules of the business
+func (s *Service) ListHostTransferSchedule(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil || bizID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsIsInvalid, common.BKAppIDField))
+		return
+	}
+
+	option := new(metadata.ListHostTransferScheduleOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	option.BizID = bizID
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, ccErr := s.CoreAPI.CoreService().Host().ListHostTransferSchedule(ctx.Kit.Ctx, ctx.Kit.Header, option)
+	if ccErr != nil {
+		blog.Errorf("list host transfer schedules failed, option: %+v, err: %v, rid: %s", option, ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// CancelHostTransferSchedule cancel the waiting host transfer schedule, the executing or finished ones can not be
+// canceled.
+func (s *Service) CancelHostTransferSchedule(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil || bizID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsIsInvalid, common.BKAppIDField))
+		return
+	}
+
+	id, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKFieldID), 10, 64)
+	if err != nil || id <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsIsInvalid, common.BKFieldID))
+		return
+	}
+
+	if _, ccErr := s.getHostTransferSchedule(ctx.Kit, bizID, id); ccErr != nil {
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	option := &metadata.UpdateHostTransferScheduleStatusOption{
+		ID:   id,
+		From: []metadata.HostTransferScheduleStatus{metadata.HostTransferScheduleWaiting},
+		To:   metadata.HostTransferScheduleCanceled,
+	}
+	if ccErr := s.CoreAPI.CoreService().Host().UpdateHostTransferScheduleStatus(ctx.Kit.Ctx, ctx.Kit.Header,
+		option); ccErr != nil {
+		blog.Errorf("cancel host transfer schedule %d failed, err: %v, rid: %s", id, ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// ExecHostTransferScheduleTask execute the host transfer schedule dispatched by the task server on behalf of the
+// requester of the schedule, the result is recorded as the status of the schedule.
+func (s *Service) ExecHostTransferScheduleTask(ctx *rest.Contexts) {
+	taskData := new(metadata.HostTransferScheduleTaskData)
+	if err := ctx.DecodeInto(taskData); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	schedule, ccErr := s.getHostTransferSchedule(ctx.Kit, taskData.BizID, taskData.ID)
+	if ccErr != nil {
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	if schedule.Status != metadata.HostTransferScheduleExecuting {
+		blog.Infof("host transfer schedule %d status is %s, skip executing it, rid: %s", schedule.ID, schedule.Status,
+			ctx.Kit.Rid)
+		ctx.RespEntity(nil)
+		return
+	}
+
+	execErr := s.execHostTransferSchedule(ctx.Kit, schedule)
+
+	option := &metadata.UpdateHostTransferScheduleStatusOption{
+		ID:   schedule.ID,
+		From: []metadata.HostTransferScheduleStatus{metadata.HostTransferScheduleExecuting},
+		To:   metadata.HostTransferScheduleSuccess,
+	}
+	if execErr != nil {
+		option.To = metadata.HostTransferScheduleFailed
+		option.Message = execErr.Error()
+	}
+
+	if ccErr := s.CoreAPI.CoreService().Host().UpdateHostTransferScheduleStatus(ctx.Kit.Ctx, ctx.Kit.Header,
+		option); ccErr != nil {
+		blog.Errorf("update host transfer schedule %d status to %s failed, err: %v, rid: %s", schedule.ID, option.To,
+			ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	if execErr != nil {
+		ctx.RespAutoError(execErr)
+		return
+	}
+	ctx.RespEntity(nil)
+}
+
+// execHostTransferSchedule validate the transfer option with the current topology and transfer the hosts
+func (s *Service) execHostTransferSchedule(kit *rest.Kit, schedule *metadata.HostTransferSchedule) error {
+	option := schedule.Option
+	if ccErr := s.validateTransferHostWithAutoClearServiceInstanceOption(kit, schedule.BizID, &option); ccErr != nil {
+		blog.Errorf("validate host transfer schedule %d failed, err: %v, rid: %s", schedule.ID, ccErr, kit.Rid)
+		return ccErr
+	}
+
+	err := s.execTransferHostWithAutoClearServiceInstance(kit, schedule.BizID, option, metadata.FromScheduler)
+	if err != nil {
+		blog.Errorf("execute host transfer schedule %d failed, err: %v, rid: %s", schedule.ID, err, kit.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// getHostTransferSchedule get the host transfer schedule of the business
+func (s *Service) getHostTransferSchedule(kit *rest.Kit, bizID, id int64) (*metadata.HostTransferSchedule,
+	errors.CCErrorCoder) {
+
+	option := &metadata.ListHostTransferScheduleOption{
+		BizID: bizID,
+		IDs:   []int64{id},
+		Page:  metadata.BasePage{Limit: 1},
+	}
+	result, err := s.CoreAPI.CoreService().Host().ListHostTransferSchedule(kit.Ctx, kit.Header, option)
+	if err != nil {
+		blog.Errorf("get host transfer schedule %d failed, biz: %d, err: %v, rid: %s", id, bizID, err, kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Info) == 0 {
+		blog.Errorf("host transfer schedule %d is not found in biz %d, rid: %s", id, bizID, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommNotFound)
+	}
+
+	return &result.Info[0], nil
+}
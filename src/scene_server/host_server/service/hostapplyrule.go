@@ -490,14 +490,17 @@ func (s *Service) generateModuleApplyPlan(ctx *rest.Contexts, planRequest *metad
 	OuterLoop:
 		for _, item := range planRequest.AdditionalRules {
 			for index, rule := range rules.Info {
-				if item.ModuleID == rule.ModuleID && item.AttributeID == rule.AttributeID {
+				if item.ModuleID == rule.ModuleID && item.AttributeID == rule.AttributeID &&
+					item.Priority == rule.Priority {
 					rules.Info[index].PropertyValue = item.PropertyValue
+					rules.Info[index].Condition = item.Condition
 					continue OuterLoop
 				}
 			}
 			rules.Info = append(rules.Info, metadata.HostApplyRule{BizID: planRequest.BizID, ModuleID: item.ModuleID,
-				AttributeID: item.AttributeID, PropertyValue: item.PropertyValue, Creator: ctx.Kit.User,
-				Modifier: ctx.Kit.User, CreateTime: now, LastTime: now, SupplierAccount: ctx.Kit.SupplierAccount,
+				AttributeID: item.AttributeID, PropertyValue: item.PropertyValue, Condition: item.Condition,
+				Priority: item.Priority, Creator: ctx.Kit.User, Modifier: ctx.Kit.User, CreateTime: now,
+				LastTime: now, SupplierAccount: ctx.Kit.SupplierAccount,
 			})
 		}
 	}
@@ -608,6 +611,18 @@ func (s *Service) updateHostAttributes(kit *rest.Kit, planResult []metadata.Host
 	return nil
 }
 
+// runHostApplyOnHosts apply the final host apply rules of the hosts' modules to the hosts.
+func (s *Service) runHostApplyOnHosts(kit *rest.Kit, bizID int64, hostIDs []int64) errors.CCErrorCoder {
+	option := metadata.UpdateHostByHostApplyRuleOption{HostIDs: hostIDs}
+	_, err := s.CoreAPI.CoreService().HostApplyRule().RunHostApplyOnHosts(kit.Ctx, kit.Header, bizID, option)
+	if err != nil {
+		blog.Errorf("run host apply on hosts failed, bizID: %d, option: %#v, err: %v, rid: %s", bizID, option, err,
+			kit.Rid)
+		return err
+	}
+	return nil
+}
+
 // GetHostApplyTaskStatus get host auto-apply asynchronous task status.
 func (s *Service) GetHostApplyTaskStatus(ctx *rest.Contexts) {
 
@@ -730,7 +745,9 @@ func (s *Service) ExecModuleHostApplyRule(ctx *rest.Contexts) {
 			rulesOption = append(rulesOption, metadata.CreateOrUpdateApplyRuleOption{
 				AttributeID:   rule.AttributeID,
 				ModuleID:      rule.ModuleID,
-				PropertyValue: rule.PropertyValue})
+				PropertyValue: rule.PropertyValue,
+				Condition:     rule.Condition,
+				Priority:      rule.Priority})
 		}
 		// 1、update or add rules.
 		saveRuleOp := metadata.BatchCreateOrUpdateApplyRuleOption{Rules: rulesOption}
@@ -769,6 +786,16 @@ func (s *Service) ExecModuleHostApplyRule(ctx *rest.Contexts) {
 	// update host operation is not done in a transaction, since the successfully updated hosts need not roll back
 	ctx.Kit.Header.Del(common.TransactionIdHeader)
 
+	// conditional rules only apply to part of the hosts, so the hosts are updated by their own apply plans
+	if metadata.HasConditionalHostApplyRule(planReq.AdditionalRules) {
+		if err := s.runHostApplyOnHosts(ctx.Kit, planReq.BizID, hostIDs); err != nil {
+			ctx.RespAutoError(err)
+			return
+		}
+		ctx.RespEntity(nil)
+		return
+	}
+
 	attributes := make([]metadata.HostAttribute, 0)
 
 	for _, rule := range planReq.AdditionalRules {
@@ -1196,21 +1223,23 @@ func getFinalRule(rules []metadata.HostApplyRule, option *metadata.HostApplyPlan
 
 	keyToRule := make(map[string]metadata.HostApplyRule)
 	for _, rule := range rules {
-		key := ruleKey(rule.ServiceTemplateID, rule.AttributeID)
+		key := ruleKey(rule.ServiceTemplateID, rule.AttributeID, rule.Priority)
 		keyToRule[key] = rule
 	}
 
 	if len(option.AdditionalRules) > 0 {
 		for _, item := range option.AdditionalRules {
-			key := ruleKey(item.ServiceTemplateID, item.AttributeID)
+			key := ruleKey(item.ServiceTemplateID, item.AttributeID, item.Priority)
 			if rule, exist := keyToRule[key]; exist {
 				rule.PropertyValue = item.PropertyValue
+				rule.Condition = item.Condition
 				keyToRule[key] = rule
 				continue
 			}
 
 			keyToRule[key] = metadata.HostApplyRule{BizID: option.BizID, ServiceTemplateID: item.ServiceTemplateID,
-				AttributeID: item.AttributeID, PropertyValue: item.PropertyValue}
+				AttributeID: item.AttributeID, PropertyValue: item.PropertyValue, Condition: item.Condition,
+				Priority: item.Priority}
 		}
 	}
 
@@ -1224,8 +1253,8 @@ func getFinalRule(rules []metadata.HostApplyRule, option *metadata.HostApplyPlan
 	return finalRules
 }
 
-func ruleKey(id, attrID int64) string {
-	return fmt.Sprintf("%d:%d", id, attrID)
+func ruleKey(id, attrID, priority int64) string {
+	return fmt.Sprintf("%d:%d:%d", id, attrID, priority)
 }
 
 // GetServiceTemplateHostApplyRule get service template host apply rule
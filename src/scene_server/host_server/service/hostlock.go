@@ -19,8 +19,45 @@ import (
 	"configcenter/src/common/blog"
 	"configcenter/src/common/http/rest"
 	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+
+	"github.com/emicklei/go-restful/v3"
 )
 
+// hostLockOverrideFilter validates the reason to override the host locks held by other users and checks if the
+// user has the permission to override them, before the override header is forwarded to the core service.
+func (s *Service) hostLockOverrideFilter(req *restful.Request, resp *restful.Response, fchain *restful.FilterChain) {
+	header := req.Request.Header
+	reason := header.Get(common.BKHTTPHostLockOverride)
+	if len(reason) == 0 {
+		fchain.ProcessFilter(req, resp)
+		return
+	}
+
+	rid := util.GetHTTPCCRequestID(header)
+	defErr := s.CCErr.CreateDefaultCCErrorIf(util.GetLanguage(header))
+
+	if rawErr := metadata.ValidateHostLockReason(common.BKHTTPHostLockOverride, reason); rawErr.ErrCode != 0 {
+		blog.Errorf("host lock override reason is invalid, reason: %s, rid: %s", reason, rid)
+		_ = resp.WriteAsJson(metadata.BaseResp{Code: rawErr.ErrCode, ErrMsg: rawErr.ToCCError(defErr).Error()})
+		return
+	}
+
+	err := s.AuthManager.AuthorizeHostLockOverride(util.NewContextFromHTTPHeader(header), header)
+	if err != nil {
+		if err != ac.NoAuthorizeError {
+			blog.Errorf("check host lock override authorization failed, err: %v, rid: %s", err, rid)
+			_ = resp.WriteAsJson(metadata.BaseResp{Code: common.CCErrCommAuthorizeFailed,
+				ErrMsg: defErr.Error(common.CCErrCommAuthorizeFailed).Error()})
+			return
+		}
+		_ = resp.WriteAsJson(s.AuthManager.GenHostLockOverrideNoPermissionResp())
+		return
+	}
+
+	fchain.ProcessFilter(req, resp)
+}
+
 // LockHost TODO
 func (s *Service) LockHost(ctx *rest.Contexts) {
 
@@ -31,9 +68,9 @@ func (s *Service) LockHost(ctx *rest.Contexts) {
 		return
 	}
 
-	if 0 == len(input.IDS) {
-		blog.Errorf("lock host, id_list is empty,input:%+v, rid:%s", input, ctx.Kit.Rid)
-		ctx.RespAutoError(ctx.Kit.CCError.Errorf(common.CCErrCommParamsNeedSet, "id_list"))
+	if rawErr := input.ValidateLock(); rawErr.ErrCode != 0 {
+		blog.Errorf("lock host, input is invalid, input: %+v, err: %v, rid: %s", input, rawErr, ctx.Kit.Rid)
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
 		return
 	}
 
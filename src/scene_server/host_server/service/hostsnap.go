@@ -0,0 +1,162 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	authmeta "configcenter/src/ac/meta"
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// SearchHostSnapHistory search the downsampled snapshot history of a host by time range and metrics
+func (s *Service) SearchHostSnapHistory(ctx *rest.Contexts) {
+	option := new(metadata.SearchHostSnapHistoryOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	if err := s.AuthManager.AuthorizeByHostsIDs(ctx.Kit.Ctx, ctx.Kit.Header, authmeta.Find,
+		option.HostID); err != nil {
+		blog.Errorf("check host authorization failed, host: %d, err: %v, rid: %s", option.HostID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommAuthorizeFailed))
+		return
+	}
+
+	result, err := s.CoreAPI.CoreService().Host().SearchHostSnapHistory(ctx.Kit.Ctx, ctx.Kit.Header, option)
+	if err != nil {
+		blog.Errorf("search host snapshot history failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// SearchHostSnapChangeLog search the hardware and os field change logs of a host reported by snapshot
+func (s *Service) SearchHostSnapChangeLog(ctx *rest.Contexts) {
+	option := new(metadata.SearchHostSnapChangeLogOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	if err := s.AuthManager.AuthorizeByHostsIDs(ctx.Kit.Ctx, ctx.Kit.Header, authmeta.Find,
+		option.HostID); err != nil {
+		blog.Errorf("check host authorization failed, host: %d, err: %v, rid: %s", option.HostID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommAuthorizeFailed))
+		return
+	}
+
+	result, err := s.CoreAPI.CoreService().Host().SearchHostSnapChangeLog(ctx.Kit.Ctx, ctx.Kit.Header, option)
+	if err != nil {
+		blog.Errorf("search host snapshot change log failed, option: %+v, err: %v, rid: %s", option, err,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// ListBizHostSnapDrift list the drifts between the snapshot and the host in cmdb of the hosts in a business
+func (s *Service) ListBizHostSnapDrift(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil || bizID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	option := new(metadata.ListBizHostSnapDriftOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	hostCond := &metadata.DistinctHostIDByTopoRelationRequest{
+		ApplicationIDArr: []int64{bizID},
+		ModuleIDArr:      option.ModuleIDs,
+	}
+	hostIDs, ccErr := s.CoreAPI.CoreService().Host().GetDistinctHostIDByTopology(ctx.Kit.Ctx, ctx.Kit.Header,
+		hostCond)
+	if ccErr != nil {
+		blog.Errorf("get host ids failed, cond: %+v, err: %v, rid: %s", hostCond, ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	if len(hostIDs) == 0 {
+		ctx.RespEntity(metadata.HostSnapDriftResult{Info: make([]metadata.HostSnapDrift, 0)})
+		return
+	}
+
+	driftOpt := &metadata.SearchHostSnapDriftOption{
+		HostIDs:     hostIDs,
+		PropertyIDs: option.PropertyIDs,
+		Page:        option.Page,
+	}
+	result, ccErr := s.CoreAPI.CoreService().Host().SearchHostSnapDrift(ctx.Kit.Ctx, ctx.Kit.Header, driftOpt)
+	if ccErr != nil {
+		blog.Errorf("search host snapshot drift failed, biz: %d, option: %+v, err: %v, rid: %s", bizID, option,
+			ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// SummarizeBizHostSnapDrift summarize the drifts between the snapshot and the host in cmdb of the hosts in a
+// business by module
+func (s *Service) SummarizeBizHostSnapDrift(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil || bizID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	option := new(metadata.HostSnapDriftSummaryOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	summaries, ccErr := s.Logic.SummarizeBizHostSnapDrift(ctx.Kit, bizID, option)
+	if ccErr != nil {
+		blog.Errorf("summarize host snapshot drift failed, biz: %d, option: %+v, err: %v, rid: %s", bizID, option,
+			ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	ctx.RespEntity(summaries)
+}
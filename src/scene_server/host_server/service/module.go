@@ -329,16 +329,26 @@ func (s *Service) DeleteHostFromBusiness(ctx *rest.Contexts) {
 // moveHostToDefaultModule TODO
 // move host to idle, fault or recycle module under the same business.
 func (s *Service) moveHostToDefaultModule(ctx *rest.Contexts, defaultModuleFlag int) {
-
-	defErr := ctx.Kit.CCError
-	rid := ctx.Kit.Rid
 	conf := new(metadata.DefaultModuleHostConfigParams)
 	if err := ctx.DecodeInto(&conf); nil != err {
 		ctx.RespAutoError(err)
 		return
 	}
 
-	bizID := conf.ApplicationID
+	result, err := s.transferHostToDefaultModule(ctx.Kit, conf.ApplicationID, conf.HostIDs, defaultModuleFlag)
+	if err != nil {
+		ctx.RespEntityWithError(result, err)
+		return
+	}
+	ctx.RespEntity(nil)
+}
+
+// transferHostToDefaultModule transfer the hosts to the idle, fault or recycle module of the business
+func (s *Service) transferHostToDefaultModule(kit *rest.Kit, bizID int64, hostIDs []int64, defaultModuleFlag int) (
+	[]metadata.ExceptionResult, error) {
+
+	defErr := kit.CCError
+	rid := kit.Rid
 
 	moduleFilter := make(map[string]interface{})
 	if defaultModuleFlag == common.DefaultResModuleFlag {
@@ -351,53 +361,53 @@ func (s *Service) moveHostToDefaultModule(ctx *rest.Contexts, defaultModuleFlag
 		// 待回收
 		moduleFilter[common.BKDefaultField] = common.DefaultRecycleModuleFlag
 	} else {
-		blog.Errorf("move host to default module failed, unexpected flag, bizID: %d, defaultModuleFlag: %d, rid: %s", bizID, defaultModuleFlag, ctx.Kit.Rid)
-		ctx.RespAutoError(defErr.Errorf(common.CCErrCommResourceInitFailed, "audit server"))
-		return
+		blog.Errorf("move host to default module failed, unexpected flag, bizID: %d, defaultModuleFlag: %d, rid: %s",
+			bizID, defaultModuleFlag, rid)
+		return nil, defErr.Errorf(common.CCErrCommResourceInitFailed, "audit server")
 	}
 
 	moduleFilter[common.BKAppIDField] = bizID
-	moduleID, _, err := s.Logic.GetResourcePoolModuleID(ctx.Kit, moduleFilter)
+	moduleID, _, err := s.Logic.GetResourcePoolModuleID(kit, moduleFilter)
 	if err != nil {
-		blog.ErrorJSON("move host to default module failed, get default module id failed, filter: %s, err: %s, rid: %s", moduleFilter, err, ctx.Kit.Rid)
-		ctx.RespAutoError(defErr.Errorf(common.CCErrAddHostToModuleFailStr, "module not found"))
-		return
+		blog.ErrorJSON("move host to default module failed, get default module id failed, filter: %s, err: %s, "+
+			"rid: %s", moduleFilter, err, rid)
+		return nil, defErr.Errorf(common.CCErrAddHostToModuleFailStr, "module not found")
 	}
 
-	audit := auditlog.NewHostModuleLog(s.CoreAPI.CoreService(), conf.HostIDs)
-	if err := audit.WithPrevious(ctx.Kit); err != nil {
-		blog.Errorf("move host to default module s failed, get prev module host config failed, hostIDs: %v, err: %s, rid: %s", conf.HostIDs, err.Error(), ctx.Kit.Rid)
-		ctx.RespAutoError(defErr.Errorf(common.CCErrCommResourceInitFailed, "audit server"))
-		return
+	audit := auditlog.NewHostModuleLog(s.CoreAPI.CoreService(), hostIDs)
+	if err := audit.WithPrevious(kit); err != nil {
+		blog.Errorf("move host to default module s failed, get prev module host config failed, hostIDs: %v, "+
+			"err: %s, rid: %s", hostIDs, err.Error(), rid)
+		return nil, defErr.Errorf(common.CCErrCommResourceInitFailed, "audit server")
 	}
 
 	var result []metadata.ExceptionResult
-	txnErr := s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
+	txnErr := s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(kit.Ctx, kit.Header, func() error {
 
 		transferInput := &metadata.TransferHostToInnerModule{
-			ApplicationID: conf.ApplicationID,
-			HostID:        conf.HostIDs,
+			ApplicationID: bizID,
+			HostID:        hostIDs,
 			ModuleID:      moduleID,
 		}
 		var err errors.CCErrorCoder
-		result, err = s.CoreAPI.CoreService().Host().TransferToInnerModule(ctx.Kit.Ctx, ctx.Kit.Header, transferInput)
+		result, err = s.CoreAPI.CoreService().Host().TransferToInnerModule(kit.Ctx, kit.Header, transferInput)
 		if err != nil {
 			blog.Errorf("transfer host to default module failed, err: %v, input: %#v, rid:%s", err, transferInput, rid)
 			return err
 		}
 
-		if err := audit.SaveAudit(ctx.Kit); err != nil {
-			blog.ErrorJSON("move host to default module failed, save audit log failed, input:%s, err:%s, rid:%s", conf, err, ctx.Kit.Rid)
-			return ctx.Kit.CCError.Errorf(common.CCErrCommResourceInitFailed, "audit server")
+		if err := audit.SaveAudit(kit); err != nil {
+			blog.ErrorJSON("move host to default module failed, save audit log failed, input:%s, err:%s, rid:%s",
+				transferInput, err, rid)
+			return kit.CCError.Errorf(common.CCErrCommResourceInitFailed, "audit server")
 		}
 		return nil
 	})
 
 	if txnErr != nil {
-		ctx.RespEntityWithError(result, txnErr)
-		return
+		return result, txnErr
 	}
-	ctx.RespEntity(nil)
+	return nil, nil
 }
 
 // GetAppHostTopoRelation  query host and module relation,
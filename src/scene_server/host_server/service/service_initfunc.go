@@ -40,6 +40,31 @@ func (s *Service) initCloudarea(web *restful.WebService) {
 	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/hosts/cloudarea_field", Handler: s.UpdateHostCloudAreaField})
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloudarea/hostcount", Handler: s.FindCloudAreaHostCount})
 
+	// cloud area ip address management
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/create/cloudarea/{bk_cloud_id}/subnet",
+		Handler: s.CreateSubnet})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloudarea/{bk_cloud_id}/subnet",
+		Handler: s.SearchSubnet})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/update/cloudarea/{bk_cloud_id}/subnet/{bk_subnet_id}",
+		Handler: s.UpdateSubnet})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete,
+		Path: "/delete/cloudarea/{bk_cloud_id}/subnet/{bk_subnet_id}", Handler: s.DeleteSubnet})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost,
+		Path: "/findmany/cloudarea/{bk_cloud_id}/subnet/{bk_subnet_id}/hosts", Handler: s.FindSubnetHosts})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloudarea/{bk_cloud_id}/subnet/utilization",
+		Handler: s.GetSubnetUtilization})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost,
+		Path:    "/findmany/cloudarea/{bk_cloud_id}/subnet/{bk_subnet_id}/ip/reservation",
+		Handler: s.SearchSubnetIPReservation})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost,
+		Path: "/createmany/cloudarea/{bk_cloud_id}/subnet/{bk_subnet_id}/ip/reservation", Handler: s.ReserveSubnetIP})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost,
+		Path: "/createmany/cloudarea/{bk_cloud_id}/subnet/{bk_subnet_id}/ip/allocation", Handler: s.AllocateSubnetIP})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete,
+		Path: "/deletemany/cloudarea/{bk_cloud_id}/subnet/{bk_subnet_id}/ip/reservation", Handler: s.ReleaseSubnetIP})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloudarea/ip_conflict",
+		Handler: s.FindIPConflicts})
+
 	utility.AddToRestfulWebService(web)
 
 }
@@ -127,6 +152,58 @@ func (s *Service) initHost(web *restful.WebService) {
 
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/hosts/kube/search",
 		Handler: s.SearchHostWithKube})
+
+	// host snapshot history, hardware/os field change log and drift
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/hosts/snapshot/history",
+		Handler: s.SearchHostSnapHistory})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/hosts/snapshot/change_log",
+		Handler: s.SearchHostSnapChangeLog})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/hosts/snapshot/drift/biz/{bk_biz_id}",
+		Handler: s.ListBizHostSnapDrift})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost,
+		Path: "/findmany/hosts/snapshot/drift/summary/biz/{bk_biz_id}", Handler: s.SummarizeBizHostSnapDrift})
+
+	// host lifecycle state machine of the bk_state field
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/update/hosts/lifecycle",
+		Handler: s.UpdateHostLifecycle})
+	utility.AddHandler(rest.Action{Verb: http.MethodGet, Path: "/find/hosts/lifecycle", Handler: s.FindHostLifecycle})
+
+	// duplicate host detection and merge
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/hosts/duplicate",
+		Handler: s.FindDuplicateHosts})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/find/hosts/merge/preview",
+		Handler: s.PreviewMergeHost})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/update/hosts/merge", Handler: s.MergeHost})
+
+	// bulk host update by filter, the task is executed by task server in batches
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/updatemany/hosts/by_filter/biz/{bk_biz_id}/preview",
+		Handler: s.PreviewBulkUpdateHost})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/updatemany/hosts/by_filter/biz/{bk_biz_id}",
+		Handler: s.BulkUpdateHostByFilter})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/updatemany/hosts/by_filter/task",
+		Handler: s.ExecBulkUpdateHostTask})
+	utility.AddHandler(rest.Action{Verb: http.MethodGet, Path: "/find/hosts/by_filter/biz/{bk_biz_id}/task/{task_id}",
+		Handler: s.GetBulkUpdateHostTaskStatus})
+
+	// scheduled host transfer, the schedule is executed by task server at its execute time
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/create/hosts/transfer_schedule/biz/{bk_biz_id}",
+		Handler: s.CreateHostTransferSchedule})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/hosts/transfer_schedule/biz/{bk_biz_id}",
+		Handler: s.ListHostTransferSchedule})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut,
+		Path: "/update/hosts/transfer_schedule/biz/{bk_biz_id}/{id}/cancel", Handler: s.CancelHostTransferSchedule})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/updatemany/hosts/transfer_schedule/task",
+		Handler: s.ExecHostTransferScheduleTask})
+
+	// stale host detection by the last snapshot time, the policy action is executed by task server
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/update/hosts/stale/policy",
+		Handler: s.UpdateStaleHostPolicy})
+	utility.AddHandler(rest.Action{Verb: http.MethodGet, Path: "/find/hosts/stale/policy",
+		Handler: s.FindStaleHostPolicy})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/hosts/stale/biz/{bk_biz_id}",
+		Handler: s.ListBizStaleHost})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/updatemany/hosts/stale/task",
+		Handler: s.ExecStaleHostTask})
 	utility.AddToRestfulWebService(web)
 
 }
@@ -312,6 +389,13 @@ func (s *Service) initDynamicGroup(web *restful.WebService) {
 		Handler: s.ExecuteDynamicGroup,
 	})
 
+	// watch membership changes of dynamic group.
+	utility.AddHandler(rest.Action{
+		Verb:    http.MethodPost,
+		Path:    "/dynamicgroup/watch/{bk_biz_id}/{id}",
+		Handler: s.WatchDynamicGroup,
+	})
+
 	utility.AddToRestfulWebService(web)
 }
 
@@ -0,0 +1,213 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+)
+
+// UpdateStaleHostPolicy update the stale host detection policy of the supplier account
+func (s *Service) UpdateStaleHostPolicy(ctx *rest.Contexts) {
+	policy := new(metadata.StaleHostPolicy)
+	if err := ctx.DecodeInto(policy); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := policy.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	if err := s.Logic.ValidateStaleHostPolicyState(ctx.Kit, policy); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	err := s.CoreAPI.CoreService().Host().SaveStaleHostPolicy(ctx.Kit.Ctx, ctx.Kit.Header, policy)
+	if err != nil {
+		blog.Errorf("save stale host policy failed, policy: %+v, err: %v, rid: %s", policy, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// FindStaleHostPolicy find the stale host detection policy of the supplier account
+func (s *Service) FindStaleHostPolicy(ctx *rest.Contexts) {
+	policy, err := s.CoreAPI.CoreService().Host().GetStaleHostPolicy(ctx.Kit.Ctx, ctx.Kit.Header)
+	if err != nil {
+		blog.Errorf("get stale host policy failed, err: %v, rid: %s", err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(policy)
+}
+
+// ListBizStaleHost list the hosts in a business that are flagged as stale with their last snapshot time
+func (s *Service) ListBizStaleHost(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil || bizID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	option := new(metadata.ListBizStaleHostOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	hostCond := &metadata.DistinctHostIDByTopoRelationRequest{
+		ApplicationIDArr: []int64{bizID},
+		ModuleIDArr:      option.ModuleIDs,
+	}
+	hostIDs, ccErr := s.CoreAPI.CoreService().Host().GetDistinctHostIDByTopology(ctx.Kit.Ctx, ctx.Kit.Header,
+		hostCond)
+	if ccErr != nil {
+		blog.Errorf("get host ids failed, cond: %+v, err: %v, rid: %s", hostCond, ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	if len(hostIDs) == 0 {
+		ctx.RespEntity(metadata.HostSnapStatusResult{Info: make([]metadata.HostSnapStatus, 0)})
+		return
+	}
+
+	stale := true
+	statusOpt := &metadata.SearchHostSnapStatusOption{
+		HostIDs: hostIDs,
+		Stale:   &stale,
+		Page:    option.Page,
+	}
+	result, ccErr := s.CoreAPI.CoreService().Host().SearchHostSnapStatus(ctx.Kit.Ctx, ctx.Kit.Header, statusOpt)
+	if ccErr != nil {
+		blog.Errorf("search stale hosts failed, biz: %d, option: %+v, err: %v, rid: %s", bizID, option, ccErr,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// ExecStaleHostTask execute a sub task that takes the stale host policy action on the stale hosts of a business,
+// the hosts that have reported the snapshot again or have left the business since the task is created are skipped.
+func (s *Service) ExecStaleHostTask(ctx *rest.Contexts) {
+	taskData := new(metadata.StaleHostTaskData)
+	if err := ctx.DecodeInto(taskData); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := taskData.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	policy, ccErr := s.CoreAPI.CoreService().Host().GetStaleHostPolicy(ctx.Kit.Ctx, ctx.Kit.Header)
+	if ccErr != nil {
+		blog.Errorf("get stale host policy failed, err: %v, rid: %s", ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	if !policy.Enabled || policy.IsExcluded(taskData.BizID) {
+		blog.Infof("stale host policy is disabled or excludes biz %d, skip handling hosts %v, rid: %s",
+			taskData.BizID, taskData.HostIDs, ctx.Kit.Rid)
+		ctx.RespEntity(nil)
+		return
+	}
+
+	hostIDs, err := s.getBizStaleHostIDs(ctx.Kit, taskData.BizID, taskData.HostIDs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if len(hostIDs) == 0 {
+		ctx.RespEntity(nil)
+		return
+	}
+
+	switch taskData.Action {
+	case metadata.StaleHostActionTransfer:
+		result, err := s.transferHostToDefaultModule(ctx.Kit, taskData.BizID, hostIDs, taskData.DefaultModule)
+		if err != nil {
+			blog.Errorf("transfer stale hosts %v to default module %d failed, err: %v, rid: %s", hostIDs,
+				taskData.DefaultModule, err, ctx.Kit.Rid)
+			ctx.RespEntityWithError(result, err)
+			return
+		}
+		ctx.RespEntity(nil)
+
+	case metadata.StaleHostActionUpdateState:
+		data := mapstr.MapStr{common.BKHostState: taskData.State}
+		result, err := s.updateHostsOneByOne(ctx.Kit, taskData.BizID, hostIDs, data)
+		if err != nil {
+			ctx.RespAutoError(err)
+			return
+		}
+		ctx.RespEntity(result)
+	}
+}
+
+// getBizStaleHostIDs get the hosts that are still stale and still in the business
+func (s *Service) getBizStaleHostIDs(kit *rest.Kit, bizID int64, hostIDs []int64) ([]int64, error) {
+	stale := true
+	statusOpt := &metadata.SearchHostSnapStatusOption{
+		HostIDs: hostIDs,
+		Stale:   &stale,
+		Page:    metadata.BasePage{Limit: len(hostIDs)},
+	}
+	statuses, err := s.CoreAPI.CoreService().Host().SearchHostSnapStatus(kit.Ctx, kit.Header, statusOpt)
+	if err != nil {
+		blog.Errorf("search host snapshot status failed, hosts: %v, err: %v, rid: %s", hostIDs, err, kit.Rid)
+		return nil, err
+	}
+
+	if len(statuses.Info) == 0 {
+		return make([]int64, 0), nil
+	}
+
+	staleHostIDs := make([]int64, len(statuses.Info))
+	for idx, status := range statuses.Info {
+		staleHostIDs[idx] = status.HostID
+	}
+
+	hostCond := &metadata.DistinctHostIDByTopoRelationRequest{
+		ApplicationIDArr: []int64{bizID},
+		HostIDArr:        staleHostIDs,
+	}
+	bizHostIDs, err := s.CoreAPI.CoreService().Host().GetDistinctHostIDByTopology(kit.Ctx, kit.Header, hostCond)
+	if err != nil {
+		blog.Errorf("get host ids failed, cond: %+v, err: %v, rid: %s", hostCond, err, kit.Rid)
+		return nil, err
+	}
+
+	return bizHostIDs, nil
+}
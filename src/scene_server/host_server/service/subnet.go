@@ -0,0 +1,396 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// CreateSubnet create a subnet in the cloud area
+func (s *Service) CreateSubnet(ctx *rest.Contexts) {
+	cloudID, ok := parseSubnetCloudID(ctx)
+	if !ok {
+		return
+	}
+
+	subnet := new(metadata.Subnet)
+	if err := ctx.DecodeInto(subnet); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	subnet.CloudID = cloudID
+
+	if rawErr := subnet.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	cond := &metadata.Condition{Condition: map[string]interface{}{common.BKCloudIDField: cloudID}}
+	counts, err := s.CoreAPI.CoreService().Instance().CountInstances(ctx.Kit.Ctx, ctx.Kit.Header,
+		common.BKInnerObjIDPlat, cond)
+	if err != nil {
+		blog.Errorf("count cloud area %d failed, err: %v, rid: %s", cloudID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if counts.Count == 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrTopoCloudNotFound))
+		return
+	}
+
+	result, ccErr := s.CoreAPI.CoreService().Cloud().CreateSubnet(ctx.Kit.Ctx, ctx.Kit.Header, subnet)
+	if ccErr != nil {
+		blog.Errorf("create subnet failed, subnet: %+v, err: %v, rid: %s", subnet, ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// SearchSubnet search the subnets in the cloud area
+func (s *Service) SearchSubnet(ctx *rest.Contexts) {
+	cloudID, ok := parseSubnetCloudID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.SearchSubnetOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	option.CloudIDs = []int64{cloudID}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.CoreAPI.CoreService().Cloud().SearchSubnet(ctx.Kit.Ctx, ctx.Kit.Header, option)
+	if err != nil {
+		blog.Errorf("search subnets failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// UpdateSubnet update the vlan, gateway or description of the subnet in the cloud area
+func (s *Service) UpdateSubnet(ctx *rest.Contexts) {
+	subnetID, ok := s.parseCloudSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.UpdateSubnetOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	if err := s.CoreAPI.CoreService().Cloud().UpdateSubnet(ctx.Kit.Ctx, ctx.Kit.Header, subnetID, option); err != nil {
+		blog.Errorf("update subnet %d failed, option: %+v, err: %v, rid: %s", subnetID, option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// DeleteSubnet delete the subnet in the cloud area and its ip reservations
+func (s *Service) DeleteSubnet(ctx *rest.Contexts) {
+	subnetID, ok := s.parseCloudSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	txnErr := s.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
+		if err := s.CoreAPI.CoreService().Cloud().DeleteSubnet(ctx.Kit.Ctx, ctx.Kit.Header, subnetID); err != nil {
+			blog.Errorf("delete subnet %d failed, err: %v, rid: %s", subnetID, err, ctx.Kit.Rid)
+			return err
+		}
+		return nil
+	})
+
+	if txnErr != nil {
+		ctx.RespAutoError(txnErr)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// FindSubnetHosts find the hosts in the cloud area whose inner ips are in the subnet
+func (s *Service) FindSubnetHosts(ctx *rest.Contexts) {
+	subnetID, ok := s.parseCloudSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.FindSubnetHostOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.CoreAPI.CoreService().Cloud().FindSubnetHosts(ctx.Kit.Ctx, ctx.Kit.Header, subnetID, option)
+	if err != nil {
+		blog.Errorf("find subnet %d hosts failed, err: %v, rid: %s", subnetID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// GetSubnetUtilization get the ip utilization of the subnets in the cloud area
+func (s *Service) GetSubnetUtilization(ctx *rest.Contexts) {
+	cloudID, ok := parseSubnetCloudID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.SubnetUtilizationOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	option.CloudID = cloudID
+
+	result, err := s.CoreAPI.CoreService().Cloud().GetSubnetUtilization(ctx.Kit.Ctx, ctx.Kit.Header, option)
+	if err != nil {
+		blog.Errorf("get subnet utilization failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// SearchSubnetIPReservation search the reserved ips of the subnet in the cloud area
+func (s *Service) SearchSubnetIPReservation(ctx *rest.Contexts) {
+	subnetID, ok := s.parseCloudSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := s.CoreAPI.CoreService().Cloud().SearchSubnetIPReservation(ctx.Kit.Ctx, ctx.Kit.Header, subnetID)
+	if err != nil {
+		blog.Errorf("search subnet %d ip reservations failed, err: %v, rid: %s", subnetID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// ReserveSubnetIP reserve the specified ips of the subnet in the cloud area
+func (s *Service) ReserveSubnetIP(ctx *rest.Contexts) {
+	subnetID, ok := s.parseCloudSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.ReserveSubnetIPOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.CoreAPI.CoreService().Cloud().ReserveSubnetIP(ctx.Kit.Ctx, ctx.Kit.Header, subnetID, option)
+	if err != nil {
+		blog.Errorf("reserve subnet %d ips failed, option: %+v, err: %v, rid: %s", subnetID, option, err,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// AllocateSubnetIP allocate and reserve the next free ips of the subnet in the cloud area. the allocation is not run
+// in a transaction, since core service skips the ips that are reserved concurrently by the duplicate key error.
+func (s *Service) AllocateSubnetIP(ctx *rest.Contexts) {
+	subnetID, ok := s.parseCloudSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.AllocateSubnetIPOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.CoreAPI.CoreService().Cloud().AllocateSubnetIP(ctx.Kit.Ctx, ctx.Kit.Header, subnetID, option)
+	if err != nil {
+		blog.Errorf("allocate subnet %d ips failed, option: %+v, err: %v, rid: %s", subnetID, option, err,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// ReleaseSubnetIP release the reserved ips of the subnet in the cloud area
+func (s *Service) ReleaseSubnetIP(ctx *rest.Contexts) {
+	subnetID, ok := s.parseCloudSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.ReserveSubnetIPOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	if err := s.CoreAPI.CoreService().Cloud().ReleaseSubnetIP(ctx.Kit.Ctx, ctx.Kit.Header, subnetID,
+		option); err != nil {
+		blog.Errorf("release subnet %d ips failed, option: %+v, err: %v, rid: %s", subnetID, option, err,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// FindIPConflicts find the inner ips that are claimed by more than one host in the same cloud area
+func (s *Service) FindIPConflicts(ctx *rest.Contexts) {
+	option := new(metadata.FindIPConflictOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.CoreAPI.CoreService().Cloud().FindIPConflicts(ctx.Kit.Ctx, ctx.Kit.Header, option)
+	if err != nil {
+		blog.Errorf("find ip conflicts failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// parseSubnetCloudID parse the cloud area id of the subnet from the request path
+func parseSubnetCloudID(ctx *rest.Contexts) (int64, bool) {
+	cloudID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKCloudIDField), 10, 64)
+	if err != nil || cloudID < 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKCloudIDField))
+		return 0, false
+	}
+	return cloudID, true
+}
+
+// parseCloudSubnetID parse the cloud area id and subnet id from the request path, and check if the subnet belongs
+// to the cloud area, since the request is authorized by the cloud area.
+func (s *Service) parseCloudSubnetID(ctx *rest.Contexts) (int64, bool) {
+	cloudID, ok := parseSubnetCloudID(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	subnetID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKSubnetIDField), 10, 64)
+	if err != nil || subnetID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKSubnetIDField))
+		return 0, false
+	}
+
+	option := &metadata.SearchSubnetOption{
+		CloudIDs:  []int64{cloudID},
+		SubnetIDs: []int64{subnetID},
+		Page:      metadata.BasePage{Limit: 1},
+	}
+	result, ccErr := s.CoreAPI.CoreService().Cloud().SearchSubnet(ctx.Kit.Ctx, ctx.Kit.Header, option)
+	if ccErr != nil {
+		blog.Errorf("search subnet failed, option: %+v, err: %v, rid: %s", option, ccErr, ctx.Kit.Rid)
+		ctx.RespAutoError(ccErr)
+		return 0, false
+	}
+
+	if len(result.Info) == 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommNotFound))
+		return 0, false
+	}
+
+	return subnetID, true
+}
+
+// deletePlatSubnets delete the subnets of the cloud area and their ip reservations
+func (s *Service) deletePlatSubnets(kit *rest.Kit, platID int64) errors.CCErrorCoder {
+	option := &metadata.SearchSubnetOption{
+		CloudIDs: []int64{platID},
+		Page:     metadata.BasePage{Limit: common.BKMaxPageSize},
+	}
+
+	for {
+		result, err := s.CoreAPI.CoreService().Cloud().SearchSubnet(kit.Ctx, kit.Header, option)
+		if err != nil {
+			blog.Errorf("search cloud area %d subnets failed, err: %v, rid: %s", platID, err, kit.Rid)
+			return err
+		}
+
+		for _, subnet := range result.Info {
+			if err := s.CoreAPI.CoreService().Cloud().DeleteSubnet(kit.Ctx, kit.Header, subnet.SubnetID); err != nil {
+				blog.Errorf("delete subnet %d failed, err: %v, rid: %s", subnet.SubnetID, err, kit.Rid)
+				return err
+			}
+		}
+
+		if len(result.Info) < common.BKMaxPageSize {
+			return nil
+		}
+	}
+}
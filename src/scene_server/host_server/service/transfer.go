@@ -70,13 +70,25 @@ func (s *Service) TransferHostWithAutoClearServiceInstance(ctx *rest.Contexts) {
 		return
 	}
 
-	transferPlans, hostIDs, err := s.preTransferPlans(ctx.Kit, option, bizID)
-	if err != nil {
-		blog.ErrorJSON("generate transfer plans failed, bizID: %s, option: %s, err: %s, rid: %s", bizID, option, err,
-			ctx.Kit.Rid)
+	if err := s.execTransferHostWithAutoClearServiceInstance(ctx.Kit, bizID, option, metadata.FromUser); err != nil {
 		ctx.RespAutoError(err)
 		return
 	}
+	ctx.RespEntity(nil)
+	return
+}
+
+// execTransferHostWithAutoClearServiceInstance transfer hosts by the validated option, the host transfer audit logs
+// are recorded as from the operateFrom
+func (s *Service) execTransferHostWithAutoClearServiceInstance(kit *rest.Kit, bizID int64,
+	option metadata.TransferHostWithAutoClearServiceInstanceOption, operateFrom metadata.OperateFromType) error {
+
+	transferPlans, hostIDs, err := s.preTransferPlans(kit, option, bizID)
+	if err != nil {
+		blog.ErrorJSON("generate transfer plans failed, bizID: %s, option: %s, err: %s, rid: %s", bizID, option, err,
+			kit.Rid)
+		return err
+	}
 
 	// parse service instances to map[hostID->map[moduleID->processes]], skip those that do not belong to host modules
 	svcInstMap := make(map[int64]map[int64][]metadata.ProcessInstanceDetail)
@@ -91,17 +103,10 @@ func (s *Service) TransferHostWithAutoClearServiceInstance(ctx *rest.Contexts) {
 	transToInnerOpt, transToNormalPlans := s.parseTransferPlans(bizID, option.IsRemoveFromAll,
 		len(option.RemoveFromModules) == 0, transferPlans, svcInstMap, option.Options.HostApplyTransPropertyRule.Changed)
 
-	txnErr := s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
-		return s.transferHostWithAutoClearServiceInstance(ctx.Kit, bizID, option, transToInnerOpt, transToNormalPlans,
-			svcInstMap, hostIDs)
+	return s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(kit.Ctx, kit.Header, func() error {
+		return s.transferHostWithAutoClearServiceInstance(kit, bizID, option, transToInnerOpt, transToNormalPlans,
+			svcInstMap, hostIDs, operateFrom)
 	})
-
-	if txnErr != nil {
-		ctx.RespAutoError(txnErr)
-		return
-	}
-	ctx.RespEntity(nil)
-	return
 }
 
 // parseTransferPlans aggregate transfer plans into transfer to inner/normal module options by module ids and increment
@@ -189,9 +194,10 @@ func (s *Service) transferHostWithAutoClearServiceInstance(
 	transToInnerOpt *metadata.TransferHostToInnerModule,
 	transToNormalPlans map[string]*metadata.HostsModuleRelation,
 	svcInstMap map[int64]map[int64][]metadata.ProcessInstanceDetail,
-	hostIDs []int64) error {
+	hostIDs []int64,
+	operateFrom metadata.OperateFromType) error {
 
-	audit := auditlog.NewHostModuleLog(s.CoreAPI.CoreService(), option.HostIDs)
+	audit := auditlog.NewHostModuleLog(s.CoreAPI.CoreService(), option.HostIDs).WithOperateFrom(operateFrom)
 	if err := audit.WithPrevious(kit); err != nil {
 		blog.Errorf("generate host transfer audit failed, err: %v, HostIDs: %+v, rid: %s", err, option.HostIDs, kit.Rid)
 		return err
@@ -843,11 +849,23 @@ func (s *Service) TransferHostWithAutoClearServiceInstancePreview(ctx *rest.Cont
 		return
 	}
 
+	previews, err := s.generateTransferPreviews(ctx, bizID, option)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(previews)
+	return
+}
+
+// generateTransferPreviews generate the previews of the host transfer by the validated option
+func (s *Service) generateTransferPreviews(ctx *rest.Contexts, bizID int64,
+	option metadata.TransferHostWithAutoClearServiceInstanceOption) ([]metadata.HostTransferPreview, error) {
+
 	transferPlans, ccErr := s.generateTransferPlans(ctx.Kit, bizID, option)
 	if ccErr != nil {
 		blog.Errorf("generate plans fail, bizID: %s, option: %+v, err: %v, rid: %s", bizID, option, ccErr, ctx.Kit.Rid)
-		ctx.RespAutoError(ccErr)
-		return
+		return nil, ccErr
 	}
 
 	addModuleIDs := make([]int64, 0)
@@ -858,12 +876,12 @@ func (s *Service) TransferHostWithAutoClearServiceInstancePreview(ctx *rest.Cont
 	}
 
 	// get to remove service instances
+	var err error
 	moduleHostSrvInstMap := make(map[int64]map[int64][]metadata.ServiceInstance)
 	if len(removeModuleIDs) > 0 {
 		moduleHostSrvInstMap, err = s.getRemovedServiceInstance(ctx, bizID, removeModuleIDs, option)
 		if err != nil {
-			ctx.RespAutoError(ccErr)
-			return
+			return nil, err
 		}
 	}
 
@@ -872,14 +890,11 @@ func (s *Service) TransferHostWithAutoClearServiceInstancePreview(ctx *rest.Cont
 
 		moduleServiceTemplateMap, err = s.getModuleServiceTemplate(ctx, bizID, addModuleIDs)
 		if err != nil {
-			ctx.RespAutoError(ccErr)
-			return
+			return nil, err
 		}
 	}
 
-	previews := getPreviewsResult(transferPlans, moduleServiceTemplateMap, moduleHostSrvInstMap)
-	ctx.RespEntity(previews)
-	return
+	return getPreviewsResult(transferPlans, moduleServiceTemplateMap, moduleHostSrvInstMap), nil
 }
 
 func getPreviewsResult(transferPlans []metadata.HostTransferPlan,
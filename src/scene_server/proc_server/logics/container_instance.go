@@ -0,0 +1,317 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package logics
+
+import (
+	"strconv"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/selector"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+)
+
+// ListContainerServiceInstanceDetail list the container backed service instances, they are derived from the pods
+// of the workloads bound to the service templates, and the containers of the pods are treated as the processes.
+// the count is always returned, the instances are only listed when the page limit is set.
+func (lgc *Logic) ListContainerServiceInstanceDetail(kit *rest.Kit, option *metadata.ListServiceInstanceDetailOption,
+	page metadata.BasePage) (*metadata.MultipleServiceInstanceDetail, errors.CCErrorCoder) {
+
+	result := &metadata.MultipleServiceInstanceDetail{Info: make([]metadata.ServiceInstanceDetail, 0)}
+
+	// container backed service instances have no id, they can not be found by service instance ids
+	if len(option.ServiceInstanceIDs) > 0 {
+		return result, nil
+	}
+
+	bindingCond := mapstr.MapStr{common.BKAppIDField: option.BusinessID}
+	if option.ModuleID > 0 {
+		bindingCond[common.BKModuleIDField] = option.ModuleID
+	}
+	bindingQuery := &metadata.QueryCondition{
+		Condition:      bindingCond,
+		Page:           metadata.BasePage{Limit: common.BKNoLimit},
+		DisableCounter: true,
+	}
+	bindings, err := lgc.CoreAPI.CoreService().Kube().ListServiceBinding(kit.Ctx, kit.Header, bindingQuery)
+	if err != nil {
+		blog.Errorf("list workload service bindings failed, cond: %v, err: %v, rid: %s", bindingCond, err, kit.Rid)
+		return nil, err
+	}
+
+	if len(bindings.Data) == 0 {
+		return result, nil
+	}
+
+	podCond, err := buildBoundPodCond(kit, option, bindings.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := lgc.CoreAPI.CoreService().Count().GetCountByFilter(kit.Ctx, kit.Header, types.BKTableNameBasePod,
+		[]map[string]interface{}{podCond})
+	if err != nil {
+		blog.Errorf("count bound pods failed, cond: %v, err: %v, rid: %s", podCond, err, kit.Rid)
+		return nil, err
+	}
+	result.Count = uint64(counts[0])
+
+	if page.Limit <= 0 || int64(page.Start) >= counts[0] {
+		return result, nil
+	}
+
+	podQuery := &metadata.QueryCondition{
+		Condition: podCond,
+		Page: metadata.BasePage{
+			Start: page.Start,
+			Limit: page.Limit,
+			Sort:  common.BKFieldID,
+		},
+		DisableCounter: true,
+	}
+	pods, err := lgc.CoreAPI.CoreService().Kube().ListPod(kit.Ctx, kit.Header, podQuery)
+	if err != nil {
+		blog.Errorf("list bound pods failed, cond: %v, err: %v, rid: %s", podCond, err, kit.Rid)
+		return nil, err
+	}
+
+	if len(pods.Info) == 0 {
+		return result, nil
+	}
+
+	podContainers, err := lgc.getPodContainers(kit, pods.Info)
+	if err != nil {
+		return nil, err
+	}
+
+	procTemplates, err := lgc.getProcTemplateIDsByName(kit, option.BusinessID, bindings.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	bindingMap := make(map[types.WorkloadType]map[int64]types.WorkloadServiceBinding)
+	for _, binding := range bindings.Data {
+		if _, exists := bindingMap[binding.Ref.Kind]; !exists {
+			bindingMap[binding.Ref.Kind] = make(map[int64]types.WorkloadServiceBinding)
+		}
+		bindingMap[binding.Ref.Kind][binding.Ref.ID] = binding
+	}
+
+	for _, pod := range pods.Info {
+		binding := bindingMap[pod.Ref.Kind][pod.Ref.ID]
+		instance := buildContainerServiceInstance(pod, binding, podContainers[pod.ID],
+			procTemplates[binding.ServiceTemplateID])
+		result.Info = append(result.Info, instance)
+	}
+
+	return result, nil
+}
+
+// buildBoundPodCond build the condition of the pods that belong to the bound workloads, the host and label
+// filters of the option are applied to the pods.
+func buildBoundPodCond(kit *rest.Kit, option *metadata.ListServiceInstanceDetailOption,
+	bindings []types.WorkloadServiceBinding) (map[string]interface{}, errors.CCErrorCoder) {
+
+	workloadIDs := make(map[types.WorkloadType][]int64)
+	for _, binding := range bindings {
+		workloadIDs[binding.Ref.Kind] = append(workloadIDs[binding.Ref.Kind], binding.Ref.ID)
+	}
+
+	refConds := make([]map[string]interface{}, 0)
+	for kind, ids := range workloadIDs {
+		refConds = append(refConds, map[string]interface{}{
+			types.RefKindField: kind,
+			types.RefIDField:   map[string]interface{}{common.BKDBIN: ids},
+		})
+	}
+
+	cond := map[string]interface{}{
+		common.BKAppIDField: option.BusinessID,
+		common.BKDBOR:       refConds,
+	}
+
+	if option.HostID > 0 {
+		cond[common.BKHostIDField] = option.HostID
+	}
+
+	if len(option.HostList) > 0 {
+		cond[common.BKHostIDField] = map[string]interface{}{common.BKDBIN: option.HostList}
+	}
+
+	if len(option.Selectors) != 0 {
+		if key, err := option.Selectors.Validate(); err != nil {
+			blog.Errorf("selectors is invalid, selectors: %+v, key: %s, err: %v, rid: %s", option.Selectors, key,
+				err, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, key)
+		}
+
+		labelFilter, err := option.Selectors.ToMgoFilter()
+		if err != nil {
+			blog.Errorf("selectors to filter failed, selectors: %+v, err: %v, rid: %s", option.Selectors, err,
+				kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "labels")
+		}
+		cond = util.MergeMaps(cond, labelFilter)
+	}
+
+	return cond, nil
+}
+
+// getPodContainers get the containers of the pods, returns the map of pod id to its containers
+func (lgc *Logic) getPodContainers(kit *rest.Kit, pods []types.Pod) (map[int64][]types.Container,
+	errors.CCErrorCoder) {
+
+	podIDs := make([]int64, len(pods))
+	for idx, pod := range pods {
+		podIDs[idx] = pod.ID
+	}
+
+	query := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{types.BKPodIDField: mapstr.MapStr{common.BKDBIN: podIDs}},
+		Page:           metadata.BasePage{Limit: common.BKNoLimit, Sort: common.BKFieldID},
+		DisableCounter: true,
+	}
+	containers, err := lgc.CoreAPI.CoreService().Kube().ListContainer(kit.Ctx, kit.Header, query)
+	if err != nil {
+		blog.Errorf("list containers failed, pod ids: %v, err: %v, rid: %s", podIDs, err, kit.Rid)
+		return nil, err
+	}
+
+	podContainers := make(map[int64][]types.Container)
+	for _, container := range containers.Info {
+		podContainers[container.PodID] = append(podContainers[container.PodID], container)
+	}
+	return podContainers, nil
+}
+
+// getProcTemplateIDsByName get the process templates of the bound service templates, returns the map of
+// service template id to the map of process name to process template id
+func (lgc *Logic) getProcTemplateIDsByName(kit *rest.Kit, bizID int64, bindings []types.WorkloadServiceBinding) (
+	map[int64]map[string]int64, errors.CCErrorCoder) {
+
+	svcTemplateIDs := make([]int64, 0)
+	for _, binding := range bindings {
+		svcTemplateIDs = append(svcTemplateIDs, binding.ServiceTemplateID)
+	}
+
+	option := &metadata.ListProcessTemplatesOption{
+		BusinessID:         bizID,
+		ServiceTemplateIDs: util.IntArrayUnique(svcTemplateIDs),
+		Page:               metadata.BasePage{Limit: common.BKNoLimit},
+	}
+	templates, err := lgc.CoreAPI.CoreService().Process().ListProcessTemplates(kit.Ctx, kit.Header, option)
+	if err != nil {
+		blog.Errorf("list process templates failed, option: %#v, err: %v, rid: %s", option, err, kit.Rid)
+		return nil, err
+	}
+
+	procTemplates := make(map[int64]map[string]int64)
+	for _, template := range templates.Info {
+		if _, exists := procTemplates[template.ServiceTemplateID]; !exists {
+			procTemplates[template.ServiceTemplateID] = make(map[string]int64)
+		}
+		procTemplates[template.ServiceTemplateID][template.ProcessName] = template.ID
+	}
+	return procTemplates, nil
+}
+
+// buildContainerServiceInstance build the container backed service instance of the pod, the containers are
+// matched to the process templates by name, and their ports are used as the process bind info.
+func buildContainerServiceInstance(pod types.Pod, binding types.WorkloadServiceBinding,
+	containers []types.Container, procTemplates map[string]int64) metadata.ServiceInstanceDetail {
+
+	instance := metadata.ServiceInstanceDetail{
+		ServiceInstance: metadata.ServiceInstance{
+			BizID:             binding.BizID,
+			ServiceTemplateID: binding.ServiceTemplateID,
+			HostID:            pod.HostID,
+			ModuleID:          binding.ModuleID,
+			Creator:           pod.Creator,
+			Modifier:          pod.Modifier,
+			CreateTime:        time.Unix(pod.CreateTime, 0),
+			LastTime:          time.Unix(pod.LastTime, 0),
+			SupplierAccount:   pod.SupplierAccount,
+		},
+		ProcessInstances: make([]metadata.ProcessInstanceNG, 0),
+		PodID:            pod.ID,
+	}
+
+	if pod.Name != nil {
+		instance.Name = *pod.Name
+	}
+
+	if pod.Labels != nil {
+		instance.Labels = selector.Labels(*pod.Labels)
+	}
+
+	podIP := ""
+	if pod.IP != nil {
+		podIP = *pod.IP
+	}
+
+	for _, container := range containers {
+		process := metadata.Process{
+			ProcessName:     container.Name,
+			FuncName:        container.Name,
+			BusinessID:      binding.BizID,
+			SupplierAccount: container.SupplierAccount,
+			CreateTime:      time.Unix(container.CreateTime, 0),
+			LastTime:        time.Unix(container.LastTime, 0),
+			BindInfo:        make([]metadata.ProcBindInfo, 0),
+		}
+
+		if container.Ports != nil {
+			for _, port := range *container.Ports {
+				protocol := metadata.ProtocolTypeTCP
+				switch port.Protocol {
+				case "", "TCP":
+				case "UDP":
+					protocol = metadata.ProtocolTypeUDP
+				default:
+					// the process bind info does not support the other protocols, like SCTP
+					continue
+				}
+				bindInfo := metadata.NewContainerProcBindInfo(podIP, strconv.Itoa(int(port.ContainerPort)), protocol)
+				process.BindInfo = append(process.BindInfo, bindInfo)
+			}
+		}
+
+		relation := metadata.ProcessInstanceRelation{
+			BizID:           binding.BizID,
+			HostID:          pod.HostID,
+			SupplierAccount: container.SupplierAccount,
+		}
+		if container.Name != nil {
+			relation.ProcessTemplateID = procTemplates[*container.Name]
+		}
+
+		instance.ProcessInstances = append(instance.ProcessInstances, metadata.ProcessInstanceNG{
+			Process:     process,
+			Relation:    relation,
+			ContainerID: container.ID,
+		})
+	}
+
+	return instance
+}
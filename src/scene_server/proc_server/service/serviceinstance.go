@@ -594,6 +594,29 @@ func (ps *ProcServer) ListServiceInstancesDetails(ctx *rest.Contexts) {
 		return
 	}
 
+	if !input.WithContainer {
+		ctx.RespEntity(instances)
+		return
+	}
+
+	// the container backed service instances are placed after the host based ones, fill the rest of the page
+	// with them after the host based service instances are all returned.
+	page := metadata.BasePage{Start: input.Page.Start - int(instances.Count)}
+	if page.Start < 0 {
+		page.Start = 0
+	}
+	if input.Page.Limit > len(instances.Info) {
+		page.Limit = input.Page.Limit - len(instances.Info)
+	}
+
+	containerInstances, ccErr := ps.Logic.ListContainerServiceInstanceDetail(ctx.Kit, input, page)
+	if ccErr != nil {
+		ctx.RespAutoError(ccErr)
+		return
+	}
+
+	instances.Count += containerInstances.Count
+	instances.Info = append(instances.Info, containerInstances.Info...)
 	ctx.RespEntity(instances)
 }
 
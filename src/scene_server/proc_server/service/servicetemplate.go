@@ -370,6 +370,8 @@ func (ps *ProcServer) ExecServiceTemplateHostApplyRule(ctx *rest.Contexts) {
 				AttributeID:       rule.AttributeID,
 				ServiceTemplateID: rule.ServiceTemplateID,
 				PropertyValue:     rule.PropertyValue,
+				Condition:         rule.Condition,
+				Priority:          rule.Priority,
 			})
 		}
 		saveRuleOp := metadata.BatchCreateOrUpdateApplyRuleOption{Rules: rulesOption}
@@ -414,6 +416,21 @@ func (ps *ProcServer) ExecServiceTemplateHostApplyRule(ctx *rest.Contexts) {
 	// update host operation is not done in a transaction, since the successfully updated hosts need not roll back
 	ctx.Kit.Header.Del(common.TransactionIdHeader)
 
+	// conditional rules only apply to part of the hosts, so the hosts are updated by their own apply plans
+	if metadata.HasConditionalHostApplyRule(planReq.AdditionalRules) {
+		option := metadata.UpdateHostByHostApplyRuleOption{HostIDs: hostIDs}
+		_, ccErr := ps.CoreAPI.CoreService().HostApplyRule().RunHostApplyOnHosts(ctx.Kit.Ctx, ctx.Kit.Header,
+			planReq.BizID, option)
+		if ccErr != nil {
+			blog.Errorf("run host apply on hosts failed, bizID: %d, option: %#v, err: %v, rid: %s", planReq.BizID,
+				option, ccErr, rid)
+			ctx.RespAutoError(ccErr)
+			return
+		}
+		ctx.RespEntity(nil)
+		return
+	}
+
 	// host apply attribute rules to the host.
 	err = ps.updateHostAttributes(ctx.Kit, planReq, hostIDs)
 	if err != nil {
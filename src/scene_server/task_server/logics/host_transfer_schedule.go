@@ -0,0 +1,154 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"context"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+)
+
+// ListDueHostTransferSchedule list the waiting host transfer schedules of all supplier accounts whose execute time
+// is reached, the earliest ones are returned first.
+func (lgc *Logics) ListDueHostTransferSchedule(ctx context.Context, limit uint64, rid string) (
+	[]metadata.HostTransferSchedule, error) {
+
+	cond := mapstr.MapStr{
+		common.BKStatusField:      metadata.HostTransferScheduleWaiting,
+		common.BKExecuteTimeField: mapstr.MapStr{common.BKDBLTE: time.Now()},
+	}
+
+	schedules := make([]metadata.HostTransferSchedule, 0)
+	err := lgc.db.Table(common.BKTableNameHostTransferSchedule).Find(cond).Sort(common.BKExecuteTimeField).
+		Limit(limit).All(ctx, &schedules)
+	if err != nil {
+		blog.Errorf("list due host transfer schedules failed, cond: %v, err: %v, rid: %s", cond, err, rid)
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// DispatchHostTransferSchedule change the host transfer schedule to executing, and create the task that executes it
+// on behalf of the requester in the kit, the schedule is failed if the task can not be created.
+func (lgc *Logics) DispatchHostTransferSchedule(kit *rest.Kit, schedule *metadata.HostTransferSchedule) error {
+	cond := mapstr.MapStr{
+		common.BKFieldID:     schedule.ID,
+		common.BKStatusField: metadata.HostTransferScheduleWaiting,
+	}
+	data := mapstr.MapStr{
+		common.BKStatusField: metadata.HostTransferScheduleExecuting,
+		common.LastTimeField: time.Now(),
+	}
+
+	// the schedule may be canceled in the meantime, only the schedule that is still waiting can be dispatched
+	updated, err := lgc.db.Table(common.BKTableNameHostTransferSchedule).UpdateMany(kit.Ctx, cond, data)
+	if err != nil {
+		blog.Errorf("update host transfer schedule %d to executing failed, err: %v, rid: %s", schedule.ID, err,
+			kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
+	}
+
+	if updated == 0 {
+		blog.Infof("host transfer schedule %d is not waiting, skip dispatching it, rid: %s", schedule.ID, kit.Rid)
+		return nil
+	}
+
+	taskData := mapstr.MapStr{common.BKAppIDField: schedule.BizID, common.BKFieldID: schedule.ID}
+	task, taskErr := lgc.Create(kit, &metadata.CreateTaskRequest{
+		TaskType: common.HostTransferScheduleTaskFlag,
+		InstID:   schedule.ID,
+		Data:     []interface{}{taskData},
+	})
+
+	cond = mapstr.MapStr{common.BKFieldID: schedule.ID}
+	data = mapstr.MapStr{common.LastTimeField: time.Now()}
+	if taskErr != nil {
+		blog.Errorf("create host transfer schedule %d task failed, err: %v, rid: %s", schedule.ID, taskErr, kit.Rid)
+		data[common.BKStatusField] = metadata.HostTransferScheduleFailed
+		data["message"] = taskErr.Error()
+	} else {
+		data[common.BKTaskIDField] = task.TaskID
+	}
+
+	if err := lgc.db.Table(common.BKTableNameHostTransferSchedule).Update(kit.Ctx, cond, data); err != nil {
+		blog.Errorf("update host transfer schedule %d failed, data: %v, err: %v, rid: %s", schedule.ID, data, err,
+			kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
+	}
+
+	return taskErr
+}
+
+// RecoverStuckHostTransferSchedule recover the schedules that are changed to executing for longer than the timeout
+// but have no task id, which happens when the task server exits in the middle of dispatching them. if the task of
+// the schedule is created, its task id is saved, otherwise the schedule is changed back to waiting to be dispatched.
+func (lgc *Logics) RecoverStuckHostTransferSchedule(ctx context.Context, timeout time.Duration, limit uint64,
+	rid string) error {
+
+	cond := mapstr.MapStr{
+		common.BKStatusField: metadata.HostTransferScheduleExecuting,
+		common.BKTaskIDField: "",
+		common.LastTimeField: mapstr.MapStr{common.BKDBLTE: time.Now().Add(-timeout)},
+	}
+
+	schedules := make([]metadata.HostTransferSchedule, 0)
+	err := lgc.db.Table(common.BKTableNameHostTransferSchedule).Find(cond).Fields(common.BKFieldID).Limit(limit).
+		All(ctx, &schedules)
+	if err != nil {
+		blog.Errorf("list stuck host transfer schedules failed, cond: %v, err: %v, rid: %s", cond, err, rid)
+		return err
+	}
+
+	for _, schedule := range schedules {
+		taskCond := mapstr.MapStr{
+			common.BKTaskTypeField: common.HostTransferScheduleTaskFlag,
+			common.BKInstIDField:   schedule.ID,
+		}
+		task := new(metadata.APITaskDetail)
+		err := lgc.db.Table(common.BKTableNameAPITask).Find(taskCond).Fields(common.BKTaskIDField).One(ctx, task)
+		if err != nil && !lgc.db.IsNotFoundError(err) {
+			blog.Errorf("get host transfer schedule %d task failed, err: %v, rid: %s", schedule.ID, err, rid)
+			return err
+		}
+
+		data := mapstr.MapStr{common.LastTimeField: time.Now()}
+		if err == nil {
+			data[common.BKTaskIDField] = task.TaskID
+		} else {
+			data[common.BKStatusField] = metadata.HostTransferScheduleWaiting
+		}
+
+		// the schedule is checked again in case its task id is saved in the meantime
+		updateCond := mapstr.MapStr{
+			common.BKFieldID:     schedule.ID,
+			common.BKStatusField: metadata.HostTransferScheduleExecuting,
+			common.BKTaskIDField: "",
+		}
+		if _, err := lgc.db.Table(common.BKTableNameHostTransferSchedule).UpdateMany(ctx, updateCond,
+			data); err != nil {
+			blog.Errorf("recover host transfer schedule %d failed, data: %v, err: %v, rid: %s", schedule.ID, data,
+				err, rid)
+			return err
+		}
+
+		blog.Infof("recovered stuck host transfer schedule %d, data: %v, rid: %s", schedule.ID, data, rid)
+	}
+
+	return nil
+}
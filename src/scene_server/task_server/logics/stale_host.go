@@ -0,0 +1,293 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logics
+
+import (
+	"context"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+)
+
+// ListEnabledStaleHostPolicy list the enabled stale host policies of all supplier accounts
+func (lgc *Logics) ListEnabledStaleHostPolicy(ctx context.Context, rid string) ([]metadata.StaleHostPolicy, error) {
+	cond := mapstr.MapStr{"enabled": true}
+
+	policies := make([]metadata.StaleHostPolicy, 0)
+	if err := lgc.db.Table(common.BKTableNameStaleHostPolicy).Find(cond).All(ctx, &policies); err != nil {
+		blog.Errorf("list enabled stale host policies failed, err: %v, rid: %s", err, rid)
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// staleHostCandidate is a host with its snapshot status, the status is empty if the host never reports snapshot
+type staleHostCandidate struct {
+	HostID   int64                     `bson:"bk_host_id"`
+	Statuses []metadata.HostSnapStatus `bson:"snap_status"`
+}
+
+// ListStaleHostCandidate list the hosts that are not flagged as stale but have not reported the snapshot for the
+// stale hours of the policy, the hosts that never report the snapshot are listed if they are created before the stale
+// hours. the hosts are paged by the host id which is greater than the start host id.
+func (lgc *Logics) ListStaleHostCandidate(ctx context.Context, policy *metadata.StaleHostPolicy, startHostID int64,
+	limit uint64, rid string) ([]metadata.HostSnapStatus, error) {
+
+	cutoff := staleHostCutoffTime(policy)
+	pipeline := []map[string]interface{}{
+		{common.BKDBMatch: mapstr.MapStr{
+			common.BKHostIDField:     mapstr.MapStr{common.BKDBGT: startHostID},
+			common.BkSupplierAccount: policy.SupplierAccount,
+		}},
+		{common.BKDBSort: mapstr.MapStr{common.BKHostIDField: 1}},
+		{common.BKDBLookUp: mapstr.MapStr{
+			"from":         common.BKTableNameHostSnapStatus,
+			"localField":   common.BKHostIDField,
+			"foreignField": common.BKHostIDField,
+			"as":           "snap_status",
+		}},
+		{common.BKDBMatch: mapstr.MapStr{
+			common.BKDBOR: []mapstr.MapStr{
+				{
+					"snap_status":          mapstr.MapStr{common.BKDBSize: 0},
+					common.CreateTimeField: mapstr.MapStr{common.BKDBLT: cutoff},
+				},
+				{
+					"snap_status." + common.HostSnapStaleField:    false,
+					"snap_status." + common.HostSnapLastTimeField: mapstr.MapStr{common.BKDBLT: cutoff},
+				},
+			},
+		}},
+		{common.BKDBLimit: int64(limit)},
+		{common.BKDBProject: mapstr.MapStr{common.BKHostIDField: 1, "snap_status": 1}},
+	}
+
+	candidates := make([]staleHostCandidate, 0)
+	if err := lgc.db.Table(common.BKTableNameBaseHost).AggregateAll(ctx, pipeline, &candidates); err != nil {
+		blog.Errorf("list stale host candidates failed, pipeline: %v, err: %v, rid: %s", pipeline, err, rid)
+		return nil, err
+	}
+
+	statuses := make([]metadata.HostSnapStatus, len(candidates))
+	for idx, candidate := range candidates {
+		if len(candidate.Statuses) > 0 {
+			statuses[idx] = candidate.Statuses[0]
+			continue
+		}
+
+		statuses[idx] = metadata.HostSnapStatus{HostID: candidate.HostID, SupplierAccount: policy.SupplierAccount}
+	}
+
+	return statuses, nil
+}
+
+// staleHostCutoffTime returns the time before which the host's last snapshot is regarded as stale
+func staleHostCutoffTime(policy *metadata.StaleHostPolicy) time.Time {
+	return time.Now().Add(-time.Duration(policy.StaleHours) * time.Hour)
+}
+
+// HandleStaleHost flag the stale hosts and create the tasks that take the policy action on them by business.
+// the hosts of the excluded businesses are neither flagged nor handled, the hosts whose action task can not be
+// created are not flagged so that they are handled in the next round, and the hosts in the resource pool are not
+// transferred since the resource pool only has the idle module.
+func (lgc *Logics) HandleStaleHost(kit *rest.Kit, policy *metadata.StaleHostPolicy,
+	statuses []metadata.HostSnapStatus) error {
+
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	hostIDs := make([]int64, len(statuses))
+	for idx, status := range statuses {
+		hostIDs[idx] = status.HostID
+	}
+
+	bizHostIDs, orphanHostIDs, err := lgc.groupHostByBiz(kit, hostIDs)
+	if err != nil {
+		return err
+	}
+
+	// the status of the deleted hosts are no longer needed
+	if len(orphanHostIDs) > 0 {
+		cond := mapstr.MapStr{common.BKHostIDField: mapstr.MapStr{common.BKDBIN: orphanHostIDs}}
+		if err := lgc.db.Table(common.BKTableNameHostSnapStatus).Delete(kit.Ctx, cond); err != nil {
+			blog.Errorf("delete deleted hosts %v snapshot status failed, err: %v, rid: %s", orphanHostIDs, err,
+				kit.Rid)
+		}
+	}
+
+	resPoolBizID, err := lgc.getResourcePoolBizID(kit)
+	if err != nil {
+		return err
+	}
+
+	staleHostIDs := make([]int64, 0)
+	for bizID, ids := range bizHostIDs {
+		if policy.IsExcluded(bizID) {
+			continue
+		}
+
+		if policy.Action == metadata.StaleHostActionNone ||
+			(policy.Action == metadata.StaleHostActionTransfer && bizID == resPoolBizID) {
+			staleHostIDs = append(staleHostIDs, ids...)
+			continue
+		}
+
+		if err := lgc.createStaleHostTask(kit, policy, bizID, ids); err != nil {
+			continue
+		}
+		staleHostIDs = append(staleHostIDs, ids...)
+	}
+
+	if len(staleHostIDs) == 0 {
+		return nil
+	}
+
+	if err := lgc.flagStaleHost(kit, policy, statuses, staleHostIDs); err != nil {
+		return err
+	}
+
+	blog.Infof("flag stale hosts %v, action: %s, rid: %s", staleHostIDs, policy.Action, kit.Rid)
+	return nil
+}
+
+// flagStaleHost flag the stale hosts, the snapshot status is created for the stale hosts that never report snapshot
+func (lgc *Logics) flagStaleHost(kit *rest.Kit, policy *metadata.StaleHostPolicy, statuses []metadata.HostSnapStatus,
+	staleHostIDs []int64) error {
+
+	// the host that reported the snapshot in the meantime is not flagged
+	cond := mapstr.MapStr{
+		common.BKHostIDField:         mapstr.MapStr{common.BKDBIN: staleHostIDs},
+		common.HostSnapLastTimeField: mapstr.MapStr{common.BKDBLT: staleHostCutoffTime(policy)},
+	}
+	data := mapstr.MapStr{
+		common.HostSnapStaleField: true,
+		"stale_time":              time.Now(),
+	}
+	if _, err := lgc.db.Table(common.BKTableNameHostSnapStatus).UpdateMany(kit.Ctx, cond, data); err != nil {
+		blog.Errorf("flag stale hosts %v failed, err: %v, rid: %s", staleHostIDs, err, kit.Rid)
+		return err
+	}
+
+	staleHostMap := make(map[int64]struct{})
+	for _, hostID := range staleHostIDs {
+		staleHostMap[hostID] = struct{}{}
+	}
+
+	now := time.Now()
+	for _, status := range statuses {
+		if _, exists := staleHostMap[status.HostID]; !exists || !status.LastSnapshotTime.IsZero() {
+			continue
+		}
+
+		status.Stale = true
+		status.StaleTime = now
+		// the host that reported the snapshot in the meantime already has its status, skip it
+		err := lgc.db.Table(common.BKTableNameHostSnapStatus).Insert(kit.Ctx, status)
+		if err != nil && !lgc.db.IsDuplicatedError(err) {
+			blog.Errorf("create stale host %d snapshot status failed, err: %v, rid: %s", status.HostID, err, kit.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// groupHostByBiz returns the host ids grouped by their business, and the ids of the hosts that have been deleted
+func (lgc *Logics) groupHostByBiz(kit *rest.Kit, hostIDs []int64) (map[int64][]int64, []int64, error) {
+	cond := mapstr.MapStr{common.BKHostIDField: mapstr.MapStr{common.BKDBIN: hostIDs}}
+
+	relations := make([]metadata.ModuleHost, 0)
+	err := lgc.db.Table(common.BKTableNameModuleHostConfig).Find(cond).
+		Fields(common.BKHostIDField, common.BKAppIDField).All(kit.Ctx, &relations)
+	if err != nil {
+		blog.Errorf("get host relations failed, hosts: %v, err: %v, rid: %s", hostIDs, err, kit.Rid)
+		return nil, nil, err
+	}
+
+	bizHostIDs := make(map[int64][]int64)
+	hostExists := make(map[int64]struct{})
+	for _, relation := range relations {
+		// a host can be in multiple modules of the same business
+		if _, exists := hostExists[relation.HostID]; exists {
+			continue
+		}
+		hostExists[relation.HostID] = struct{}{}
+		bizHostIDs[relation.AppID] = append(bizHostIDs[relation.AppID], relation.HostID)
+	}
+
+	orphanHostIDs := make([]int64, 0)
+	for _, hostID := range hostIDs {
+		if _, exists := hostExists[hostID]; !exists {
+			orphanHostIDs = append(orphanHostIDs, hostID)
+		}
+	}
+
+	return bizHostIDs, orphanHostIDs, nil
+}
+
+// getResourcePoolBizID get the id of the resource pool business of the supplier account in the kit
+func (lgc *Logics) getResourcePoolBizID(kit *rest.Kit) (int64, error) {
+	cond := mapstr.MapStr{
+		common.BKDefaultField:    common.DefaultAppFlag,
+		common.BkSupplierAccount: kit.SupplierAccount,
+	}
+
+	biz := make(mapstr.MapStr)
+	err := lgc.db.Table(common.BKTableNameBaseApp).Find(cond).Fields(common.BKAppIDField).One(kit.Ctx, &biz)
+	if err != nil {
+		blog.Errorf("get resource pool biz failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return 0, err
+	}
+
+	return biz.Int64(common.BKAppIDField)
+}
+
+// createStaleHostTask create the task that takes the policy action on the stale hosts of the business in batches
+func (lgc *Logics) createStaleHostTask(kit *rest.Kit, policy *metadata.StaleHostPolicy, bizID int64,
+	hostIDs []int64) error {
+
+	taskData := make([]interface{}, 0)
+	for start := 0; start < len(hostIDs); start += metadata.StaleHostTaskBatchSize {
+		end := start + metadata.StaleHostTaskBatchSize
+		if end > len(hostIDs) {
+			end = len(hostIDs)
+		}
+
+		taskData = append(taskData, mapstr.MapStr{
+			common.BKAppIDField: bizID,
+			"bk_host_ids":       hostIDs[start:end],
+			"action":            policy.Action,
+			"default_module":    policy.DefaultModule,
+			"state":             policy.State,
+		})
+	}
+
+	_, err := lgc.Create(kit, &metadata.CreateTaskRequest{
+		TaskType: common.StaleHostTaskFlag,
+		InstID:   bizID,
+		Data:     taskData,
+	})
+	if err != nil {
+		blog.Errorf("create stale host task failed, biz: %d, hosts: %v, err: %v, rid: %s", bizID, hostIDs, err,
+			kit.Rid)
+		return err
+	}
+
+	return nil
+}
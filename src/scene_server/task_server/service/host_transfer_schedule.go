@@ -0,0 +1,99 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/util"
+)
+
+const (
+	// hostTransferScheduleInterval is the interval to check if there are host transfer schedules to be executed
+	hostTransferScheduleInterval = 10 * time.Second
+	// hostTransferScheduleBatchSize is the max number of the host transfer schedules that are dispatched at a time
+	hostTransferScheduleBatchSize = 100
+	// hostTransferScheduleStuckTimeout is the timeout after which an executing schedule without task is recovered
+	hostTransferScheduleStuckTimeout = 5 * time.Minute
+)
+
+// dispatchHostTransferSchedule loop to dispatch the host transfer schedules whose execute time is reached to the
+// task queue, the dispatched schedules are executed by the host server on behalf of their requesters.
+func (tq *TaskQueue) dispatchHostTransferSchedule(ctx context.Context) {
+	blog.Infof("start dispatch host transfer schedules")
+
+	for {
+		if tq.close {
+			return
+		}
+
+		if !tq.service.Engine.ServiceManageInterface.IsMaster() {
+			blog.V(4).Infof("dispatch host transfer schedules, but is not master, skip")
+			time.Sleep(time.Minute)
+			continue
+		}
+
+		if !tq.dispatchDueHostTransferSchedule(ctx) {
+			time.Sleep(hostTransferScheduleInterval)
+		}
+	}
+}
+
+// dispatchDueHostTransferSchedule recover the stuck schedules and dispatch a batch of the due schedules, returns if
+// there may be more due schedules to dispatch. the panic is recovered so that the dispatching loop keeps running.
+func (tq *TaskQueue) dispatchDueHostTransferSchedule(ctx context.Context) (hasMore bool) {
+	rid := util.GenerateRID()
+
+	defer func() {
+		if fetalErr := recover(); fetalErr != nil {
+			blog.Errorf("dispatch host transfer schedules panic, err: %v, stack: %s, rid: %s", fetalErr,
+				debug.Stack(), rid)
+			hasMore = false
+		}
+	}()
+
+	err := tq.service.Logics.RecoverStuckHostTransferSchedule(ctx, hostTransferScheduleStuckTimeout,
+		hostTransferScheduleBatchSize, rid)
+	if err != nil {
+		blog.Errorf("recover stuck host transfer schedules failed, err: %v, rid: %s", err, rid)
+	}
+
+	schedules, err := tq.service.Logics.ListDueHostTransferSchedule(ctx, hostTransferScheduleBatchSize, rid)
+	if err != nil {
+		return false
+	}
+
+	for idx := range schedules {
+		if tq.close {
+			return false
+		}
+
+		schedule := &schedules[idx]
+		header := util.BuildHeader(schedule.Creator, schedule.OwnerID)
+		kit := rest.NewKitFromHeader(header, tq.service.CCErr)
+		kit.Ctx = ctx
+
+		blog.Infof("dispatch host transfer schedule %d of biz %d, execute time: %s, rid: %s", schedule.ID,
+			schedule.BizID, schedule.ExecuteTime, kit.Rid)
+
+		if err := tq.service.Logics.DispatchHostTransferSchedule(kit, schedule); err != nil {
+			blog.Errorf("dispatch host transfer schedule %d failed, err: %v, rid: %s", schedule.ID, err, kit.Rid)
+		}
+	}
+
+	return len(schedules) == hostTransferScheduleBatchSize
+}
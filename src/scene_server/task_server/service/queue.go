@@ -85,6 +85,18 @@ func (tq *TaskQueue) Stop() {
 func (tq *TaskQueue) Start() {
 	go tq.compensate(context.Background())
 
+	tq.Add(1)
+	go func() {
+		defer tq.Done()
+		tq.dispatchHostTransferSchedule(context.Background())
+	}()
+
+	tq.Add(1)
+	go func() {
+		defer tq.Done()
+		tq.checkStaleHost(context.Background())
+	}()
+
 	for _, taskInfo := range tq.task {
 		go func(taskInfo TaskInfo) {
 			tq.Add(1)
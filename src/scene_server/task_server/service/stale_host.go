@@ -0,0 +1,107 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+)
+
+const (
+	// staleHostCheckInterval is the interval to check if there are hosts that become stale
+	staleHostCheckInterval = 5 * time.Minute
+	// staleHostBatchSize is the max number of the stale host candidates that are handled at a time
+	staleHostBatchSize = 500
+)
+
+// checkStaleHost loop to flag the hosts that have not reported the snapshot for the stale hours of the stale host
+// policy of their supplier account, and create the tasks that take the policy action on them.
+func (tq *TaskQueue) checkStaleHost(ctx context.Context) {
+	blog.Infof("start check stale hosts")
+
+	defer func() {
+		if fetalErr := recover(); fetalErr != nil {
+			blog.Errorf("err:%s, panic:%s", fetalErr, debug.Stack())
+		}
+	}()
+
+	for {
+		if tq.close {
+			return
+		}
+
+		if !tq.service.Engine.ServiceManageInterface.IsMaster() {
+			blog.V(4).Infof("check stale hosts, but is not master, skip")
+			time.Sleep(time.Minute)
+			continue
+		}
+
+		rid := util.GenerateRID()
+		policies, err := tq.service.Logics.ListEnabledStaleHostPolicy(ctx, rid)
+		if err != nil {
+			time.Sleep(staleHostCheckInterval)
+			continue
+		}
+
+		for idx := range policies {
+			if tq.close {
+				return
+			}
+			tq.checkPolicyStaleHost(ctx, &policies[idx])
+		}
+
+		time.Sleep(staleHostCheckInterval)
+	}
+}
+
+// checkPolicyStaleHost handle all the stale hosts of the supplier account of the stale host policy
+func (tq *TaskQueue) checkPolicyStaleHost(ctx context.Context, policy *metadata.StaleHostPolicy) {
+	header := util.BuildHeader(common.CCSystemOperatorUserName, policy.SupplierAccount)
+	kit := rest.NewKitFromHeader(header, tq.service.CCErr)
+	kit.Ctx = ctx
+
+	startHostID := int64(0)
+	for {
+		if tq.close {
+			return
+		}
+
+		statuses, err := tq.service.Logics.ListStaleHostCandidate(ctx, policy, startHostID, staleHostBatchSize,
+			kit.Rid)
+		if err != nil {
+			return
+		}
+
+		if len(statuses) == 0 {
+			return
+		}
+
+		if err := tq.service.Logics.HandleStaleHost(kit, policy, statuses); err != nil {
+			blog.Errorf("handle stale hosts failed, supplier account: %s, err: %v, rid: %s", policy.SupplierAccount,
+				err, kit.Rid)
+			return
+		}
+
+		if len(statuses) < staleHostBatchSize {
+			return
+		}
+		startHostID = statuses[len(statuses)-1].HostID
+	}
+}
@@ -46,6 +46,11 @@ func init() {
 		"/host/v3/updatemany/module/host_apply_plan/task", 1, 2)
 	AddCodeTaskConfig(common.SyncServiceTemplateHostApplyTaskFlag, types.CC_MODULE_PROC,
 		"/process/v3/updatemany/service_template/host_apply_plan/task", 1, 2)
+	AddCodeTaskConfig(common.BulkUpdateHostTaskFlag, types.CC_MODULE_HOST, "/host/v3/updatemany/hosts/by_filter/task", 1,
+		30)
+	AddCodeTaskConfig(common.HostTransferScheduleTaskFlag, types.CC_MODULE_HOST,
+		"/host/v3/updatemany/hosts/transfer_schedule/task", 1, 30)
+	AddCodeTaskConfig(common.StaleHostTaskFlag, types.CC_MODULE_HOST, "/host/v3/updatemany/hosts/stale/task", 1, 30)
 }
 
 // AddCodeTaskConfig add task
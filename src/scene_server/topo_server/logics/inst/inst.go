@@ -57,6 +57,9 @@ type InstOperationInterface interface {
 	// CountObjectInstances counts object instances num.
 	CountObjectInstances(kit *rest.Kit, objID string, input *metadata.CommonCountFilter) (*metadata.CommonCountResult,
 		error)
+	// AggregateObjectInstances groups object instances and calculates the metrics of each group.
+	AggregateObjectInstances(kit *rest.Kit, objID string, input *metadata.InstAggregateOption) (
+		*metadata.InstAggregateResult, error)
 	// FindInstChildTopo find instance's child topo
 	FindInstChildTopo(kit *rest.Kit, objID string, instID int64) (int, []*metadata.CommonInstTopo, error)
 	// FindInstTopo find instance all topo which include it's child and parent
@@ -545,7 +548,7 @@ func (c *commonInst) FindInst(kit *rest.Kit, objID string, cond *metadata.QueryC
 			return nil, err
 		}
 
-		return &metadata.InstResult{Count: rsp.Count, Info: rsp.Info}, nil
+		return &metadata.InstResult{Count: rsp.Count, Info: rsp.Info, NextCursor: rsp.NextCursor}, nil
 	}
 }
 
@@ -803,6 +806,61 @@ func (c *commonInst) CountObjectInstances(kit *rest.Kit, objID string,
 	return &metadata.CommonCountResult{Count: resp.Count}, nil
 }
 
+// AggregateObjectInstances groups object instances and calculates the metrics of each group.
+func (c *commonInst) AggregateObjectInstances(kit *rest.Kit, objID string, input *metadata.InstAggregateOption) (
+	*metadata.InstAggregateResult, error) {
+
+	attrOpt := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{common.BKObjIDField: objID},
+		Fields:         []string{common.BKPropertyIDField, common.BKPropertyTypeField},
+		Page:           metadata.BasePage{Limit: common.BKNoLimit},
+		DisableCounter: true,
+	}
+
+	attrs, err := c.clientSet.CoreService().Model().ReadModelAttr(kit.Ctx, kit.Header, objID, attrOpt)
+	if err != nil {
+		blog.Errorf("get object %s attributes failed, err: %v, rid: %s", objID, err, kit.Rid)
+		return nil, err
+	}
+
+	propertyTypes := map[string]string{
+		metadata.GetInstIDFieldByObjID(objID): common.FieldTypeInt,
+		common.CreateTimeField:                common.FieldTypeTime,
+		common.LastTimeField:                  common.FieldTypeTime,
+	}
+	for _, attr := range attrs.Info {
+		propertyTypes[attr.PropertyID] = attr.PropertyType
+	}
+
+	// hosts can be aggregated by their topology relations, these relation fields can only be used to filter, group
+	// and count distinct, since the set and module relations are multiple values.
+	if objID == common.BKInnerObjIDHost {
+		for _, field := range metadata.HostRelationAggregateFields {
+			propertyTypes[field] = common.FieldTypeInt
+		}
+
+		for _, metric := range input.Metrics {
+			if metric.Type != metadata.AggregateCount && metric.Type != metadata.AggregateDistinctCount &&
+				util.InStrArr(metadata.HostRelationAggregateFields, metric.Field) {
+				return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "metrics.field")
+			}
+		}
+	}
+
+	if invalidKey, err := input.ValidateFields(propertyTypes); err != nil {
+		blog.Errorf("aggregate object %s instances option is invalid, err: %v, rid: %s", objID, err, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, invalidKey)
+	}
+
+	result, err := c.clientSet.CoreService().Instance().AggregateInstances(kit.Ctx, kit.Header, objID, input)
+	if err != nil {
+		blog.Errorf("aggregate object %s instances failed, err: %v, rid: %s", objID, err, kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // FindInstChildTopo find instance's child topo
 func (c *commonInst) FindInstChildTopo(kit *rest.Kit, objID string, instID int64) (
 	int, []*metadata.CommonInstTopo, error) {
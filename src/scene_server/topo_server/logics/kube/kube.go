@@ -41,6 +41,9 @@ type KubeOperationInterface interface {
 	BatchDeleteNode(kit *rest.Kit, bizID int64, option *types.BatchDeleteNodeOption) error
 	BatchCreateNode(kit *rest.Kit, data *types.CreateNodesOption, bizID int64) ([]int64, error)
 	BatchCreatePod(kit *rest.Kit, data *types.CreatePodsOption) ([]int64, error)
+	PlanManifestImport(kit *rest.Kit, bizID int64, opt *types.ManifestImportOption) (*types.ManifestImportPlan,
+		error)
+	ImportManifest(kit *rest.Kit, bizID int64, opt *types.ManifestImportOption) (*types.ManifestImportPlan, error)
 }
 
 // NewClusterOperation create a business instance
@@ -0,0 +1,263 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kube
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"configcenter/src/kube/converter"
+	"configcenter/src/kube/types"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// manifestBufferSize is the buffer size used to sniff whether the manifests are yaml or json
+	manifestBufferSize = 4096
+	// podsWorkloadName is the name of the pods workload that the pods without a supported workload owner belong to
+	podsWorkloadName = "pods"
+)
+
+var (
+	namespaceGK     = schema.GroupKind{Group: corev1.GroupName, Kind: "Namespace"}
+	resourceQuotaGK = schema.GroupKind{Group: corev1.GroupName, Kind: "ResourceQuota"}
+	podGK           = schema.GroupKind{Group: corev1.GroupName, Kind: "Pod"}
+	deploymentGK    = schema.GroupKind{Group: appsv1.GroupName, Kind: "Deployment"}
+	statefulSetGK   = schema.GroupKind{Group: appsv1.GroupName, Kind: "StatefulSet"}
+	daemonSetGK     = schema.GroupKind{Group: appsv1.GroupName, Kind: "DaemonSet"}
+	jobGK           = schema.GroupKind{Group: batchv1.GroupName, Kind: "Job"}
+	cronJobGK       = schema.GroupKind{Group: batchv1.GroupName, Kind: "CronJob"}
+)
+
+// manifestWorkloadKinds is the workload kinds that can be imported from the manifests in the order that they are
+// created, the pods workload is not a kubernetes resource, it is created for the pods without a supported owner.
+var manifestWorkloadKinds = []types.WorkloadType{types.KubeDeployment, types.KubeStatefulSet, types.KubeDaemonSet,
+	types.KubeCronJob, types.KubeJob, types.KubePodWorkload}
+
+// manifestObjects is the kubernetes objects parsed from the manifests, the objects are keyed by their namespace and
+// name, the latter object overrides the former one with the same key.
+type manifestObjects struct {
+	namespaces map[string]*corev1.Namespace
+	quotas     map[string][]*corev1.ResourceQuota
+	workloads  map[types.WorkloadType]map[string]types.WorkloadInterface
+	pods       map[string]*corev1.Pod
+	// skipped is the objects whose kind is not supported
+	skipped []types.ManifestResource
+	count   int
+}
+
+// objectKey returns the key of the namespaced object
+func objectKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// splitObjectKey returns the namespace and name of the object key
+func splitObjectKey(key string) (string, string) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return "", key
+	}
+	return parts[0], parts[1]
+}
+
+// parseManifest parses the multi-document yaml or json manifests, the lists of objects are expanded.
+func parseManifest(manifest string) (*manifestObjects, error) {
+	objects := &manifestObjects{
+		namespaces: make(map[string]*corev1.Namespace),
+		quotas:     make(map[string][]*corev1.ResourceQuota),
+		workloads:  make(map[types.WorkloadType]map[string]types.WorkloadInterface),
+		pods:       make(map[string]*corev1.Pod),
+		skipped:    make([]types.ManifestResource, 0),
+	}
+	for _, kind := range manifestWorkloadKinds {
+		objects.workloads[kind] = make(map[string]types.WorkloadInterface)
+	}
+
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), manifestBufferSize)
+	for {
+		raw := json.RawMessage{}
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("decode manifest failed, err: %v", err)
+		}
+
+		// skip the empty yaml documents
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+
+		obj := new(unstructured.Unstructured)
+		if err := obj.UnmarshalJSON(raw); err != nil {
+			return nil, fmt.Errorf("decode manifest object failed, err: %v", err)
+		}
+
+		if !obj.IsList() {
+			if err := objects.add(obj); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		list, err := obj.ToList()
+		if err != nil {
+			return nil, fmt.Errorf("decode manifest %s failed, err: %v", obj.GetKind(), err)
+		}
+		for idx := range list.Items {
+			if err := objects.add(&list.Items[idx]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// add converts the object to the cmdb kube type and adds it to the manifest objects
+func (m *manifestObjects) add(obj *unstructured.Unstructured) error {
+	m.count++
+	if m.count > types.ManifestObjectLimit {
+		return fmt.Errorf("manifest objects exceed the limit %d", types.ManifestObjectLimit)
+	}
+
+	name := obj.GetName()
+	if name == "" {
+		return fmt.Errorf("manifest %s object name is not set", obj.GetKind())
+	}
+
+	// the namespaced objects without namespace are created in the default namespace by kubectl
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	key := objectKey(namespace, name)
+
+	switch obj.GroupVersionKind().GroupKind() {
+	case namespaceGK:
+		kubeNamespace := new(corev1.Namespace)
+		if err := fromUnstructured(obj, kubeNamespace); err != nil {
+			return err
+		}
+		m.namespaces[name] = kubeNamespace
+	case resourceQuotaGK:
+		quota := new(corev1.ResourceQuota)
+		if err := fromUnstructured(obj, quota); err != nil {
+			return err
+		}
+		m.quotas[namespace] = append(m.quotas[namespace], quota)
+	case podGK:
+		pod := new(corev1.Pod)
+		if err := fromUnstructured(obj, pod); err != nil {
+			return err
+		}
+		pod.Namespace = namespace
+		m.pods[key] = pod
+	case deploymentGK:
+		deploy := new(appsv1.Deployment)
+		if err := fromUnstructured(obj, deploy); err != nil {
+			return err
+		}
+		m.workloads[types.KubeDeployment][key] = converter.Deployment(deploy)
+	case statefulSetGK:
+		sts := new(appsv1.StatefulSet)
+		if err := fromUnstructured(obj, sts); err != nil {
+			return err
+		}
+		m.workloads[types.KubeStatefulSet][key] = converter.StatefulSet(sts)
+	case daemonSetGK:
+		ds := new(appsv1.DaemonSet)
+		if err := fromUnstructured(obj, ds); err != nil {
+			return err
+		}
+		m.workloads[types.KubeDaemonSet][key] = converter.DaemonSet(ds)
+	case jobGK:
+		job := new(batchv1.Job)
+		if err := fromUnstructured(obj, job); err != nil {
+			return err
+		}
+		m.workloads[types.KubeJob][key] = converter.Job(job)
+	case cronJobGK:
+		// the batch/v1beta1 cronjob has the same structure as the batch/v1 cronjob in the fields saved in cmdb
+		cronJob := new(batchv1.CronJob)
+		if err := fromUnstructured(obj, cronJob); err != nil {
+			return err
+		}
+		m.workloads[types.KubeCronJob][key] = converter.CronJob(cronJob)
+	default:
+		m.skipped = append(m.skipped, types.ManifestResource{
+			Kind:      obj.GetKind(),
+			Namespace: obj.GetNamespace(),
+			Name:      name,
+			Reason:    "unsupported kind",
+		})
+	}
+	return nil
+}
+
+// fromUnstructured converts the unstructured object to the typed kubernetes api object
+func fromUnstructured(obj *unstructured.Unstructured, typed interface{}) error {
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), typed)
+	if err != nil {
+		return fmt.Errorf("convert manifest %s %s failed, err: %v", obj.GetKind(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// resolvePodRef returns the workload that the pod belongs to, the pods whose owner is not a supported workload
+// belong to the pods workload of their namespace.
+func resolvePodRef(pod *corev1.Pod) types.Reference {
+	podsWorkload := types.Reference{Kind: types.KubePodWorkload, Name: podsWorkloadName}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return podsWorkload
+	}
+
+	switch owner.Kind {
+	case "ReplicaSet":
+		// the replica sets of a deployment are named by the deployment name and the pod template hash, since the
+		// replica sets are not imported, the deployment is resolved by the pod template hash label of the pod.
+		hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		if hash == "" || !strings.HasSuffix(owner.Name, "-"+hash) {
+			return podsWorkload
+		}
+		return types.Reference{Kind: types.KubeDeployment, Name: strings.TrimSuffix(owner.Name, "-"+hash)}
+	case "StatefulSet":
+		return types.Reference{Kind: types.KubeStatefulSet, Name: owner.Name}
+	case "DaemonSet":
+		return types.Reference{Kind: types.KubeDaemonSet, Name: owner.Name}
+	case "Job":
+		return types.Reference{Kind: types.KubeJob, Name: owner.Name}
+	default:
+		return podsWorkload
+	}
+}
@@ -0,0 +1,1009 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kube
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"configcenter/src/common"
+	"configcenter/src/common/auditlog"
+	"configcenter/src/common/blog"
+	ccErr "configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/converter"
+	"configcenter/src/kube/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// manifestPageSize is the page size of the cmdb resources listed to plan the manifest import
+	manifestPageSize = 500
+	// manifestBatchSize is the max number of the resources created or deleted by one request of the manifest import
+	manifestBatchSize = 200
+)
+
+// namespaceUpdate is a namespace to be updated by the manifest import
+type namespaceUpdate struct {
+	current types.Namespace
+	data    *types.Namespace
+}
+
+// workloadUpdate is a workload to be updated by the manifest import
+type workloadUpdate struct {
+	current types.WorkloadInterface
+	data    types.WorkloadInterface
+}
+
+// podCreate is a pod to be created by the manifest import, its namespace and workload ids are set when they are
+// created before the pod.
+type podCreate struct {
+	namespace string
+	info      types.PodsInfo
+}
+
+// podUpdate is a pod to be updated in place by the manifest import
+type podUpdate struct {
+	namespace string
+	current   types.Pod
+	info      types.PodsInfo
+}
+
+// manifestImporter plans and applies the import of the kube resources in the manifests into a cluster
+type manifestImporter struct {
+	*kube
+	kit     *rest.Kit
+	bizID   int64
+	cluster types.Cluster
+	objects *manifestObjects
+	plan    *types.ManifestImportPlan
+
+	// namespaces is the cmdb namespaces of the cluster that are in the manifests by name
+	namespaces map[string]types.Namespace
+	// workloads is the cmdb workloads of each kind in the namespaces of the manifests by the object key
+	workloads map[types.WorkloadType]map[string]types.WorkloadInterface
+	// pods is the cmdb pods in the namespaces of the manifests by the object key
+	pods map[string]*types.Pod
+	// containers is the cmdb containers of the pods in the manifests by pod id
+	containers map[int64][]types.Container
+	// nodes is the cmdb nodes of the pods in the manifests by name
+	nodes map[string]types.Node
+	// podRefs is the workload of each manifest pod by the object key
+	podRefs map[string]types.Reference
+
+	createNamespaces map[string]types.Namespace
+	updateNamespaces []namespaceUpdate
+	createWorkloads  map[types.WorkloadType]map[string]types.WorkloadInterface
+	updateWorkloads  map[types.WorkloadType][]workloadUpdate
+	deleteWorkloads  map[types.WorkloadType][]types.WorkloadInterface
+	createPods       []podCreate
+	updatePods       []podUpdate
+	deletePods       []types.Pod
+}
+
+// PlanManifestImport returns the changes to the cmdb kube resources of the cluster to import the manifests
+func (b *kube) PlanManifestImport(kit *rest.Kit, bizID int64, opt *types.ManifestImportOption) (
+	*types.ManifestImportPlan, error) {
+
+	importer, err := b.newManifestImporter(kit, bizID, opt)
+	if err != nil {
+		return nil, err
+	}
+	return importer.plan, nil
+}
+
+// ImportManifest imports the kube resources in the manifests into the cluster, returns the applied changes.
+// only the previewed plan is applied, the import is rejected if the plan is changed since it is previewed.
+func (b *kube) ImportManifest(kit *rest.Kit, bizID int64, opt *types.ManifestImportOption) (
+	*types.ManifestImportPlan, error) {
+
+	if opt.PlanHash == "" {
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsNeedSet, types.ManifestPlanHashField)
+	}
+
+	importer, err := b.newManifestImporter(kit, bizID, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if importer.plan.Hash != opt.PlanHash {
+		blog.Errorf("manifest import plan of cluster %d is changed, previewed hash: %s, current hash: %s, rid: %s",
+			opt.ClusterID, opt.PlanHash, importer.plan.Hash, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrorTopoManifestPlanChanged)
+	}
+
+	steps := []func() error{importer.applyNamespaces, importer.applyWorkloads, importer.applyPods,
+		importer.applyWorkloadDeletion}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return importer.plan, nil
+}
+
+// newManifestImporter parses the manifests and plans the import by the cmdb resources of the cluster
+func (b *kube) newManifestImporter(kit *rest.Kit, bizID int64, opt *types.ManifestImportOption) (
+	*manifestImporter, error) {
+
+	objects, err := parseManifest(opt.Manifest)
+	if err != nil {
+		blog.Errorf("parse manifest failed, err: %v, rid: %s", err, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, err.Error())
+	}
+
+	m := &manifestImporter{
+		kube:    b,
+		kit:     kit,
+		bizID:   bizID,
+		objects: objects,
+		plan: &types.ManifestImportPlan{
+			Create: make([]types.ManifestResource, 0),
+			Update: make([]types.ManifestResource, 0),
+			Delete: make([]types.ManifestResource, 0),
+			Skip:   objects.skipped,
+		},
+		workloads:        make(map[types.WorkloadType]map[string]types.WorkloadInterface),
+		podRefs:          make(map[string]types.Reference),
+		createNamespaces: make(map[string]types.Namespace),
+		createWorkloads:  make(map[types.WorkloadType]map[string]types.WorkloadInterface),
+		updateWorkloads:  make(map[types.WorkloadType][]workloadUpdate),
+		deleteWorkloads:  make(map[types.WorkloadType][]types.WorkloadInterface),
+	}
+
+	if err := m.getCluster(opt.ClusterID); err != nil {
+		return nil, err
+	}
+
+	listSteps := []func() error{m.listNamespaces, m.listWorkloads, m.listPods, m.listNodes}
+	for _, step := range listSteps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	m.planNamespaces()
+	m.planWorkloads()
+	m.planPods()
+	m.planWorkloadDeletion()
+
+	if err := m.hashPlan(opt.Manifest); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// hashPlan sets the hash of the plan, it is generated by the manifests and the planned changes, so that the
+// import can check if the plan is still the previewed one.
+func (m *manifestImporter) hashPlan(manifest string) error {
+	content, err := json.Marshal(map[string]interface{}{
+		common.BKAppIDField:    m.bizID,
+		types.BKClusterIDFiled: m.cluster.ID,
+		types.ManifestField:    manifest,
+		"plan":                 m.plan,
+	})
+	if err != nil {
+		blog.Errorf("marshal manifest import plan failed, err: %v, rid: %s", err, m.kit.Rid)
+		return m.kit.CCError.CCError(common.CCErrCommJSONMarshalFailed)
+	}
+
+	sum := sha256.Sum256(content)
+	m.plan.Hash = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// skip records the resource that is skipped with the reason in the plan
+func (m *manifestImporter) skip(kind, namespace, name, reason string) {
+	m.plan.Skip = append(m.plan.Skip, types.ManifestResource{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Reason:    reason,
+	})
+}
+
+// sortedKeys returns the sorted keys of the object keys, so that the plan is stable for the same manifests
+func sortedKeys(keys map[string]struct{}) []string {
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// manifestNamespaces returns the names of all the namespaces that the manifest objects are in
+func (m *manifestImporter) manifestNamespaces() []string {
+	names := make(map[string]struct{})
+	for name := range m.objects.namespaces {
+		names[name] = struct{}{}
+	}
+	for name := range m.objects.quotas {
+		names[name] = struct{}{}
+	}
+	for _, workloads := range m.objects.workloads {
+		for key := range workloads {
+			namespace, _ := splitObjectKey(key)
+			names[namespace] = struct{}{}
+		}
+	}
+	for _, pod := range m.objects.pods {
+		names[pod.Namespace] = struct{}{}
+	}
+	return sortedKeys(names)
+}
+
+// listByPage lists the cmdb resources page by page, the list function returns the number of the listed resources
+func listByPage(list func(page metadata.BasePage) (int, error)) error {
+	for start := 0; ; start += manifestPageSize {
+		count, err := list(metadata.BasePage{Start: start, Limit: manifestPageSize, Sort: types.BKIDField})
+		if err != nil {
+			return err
+		}
+		if count < manifestPageSize {
+			return nil
+		}
+	}
+}
+
+func (m *manifestImporter) clusterCond() mapstr.MapStr {
+	return mapstr.MapStr{common.BKAppIDField: m.bizID, types.BKClusterIDFiled: m.cluster.ID}
+}
+
+func (m *manifestImporter) getCluster(clusterID int64) error {
+	query := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{types.BKIDField: clusterID, common.BKAppIDField: m.bizID},
+		Fields:         []string{types.BKIDField, types.BKBizIDField, types.UidField},
+		DisableCounter: true,
+	}
+	result, err := m.clientSet.CoreService().Kube().SearchCluster(m.kit.Ctx, m.kit.Header, query)
+	if err != nil {
+		blog.Errorf("search cluster failed, cond: %v, err: %v, rid: %s", query.Condition, err, m.kit.Rid)
+		return err
+	}
+
+	if len(result.Data) == 0 || result.Data[0].Uid == nil {
+		blog.Errorf("cluster %d is not in the business %d, rid: %s", clusterID, m.bizID, m.kit.Rid)
+		return m.kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.BKClusterIDFiled)
+	}
+
+	m.cluster = result.Data[0]
+	return nil
+}
+
+func (m *manifestImporter) listNamespaces() error {
+	cond := m.clusterCond()
+	cond[common.BKFieldName] = mapstr.MapStr{common.BKDBIN: m.manifestNamespaces()}
+
+	m.namespaces = make(map[string]types.Namespace)
+	return listByPage(func(page metadata.BasePage) (int, error) {
+		query := &metadata.QueryCondition{Condition: cond, Page: page, DisableCounter: true}
+		result, err := m.clientSet.CoreService().Kube().ListNamespace(m.kit.Ctx, m.kit.Header, query)
+		if err != nil {
+			blog.Errorf("list namespaces failed, cond: %v, err: %v, rid: %s", cond, err, m.kit.Rid)
+			return 0, err
+		}
+
+		for _, namespace := range result.Data {
+			m.namespaces[namespace.Name] = namespace
+		}
+		return len(result.Data), nil
+	})
+}
+
+func (m *manifestImporter) listWorkloads() error {
+	cond := m.clusterCond()
+	cond[types.NamespaceField] = mapstr.MapStr{common.BKDBIN: m.manifestNamespaces()}
+
+	for _, kind := range manifestWorkloadKinds {
+		kind := kind
+		m.workloads[kind] = make(map[string]types.WorkloadInterface)
+		err := listByPage(func(page metadata.BasePage) (int, error) {
+			query := &metadata.QueryCondition{Condition: cond, Page: page, DisableCounter: true}
+			result, err := m.clientSet.CoreService().Kube().ListWorkload(m.kit.Ctx, m.kit.Header, query, kind)
+			if err != nil {
+				blog.Errorf("list %s failed, cond: %v, err: %v, rid: %s", kind, cond, err, m.kit.Rid)
+				return 0, err
+			}
+
+			for _, workload := range result.Info {
+				base := workload.GetWorkloadBase()
+				m.workloads[kind][objectKey(base.Namespace, base.Name)] = workload
+			}
+			return len(result.Info), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manifestImporter) listPods() error {
+	cond := m.clusterCond()
+	cond[types.NamespaceField] = mapstr.MapStr{common.BKDBIN: m.manifestNamespaces()}
+
+	m.pods = make(map[string]*types.Pod)
+	err := listByPage(func(page metadata.BasePage) (int, error) {
+		query := &metadata.QueryCondition{Condition: cond, Page: page, DisableCounter: true}
+		result, err := m.clientSet.CoreService().Kube().ListPod(m.kit.Ctx, m.kit.Header, query)
+		if err != nil {
+			blog.Errorf("list pods failed, cond: %v, err: %v, rid: %s", cond, err, m.kit.Rid)
+			return 0, err
+		}
+
+		for idx := range result.Info {
+			if result.Info[idx].Name == nil {
+				continue
+			}
+			m.pods[objectKey(result.Info[idx].Namespace, *result.Info[idx].Name)] = &result.Info[idx]
+		}
+		return len(result.Info), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// only the containers of the pods in the manifests are compared
+	podIDs := make([]int64, 0)
+	for key := range m.objects.pods {
+		if pod, exists := m.pods[key]; exists {
+			podIDs = append(podIDs, pod.ID)
+		}
+	}
+
+	m.containers = make(map[int64][]types.Container)
+	for start := 0; start < len(podIDs); start += manifestBatchSize {
+		end := start + manifestBatchSize
+		if end > len(podIDs) {
+			end = len(podIDs)
+		}
+
+		containerCond := mapstr.MapStr{types.BKPodIDField: mapstr.MapStr{common.BKDBIN: podIDs[start:end]}}
+		err := listByPage(func(page metadata.BasePage) (int, error) {
+			query := &metadata.QueryCondition{Condition: containerCond, Page: page, DisableCounter: true}
+			result, err := m.clientSet.CoreService().Kube().ListContainer(m.kit.Ctx, m.kit.Header, query)
+			if err != nil {
+				blog.Errorf("list containers failed, cond: %v, err: %v, rid: %s", containerCond, err, m.kit.Rid)
+				return 0, err
+			}
+
+			for _, container := range result.Info {
+				m.containers[container.PodID] = append(m.containers[container.PodID], container)
+			}
+			return len(result.Info), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manifestImporter) listNodes() error {
+	names := make(map[string]struct{})
+	for _, pod := range m.objects.pods {
+		if pod.Spec.NodeName != "" {
+			names[pod.Spec.NodeName] = struct{}{}
+		}
+	}
+
+	m.nodes = make(map[string]types.Node)
+	if len(names) == 0 {
+		return nil
+	}
+
+	cond := m.clusterCond()
+	cond[common.BKFieldName] = mapstr.MapStr{common.BKDBIN: sortedKeys(names)}
+	return listByPage(func(page metadata.BasePage) (int, error) {
+		query := &metadata.QueryCondition{Condition: cond, Page: page, DisableCounter: true}
+		result, err := m.clientSet.CoreService().Kube().SearchNode(m.kit.Ctx, m.kit.Header, query)
+		if err != nil {
+			blog.Errorf("search nodes failed, cond: %v, err: %v, rid: %s", cond, err, m.kit.Rid)
+			return 0, err
+		}
+
+		for _, node := range result.Data {
+			if node.Name != nil {
+				m.nodes[*node.Name] = node
+			}
+		}
+		return len(result.Data), nil
+	})
+}
+
+// hasNamespace checks if the namespace exists in cmdb or is to be created
+func (m *manifestImporter) hasNamespace(name string) bool {
+	if _, exists := m.namespaces[name]; exists {
+		return true
+	}
+	_, exists := m.createNamespaces[name]
+	return exists
+}
+
+// planNamespaces plans the namespaces in the manifests, the labels of a namespace are only compared when the
+// namespace is in the manifests, and the resource quotas are only compared when there are quotas of the namespace
+// in the manifests, so that they are not cleared by the manifests that do not contain them.
+func (m *manifestImporter) planNamespaces() {
+	names := make(map[string]struct{})
+	for name := range m.objects.namespaces {
+		names[name] = struct{}{}
+	}
+	for name := range m.objects.quotas {
+		names[name] = struct{}{}
+	}
+
+	for _, name := range sortedKeys(names) {
+		kubeNamespace, inManifest := m.objects.namespaces[name]
+		if !inManifest {
+			kubeNamespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		}
+
+		desired := converter.Namespace(kubeNamespace, m.objects.quotas[name])
+		if !inManifest {
+			desired.Labels = nil
+		}
+		if len(m.objects.quotas[name]) == 0 {
+			desired.ResourceQuotas = nil
+		}
+
+		current, exists := m.namespaces[name]
+		if !exists {
+			if !inManifest {
+				m.skip(types.KubeNamespace, "", name, "namespace not found")
+				continue
+			}
+
+			desired.ClusterSpec = types.ClusterSpec{BizID: m.bizID, ClusterID: m.cluster.ID,
+				ClusterUID: *m.cluster.Uid}
+			m.createNamespaces[name] = *desired
+			m.plan.Create = append(m.plan.Create, types.ManifestResource{Kind: types.KubeNamespace, Name: name})
+			continue
+		}
+
+		data := &types.Namespace{Labels: desired.Labels, ResourceQuotas: desired.ResourceQuotas}
+		currentData := new(types.Namespace)
+		if data.Labels != nil {
+			currentData.Labels = current.Labels
+		}
+		if data.ResourceQuotas != nil {
+			currentData.ResourceQuotas = current.ResourceQuotas
+		}
+		if !converter.NeedUpdate(data, currentData) {
+			continue
+		}
+
+		m.updateNamespaces = append(m.updateNamespaces, namespaceUpdate{current: current, data: data})
+		m.plan.Update = append(m.plan.Update, types.ManifestResource{Kind: types.KubeNamespace, Name: name,
+			ID: current.ID})
+	}
+}
+
+// planWorkloads plans the workloads in the manifests, the pods workload of a namespace is planned when there are
+// pods in the manifests that belong to it.
+func (m *manifestImporter) planWorkloads() {
+	for key, pod := range m.objects.pods {
+		ref := resolvePodRef(pod)
+		m.podRefs[key] = ref
+		if ref.Kind == types.KubePodWorkload {
+			m.objects.workloads[types.KubePodWorkload][objectKey(pod.Namespace, ref.Name)] = new(types.PodsWorkload)
+		}
+	}
+
+	for _, kind := range manifestWorkloadKinds {
+		desired := m.objects.workloads[kind]
+		keys := make(map[string]struct{}, len(desired))
+		for key := range desired {
+			keys[key] = struct{}{}
+		}
+
+		m.createWorkloads[kind] = make(map[string]types.WorkloadInterface)
+		for _, key := range sortedKeys(keys) {
+			workload := desired[key]
+			namespace, name := splitObjectKey(key)
+			resource := types.ManifestResource{Kind: string(kind), Namespace: namespace, Name: name}
+
+			if current, exists := m.workloads[kind][key]; exists {
+				// compare the fields except the base info, which can not be updated
+				base := current.GetWorkloadBase()
+				current.SetWorkloadBase(types.WorkloadBase{})
+				needUpdate := converter.NeedUpdate(workload, current)
+				current.SetWorkloadBase(base)
+				if !needUpdate {
+					continue
+				}
+
+				m.updateWorkloads[kind] = append(m.updateWorkloads[kind],
+					workloadUpdate{current: current, data: workload})
+				resource.ID = base.ID
+				m.plan.Update = append(m.plan.Update, resource)
+				continue
+			}
+
+			if !m.hasNamespace(namespace) {
+				resource.Reason = "namespace not found"
+				m.plan.Skip = append(m.plan.Skip, resource)
+				continue
+			}
+
+			workload.SetWorkloadBase(types.WorkloadBase{
+				NamespaceSpec: types.NamespaceSpec{
+					ClusterSpec: types.ClusterSpec{BizID: m.bizID, ClusterID: m.cluster.ID,
+						ClusterUID: *m.cluster.Uid},
+					Namespace: namespace,
+				},
+				Name: name,
+			})
+			m.createWorkloads[kind][key] = workload
+			m.plan.Create = append(m.plan.Create, resource)
+		}
+	}
+}
+
+// buildPodInfo builds the pod create info with its cmdb relations, returns the reason if the pod is skipped
+func (m *manifestImporter) buildPodInfo(key string, pod *corev1.Pod) (types.PodsInfo, string) {
+	if !m.hasNamespace(pod.Namespace) {
+		return types.PodsInfo{}, "namespace not found"
+	}
+
+	if pod.Spec.NodeName == "" {
+		return types.PodsInfo{}, "pod is not scheduled"
+	}
+
+	node, exists := m.nodes[pod.Spec.NodeName]
+	if !exists {
+		return types.PodsInfo{}, "node not found"
+	}
+
+	ref := m.podRefs[key]
+	refKey := objectKey(pod.Namespace, ref.Name)
+	if workload, exists := m.workloads[ref.Kind][refKey]; exists {
+		ref.ID = workload.GetWorkloadBase().ID
+	} else if _, exists := m.createWorkloads[ref.Kind][refKey]; !exists {
+		return types.PodsInfo{}, "workload not found"
+	}
+
+	kubePod, containers := converter.Pod(pod)
+	return types.PodsInfo{
+		Spec: types.SpecSimpleInfo{
+			ClusterID:   m.cluster.ID,
+			NamespaceID: m.namespaces[pod.Namespace].ID,
+			Ref:         ref,
+			NodeID:      node.ID,
+		},
+		HostID:     node.HostID,
+		Pod:        kubePod,
+		Containers: containers,
+	}, ""
+}
+
+// planPods plans the pods in the manifests, the pods whose node, workload or containers changed are updated in
+// place, and the cmdb pods in the namespaces of the manifest pods that are not in the manifests are deleted.
+func (m *manifestImporter) planPods() {
+	keys := make(map[string]struct{}, len(m.objects.pods))
+	namespaces := make(map[string]struct{})
+	for key, pod := range m.objects.pods {
+		keys[key] = struct{}{}
+		namespaces[pod.Namespace] = struct{}{}
+	}
+
+	for _, key := range sortedKeys(keys) {
+		pod := m.objects.pods[key]
+		resource := types.ManifestResource{Kind: types.KubePod, Namespace: pod.Namespace, Name: pod.Name}
+
+		info, reason := m.buildPodInfo(key, pod)
+		if reason != "" {
+			resource.Reason = reason
+			m.plan.Skip = append(m.plan.Skip, resource)
+			continue
+		}
+
+		current, exists := m.pods[key]
+		if exists && info.Spec.Ref.ID != 0 && converter.SamePod(current, m.containers[current.ID], &info) {
+			continue
+		}
+
+		if !exists {
+			m.createPods = append(m.createPods, podCreate{namespace: pod.Namespace, info: info})
+			m.plan.Create = append(m.plan.Create, resource)
+			continue
+		}
+
+		m.updatePods = append(m.updatePods, podUpdate{namespace: pod.Namespace, current: *current, info: info})
+		resource.ID = current.ID
+		m.plan.Update = append(m.plan.Update, resource)
+	}
+
+	staleKeys := make(map[string]struct{})
+	for key, pod := range m.pods {
+		if _, inManifest := m.objects.pods[key]; inManifest {
+			continue
+		}
+		if _, covered := namespaces[pod.Namespace]; covered {
+			staleKeys[key] = struct{}{}
+		}
+	}
+
+	for _, key := range sortedKeys(staleKeys) {
+		pod := m.pods[key]
+		m.deletePods = append(m.deletePods, *pod)
+		m.plan.Delete = append(m.plan.Delete, types.ManifestResource{Kind: types.KubePod, Namespace: pod.Namespace,
+			Name: *pod.Name, ID: pod.ID})
+	}
+}
+
+// planWorkloadDeletion plans the deletion of the cmdb workloads of a kind in the namespaces that the manifest
+// workloads of the kind are in, the workloads that still have pods after the import are skipped. the pods
+// workloads are never deleted, since they are not kubernetes resources.
+func (m *manifestImporter) planWorkloadDeletion() {
+	// changedPods is the cmdb pods that are deleted or whose workload may be changed by the import
+	changedPods := make(map[int64]struct{}, len(m.deletePods)+len(m.updatePods))
+	for _, pod := range m.deletePods {
+		changedPods[pod.ID] = struct{}{}
+	}
+	for _, pod := range m.updatePods {
+		changedPods[pod.current.ID] = struct{}{}
+	}
+
+	// referred is the workloads that have pods after the import by the kind and object key
+	referred := make(map[types.WorkloadType]map[string]struct{})
+	refer := func(kind types.WorkloadType, key string) {
+		if _, exists := referred[kind]; !exists {
+			referred[kind] = make(map[string]struct{})
+		}
+		referred[kind][key] = struct{}{}
+	}
+	for _, pod := range m.pods {
+		if _, changed := changedPods[pod.ID]; !changed {
+			refer(pod.Ref.Kind, objectKey(pod.Namespace, pod.Ref.Name))
+		}
+	}
+	for _, pod := range m.createPods {
+		refer(pod.info.Spec.Ref.Kind, objectKey(pod.namespace, pod.info.Spec.Ref.Name))
+	}
+	for _, pod := range m.updatePods {
+		refer(pod.info.Spec.Ref.Kind, objectKey(pod.namespace, pod.info.Spec.Ref.Name))
+	}
+
+	for _, kind := range manifestWorkloadKinds {
+		if kind == types.KubePodWorkload {
+			continue
+		}
+
+		namespaces := make(map[string]struct{})
+		for key := range m.objects.workloads[kind] {
+			namespace, _ := splitObjectKey(key)
+			namespaces[namespace] = struct{}{}
+		}
+
+		staleKeys := make(map[string]struct{})
+		for key := range m.workloads[kind] {
+			namespace, _ := splitObjectKey(key)
+			_, covered := namespaces[namespace]
+			_, inManifest := m.objects.workloads[kind][key]
+			if covered && !inManifest {
+				staleKeys[key] = struct{}{}
+			}
+		}
+
+		for _, key := range sortedKeys(staleKeys) {
+			workload := m.workloads[kind][key]
+			base := workload.GetWorkloadBase()
+			resource := types.ManifestResource{Kind: string(kind), Namespace: base.Namespace, Name: base.Name,
+				ID: base.ID}
+
+			if _, hasPods := referred[kind][key]; hasPods {
+				resource.Reason = "workload has pods"
+				m.plan.Skip = append(m.plan.Skip, resource)
+				continue
+			}
+
+			m.deleteWorkloads[kind] = append(m.deleteWorkloads[kind], workload)
+			m.plan.Delete = append(m.plan.Delete, resource)
+		}
+	}
+}
+
+// forEachBatch calls the handler with the [start, end) of each batch of the total items
+func forEachBatch(total int, handler func(start, end int) error) error {
+	for start := 0; start < total; start += manifestBatchSize {
+		end := start + manifestBatchSize
+		if end > total {
+			end = total
+		}
+		if err := handler(start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveAuditLog saves the generated audit logs of the manifest import
+func (m *manifestImporter) saveAuditLog(auditLogs []metadata.AuditLog, err ccErr.CCErrorCoder) error {
+	if err != nil {
+		blog.Errorf("generate audit log failed, err: %v, rid: %s", err, m.kit.Rid)
+		return err
+	}
+
+	audit := auditlog.NewKubeAudit(m.clientSet.CoreService())
+	if err := audit.SaveAuditLog(m.kit, auditLogs...); err != nil {
+		blog.Errorf("save audit log failed, err: %v, rid: %s", err, m.kit.Rid)
+		return m.kit.CCError.CCError(common.CCErrAuditSaveLogFailed)
+	}
+	return nil
+}
+
+func (m *manifestImporter) applyNamespaces() error {
+	audit := auditlog.NewKubeAudit(m.clientSet.CoreService())
+
+	names := make(map[string]struct{}, len(m.createNamespaces))
+	for name := range m.createNamespaces {
+		names[name] = struct{}{}
+	}
+	toCreate := make([]types.Namespace, 0, len(names))
+	for _, name := range sortedKeys(names) {
+		toCreate = append(toCreate, m.createNamespaces[name])
+	}
+
+	err := forEachBatch(len(toCreate), func(start, end int) error {
+		opt := &types.NsCreateOption{Data: toCreate[start:end]}
+		if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+			return rawErr.ToCCError(m.kit.CCError)
+		}
+
+		result, err := m.clientSet.CoreService().Kube().CreateNamespace(m.kit.Ctx, m.kit.Header, m.bizID, opt)
+		if err != nil {
+			blog.Errorf("create namespaces failed, data: %v, err: %v, rid: %s", opt.Data, err, m.kit.Rid)
+			return err
+		}
+
+		for idx := range opt.Data {
+			opt.Data[idx].ID = result.IDs[idx]
+			opt.Data[idx].SupplierAccount = m.kit.SupplierAccount
+			m.namespaces[opt.Data[idx].Name] = opt.Data[idx]
+		}
+
+		auditParam := auditlog.NewGenerateAuditCommonParameter(m.kit, metadata.AuditCreate)
+		return m.saveAuditLog(audit.GenerateNamespaceAuditLog(auditParam, opt.Data))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, update := range m.updateNamespaces {
+		opt := &types.NsUpdateOption{IDs: []int64{update.current.ID}, Data: update.data}
+		if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+			return rawErr.ToCCError(m.kit.CCError)
+		}
+
+		var err error
+		err = m.clientSet.CoreService().Kube().UpdateNamespace(m.kit.Ctx, m.kit.Header, m.bizID, opt)
+		if err != nil {
+			blog.Errorf("update namespace failed, opt: %v, err: %v, rid: %s", opt, err, m.kit.Rid)
+			return err
+		}
+
+		updateFields, err := mapstr.Struct2Map(update.data)
+		if err != nil {
+			blog.Errorf("update fields convert failed, err: %v, rid: %s", err, m.kit.Rid)
+			return err
+		}
+		auditParam := auditlog.NewGenerateAuditCommonParameter(m.kit, metadata.AuditUpdate).
+			WithUpdateFields(updateFields)
+		err = m.saveAuditLog(audit.GenerateNamespaceAuditLog(auditParam, []types.Namespace{update.current}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manifestImporter) applyWorkloads() error {
+	audit := auditlog.NewKubeAudit(m.clientSet.CoreService())
+
+	for _, kind := range manifestWorkloadKinds {
+		kind := kind
+		keys := make(map[string]struct{}, len(m.createWorkloads[kind]))
+		for key := range m.createWorkloads[kind] {
+			keys[key] = struct{}{}
+		}
+
+		toCreate := make([]types.WorkloadInterface, 0, len(keys))
+		for _, key := range sortedKeys(keys) {
+			workload := m.createWorkloads[kind][key]
+			base := workload.GetWorkloadBase()
+			base.NamespaceID = m.namespaces[base.Namespace].ID
+			workload.SetWorkloadBase(base)
+			toCreate = append(toCreate, workload)
+		}
+
+		err := forEachBatch(len(toCreate), func(start, end int) error {
+			opt := &types.WlCreateOption{Kind: kind, Data: toCreate[start:end]}
+			if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+				return rawErr.ToCCError(m.kit.CCError)
+			}
+
+			result, err := m.clientSet.CoreService().Kube().CreateWorkload(m.kit.Ctx, m.kit.Header, m.bizID, kind,
+				opt)
+			if err != nil {
+				blog.Errorf("create %s failed, data: %v, err: %v, rid: %s", kind, opt.Data, err, m.kit.Rid)
+				return err
+			}
+
+			for idx, workload := range opt.Data {
+				base := workload.GetWorkloadBase()
+				base.ID = result.IDs[idx]
+				base.SupplierAccount = m.kit.SupplierAccount
+				workload.SetWorkloadBase(base)
+				m.workloads[kind][objectKey(base.Namespace, base.Name)] = workload
+			}
+
+			auditParam := auditlog.NewGenerateAuditCommonParameter(m.kit, metadata.AuditCreate)
+			return m.saveAuditLog(audit.GenerateWorkloadAuditLog(auditParam, opt.Data, kind))
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, update := range m.updateWorkloads[kind] {
+			opt := &types.WlUpdateOption{Kind: kind, IDs: []int64{update.current.GetWorkloadBase().ID},
+				Data: update.data}
+			if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+				return rawErr.ToCCError(m.kit.CCError)
+			}
+
+			var err error
+			err = m.clientSet.CoreService().Kube().UpdateWorkload(m.kit.Ctx, m.kit.Header, m.bizID, kind, opt)
+			if err != nil {
+				blog.Errorf("update %s failed, opt: %v, err: %v, rid: %s", kind, opt, err, m.kit.Rid)
+				return err
+			}
+
+			updateFields, err := mapstr.Struct2Map(update.data)
+			if err != nil {
+				blog.Errorf("update fields convert failed, err: %v, rid: %s", err, m.kit.Rid)
+				return err
+			}
+			auditParam := auditlog.NewGenerateAuditCommonParameter(m.kit, metadata.AuditUpdate).
+				WithUpdateFields(updateFields)
+			err = m.saveAuditLog(audit.GenerateWorkloadAuditLog(auditParam,
+				[]types.WorkloadInterface{update.current}, kind))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *manifestImporter) applyPods() error {
+	audit := auditlog.NewKubeAudit(m.clientSet.CoreService())
+
+	err := forEachBatch(len(m.deletePods), func(start, end int) error {
+		pods := m.deletePods[start:end]
+		ids := make([]int64, len(pods))
+		for idx, pod := range pods {
+			ids[idx] = pod.ID
+		}
+
+		opt := &types.DeletePodsByIDsOption{PodIDs: ids}
+		if err := m.clientSet.CoreService().Kube().DeletePods(m.kit.Ctx, m.kit.Header, opt); err != nil {
+			blog.Errorf("delete pods failed, ids: %v, err: %v, rid: %s", ids, err, m.kit.Rid)
+			return err
+		}
+
+		auditParam := auditlog.NewGenerateAuditCommonParameter(m.kit, metadata.AuditDelete)
+		return m.saveAuditLog(audit.GeneratePodAuditLog(auditParam, pods))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = forEachBatch(len(m.updatePods), func(start, end int) error {
+		opt := &types.UpdatePodsOption{BizID: m.bizID, Data: make([]types.UpdatePodInfo, 0, end-start)}
+		auditLogs := make([]metadata.AuditLog, 0, end-start)
+		for _, pod := range m.updatePods[start:end] {
+			info := m.resolvePodInfo(pod.namespace, pod.info)
+			opt.Data = append(opt.Data, types.UpdatePodInfo{ID: pod.current.ID, Info: info})
+
+			updateFields, err := mapstr.Struct2Map(info.Pod)
+			if err != nil {
+				blog.Errorf("update fields convert failed, err: %v, rid: %s", err, m.kit.Rid)
+				return err
+			}
+			auditParam := auditlog.NewGenerateAuditCommonParameter(m.kit, metadata.AuditUpdate).
+				WithUpdateFields(updateFields)
+			podLogs, genErr := audit.GeneratePodAuditLog(auditParam, []types.Pod{pod.current})
+			if genErr != nil {
+				return m.saveAuditLog(nil, genErr)
+			}
+			auditLogs = append(auditLogs, podLogs...)
+		}
+
+		if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+			return rawErr.ToCCError(m.kit.CCError)
+		}
+
+		if err := m.clientSet.CoreService().Kube().UpdatePods(m.kit.Ctx, m.kit.Header, opt); err != nil {
+			blog.Errorf("update pods failed, data: %v, err: %v, rid: %s", opt.Data, err, m.kit.Rid)
+			return err
+		}
+		return m.saveAuditLog(auditLogs, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	toCreate := make([]types.PodsInfo, len(m.createPods))
+	for idx, pod := range m.createPods {
+		toCreate[idx] = m.resolvePodInfo(pod.namespace, pod.info)
+	}
+
+	return forEachBatch(len(toCreate), func(start, end int) error {
+		opt := &types.CreatePodsOption{Data: []types.PodsInfoArray{{BizID: m.bizID, Pods: toCreate[start:end]}}}
+		if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+			return rawErr.ToCCError(m.kit.CCError)
+		}
+
+		_, err := m.BatchCreatePod(m.kit, opt)
+		return err
+	})
+}
+
+// resolvePodInfo sets the ids of the namespace and workload of the pod, which may be created by the import
+func (m *manifestImporter) resolvePodInfo(namespace string, info types.PodsInfo) types.PodsInfo {
+	info.Spec.NamespaceID = m.namespaces[namespace].ID
+	info.Spec.Ref.ID = m.workloads[info.Spec.Ref.Kind][objectKey(namespace, info.Spec.Ref.Name)].GetWorkloadBase().ID
+	return info
+}
+
+// applyWorkloadDeletion deletes the stale workloads in the reverse order of the creation
+func (m *manifestImporter) applyWorkloadDeletion() error {
+	audit := auditlog.NewKubeAudit(m.clientSet.CoreService())
+
+	for idx := len(manifestWorkloadKinds) - 1; idx >= 0; idx-- {
+		kind := manifestWorkloadKinds[idx]
+		workloads := m.deleteWorkloads[kind]
+		err := forEachBatch(len(workloads), func(start, end int) error {
+			ids := make([]int64, 0, end-start)
+			for _, workload := range workloads[start:end] {
+				ids = append(ids, workload.GetWorkloadBase().ID)
+			}
+
+			opt := &types.WlDeleteOption{IDs: ids}
+			err := m.clientSet.CoreService().Kube().DeleteWorkload(m.kit.Ctx, m.kit.Header, m.bizID, kind, opt)
+			if err != nil {
+				blog.Errorf("delete %s failed, ids: %v, err: %v, rid: %s", kind, ids, err, m.kit.Rid)
+				return err
+			}
+
+			auditParam := auditlog.NewGenerateAuditCommonParameter(m.kit, metadata.AuditDelete)
+			return m.saveAuditLog(audit.GenerateWorkloadAuditLog(auditParam, workloads[start:end], kind))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
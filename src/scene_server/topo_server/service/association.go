@@ -1192,6 +1192,20 @@ func (s *Service) SearchHostTopoPath(ctx *rest.Contexts) {
 		bizIDs = append(bizIDs, bizID)
 	}
 
+	hostToBoundModule, moduleToBiz, err := s.getHostBoundModules(ctx.Kit, req.HostIDs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	for _, bizID := range moduleToBiz {
+		if _, ok := uniqueMap[bizID]; ok {
+			continue
+		}
+		uniqueMap[bizID] = struct{}{}
+		bizIDs = append(bizIDs, bizID)
+	}
+
 	bizToTopo := make(map[int64]*metadata.TopoInstanceNode)
 	for _, bizID := range bizIDs {
 		topoRoot, err := s.Engine.CoreAPI.CoreService().Mainline().SearchMainlineInstanceTopo(ctx.Kit.Ctx,
@@ -1220,9 +1234,20 @@ func (s *Service) SearchHostTopoPath(ctx *rest.Contexts) {
 			paths = append(paths, path)
 		}
 
+		var containerPaths [][]*metadata.TopoInstanceNodeSimplify
+		for _, moduleID := range hostToBoundModule[hostID] {
+			topoPath := bizToTopo[moduleToBiz[moduleID]].TraversalFindNode(common.BKInnerObjIDModule, moduleID)
+			path := make([]*metadata.TopoInstanceNodeSimplify, 0)
+			for _, item := range topoPath {
+				path = append(path, item.ToSimplify())
+			}
+			containerPaths = append(containerPaths, path)
+		}
+
 		result[idx] = &metadata.HostTopoPath{
-			HostID: hostID,
-			Path:   paths,
+			HostID:        hostID,
+			Path:          paths,
+			ContainerPath: containerPaths,
 		}
 	}
 
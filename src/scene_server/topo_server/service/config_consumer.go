@@ -0,0 +1,256 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/types"
+)
+
+// configBrief the brief info of a config map or secret that is used to match its consumers
+type configBrief struct {
+	id          int64
+	namespaceID int64
+	name        string
+}
+
+// FindConfigMapConsumers find the workloads and pods that consume the config maps
+func (s *Service) FindConfigMapConsumers(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := new(types.ConfigConsumersOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	configMaps, err := s.getConfigMapsInBiz(ctx.Kit, bizID, req.IDs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	briefs := make([]configBrief, len(configMaps))
+	for idx, configMap := range configMaps {
+		briefs[idx] = configBrief{id: configMap.ID, namespaceID: configMap.NamespaceID, name: configMap.Name}
+	}
+
+	result, err := s.findConfigConsumers(ctx.Kit, bizID, types.KubeConfigMap, briefs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// FindSecretConsumers find the workloads and pods that consume the secrets
+func (s *Service) FindSecretConsumers(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := new(types.ConfigConsumersOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	secrets, err := s.getSecretsInBiz(ctx.Kit, bizID, req.IDs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	briefs := make([]configBrief, len(secrets))
+	for idx, secret := range secrets {
+		briefs[idx] = configBrief{id: secret.ID, namespaceID: secret.NamespaceID, name: secret.Name}
+	}
+
+	result, err := s.findConfigConsumers(ctx.Kit, bizID, types.KubeSecret, briefs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// findConfigConsumers find the consumers of the config maps or secrets, a pod consumes a config map or secret in the
+// same namespace if its volumes or its containers' environments refer to it, and the workloads are the owners of
+// these pods.
+func (s *Service) findConfigConsumers(kit *rest.Kit, bizID int64, kind string, briefs []configBrief) (
+	[]types.ConfigConsumers, error) {
+
+	result := make([]types.ConfigConsumers, len(briefs))
+	if len(briefs) == 0 {
+		return result, nil
+	}
+
+	nsNames := make(map[int64][]string)
+	allNames := make([]string, 0)
+	resultIdx := make(map[int64]map[string]int)
+	for idx, brief := range briefs {
+		result[idx] = types.ConfigConsumers{
+			ID:        brief.id,
+			Workloads: make([]types.Reference, 0),
+			Pods:      make([]types.PodSimpleInfo, 0),
+		}
+		nsNames[brief.namespaceID] = append(nsNames[brief.namespaceID], brief.name)
+		allNames = append(allNames, brief.name)
+		if _, exists := resultIdx[brief.namespaceID]; !exists {
+			resultIdx[brief.namespaceID] = make(map[string]int)
+		}
+		resultIdx[brief.namespaceID][brief.name] = idx
+	}
+
+	// pods that refer to the configs by volumes
+	podCond := types.BuildConfigPodCond(kind, nsNames)
+	podCond[common.BKAppIDField] = bizID
+	volumePods, err := s.listConfigConsumerPods(kit, podCond)
+	if err != nil {
+		return nil, err
+	}
+
+	podNames := make(map[int64][]string)
+	podMap := make(map[int64]types.Pod)
+	for _, pod := range volumePods {
+		if pod.Volumes == nil {
+			continue
+		}
+		podMap[pod.ID] = pod
+		podNames[pod.ID] = types.GetVolumeConfigNames(kind, *pod.Volumes)
+	}
+
+	// containers do not have namespace, so the pods of the containers that refer to the configs by environments
+	// are checked by the namespace and business afterwards
+	query := &metadata.QueryCondition{
+		Condition:      types.BuildConfigContainerCond(kind, allNames),
+		Fields:         []string{types.BKPodIDField, types.EnvironmentField},
+		DisableCounter: true,
+	}
+	containers, err := s.Engine.CoreAPI.CoreService().Kube().ListContainer(kit.Ctx, kit.Header, query)
+	if err != nil {
+		blog.Errorf("list containers failed, cond: %v, err: %v, rid: %s", query.Condition, err, kit.Rid)
+		return nil, err
+	}
+
+	envPodIDs := make([]int64, 0)
+	for _, container := range containers.Info {
+		if container.Environment == nil {
+			continue
+		}
+		names := types.GetEnvConfigNames(kind, *container.Environment)
+		if len(names) == 0 {
+			continue
+		}
+		podNames[container.PodID] = append(podNames[container.PodID], names...)
+		if _, exists := podMap[container.PodID]; !exists {
+			envPodIDs = append(envPodIDs, container.PodID)
+		}
+	}
+
+	if len(envPodIDs) > 0 {
+		envPodCond := mapstr.MapStr{
+			common.BKAppIDField: bizID,
+			common.BKFieldID:    mapstr.MapStr{common.BKDBIN: envPodIDs},
+		}
+		envPods, err := s.listConfigConsumerPods(kit, envPodCond)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range envPods {
+			podMap[pod.ID] = pod
+		}
+	}
+
+	for podID, names := range podNames {
+		pod, exists := podMap[podID]
+		if !exists || pod.Name == nil {
+			continue
+		}
+
+		matched := make(map[int]struct{})
+		for _, name := range names {
+			idx, exists := resultIdx[pod.NamespaceID][name]
+			if !exists {
+				continue
+			}
+			if _, exists := matched[idx]; exists {
+				continue
+			}
+			matched[idx] = struct{}{}
+
+			result[idx].Pods = append(result[idx].Pods, types.PodSimpleInfo{ID: pod.ID, Name: *pod.Name})
+			if !hasWorkloadRef(result[idx].Workloads, pod.Ref) {
+				result[idx].Workloads = append(result[idx].Workloads, pod.Ref)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// listConfigConsumerPods list the pods with the fields that are needed to match the config consumers
+func (s *Service) listConfigConsumerPods(kit *rest.Kit, cond mapstr.MapStr) ([]types.Pod, error) {
+	query := &metadata.QueryCondition{
+		Condition: cond,
+		Fields: []string{common.BKFieldID, common.BKFieldName, types.BKNamespaceIDField, types.VolumesField,
+			types.RefField},
+		DisableCounter: true,
+	}
+	pods, err := s.Engine.CoreAPI.CoreService().Kube().ListPod(kit.Ctx, kit.Header, query)
+	if err != nil {
+		blog.Errorf("list pods failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, err
+	}
+
+	return pods.Info, nil
+}
+
+// hasWorkloadRef check if the workload is already in the workload references
+func hasWorkloadRef(refs []types.Reference, ref types.Reference) bool {
+	for _, r := range refs {
+		if r.Kind == ref.Kind && r.ID == ref.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/types"
+)
+
+// configMapResource returns the definition of kube config map for the common kube resource handlers
+func (s *Service) configMapResource() *kubeResource {
+	cli := s.Engine.CoreAPI.CoreService().Kube()
+	return &kubeResource{
+		name:            "config map",
+		table:           types.BKTableNameBaseConfigMap,
+		newCreateOption: func() kubeResOption { return new(types.ConfigMapCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.ConfigMapUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.ConfigMapDeleteOption) },
+		newQueryOption:  func() kubeResQueryOption { return new(types.ConfigMapQueryOption) },
+		updateInfo: func(opt kubeResOption) ([]int64, interface{}) {
+			req := opt.(*types.ConfigMapUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.ConfigMapDeleteOption).IDs
+		},
+		queryPage: func(opt kubeResQueryOption) (*metadata.BasePage, []string) {
+			req := opt.(*types.ConfigMapQueryOption)
+			return &req.Page, req.Fields
+		},
+		create: func(kit *rest.Kit, bizID int64, opt kubeResOption) (*metadata.RspIDs, error) {
+			return cli.CreateConfigMap(kit.Ctx, kit.Header, bizID, opt.(*types.ConfigMapCreateOption))
+		},
+		update: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.UpdateConfigMap(kit.Ctx, kit.Header, bizID, opt.(*types.ConfigMapUpdateOption))
+		},
+		delete: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.DeleteConfigMap(kit.Ctx, kit.Header, bizID, opt.(*types.ConfigMapDeleteOption))
+		},
+		list: func(kit *rest.Kit, query *metadata.QueryCondition) (interface{}, []kubeResBrief, error) {
+			resp, err := cli.ListConfigMap(kit.Ctx, kit.Header, query)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			briefs := make([]kubeResBrief, len(resp.Data))
+			for idx, data := range resp.Data {
+				briefs[idx] = kubeResBrief{ID: data.ID, BizID: data.BizID}
+			}
+			return resp.Data, briefs, nil
+		},
+	}
+}
+
+// CreateConfigMap create kube config map
+func (s *Service) CreateConfigMap(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.configMapResource())
+}
+
+// UpdateConfigMap update kube config map
+func (s *Service) UpdateConfigMap(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.configMapResource())
+}
+
+// DeleteConfigMap delete kube config map
+func (s *Service) DeleteConfigMap(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.configMapResource())
+}
+
+// ListConfigMap list kube config map
+func (s *Service) ListConfigMap(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.configMapResource())
+}
+
+// getConfigMapsInBiz get config maps by ids, returns error if any of them does not belong to the business
+func (s *Service) getConfigMapsInBiz(kit *rest.Kit, bizID int64, ids []int64) ([]types.ConfigMap, error) {
+	data, _, err := s.getKubeResInBiz(kit, s.configMapResource(), bizID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return data.([]types.ConfigMap), nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/types"
+)
+
+// endpointResource returns the definition of kube endpoint for the common kube resource handlers
+func (s *Service) endpointResource() *kubeResource {
+	cli := s.Engine.CoreAPI.CoreService().Kube()
+	return &kubeResource{
+		name:            "endpoint",
+		table:           types.BKTableNameBaseEndpoint,
+		newCreateOption: func() kubeResOption { return new(types.EndpointCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.EndpointUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.EndpointDeleteOption) },
+		newQueryOption:  func() kubeResQueryOption { return new(types.EndpointQueryOption) },
+		updateInfo: func(opt kubeResOption) ([]int64, interface{}) {
+			req := opt.(*types.EndpointUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.EndpointDeleteOption).IDs
+		},
+		queryPage: func(opt kubeResQueryOption) (*metadata.BasePage, []string) {
+			req := opt.(*types.EndpointQueryOption)
+			return &req.Page, req.Fields
+		},
+		create: func(kit *rest.Kit, bizID int64, opt kubeResOption) (*metadata.RspIDs, error) {
+			return cli.CreateEndpoint(kit.Ctx, kit.Header, bizID, opt.(*types.EndpointCreateOption))
+		},
+		update: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.UpdateEndpoint(kit.Ctx, kit.Header, bizID, opt.(*types.EndpointUpdateOption))
+		},
+		delete: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.DeleteEndpoint(kit.Ctx, kit.Header, bizID, opt.(*types.EndpointDeleteOption))
+		},
+		list: func(kit *rest.Kit, query *metadata.QueryCondition) (interface{}, []kubeResBrief, error) {
+			resp, err := cli.ListEndpoint(kit.Ctx, kit.Header, query)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			briefs := make([]kubeResBrief, len(resp.Data))
+			for idx, data := range resp.Data {
+				briefs[idx] = kubeResBrief{ID: data.ID, BizID: data.BizID}
+			}
+			return resp.Data, briefs, nil
+		},
+	}
+}
+
+// CreateEndpoint create kube endpoint
+func (s *Service) CreateEndpoint(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.endpointResource())
+}
+
+// UpdateEndpoint update kube endpoint
+func (s *Service) UpdateEndpoint(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.endpointResource())
+}
+
+// DeleteEndpoint delete kube endpoint
+func (s *Service) DeleteEndpoint(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.endpointResource())
+}
+
+// ListEndpoint list kube endpoint
+func (s *Service) ListEndpoint(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.endpointResource())
+}
@@ -0,0 +1,92 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/types"
+)
+
+// hpaResource returns the definition of kube horizontal pod autoscaler for the common kube resource handlers
+func (s *Service) hpaResource() *kubeResource {
+	cli := s.Engine.CoreAPI.CoreService().Kube()
+	return &kubeResource{
+		name:            "horizontal pod autoscaler",
+		table:           types.BKTableNameBaseHorizontalPodAutoscaler,
+		newCreateOption: func() kubeResOption { return new(types.HorizontalPodAutoscalerCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.HorizontalPodAutoscalerUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.HorizontalPodAutoscalerDeleteOption) },
+		newQueryOption:  func() kubeResQueryOption { return new(types.HorizontalPodAutoscalerQueryOption) },
+		updateInfo: func(opt kubeResOption) ([]int64, interface{}) {
+			req := opt.(*types.HorizontalPodAutoscalerUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.HorizontalPodAutoscalerDeleteOption).IDs
+		},
+		queryPage: func(opt kubeResQueryOption) (*metadata.BasePage, []string) {
+			req := opt.(*types.HorizontalPodAutoscalerQueryOption)
+			return &req.Page, req.Fields
+		},
+		create: func(kit *rest.Kit, bizID int64, opt kubeResOption) (*metadata.RspIDs, error) {
+			return cli.CreateHorizontalPodAutoscaler(kit.Ctx, kit.Header, bizID,
+				opt.(*types.HorizontalPodAutoscalerCreateOption))
+		},
+		update: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.UpdateHorizontalPodAutoscaler(kit.Ctx, kit.Header, bizID,
+				opt.(*types.HorizontalPodAutoscalerUpdateOption))
+		},
+		delete: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.DeleteHorizontalPodAutoscaler(kit.Ctx, kit.Header, bizID,
+				opt.(*types.HorizontalPodAutoscalerDeleteOption))
+		},
+		list: func(kit *rest.Kit, query *metadata.QueryCondition) (interface{}, []kubeResBrief, error) {
+			resp, err := cli.ListHorizontalPodAutoscaler(kit.Ctx, kit.Header, query)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			briefs := make([]kubeResBrief, len(resp.Data))
+			for idx, data := range resp.Data {
+				briefs[idx] = kubeResBrief{ID: data.ID, BizID: data.BizID}
+			}
+			return resp.Data, briefs, nil
+		},
+	}
+}
+
+// CreateHorizontalPodAutoscaler create kube horizontal pod autoscaler
+func (s *Service) CreateHorizontalPodAutoscaler(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.hpaResource())
+}
+
+// UpdateHorizontalPodAutoscaler update kube horizontal pod autoscaler
+func (s *Service) UpdateHorizontalPodAutoscaler(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.hpaResource())
+}
+
+// DeleteHorizontalPodAutoscaler delete kube horizontal pod autoscaler
+func (s *Service) DeleteHorizontalPodAutoscaler(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.hpaResource())
+}
+
+// ListHorizontalPodAutoscaler list kube horizontal pod autoscaler
+func (s *Service) ListHorizontalPodAutoscaler(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.hpaResource())
+}
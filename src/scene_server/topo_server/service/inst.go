@@ -522,6 +522,35 @@ func (s *Service) CountObjectInstances(ctx *rest.Contexts) {
 	ctx.RespEntity(result)
 }
 
+// AggregateObjectInstances groups object instances and calculates the metrics of each group, hosts can be grouped by
+// their topology relations too.
+func (s *Service) AggregateObjectInstances(ctx *rest.Contexts) {
+	objID := ctx.Request.PathParameter("bk_obj_id")
+
+	input := new(metadata.InstAggregateOption)
+	if err := ctx.DecodeInto(input); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if invalidKey, err := input.Validate(); err != nil {
+		blog.Errorf("validate aggregate instances option failed, err: %v, rid: %s", err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, invalidKey))
+		return
+	}
+
+	ctx.SetReadPreference(common.SecondaryPreferredMode)
+
+	result, err := s.Logics.InstOperation().AggregateObjectInstances(ctx.Kit, objID, input)
+	if err != nil {
+		blog.Errorf("aggregate object[%s] instances failed, err: %v, rid: %s", objID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
 // SearchInstAndAssociationDetail search the inst with association details
 func (s *Service) SearchInstAndAssociationDetail(ctx *rest.Contexts) {
 	objID := ctx.Request.PathParameter("bk_obj_id")
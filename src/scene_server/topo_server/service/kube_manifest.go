@@ -0,0 +1,88 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/kube/types"
+)
+
+// PlanKubeManifestImport returns the changes to the kube resources of a cluster if the manifests are imported
+func (s *Service) PlanKubeManifestImport(ctx *rest.Contexts) {
+	bizID, req, err := s.decodeManifestImportOption(ctx)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	plan, err := s.Logics.KubeOperation().PlanManifestImport(ctx.Kit, bizID, req)
+	if err != nil {
+		blog.Errorf("plan manifest import failed, cluster: %d, err: %v, rid: %s", req.ClusterID, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(plan)
+}
+
+// ImportKubeManifest import the kube resources of a cluster from the manifests
+func (s *Service) ImportKubeManifest(ctx *rest.Contexts) {
+	bizID, req, err := s.decodeManifestImportOption(ctx)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	var plan *types.ManifestImportPlan
+	txnErr := s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
+		plan, err = s.Logics.KubeOperation().ImportManifest(ctx.Kit, bizID, req)
+		if err != nil {
+			blog.Errorf("import manifest failed, cluster: %d, err: %v, rid: %s", req.ClusterID, err, ctx.Kit.Rid)
+			return err
+		}
+		return nil
+	})
+
+	if txnErr != nil {
+		ctx.RespAutoError(txnErr)
+		return
+	}
+
+	ctx.RespEntity(plan)
+}
+
+func (s *Service) decodeManifestImportOption(ctx *rest.Contexts) (int64, *types.ManifestImportOption, error) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		return 0, nil, ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField)
+	}
+
+	req := new(types.ManifestImportOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		return 0, nil, err
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		return 0, nil, rawErr.ToCCError(ctx.Kit.CCError)
+	}
+	return bizID, req, nil
+}
@@ -82,6 +82,32 @@ func (s *Service) findKubeTopoPathInfo(kit *rest.Kit, option *types.KubeTopoPath
 					Kind: types.KubeNamespace,
 				})
 			}
+		case types.BKTableNameBasePersistentVolume:
+			pvs, err := s.Engine.CoreAPI.CoreService().Kube().ListPersistentVolume(kit.Ctx, kit.Header, query)
+			if err != nil {
+				blog.Errorf("find persistent volume failed, cond: %v, err: %v, rid: %s", query, err, kit.Rid)
+				return result, err
+			}
+			for _, pv := range pvs.Data {
+				result.Info = append(result.Info, types.KubeObjectInfo{
+					ID:   pv.ID,
+					Name: pv.Name,
+					Kind: types.KubePersistentVolume,
+				})
+			}
+		case types.BKTableNameBasePersistentVolumeClaim:
+			pvcs, err := s.Engine.CoreAPI.CoreService().Kube().ListPersistentVolumeClaim(kit.Ctx, kit.Header, query)
+			if err != nil {
+				blog.Errorf("find persistent volume claim failed, cond: %v, err: %v, rid: %s", query, err, kit.Rid)
+				return result, err
+			}
+			for _, pvc := range pvcs.Data {
+				result.Info = append(result.Info, types.KubeObjectInfo{
+					ID:   pvc.ID,
+					Name: pvc.Name,
+					Kind: types.KubePersistentVolumeClaim,
+				})
+			}
 		default:
 
 			kind, err := types.GetKindByWorkLoadTableNameMap(tableName)
@@ -230,6 +256,15 @@ func (s *Service) CountKubeTopoHostsOrPods(ctx *rest.Contexts) {
 		return
 	}
 
+	// get the result from the kube topology cache first, get it from db if the cache can not serve the request.
+	cacheResult, cacheErr := s.Engine.CoreAPI.CacheService().Cache().Kube().CountKubeTopoHostsOrPods(ctx.Kit.Ctx,
+		ctx.Kit.Header, bizID, kind, option)
+	if cacheErr == nil {
+		ctx.RespEntity(cacheResult)
+		return
+	}
+	blog.Warnf("count kube topo %s from cache failed, get from db, err: %v, rid: %s", kind, cacheErr, ctx.Kit.Rid)
+
 	result, err := s.countKubeHostOrPodsByCond(ctx.Kit, option, bizID, kind)
 	if err != nil {
 		blog.Errorf("failed to get(%s) number, bizID: %d, option: %+v, err: %v, rid: %s",
@@ -457,6 +492,19 @@ func (s *Service) SearchKubeTopoPath(ctx *rest.Contexts) {
 		return
 	}
 
+	// get the result from the kube topology cache first, get it from db if the cache can not serve the request.
+	cacheResult, cacheErr := s.Engine.CoreAPI.CacheService().Cache().Kube().SearchKubeTopoPath(ctx.Kit.Ctx,
+		ctx.Kit.Header, bizID, option)
+	if cacheErr == nil {
+		if option.Page.EnableCount {
+			ctx.RespEntityWithCount(int64(cacheResult.Count), make([]mapstr.MapStr, 0))
+			return
+		}
+		ctx.RespEntity(cacheResult)
+		return
+	}
+	blog.Warnf("search kube topo path from cache failed, get from db, err: %v, rid: %s", cacheErr, ctx.Kit.Rid)
+
 	// get the next level resource object.
 	subObject, filter := types.GetKubeSubTopoObject(option.ReferenceObjID, option.ReferenceID, bizID)
 	if filter == nil {
@@ -470,6 +518,7 @@ func (s *Service) SearchKubeTopoPath(ctx *rest.Contexts) {
 		ctx.RespAutoError(err)
 		return
 	}
+	tableNames = append(tableNames, types.GetTopoStorageTables(subObject)...)
 
 	if option.Page.EnableCount {
 		var count int64
@@ -576,13 +625,18 @@ func (s *Service) hasNextLevelResource(kit *rest.Kit, kind string, bizID int64,
 
 	switch kind {
 	case types.KubeCluster:
-		tables = []string{types.BKTableNameBaseNamespace, types.BKTableNameBaseNode, types.BKTableNameBasePod}
+		tables = []string{types.BKTableNameBaseNamespace, types.BKTableNameBaseNode, types.BKTableNameBasePod,
+			types.BKTableNameBaseService, types.BKTableNameBaseIngress, types.BKTableNameBasePersistentVolumeClaim,
+			types.BKTableNameBasePersistentVolume, types.BKTableNameBaseStorageClass,
+			types.BKTableNameBaseHorizontalPodAutoscaler, types.BKTableNameBaseConfigMap, types.BKTableNameBaseSecret}
 		workLoads := types.GetWorkLoadTables()
 		tables = append(tables, workLoads...)
 		filter[types.BKClusterIDFiled] = map[string]interface{}{common.BKDBIN: ids}
 
 	case types.KubeNamespace:
-		tables = []string{types.BKTableNameBasePod}
+		tables = []string{types.BKTableNameBasePod, types.BKTableNameBaseService, types.BKTableNameBaseIngress,
+			types.BKTableNameBasePersistentVolumeClaim, types.BKTableNameBaseHorizontalPodAutoscaler,
+			types.BKTableNameBaseConfigMap, types.BKTableNameBaseSecret}
 		workLoads := types.GetWorkLoadTables()
 		tables = append(tables, workLoads...)
 		filter[types.BKNamespaceIDField] = map[string]interface{}{common.BKDBIN: ids}
@@ -0,0 +1,350 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/auditlog"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+)
+
+// kubeResOption is the create, update or delete request option of a kind of kube resource
+type kubeResOption interface {
+	Validate() errors.RawErrorInfo
+}
+
+// kubeResQueryOption is the query request option of a kind of kube resource
+type kubeResQueryOption interface {
+	kubeResOption
+	BuildCond(bizID int64) (mapstr.MapStr, error)
+}
+
+// kubeResBrief is the id and business id of a kube resource, it is used to check if the resource belongs to the
+// business that it is operated in.
+type kubeResBrief struct {
+	ID    int64
+	BizID int64
+}
+
+// kubeResource defines a kind of kube resource whose create, update, delete and list flows are the same, it wraps
+// the typed request options and core service apis of the kind, so that these flows are handled by the common kube
+// resource handlers below.
+type kubeResource struct {
+	// name is the name of the kube resource kind used in the logs
+	name  string
+	table string
+
+	newCreateOption func() kubeResOption
+	newUpdateOption func() kubeResOption
+	newDeleteOption func() kubeResOption
+	newQueryOption  func() kubeResQueryOption
+
+	// updateInfo returns the ids and the update data of the update option
+	updateInfo func(opt kubeResOption) ([]int64, interface{})
+	// deleteIDs returns the ids of the delete option
+	deleteIDs func(opt kubeResOption) []int64
+	// queryPage returns the page and the fields of the query option
+	queryPage func(opt kubeResQueryOption) (*metadata.BasePage, []string)
+
+	create func(kit *rest.Kit, bizID int64, opt kubeResOption) (*metadata.RspIDs, error)
+	update func(kit *rest.Kit, bizID int64, opt kubeResOption) error
+	delete func(kit *rest.Kit, bizID int64, opt kubeResOption) error
+	// list returns the typed slice of the kube resources and their briefs in the same order
+	list func(kit *rest.Kit, query *metadata.QueryCondition) (interface{}, []kubeResBrief, error)
+}
+
+// createKubeResource create kube resources of the kind
+func (s *Service) createKubeResource(ctx *rest.Contexts, res *kubeResource) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := res.newCreateOption()
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	var data *metadata.RspIDs
+	txnErr := s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
+		data, err = res.create(ctx.Kit, bizID, req)
+		if err != nil {
+			blog.Errorf("create %s failed, data: %v, err: %v, rid: %s", res.name, req, err, ctx.Kit.Rid)
+			return err
+		}
+
+		// get the created resources with the fields filled by core service for audit log.
+		query := &metadata.QueryCondition{
+			Condition:      mapstr.MapStr{common.BKFieldID: mapstr.MapStr{common.BKDBIN: data.IDs}},
+			DisableCounter: true,
+		}
+		created, _, err := res.list(ctx.Kit, query)
+		if err != nil {
+			blog.Errorf("list %s failed, ids: %v, err: %v, rid: %s", res.name, data.IDs, err, ctx.Kit.Rid)
+			return err
+		}
+
+		// audit log.
+		audit := auditlog.NewKubeAudit(s.Engine.CoreAPI.CoreService())
+		auditParam := auditlog.NewGenerateAuditCommonParameter(ctx.Kit, metadata.AuditCreate)
+		auditLogs, err := audit.GenerateKubeResourceAuditLog(auditParam, created)
+		if err != nil {
+			blog.Errorf("generate audit log failed, ids: %v, err: %v, rid: %s", data.IDs, err, ctx.Kit.Rid)
+			return err
+		}
+		if err := audit.SaveAuditLog(ctx.Kit, auditLogs...); err != nil {
+			blog.Errorf("save audit log failed, ids: %v, err: %v, rid: %s", data.IDs, err, ctx.Kit.Rid)
+			return err
+		}
+		return nil
+	})
+
+	if txnErr != nil {
+		ctx.RespAutoError(txnErr)
+		return
+	}
+
+	ctx.RespEntity(data)
+}
+
+// updateKubeResource update kube resources of the kind
+func (s *Service) updateKubeResource(ctx *rest.Contexts, res *kubeResource) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := res.newUpdateOption()
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	ids, updateData := res.updateInfo(req)
+	data, count, err := s.getKubeResInBiz(ctx.Kit, res, bizID, ids)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if count == 0 {
+		blog.Errorf("no %s founded, bizID: %d, ids: %v, rid: %s", res.name, bizID, ids, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommNotFound))
+		return
+	}
+
+	txnErr := s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
+		if err := res.update(ctx.Kit, bizID, req); err != nil {
+			blog.Errorf("update %s failed, data: %v, err: %v, rid: %s", res.name, req, err, ctx.Kit.Rid)
+			return err
+		}
+
+		audit := auditlog.NewKubeAudit(s.Engine.CoreAPI.CoreService())
+		auditParam := auditlog.NewGenerateAuditCommonParameter(ctx.Kit, metadata.AuditUpdate)
+		updateFields, goErr := mapstr.Struct2Map(updateData)
+		if goErr != nil {
+			blog.Errorf("update fields convert failed, err: %v, rid: %s", goErr, ctx.Kit.Rid)
+			return goErr
+		}
+		auditParam.WithUpdateFields(updateFields)
+		auditLogs, err := audit.GenerateKubeResourceAuditLog(auditParam, data)
+		if err != nil {
+			blog.Errorf("generate audit log failed, data: %v, err: %v, rid: %s", data, err, ctx.Kit.Rid)
+			return err
+		}
+		if err := audit.SaveAuditLog(ctx.Kit, auditLogs...); err != nil {
+			blog.Errorf("save audit log failed, data: %v, err: %v, rid: %s", data, err, ctx.Kit.Rid)
+			return err
+		}
+		return nil
+	})
+
+	if txnErr != nil {
+		ctx.RespAutoError(txnErr)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// deleteKubeResource delete kube resources of the kind
+func (s *Service) deleteKubeResource(ctx *rest.Contexts, res *kubeResource) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := res.newDeleteOption()
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	data, count, err := s.getKubeResInBiz(ctx.Kit, res, bizID, res.deleteIDs(req))
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if count == 0 {
+		ctx.RespEntity(nil)
+		return
+	}
+
+	txnErr := s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
+		if err := res.delete(ctx.Kit, bizID, req); err != nil {
+			blog.Errorf("delete %s failed, data: %v, err: %v, rid: %s", res.name, req, err, ctx.Kit.Rid)
+			return err
+		}
+
+		// audit log.
+		audit := auditlog.NewKubeAudit(s.Engine.CoreAPI.CoreService())
+		auditParam := auditlog.NewGenerateAuditCommonParameter(ctx.Kit, metadata.AuditDelete)
+		auditLogs, err := audit.GenerateKubeResourceAuditLog(auditParam, data)
+		if err != nil {
+			blog.Errorf("generate audit log failed, data: %v, err: %v, rid: %s", data, err, ctx.Kit.Rid)
+			return err
+		}
+		if err := audit.SaveAuditLog(ctx.Kit, auditLogs...); err != nil {
+			blog.Errorf("save audit log failed, data: %v, err: %v, rid: %s", data, err, ctx.Kit.Rid)
+			return err
+		}
+		return nil
+	})
+
+	if txnErr != nil {
+		ctx.RespAutoError(txnErr)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// listKubeResource list kube resources of the kind
+func (s *Service) listKubeResource(ctx *rest.Contexts, res *kubeResource) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := res.newQueryOption()
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	cond, err := req.BuildCond(bizID)
+	if err != nil {
+		blog.Errorf("build query %s condition failed, err: %v, rid: %s", res.name, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	page, fields := res.queryPage(req)
+	if page.EnableCount {
+		counts, err := s.Engine.CoreAPI.CoreService().Count().GetCountByFilter(ctx.Kit.Ctx, ctx.Kit.Header,
+			res.table, []map[string]interface{}{cond})
+		if err != nil {
+			blog.Errorf("count %s failed, cond: %v, err: %v, rid: %s", res.name, cond, err, ctx.Kit.Rid)
+			ctx.RespAutoError(err)
+			return
+		}
+		ctx.RespEntityWithCount(counts[0], make([]mapstr.MapStr, 0))
+		return
+	}
+
+	if page.Sort == "" {
+		page.Sort = common.BKFieldID
+	}
+
+	query := &metadata.QueryCondition{
+		Condition: cond,
+		Page:      *page,
+		Fields:    fields,
+	}
+	data, _, err := res.list(ctx.Kit, query)
+	if err != nil {
+		blog.Errorf("list %s failed, bizID: %d, data: %v, err: %v, rid: %s", res.name, bizID, req, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntityWithCount(0, data)
+}
+
+// getKubeResInBiz get kube resources of the kind by ids, returns the typed slice of them and their count, returns
+// error if any of them does not belong to the business.
+func (s *Service) getKubeResInBiz(kit *rest.Kit, res *kubeResource, bizID int64, ids []int64) (interface{}, int,
+	error) {
+
+	query := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{common.BKFieldID: mapstr.MapStr{common.BKDBIN: ids}},
+		DisableCounter: true,
+	}
+	data, briefs, err := res.list(kit, query)
+	if err != nil {
+		blog.Errorf("list %s failed, bizID: %d, ids: %v, err: %v, rid: %s", res.name, bizID, ids, err, kit.Rid)
+		return nil, 0, err
+	}
+
+	invalidIDs := make([]int64, 0)
+	for _, brief := range briefs {
+		if brief.BizID != bizID {
+			invalidIDs = append(invalidIDs, brief.ID)
+		}
+	}
+
+	if len(invalidIDs) != 0 {
+		blog.Errorf("%s does not belong to this business, ids: %v, bizID: %d, rid: %s", res.name, invalidIDs, bizID,
+			kit.Rid)
+		return nil, 0, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, invalidIDs)
+	}
+
+	return data, len(briefs), nil
+}
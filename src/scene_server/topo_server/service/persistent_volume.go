@@ -0,0 +1,89 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/types"
+)
+
+// pvResource returns the definition of kube persistent volume for the common kube resource handlers
+func (s *Service) pvResource() *kubeResource {
+	cli := s.Engine.CoreAPI.CoreService().Kube()
+	return &kubeResource{
+		name:            "persistent volume",
+		table:           types.BKTableNameBasePersistentVolume,
+		newCreateOption: func() kubeResOption { return new(types.PersistentVolumeCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.PersistentVolumeUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.PersistentVolumeDeleteOption) },
+		newQueryOption:  func() kubeResQueryOption { return new(types.PersistentVolumeQueryOption) },
+		updateInfo: func(opt kubeResOption) ([]int64, interface{}) {
+			req := opt.(*types.PersistentVolumeUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.PersistentVolumeDeleteOption).IDs
+		},
+		queryPage: func(opt kubeResQueryOption) (*metadata.BasePage, []string) {
+			req := opt.(*types.PersistentVolumeQueryOption)
+			return &req.Page, req.Fields
+		},
+		create: func(kit *rest.Kit, bizID int64, opt kubeResOption) (*metadata.RspIDs, error) {
+			return cli.CreatePersistentVolume(kit.Ctx, kit.Header, bizID, opt.(*types.PersistentVolumeCreateOption))
+		},
+		update: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.UpdatePersistentVolume(kit.Ctx, kit.Header, bizID, opt.(*types.PersistentVolumeUpdateOption))
+		},
+		delete: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.DeletePersistentVolume(kit.Ctx, kit.Header, bizID, opt.(*types.PersistentVolumeDeleteOption))
+		},
+		list: func(kit *rest.Kit, query *metadata.QueryCondition) (interface{}, []kubeResBrief, error) {
+			resp, err := cli.ListPersistentVolume(kit.Ctx, kit.Header, query)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			briefs := make([]kubeResBrief, len(resp.Data))
+			for idx, data := range resp.Data {
+				briefs[idx] = kubeResBrief{ID: data.ID, BizID: data.BizID}
+			}
+			return resp.Data, briefs, nil
+		},
+	}
+}
+
+// CreatePersistentVolume create kube persistent volume
+func (s *Service) CreatePersistentVolume(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.pvResource())
+}
+
+// UpdatePersistentVolume update kube persistent volume
+func (s *Service) UpdatePersistentVolume(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.pvResource())
+}
+
+// DeletePersistentVolume delete kube persistent volume
+func (s *Service) DeletePersistentVolume(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.pvResource())
+}
+
+// ListPersistentVolume list kube persistent volume
+func (s *Service) ListPersistentVolume(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.pvResource())
+}
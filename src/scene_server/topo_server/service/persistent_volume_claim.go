@@ -0,0 +1,194 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/types"
+)
+
+// pvcResource returns the definition of kube persistent volume claim for the common kube resource handlers
+func (s *Service) pvcResource() *kubeResource {
+	cli := s.Engine.CoreAPI.CoreService().Kube()
+	return &kubeResource{
+		name:            "persistent volume claim",
+		table:           types.BKTableNameBasePersistentVolumeClaim,
+		newCreateOption: func() kubeResOption { return new(types.PersistentVolumeClaimCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.PersistentVolumeClaimUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.PersistentVolumeClaimDeleteOption) },
+		newQueryOption:  func() kubeResQueryOption { return new(types.PersistentVolumeClaimQueryOption) },
+		updateInfo: func(opt kubeResOption) ([]int64, interface{}) {
+			req := opt.(*types.PersistentVolumeClaimUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.PersistentVolumeClaimDeleteOption).IDs
+		},
+		queryPage: func(opt kubeResQueryOption) (*metadata.BasePage, []string) {
+			req := opt.(*types.PersistentVolumeClaimQueryOption)
+			return &req.Page, req.Fields
+		},
+		create: func(kit *rest.Kit, bizID int64, opt kubeResOption) (*metadata.RspIDs, error) {
+			return cli.CreatePersistentVolumeClaim(kit.Ctx, kit.Header, bizID, opt.(*types.PersistentVolumeClaimCreateOption))
+		},
+		update: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.UpdatePersistentVolumeClaim(kit.Ctx, kit.Header, bizID, opt.(*types.PersistentVolumeClaimUpdateOption))
+		},
+		delete: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.DeletePersistentVolumeClaim(kit.Ctx, kit.Header, bizID, opt.(*types.PersistentVolumeClaimDeleteOption))
+		},
+		list: func(kit *rest.Kit, query *metadata.QueryCondition) (interface{}, []kubeResBrief, error) {
+			resp, err := cli.ListPersistentVolumeClaim(kit.Ctx, kit.Header, query)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			briefs := make([]kubeResBrief, len(resp.Data))
+			for idx, data := range resp.Data {
+				briefs[idx] = kubeResBrief{ID: data.ID, BizID: data.BizID}
+			}
+			return resp.Data, briefs, nil
+		},
+	}
+}
+
+// CreatePersistentVolumeClaim create kube persistent volume claim
+func (s *Service) CreatePersistentVolumeClaim(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.pvcResource())
+}
+
+// UpdatePersistentVolumeClaim update kube persistent volume claim
+func (s *Service) UpdatePersistentVolumeClaim(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.pvcResource())
+}
+
+// DeletePersistentVolumeClaim delete kube persistent volume claim
+func (s *Service) DeletePersistentVolumeClaim(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.pvcResource())
+}
+
+// ListPersistentVolumeClaim list kube persistent volume claim
+func (s *Service) ListPersistentVolumeClaim(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.pvcResource())
+}
+
+// getPersistentVolumeClaimsInBiz get persistent volume claims by ids, returns error if any of them does not belong
+// to the business
+func (s *Service) getPersistentVolumeClaimsInBiz(kit *rest.Kit, bizID int64, ids []int64) (
+	[]types.PersistentVolumeClaim, error) {
+
+	data, _, err := s.getKubeResInBiz(kit, s.pvcResource(), bizID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return data.([]types.PersistentVolumeClaim), nil
+}
+
+// FindPVCMountedPods find the pods that mount the persistent volume claims
+func (s *Service) FindPVCMountedPods(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := new(types.PVCMountedPodsOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	pvcs, err := s.getPersistentVolumeClaimsInBiz(ctx.Kit, bizID, req.IDs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result := make([]types.PVCMountedPods, len(pvcs))
+	if len(pvcs) == 0 {
+		ctx.RespEntity(result)
+		return
+	}
+
+	// pods can only mount the persistent volume claims in the same namespace, so they are matched by the
+	// namespace id and the claim name of the pod volumes.
+	nsClaimNames := make(map[int64][]string)
+	resultIdx := make(map[int64]map[string]int)
+	for idx, pvc := range pvcs {
+		result[idx] = types.PVCMountedPods{ID: pvc.ID, Pods: make([]types.PodSimpleInfo, 0)}
+		nsClaimNames[pvc.NamespaceID] = append(nsClaimNames[pvc.NamespaceID], pvc.Name)
+		if _, exists := resultIdx[pvc.NamespaceID]; !exists {
+			resultIdx[pvc.NamespaceID] = make(map[string]int)
+		}
+		resultIdx[pvc.NamespaceID][pvc.Name] = idx
+	}
+
+	orCond := make([]mapstr.MapStr, 0, len(nsClaimNames))
+	for nsID, names := range nsClaimNames {
+		orCond = append(orCond, mapstr.MapStr{
+			types.BKNamespaceIDField: nsID,
+			types.PVCClaimNameField:  mapstr.MapStr{common.BKDBIN: names},
+		})
+	}
+
+	query := &metadata.QueryCondition{
+		Condition: mapstr.MapStr{
+			common.BKAppIDField: bizID,
+			common.BKDBOR:       orCond,
+		},
+		Fields:         []string{common.BKFieldID, common.BKFieldName, types.BKNamespaceIDField, types.VolumesField},
+		DisableCounter: true,
+	}
+	pods, err := s.Engine.CoreAPI.CoreService().Kube().ListPod(ctx.Kit.Ctx, ctx.Kit.Header, query)
+	if err != nil {
+		blog.Errorf("list pods failed, cond: %v, err: %v, rid: %s", query.Condition, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	for _, pod := range pods.Info {
+		if pod.Volumes == nil || pod.Name == nil {
+			continue
+		}
+
+		for _, volume := range *pod.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+
+			idx, exists := resultIdx[pod.NamespaceID][volume.PersistentVolumeClaim.ClaimName]
+			if !exists {
+				continue
+			}
+			result[idx].Pods = append(result[idx].Pods, types.PodSimpleInfo{ID: pod.ID, Name: *pod.Name})
+		}
+	}
+
+	ctx.RespEntity(result)
+}
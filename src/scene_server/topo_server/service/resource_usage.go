@@ -0,0 +1,339 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/types"
+)
+
+// resourceUsagePageSize is the page size of the pods, containers and nodes listed to aggregate the resource usage
+const resourceUsagePageSize = 500
+
+// AggregateResourceUsage aggregate the cpu and memory requests and limits of the containers by container, pod,
+// workload, namespace, node, cluster or biz, and compare them with the allocatable resources of the nodes
+func (s *Service) AggregateResourceUsage(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := new(types.ResourceUsageOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	if req.Kind == types.KubeBusiness {
+		req.IDs = []int64{bizID}
+	}
+
+	usages := make(map[int64]*types.ResourceUsage, len(req.IDs))
+	for _, id := range req.IDs {
+		usages[id] = &types.ResourceUsage{ID: id}
+	}
+
+	var nodeIDs map[int64][]int64
+	if req.Kind == types.KubeContainer {
+		err = s.aggregateContainerUsage(ctx.Kit, bizID, req.IDs, usages)
+	} else {
+		nodeIDs, err = s.aggregatePodUsage(ctx.Kit, bizID, req, usages)
+	}
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	switch req.Kind {
+	case types.KubeNode, types.KubeNamespace, types.KubeCluster, types.KubeBusiness:
+		if err := s.aggregateAllocatable(ctx.Kit, bizID, req, nodeIDs, usages); err != nil {
+			ctx.RespAutoError(err)
+			return
+		}
+	}
+
+	result := make([]types.ResourceUsage, len(req.IDs))
+	for idx, id := range req.IDs {
+		result[idx] = *usages[id]
+	}
+	ctx.RespEntity(result)
+}
+
+// aggregateContainerUsage aggregate the requests and limits of the containers of the pods in the business
+func (s *Service) aggregateContainerUsage(kit *rest.Kit, bizID int64, ids []int64,
+	usages map[int64]*types.ResourceUsage) error {
+
+	query := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{common.BKFieldID: mapstr.MapStr{common.BKDBIN: ids}},
+		Fields:         []string{common.BKFieldID, types.BKPodIDField, types.LimitsField, types.RequestsField},
+		Page:           metadata.BasePage{Limit: types.ResourceUsageLimit},
+		DisableCounter: true,
+	}
+	containers, err := s.Engine.CoreAPI.CoreService().Kube().ListContainer(kit.Ctx, kit.Header, query)
+	if err != nil {
+		blog.Errorf("list containers failed, cond: %v, err: %v, rid: %s", query.Condition, err, kit.Rid)
+		return err
+	}
+
+	// containers do not have the business id, so they are checked by their pods
+	podIDs := make([]int64, 0, len(containers.Info))
+	for _, container := range containers.Info {
+		podIDs = append(podIDs, container.PodID)
+	}
+
+	podQuery := &metadata.QueryCondition{
+		Condition: mapstr.MapStr{
+			common.BKAppIDField: bizID,
+			common.BKFieldID:    mapstr.MapStr{common.BKDBIN: podIDs},
+		},
+		Fields:         []string{common.BKFieldID},
+		DisableCounter: true,
+	}
+	pods, err := s.Engine.CoreAPI.CoreService().Kube().ListPod(kit.Ctx, kit.Header, podQuery)
+	if err != nil {
+		blog.Errorf("list pods failed, cond: %v, err: %v, rid: %s", podQuery.Condition, err, kit.Rid)
+		return err
+	}
+
+	bizPods := make(map[int64]struct{}, len(pods.Info))
+	for _, pod := range pods.Info {
+		bizPods[pod.ID] = struct{}{}
+	}
+
+	for idx := range containers.Info {
+		if _, exists := bizPods[containers.Info[idx].PodID]; !exists {
+			continue
+		}
+		addContainerUsage(usages[containers.Info[idx].ID], &containers.Info[idx])
+	}
+	return nil
+}
+
+// addContainerUsage add the requests and limits of the container to the resource usage
+func addContainerUsage(usage *types.ResourceUsage, container *types.Container) {
+	usage.ContainerCount++
+	usage.Requests.Add(types.ContainerRequests(container))
+	if container.Limits != nil {
+		usage.Limits.Add(*container.Limits)
+	}
+}
+
+// podUsageGroup returns the pod field to group the pods by the resource usage kind, and the group id of the pod
+func podUsageGroup(kind string) (string, func(pod *types.Pod) int64) {
+	switch kind {
+	case types.KubePod:
+		return common.BKFieldID, func(pod *types.Pod) int64 { return pod.ID }
+	case types.KubeNamespace:
+		return types.BKNamespaceIDField, func(pod *types.Pod) int64 { return pod.NamespaceID }
+	case types.KubeNode:
+		return types.BKNodeIDField, func(pod *types.Pod) int64 { return pod.NodeID }
+	case types.KubeCluster:
+		return types.BKClusterIDFiled, func(pod *types.Pod) int64 { return pod.ClusterID }
+	case types.KubeBusiness:
+		return common.BKAppIDField, func(pod *types.Pod) int64 { return pod.BizID }
+	default:
+		return types.RefIDField, func(pod *types.Pod) int64 { return pod.Ref.ID }
+	}
+}
+
+// aggregatePodUsage aggregate the requests and limits of the containers of the pods grouped by the resource
+// usage kind, returns the ids of the nodes that the pods of each group are scheduled to.
+func (s *Service) aggregatePodUsage(kit *rest.Kit, bizID int64, opt *types.ResourceUsageOption,
+	usages map[int64]*types.ResourceUsage) (map[int64][]int64, error) {
+
+	field, groupID := podUsageGroup(opt.Kind)
+	cond := mapstr.MapStr{
+		common.BKAppIDField: bizID,
+		field:               mapstr.MapStr{common.BKDBIN: opt.IDs},
+	}
+	if field == types.RefIDField {
+		cond[types.RefKindField] = opt.Kind
+	}
+
+	nodeIDs := make(map[int64][]int64)
+	nodeExists := make(map[int64]map[int64]struct{})
+	for start := 0; ; start += resourceUsagePageSize {
+		query := &metadata.QueryCondition{
+			Condition: cond,
+			Fields: []string{common.BKFieldID, common.BKAppIDField, types.BKClusterIDFiled,
+				types.BKNamespaceIDField, types.BKNodeIDField, types.RefField},
+			Page:           metadata.BasePage{Start: start, Limit: resourceUsagePageSize, Sort: common.BKFieldID},
+			DisableCounter: true,
+		}
+		pods, err := s.Engine.CoreAPI.CoreService().Kube().ListPod(kit.Ctx, kit.Header, query)
+		if err != nil {
+			blog.Errorf("list pods failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+			return nil, err
+		}
+
+		podGroups := make(map[int64]int64, len(pods.Info))
+		podIDs := make([]int64, 0, len(pods.Info))
+		for idx := range pods.Info {
+			pod := &pods.Info[idx]
+			group := groupID(pod)
+			podGroups[pod.ID] = group
+			podIDs = append(podIDs, pod.ID)
+
+			if _, exists := nodeExists[group]; !exists {
+				nodeExists[group] = make(map[int64]struct{})
+			}
+			if _, exists := nodeExists[group][pod.NodeID]; !exists && pod.NodeID != 0 {
+				nodeExists[group][pod.NodeID] = struct{}{}
+				nodeIDs[group] = append(nodeIDs[group], pod.NodeID)
+			}
+		}
+
+		if err := s.aggregatePodContainers(kit, podIDs, podGroups, usages); err != nil {
+			return nil, err
+		}
+
+		if len(pods.Info) < resourceUsagePageSize {
+			return nodeIDs, nil
+		}
+	}
+}
+
+// aggregatePodContainers aggregate the requests and limits of the containers of the pods to their groups
+func (s *Service) aggregatePodContainers(kit *rest.Kit, podIDs []int64, podGroups map[int64]int64,
+	usages map[int64]*types.ResourceUsage) error {
+
+	if len(podIDs) == 0 {
+		return nil
+	}
+
+	cond := mapstr.MapStr{types.BKPodIDField: mapstr.MapStr{common.BKDBIN: podIDs}}
+	for start := 0; ; start += resourceUsagePageSize {
+		query := &metadata.QueryCondition{
+			Condition:      cond,
+			Fields:         []string{common.BKFieldID, types.BKPodIDField, types.LimitsField, types.RequestsField},
+			Page:           metadata.BasePage{Start: start, Limit: resourceUsagePageSize, Sort: common.BKFieldID},
+			DisableCounter: true,
+		}
+		containers, err := s.Engine.CoreAPI.CoreService().Kube().ListContainer(kit.Ctx, kit.Header, query)
+		if err != nil {
+			blog.Errorf("list containers failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+			return err
+		}
+
+		for idx := range containers.Info {
+			usage, exists := usages[podGroups[containers.Info[idx].PodID]]
+			if !exists {
+				continue
+			}
+			addContainerUsage(usage, &containers.Info[idx])
+		}
+
+		if len(containers.Info) < resourceUsagePageSize {
+			return nil
+		}
+	}
+}
+
+// aggregateAllocatable aggregate the allocatable resources of the nodes that the resources use, the nodes of a
+// namespace are the nodes that its pods are scheduled to, and the nodes of a cluster or biz are all their nodes.
+func (s *Service) aggregateAllocatable(kit *rest.Kit, bizID int64, opt *types.ResourceUsageOption,
+	podNodeIDs map[int64][]int64, usages map[int64]*types.ResourceUsage) error {
+
+	// nodeGroups is the groups that each node belongs to
+	nodeGroups := make(map[int64][]int64)
+	cond := mapstr.MapStr{common.BKAppIDField: bizID}
+	switch opt.Kind {
+	case types.KubeNode:
+		cond[common.BKFieldID] = mapstr.MapStr{common.BKDBIN: opt.IDs}
+	case types.KubeCluster:
+		cond[types.BKClusterIDFiled] = mapstr.MapStr{common.BKDBIN: opt.IDs}
+	case types.KubeNamespace:
+		ids := make([]int64, 0)
+		for group, nodeIDs := range podNodeIDs {
+			for _, nodeID := range nodeIDs {
+				if _, exists := nodeGroups[nodeID]; !exists {
+					ids = append(ids, nodeID)
+				}
+				nodeGroups[nodeID] = append(nodeGroups[nodeID], group)
+			}
+		}
+		cond[common.BKFieldID] = mapstr.MapStr{common.BKDBIN: ids}
+	}
+
+	for _, usage := range usages {
+		usage.Allocatable = new(types.ResourceAmount)
+	}
+
+	for start := 0; ; start += resourceUsagePageSize {
+		query := &metadata.QueryCondition{
+			Condition: cond,
+			Fields: []string{common.BKFieldID, common.BKAppIDField, types.BKClusterIDFiled,
+				types.AllocatableField},
+			Page:           metadata.BasePage{Start: start, Limit: resourceUsagePageSize, Sort: common.BKFieldID},
+			DisableCounter: true,
+		}
+		nodes, err := s.Engine.CoreAPI.CoreService().Kube().SearchNode(kit.Ctx, kit.Header, query)
+		if err != nil {
+			blog.Errorf("search nodes failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+			return err
+		}
+
+		for _, node := range nodes.Data {
+			if node.Allocatable == nil {
+				continue
+			}
+
+			var groups []int64
+			switch opt.Kind {
+			case types.KubeNode:
+				groups = []int64{node.ID}
+			case types.KubeCluster:
+				groups = []int64{node.ClusterID}
+			case types.KubeBusiness:
+				groups = []int64{node.BizID}
+			case types.KubeNamespace:
+				groups = nodeGroups[node.ID]
+			}
+
+			for _, group := range groups {
+				if usage, exists := usages[group]; exists {
+					usage.Allocatable.Add(*node.Allocatable)
+				}
+			}
+		}
+
+		if len(nodes.Data) < resourceUsagePageSize {
+			break
+		}
+	}
+
+	// the resources whose nodes have no allocatable resources recorded are not regarded as over committed
+	for _, usage := range usages {
+		if *usage.Allocatable != (types.ResourceAmount{}) {
+			usage.OverCommitted = usage.Limits.Exceeds(*usage.Allocatable)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/types"
+)
+
+// secretResource returns the definition of kube secret for the common kube resource handlers
+func (s *Service) secretResource() *kubeResource {
+	cli := s.Engine.CoreAPI.CoreService().Kube()
+	return &kubeResource{
+		name:            "secret",
+		table:           types.BKTableNameBaseSecret,
+		newCreateOption: func() kubeResOption { return new(types.SecretCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.SecretUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.SecretDeleteOption) },
+		newQueryOption:  func() kubeResQueryOption { return new(types.SecretQueryOption) },
+		updateInfo: func(opt kubeResOption) ([]int64, interface{}) {
+			req := opt.(*types.SecretUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.SecretDeleteOption).IDs
+		},
+		queryPage: func(opt kubeResQueryOption) (*metadata.BasePage, []string) {
+			req := opt.(*types.SecretQueryOption)
+			return &req.Page, req.Fields
+		},
+		create: func(kit *rest.Kit, bizID int64, opt kubeResOption) (*metadata.RspIDs, error) {
+			return cli.CreateSecret(kit.Ctx, kit.Header, bizID, opt.(*types.SecretCreateOption))
+		},
+		update: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.UpdateSecret(kit.Ctx, kit.Header, bizID, opt.(*types.SecretUpdateOption))
+		},
+		delete: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.DeleteSecret(kit.Ctx, kit.Header, bizID, opt.(*types.SecretDeleteOption))
+		},
+		list: func(kit *rest.Kit, query *metadata.QueryCondition) (interface{}, []kubeResBrief, error) {
+			resp, err := cli.ListSecret(kit.Ctx, kit.Header, query)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			briefs := make([]kubeResBrief, len(resp.Data))
+			for idx, data := range resp.Data {
+				briefs[idx] = kubeResBrief{ID: data.ID, BizID: data.BizID}
+			}
+			return resp.Data, briefs, nil
+		},
+	}
+}
+
+// CreateSecret create kube secret
+func (s *Service) CreateSecret(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.secretResource())
+}
+
+// UpdateSecret update kube secret
+func (s *Service) UpdateSecret(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.secretResource())
+}
+
+// DeleteSecret delete kube secret
+func (s *Service) DeleteSecret(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.secretResource())
+}
+
+// ListSecret list kube secret
+func (s *Service) ListSecret(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.secretResource())
+}
+
+// getSecretsInBiz get secrets by ids, returns error if any of them does not belong to the business
+func (s *Service) getSecretsInBiz(kit *rest.Kit, bizID int64, ids []int64) ([]types.Secret, error) {
+	data, _, err := s.getKubeResInBiz(kit, s.secretResource(), bizID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return data.([]types.Secret), nil
+}
@@ -0,0 +1,320 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/auditlog"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+)
+
+// CreateServiceBinding bind kube workloads to the service template of a module, so that their pods are shown as
+// the container backed service instances of the module
+func (s *Service) CreateServiceBinding(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := new(types.ServiceBindingCreateOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	var data *metadata.RspIDs
+	txnErr := s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
+		data, err = s.Engine.CoreAPI.CoreService().Kube().CreateServiceBinding(ctx.Kit.Ctx, ctx.Kit.Header, bizID, req)
+		if err != nil {
+			blog.Errorf("create workload service binding failed, data: %v, err: %v, rid: %s", req, err, ctx.Kit.Rid)
+			return err
+		}
+
+		// get the created bindings with the fields filled by core service for audit log.
+		query := &metadata.QueryCondition{
+			Condition:      mapstr.MapStr{common.BKFieldID: mapstr.MapStr{common.BKDBIN: data.IDs}},
+			DisableCounter: true,
+		}
+		resp, err := s.Engine.CoreAPI.CoreService().Kube().ListServiceBinding(ctx.Kit.Ctx, ctx.Kit.Header, query)
+		if err != nil {
+			blog.Errorf("list workload service binding failed, ids: %v, err: %v, rid: %s", data.IDs, err, ctx.Kit.Rid)
+			return err
+		}
+
+		// audit log.
+		audit := auditlog.NewKubeAudit(s.Engine.CoreAPI.CoreService())
+		auditParam := auditlog.NewGenerateAuditCommonParameter(ctx.Kit, metadata.AuditCreate)
+		auditLogs, err := audit.GenerateServiceBindingAuditLog(auditParam, resp.Data)
+		if err != nil {
+			blog.Errorf("generate audit log failed, ids: %v, err: %v, rid: %s", data.IDs, err, ctx.Kit.Rid)
+			return err
+		}
+		if err := audit.SaveAuditLog(ctx.Kit, auditLogs...); err != nil {
+			blog.Errorf("save audit log failed, ids: %v, err: %v, rid: %s", data.IDs, err, ctx.Kit.Rid)
+			return err
+		}
+		return nil
+	})
+
+	if txnErr != nil {
+		ctx.RespAutoError(txnErr)
+		return
+	}
+
+	ctx.RespEntity(data)
+}
+
+// DeleteServiceBinding delete kube workload service bindings
+func (s *Service) DeleteServiceBinding(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := new(types.ServiceBindingDeleteOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	data, err := s.getServiceBindingsInBiz(ctx.Kit, bizID, req.IDs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if len(data) == 0 {
+		ctx.RespEntity(nil)
+		return
+	}
+
+	txnErr := s.Engine.CoreAPI.CoreService().Txn().AutoRunTxn(ctx.Kit.Ctx, ctx.Kit.Header, func() error {
+		err := s.Engine.CoreAPI.CoreService().Kube().DeleteServiceBinding(ctx.Kit.Ctx, ctx.Kit.Header, bizID, req)
+		if err != nil {
+			blog.Errorf("delete workload service binding failed, data: %v, err: %v, rid: %s", req, err, ctx.Kit.Rid)
+			return err
+		}
+
+		// audit log.
+		audit := auditlog.NewKubeAudit(s.Engine.CoreAPI.CoreService())
+		auditParam := auditlog.NewGenerateAuditCommonParameter(ctx.Kit, metadata.AuditDelete)
+		auditLogs, err := audit.GenerateServiceBindingAuditLog(auditParam, data)
+		if err != nil {
+			blog.Errorf("generate audit log failed, data: %v, err: %v, rid: %s", data, err, ctx.Kit.Rid)
+			return err
+		}
+		if err := audit.SaveAuditLog(ctx.Kit, auditLogs...); err != nil {
+			blog.Errorf("save audit log failed, data: %v, err: %v, rid: %s", data, err, ctx.Kit.Rid)
+			return err
+		}
+		return nil
+	})
+
+	if txnErr != nil {
+		ctx.RespAutoError(txnErr)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// ListServiceBinding list kube workload service bindings
+func (s *Service) ListServiceBinding(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := new(types.ServiceBindingQueryOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	cond, err := req.BuildCond(bizID)
+	if err != nil {
+		blog.Errorf("build query workload service binding condition failed, err: %v, rid: %s", err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if req.Page.EnableCount {
+		counts, err := s.Engine.CoreAPI.CoreService().Count().GetCountByFilter(ctx.Kit.Ctx, ctx.Kit.Header,
+			types.BKTableNameWorkloadServiceBinding, []map[string]interface{}{cond})
+		if err != nil {
+			blog.Errorf("count workload service binding failed, cond: %v, err: %v, rid: %s", cond, err, ctx.Kit.Rid)
+			ctx.RespAutoError(err)
+			return
+		}
+		ctx.RespEntityWithCount(counts[0], make([]mapstr.MapStr, 0))
+		return
+	}
+
+	if req.Page.Sort == "" {
+		req.Page.Sort = common.BKFieldID
+	}
+
+	query := &metadata.QueryCondition{
+		Condition: cond,
+		Page:      req.Page,
+		Fields:    req.Fields,
+	}
+	resp, err := s.Engine.CoreAPI.CoreService().Kube().ListServiceBinding(ctx.Kit.Ctx, ctx.Kit.Header, query)
+	if err != nil {
+		blog.Errorf("list workload service binding failed, bizID: %d, data: %v, err: %v, rid: %s", bizID, req, err,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntityWithCount(0, resp.Data)
+}
+
+// getServiceBindingsInBiz get workload service bindings by ids, returns error if any of them does not belong to
+// the business
+func (s *Service) getServiceBindingsInBiz(kit *rest.Kit, bizID int64, ids []int64) ([]types.WorkloadServiceBinding,
+	error) {
+
+	query := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{common.BKFieldID: mapstr.MapStr{common.BKDBIN: ids}},
+		DisableCounter: true,
+	}
+	resp, err := s.Engine.CoreAPI.CoreService().Kube().ListServiceBinding(kit.Ctx, kit.Header, query)
+	if err != nil {
+		blog.Errorf("list workload service binding failed, bizID: %d, ids: %v, err: %v, rid: %s", bizID, ids, err,
+			kit.Rid)
+		return nil, err
+	}
+
+	invalidIDs := make([]int64, 0)
+	for _, data := range resp.Data {
+		if data.BizID != bizID {
+			invalidIDs = append(invalidIDs, data.ID)
+		}
+	}
+
+	if len(invalidIDs) != 0 {
+		blog.Errorf("workload service binding does not belong to this business, ids: %v, bizID: %d, rid: %s",
+			invalidIDs, bizID, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, invalidIDs)
+	}
+
+	return resp.Data, nil
+}
+
+// getHostBoundModules get the modules that the pods running on the hosts belong to through the service template
+// bindings of their workloads, returns the map of host id to the module ids, and the map of module id to biz id
+func (s *Service) getHostBoundModules(kit *rest.Kit, hostIDs []int64) (map[int64][]int64, map[int64]int64, error) {
+	hostToModule := make(map[int64][]int64)
+	moduleToBiz := make(map[int64]int64)
+
+	podQuery := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{common.BKHostIDField: mapstr.MapStr{common.BKDBIN: hostIDs}},
+		Fields:         []string{common.BKHostIDField, types.RefField},
+		Page:           metadata.BasePage{Limit: common.BKNoLimit},
+		DisableCounter: true,
+	}
+	pods, err := s.Engine.CoreAPI.CoreService().Kube().ListPod(kit.Ctx, kit.Header, podQuery)
+	if err != nil {
+		blog.Errorf("list pods failed, host ids: %v, err: %v, rid: %s", hostIDs, err, kit.Rid)
+		return nil, nil, err
+	}
+
+	if len(pods.Info) == 0 {
+		return hostToModule, moduleToBiz, nil
+	}
+
+	workloadIDs := make(map[types.WorkloadType][]int64)
+	for _, pod := range pods.Info {
+		workloadIDs[pod.Ref.Kind] = append(workloadIDs[pod.Ref.Kind], pod.Ref.ID)
+	}
+
+	refConds := make([]mapstr.MapStr, 0)
+	for kind, ids := range workloadIDs {
+		refConds = append(refConds, mapstr.MapStr{
+			types.RefKindField: kind,
+			types.RefIDField:   mapstr.MapStr{common.BKDBIN: util.IntArrayUnique(ids)},
+		})
+	}
+
+	bindingQuery := &metadata.QueryCondition{
+		Condition:      mapstr.MapStr{common.BKDBOR: refConds},
+		Fields:         []string{common.BKAppIDField, types.RefField, common.BKModuleIDField},
+		Page:           metadata.BasePage{Limit: common.BKNoLimit},
+		DisableCounter: true,
+	}
+	bindings, err := s.Engine.CoreAPI.CoreService().Kube().ListServiceBinding(kit.Ctx, kit.Header, bindingQuery)
+	if err != nil {
+		blog.Errorf("list workload service bindings failed, cond: %v, err: %v, rid: %s", bindingQuery.Condition, err,
+			kit.Rid)
+		return nil, nil, err
+	}
+
+	workloadModule := make(map[types.WorkloadType]map[int64]int64)
+	for _, binding := range bindings.Data {
+		if _, exists := workloadModule[binding.Ref.Kind]; !exists {
+			workloadModule[binding.Ref.Kind] = make(map[int64]int64)
+		}
+		workloadModule[binding.Ref.Kind][binding.Ref.ID] = binding.ModuleID
+		moduleToBiz[binding.ModuleID] = binding.BizID
+	}
+
+	hostModuleExists := make(map[int64]map[int64]struct{})
+	for _, pod := range pods.Info {
+		moduleID, exists := workloadModule[pod.Ref.Kind][pod.Ref.ID]
+		if !exists {
+			continue
+		}
+
+		if _, exists := hostModuleExists[pod.HostID]; !exists {
+			hostModuleExists[pod.HostID] = make(map[int64]struct{})
+		}
+		if _, exists := hostModuleExists[pod.HostID][moduleID]; exists {
+			continue
+		}
+		hostModuleExists[pod.HostID][moduleID] = struct{}{}
+		hostToModule[pod.HostID] = append(hostToModule[pod.HostID], moduleID)
+	}
+
+	return hostToModule, moduleToBiz, nil
+}
@@ -217,6 +217,8 @@ func (s *Service) initBusinessInst(web *restful.WebService) {
 		Handler: s.SearchObjectInstances})
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/count/instances/object/{bk_obj_id}",
 		Handler: s.CountObjectInstances})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/aggregate/instances/object/{bk_obj_id}",
+		Handler: s.AggregateObjectInstances})
 
 	utility.AddToRestfulWebService(web)
 }
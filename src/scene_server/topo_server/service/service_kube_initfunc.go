@@ -47,6 +47,10 @@ func (s *Service) initKube(web *restful.WebService) {
 		Path:    "/findmany/kube/cluster/bk_biz_id/{bk_biz_id}",
 		Handler: s.SearchClusters})
 
+	utility.AddHandler(rest.Action{Verb: http.MethodPost,
+		Path:    "/findmany/kube/cluster/sync_status/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListClusterSyncStatus})
+
 	utility.AddHandler(rest.Action{Verb: http.MethodPost,
 		Path:    "/createmany/kube/node/bk_biz_id/{bk_biz_id}",
 		Handler: s.BatchCreateNode})
@@ -82,6 +86,8 @@ func (s *Service) initKube(web *restful.WebService) {
 		Handler: s.DeleteWorkload})
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/workload/{kind}/{bk_biz_id}",
 		Handler: s.ListWorkload})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/workload/matched_pod/{kind}/{bk_biz_id}",
+		Handler: s.FindWorkloadMatchedPods})
 
 	// topo
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/find/kube/host_node_path",
@@ -113,5 +119,127 @@ func (s *Service) initKube(web *restful.WebService) {
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/container/bk_biz_id/{bk_biz_id}",
 		Handler: s.ListContainer})
 
+	// service
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/service/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateService})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/service/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateService})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/kube/service/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteService})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/service/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListService})
+
+	// ingress
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/ingress/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateIngress})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/ingress/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateIngress})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/kube/ingress/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteIngress})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/ingress/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListIngress})
+
+	// endpoint
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/endpoint/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateEndpoint})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/endpoint/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateEndpoint})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/kube/endpoint/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteEndpoint})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/endpoint/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListEndpoint})
+
+	// pvc
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/pvc/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreatePersistentVolumeClaim})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/pvc/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdatePersistentVolumeClaim})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/kube/pvc/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeletePersistentVolumeClaim})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/pvc/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListPersistentVolumeClaim})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/pvc/mounted_pod/bk_biz_id/{bk_biz_id}",
+		Handler: s.FindPVCMountedPods})
+
+	// pv
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/pv/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreatePersistentVolume})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/pv/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdatePersistentVolume})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/kube/pv/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeletePersistentVolume})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/pv/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListPersistentVolume})
+
+	// storage class
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/storage_class/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateStorageClass})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/storage_class/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateStorageClass})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/kube/storage_class/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteStorageClass})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/storage_class/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListStorageClass})
+
+	// storage capacity
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/find/kube/storage_capacity/bk_biz_id/{bk_biz_id}",
+		Handler: s.AggregateStorageCapacity})
+
+	// resource usage
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/find/kube/resource_usage/bk_biz_id/{bk_biz_id}",
+		Handler: s.AggregateResourceUsage})
+
+	// manifest
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/find/kube/manifest/import_plan/bk_biz_id/{bk_biz_id}",
+		Handler: s.PlanKubeManifestImport})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/import/kube/manifest/bk_biz_id/{bk_biz_id}",
+		Handler: s.ImportKubeManifest})
+
+	// workload service binding
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/service_binding/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateServiceBinding})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete,
+		Path: "/deletemany/kube/service_binding/bk_biz_id/{bk_biz_id}", Handler: s.DeleteServiceBinding})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/service_binding/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListServiceBinding})
+
+	// horizontal pod autoscaler
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/hpa/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateHorizontalPodAutoscaler})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/hpa/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateHorizontalPodAutoscaler})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/kube/hpa/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteHorizontalPodAutoscaler})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/hpa/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListHorizontalPodAutoscaler})
+
+	// workload replica history
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/replica_history/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListReplicaHistory})
+
+	// config map
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/config_map/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateConfigMap})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/config_map/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateConfigMap})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/kube/config_map/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteConfigMap})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/config_map/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListConfigMap})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost,
+		Path: "/findmany/kube/config_map/consumer/bk_biz_id/{bk_biz_id}", Handler: s.FindConfigMapConsumers})
+
+	// secret
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/kube/secret/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateSecret})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/secret/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateSecret})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/kube/secret/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteSecret})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/secret/bk_biz_id/{bk_biz_id}",
+		Handler: s.ListSecret})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/secret/consumer/bk_biz_id/{bk_biz_id}",
+		Handler: s.FindSecretConsumers})
+
 	utility.AddToRestfulWebService(web)
 }
@@ -0,0 +1,89 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/kube/types"
+)
+
+// storageClassResource returns the definition of kube storage class for the common kube resource handlers
+func (s *Service) storageClassResource() *kubeResource {
+	cli := s.Engine.CoreAPI.CoreService().Kube()
+	return &kubeResource{
+		name:            "storage class",
+		table:           types.BKTableNameBaseStorageClass,
+		newCreateOption: func() kubeResOption { return new(types.StorageClassCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.StorageClassUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.StorageClassDeleteOption) },
+		newQueryOption:  func() kubeResQueryOption { return new(types.StorageClassQueryOption) },
+		updateInfo: func(opt kubeResOption) ([]int64, interface{}) {
+			req := opt.(*types.StorageClassUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.StorageClassDeleteOption).IDs
+		},
+		queryPage: func(opt kubeResQueryOption) (*metadata.BasePage, []string) {
+			req := opt.(*types.StorageClassQueryOption)
+			return &req.Page, req.Fields
+		},
+		create: func(kit *rest.Kit, bizID int64, opt kubeResOption) (*metadata.RspIDs, error) {
+			return cli.CreateStorageClass(kit.Ctx, kit.Header, bizID, opt.(*types.StorageClassCreateOption))
+		},
+		update: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.UpdateStorageClass(kit.Ctx, kit.Header, bizID, opt.(*types.StorageClassUpdateOption))
+		},
+		delete: func(kit *rest.Kit, bizID int64, opt kubeResOption) error {
+			return cli.DeleteStorageClass(kit.Ctx, kit.Header, bizID, opt.(*types.StorageClassDeleteOption))
+		},
+		list: func(kit *rest.Kit, query *metadata.QueryCondition) (interface{}, []kubeResBrief, error) {
+			resp, err := cli.ListStorageClass(kit.Ctx, kit.Header, query)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			briefs := make([]kubeResBrief, len(resp.Data))
+			for idx, data := range resp.Data {
+				briefs[idx] = kubeResBrief{ID: data.ID, BizID: data.BizID}
+			}
+			return resp.Data, briefs, nil
+		},
+	}
+}
+
+// CreateStorageClass create kube storage class
+func (s *Service) CreateStorageClass(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.storageClassResource())
+}
+
+// UpdateStorageClass update kube storage class
+func (s *Service) UpdateStorageClass(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.storageClassResource())
+}
+
+// DeleteStorageClass delete kube storage class
+func (s *Service) DeleteStorageClass(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.storageClassResource())
+}
+
+// ListStorageClass list kube storage class
+func (s *Service) ListStorageClass(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.storageClassResource())
+}
@@ -18,6 +18,7 @@
 package service
 
 import (
+	"encoding/json"
 	"errors"
 	"strconv"
 
@@ -348,3 +349,164 @@ func (s *Service) ListWorkload(ctx *rest.Contexts) {
 	ctx.RespEntityWithCount(0, resp.Info)
 
 }
+
+// FindWorkloadMatchedPods find the pods that the label selectors of the workloads match
+func (s *Service) FindWorkloadMatchedPods(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	kind := types.WorkloadType(ctx.Request.PathParameter(types.KindField))
+	if _, err := kind.Table(); err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.KindField))
+		return
+	}
+
+	req := new(types.WlMatchedPodsOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	workloads, err := s.getWorkloadSelectors(ctx.Kit, bizID, kind, req.IDs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result := make([]types.WlMatchedPods, len(workloads))
+	// the label selector of a workload only matches the pods in the same namespace
+	orCond := make([]mapstr.MapStr, 0, len(workloads))
+	for idx, workload := range workloads {
+		result[idx] = types.WlMatchedPods{ID: workload.ID, Pods: make([]types.PodSimpleInfo, 0)}
+
+		selectors, err := workload.Selector.ToSelectors()
+		if err != nil {
+			blog.Errorf("workload %d selector is invalid, err: %v, rid: %s", workload.ID, err, ctx.Kit.Rid)
+			ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.SelectorField))
+			return
+		}
+		if selectors == nil {
+			continue
+		}
+
+		selectorCond, err := types.LabelSelectorsCond(selectors)
+		if err != nil {
+			blog.Errorf("convert workload %d selector failed, err: %v, rid: %s", workload.ID, err, ctx.Kit.Rid)
+			ctx.RespAutoError(err)
+			return
+		}
+		orCond = append(orCond, mapstr.MapStr{common.BKDBAND: []mapstr.MapStr{
+			{types.BKNamespaceIDField: workload.NamespaceID}, selectorCond}})
+	}
+
+	if len(orCond) == 0 {
+		ctx.RespEntity(result)
+		return
+	}
+
+	query := &metadata.QueryCondition{
+		Condition: mapstr.MapStr{
+			common.BKAppIDField: bizID,
+			common.BKDBOR:       orCond,
+		},
+		Fields: []string{common.BKFieldID, common.BKFieldName, types.BKNamespaceIDField, types.LabelsField},
+		Page: metadata.BasePage{
+			Limit: common.BKMaxPageSize,
+			Sort:  common.BKFieldID,
+		},
+		DisableCounter: true,
+	}
+
+	for {
+		pods, err := s.Engine.CoreAPI.CoreService().Kube().ListPod(ctx.Kit.Ctx, ctx.Kit.Header, query)
+		if err != nil {
+			blog.Errorf("list pods failed, cond: %v, err: %v, rid: %s", query.Condition, err, ctx.Kit.Rid)
+			ctx.RespAutoError(err)
+			return
+		}
+
+		matchWorkloadPods(workloads, pods.Info, result)
+
+		if len(pods.Info) < common.BKMaxPageSize {
+			break
+		}
+		query.Page.Start += common.BKMaxPageSize
+	}
+
+	ctx.RespEntity(result)
+}
+
+// matchWorkloadPods adds the pods to the result of the workloads whose label selector matches them, since a pod
+// may be matched by the selectors of multiple workloads, the pods are matched to each workload again.
+func matchWorkloadPods(workloads []workloadSelector, pods []types.Pod, result []types.WlMatchedPods) {
+	for _, pod := range pods {
+		if pod.Name == nil {
+			continue
+		}
+
+		labels := make(map[string]string)
+		if pod.Labels != nil {
+			labels = *pod.Labels
+		}
+
+		for idx, workload := range workloads {
+			if workload.NamespaceID == pod.NamespaceID && workload.Selector.Matches(labels) {
+				result[idx].Pods = append(result[idx].Pods, types.PodSimpleInfo{ID: pod.ID, Name: *pod.Name})
+			}
+		}
+	}
+}
+
+// workloadSelector is the label selector of the workload with its namespace
+type workloadSelector struct {
+	ID          int64                `json:"id"`
+	NamespaceID int64                `json:"bk_namespace_id"`
+	Selector    *types.LabelSelector `json:"selector"`
+}
+
+// getWorkloadSelectors get the label selectors of the workloads in the business, returns error if any of the
+// workloads does not belong to the business
+func (s *Service) getWorkloadSelectors(kit *rest.Kit, bizID int64, kind types.WorkloadType, ids []int64) (
+	[]workloadSelector, error) {
+
+	query := &metadata.QueryCondition{
+		Condition: mapstr.MapStr{
+			common.BKAppIDField: bizID,
+			common.BKFieldID:    mapstr.MapStr{common.BKDBIN: ids},
+		},
+		Fields:         []string{common.BKFieldID, types.BKNamespaceIDField, types.SelectorField},
+		DisableCounter: true,
+	}
+	resp, err := s.Engine.CoreAPI.CoreService().Kube().ListWorkload(kit.Ctx, kit.Header, query, kind)
+	if err != nil {
+		blog.Errorf("list %s failed, cond: %v, err: %v, rid: %s", kind, query.Condition, err, kit.Rid)
+		return nil, err
+	}
+
+	// the selector is decoded from the workload of each kind by their common json fields
+	data, jsonErr := json.Marshal(resp.Info)
+	if jsonErr != nil {
+		blog.Errorf("marshal %s failed, err: %v, rid: %s", kind, jsonErr, kit.Rid)
+		return nil, jsonErr
+	}
+
+	workloads := make([]workloadSelector, 0, len(resp.Info))
+	if err := json.Unmarshal(data, &workloads); err != nil {
+		blog.Errorf("decode %s selectors failed, err: %v, rid: %s", kind, err, kit.Rid)
+		return nil, err
+	}
+
+	if len(workloads) != len(ids) {
+		blog.Errorf("workloads does not belong to this business, ids: %v, bizID: %d, rid: %s", ids, bizID, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "ids")
+	}
+	return workloads, nil
+}
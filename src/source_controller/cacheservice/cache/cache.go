@@ -18,7 +18,9 @@ import (
 
 	"configcenter/src/apimachinery/discovery"
 	"configcenter/src/source_controller/cacheservice/cache/host"
+	"configcenter/src/source_controller/cacheservice/cache/kubetopo"
 	"configcenter/src/source_controller/cacheservice/cache/mainline"
+	"configcenter/src/source_controller/cacheservice/cache/subnet"
 	"configcenter/src/source_controller/cacheservice/cache/topology"
 	"configcenter/src/source_controller/cacheservice/cache/topotree"
 	"configcenter/src/source_controller/cacheservice/event/watch"
@@ -46,6 +48,15 @@ func NewCache(reflector reflector.Interface, loopW stream.LoopInterface, isMaste
 		return nil, err
 	}
 
+	kubeTopo, err := kubetopo.NewKubeTopology(isMaster, loopW)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := subnet.NewHostIPSyncer(isMaster, loopW); err != nil {
+		return nil, fmt.Errorf("new subnet host ip syncer failed, err: %v", err)
+	}
+
 	mainlineClient := mainline.NewMainlineClient()
 	hostClient := host.NewClient()
 
@@ -54,6 +65,7 @@ func NewCache(reflector reflector.Interface, loopW stream.LoopInterface, isMaste
 		Host:     hostClient,
 		Business: mainlineClient,
 		Topology: topo,
+		KubeTopo: kubeTopo,
 		Event:    watch.NewClient(watchDB, mongodb.Client(), redis.Client()),
 	}
 	return cache, nil
@@ -63,6 +75,7 @@ func NewCache(reflector reflector.Interface, loopW stream.LoopInterface, isMaste
 type ClientSet struct {
 	Tree     *topotree.TopologyTree
 	Topology *topology.Topology
+	KubeTopo *kubetopo.KubeTopology
 	Host     *host.Client
 	Business *mainline.Client
 	Event    *watch.Client
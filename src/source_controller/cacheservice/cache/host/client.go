@@ -167,7 +167,9 @@ func (c *Client) GetHostWithInnerIP(ctx context.Context, opt *metadata.SearchHos
 
 // ListHostsWithPage get host id list sorted with host id with forward sort.
 // this id list has a ttl life cycle, and triggered with update with user's request.
-func (c *Client) ListHostsWithPage(ctx context.Context, opt *metadata.ListHostWithPage) (int64, []string, error) {
+// if the page uses keyset pagination, the next cursor is returned when there are more hosts.
+func (c *Client) ListHostsWithPage(ctx context.Context, opt *metadata.ListHostWithPage) (int64, []string, string,
+	error) {
 	rid := util.ExtractRequestIDFromContext(ctx)
 
 	if len(opt.HostIDs) != 0 {
@@ -175,7 +177,7 @@ func (c *Client) ListHostsWithPage(ctx context.Context, opt *metadata.ListHostWi
 		total, err := c.countHost(ctx, mapstr.MapStr{common.BKHostIDField: mapstr.MapStr{common.BKDBIN: opt.HostIDs}})
 		if err != nil {
 			blog.Errorf("list host with page, but count failed, err: %v, rid: %v", err, rid)
-			return 0, nil, err
+			return 0, nil, "", err
 		}
 
 		options := metadata.ListWithIDOption{
@@ -184,25 +186,30 @@ func (c *Client) ListHostsWithPage(ctx context.Context, opt *metadata.ListHostWi
 		}
 		list, err := c.ListHostWithHostIDs(ctx, &options)
 
-		return int64(total), list, err
+		return int64(total), list, "", err
+	}
+
+	if opt.Page.IsCursorPage() {
+		return c.listHostsWithCursor(ctx, opt)
 	}
 
 	// validate the page limit
 	if opt.Page.Limit > common.BKMaxPageSize {
-		return 0, nil, errors.New("page size is over limit")
+		return 0, nil, "", errors.New("page size is over limit")
 	}
 
 	cnt, idList, details, err := c.getPagedHostDetailList(opt.Page)
 	if err != nil {
 		if err != keyNotExistError {
-			return 0, nil, err
+			return 0, nil, "", err
 		}
 
 		// force refresh the host id list
 		c.forceRefreshHostIDList(ctx)
 
 		// zset key is not exist, then we get it from mongodb.
-		return c.getHostsWithPage(ctx, opt)
+		total, list, err := c.getHostsWithPage(ctx, opt)
+		return total, list, "", err
 	}
 
 	// try to refresh host id list in cache.
@@ -230,7 +237,7 @@ func (c *Client) ListHostsWithPage(ctx context.Context, opt *metadata.ListHostWi
 		refresh, err := listHostDetailsFromMongoWithHostID(toRefreshIds)
 		if err != nil {
 			blog.Errorf("list host with ids, but get from db failed, host: %v, rid: %s", toRefreshIds, rid)
-			return 0, nil, err
+			return 0, nil, "", err
 		}
 
 		for _, host := range refresh {
@@ -245,5 +252,5 @@ func (c *Client) ListHostsWithPage(ctx context.Context, opt *metadata.ListHostWi
 		}
 	}
 
-	return cnt, all, nil
+	return cnt, all, "", nil
 }
@@ -21,6 +21,7 @@ import (
 	"configcenter/src/common"
 	"configcenter/src/common/blog"
 	"configcenter/src/common/json"
+	"configcenter/src/common/mapstr"
 	"configcenter/src/common/metadata"
 	"configcenter/src/common/util"
 	"configcenter/src/storage/driver/mongodb"
@@ -292,3 +293,130 @@ func (c *Client) getHostsWithPage(ctx context.Context, opt *metadata.ListHostWit
 func (c *Client) countHost(ctx context.Context, filter map[string]interface{}) (uint64, error) {
 	return mongodb.Client().Table(common.BKTableNameBaseHost).Find(filter).Count(ctx)
 }
+
+// listHostsWithCursor list hosts with keyset pagination that sorts on the host id, the hosts are got from redis if the
+// host id list exists, otherwise from mongodb.
+func (c *Client) listHostsWithCursor(ctx context.Context, opt *metadata.ListHostWithPage) (int64, []string, string,
+	error) {
+
+	rid := util.ExtractRequestIDFromContext(ctx)
+
+	cursor, err := opt.Page.ParsePageCursor(common.BKHostIDField)
+	if err != nil {
+		blog.Errorf("list host with cursor, but parse page cursor failed, err: %v, page: %+v, rid: %s", err, opt.Page,
+			rid)
+		return 0, nil, "", err
+	}
+
+	// get one more host to check if there are more hosts.
+	cnt, idList, details, err := c.getHostDetailListAfterCursor(cursor, opt.Page.Limit+1)
+	if err != nil {
+		if err != keyNotExistError {
+			return 0, nil, "", err
+		}
+
+		// force refresh the host id list
+		c.forceRefreshHostIDList(ctx)
+
+		// zset key is not exist, then we get it from mongodb.
+		return c.getHostsWithCursor(ctx, opt, cursor)
+	}
+
+	// try to refresh host id list in cache.
+	c.tryRefreshHostIDList(rid)
+
+	var nextCursor string
+	if len(idList) > opt.Page.Limit {
+		idList, details = idList[:opt.Page.Limit], details[:opt.Page.Limit]
+		nextCursor = cursor.Next(idList[len(idList)-1])
+	}
+
+	// refresh those details which are not exist, and keep the hosts' order as the id list.
+	toRefreshIds := make([]int64, 0)
+	for idx, h := range details {
+		if len(h) == 0 {
+			toRefreshIds = append(toRefreshIds, idList[idx])
+		}
+	}
+
+	refreshMap := make(map[int64]string)
+	if len(toRefreshIds) != 0 {
+		refresh, err := listHostDetailsFromMongoWithHostID(toRefreshIds)
+		if err != nil {
+			blog.Errorf("list host with cursor, but get from db failed, host: %v, rid: %s", toRefreshIds, rid)
+			return 0, nil, "", err
+		}
+
+		for _, host := range refresh {
+			c.tryRefreshHostDetail(host.id, host.ip, host.cloudID, []byte(host.detail))
+			refreshMap[host.id] = host.detail
+		}
+	}
+
+	all := make([]string, 0)
+	for idx, h := range details {
+		if len(h) == 0 {
+			refreshed, exists := refreshMap[idList[idx]]
+			if !exists {
+				// the host has been deleted.
+				continue
+			}
+			h = refreshed
+		}
+
+		if len(opt.Fields) != 0 {
+			// only return with user needed fields.
+			all = append(all, *json.CutJsonDataWithFields(&h, opt.Fields))
+		} else {
+			all = append(all, h)
+		}
+	}
+
+	return cnt, all, nextCursor, nil
+}
+
+// getHostsWithCursor get hosts after the cursor from mongodb with keyset pagination.
+func (c *Client) getHostsWithCursor(ctx context.Context, opt *metadata.ListHostWithPage, cursor *metadata.PageCursor) (
+	int64, []string, string, error) {
+
+	rid := ctx.Value(common.ContextRequestIDField)
+
+	total, err := c.countHost(ctx, nil)
+	if err != nil {
+		blog.Errorf("get host with cursor, but count failed, err: %v, rid: %v", err, rid)
+		return 0, nil, "", err
+	}
+
+	fields := opt.Fields
+	if len(fields) != 0 && !util.InStrArr(fields, common.BKHostIDField) {
+		fields = append(fields, common.BKHostIDField)
+	}
+
+	list := make([]metadata.HostMapStr, 0)
+	if err := mongodb.Client().Table(common.BKTableNameBaseHost).Find(cursor.MergeCondition(mapstr.MapStr{})).
+		Limit(uint64(opt.Page.Limit+1)).Sort(cursor.Sort()).Fields(fields...).All(ctx, &list); err != nil {
+
+		blog.Errorf("get host list with cursor failed, err: %v, rid: %v", err, rid)
+		return 0, nil, "", err
+	}
+
+	var nextCursor string
+	if len(list) > opt.Page.Limit {
+		list = list[:opt.Page.Limit]
+		lastID, err := util.GetInt64ByInterface(list[len(list)-1][common.BKHostIDField])
+		if err != nil {
+			blog.Errorf("get host list with cursor, but parse host id failed, err: %v, rid: %v", err, rid)
+			return 0, nil, "", err
+		}
+		nextCursor = cursor.Next(lastID)
+	}
+
+	all := make([]string, len(list))
+	for idx := range list {
+		// the err can be ignore because it's unmarshal from bson upper, marshal it again is also available.
+		js, _ := json.Marshal(list[idx])
+		all[idx] = string(js)
+	}
+
+	return int64(total), all, nextCursor, nil
+}
@@ -97,6 +97,52 @@ const (
 	// KEYS[3]: page stopped at position.
 	// KEYS[4]: host detail key prefix
 	// ARGV[1]: zset key not exist error
+	// getHostIDListAfterCursorScript is used to check if a zset key is exist or not, if not then return with a error.
+	// if yes, then return the total keys of this zset and the limited keys after the start score sorted with scores.
+	// KEYS[1]: zset key's name
+	// KEYS[2]: the exclusive start score.
+	// KEYS[3]: page limit.
+	// KEYS[4]: host detail key prefix
+	// KEYS[5]: "1" means sorted with scores in descending order.
+	// ARGV[1]: zset key not exist error
+	getHostIDListAfterCursorScript = `
+local exist = redis.pcall('exists', KEYS[1]);
+
+if (exist == 0) then
+	return ARGV[1]
+end;
+
+local total = redis.pcall('zcard', KEYS[1]);
+local keys;
+if KEYS[5] == '1' then
+	keys = redis.pcall('zrevrangebyscore', KEYS[1], KEYS[2], '-inf', 'LIMIT', 0, KEYS[3]);
+else
+	keys = redis.pcall('zrangebyscore', KEYS[1], KEYS[2], '+inf', 'LIMIT', 0, KEYS[3]);
+end;
+
+if table.getn(keys) == 0 then
+	local elements = {};
+	elements[1] = total;
+	elements[2] = keys;
+	elements[3] = {};
+	return elements
+end;
+
+local list ={}
+for _,key in ipairs(keys) do
+        table.insert(list,KEYS[4]..key)
+end
+
+local details = redis.pcall('MGET', unpack(list));
+
+local elements = {};
+elements[1] = total;
+elements[2] = keys;
+elements[3] = details;
+
+return elements
+`
+
 	getPagedHostIDListScript = `
 local exist = redis.pcall('exists', KEYS[1]);
 
@@ -151,6 +197,42 @@ func (c *Client) getPagedHostDetailList(page metadata.BasePage) (int64, []int64,
 		return 0, nil, nil, err
 	}
 
+	return parsePagedHostDetailResult(result)
+}
+
+// getHostDetailListAfterCursor get host id list after the cursor from redis with keyset pagination, it returns the
+// limited host id list sorted by the cursor if zset key is exist.
+// Otherwise, return with a not exist error
+// Note: the returned host detail string may be empty when the host detail is not exist.
+func (c *Client) getHostDetailListAfterCursor(cursor *metadata.PageCursor, limit int) (int64, []int64, []string,
+	error) {
+
+	if limit <= 0 {
+		return 0, nil, nil, errors.New("invalid page parameter")
+	}
+
+	// the host id is used as the score, so the hosts after the cursor can be got by the score range directly.
+	start, order := "-inf", "0"
+	if cursor.Desc {
+		start, order = "+inf", "1"
+	}
+	if cursor.LastID > 0 {
+		start = "(" + strconv.FormatInt(cursor.LastID, 10)
+	}
+
+	keys := []string{hostKey.HostIDListKey(), start, strconv.Itoa(limit), hostKey.HostDetailKeyPrefix(), order}
+
+	result, err := redis.Client().Eval(context.Background(), getHostIDListAfterCursorScript, keys,
+		notExistError).Result()
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return parsePagedHostDetailResult(result)
+}
+
+// parsePagedHostDetailResult parses the paged host id list and details returned by the redis lua script.
+func parsePagedHostDetailResult(result interface{}) (int64, []int64, []string, error) {
 	switch reflect.TypeOf(result).Kind() {
 	case reflect.String:
 		err := result.(string)
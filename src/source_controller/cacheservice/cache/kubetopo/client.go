@@ -0,0 +1,147 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kubetopo
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/util"
+	kubetypes "configcenter/src/kube/types"
+)
+
+// getBizKubeTopology get business kube topology from cache, if the cache is not exist, get it from db and refresh
+// the cache.
+func (k *KubeTopology) getBizKubeTopology(kit *rest.Kit, biz int64) (*BizKubeTopology, error) {
+	// read from secondary in mongodb cluster.
+	kit.Ctx = util.SetDBReadPreference(kit.Ctx, common.SecondaryPreferredMode)
+
+	topo, err := k.key.getTopology(kit.Ctx, biz)
+	if err == nil && topo != nil {
+		// get data from cache success
+		return topo, nil
+	}
+
+	blog.Errorf("get biz: %d kube topology from cache failed, get from db now, err: %v, rid: %s", biz, err, kit.Rid)
+
+	// do not get biz kube topology from cache, get it from db directly.
+	topo, err = k.genBizKubeTopology(kit.Ctx, biz)
+	if err != nil {
+		blog.Errorf("generate biz: %d kube topology from db failed, err: %v, rid: %s", biz, err, kit.Rid)
+		return nil, err
+	}
+
+	// update it to cache directly.
+	if err := k.key.updateTopology(kit.Ctx, topo); err != nil {
+		blog.Errorf("refresh biz: %d kube topology cache failed, err: %v, rid: %s", biz, err, kit.Rid)
+		// do not return error
+	}
+
+	return topo, nil
+}
+
+// SearchKubeTopoPath get the next level nodes of the reference kube topology node from cache, the nodes of all the
+// next level resources are paged together. if the reference node is not in the cache, a not found error is returned.
+func (k *KubeTopology) SearchKubeTopoPath(kit *rest.Kit, biz int64, opt *kubetypes.KubeTopoPathOption) (
+	*kubetypes.KubeTopoPathRsp, error) {
+
+	topo, err := k.getBizKubeTopology(kit, biz)
+	if err != nil {
+		return nil, err
+	}
+
+	var subNodes []*Node
+	switch opt.ReferenceObjID {
+	case kubetypes.KubeBusiness:
+		subNodes = topo.Clusters
+	case kubetypes.KubeCluster, kubetypes.KubeNamespace:
+		node, exists := topo.findNode(opt.ReferenceObjID, opt.ReferenceID)
+		if !exists {
+			blog.Errorf("%s %d is not in biz %d kube topology cache, rid: %s", opt.ReferenceObjID, opt.ReferenceID,
+				biz, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommNotFound)
+		}
+		subNodes = node.SubNodes
+	default:
+		blog.Errorf("kube topology cache does not support %s topo path, rid: %s", opt.ReferenceObjID, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "bk_reference_obj_id")
+	}
+
+	result := &kubetypes.KubeTopoPathRsp{Info: make([]kubetypes.KubeObjectInfo, 0)}
+	if opt.Page.EnableCount {
+		result.Count = len(subNodes)
+		return result, nil
+	}
+
+	start := opt.Page.Start
+	if start > len(subNodes) {
+		start = len(subNodes)
+	}
+	end := len(subNodes)
+	if opt.Page.Limit > 0 && start+opt.Page.Limit < end {
+		end = start + opt.Page.Limit
+	}
+
+	for _, node := range subNodes[start:end] {
+		result.Info = append(result.Info, kubetypes.KubeObjectInfo{
+			ID:   node.ID,
+			Name: node.Name,
+			Kind: node.Kind,
+		})
+	}
+
+	return result, nil
+}
+
+// CountKubeTopoHostsOrPods get the number of hosts or pods of the kube topology nodes from cache, if one of the
+// nodes is not in the cache, a not found error is returned.
+func (k *KubeTopology) CountKubeTopoHostsOrPods(kit *rest.Kit, biz int64, kind string,
+	opt *kubetypes.KubeTopoCountOption) ([]kubetypes.KubeTopoCountRsp, error) {
+
+	if kind != kubetypes.KubeHostKind && kind != kubetypes.KubePodKind {
+		blog.Errorf("count kind %s is invalid, rid: %s", kind, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "type")
+	}
+
+	topo, err := k.getBizKubeTopology(kit, biz)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]kubetypes.KubeTopoCountRsp, 0)
+	for _, info := range opt.ResourceInfos {
+		node, exists := topo.findNode(info.Kind, info.ID)
+		if !exists {
+			blog.Errorf("%s %d is not in biz %d kube topology cache, rid: %s", info.Kind, info.ID, biz, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommNotFound)
+		}
+
+		count := node.PodCount
+		if kind == kubetypes.KubeHostKind {
+			count = node.HostCount
+		}
+
+		result = append(result, kubetypes.KubeTopoCountRsp{
+			Kind:  info.Kind,
+			ID:    info.ID,
+			Count: count,
+		})
+	}
+
+	return result, nil
+}
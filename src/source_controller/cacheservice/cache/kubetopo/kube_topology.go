@@ -0,0 +1,175 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kubetopo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"configcenter/src/apimachinery/discovery"
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/dal"
+	"configcenter/src/storage/driver/mongodb"
+	"configcenter/src/storage/stream"
+)
+
+// NewKubeTopology new kube topology cache, it watches the kube resources to refresh the changed business's kube
+// topology, and loops all the business's kube topology at intervals to keep the cache consistent with the db.
+func NewKubeTopology(isMaster discovery.ServiceManageInterface, loopW stream.LoopInterface) (*KubeTopology, error) {
+
+	k := &KubeTopology{
+		db:          mongodb.Client(),
+		loopW:       loopW,
+		checkMaster: isMaster,
+		key:         newKubeTopologyKey(),
+		changedBiz:  make(map[int64]struct{}),
+	}
+
+	if err := k.watchKube(); err != nil {
+		blog.Errorf("kube topology watch kube resources failed, err: %v", err)
+		return nil, err
+	}
+
+	go k.loopChangedBizKubeTopology()
+	go k.loopBizKubeTopologyCache()
+
+	return k, nil
+}
+
+// KubeTopology is the business's brief kube topology cache
+type KubeTopology struct {
+	db          dal.DB
+	loopW       stream.LoopInterface
+	checkMaster discovery.ServiceManageInterface
+	key         *cacheKey
+
+	// changedBiz is the businesses whose kube topology is changed by the watched events and waits to be refreshed,
+	// the events of a business in a refresh interval are merged so that the topology is rebuilt only once.
+	changedBiz     map[int64]struct{}
+	changedBizLock sync.Mutex
+}
+
+// addChangedBiz add the businesses whose kube topology need to be refreshed
+func (k *KubeTopology) addChangedBiz(bizList []int64) {
+	k.changedBizLock.Lock()
+	defer k.changedBizLock.Unlock()
+
+	for _, biz := range bizList {
+		k.changedBiz[biz] = struct{}{}
+	}
+}
+
+// popChangedBiz returns and clears the businesses whose kube topology need to be refreshed
+func (k *KubeTopology) popChangedBiz() []int64 {
+	k.changedBizLock.Lock()
+	defer k.changedBizLock.Unlock()
+
+	bizList := make([]int64, 0, len(k.changedBiz))
+	for biz := range k.changedBiz {
+		bizList = append(bizList, biz)
+	}
+	k.changedBiz = make(map[int64]struct{})
+	return bizList
+}
+
+// loopChangedBizKubeTopology refresh the kube topology of the changed businesses every debounce interval, the
+// businesses that failed to refresh are added back to be refreshed in the next round.
+func (k *KubeTopology) loopChangedBizKubeTopology() {
+	for {
+		time.Sleep(changedBizRefreshInterval)
+
+		bizList := k.popChangedBiz()
+		if len(bizList) == 0 {
+			continue
+		}
+
+		rid := util.GenerateRID()
+		blog.Infof("try to refresh changed biz: %v kube topology, rid: %s", bizList, rid)
+		for _, biz := range bizList {
+			if err := k.refreshBizKubeTopology(biz, rid); err != nil {
+				blog.Errorf("refresh changed biz %d kube topology failed, err: %v, rid: %s", biz, err, rid)
+				k.addChangedBiz([]int64{biz})
+			}
+		}
+	}
+}
+
+// refreshBizKubeTopology construct a business kube topology and update it to cache.
+func (k *KubeTopology) refreshBizKubeTopology(biz int64, rid string) error {
+	ctx := context.WithValue(context.TODO(), common.ContextRequestIDField, rid)
+	topo, err := k.genBizKubeTopology(ctx, biz)
+	if err != nil {
+		blog.Errorf("refresh biz %d kube topology, but generate topology failed, err: %v, rid: %s", biz, err, rid)
+		return err
+	}
+
+	err = k.key.updateTopology(ctx, topo)
+	if err != nil {
+		blog.Errorf("update biz %d kube topology to cache failed, err: %v, rid: %s", biz, err, rid)
+		return err
+	}
+
+	return nil
+}
+
+// loopBizKubeTopologyCache launch the task to loop business's kube topology every interval minutes, so that the
+// changes that are not watched, like the host transfer between businesses, can be synced to the cache.
+func (k *KubeTopology) loopBizKubeTopologyCache() {
+	blog.Infof("loop refresh biz kube topology task every %d minutes.", getKubeTopoCacheRefreshMinutes())
+	for {
+
+		if !k.checkMaster.IsMaster() {
+			blog.V(4).Infof("loop biz kube topology cache, but not master, skip.")
+			time.Sleep(time.Minute)
+			continue
+		}
+
+		interval := getKubeTopoCacheRefreshMinutes()
+		time.Sleep(time.Duration(interval) * time.Minute)
+		rid := util.GenerateRID()
+
+		blog.Infof("start loop refresh biz kube topology task, interval: %d, rid: %s", interval, rid)
+		k.doLoopBizKubeTopologyToCache(rid)
+		blog.Infof("finished loop refresh biz kube topology task, rid: %s", rid)
+	}
+}
+
+func (k *KubeTopology) doLoopBizKubeTopologyToCache(rid string) {
+	// read from secondary in mongodb cluster.
+	ctx := util.SetDBReadPreference(context.Background(), common.SecondaryPreferredMode)
+
+	all, err := k.listAllBusiness(ctx)
+	if err != nil {
+		blog.Errorf("loop biz kube topology, but list all business failed, err: %v, rid: %s", err, rid)
+		return
+	}
+
+	for _, biz := range all {
+		time.Sleep(50 * time.Millisecond)
+
+		err := k.refreshBizKubeTopology(biz, rid)
+		if err != nil {
+			blog.Errorf("loop refresh biz %d kube topology failed, err: %v, rid: %s", biz, err, rid)
+		} else {
+			blog.V(4).Infof("loop refresh biz %d kube topology success, rid: %s", biz, rid)
+		}
+	}
+}
@@ -0,0 +1,489 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kubetopo
+
+import (
+	"context"
+
+	"configcenter/src/common"
+	"configcenter/src/common/backbone/configcenter"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+	kubetypes "configcenter/src/kube/types"
+)
+
+// genBizKubeTopology generate the kube topology of a business, the topology contains the clusters of this business,
+// and the host and pod counts are calculated with the pods and nodes that belong to this business.
+func (k *KubeTopology) genBizKubeTopology(ctx context.Context, biz int64) (*BizKubeTopology, error) {
+	topo := &BizKubeTopology{
+		BizID:    biz,
+		Clusters: make([]*Node, 0),
+	}
+
+	clusters, err := k.listClusters(ctx, biz)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(clusters) == 0 {
+		return topo, nil
+	}
+
+	clusterIDs := make([]int64, len(clusters))
+	for idx, cluster := range clusters {
+		clusterIDs[idx] = cluster.ID
+	}
+
+	counter, err := k.genTopoCounter(ctx, biz, clusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceNodes, err := k.genNamespaceNodes(ctx, clusterIDs, counter)
+	if err != nil {
+		return nil, err
+	}
+
+	pvNodes, err := k.genNamespacedNodes(ctx, kubetypes.BKTableNameBasePersistentVolume,
+		kubetypes.KubePersistentVolume, clusterIDs, counter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters {
+		folder := &Node{
+			Kind:      kubetypes.KubeFolder,
+			ID:        kubetypes.KubeFolderID,
+			Name:      kubetypes.KubeFolderName,
+			HostCount: int64(len(counter.folderHosts[cluster.ID])),
+		}
+
+		// according to the topology display, put the folder to the front
+		subNodes := []*Node{folder}
+		subNodes = append(subNodes, namespaceNodes[cluster.ID]...)
+		subNodes = append(subNodes, pvNodes[cluster.ID]...)
+
+		topo.Clusters = append(topo.Clusters, &Node{
+			Kind:      kubetypes.KubeCluster,
+			ID:        cluster.ID,
+			Name:      cluster.Name,
+			HostCount: int64(len(counter.clusterHosts(cluster.ID))),
+			PodCount:  counter.pods[kubetypes.KubeCluster][cluster.ID],
+			SubNodes:  subNodes,
+		})
+	}
+
+	return topo, nil
+}
+
+// genNamespaceNodes generate the namespace nodes with their workloads and persistent volume claims, returns the map
+// of cluster id to its namespace nodes.
+func (k *KubeTopology) genNamespaceNodes(ctx context.Context, clusterIDs []int64, counter *topoCounter) (
+	map[int64][]*Node, error) {
+
+	namespaceSubNodes := make(map[int64][]*Node)
+	for _, table := range kubetypes.GetWorkLoadTables() {
+		kind, err := kubetypes.GetKindByWorkLoadTableNameMap(table)
+		if err != nil {
+			return nil, err
+		}
+
+		workloads, err := k.listNamespacedResources(ctx, table, clusterIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, workload := range workloads {
+			namespaceSubNodes[workload.NamespaceID] = append(namespaceSubNodes[workload.NamespaceID], &Node{
+				Kind:      kind[table],
+				ID:        workload.ID,
+				Name:      workload.Name,
+				HostCount: int64(len(counter.hosts[kind[table]][workload.ID])),
+				PodCount:  counter.pods[kind[table]][workload.ID],
+			})
+		}
+	}
+
+	pvcs, err := k.listNamespacedResources(ctx, kubetypes.BKTableNameBasePersistentVolumeClaim, clusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pvc := range pvcs {
+		namespaceSubNodes[pvc.NamespaceID] = append(namespaceSubNodes[pvc.NamespaceID], &Node{
+			Kind: kubetypes.KubePersistentVolumeClaim,
+			ID:   pvc.ID,
+			Name: pvc.Name,
+		})
+	}
+
+	namespaces, err := k.listNamespacedResources(ctx, kubetypes.BKTableNameBaseNamespace, clusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterNamespaces := make(map[int64][]*Node)
+	for _, namespace := range namespaces {
+		clusterNamespaces[namespace.ClusterID] = append(clusterNamespaces[namespace.ClusterID], &Node{
+			Kind:      kubetypes.KubeNamespace,
+			ID:        namespace.ID,
+			Name:      namespace.Name,
+			HostCount: int64(len(counter.hosts[kubetypes.KubeNamespace][namespace.ID])),
+			PodCount:  counter.pods[kubetypes.KubeNamespace][namespace.ID],
+			SubNodes:  namespaceSubNodes[namespace.ID],
+		})
+	}
+
+	return clusterNamespaces, nil
+}
+
+// genNamespacedNodes generate the nodes of the resources that have no sub nodes, returns the map of cluster id to
+// the nodes.
+func (k *KubeTopology) genNamespacedNodes(ctx context.Context, table, kind string, clusterIDs []int64,
+	counter *topoCounter) (map[int64][]*Node, error) {
+
+	resources, err := k.listNamespacedResources(ctx, table, clusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[int64][]*Node)
+	for _, res := range resources {
+		nodes[res.ClusterID] = append(nodes[res.ClusterID], &Node{
+			Kind:      kind,
+			ID:        res.ID,
+			Name:      res.Name,
+			HostCount: int64(len(counter.hosts[kind][res.ID])),
+			PodCount:  counter.pods[kind][res.ID],
+		})
+	}
+
+	return nodes, nil
+}
+
+// topoCounter records the hosts and the number of pods of each kind of the kube topology nodes.
+type topoCounter struct {
+	// hosts is the map of kind to the map of resource id to the hosts in the business
+	hosts map[string]map[int64]map[int64]struct{}
+	// pods is the map of kind to the map of resource id to the number of pods in the business
+	pods map[string]map[int64]int64
+	// folderHosts is the map of cluster id to the hosts in the business of the nodes without pods
+	folderHosts map[int64]map[int64]struct{}
+}
+
+func (c *topoCounter) add(kind string, id, hostID int64, hostInBiz bool) {
+	if _, exists := c.pods[kind]; !exists {
+		c.pods[kind] = make(map[int64]int64)
+		c.hosts[kind] = make(map[int64]map[int64]struct{})
+	}
+	c.pods[kind][id]++
+
+	if !hostInBiz {
+		return
+	}
+
+	if _, exists := c.hosts[kind][id]; !exists {
+		c.hosts[kind][id] = make(map[int64]struct{})
+	}
+	c.hosts[kind][id][hostID] = struct{}{}
+}
+
+// clusterHosts returns the hosts of the cluster, including the hosts of the pods and the nodes without pods.
+func (c *topoCounter) clusterHosts(clusterID int64) map[int64]struct{} {
+	hosts := make(map[int64]struct{})
+	for hostID := range c.hosts[kubetypes.KubeCluster][clusterID] {
+		hosts[hostID] = struct{}{}
+	}
+	for hostID := range c.folderHosts[clusterID] {
+		hosts[hostID] = struct{}{}
+	}
+	return hosts
+}
+
+// genTopoCounter calculate the hosts and pods of the kube topology nodes with the pods and nodes of the business.
+// only the hosts that belong to the business are counted, which is the same with the topo server's logic.
+func (k *KubeTopology) genTopoCounter(ctx context.Context, biz int64, clusterIDs []int64) (*topoCounter, error) {
+	pods, err := k.listPods(ctx, biz)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := k.listNoPodNodes(ctx, biz, clusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	hostIDs := make([]int64, 0)
+	for _, pod := range pods {
+		hostIDs = append(hostIDs, pod.HostID)
+	}
+	for _, node := range nodes {
+		hostIDs = append(hostIDs, node.HostID)
+	}
+
+	bizHosts, err := k.getBizHosts(ctx, biz, util.IntArrayUnique(hostIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &topoCounter{
+		hosts:       make(map[string]map[int64]map[int64]struct{}),
+		pods:        make(map[string]map[int64]int64),
+		folderHosts: make(map[int64]map[int64]struct{}),
+	}
+
+	for _, pod := range pods {
+		_, hostInBiz := bizHosts[pod.HostID]
+		counter.add(kubetypes.KubeCluster, pod.ClusterID, pod.HostID, hostInBiz)
+		counter.add(kubetypes.KubeNamespace, pod.NamespaceID, pod.HostID, hostInBiz)
+		counter.add(pod.Ref.Kind, pod.Ref.ID, pod.HostID, hostInBiz)
+	}
+
+	for _, node := range nodes {
+		if _, exists := bizHosts[node.HostID]; !exists {
+			continue
+		}
+
+		if _, exists := counter.folderHosts[node.ClusterID]; !exists {
+			counter.folderHosts[node.ClusterID] = make(map[int64]struct{})
+		}
+		counter.folderHosts[node.ClusterID][node.HostID] = struct{}{}
+	}
+
+	return counter, nil
+}
+
+// getBizHosts get the hosts that belongs to the business in the given hosts
+func (k *KubeTopology) getBizHosts(ctx context.Context, biz int64, hostIDs []int64) (map[int64]struct{}, error) {
+	bizHosts := make(map[int64]struct{})
+	for start := 0; start < len(hostIDs); start += step {
+		end := start + step
+		if end > len(hostIDs) {
+			end = len(hostIDs)
+		}
+
+		filter := mapstr.MapStr{
+			common.BKAppIDField:  biz,
+			common.BKHostIDField: mapstr.MapStr{common.BKDBIN: hostIDs[start:end]},
+		}
+		ids, err := k.db.Table(common.BKTableNameModuleHostConfig).Distinct(ctx, common.BKHostIDField, filter)
+		if err != nil {
+			blog.Errorf("get biz: %d host relations failed, err: %v", biz, err)
+			return nil, err
+		}
+
+		for _, id := range ids {
+			hostID, err := util.GetInt64ByInterface(id)
+			if err != nil {
+				blog.Errorf("parse host id %v failed, err: %v", id, err)
+				return nil, err
+			}
+			bizHosts[hostID] = struct{}{}
+		}
+	}
+
+	return bizHosts, nil
+}
+
+// listClusters list a business's all clusters
+func (k *KubeTopology) listClusters(ctx context.Context, biz int64) ([]*clusterBase, error) {
+	filter := mapstr.MapStr{kubetypes.BKBizIDField: biz}
+	all := make([]*clusterBase, 0)
+	start := uint64(0)
+	for {
+		oneStep := make([]*clusterBase, 0)
+		err := k.db.Table(kubetypes.BKTableNameBaseCluster).Find(filter).Fields(clusterBaseFields...).Start(start).
+			Limit(step).Sort(kubetypes.BKIDField).All(ctx, &oneStep)
+		if err != nil {
+			blog.Errorf("get biz: %d cluster list failed, err: %v", biz, err)
+			return nil, err
+		}
+
+		all = append(all, oneStep...)
+
+		if len(oneStep) < step {
+			// we got all the data
+			break
+		}
+
+		// update start position
+		start += step
+	}
+
+	return all, nil
+}
+
+// listNamespacedResources list the namespaces, workloads, pvs and pvcs in the clusters, the resources that belong to
+// other businesses in a shared cluster are also included, which is the same with the topo path's logic.
+func (k *KubeTopology) listNamespacedResources(ctx context.Context, table string, clusterIDs []int64) (
+	[]*namespacedBase, error) {
+
+	filter := mapstr.MapStr{kubetypes.BKClusterIDFiled: mapstr.MapStr{common.BKDBIN: clusterIDs}}
+	all := make([]*namespacedBase, 0)
+	start := uint64(0)
+	for {
+		oneStep := make([]*namespacedBase, 0)
+		err := k.db.Table(table).Find(filter).Fields(namespacedBaseFields...).Start(start).Limit(step).
+			Sort(kubetypes.BKIDField).All(ctx, &oneStep)
+		if err != nil {
+			blog.Errorf("get %s list failed, cluster ids: %v, err: %v", table, clusterIDs, err)
+			return nil, err
+		}
+
+		all = append(all, oneStep...)
+
+		if len(oneStep) < step {
+			// we got all the data
+			break
+		}
+
+		// update start position
+		start += step
+	}
+
+	return all, nil
+}
+
+// listPods list a business's all pods
+func (k *KubeTopology) listPods(ctx context.Context, biz int64) ([]*podBase, error) {
+	filter := mapstr.MapStr{kubetypes.BKBizIDField: biz}
+	all := make([]*podBase, 0)
+	start := uint64(0)
+	for {
+		oneStep := make([]*podBase, 0)
+		err := k.db.Table(kubetypes.BKTableNameBasePod).Find(filter).Fields(podBaseFields...).Start(start).
+			Limit(step).Sort(kubetypes.BKIDField).All(ctx, &oneStep)
+		if err != nil {
+			blog.Errorf("get biz: %d pod list failed, err: %v", biz, err)
+			return nil, err
+		}
+
+		all = append(all, oneStep...)
+
+		if len(oneStep) < step {
+			// we got all the data
+			break
+		}
+
+		// update start position
+		start += step
+	}
+
+	return all, nil
+}
+
+// listNoPodNodes list a business's nodes that have no pods in the clusters, they are displayed in the folder node.
+func (k *KubeTopology) listNoPodNodes(ctx context.Context, biz int64, clusterIDs []int64) ([]*nodeBase, error) {
+	filter := mapstr.MapStr{
+		kubetypes.BKBizIDField:     biz,
+		kubetypes.BKClusterIDFiled: mapstr.MapStr{common.BKDBIN: clusterIDs},
+		kubetypes.HasPodField:      false,
+	}
+	all := make([]*nodeBase, 0)
+	start := uint64(0)
+	for {
+		oneStep := make([]*nodeBase, 0)
+		err := k.db.Table(kubetypes.BKTableNameBaseNode).Find(filter).Fields(nodeBaseFields...).Start(start).
+			Limit(step).Sort(kubetypes.BKIDField).All(ctx, &oneStep)
+		if err != nil {
+			blog.Errorf("get biz: %d node list failed, err: %v", biz, err)
+			return nil, err
+		}
+
+		all = append(all, oneStep...)
+
+		if len(oneStep) < step {
+			// we got all the data
+			break
+		}
+
+		// update start position
+		start += step
+	}
+
+	return all, nil
+}
+
+// getClusterBizIDs get the business ids of the clusters
+func (k *KubeTopology) getClusterBizIDs(ctx context.Context, clusterIDs []int64) ([]int64, error) {
+	if len(clusterIDs) == 0 {
+		return make([]int64, 0), nil
+	}
+
+	filter := mapstr.MapStr{kubetypes.BKIDField: mapstr.MapStr{common.BKDBIN: clusterIDs}}
+	clusters := make([]*clusterBase, 0)
+	err := k.db.Table(kubetypes.BKTableNameBaseCluster).Find(filter).Fields(clusterBaseFields...).All(ctx, &clusters)
+	if err != nil {
+		blog.Errorf("get clusters failed, ids: %v, err: %v", clusterIDs, err)
+		return nil, err
+	}
+
+	bizIDs := make([]int64, len(clusters))
+	for idx, cluster := range clusters {
+		bizIDs[idx] = cluster.BizID
+	}
+
+	return bizIDs, nil
+}
+
+// listAllBusiness list all business ids
+func (k *KubeTopology) listAllBusiness(ctx context.Context) ([]int64, error) {
+
+	filter := mapstr.MapStr{}
+	all := make([]int64, 0)
+	start := uint64(0)
+	for {
+		oneStep := make([]*bizBase, 0)
+		err := k.db.Table(common.BKTableNameBaseApp).Find(filter).Fields(bizBaseFields...).Start(start).
+			Limit(step).Sort(common.BKAppIDField).All(ctx, &oneStep)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, biz := range oneStep {
+			all = append(all, biz.ID)
+		}
+
+		if len(oneStep) < step {
+			// we got all the data
+			break
+		}
+
+		// update start position
+		start += step
+	}
+
+	return all, nil
+}
+
+func getKubeTopoCacheRefreshMinutes() int {
+	duration, err := configcenter.Int("cacheService.kubeTopologySyncIntervalMinutes")
+	if err != nil {
+		blog.Errorf("get kube topology cache refresh interval minutes failed, err: %v, use default value 15.", err)
+		return defaultRefreshIntervalMinutes
+	}
+
+	if duration < 2 {
+		blog.Warnf("got invalid kube topology cache refresh interval minutes %d, < 2min, use default value 15.",
+			duration)
+		return defaultRefreshIntervalMinutes
+	}
+
+	return duration
+}
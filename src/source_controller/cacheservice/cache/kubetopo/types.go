@@ -0,0 +1,165 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kubetopo
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	kubetypes "configcenter/src/kube/types"
+)
+
+// BizKubeTopology is the brief kube topology of a business, it is organized as cluster -> namespace -> workload
+// -> pod, the folder node and the storage resources are displayed with the namespaces and workloads.
+type BizKubeTopology struct {
+	BizID    int64   `json:"biz"`
+	Clusters []*Node `json:"nds"`
+}
+
+// Node is a brief kube topology node, it contains the basic information of the kube resource and the number of the
+// hosts and pods under it.
+type Node struct {
+	// Kind the kind of this node, like cluster, namespace, the workload kinds, pod, folder, pv and pvc
+	Kind string `json:"kind"`
+	// ID the id of the kube resource, the folder node uses kubetypes.KubeFolderID
+	ID   int64  `json:"id"`
+	Name string `json:"nm"`
+	// HostCount the number of the hosts in the business that the pods under this node are running on, for the
+	// cluster and the folder node, the hosts of the nodes that have no pods are also included.
+	HostCount int64 `json:"host_cnt"`
+	// PodCount the number of the pods in the business under this node
+	PodCount int64   `json:"pod_cnt"`
+	SubNodes []*Node `json:"nds,omitempty"`
+}
+
+// findSubNode find the sub node with the kind and id
+func (n *Node) findSubNode(kind string, id int64) (*Node, bool) {
+	for _, sub := range n.SubNodes {
+		if sub.Kind == kind && sub.ID == id {
+			return sub, true
+		}
+	}
+	return nil, false
+}
+
+// findNode find the node with the kind and id in the topology, the folder node is found by its cluster id.
+func (t *BizKubeTopology) findNode(kind string, id int64) (*Node, bool) {
+	for _, cluster := range t.Clusters {
+		switch kind {
+		case kubetypes.KubeCluster:
+			if cluster.ID == id {
+				return cluster, true
+			}
+			continue
+		case kubetypes.KubeFolder:
+			if cluster.ID == id {
+				return cluster.findSubNode(kubetypes.KubeFolder, kubetypes.KubeFolderID)
+			}
+			continue
+		}
+
+		for _, namespace := range cluster.SubNodes {
+			if namespace.Kind != kubetypes.KubeNamespace {
+				continue
+			}
+
+			if kind == kubetypes.KubeNamespace {
+				if namespace.ID == id {
+					return namespace, true
+				}
+				continue
+			}
+
+			if node, exists := namespace.findSubNode(kind, id); exists {
+				return node, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+var clusterBaseFields = []string{kubetypes.BKIDField, kubetypes.KubeNameField, kubetypes.BKBizIDField}
+
+type clusterBase struct {
+	ID    int64  `bson:"id"`
+	Name  string `bson:"name"`
+	BizID int64  `bson:"bk_biz_id"`
+}
+
+var namespacedBaseFields = []string{kubetypes.BKIDField, kubetypes.KubeNameField, kubetypes.BKBizIDField,
+	kubetypes.BKClusterIDFiled, kubetypes.BKNamespaceIDField}
+
+// namespacedBase is the base information of the namespaces, workloads and the other namespace scoped resources
+type namespacedBase struct {
+	ID          int64  `bson:"id"`
+	Name        string `bson:"name"`
+	BizID       int64  `bson:"bk_biz_id"`
+	ClusterID   int64  `bson:"bk_cluster_id"`
+	NamespaceID int64  `bson:"bk_namespace_id"`
+}
+
+var podBaseFields = []string{kubetypes.BKIDField, kubetypes.KubeNameField, kubetypes.BKClusterIDFiled,
+	kubetypes.BKNamespaceIDField, kubetypes.RefField, common.BKHostIDField}
+
+type podBase struct {
+	ID          int64  `bson:"id"`
+	Name        string `bson:"name"`
+	ClusterID   int64  `bson:"bk_cluster_id"`
+	NamespaceID int64  `bson:"bk_namespace_id"`
+	Ref         podRef `bson:"ref"`
+	HostID      int64  `bson:"bk_host_id"`
+}
+
+type podRef struct {
+	Kind string `bson:"kind"`
+	ID   int64  `bson:"id"`
+}
+
+var nodeBaseFields = []string{kubetypes.BKClusterIDFiled, common.BKHostIDField}
+
+type nodeBase struct {
+	ClusterID int64 `bson:"bk_cluster_id"`
+	HostID    int64 `bson:"bk_host_id"`
+}
+
+// kubeEventBase is the fields of the watched kube resources that are used to find the businesses whose kube
+// topology need to be refreshed.
+type kubeEventBase struct {
+	BizID     int64 `bson:"bk_biz_id"`
+	ClusterID int64 `bson:"bk_cluster_id"`
+}
+
+type kubeEventArchive struct {
+	Oid    string         `bson:"oid"`
+	Detail *kubeEventBase `bson:"detail"`
+}
+
+var bizBaseFields = []string{"bk_biz_id"}
+
+type bizBase struct {
+	ID int64 `bson:"bk_biz_id"`
+}
+
+// page step
+const (
+	step                          = 500
+	defaultRefreshIntervalMinutes = 15
+	// changedBizRefreshInterval is the interval to refresh the kube topology of the businesses changed by events
+	changedBizRefreshInterval = 5 * time.Second
+)
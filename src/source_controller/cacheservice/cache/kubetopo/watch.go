@@ -0,0 +1,171 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kubetopo
+
+import (
+	"context"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+	kubetypes "configcenter/src/kube/types"
+	"configcenter/src/storage/stream/types"
+)
+
+// watchKube watch all the kube resources that are displayed in the kube topology, and refresh the topology of the
+// related businesses when these resources are created, deleted or their hosts are changed.
+func (k *KubeTopology) watchKube() error {
+	watchOpts := &types.WatchOptions{
+		Options: types.Options{
+			EventStruct: new(kubeEventBase),
+			CollectionFilter: map[string]interface{}{
+				common.BKDBIN: append([]string{kubetypes.BKTableNameBaseCluster, kubetypes.BKTableNameBaseNamespace,
+					kubetypes.BKTableNameBaseNode, kubetypes.BKTableNameBasePod,
+					kubetypes.BKTableNameBasePersistentVolume, kubetypes.BKTableNameBasePersistentVolumeClaim},
+					kubetypes.GetWorkLoadTables()...),
+			},
+		},
+	}
+
+	tokenHandler := newTokenHandler("kube")
+	startAtTime, err := tokenHandler.getStartWatchTime(context.Background())
+	if err != nil {
+		blog.Errorf("get start watch time for kube topology failed, err: %v", err)
+		return err
+	}
+	watchOpts.StartAtTime = startAtTime
+	watchOpts.WatchFatalErrorCallback = tokenHandler.resetWatchToken
+
+	loopOptions := &types.LoopBatchOptions{
+		LoopOptions: types.LoopOptions{
+			Name:         "kube topology cache",
+			WatchOpt:     watchOpts,
+			TokenHandler: tokenHandler,
+			RetryOptions: &types.RetryOptions{
+				MaxRetryCount: 10,
+				RetryDuration: 1 * time.Second,
+			},
+		},
+		EventHandler: &types.BatchHandler{
+			DoBatch: k.onKubeChange,
+		},
+		BatchSize: 200,
+	}
+
+	return k.loopW.WithBatch(loopOptions)
+}
+
+func (k *KubeTopology) onKubeChange(es []*types.Event) (retry bool) {
+	if len(es) == 0 {
+		return false
+	}
+
+	rid := es[0].ID()
+	bizList := make([]int64, 0)
+	clusterIDs := make([]int64, 0)
+	for idx := range es {
+		one := es[idx]
+
+		var res *kubeEventBase
+		switch one.OperationType {
+		case types.Insert:
+			res = one.Document.(*kubeEventBase)
+
+		case types.Update:
+			if !isKubeTopoUpdated(one) {
+				continue
+			}
+
+			res = one.Document.(*kubeEventBase)
+
+		case types.Delete:
+			filter := mapstr.MapStr{
+				"oid":  one.Oid,
+				"coll": one.Collection,
+			}
+			archive := new(kubeEventArchive)
+			err := k.db.Table(common.BKTableNameDelArchive).Find(filter).One(context.TODO(), archive)
+			if err != nil {
+				blog.Errorf("kube topology cache, get deleted %s %s failed, err: %v, rid: %s", one.Collection,
+					one.Oid, err, rid)
+				if k.db.IsNotFoundError(err) {
+					blog.Errorf("can not find deleted %s %s detail, skip, rid: %s", one.Collection, one.Oid, rid)
+					continue
+				}
+				return true
+			}
+
+			res = archive.Detail
+
+		default:
+			// only handle insert and delete event.
+			continue
+		}
+
+		if res == nil {
+			continue
+		}
+
+		blog.Infof("kube topology cache, received biz: %d, %s: %s changed event, op-time: %s, rid: %s", res.BizID,
+			one.Collection, one.Oid, one.ClusterTime.String(), rid)
+
+		bizList = append(bizList, res.BizID)
+		// the business of the cluster is also refreshed, because the namespaced resources in a shared cluster may
+		// belong to a business that is different from the cluster's business.
+		if res.ClusterID != 0 {
+			clusterIDs = append(clusterIDs, res.ClusterID)
+		}
+	}
+
+	ctx := context.WithValue(context.Background(), common.ContextRequestIDField, rid)
+	clusterBizIDs, err := k.getClusterBizIDs(ctx, util.IntArrayUnique(clusterIDs))
+	if err != nil {
+		blog.Errorf("get the business of the clusters %v failed, err: %v, rid: %s", clusterIDs, err, rid)
+		return true
+	}
+
+	// the topology is not rebuilt for each event, the changed businesses are refreshed in batch at intervals, so
+	// that the frequent pod changes of a business only cause one rebuild in an interval.
+	k.addChangedBiz(util.IntArrayUnique(append(bizList, clusterBizIDs...)))
+	return false
+}
+
+// isKubeTopoUpdated check if the update event changes the kube topology or its host counts, the nodes without pods
+// are displayed in the folder node and the host counts are calculated by the host of the nodes and pods, so these
+// changes are handled, the other updates do not change the topology.
+func isKubeTopoUpdated(e *types.Event) bool {
+	if e.ChangeDesc == nil {
+		return false
+	}
+
+	switch e.Collection {
+	case kubetypes.BKTableNameBaseNode:
+		if _, exists := e.ChangeDesc.UpdatedFields[kubetypes.HasPodField]; exists {
+			return true
+		}
+		_, exists := e.ChangeDesc.UpdatedFields[common.BKHostIDField]
+		return exists
+	case kubetypes.BKTableNameBasePod:
+		_, exists := e.ChangeDesc.UpdatedFields[common.BKHostIDField]
+		return exists
+	default:
+		return false
+	}
+}
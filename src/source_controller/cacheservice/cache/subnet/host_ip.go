@@ -0,0 +1,163 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package subnet
+
+import (
+	"context"
+	"time"
+
+	"configcenter/src/apimachinery/discovery"
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/dal"
+	"configcenter/src/storage/driver/mongodb"
+	"configcenter/src/storage/stream"
+)
+
+const (
+	// syncAllIntervalHours is the interval hours to sync the ips of all the hosts
+	syncAllIntervalHours = 6
+	// syncAllPageSize is the page size of the hosts when syncing the ips of all the hosts
+	syncAllPageSize = 1000
+)
+
+// NewHostIPSyncer new the subnet host ip syncer, it watches the hosts to sync the inner ips of the changed hosts to
+// the subnet host ip table, and syncs the ips of all the hosts at intervals to keep the table consistent with the db.
+func NewHostIPSyncer(isMaster discovery.ServiceManageInterface, loopW stream.LoopInterface) error {
+	s := &hostIPSyncer{
+		db:          mongodb.Client(),
+		loopW:       loopW,
+		checkMaster: isMaster,
+	}
+
+	if err := s.watchHost(); err != nil {
+		blog.Errorf("subnet host ip syncer watch host failed, err: %v", err)
+		return err
+	}
+
+	go s.loopSyncAllHostIPs()
+
+	return nil
+}
+
+// hostIPSyncer syncs the inner ips of the hosts to the subnet host ip table, so that the subnets can find their
+// hosts by the ip range instead of scanning all the hosts of the cloud area.
+type hostIPSyncer struct {
+	db          dal.DB
+	loopW       stream.LoopInterface
+	checkMaster discovery.ServiceManageInterface
+}
+
+// syncHostIPs sync the ips of the hosts, the ips of the hosts that are deleted are removed.
+func (s *hostIPSyncer) syncHostIPs(ctx context.Context, hostIDs []int64) error {
+	filter := map[string]interface{}{
+		common.BKHostIDField: map[string]interface{}{common.BKDBIN: hostIDs},
+	}
+
+	hosts := make([]hostIPBase, 0)
+	err := s.db.Table(common.BKTableNameBaseHost).Find(filter).Fields(hostIPFields...).All(ctx, &hosts)
+	if err != nil {
+		return err
+	}
+
+	return s.replaceHostIPs(ctx, filter, hosts)
+}
+
+// replaceHostIPs replace the subnet host ips that match the filter with the ips of the hosts
+func (s *hostIPSyncer) replaceHostIPs(ctx context.Context, filter map[string]interface{}, hosts []hostIPBase) error {
+	if err := s.db.Table(common.BKTableNameSubnetHostIP).Delete(ctx, filter); err != nil {
+		return err
+	}
+
+	hostIPs := make([]interface{}, 0)
+	for idx := range hosts {
+		for _, hostIP := range hosts[idx].subnetHostIPs() {
+			hostIPs = append(hostIPs, hostIP)
+		}
+	}
+
+	if len(hostIPs) == 0 {
+		return nil
+	}
+
+	return s.db.Table(common.BKTableNameSubnetHostIP).Insert(ctx, hostIPs)
+}
+
+// loopSyncAllHostIPs sync the ips of all the hosts when the syncer starts and every interval hours, so that the
+// existing hosts and the changes that are missed by the watch can be synced.
+func (s *hostIPSyncer) loopSyncAllHostIPs() {
+	blog.Infof("loop sync all subnet host ips every %d hours.", syncAllIntervalHours)
+	for {
+		if !s.checkMaster.IsMaster() {
+			blog.V(4).Infof("loop sync all subnet host ips, but not master, skip.")
+			time.Sleep(time.Minute)
+			continue
+		}
+
+		rid := util.GenerateRID()
+		blog.Infof("start loop sync all subnet host ips task, rid: %s", rid)
+		if err := s.syncAllHostIPs(rid); err != nil {
+			blog.Errorf("loop sync all subnet host ips failed, err: %v, rid: %s", err, rid)
+			time.Sleep(time.Minute)
+			continue
+		}
+		blog.Infof("finished loop sync all subnet host ips task, rid: %s", rid)
+
+		time.Sleep(syncAllIntervalHours * time.Hour)
+	}
+}
+
+// syncAllHostIPs sync the ips of all the hosts page by page, the subnet host ips in the host id range of each page
+// are replaced, so that the ips of the hosts that are deleted are removed too.
+func (s *hostIPSyncer) syncAllHostIPs(rid string) error {
+	ctx := context.WithValue(context.Background(), common.ContextRequestIDField, rid)
+
+	lastHostID := int64(0)
+	for {
+		filter := map[string]interface{}{
+			common.BKHostIDField: map[string]interface{}{common.BKDBGT: lastHostID},
+		}
+
+		hosts := make([]hostIPBase, 0)
+		err := s.db.Table(common.BKTableNameBaseHost).Find(filter).Fields(hostIPFields...).
+			Sort(common.BKHostIDField).Limit(syncAllPageSize).All(ctx, &hosts)
+		if err != nil {
+			blog.Errorf("list hosts after host id %d failed, err: %v, rid: %s", lastHostID, err, rid)
+			return err
+		}
+
+		idFilter := map[string]interface{}{common.BKDBGT: lastHostID}
+		if len(hosts) == syncAllPageSize {
+			idFilter[common.BKDBLTE] = hosts[len(hosts)-1].HostID
+		}
+
+		rangeFilter := map[string]interface{}{common.BKHostIDField: idFilter}
+		if err := s.replaceHostIPs(ctx, rangeFilter, hosts); err != nil {
+			blog.Errorf("sync subnet host ips in host id range %v failed, err: %v, rid: %s", idFilter, err, rid)
+			return err
+		}
+
+		if len(hosts) < syncAllPageSize {
+			return nil
+		}
+
+		lastHostID = hosts[len(hosts)-1].HostID
+		time.Sleep(50 * time.Millisecond)
+	}
+}
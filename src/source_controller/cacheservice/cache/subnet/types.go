@@ -0,0 +1,48 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package subnet
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/metadata"
+)
+
+// hostIPBase is the fields of the host that are synced to the subnet host ips
+type hostIPBase struct {
+	HostID    int64                        `bson:"bk_host_id"`
+	CloudID   int64                        `bson:"bk_cloud_id"`
+	InnerIP   metadata.StringArrayToString `bson:"bk_host_innerip"`
+	InnerIPv6 metadata.StringArrayToString `bson:"bk_host_innerip_v6"`
+	OwnerID   string                       `bson:"bk_supplier_account"`
+}
+
+// subnetHostIPs returns the subnet host ips of the host
+func (h *hostIPBase) subnetHostIPs() []metadata.SubnetHostIP {
+	return metadata.NewSubnetHostIPs(h.HostID, h.CloudID, h.OwnerID, map[string]interface{}{
+		common.BKHostInnerIPField:   string(h.InnerIP),
+		common.BKHostInnerIPv6Field: string(h.InnerIPv6),
+	})
+}
+
+type hostIPArchive struct {
+	Oid    string      `bson:"oid"`
+	Detail *hostIPBase `bson:"detail"`
+}
+
+var hostIPFields = []string{common.BKHostIDField, common.BKCloudIDField, common.BKHostInnerIPField,
+	common.BKHostInnerIPv6Field, common.BkSupplierAccount}
@@ -0,0 +1,152 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package subnet
+
+import (
+	"context"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/stream/types"
+)
+
+// hostIPChangeFields is the host fields whose change need to sync the ips of the host
+var hostIPChangeFields = []string{common.BKCloudIDField, common.BKHostInnerIPField, common.BKHostInnerIPv6Field}
+
+// watchHost watch the hosts, and sync the ips of the hosts that are created, deleted or whose ips are changed.
+func (s *hostIPSyncer) watchHost() error {
+	watchOpts := &types.WatchOptions{
+		Options: types.Options{
+			EventStruct: new(hostIPBase),
+			Collection:  common.BKTableNameBaseHost,
+		},
+	}
+
+	tokenHandler := newTokenHandler("host")
+	startAtTime, err := tokenHandler.getStartWatchTime(context.Background())
+	if err != nil {
+		blog.Errorf("get start watch time for subnet host ip failed, err: %v", err)
+		return err
+	}
+	watchOpts.StartAtTime = startAtTime
+	watchOpts.WatchFatalErrorCallback = tokenHandler.resetWatchToken
+
+	loopOptions := &types.LoopBatchOptions{
+		LoopOptions: types.LoopOptions{
+			Name:         "subnet host ip",
+			WatchOpt:     watchOpts,
+			TokenHandler: tokenHandler,
+			RetryOptions: &types.RetryOptions{
+				MaxRetryCount: 10,
+				RetryDuration: 1 * time.Second,
+			},
+		},
+		EventHandler: &types.BatchHandler{
+			DoBatch: s.onHostChange,
+		},
+		BatchSize: 200,
+	}
+
+	return s.loopW.WithBatch(loopOptions)
+}
+
+func (s *hostIPSyncer) onHostChange(es []*types.Event) (retry bool) {
+	if len(es) == 0 {
+		return false
+	}
+
+	rid := es[0].ID()
+	hostIDs := make([]int64, 0)
+	for idx := range es {
+		one := es[idx]
+
+		var host *hostIPBase
+		switch one.OperationType {
+		case types.Insert:
+			host = one.Document.(*hostIPBase)
+
+		case types.Update:
+			if !isHostIPChanged(one.ChangeDesc) {
+				continue
+			}
+			host = one.Document.(*hostIPBase)
+
+		case types.Delete:
+			filter := mapstr.MapStr{
+				"oid":  one.Oid,
+				"coll": common.BKTableNameBaseHost,
+			}
+			archive := new(hostIPArchive)
+			err := s.db.Table(common.BKTableNameDelArchive).Find(filter).One(context.TODO(), archive)
+			if err != nil {
+				blog.Errorf("subnet host ip, get deleted host %s failed, err: %v, rid: %s", one.Oid, err, rid)
+				if s.db.IsNotFoundError(err) {
+					blog.Errorf("can not find deleted host %s detail, skip, rid: %s", one.Oid, rid)
+					continue
+				}
+				return true
+			}
+			host = archive.Detail
+
+		default:
+			continue
+		}
+
+		if host == nil || host.HostID == 0 {
+			continue
+		}
+
+		blog.V(4).Infof("subnet host ip, received host %d %s event, op-time: %s, rid: %s", host.HostID,
+			one.OperationType, one.ClusterTime.String(), rid)
+		hostIDs = append(hostIDs, host.HostID)
+	}
+
+	if len(hostIDs) == 0 {
+		return false
+	}
+
+	ctx := context.WithValue(context.Background(), common.ContextRequestIDField, rid)
+	if err := s.syncHostIPs(ctx, util.IntArrayUnique(hostIDs)); err != nil {
+		blog.Errorf("sync subnet host ips of hosts %v failed, err: %v, rid: %s", hostIDs, err, rid)
+		return true
+	}
+
+	return false
+}
+
+// isHostIPChanged checks if the cloud area or the inner ips of the host is updated or removed
+func isHostIPChanged(desc *types.ChangeDescription) bool {
+	if desc == nil {
+		return true
+	}
+
+	for _, field := range hostIPChangeFields {
+		if _, exists := desc.UpdatedFields[field]; exists {
+			return true
+		}
+
+		if util.InStrArr(desc.RemovedFields, field) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -118,6 +118,27 @@ func NewEvent(watch stream.LoopInterface, isMaster discovery.ServiceManageInterf
 		blog.Errorf("run kube pod event flow failed, err: %v", err)
 		return err
 	}
+
+	if err := e.runHostTransferSchedule(context.Background()); err != nil {
+		blog.Errorf("run host transfer schedule event flow failed, err: %v", err)
+		return err
+	}
+
+	if err := e.runKubeService(context.Background()); err != nil {
+		blog.Errorf("run kube service event flow failed, err: %v", err)
+		return err
+	}
+
+	if err := e.runKubeIngress(context.Background()); err != nil {
+		blog.Errorf("run kube ingress event flow failed, err: %v", err)
+		return err
+	}
+
+	if err := e.runKubeEndpoint(context.Background()); err != nil {
+		blog.Errorf("run kube endpoint event flow failed, err: %v", err)
+		return err
+	}
+
 	gc := &gc{
 		ccDB:     ccDB,
 		isMaster: isMaster,
@@ -342,3 +363,55 @@ func (e *Event) runKubePod(ctx context.Context) error {
 
 	return newFlow(ctx, opts, getDeleteEventDetails, parsePodEvent)
 }
+
+func (e *Event) runHostTransferSchedule(ctx context.Context) error {
+	opts := flowOptions{
+		key:         event.HostTransferScheduleKey,
+		watch:       e.watch,
+		watchDB:     e.watchDB,
+		ccDB:        e.ccDB,
+		isMaster:    e.isMaster,
+		EventStruct: new(map[string]interface{}),
+	}
+
+	return newFlow(ctx, opts, getDeleteEventDetails, parseEvent)
+}
+
+func (e *Event) runKubeService(ctx context.Context) error {
+	opts := flowOptions{
+		key:         event.KubeServiceKey,
+		watch:       e.watch,
+		watchDB:     e.watchDB,
+		ccDB:        e.ccDB,
+		isMaster:    e.isMaster,
+		EventStruct: new(map[string]interface{}),
+	}
+
+	return newFlow(ctx, opts, getDeleteEventDetails, parseEvent)
+}
+
+func (e *Event) runKubeIngress(ctx context.Context) error {
+	opts := flowOptions{
+		key:         event.KubeIngressKey,
+		watch:       e.watch,
+		watchDB:     e.watchDB,
+		ccDB:        e.ccDB,
+		isMaster:    e.isMaster,
+		EventStruct: new(map[string]interface{}),
+	}
+
+	return newFlow(ctx, opts, getDeleteEventDetails, parseEvent)
+}
+
+func (e *Event) runKubeEndpoint(ctx context.Context) error {
+	opts := flowOptions{
+		key:         event.KubeEndpointKey,
+		watch:       e.watch,
+		watchDB:     e.watchDB,
+		ccDB:        e.ccDB,
+		isMaster:    e.isMaster,
+		EventStruct: new(map[string]interface{}),
+	}
+
+	return newFlow(ctx, opts, getDeleteEventDetails, parseEvent)
+}
@@ -23,6 +23,7 @@ import (
 
 	"configcenter/src/common"
 	"configcenter/src/common/blog"
+	"configcenter/src/common/json"
 	kubetypes "configcenter/src/kube/types"
 	"configcenter/src/storage/stream/types"
 )
@@ -96,3 +97,117 @@ func (f *WorkloadFlow) RunFlow(ctx context.Context) error {
 
 	return nil
 }
+
+// doBatch record the replica history of the workloads whose replica count is changed, then handle the events.
+func (f *WorkloadFlow) doBatch(es []*types.Event) (retry bool) {
+	if len(es) == 0 {
+		return false
+	}
+
+	if retry := f.recordReplicaHistory(es); retry {
+		return true
+	}
+
+	return f.Flow.doBatch(es)
+}
+
+// recordReplicaHistory record the replica history of the workloads in the events, the id of the event is used as
+// the unique key of the history, so the histories that are already recorded are skipped when the events are retried.
+func (f *WorkloadFlow) recordReplicaHistory(es []*types.Event) (retry bool) {
+	rid := es[0].ID()
+
+	histories := make([]kubetypes.WorkloadReplicaHistory, 0)
+	for _, e := range es {
+		history, ok := parseReplicaHistory(e, rid)
+		if !ok {
+			continue
+		}
+		histories = append(histories, history)
+	}
+
+	if len(histories) == 0 {
+		return false
+	}
+
+	ids, err := f.ccDB.NextSequences(context.Background(), kubetypes.BKTableNameWorkloadReplicaHistory,
+		len(histories))
+	if err != nil {
+		blog.Errorf("get workload replica history ids failed, err: %v, rid: %s", err, rid)
+		return true
+	}
+
+	for idx := range histories {
+		histories[idx].ID = int64(ids[idx])
+		err := f.ccDB.Table(kubetypes.BKTableNameWorkloadReplicaHistory).Insert(context.Background(), histories[idx])
+		if err != nil {
+			if f.ccDB.IsDuplicatedError(err) {
+				continue
+			}
+			blog.Errorf("add workload replica history failed, data: %+v, err: %v, rid: %s", histories[idx], err, rid)
+			return true
+		}
+	}
+
+	return false
+}
+
+// replicaHistoryWorkload is the workload fields that are needed to record the replica history
+type replicaHistoryWorkload struct {
+	kubetypes.WorkloadBase `json:",inline"`
+	Replicas               *int64 `json:"replicas"`
+}
+
+// parseReplicaHistory parse the replica history from the workload event, returns false if the event does not set
+// or change the replica count of a workload that has replica history.
+func parseReplicaHistory(e *types.Event, rid string) (kubetypes.WorkloadReplicaHistory, bool) {
+	var kind kubetypes.WorkloadType
+	switch e.Collection {
+	case kubetypes.BKTableNameBaseDeployment:
+		kind = kubetypes.KubeDeployment
+	case kubetypes.BKTableNameBaseStatefulSet:
+		kind = kubetypes.KubeStatefulSet
+	default:
+		return kubetypes.WorkloadReplicaHistory{}, false
+	}
+
+	switch e.OperationType {
+	case types.Insert, types.Replace:
+	case types.Update:
+		if e.ChangeDesc == nil {
+			return kubetypes.WorkloadReplicaHistory{}, false
+		}
+		if _, changed := e.ChangeDesc.UpdatedFields[kubetypes.ReplicasField]; !changed {
+			return kubetypes.WorkloadReplicaHistory{}, false
+		}
+	default:
+		return kubetypes.WorkloadReplicaHistory{}, false
+	}
+
+	workload := new(replicaHistoryWorkload)
+	if err := json.Unmarshal(e.DocBytes, workload); err != nil {
+		blog.Errorf("unmarshal workload event doc failed, doc: %s, err: %v, rid: %s", e.DocBytes, err, rid)
+		return kubetypes.WorkloadReplicaHistory{}, false
+	}
+
+	if workload.Replicas == nil {
+		return kubetypes.WorkloadReplicaHistory{}, false
+	}
+
+	changeTime := time.Unix(int64(e.ClusterTime.Sec), 0).UTC()
+	history := kubetypes.WorkloadReplicaHistory{
+		WorkloadSpec: kubetypes.WorkloadSpec{
+			NamespaceSpec: workload.NamespaceSpec,
+			Ref: kubetypes.Reference{
+				Kind: kind,
+				Name: workload.Name,
+				ID:   workload.ID,
+			},
+		},
+		Replicas:        *workload.Replicas,
+		Time:            changeTime.Unix(),
+		EventID:         e.ID(),
+		SupplierAccount: workload.SupplierAccount,
+		ExpireAt:        changeTime.AddDate(0, 0, kubetypes.ReplicaHistoryRetentionDays),
+	}
+	return history, true
+}
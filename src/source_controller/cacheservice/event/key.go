@@ -466,6 +466,99 @@ var KubePodKey = Key{
 	},
 }
 
+// KubeServiceKey kube service event watch key
+var KubeServiceKey = Key{
+	namespace:  watchCacheNamespace + kubetypes.KubeService,
+	collection: kubetypes.BKTableNameBaseService,
+	ttlSeconds: 6 * 60 * 60,
+	validator: func(doc []byte) error {
+		fields := gjson.GetManyBytes(doc, kubeFields...)
+		for idx := range kubeFields {
+			if !fields[idx].Exists() {
+				return fmt.Errorf("field %s not exist", kubeFields[idx])
+			}
+		}
+		return nil
+	},
+	instName: func(doc []byte) string {
+		return gjson.GetBytes(doc, common.BKFieldName).String()
+	},
+	instID: func(doc []byte) int64 {
+		return gjson.GetBytes(doc, common.BKFieldID).Int()
+	},
+}
+
+// KubeIngressKey kube ingress event watch key
+var KubeIngressKey = Key{
+	namespace:  watchCacheNamespace + kubetypes.KubeIngress,
+	collection: kubetypes.BKTableNameBaseIngress,
+	ttlSeconds: 6 * 60 * 60,
+	validator: func(doc []byte) error {
+		fields := gjson.GetManyBytes(doc, kubeFields...)
+		for idx := range kubeFields {
+			if !fields[idx].Exists() {
+				return fmt.Errorf("field %s not exist", kubeFields[idx])
+			}
+		}
+		return nil
+	},
+	instName: func(doc []byte) string {
+		return gjson.GetBytes(doc, common.BKFieldName).String()
+	},
+	instID: func(doc []byte) int64 {
+		return gjson.GetBytes(doc, common.BKFieldID).Int()
+	},
+}
+
+// kubeEndpointFields kube endpoint id and pod name fields, used for validation, endpoint has no name of its own
+var kubeEndpointFields = []string{common.BKFieldID, kubetypes.PodNameField}
+
+// KubeEndpointKey kube endpoint event watch key
+var KubeEndpointKey = Key{
+	namespace:  watchCacheNamespace + kubetypes.KubeEndpoint,
+	collection: kubetypes.BKTableNameBaseEndpoint,
+	ttlSeconds: 6 * 60 * 60,
+	validator: func(doc []byte) error {
+		fields := gjson.GetManyBytes(doc, kubeEndpointFields...)
+		for idx := range kubeEndpointFields {
+			if !fields[idx].Exists() {
+				return fmt.Errorf("field %s not exist", kubeEndpointFields[idx])
+			}
+		}
+		return nil
+	},
+	instName: func(doc []byte) string {
+		return gjson.GetBytes(doc, kubetypes.PodNameField).String()
+	},
+	instID: func(doc []byte) int64 {
+		return gjson.GetBytes(doc, common.BKFieldID).Int()
+	},
+}
+
+var hostTransferScheduleFields = []string{common.BKFieldID, common.BKAppIDField, common.BKStatusField}
+
+// HostTransferScheduleKey scheduled host transfer event watch key
+var HostTransferScheduleKey = Key{
+	namespace:  watchCacheNamespace + "host_transfer_schedule",
+	collection: common.BKTableNameHostTransferSchedule,
+	ttlSeconds: 6 * 60 * 60,
+	validator: func(doc []byte) error {
+		fields := gjson.GetManyBytes(doc, hostTransferScheduleFields...)
+		for idx := range hostTransferScheduleFields {
+			if !fields[idx].Exists() {
+				return fmt.Errorf("field %s not exist", hostTransferScheduleFields[idx])
+			}
+		}
+		return nil
+	},
+	instName: func(doc []byte) string {
+		return gjson.GetBytes(doc, common.BKStatusField).String()
+	},
+	instID: func(doc []byte) int64 {
+		return gjson.GetBytes(doc, common.BKFieldID).Int()
+	},
+}
+
 // Key TODO
 type Key struct {
 	namespace string
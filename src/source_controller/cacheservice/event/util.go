@@ -64,6 +64,14 @@ func GetResourceKeyWithCursorType(res watch.CursorType) (Key, error) {
 		key = KubeWorkloadKey
 	case watch.KubePod:
 		key = KubePodKey
+	case watch.HostTransferSchedule:
+		key = HostTransferScheduleKey
+	case watch.KubeService:
+		key = KubeServiceKey
+	case watch.KubeIngress:
+		key = KubeIngressKey
+	case watch.KubeEndpoint:
+		key = KubeEndpointKey
 	default:
 		return key, fmt.Errorf("unsupported cursor type %s", res)
 	}
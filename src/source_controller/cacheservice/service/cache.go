@@ -22,6 +22,7 @@ import (
 	"configcenter/src/common/metadata"
 	"configcenter/src/common/util"
 	"configcenter/src/common/watch"
+	kubetypes "configcenter/src/kube/types"
 	"configcenter/src/source_controller/cacheservice/cache/topotree"
 	"configcenter/src/source_controller/cacheservice/event"
 )
@@ -84,12 +85,12 @@ func (s *cacheService) ListHostWithPageInCache(ctx *rest.Contexts) {
 		return
 	}
 
-	cnt, host, err := s.cacheSet.Host.ListHostsWithPage(ctx.Kit.Ctx, opt)
+	cnt, host, nextCursor, err := s.cacheSet.Host.ListHostsWithPage(ctx.Kit.Ctx, opt)
 	if err != nil {
 		ctx.RespErrorCodeOnly(common.CCErrCommDBSelectFailed, "list host with id in cache, but get host failed, err: %v", err)
 		return
 	}
-	ctx.RespCountInfoString(cnt, host)
+	ctx.RespCursorCountInfoString(cnt, host, nextCursor)
 }
 
 // ListBusinessInCache list business with id from cache, if not exist in cache, then get from mongodb directly.
@@ -257,6 +258,69 @@ func (s *cacheService) SearchBusinessBriefTopology(ctx *rest.Contexts) {
 	ctx.RespString(topo)
 }
 
+// SearchKubeTopoPath search the next level nodes of the kube topology node in cache
+func (s *cacheService) SearchKubeTopoPath(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		blog.Errorf("failed to parse the biz id, err: %v, rid: %s", err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	opt := new(kubetypes.KubeTopoPathOption)
+	if err := ctx.DecodeInto(opt); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+		blog.Errorf("validate request failed, err: %v, rid: %s", rawErr, ctx.Kit.Rid)
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.cacheSet.KubeTopo.SearchKubeTopoPath(ctx.Kit, bizID, opt)
+	if err != nil {
+		blog.Errorf("search kube topo path in cache failed, opt: %+v, err: %v, rid: %s", opt, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// CountKubeTopoHostsOrPods count the number of hosts or pods of the kube topology nodes in cache
+func (s *cacheService) CountKubeTopoHostsOrPods(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		blog.Errorf("failed to parse the biz id, err: %v, rid: %s", err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	opt := new(kubetypes.KubeTopoCountOption)
+	if err := ctx.DecodeInto(opt); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+		blog.Errorf("validate request failed, err: %v, rid: %s", rawErr, ctx.Kit.Rid)
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	kind := ctx.Request.PathParameter("type")
+	result, err := s.cacheSet.KubeTopo.CountKubeTopoHostsOrPods(ctx.Kit, bizID, kind, opt)
+	if err != nil {
+		blog.Errorf("count kube topo %s in cache failed, opt: %+v, err: %v, rid: %s", kind, opt, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
 // WatchEvent TODO
 func (s *cacheService) WatchEvent(ctx *rest.Contexts) {
 	var err error
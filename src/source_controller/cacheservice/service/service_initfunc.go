@@ -90,6 +90,16 @@ func (s *cacheService) initCache(web *restful.WebService) {
 		Path:    "/find/cache/topo/brief/biz/{biz}",
 		Handler: s.SearchBusinessBriefTopology,
 	})
+	utility.AddHandler(rest.Action{
+		Verb:    http.MethodPost,
+		Path:    "/find/cache/kube/topo_path/bk_biz_id/{bk_biz_id}",
+		Handler: s.SearchKubeTopoPath,
+	})
+	utility.AddHandler(rest.Action{
+		Verb:    http.MethodPost,
+		Path:    "/find/cache/kube/topo_node/{type}/count/bk_biz_id/{bk_biz_id}",
+		Handler: s.CountKubeTopoHostsOrPods,
+	})
 	utility.AddHandler(rest.Action{
 		Verb:    http.MethodPost,
 		Path:    "/watch/cache/event",
@@ -0,0 +1,233 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.,
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the ",License",); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an ",AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloud
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/lock"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/driver/redis"
+)
+
+// CreateSubnet create a subnet in the cloud area, the subnet can not overlap with the other subnets of the cloud area
+func (c *cloudOperation) CreateSubnet(kit *rest.Kit, subnet *metadata.Subnet) (*metadata.Subnet, errors.CCErrorCoder) {
+	if rawErr := subnet.Validate(); rawErr.ErrCode != 0 {
+		return nil, rawErr.ToCCError(kit.CCError)
+	}
+
+	// lock the cloud area to avoid creating overlapped subnets concurrently
+	locker := lock.NewLocker(redis.Client())
+	locked, lockErr := locker.Lock(lock.GetLockKey(lock.CreateSubnetFormat, subnet.CloudID), 10*time.Second)
+	defer locker.Unlock()
+	if lockErr != nil {
+		blog.Errorf("get create subnet lock of cloud area %d failed, err: %v, rid: %s", subnet.CloudID, lockErr, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommRedisOPErr)
+	}
+	if !locked {
+		blog.Errorf("create subnet in cloud area %d has another task in progress, rid: %s", subnet.CloudID, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommOPInProgressErr,
+			fmt.Sprintf("create subnet in cloud area %d", subnet.CloudID))
+	}
+
+	ipNet, _ := subnet.IPNet()
+	existSubnets, err := c.listCloudSubnets(kit, []int64{subnet.CloudID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, exist := range existSubnets {
+		existNet, parseErr := exist.IPNet()
+		if parseErr != nil {
+			blog.Errorf("parse subnet %d cidr %s failed, err: %v, rid: %s", exist.SubnetID, exist.CIDR, parseErr,
+				kit.Rid)
+			continue
+		}
+
+		if existNet.Contains(ipNet.IP) || ipNet.Contains(existNet.IP) {
+			return nil, kit.CCError.CCErrorf(common.CCErrCoreServiceSubnetOverlap, subnet.CIDR, exist.CIDR)
+		}
+	}
+
+	id, dbErr := c.dbProxy.NextSequence(kit.Ctx, common.BKTableNameSubnet)
+	if dbErr != nil {
+		blog.Errorf("generate subnet id failed, err: %v, rid: %s", dbErr, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommGenerateRecordIDFailed)
+	}
+
+	now := time.Now()
+	subnet.SubnetID = int64(id)
+	subnet.OwnerID = kit.SupplierAccount
+	subnet.Creator = kit.User
+	subnet.LastEditor = kit.User
+	subnet.CreateTime = now
+	subnet.LastTime = now
+
+	if dbErr := c.dbProxy.Table(common.BKTableNameSubnet).Insert(kit.Ctx, subnet); dbErr != nil {
+		blog.Errorf("insert subnet failed, subnet: %+v, err: %v, rid: %s", subnet, dbErr, kit.Rid)
+		if c.dbProxy.IsDuplicatedError(dbErr) {
+			return nil, kit.CCError.CCErrorf(common.CCErrCommDuplicateItem, subnet.CIDR)
+		}
+		return nil, kit.CCError.CCError(common.CCErrCommDBInsertFailed)
+	}
+
+	return subnet, nil
+}
+
+// SearchSubnet search the subnets by cloud area ids or subnet ids
+func (c *cloudOperation) SearchSubnet(kit *rest.Kit, option *metadata.SearchSubnetOption) (*metadata.MultipleSubnet,
+	errors.CCErrorCoder) {
+
+	cond := subnetCondition(kit, option.CloudIDs, option.SubnetIDs)
+	count, err := c.dbProxy.Table(common.BKTableNameSubnet).Find(cond).Count(kit.Ctx)
+	if err != nil {
+		blog.Errorf("count subnets failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	sort := option.Page.Sort
+	if len(sort) == 0 {
+		sort = common.BKSubnetIDField
+	}
+
+	subnets := make([]metadata.Subnet, 0)
+	err = c.dbProxy.Table(common.BKTableNameSubnet).Find(cond).Sort(sort).Start(uint64(option.Page.Start)).
+		Limit(uint64(option.Page.Limit)).All(kit.Ctx, &subnets)
+	if err != nil {
+		blog.Errorf("search subnets failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	return &metadata.MultipleSubnet{Count: int64(count), Info: subnets}, nil
+}
+
+// UpdateSubnet update the vlan, gateway or description of the subnet
+func (c *cloudOperation) UpdateSubnet(kit *rest.Kit, subnetID int64, option *metadata.UpdateSubnetOption) errors.
+	CCErrorCoder {
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		return rawErr.ToCCError(kit.CCError)
+	}
+
+	subnet, ipNet, err := c.getSubnet(kit, subnetID)
+	if err != nil {
+		return err
+	}
+
+	data := mapstr.MapStr{
+		common.BKLastEditor:  kit.User,
+		common.LastTimeField: time.Now(),
+	}
+
+	if option.VlanID != nil {
+		data[common.BKSubnetVlanIDField] = *option.VlanID
+	}
+
+	if option.Description != nil {
+		data[common.BKDescriptionField] = *option.Description
+	}
+
+	if option.Gateway != nil {
+		gateway := ""
+		if len(*option.Gateway) > 0 {
+			ip := net.ParseIP(*option.Gateway)
+			if ip == nil || !metadata.IsSubnetAssignableIP(ipNet, ip) {
+				return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKSubnetGatewayField)
+			}
+			gateway = ip.String()
+		}
+		data[common.BKSubnetGatewayField] = gateway
+	}
+
+	cond := subnetCondition(kit, nil, []int64{subnet.SubnetID})
+	if err := c.dbProxy.Table(common.BKTableNameSubnet).Update(kit.Ctx, cond, data); err != nil {
+		blog.Errorf("update subnet failed, cond: %v, data: %v, err: %v, rid: %s", cond, data, err, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
+	}
+
+	return nil
+}
+
+// DeleteSubnet delete the subnet and its ip reservations
+func (c *cloudOperation) DeleteSubnet(kit *rest.Kit, subnetID int64) errors.CCErrorCoder {
+	cond := subnetCondition(kit, nil, []int64{subnetID})
+	if err := c.dbProxy.Table(common.BKTableNameSubnetIPReservation).Delete(kit.Ctx, cond); err != nil {
+		blog.Errorf("delete subnet %d ip reservations failed, err: %v, rid: %s", subnetID, err, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBDeleteFailed)
+	}
+
+	if err := c.dbProxy.Table(common.BKTableNameSubnet).Delete(kit.Ctx, cond); err != nil {
+		blog.Errorf("delete subnet %d failed, err: %v, rid: %s", subnetID, err, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBDeleteFailed)
+	}
+
+	return nil
+}
+
+// subnetCondition returns the condition of the subnets or the subnet ip reservations in the cloud areas or subnets
+func subnetCondition(kit *rest.Kit, cloudIDs, subnetIDs []int64) map[string]interface{} {
+	cond := make(map[string]interface{})
+	if len(cloudIDs) > 0 {
+		cond[common.BKCloudIDField] = map[string]interface{}{common.BKDBIN: cloudIDs}
+	}
+
+	if len(subnetIDs) > 0 {
+		cond[common.BKSubnetIDField] = map[string]interface{}{common.BKDBIN: subnetIDs}
+	}
+	return util.SetQueryOwner(cond, kit.SupplierAccount)
+}
+
+// getSubnet get the subnet and its parsed cidr by id
+func (c *cloudOperation) getSubnet(kit *rest.Kit, subnetID int64) (*metadata.Subnet, *net.IPNet,
+	errors.CCErrorCoder) {
+
+	subnet := new(metadata.Subnet)
+	cond := subnetCondition(kit, nil, []int64{subnetID})
+	if err := c.dbProxy.Table(common.BKTableNameSubnet).Find(cond).One(kit.Ctx, subnet); err != nil {
+		if c.dbProxy.IsNotFoundError(err) {
+			return nil, nil, kit.CCError.CCErrorf(common.CCErrCommNotFound)
+		}
+		blog.Errorf("get subnet %d failed, err: %v, rid: %s", subnetID, err, kit.Rid)
+		return nil, nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	ipNet, err := subnet.IPNet()
+	if err != nil {
+		blog.Errorf("parse subnet %d cidr %s failed, err: %v, rid: %s", subnetID, subnet.CIDR, err, kit.Rid)
+		return nil, nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKSubnetCIDRField)
+	}
+
+	return subnet, ipNet, nil
+}
+
+// listCloudSubnets list all the subnets in the cloud areas, or the specified subnets in the cloud areas
+func (c *cloudOperation) listCloudSubnets(kit *rest.Kit, cloudIDs, subnetIDs []int64) ([]metadata.Subnet,
+	errors.CCErrorCoder) {
+
+	subnets := make([]metadata.Subnet, 0)
+	cond := subnetCondition(kit, cloudIDs, subnetIDs)
+	err := c.dbProxy.Table(common.BKTableNameSubnet).Find(cond).Sort(common.BKSubnetIDField).All(kit.Ctx, &subnets)
+	if err != nil {
+		blog.Errorf("list subnets failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+	return subnets, nil
+}
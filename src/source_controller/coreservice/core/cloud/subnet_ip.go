@@ -0,0 +1,434 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.,
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the ",License",); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an ",AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloud
+
+import (
+	"net"
+	"sort"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+)
+
+const (
+	// subnetAllocateMaxRetry is the max times to retry when the allocated ips are reserved concurrently
+	subnetAllocateMaxRetry = 3
+)
+
+// listSubnetHostIPs returns the ips of the hosts in the cloud area that match the ip filter and the hosts that use
+// the ip, the ips are read from the subnet host ips that are synced from the host events by the cache service.
+func (c *cloudOperation) listSubnetHostIPs(kit *rest.Kit, cloudID int64, ipFilter map[string]interface{}) (
+	map[string][]int64, errors.CCErrorCoder) {
+
+	cond := map[string]interface{}{common.BKCloudIDField: cloudID}
+	for key, value := range ipFilter {
+		cond[key] = value
+	}
+	cond = util.SetQueryOwner(cond, kit.SupplierAccount)
+
+	hostIPs := make([]metadata.SubnetHostIP, 0)
+	err := c.dbProxy.Table(common.BKTableNameSubnetHostIP).Find(cond).
+		Fields(common.BKHostIDField, common.BKSubnetIPField).All(kit.Ctx, &hostIPs)
+	if err != nil {
+		blog.Errorf("list cloud area %d host ips failed, cond: %v, err: %v, rid: %s", cloudID, cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	ipHostMap := make(map[string][]int64)
+	for _, hostIP := range hostIPs {
+		ipHostMap[hostIP.IP] = append(ipHostMap[hostIP.IP], hostIP.HostID)
+	}
+	return ipHostMap, nil
+}
+
+// subnetIPRangeFilter returns the filter of the host ips that are in the ip range of the subnets
+func subnetIPRangeFilter(ipNets ...*net.IPNet) map[string]interface{} {
+	ranges := make([]map[string]interface{}, 0)
+	for _, ipNet := range ipNets {
+		first, last := metadata.SubnetIPKeyRange(ipNet)
+		ranges = append(ranges, map[string]interface{}{
+			common.BKSubnetIPKeyField: map[string]interface{}{common.BKDBGTE: first, common.BKDBLTE: last},
+		})
+	}
+	return map[string]interface{}{common.BKDBOR: ranges}
+}
+
+// FindSubnetHosts find the hosts of the cloud area whose inner ips are in the subnet
+func (c *cloudOperation) FindSubnetHosts(kit *rest.Kit, subnetID int64, page metadata.BasePage) (
+	*metadata.MultipleSubnetHost, errors.CCErrorCoder) {
+
+	subnet, ipNet, err := c.getSubnet(kit, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	ipHostMap, err := c.listSubnetHostIPs(kit, subnet.CloudID, subnetIPRangeFilter(ipNet))
+	if err != nil {
+		return nil, err
+	}
+
+	hostIPMap := make(map[int64][]string)
+	for ip, hostIDs := range ipHostMap {
+		for _, hostID := range hostIDs {
+			hostIPMap[hostID] = append(hostIPMap[hostID], ip)
+		}
+	}
+
+	hostIDs := make([]int64, 0)
+	for hostID := range hostIPMap {
+		hostIDs = append(hostIDs, hostID)
+	}
+	sort.Slice(hostIDs, func(i, j int) bool { return hostIDs[i] < hostIDs[j] })
+
+	result := &metadata.MultipleSubnetHost{Count: int64(len(hostIDs)), Info: make([]metadata.SubnetHost, 0)}
+	if page.Start >= len(hostIDs) {
+		return result, nil
+	}
+
+	end := len(hostIDs)
+	if page.Limit > 0 && page.Start+page.Limit < end {
+		end = page.Start + page.Limit
+	}
+
+	for _, hostID := range hostIDs[page.Start:end] {
+		ips := hostIPMap[hostID]
+		sort.Strings(ips)
+		result.Info = append(result.Info, metadata.SubnetHost{HostID: hostID, IPs: ips})
+	}
+	return result, nil
+}
+
+// GetSubnetUtilization get the number of the used, reserved and free ips of the subnets in the cloud area
+func (c *cloudOperation) GetSubnetUtilization(kit *rest.Kit, option *metadata.SubnetUtilizationOption) (
+	[]metadata.SubnetUtilization, errors.CCErrorCoder) {
+
+	subnets, err := c.listCloudSubnets(kit, []int64{option.CloudID}, option.SubnetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]metadata.SubnetUtilization, 0)
+	if len(subnets) == 0 {
+		return result, nil
+	}
+
+	ipNets := make([]*net.IPNet, 0)
+	subnetIPNets := make(map[int64]*net.IPNet)
+	for idx := range subnets {
+		ipNet, parseErr := subnets[idx].IPNet()
+		if parseErr != nil {
+			blog.Errorf("parse subnet %d cidr %s failed, err: %v, rid: %s", subnets[idx].SubnetID, subnets[idx].CIDR,
+				parseErr, kit.Rid)
+			continue
+		}
+		ipNets = append(ipNets, ipNet)
+		subnetIPNets[subnets[idx].SubnetID] = ipNet
+	}
+
+	if len(ipNets) == 0 {
+		return result, nil
+	}
+
+	ipHostMap, err := c.listSubnetHostIPs(kit, option.CloudID, subnetIPRangeFilter(ipNets...))
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := c.listSubnetIPReservations(kit, []int64{option.CloudID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range subnets {
+		subnet := &subnets[idx]
+		ipNet, exists := subnetIPNets[subnet.SubnetID]
+		if !exists {
+			continue
+		}
+
+		hostUsed := int64(0)
+		for ip := range ipHostMap {
+			if ip != subnet.Gateway && metadata.IsSubnetAssignableIP(ipNet, net.ParseIP(ip)) {
+				hostUsed++
+			}
+		}
+
+		reserved := int64(0)
+		for _, reservation := range reservations {
+			if reservation.SubnetID != subnet.SubnetID || reservation.IP == subnet.Gateway {
+				continue
+			}
+			if _, exists := ipHostMap[reservation.IP]; !exists {
+				reserved++
+			}
+		}
+
+		result = append(result, metadata.NewSubnetUtilization(subnet, ipNet, hostUsed, reserved))
+	}
+
+	return result, nil
+}
+
+// SearchSubnetIPReservation search the reserved ips of the subnet
+func (c *cloudOperation) SearchSubnetIPReservation(kit *rest.Kit, subnetID int64) ([]metadata.SubnetIPReservation,
+	errors.CCErrorCoder) {
+
+	return c.listSubnetIPReservations(kit, nil, []int64{subnetID})
+}
+
+// ReserveSubnetIP reserve the specified ips of the subnet, the ips must be assignable and not used by any host
+func (c *cloudOperation) ReserveSubnetIP(kit *rest.Kit, subnetID int64, option *metadata.ReserveSubnetIPOption) (
+	[]metadata.SubnetIPReservation, errors.CCErrorCoder) {
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		return nil, rawErr.ToCCError(kit.CCError)
+	}
+
+	subnet, ipNet, err := c.getSubnet(kit, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ip := range option.IPs {
+		if ip == subnet.Gateway || !metadata.IsSubnetAssignableIP(ipNet, net.ParseIP(ip)) {
+			return nil, kit.CCError.CCErrorf(common.CCErrCoreServiceSubnetIPNotInSubnet, ip, subnet.CIDR)
+		}
+	}
+
+	ipFilter := map[string]interface{}{common.BKSubnetIPField: map[string]interface{}{common.BKDBIN: option.IPs}}
+	ipHostMap, err := c.listSubnetHostIPs(kit, subnet.CloudID, ipFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ip := range option.IPs {
+		if hostIDs, exists := ipHostMap[ip]; exists {
+			return nil, kit.CCError.CCErrorf(common.CCErrCoreServiceSubnetIPUsedByHost, ip, hostIDs[0])
+		}
+	}
+
+	reservations := make([]metadata.SubnetIPReservation, 0)
+	for _, ip := range option.IPs {
+		reservation, err := c.insertSubnetIPReservation(kit, subnet, ip, option.Description)
+		if err != nil {
+			c.rollbackSubnetIPReservations(kit, subnet, reservations)
+			return nil, err
+		}
+		reservations = append(reservations, *reservation)
+	}
+
+	return reservations, nil
+}
+
+// AllocateSubnetIP allocate the next free ips of the subnet and reserve them, the ips that are used by hosts, already
+// reserved or the gateway of the subnet are skipped.
+func (c *cloudOperation) AllocateSubnetIP(kit *rest.Kit, subnetID int64, option *metadata.AllocateSubnetIPOption) (
+	[]metadata.SubnetIPReservation, errors.CCErrorCoder) {
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		return nil, rawErr.ToCCError(kit.CCError)
+	}
+
+	subnet, ipNet, err := c.getSubnet(kit, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	ipHostMap, err := c.listSubnetHostIPs(kit, subnet.CloudID, subnetIPRangeFilter(ipNet))
+	if err != nil {
+		return nil, err
+	}
+
+	existReservations, err := c.listSubnetIPReservations(kit, []int64{subnet.CloudID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]struct{})
+	for ip := range ipHostMap {
+		used[ip] = struct{}{}
+	}
+	for _, reservation := range existReservations {
+		used[reservation.IP] = struct{}{}
+	}
+	if len(subnet.Gateway) > 0 {
+		used[subnet.Gateway] = struct{}{}
+	}
+
+	reservations := make([]metadata.SubnetIPReservation, 0)
+	for retry := 0; retry < subnetAllocateMaxRetry && len(reservations) < option.Count; retry++ {
+		ips := metadata.NextFreeSubnetIPs(ipNet, used, option.Count-len(reservations))
+		if len(ips) < option.Count-len(reservations) {
+			c.rollbackSubnetIPReservations(kit, subnet, reservations)
+			return nil, kit.CCError.CCErrorf(common.CCErrCoreServiceSubnetNoFreeIP, subnet.CIDR)
+		}
+
+		for _, ip := range ips {
+			used[ip] = struct{}{}
+			reservation, err := c.insertSubnetIPReservation(kit, subnet, ip, option.Description)
+			if err != nil {
+				// the ip is reserved concurrently, skip it and allocate another one in the next round
+				if err.GetCode() == common.CCErrCoreServiceSubnetIPReserved {
+					continue
+				}
+				c.rollbackSubnetIPReservations(kit, subnet, reservations)
+				return nil, err
+			}
+			reservations = append(reservations, *reservation)
+		}
+	}
+
+	if len(reservations) < option.Count {
+		c.rollbackSubnetIPReservations(kit, subnet, reservations)
+		return nil, kit.CCError.CCErrorf(common.CCErrCoreServiceSubnetNoFreeIP, subnet.CIDR)
+	}
+
+	return reservations, nil
+}
+
+// ReleaseSubnetIP release the reserved ips of the subnet
+func (c *cloudOperation) ReleaseSubnetIP(kit *rest.Kit, subnetID int64, option *metadata.ReserveSubnetIPOption) errors.
+	CCErrorCoder {
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		return rawErr.ToCCError(kit.CCError)
+	}
+
+	cond := subnetCondition(kit, nil, []int64{subnetID})
+	cond[common.BKSubnetIPField] = map[string]interface{}{common.BKDBIN: option.IPs}
+	if err := c.dbProxy.Table(common.BKTableNameSubnetIPReservation).Delete(kit.Ctx, cond); err != nil {
+		blog.Errorf("release subnet ips failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBDeleteFailed)
+	}
+
+	return nil
+}
+
+// insertSubnetIPReservation reserve an ip of the subnet, the ip is unique in the cloud area
+func (c *cloudOperation) insertSubnetIPReservation(kit *rest.Kit, subnet *metadata.Subnet, ip, description string) (
+	*metadata.SubnetIPReservation, errors.CCErrorCoder) {
+
+	reservation := &metadata.SubnetIPReservation{
+		SubnetID:    subnet.SubnetID,
+		CloudID:     subnet.CloudID,
+		IP:          ip,
+		Description: description,
+		OwnerID:     kit.SupplierAccount,
+		Creator:     kit.User,
+		CreateTime:  time.Now(),
+	}
+
+	if err := c.dbProxy.Table(common.BKTableNameSubnetIPReservation).Insert(kit.Ctx, reservation); err != nil {
+		if c.dbProxy.IsDuplicatedError(err) {
+			return nil, kit.CCError.CCErrorf(common.CCErrCoreServiceSubnetIPReserved, ip)
+		}
+		blog.Errorf("reserve subnet %d ip %s failed, err: %v, rid: %s", subnet.SubnetID, ip, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBInsertFailed)
+	}
+
+	return reservation, nil
+}
+
+// rollbackSubnetIPReservations release the ips that are reserved by a failed reservation or allocation
+func (c *cloudOperation) rollbackSubnetIPReservations(kit *rest.Kit, subnet *metadata.Subnet,
+	reservations []metadata.SubnetIPReservation) {
+
+	if len(reservations) == 0 {
+		return
+	}
+
+	ips := make([]string, len(reservations))
+	for idx, reservation := range reservations {
+		ips[idx] = reservation.IP
+	}
+
+	cond := subnetCondition(kit, nil, []int64{subnet.SubnetID})
+	cond[common.BKSubnetIPField] = map[string]interface{}{common.BKDBIN: ips}
+	if err := c.dbProxy.Table(common.BKTableNameSubnetIPReservation).Delete(kit.Ctx, cond); err != nil {
+		blog.Errorf("rollback subnet ip reservations failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+	}
+}
+
+// listSubnetIPReservations list the reserved ips of the cloud areas or subnets
+func (c *cloudOperation) listSubnetIPReservations(kit *rest.Kit, cloudIDs, subnetIDs []int64) (
+	[]metadata.SubnetIPReservation, errors.CCErrorCoder) {
+
+	reservations := make([]metadata.SubnetIPReservation, 0)
+	cond := subnetCondition(kit, cloudIDs, subnetIDs)
+	err := c.dbProxy.Table(common.BKTableNameSubnetIPReservation).Find(cond).Sort(common.BKSubnetIPField).
+		All(kit.Ctx, &reservations)
+	if err != nil {
+		blog.Errorf("list subnet ip reservations failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+	return reservations, nil
+}
+
+// ipConflictAggregation is the aggregation result of the hosts that claim the same ip in the same cloud area
+type ipConflictAggregation struct {
+	ID struct {
+		CloudID int64  `bson:"bk_cloud_id"`
+		IP      string `bson:"ip"`
+	} `bson:"_id"`
+	HostIDs []int64 `bson:"host_ids"`
+}
+
+// FindIPConflicts find the inner ipv4 and ipv6 addresses that are claimed by more than one host in the cloud areas
+func (c *cloudOperation) FindIPConflicts(kit *rest.Kit, option *metadata.FindIPConflictOption) (
+	[]metadata.IPConflict, errors.CCErrorCoder) {
+
+	conflicts := make([]metadata.IPConflict, 0)
+	for _, field := range []string{common.BKHostInnerIPField, common.BKHostInnerIPv6Field} {
+		if len(conflicts) >= option.Limit {
+			break
+		}
+
+		filter := map[string]interface{}{
+			common.BKCloudIDField: map[string]interface{}{common.BKDBIN: option.CloudIDs},
+			field:                 map[string]interface{}{common.BKDBNIN: []interface{}{nil, ""}},
+		}
+		pipeline := []map[string]interface{}{
+			{common.BKDBMatch: util.SetQueryOwner(filter, kit.SupplierAccount)},
+			{common.BKDBUnwind: "$" + field},
+			{common.BKDBGroup: map[string]interface{}{
+				"_id": map[string]interface{}{
+					common.BKCloudIDField: "$" + common.BKCloudIDField,
+					"ip":                  "$" + field,
+				},
+				"host_ids": map[string]interface{}{common.BKDBAddToSet: "$" + common.BKHostIDField},
+			}},
+			{common.BKDBMatch: map[string]interface{}{"host_ids.1": map[string]interface{}{common.BKDBExists: true}}},
+			{common.BKDBSort: map[string]interface{}{"_id": 1}},
+			{common.BKDBLimit: option.Limit - len(conflicts)},
+		}
+
+		result := make([]ipConflictAggregation, 0)
+		err := c.dbProxy.Table(common.BKTableNameBaseHost).AggregateAll(kit.Ctx, pipeline, &result)
+		if err != nil {
+			blog.Errorf("aggregate ip conflicts failed, field: %s, err: %v, rid: %s", field, err, kit.Rid)
+			return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+		}
+
+		for _, item := range result {
+			conflicts = append(conflicts, metadata.IPConflict{CloudID: item.ID.CloudID, IP: item.ID.IP,
+				HostIDs: item.HostIDs})
+		}
+	}
+
+	return conflicts, nil
+}
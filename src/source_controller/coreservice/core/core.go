@@ -116,6 +116,8 @@ type InstanceOperation interface {
 	SearchModelInstance(kit *rest.Kit, objID string, inputParam metadata.QueryCondition) (*metadata.QueryResult, error)
 	CountModelInstances(kit *rest.Kit, objID string, input *metadata.Condition) (
 		*metadata.CommonCountResult, error)
+	AggregateModelInstances(kit *rest.Kit, objID string, input *metadata.InstAggregateOption) (
+		*metadata.InstAggregateResult, error)
 	DeleteModelInstance(kit *rest.Kit, objID string, inputParam metadata.DeleteOption) (*metadata.DeletedCount, error)
 	CascadeDeleteModelInstance(kit *rest.Kit, objID string, inputParam metadata.DeleteOption) (*metadata.DeletedCount,
 		error)
@@ -205,6 +207,26 @@ type HostOperation interface {
 	LockHost(kit *rest.Kit, input *metadata.HostLockRequest) errors.CCError
 	UnlockHost(kit *rest.Kit, input *metadata.HostLockRequest) errors.CCError
 	QueryHostLock(kit *rest.Kit, input *metadata.QueryHostLockRequest) ([]metadata.HostLockData, errors.CCError)
+	CheckHostLock(kit *rest.Kit, hostIDs []int64) errors.CCErrorCoder
+
+	SaveHostLifecycle(kit *rest.Kit, lifecycle *metadata.HostLifecycle) errors.CCErrorCoder
+	GetHostLifecycle(kit *rest.Kit) (*metadata.HostLifecycle, errors.CCErrorCoder)
+	ValidateHostCreateState(kit *rest.Kit, hosts []mapstr.MapStr) errors.CCErrorCoder
+	ValidateHostStateTransition(kit *rest.Kit, origins []mapstr.MapStr, data mapstr.MapStr) (
+		[]metadata.HostStateTransition, errors.CCErrorCoder)
+	ApplyHostStateTransition(kit *rest.Kit, transitions []metadata.HostStateTransition) errors.CCErrorCoder
+
+	CreateHostTransferSchedule(kit *rest.Kit, schedule *metadata.HostTransferSchedule) (
+		*metadata.HostTransferSchedule, errors.CCErrorCoder)
+	ListHostTransferSchedule(kit *rest.Kit, option *metadata.ListHostTransferScheduleOption) (
+		*metadata.MultipleHostTransferSchedule, errors.CCErrorCoder)
+	UpdateHostTransferScheduleStatus(kit *rest.Kit,
+		option *metadata.UpdateHostTransferScheduleStatusOption) errors.CCErrorCoder
+
+	FindDuplicateHosts(kit *rest.Kit, option *metadata.FindDuplicateHostOption) ([]metadata.DuplicateHostGroup,
+		errors.CCErrorCoder)
+	MergeHost(kit *rest.Kit, option *metadata.MergeHostOption, preview bool) (*metadata.MergeHostResult,
+		errors.CCErrorCoder)
 
 	// ListHosts TODO
 	// host search
@@ -215,6 +237,23 @@ type HostOperation interface {
 		error)
 
 	TransferResourceDirectory(kit *rest.Kit, input *metadata.TransferHostResourceDirectory) errors.CCErrorCoder
+
+	// SearchHostSnapHistory search the downsampled snapshot history of a host
+	SearchHostSnapHistory(kit *rest.Kit, option *metadata.SearchHostSnapHistoryOption) (
+		*metadata.HostSnapHistoryResult, errors.CCErrorCoder)
+	// SearchHostSnapChangeLog search the hardware and os field change logs of a host reported by snapshot
+	SearchHostSnapChangeLog(kit *rest.Kit, option *metadata.SearchHostSnapChangeLogOption) (
+		*metadata.HostSnapChangeLogResult, errors.CCErrorCoder)
+	// SearchHostSnapDrift search the drifts between the snapshot and the host in cmdb of the specified hosts
+	SearchHostSnapDrift(kit *rest.Kit, option *metadata.SearchHostSnapDriftOption) (*metadata.HostSnapDriftResult,
+		errors.CCErrorCoder)
+	// SearchHostSnapStatus search the last snapshot time and the stale flag of the specified hosts
+	SearchHostSnapStatus(kit *rest.Kit, option *metadata.SearchHostSnapStatusOption) (
+		*metadata.HostSnapStatusResult, errors.CCErrorCoder)
+	// SaveStaleHostPolicy save the stale host policy of the supplier account
+	SaveStaleHostPolicy(kit *rest.Kit, policy *metadata.StaleHostPolicy) errors.CCErrorCoder
+	// GetStaleHostPolicy get the stale host policy of the supplier account
+	GetStaleHostPolicy(kit *rest.Kit) (*metadata.StaleHostPolicy, errors.CCErrorCoder)
 }
 
 // AssociationOperation association methods
@@ -417,6 +456,23 @@ type CloudOperation interface {
 	SearchSyncHistory(kit *rest.Kit, option *metadata.SearchSyncHistoryOption) (*metadata.MultipleSyncHistory,
 		errors.CCErrorCoder)
 	DeleteDestroyedHostRelated(kit *rest.Kit, option *metadata.DeleteDestroyedHostRelatedOption) errors.CCErrorCoder
+
+	// cloud area ip address management
+	CreateSubnet(kit *rest.Kit, subnet *metadata.Subnet) (*metadata.Subnet, errors.CCErrorCoder)
+	SearchSubnet(kit *rest.Kit, option *metadata.SearchSubnetOption) (*metadata.MultipleSubnet, errors.CCErrorCoder)
+	UpdateSubnet(kit *rest.Kit, subnetID int64, option *metadata.UpdateSubnetOption) errors.CCErrorCoder
+	DeleteSubnet(kit *rest.Kit, subnetID int64) errors.CCErrorCoder
+	FindSubnetHosts(kit *rest.Kit, subnetID int64, page metadata.BasePage) (*metadata.MultipleSubnetHost,
+		errors.CCErrorCoder)
+	GetSubnetUtilization(kit *rest.Kit, option *metadata.SubnetUtilizationOption) ([]metadata.SubnetUtilization,
+		errors.CCErrorCoder)
+	SearchSubnetIPReservation(kit *rest.Kit, subnetID int64) ([]metadata.SubnetIPReservation, errors.CCErrorCoder)
+	ReserveSubnetIP(kit *rest.Kit, subnetID int64, option *metadata.ReserveSubnetIPOption) (
+		[]metadata.SubnetIPReservation, errors.CCErrorCoder)
+	AllocateSubnetIP(kit *rest.Kit, subnetID int64, option *metadata.AllocateSubnetIPOption) (
+		[]metadata.SubnetIPReservation, errors.CCErrorCoder)
+	ReleaseSubnetIP(kit *rest.Kit, subnetID int64, option *metadata.ReserveSubnetIPOption) errors.CCErrorCoder
+	FindIPConflicts(kit *rest.Kit, option *metadata.FindIPConflictOption) ([]metadata.IPConflict, errors.CCErrorCoder)
 }
 
 // SystemOperation TODO
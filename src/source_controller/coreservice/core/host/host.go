@@ -22,16 +22,18 @@ import (
 var _ core.HostOperation = (*hostManager)(nil)
 
 type hostManager struct {
-	hostTransfer *transfer.TransferManager
-	dependent    transfer.OperationDependence
-	hostSearcher searcher.Searcher
+	hostTransfer        *transfer.TransferManager
+	dependent           transfer.OperationDependence
+	hostApplyDependence transfer.HostApplyRuleDependence
+	hostSearcher        searcher.Searcher
 }
 
 // New create a new model manager instance
 func New(dependent transfer.OperationDependence, hostApplyDependence transfer.HostApplyRuleDependence) core.HostOperation {
 
 	coreMgr := &hostManager{
-		dependent: dependent,
+		dependent:           dependent,
+		hostApplyDependence: hostApplyDependence,
 	}
 	coreMgr.hostTransfer = transfer.New(dependent, hostApplyDependence)
 	coreMgr.hostSearcher = searcher.New()
@@ -0,0 +1,446 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"strings"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// SaveHostLifecycle save the host lifecycle of the supplier account
+func (hm *hostManager) SaveHostLifecycle(kit *rest.Kit, lifecycle *metadata.HostLifecycle) errors.CCErrorCoder {
+	if err := hm.validateHostLifecycleAttrs(kit, lifecycle); err != nil {
+		return err
+	}
+
+	lifecycle.SupplierAccount = kit.SupplierAccount
+	lifecycle.Modifier = kit.User
+	lifecycle.LastTime = time.Now()
+
+	cond := mapstr.MapStr{common.BkSupplierAccount: kit.SupplierAccount}
+	if err := mongodb.Client().Table(common.BKTableNameHostLifecycle).Upsert(kit.Ctx, cond, lifecycle); err != nil {
+		blog.Errorf("save host lifecycle failed, err: %v, lifecycle: %+v, rid: %s", err, lifecycle, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
+	}
+	return nil
+}
+
+// GetHostLifecycle get the host lifecycle of the supplier account, returns a disabled lifecycle if not configured
+func (hm *hostManager) GetHostLifecycle(kit *rest.Kit) (*metadata.HostLifecycle, errors.CCErrorCoder) {
+	cond := mapstr.MapStr{common.BkSupplierAccount: kit.SupplierAccount}
+
+	lifecycle := new(metadata.HostLifecycle)
+	err := mongodb.Client().Table(common.BKTableNameHostLifecycle).Find(cond).One(kit.Ctx, lifecycle)
+	if err != nil {
+		if mongodb.Client().IsNotFoundError(err) {
+			return &metadata.HostLifecycle{
+				States:          make([]metadata.HostLifecycleState, 0),
+				Transitions:     make([]metadata.HostStateTransitionRule, 0),
+				SupplierAccount: kit.SupplierAccount,
+			}, nil
+		}
+		blog.Errorf("get host lifecycle failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+	return lifecycle, nil
+}
+
+// validateHostLifecycleAttrs check if the states of the host lifecycle are the options of the host bk_state field,
+// and if the required fields of the states are host fields.
+func (hm *hostManager) validateHostLifecycleAttrs(kit *rest.Kit,
+	lifecycle *metadata.HostLifecycle) errors.CCErrorCoder {
+
+	if !lifecycle.IsEnabled() {
+		return nil
+	}
+
+	cond := util.SetQueryOwner(mapstr.MapStr{common.BKObjIDField: common.BKInnerObjIDHost}, kit.SupplierAccount)
+	attributes := make([]metadata.Attribute, 0)
+	err := mongodb.Client().Table(common.BKTableNameObjAttDes).Find(cond).Fields(common.BKPropertyIDField,
+		common.BKOptionField).All(kit.Ctx, &attributes)
+	if err != nil {
+		blog.Errorf("get host attributes failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	fieldMap := make(map[string]metadata.Attribute)
+	for _, attribute := range attributes {
+		fieldMap[attribute.PropertyID] = attribute
+	}
+
+	optionMap, ccErr := hm.parseHostStateOptions(kit, fieldMap[common.BKHostState])
+	if ccErr != nil {
+		return ccErr
+	}
+
+	for _, state := range lifecycle.States {
+		if _, exists := optionMap[state.Name]; !exists {
+			blog.Errorf("host state %s is not an option of %s, rid: %s", state.Name, common.BKHostState, kit.Rid)
+			return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "states.name")
+		}
+
+		for _, field := range state.RequiredFields {
+			if _, exists := fieldMap[field]; !exists {
+				blog.Errorf("required field %s of host state %s is not a host field, rid: %s", field, state.Name,
+					kit.Rid)
+				return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "states.required_fields")
+			}
+		}
+	}
+
+	return nil
+}
+
+// getHostStateOptions get the options of the host bk_state enum field
+func (hm *hostManager) getHostStateOptions(kit *rest.Kit) (map[string]struct{}, errors.CCErrorCoder) {
+	cond := util.SetQueryOwner(mapstr.MapStr{
+		common.BKObjIDField:      common.BKInnerObjIDHost,
+		common.BKPropertyIDField: common.BKHostState,
+	}, kit.SupplierAccount)
+
+	attributes := make([]metadata.Attribute, 0)
+	err := mongodb.Client().Table(common.BKTableNameObjAttDes).Find(cond).Fields(common.BKPropertyIDField,
+		common.BKOptionField).Limit(1).All(kit.Ctx, &attributes)
+	if err != nil {
+		blog.Errorf("get host state attribute failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	if len(attributes) == 0 {
+		return hm.parseHostStateOptions(kit, metadata.Attribute{})
+	}
+	return hm.parseHostStateOptions(kit, attributes[0])
+}
+
+// parseHostStateOptions parse the options of the host bk_state enum field
+func (hm *hostManager) parseHostStateOptions(kit *rest.Kit, stateAttr metadata.Attribute) (map[string]struct{},
+	errors.CCErrorCoder) {
+
+	if stateAttr.PropertyID != common.BKHostState {
+		blog.Errorf("host state attribute not found, rid: %s", kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKHostState)
+	}
+
+	options, err := metadata.ParseEnumOption(kit.Ctx, stateAttr.Option)
+	if err != nil {
+		blog.Errorf("parse host state options failed, option: %v, err: %v, rid: %s", stateAttr.Option, err, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKHostState)
+	}
+
+	optionMap := make(map[string]struct{})
+	for _, option := range options {
+		optionMap[option.ID] = struct{}{}
+	}
+	return optionMap, nil
+}
+
+// getHostTargetState get the lifecycle state that the hosts change to, the state must be in the lifecycle and still
+// be an option of the host bk_state field, because the options may be changed after the lifecycle is saved.
+func (hm *hostManager) getHostTargetState(kit *rest.Kit, lifecycle *metadata.HostLifecycle, to string) (
+	*metadata.HostLifecycleState, errors.CCErrorCoder) {
+
+	state := lifecycle.GetState(to)
+	if state == nil {
+		blog.Errorf("host state %s is not in the lifecycle, rid: %s", to, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCoreServiceHostStateNotInLifecycle, to)
+	}
+
+	optionMap, err := hm.getHostStateOptions(kit)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, exists := optionMap[to]; !exists {
+		blog.Errorf("host state %s is not an option of %s, rid: %s", to, common.BKHostState, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKHostState)
+	}
+	return state, nil
+}
+
+// ValidateHostCreateState validate the state of the hosts to be created by the host lifecycle, a host can be created
+// without a state, or in a state of the lifecycle with the required fields of the state set.
+func (hm *hostManager) ValidateHostCreateState(kit *rest.Kit, hosts []mapstr.MapStr) errors.CCErrorCoder {
+	states := make(map[string][]mapstr.MapStr)
+	for _, host := range hosts {
+		to := util.GetStrByInterface(host[common.BKHostState])
+		if len(to) == 0 {
+			continue
+		}
+		states[to] = append(states[to], host)
+	}
+
+	if len(states) == 0 {
+		return nil
+	}
+
+	lifecycle, err := hm.GetHostLifecycle(kit)
+	if err != nil {
+		return err
+	}
+
+	if !lifecycle.IsEnabled() {
+		return nil
+	}
+
+	for to, stateHosts := range states {
+		state, err := hm.getHostTargetState(kit, lifecycle, to)
+		if err != nil {
+			return err
+		}
+
+		for _, host := range stateHosts {
+			for _, field := range state.RequiredFields {
+				if isEmptyHostField(host[field]) {
+					blog.Errorf("host is created in state %s, but field %s is not set, rid: %s", to, field, kit.Rid)
+					return kit.CCError.CCErrorf(common.CCErrCommParamsNeedSet, field)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateHostStateTransition validate the host state changes in the host update data by the host lifecycle,
+// returns the transitions of the hosts whose states are changed.
+func (hm *hostManager) ValidateHostStateTransition(kit *rest.Kit, origins []mapstr.MapStr, data mapstr.MapStr) (
+	[]metadata.HostStateTransition, errors.CCErrorCoder) {
+
+	toVal, exists := data[common.BKHostState]
+	if !exists {
+		return nil, nil
+	}
+
+	lifecycle, err := hm.GetHostLifecycle(kit)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lifecycle.IsEnabled() {
+		return nil, nil
+	}
+
+	to := util.GetStrByInterface(toVal)
+	var state *metadata.HostLifecycleState
+
+	transitions := make([]metadata.HostStateTransition, 0)
+	for _, origin := range origins {
+		hostID, parseErr := util.GetInt64ByInterface(origin[common.BKHostIDField])
+		if parseErr != nil {
+			blog.Errorf("parse host id failed, err: %v, host: %+v, rid: %s", parseErr, origin, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKHostIDField)
+		}
+
+		from := util.GetStrByInterface(origin[common.BKHostState])
+		if from == to {
+			continue
+		}
+
+		if state == nil {
+			if state, err = hm.getHostTargetState(kit, lifecycle, to); err != nil {
+				return nil, err
+			}
+		}
+
+		if !lifecycle.CanTransit(from, to) {
+			blog.Errorf("host %d can not change state from %s to %s, rid: %s", hostID, from, to, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCoreServiceHostStateTransitionForbidden, hostID, from, to)
+		}
+
+		for _, field := range state.RequiredFields {
+			value, exists := data[field]
+			if !exists {
+				value = origin[field]
+			}
+
+			if isEmptyHostField(value) {
+				blog.Errorf("host %d changes to state %s, but field %s is not set, rid: %s", hostID, to, field,
+					kit.Rid)
+				return nil, kit.CCError.CCErrorf(common.CCErrCoreServiceHostStateRequiredFieldNotSet, hostID, to,
+					field)
+			}
+		}
+
+		transitions = append(transitions, metadata.HostStateTransition{HostID: hostID, From: from, To: to})
+	}
+
+	return transitions, nil
+}
+
+// isEmptyHostField check if the host field value is not set
+func isEmptyHostField(value interface{}) bool {
+	switch val := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(strings.TrimSpace(val)) == 0
+	case []interface{}:
+		return len(val) == 0
+	default:
+		return false
+	}
+}
+
+// ApplyHostStateTransition record the host state transitions in the audit log, and run the hooks of the states
+// that the hosts change to, e.g. transfer the hosts to the idle module.
+func (hm *hostManager) ApplyHostStateTransition(kit *rest.Kit,
+	transitions []metadata.HostStateTransition) errors.CCErrorCoder {
+
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	lifecycle, err := hm.GetHostLifecycle(kit)
+	if err != nil {
+		return err
+	}
+
+	hostIDs := make([]int64, len(transitions))
+	for idx, transition := range transitions {
+		hostIDs[idx] = transition.HostID
+	}
+
+	hostIPMap, hostBizMap, err := hm.getHostAuditInfo(kit, hostIDs)
+	if err != nil {
+		return err
+	}
+
+	auditLogs := make([]metadata.AuditLog, len(transitions))
+	idleBizHostMap := make(map[int64][]int64)
+	for idx, transition := range transitions {
+		auditLogs[idx] = metadata.AuditLog{
+			AuditType:    metadata.HostType,
+			ResourceType: metadata.HostRes,
+			Action:       metadata.AuditChangeHostState,
+			BusinessID:   hostBizMap[transition.HostID],
+			ResourceID:   transition.HostID,
+			ResourceName: hostIPMap[transition.HostID],
+			OperationDetail: &metadata.InstanceOpDetail{
+				BasicOpDetail: metadata.BasicOpDetail{
+					Details: &metadata.BasicContent{
+						PreData: map[string]interface{}{common.BKHostState: transition.From},
+						CurData: map[string]interface{}{common.BKHostState: transition.To},
+					},
+				},
+				ModelID: common.BKInnerObjIDHost,
+			},
+		}
+
+		if state := lifecycle.GetState(transition.To); state != nil && state.MoveToIdle {
+			bizID := hostBizMap[transition.HostID]
+			idleBizHostMap[bizID] = append(idleBizHostMap[bizID], transition.HostID)
+		}
+	}
+
+	if err := hm.dependent.CreateAuditLogDependence(kit, auditLogs...); err != nil {
+		blog.Errorf("save host state transition audit log failed, err: %v, rid: %s", err, kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrCommDBInsertFailed)
+	}
+
+	for bizID, hostIDs := range idleBizHostMap {
+		if err := hm.moveHostsToIdleModule(kit, bizID, hostIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveHostsToIdleModule transfer the hosts to the idle module of their business
+func (hm *hostManager) moveHostsToIdleModule(kit *rest.Kit, bizID int64, hostIDs []int64) errors.CCErrorCoder {
+	idleModuleFilter := map[string]interface{}{
+		common.BKAppIDField:   bizID,
+		common.BKDefaultField: common.DefaultResModuleFlag,
+	}
+	idleModule := metadata.ModuleHost{}
+	err := mongodb.Client().Table(common.BKTableNameBaseModule).Find(idleModuleFilter).Fields(
+		common.BKModuleIDField).One(kit.Ctx, &idleModule)
+	if err != nil {
+		blog.Errorf("get idle module failed, err: %v, filter: %+v, rid: %s", err, idleModuleFilter, kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrHostGetModuleFail, err.Error())
+	}
+
+	option := &metadata.TransferHostToInnerModule{
+		ApplicationID: bizID,
+		ModuleID:      idleModule.ModuleID,
+		HostID:        hostIDs,
+	}
+	if err := hm.hostTransfer.TransferToInnerModule(kit, option); err != nil {
+		blog.Errorf("transfer hosts to idle module failed, err: %v, option: %+v, rid: %s", err, option, kit.Rid)
+		if ccErr, ok := err.(errors.CCErrorCoder); ok {
+			return ccErr
+		}
+		return kit.CCError.CCErrorf(common.CCErrCoreServiceTransferHostModuleErr)
+	}
+	return nil
+}
+
+// checkHostTransferState check if the hosts are in the states that are forbidden to be transferred
+func (hm *hostManager) checkHostTransferState(kit *rest.Kit, hostIDs []int64) errors.CCErrorCoder {
+	if len(hostIDs) == 0 {
+		return nil
+	}
+
+	lifecycle, err := hm.GetHostLifecycle(kit)
+	if err != nil {
+		return err
+	}
+
+	forbiddenStates := make([]string, 0)
+	for _, state := range lifecycle.States {
+		if state.ForbidTransfer {
+			forbiddenStates = append(forbiddenStates, state.Name)
+		}
+	}
+
+	if len(forbiddenStates) == 0 {
+		return nil
+	}
+
+	cond := mapstr.MapStr{
+		common.BKHostIDField: mapstr.MapStr{common.BKDBIN: hostIDs},
+		common.BKHostState:   mapstr.MapStr{common.BKDBIN: forbiddenStates},
+	}
+	hosts := make([]metadata.HostMapStr, 0)
+	dbErr := mongodb.Client().Table(common.BKTableNameBaseHost).Find(cond).Fields(common.BKHostIDField,
+		common.BKHostState).Limit(1).All(kit.Ctx, &hosts)
+	if dbErr != nil {
+		blog.Errorf("get hosts in forbidden states failed, err: %v, cond: %+v, rid: %s", dbErr, cond, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	if len(hosts) > 0 {
+		host := hosts[0]
+		blog.Errorf("host %v is in state %v, can not be transferred, rid: %s", host[common.BKHostIDField],
+			host[common.BKHostState], kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrCoreServiceHostStateForbidTransfer, host[common.BKHostIDField],
+			host[common.BKHostState])
+	}
+	return nil
+}
+
+// checkHostTransfer check if the hosts can be transferred by the host locks and the host lifecycle
+func (hm *hostManager) checkHostTransfer(kit *rest.Kit, hostIDs []int64) errors.CCErrorCoder {
+	if err := hm.CheckHostLock(kit, hostIDs); err != nil {
+		return err
+	}
+	return hm.checkHostTransferState(kit, hostIDs)
+}
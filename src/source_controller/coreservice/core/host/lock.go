@@ -23,10 +23,14 @@ import (
 	"configcenter/src/common/mapstr"
 	"configcenter/src/common/metadata"
 	"configcenter/src/common/util"
+	"configcenter/src/storage/dal/types"
 	"configcenter/src/storage/driver/mongodb"
 )
 
-// LockHost TODO
+// LockHost lock the hosts with a reason and an optional expiration time, the hosts that are already locked by
+// the user are locked again with the new reason and expiration time. each host is locked atomically by the
+// conditional upsert, which only matches the lock held by the user or the expired lock, and the unique host id
+// index rejects the lock if the host is locked by another user at the same time.
 func (hm *hostManager) LockHost(kit *rest.Kit, input *metadata.HostLockRequest) errors.CCError {
 	input.IDS = util.IntArrayUnique(input.IDS)
 	condition := mapstr.MapStr{
@@ -48,40 +52,134 @@ func (hm *hostManager) LockHost(kit *rest.Kit, input *metadata.HostLockRequest)
 	}
 
 	user := util.GetUser(kit.Header)
-	var insertDataArr []interface{}
+	othersLocks, ccErr := hm.getActiveHostLocks(kit, input.IDS, user)
+	if ccErr != nil {
+		return ccErr
+	}
+	if len(othersLocks) > 0 {
+		lock := othersLocks[0]
+		blog.Errorf("lock host, host %d is already locked by %s, rid: %s", lock.ID, lock.User, kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrCoreServiceHostLocked, lock.ID, lock.User, lock.Reason)
+	}
+
+	heldLocks, ccErr := hm.getUserHostLocks(kit, input.IDS, user)
+	if ccErr != nil {
+		return ccErr
+	}
+
 	ts := time.Now().UTC()
+	lockedIDs := make([]int64, 0)
 	for _, id := range input.IDS {
-		conds := mapstr.MapStr{
+		lockCond := mapstr.MapStr{
 			common.BKHostIDField: id,
+			common.BKDBOR: []mapstr.MapStr{
+				{common.HostLockUserField: user},
+				{common.HostLockExpireTimeField: mapstr.MapStr{common.BKDBLTE: ts}},
+			},
 		}
-		conds = util.SetQueryOwner(conds, kit.SupplierAccount)
-		cnt, err := mongodb.Client().Table(common.BKTableNameHostLock).Find(conds).Count(kit.Ctx)
-		if nil != err {
-			blog.Errorf("lock host, query host lock from db failed, err:%+v, rid:%s", err, kit.Rid)
-			return kit.CCError.Errorf(common.CCErrCommDBSelectFailed)
+		lockCond = util.SetModOwner(lockCond, kit.SupplierAccount)
+
+		updates := []types.ModeUpdate{{Op: "set", Doc: metadata.HostLockData{
+			User:       user,
+			ID:         id,
+			Reason:     input.Reason,
+			ExpireTime: input.ExpireTime,
+			CreateTime: ts,
+			OwnerID:    util.GetOwnerID(kit.Header),
+		}}}
+		if input.ExpireTime == nil {
+			updates = append(updates, types.ModeUpdate{Op: "unset",
+				Doc: mapstr.MapStr{common.HostLockExpireTimeField: ""}})
 		}
-		if 0 == cnt {
-			insertDataArr = append(insertDataArr, metadata.HostLockData{
-				User:       user,
-				ID:         id,
-				CreateTime: ts,
-				OwnerID:    util.GetOwnerID(kit.Header),
-			})
+
+		err := mongodb.Client().Table(common.BKTableNameHostLock).UpsertMultiModel(kit.Ctx, lockCond, updates...)
+		if err == nil {
+			if _, exists := heldLocks[id]; !exists {
+				lockedIDs = append(lockedIDs, id)
+			}
+			continue
 		}
-	}
 
-	if 0 < len(insertDataArr) {
-		err := mongodb.Client().Table(common.BKTableNameHostLock).Insert(kit.Ctx, insertDataArr)
-		if nil != err {
-			blog.Errorf("lock host, save host lock to db failed, err: %+v, rid:%s", err, kit.Rid)
+		// release the hosts newly locked by this request, so that the hosts are locked all or none
+		hm.releaseHostLocks(kit, lockedIDs, user)
+
+		if !mongodb.Client().IsDuplicatedError(err) {
+			blog.Errorf("lock host, save host %d lock to db failed, err: %v, rid: %s", id, err, kit.Rid)
 			return kit.CCError.Errorf(common.CCErrCommDBInsertFailed)
 		}
+
+		// the host is locked by another user after the check
+		locks, ccErr := hm.getActiveHostLocks(kit, []int64{id}, user)
+		if ccErr != nil {
+			return ccErr
+		}
+		if len(locks) == 0 {
+			blog.Errorf("lock host, host %d lock conflicts but the lock is released, rid: %s", id, kit.Rid)
+			return kit.CCError.Errorf(common.CCErrCommDBInsertFailed)
+		}
+		blog.Errorf("lock host, host %d is already locked by %s, rid: %s", id, locks[0].User, kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrCoreServiceHostLocked, id, locks[0].User, locks[0].Reason)
 	}
+
 	return nil
 }
 
-// UnlockHost TODO
+// getUserHostLocks get the ids of the hosts whose active locks are held by the user
+func (hm *hostManager) getUserHostLocks(kit *rest.Kit, hostIDs []int64, user string) (map[int64]struct{},
+	errors.CCErrorCoder) {
+
+	cond := mapstr.MapStr{
+		common.BKHostIDField:     mapstr.MapStr{common.BKDBIN: hostIDs},
+		common.HostLockUserField: user,
+		common.HostLockExpireTimeField: mapstr.MapStr{
+			common.BKDBNot: mapstr.MapStr{common.BKDBLTE: time.Now().UTC()},
+		},
+	}
+	cond = util.SetQueryOwner(cond, kit.SupplierAccount)
+
+	locks := make([]metadata.HostLockData, 0)
+	err := mongodb.Client().Table(common.BKTableNameHostLock).Find(cond).Fields(common.BKHostIDField).
+		All(kit.Ctx, &locks)
+	if err != nil {
+		blog.Errorf("get user host locks failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommDBSelectFailed)
+	}
+
+	hostIDMap := make(map[int64]struct{})
+	for _, lock := range locks {
+		hostIDMap[lock.ID] = struct{}{}
+	}
+	return hostIDMap, nil
+}
+
+// releaseHostLocks release the host locks held by the user, the failure is only logged because it is used to roll
+// back the locks, and the locks can also be unlocked by the user.
+func (hm *hostManager) releaseHostLocks(kit *rest.Kit, hostIDs []int64, user string) {
+	if len(hostIDs) == 0 {
+		return
+	}
+
+	cond := mapstr.MapStr{
+		common.BKHostIDField:     mapstr.MapStr{common.BKDBIN: hostIDs},
+		common.HostLockUserField: user,
+	}
+	cond = util.SetModOwner(cond, kit.SupplierAccount)
+	if err := mongodb.Client().Table(common.BKTableNameHostLock).Delete(kit.Ctx, cond); err != nil {
+		blog.Errorf("release host locks failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+	}
+}
+
+// UnlockHost unlock the hosts, only the lock holder can unlock the hosts unless the caller overrides the locks.
 func (hm *hostManager) UnlockHost(kit *rest.Kit, input *metadata.HostLockRequest) errors.CCError {
+	lock, ccErr := hm.overrideHostLocks(kit, input.IDS)
+	if ccErr != nil {
+		return ccErr
+	}
+	if lock != nil {
+		blog.Errorf("unlock host, host %d is locked by %s, rid: %s", lock.ID, lock.User, kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrCoreServiceHostLockNotOwner, lock.ID, lock.User)
+	}
+
 	conds := mapstr.MapStr{
 		common.BKHostIDField: mapstr.MapStr{common.BKDBIN: input.IDS},
 	}
@@ -95,20 +193,177 @@ func (hm *hostManager) UnlockHost(kit *rest.Kit, input *metadata.HostLockRequest
 	return nil
 }
 
-// QueryHostLock TODO
+// QueryHostLock query the host locks that are not expired
 func (hm *hostManager) QueryHostLock(kit *rest.Kit, input *metadata.QueryHostLockRequest) ([]metadata.HostLockData, errors.CCError) {
-	hostLockInfoArr := make([]metadata.HostLockData, 0)
-	conds := mapstr.MapStr{
-		common.BKHostIDField: mapstr.MapStr{common.BKDBIN: input.IDS},
+	hostLockInfoArr, err := hm.getActiveHostLocks(kit, input.IDS, "")
+	if err != nil {
+		return nil, err
 	}
-	conds = util.SetModOwner(conds, kit.SupplierAccount)
-	limit := uint64(len(input.IDS))
-	err := mongodb.Client().Table(common.BKTableNameHostLock).Find(conds).Limit(limit).All(kit.Ctx, &hostLockInfoArr)
-	if nil != err {
-		blog.Errorf("query lock host, query host lock from db error, err: %+v, rid:%s", err, kit.Rid)
+	return hostLockInfoArr, nil
+}
+
+// CheckHostLock check if the hosts can be updated, transferred or deleted by the user, the hosts locked by other
+// users are rejected unless the caller overrides the locks. the internal system writers like the host snapshot
+// collector are not restricted by the locks, because the locks are used to prevent the hosts from being changed by
+// users, they are marked by the exempt header that can not be set by the requests outside of cmdb.
+func (hm *hostManager) CheckHostLock(kit *rest.Kit, hostIDs []int64) errors.CCErrorCoder {
+	if kit.Header.Get(common.BKHTTPHostLockExempt) == "true" {
+		return nil
+	}
+
+	lock, err := hm.overrideHostLocks(kit, hostIDs)
+	if err != nil {
+		return err
+	}
+
+	if lock != nil {
+		blog.Errorf("host %d is locked by %s, reason: %s, rid: %s", lock.ID, lock.User, lock.Reason, kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrCoreServiceHostLocked, lock.ID, lock.User, lock.Reason)
+	}
+	return nil
+}
+
+// overrideHostLocks override the locks of the hosts held by other users with the reason in the request header, and
+// record the override in the audit log. returns the first lock held by other users if the reason is not set.
+func (hm *hostManager) overrideHostLocks(kit *rest.Kit, hostIDs []int64) (*metadata.HostLockData,
+	errors.CCErrorCoder) {
+
+	if len(hostIDs) == 0 {
+		return nil, nil
+	}
+
+	locks, err := hm.getActiveHostLocks(kit, hostIDs, util.GetUser(kit.Header))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(locks) == 0 {
+		return nil, nil
+	}
+
+	reason := kit.Header.Get(common.BKHTTPHostLockOverride)
+	if len(reason) == 0 {
+		return &locks[0], nil
+	}
+
+	if err := hm.saveHostLockOverrideAudit(kit, locks, reason); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// getActiveHostLocks get the host locks that are not expired, the locks held by the excluded user are ignored.
+func (hm *hostManager) getActiveHostLocks(kit *rest.Kit, hostIDs []int64, excludeUser string) (
+	[]metadata.HostLockData, errors.CCErrorCoder) {
+
+	cond := mapstr.MapStr{
+		common.BKHostIDField: mapstr.MapStr{common.BKDBIN: hostIDs},
+		common.HostLockExpireTimeField: mapstr.MapStr{
+			common.BKDBNot: mapstr.MapStr{common.BKDBLTE: time.Now().UTC()},
+		},
+	}
+	if len(excludeUser) > 0 {
+		cond[common.HostLockUserField] = mapstr.MapStr{common.BKDBNE: excludeUser}
+	}
+	cond = util.SetQueryOwner(cond, kit.SupplierAccount)
+
+	locks := make([]metadata.HostLockData, 0)
+	err := mongodb.Client().Table(common.BKTableNameHostLock).Find(cond).All(kit.Ctx, &locks)
+	if err != nil {
+		blog.Errorf("get host locks failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
 		return nil, kit.CCError.CCErrorf(common.CCErrCommDBSelectFailed)
 	}
-	return hostLockInfoArr, nil
+	return locks, nil
+}
+
+// saveHostLockOverrideAudit record the overridden host locks and the override reason in the host audit log
+func (hm *hostManager) saveHostLockOverrideAudit(kit *rest.Kit, locks []metadata.HostLockData,
+	reason string) errors.CCErrorCoder {
+
+	hostIDs := make([]int64, len(locks))
+	for idx, lock := range locks {
+		hostIDs[idx] = lock.ID
+	}
+
+	hostIPMap, hostBizMap, err := hm.getHostAuditInfo(kit, hostIDs)
+	if err != nil {
+		return err
+	}
+
+	auditLogs := make([]metadata.AuditLog, len(locks))
+	for idx, lock := range locks {
+		lockData := map[string]interface{}{
+			common.HostLockUserField: lock.User,
+			"reason":                 lock.Reason,
+			common.CreateTimeField:   lock.CreateTime,
+		}
+		if lock.ExpireTime != nil {
+			lockData[common.HostLockExpireTimeField] = *lock.ExpireTime
+		}
+
+		auditLogs[idx] = metadata.AuditLog{
+			AuditType:    metadata.HostType,
+			ResourceType: metadata.HostRes,
+			Action:       metadata.AuditOverrideHostLock,
+			BusinessID:   hostBizMap[lock.ID],
+			ResourceID:   lock.ID,
+			ResourceName: hostIPMap[lock.ID],
+			OperationDetail: &metadata.InstanceOpDetail{
+				BasicOpDetail: metadata.BasicOpDetail{
+					Details: &metadata.BasicContent{
+						PreData:      lockData,
+						UpdateFields: map[string]interface{}{"reason": reason},
+					},
+				},
+				ModelID: common.BKInnerObjIDHost,
+			},
+		}
+	}
+
+	if err := hm.dependent.CreateAuditLogDependence(kit, auditLogs...); err != nil {
+		blog.Errorf("save host lock override audit log failed, err: %v, rid: %s", err, kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrCommDBInsertFailed)
+	}
+	return nil
+}
+
+// getHostAuditInfo get the inner ip and business id of the hosts for the host audit log
+func (hm *hostManager) getHostAuditInfo(kit *rest.Kit, hostIDs []int64) (map[int64]string, map[int64]int64,
+	errors.CCErrorCoder) {
+
+	hostCond := mapstr.MapStr{common.BKHostIDField: mapstr.MapStr{common.BKDBIN: hostIDs}}
+	hosts := make([]metadata.HostMapStr, 0)
+	err := mongodb.Client().Table(common.BKTableNameBaseHost).Find(hostCond).Fields(common.BKHostIDField,
+		common.BKHostInnerIPField).All(kit.Ctx, &hosts)
+	if err != nil {
+		blog.Errorf("get hosts failed, err: %v, cond: %+v, rid: %s", err, hostCond, kit.Rid)
+		return nil, nil, kit.CCError.CCErrorf(common.CCErrCommDBSelectFailed)
+	}
+
+	hostIPMap := make(map[int64]string)
+	for _, host := range hosts {
+		hostID, err := util.GetInt64ByInterface(host[common.BKHostIDField])
+		if err != nil {
+			blog.Errorf("parse host id failed, err: %v, host: %+v, rid: %s", err, host, kit.Rid)
+			return nil, nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKHostIDField)
+		}
+		hostIPMap[hostID] = util.GetStrByInterface(host[common.BKHostInnerIPField])
+	}
+
+	relations := make([]metadata.ModuleHost, 0)
+	err = mongodb.Client().Table(common.BKTableNameModuleHostConfig).Find(hostCond).Fields(common.BKHostIDField,
+		common.BKAppIDField).All(kit.Ctx, &relations)
+	if err != nil {
+		blog.Errorf("get host relations failed, err: %v, cond: %+v, rid: %s", err, hostCond, kit.Rid)
+		return nil, nil, kit.CCError.CCErrorf(common.CCErrCommDBSelectFailed)
+	}
+
+	hostBizMap := make(map[int64]int64)
+	for _, relation := range relations {
+		hostBizMap[relation.HostID] = relation.AppID
+	}
+
+	return hostIPMap, hostBizMap, nil
 }
 
 func diffHostLockID(ids []int64, hostInfos []metadata.HostMapStr, rid string) []int64 {
@@ -0,0 +1,633 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// duplicateHostAggregation is the aggregation result of the hosts that share the same field value
+type duplicateHostAggregation struct {
+	Value   interface{} `bson:"_id"`
+	HostIDs []int64     `bson:"host_ids"`
+}
+
+// FindDuplicateHosts find the hosts that share the same value of the matching fields, e.g. the hosts that are
+// re-installed with a new inner ip or imported twice.
+func (hm *hostManager) FindDuplicateHosts(kit *rest.Kit, option *metadata.FindDuplicateHostOption) (
+	[]metadata.DuplicateHostGroup, errors.CCErrorCoder) {
+
+	groups := make([]metadata.DuplicateHostGroup, 0)
+	for _, field := range option.MatchFields {
+		filter := map[string]interface{}{
+			field: map[string]interface{}{common.BKDBNIN: []interface{}{nil, ""}},
+		}
+		pipeline := []map[string]interface{}{
+			{common.BKDBMatch: util.SetQueryOwner(filter, kit.SupplierAccount)},
+			{common.BKDBGroup: map[string]interface{}{
+				"_id":      "$" + field,
+				"host_ids": map[string]interface{}{common.BKDBPush: "$" + common.BKHostIDField},
+				"count":    map[string]interface{}{common.BKDBSum: 1},
+			}},
+			{common.BKDBMatch: map[string]interface{}{"count": map[string]interface{}{common.BKDBGT: 1}}},
+			{common.BKDBSort: map[string]interface{}{"_id": 1}},
+			{common.BKDBLimit: option.Limit},
+		}
+
+		result := make([]duplicateHostAggregation, 0)
+		err := mongodb.Client().Table(common.BKTableNameBaseHost).AggregateAll(kit.Ctx, pipeline, &result)
+		if err != nil {
+			blog.Errorf("aggregate duplicate hosts failed, field: %s, err: %v, rid: %s", field, err, kit.Rid)
+			return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+		}
+
+		for _, item := range result {
+			groups = append(groups, metadata.DuplicateHostGroup{Field: field, Value: item.Value,
+				HostIDs: item.HostIDs})
+		}
+	}
+
+	return groups, nil
+}
+
+// MergeHost merge the duplicate hosts into the kept host, the module relations, service instances, processes and
+// instance associations of the duplicate hosts are moved to the kept host, the host apply rules of the added
+// modules are applied to the kept host, then the duplicate hosts are deleted with their locks and snapshot data.
+// only returns what would be moved without changing anything if preview is set.
+func (hm *hostManager) MergeHost(kit *rest.Kit, option *metadata.MergeHostOption, preview bool) (
+	*metadata.MergeHostResult, errors.CCErrorCoder) {
+
+	hostIDs := append([]int64{option.HostID}, option.DuplicateHostIDs...)
+	hosts, err := hm.getMergeHosts(kit, hostIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// merging transfers the duplicate hosts' modules to the kept host, so it's restricted like the transfers
+	if !preview {
+		if err := hm.checkHostTransfer(kit, hostIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &metadata.MergeHostResult{
+		HostID:           option.HostID,
+		DuplicateHostIDs: option.DuplicateHostIDs,
+	}
+
+	addedRelations, err := hm.planMergeHostRelations(kit, option, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hm.planMergeHostServiceInstances(kit, option, result); err != nil {
+		return nil, err
+	}
+
+	movedAssts, err := hm.planMergeHostAssociations(kit, option, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if preview {
+		return result, nil
+	}
+
+	if err := hm.moveMergeHostRelations(kit, option, result, addedRelations); err != nil {
+		return nil, err
+	}
+
+	if err := hm.moveMergeHostServiceInstances(kit, result); err != nil {
+		return nil, err
+	}
+
+	if err := hm.moveMergeHostAssociations(kit, movedAssts, result.DroppedAssociationIDs); err != nil {
+		return nil, err
+	}
+
+	hostCond := util.SetModOwner(map[string]interface{}{
+		common.BKHostIDField: map[string]interface{}{common.BKDBIN: option.DuplicateHostIDs},
+	}, kit.SupplierAccount)
+	if err := mongodb.Client().Table(common.BKTableNameBaseHost).Delete(kit.Ctx, hostCond); err != nil {
+		blog.Errorf("delete duplicate hosts failed, err: %v, cond: %+v, rid: %s", err, hostCond, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBDeleteFailed)
+	}
+
+	if err := hm.deleteMergeHostData(kit, option.DuplicateHostIDs); err != nil {
+		return nil, err
+	}
+
+	if len(addedRelations) > 0 {
+		if _, err := hm.hostApplyDependence.RunHostApplyOnHosts(kit, result.BizID, addedRelations); err != nil {
+			blog.Errorf("run host apply rule on merged host failed, err: %v, rid: %s", err, kit.Rid)
+			return nil, err
+		}
+	}
+
+	if err := hm.saveMergeHostAudit(kit, hosts, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getMergeHosts get the hosts to be merged, all the hosts must exist
+func (hm *hostManager) getMergeHosts(kit *rest.Kit, hostIDs []int64) ([]metadata.HostMapStr, errors.CCErrorCoder) {
+	cond := util.SetQueryOwner(map[string]interface{}{
+		common.BKHostIDField: map[string]interface{}{common.BKDBIN: hostIDs},
+	}, kit.SupplierAccount)
+
+	hosts := make([]metadata.HostMapStr, 0)
+	if err := mongodb.Client().Table(common.BKTableNameBaseHost).Find(cond).All(kit.Ctx, &hosts); err != nil {
+		blog.Errorf("get merge hosts failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	if len(hosts) != len(hostIDs) {
+		blog.Errorf("some merge hosts are not exist, host ids: %v, rid: %s", hostIDs, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKHostIDField)
+	}
+	return hosts, nil
+}
+
+// planMergeHostRelations plan the module relations of the kept host, which are the union of the modules of all the
+// merged hosts. the inner modules, e.g. the idle module, are dropped if the kept host is in any normal module.
+// returns the module relations to be added to the kept host.
+func (hm *hostManager) planMergeHostRelations(kit *rest.Kit, option *metadata.MergeHostOption,
+	result *metadata.MergeHostResult) ([]metadata.ModuleHost, errors.CCErrorCoder) {
+
+	relCond := util.SetQueryOwner(map[string]interface{}{
+		common.BKHostIDField: map[string]interface{}{
+			common.BKDBIN: append([]int64{option.HostID}, option.DuplicateHostIDs...),
+		},
+	}, kit.SupplierAccount)
+	relations := make([]metadata.ModuleHost, 0)
+	err := mongodb.Client().Table(common.BKTableNameModuleHostConfig).Find(relCond).All(kit.Ctx, &relations)
+	if err != nil {
+		blog.Errorf("get merge host relations failed, err: %v, cond: %+v, rid: %s", err, relCond, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	moduleIDs := make([]int64, 0)
+	keptModuleMap := make(map[int64]struct{})
+	for _, relation := range relations {
+		if result.BizID == 0 {
+			result.BizID = relation.AppID
+		}
+
+		if relation.AppID != result.BizID {
+			blog.Errorf("merge hosts belong to different business, relations: %+v, rid: %s", relations, kit.Rid)
+			return nil, kit.CCError.CCError(common.CCErrCoreServiceHostMergeBizNotMatch)
+		}
+
+		if relation.HostID == option.HostID {
+			keptModuleMap[relation.ModuleID] = struct{}{}
+		}
+		moduleIDs = append(moduleIDs, relation.ModuleID)
+	}
+
+	moduleCond := util.SetQueryOwner(map[string]interface{}{
+		common.BKModuleIDField: map[string]interface{}{common.BKDBIN: util.IntArrayUnique(moduleIDs)},
+	}, kit.SupplierAccount)
+	modules := make([]metadata.ModuleInst, 0)
+	err = mongodb.Client().Table(common.BKTableNameBaseModule).Find(moduleCond).Fields(common.BKModuleIDField,
+		common.BKSetIDField, common.BKDefaultField, common.HostApplyEnabledField).All(kit.Ctx, &modules)
+	if err != nil {
+		blog.Errorf("get merge host modules failed, err: %v, cond: %+v, rid: %s", err, moduleCond, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	hasNormalModule := false
+	for _, module := range modules {
+		if module.Default == int64(common.NormalModuleFlag) {
+			hasNormalModule = true
+			break
+		}
+	}
+
+	result.AddedModuleIDs = make([]int64, 0)
+	result.RemovedModuleIDs = make([]int64, 0)
+	result.HostApplyModuleIDs = make([]int64, 0)
+	addedRelations := make([]metadata.ModuleHost, 0)
+	for _, module := range modules {
+		_, isKept := keptModuleMap[module.ModuleID]
+		isNormal := module.Default == int64(common.NormalModuleFlag)
+
+		switch {
+		case isKept && hasNormalModule && !isNormal:
+			result.RemovedModuleIDs = append(result.RemovedModuleIDs, module.ModuleID)
+		case !isKept && isNormal:
+			result.AddedModuleIDs = append(result.AddedModuleIDs, module.ModuleID)
+			addedRelations = append(addedRelations, metadata.ModuleHost{
+				AppID:    result.BizID,
+				HostID:   option.HostID,
+				ModuleID: module.ModuleID,
+				SetID:    module.SetID,
+				OwnerID:  kit.SupplierAccount,
+			})
+			if module.HostApplyEnabled {
+				result.HostApplyModuleIDs = append(result.HostApplyModuleIDs, module.ModuleID)
+			}
+		}
+	}
+
+	return addedRelations, nil
+}
+
+// planMergeHostServiceInstances plan the service instances and processes of the duplicate hosts, the ones in the
+// modules that the kept host or a former duplicate host already has service instances in are dropped, so that the
+// kept host won't have duplicate service instances, the others are moved to the kept host.
+func (hm *hostManager) planMergeHostServiceInstances(kit *rest.Kit, option *metadata.MergeHostOption,
+	result *metadata.MergeHostResult) errors.CCErrorCoder {
+
+	cond := util.SetQueryOwner(map[string]interface{}{
+		common.BKHostIDField: map[string]interface{}{
+			common.BKDBIN: append([]int64{option.HostID}, option.DuplicateHostIDs...),
+		},
+	}, kit.SupplierAccount)
+
+	instances := make([]metadata.ServiceInstance, 0)
+	err := mongodb.Client().Table(common.BKTableNameServiceInstance).Find(cond).Fields(common.BKFieldID,
+		common.BKHostIDField, common.BKModuleIDField).All(kit.Ctx, &instances)
+	if err != nil {
+		blog.Errorf("get merge host service instances failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	hostInstMap := make(map[int64][]metadata.ServiceInstance)
+	for _, instance := range instances {
+		hostInstMap[instance.HostID] = append(hostInstMap[instance.HostID], instance)
+	}
+
+	// moduleHostMap is the host whose service instances are kept in the module
+	moduleHostMap := make(map[int64]int64)
+	for _, instance := range hostInstMap[option.HostID] {
+		moduleHostMap[instance.ModuleID] = option.HostID
+	}
+
+	result.ServiceInstanceIDs = make([]int64, 0)
+	result.DroppedServiceInstanceIDs = make([]int64, 0)
+	for _, hostID := range option.DuplicateHostIDs {
+		for _, instance := range hostInstMap[hostID] {
+			if keptHostID, exists := moduleHostMap[instance.ModuleID]; exists && keptHostID != hostID {
+				result.DroppedServiceInstanceIDs = append(result.DroppedServiceInstanceIDs, instance.ID)
+				continue
+			}
+
+			moduleHostMap[instance.ModuleID] = hostID
+			result.ServiceInstanceIDs = append(result.ServiceInstanceIDs, instance.ID)
+		}
+	}
+
+	result.ProcessIDs = make([]int64, 0)
+	result.DroppedProcessIDs = make([]int64, 0)
+	if len(result.ServiceInstanceIDs) == 0 && len(result.DroppedServiceInstanceIDs) == 0 {
+		return nil
+	}
+
+	relCond := util.SetQueryOwner(map[string]interface{}{
+		common.BKServiceInstanceIDField: map[string]interface{}{
+			common.BKDBIN: append(result.ServiceInstanceIDs, result.DroppedServiceInstanceIDs...),
+		},
+	}, kit.SupplierAccount)
+	processRelations := make([]metadata.ProcessInstanceRelation, 0)
+	err = mongodb.Client().Table(common.BKTableNameProcessInstanceRelation).Find(relCond).Fields(
+		common.BKProcessIDField, common.BKServiceInstanceIDField).All(kit.Ctx, &processRelations)
+	if err != nil {
+		blog.Errorf("get duplicate host processes failed, err: %v, cond: %+v, rid: %s", err, relCond, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	droppedInstMap := make(map[int64]struct{})
+	for _, instanceID := range result.DroppedServiceInstanceIDs {
+		droppedInstMap[instanceID] = struct{}{}
+	}
+
+	for _, relation := range processRelations {
+		if _, exists := droppedInstMap[relation.ServiceInstanceID]; exists {
+			result.DroppedProcessIDs = append(result.DroppedProcessIDs, relation.ProcessID)
+			continue
+		}
+		result.ProcessIDs = append(result.ProcessIDs, relation.ProcessID)
+	}
+	return nil
+}
+
+// mergeHostAsstKey is the unique key of an instance association
+type mergeHostAsstKey struct {
+	objAsstID  string
+	instID     int64
+	asstInstID int64
+}
+
+// planMergeHostAssociations get the instance associations of the duplicate hosts and replace the duplicate host
+// ids in them with the kept host id. the associations that the kept host already has, or that become associations
+// between the kept host and itself are dropped. returns the associations to be moved.
+func (hm *hostManager) planMergeHostAssociations(kit *rest.Kit, option *metadata.MergeHostOption,
+	result *metadata.MergeHostResult) ([]metadata.InstAsst, errors.CCErrorCoder) {
+
+	keptAssts, err := hm.getHostAssociations(kit, []int64{option.HostID})
+	if err != nil {
+		return nil, err
+	}
+
+	asstKeyMap := make(map[mergeHostAsstKey]struct{})
+	for _, asst := range keptAssts {
+		asstKeyMap[mergeHostAsstKey{objAsstID: asst.ObjectAsstID, instID: asst.InstID,
+			asstInstID: asst.AsstInstID}] = struct{}{}
+	}
+
+	duplicateAssts, err := hm.getHostAssociations(kit, option.DuplicateHostIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicateMap := make(map[int64]struct{})
+	for _, hostID := range option.DuplicateHostIDs {
+		duplicateMap[hostID] = struct{}{}
+	}
+
+	result.AssociationIDs = make([]int64, 0)
+	result.DroppedAssociationIDs = make([]int64, 0)
+	movedAssts := make([]metadata.InstAsst, 0)
+	for _, asst := range duplicateAssts {
+		if _, exists := duplicateMap[asst.InstID]; exists && asst.ObjectID == common.BKInnerObjIDHost {
+			asst.InstID = option.HostID
+		}
+		if _, exists := duplicateMap[asst.AsstInstID]; exists && asst.AsstObjectID == common.BKInnerObjIDHost {
+			asst.AsstInstID = option.HostID
+		}
+
+		key := mergeHostAsstKey{objAsstID: asst.ObjectAsstID, instID: asst.InstID, asstInstID: asst.AsstInstID}
+		_, exists := asstKeyMap[key]
+		isSelfAsst := asst.ObjectID == asst.AsstObjectID && asst.InstID == asst.AsstInstID
+		if exists || isSelfAsst {
+			result.DroppedAssociationIDs = append(result.DroppedAssociationIDs, asst.ID)
+			continue
+		}
+
+		asstKeyMap[key] = struct{}{}
+		result.AssociationIDs = append(result.AssociationIDs, asst.ID)
+		movedAssts = append(movedAssts, asst)
+	}
+
+	return movedAssts, nil
+}
+
+// getHostAssociations get the instance associations of the hosts
+func (hm *hostManager) getHostAssociations(kit *rest.Kit, hostIDs []int64) ([]metadata.InstAsst,
+	errors.CCErrorCoder) {
+
+	cond := util.SetQueryOwner(map[string]interface{}{
+		common.BKDBOR: []map[string]interface{}{
+			{
+				common.BKObjIDField:  common.BKInnerObjIDHost,
+				common.BKInstIDField: map[string]interface{}{common.BKDBIN: hostIDs},
+			},
+			{
+				common.BKAsstObjIDField:  common.BKInnerObjIDHost,
+				common.BKAsstInstIDField: map[string]interface{}{common.BKDBIN: hostIDs},
+			},
+		},
+	}, kit.SupplierAccount)
+
+	assts := make([]metadata.InstAsst, 0)
+	tableName := common.GetObjectInstAsstTableName(common.BKInnerObjIDHost, kit.SupplierAccount)
+	if err := mongodb.Client().Table(tableName).Find(cond).All(kit.Ctx, &assts); err != nil {
+		blog.Errorf("get host associations failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+	return assts, nil
+}
+
+// moveMergeHostRelations delete the module relations of the duplicate hosts and the dropped inner module
+// relations of the kept host, then add the planned module relations to the kept host
+func (hm *hostManager) moveMergeHostRelations(kit *rest.Kit, option *metadata.MergeHostOption,
+	result *metadata.MergeHostResult, addedRelations []metadata.ModuleHost) errors.CCErrorCoder {
+
+	delCond := util.SetModOwner(map[string]interface{}{
+		common.BKDBOR: []map[string]interface{}{
+			{common.BKHostIDField: map[string]interface{}{common.BKDBIN: option.DuplicateHostIDs}},
+			{
+				common.BKHostIDField:   option.HostID,
+				common.BKModuleIDField: map[string]interface{}{common.BKDBIN: result.RemovedModuleIDs},
+			},
+		},
+	}, kit.SupplierAccount)
+	if err := mongodb.Client().Table(common.BKTableNameModuleHostConfig).Delete(kit.Ctx, delCond); err != nil {
+		blog.Errorf("delete merge host relations failed, err: %v, cond: %+v, rid: %s", err, delCond, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBDeleteFailed)
+	}
+
+	if len(addedRelations) == 0 {
+		return nil
+	}
+
+	if err := mongodb.Client().Table(common.BKTableNameModuleHostConfig).Insert(kit.Ctx, addedRelations); err != nil {
+		blog.Errorf("add merged host relations failed, err: %v, relations: %+v, rid: %s", err, addedRelations,
+			kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBInsertFailed)
+	}
+	return nil
+}
+
+// moveMergeHostServiceInstances move the planned service instances and processes of the duplicate hosts to the kept
+// host, and delete the dropped service instances with their processes
+func (hm *hostManager) moveMergeHostServiceInstances(kit *rest.Kit,
+	result *metadata.MergeHostResult) errors.CCErrorCoder {
+
+	if len(result.ServiceInstanceIDs) > 0 {
+		data := map[string]interface{}{common.BKHostIDField: result.HostID}
+		instTableCondMap := map[string]map[string]interface{}{
+			common.BKTableNameServiceInstance: {
+				common.BKFieldID: map[string]interface{}{common.BKDBIN: result.ServiceInstanceIDs},
+			},
+			common.BKTableNameProcessInstanceRelation: {
+				common.BKServiceInstanceIDField: map[string]interface{}{common.BKDBIN: result.ServiceInstanceIDs},
+			},
+		}
+
+		for table, cond := range instTableCondMap {
+			cond = util.SetModOwner(cond, kit.SupplierAccount)
+			if err := mongodb.Client().Table(table).Update(kit.Ctx, cond, data); err != nil {
+				blog.Errorf("move duplicate host data in %s failed, err: %v, cond: %+v, rid: %s", table, err, cond,
+					kit.Rid)
+				return kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
+			}
+		}
+	}
+
+	if len(result.DroppedServiceInstanceIDs) == 0 {
+		return nil
+	}
+
+	dropCondMap := map[string]map[string]interface{}{
+		common.BKTableNameProcessInstanceRelation: {
+			common.BKServiceInstanceIDField: map[string]interface{}{common.BKDBIN: result.DroppedServiceInstanceIDs},
+		},
+		common.BKTableNameServiceInstance: {
+			common.BKFieldID: map[string]interface{}{common.BKDBIN: result.DroppedServiceInstanceIDs},
+		},
+	}
+	if len(result.DroppedProcessIDs) > 0 {
+		dropCondMap[common.BKTableNameBaseProcess] = map[string]interface{}{
+			common.BKProcessIDField: map[string]interface{}{common.BKDBIN: result.DroppedProcessIDs},
+		}
+	}
+
+	for table, cond := range dropCondMap {
+		cond = util.SetModOwner(cond, kit.SupplierAccount)
+		if err := mongodb.Client().Table(table).Delete(kit.Ctx, cond); err != nil {
+			blog.Errorf("delete dropped duplicate host data in %s failed, err: %v, cond: %+v, rid: %s", table, err,
+				cond, kit.Rid)
+			return kit.CCError.CCError(common.CCErrCommDBDeleteFailed)
+		}
+	}
+	return nil
+}
+
+// deleteMergeHostData delete the data that only belongs to the deleted duplicate hosts, which are the host locks and
+// the host snapshot history, change logs, drifts and status
+func (hm *hostManager) deleteMergeHostData(kit *rest.Kit, hostIDs []int64) errors.CCErrorCoder {
+	cond := util.SetModOwner(map[string]interface{}{
+		common.BKHostIDField: map[string]interface{}{common.BKDBIN: hostIDs},
+	}, kit.SupplierAccount)
+
+	tables := []string{common.BKTableNameHostLock, common.BKTableNameHostSnapHistory,
+		common.BKTableNameHostSnapChangeLog, common.BKTableNameHostSnapDrift, common.BKTableNameHostSnapStatus}
+	for _, table := range tables {
+		if err := mongodb.Client().Table(table).Delete(kit.Ctx, cond); err != nil {
+			blog.Errorf("delete duplicate host data in %s failed, err: %v, cond: %+v, rid: %s", table, err, cond,
+				kit.Rid)
+			return kit.CCError.CCError(common.CCErrCommDBDeleteFailed)
+		}
+	}
+	return nil
+}
+
+// moveMergeHostAssociations update the moved instance associations and delete the dropped ones, the instance
+// associations are stored in the association tables of both the source and the target object.
+func (hm *hostManager) moveMergeHostAssociations(kit *rest.Kit, movedAssts []metadata.InstAsst,
+	droppedIDs []int64) errors.CCErrorCoder {
+
+	hostAsstTable := common.GetObjectInstAsstTableName(common.BKInnerObjIDHost, kit.SupplierAccount)
+	for _, asst := range movedAssts {
+		cond := util.SetModOwner(map[string]interface{}{common.BKFieldID: asst.ID}, kit.SupplierAccount)
+		data := map[string]interface{}{
+			common.BKInstIDField:     asst.InstID,
+			common.BKAsstInstIDField: asst.AsstInstID,
+		}
+
+		tables := []string{hostAsstTable}
+		if otherObjID := getAsstOtherObjID(asst); otherObjID != common.BKInnerObjIDHost {
+			tables = append(tables, common.GetObjectInstAsstTableName(otherObjID, kit.SupplierAccount))
+		}
+
+		for _, table := range tables {
+			if err := mongodb.Client().Table(table).Update(kit.Ctx, cond, data); err != nil {
+				blog.Errorf("move host association in %s failed, err: %v, cond: %+v, rid: %s", table, err, cond,
+					kit.Rid)
+				return kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
+			}
+		}
+	}
+
+	if len(droppedIDs) == 0 {
+		return nil
+	}
+
+	cond := util.SetModOwner(map[string]interface{}{
+		common.BKFieldID: map[string]interface{}{common.BKDBIN: droppedIDs},
+	}, kit.SupplierAccount)
+	droppedAssts := make([]metadata.InstAsst, 0)
+	err := mongodb.Client().Table(hostAsstTable).Find(cond).Fields(common.BKObjIDField, common.BKAsstObjIDField).
+		All(kit.Ctx, &droppedAssts)
+	if err != nil {
+		blog.Errorf("get dropped host associations failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	tableMap := map[string]struct{}{hostAsstTable: {}}
+	for _, asst := range droppedAssts {
+		tableMap[common.GetObjectInstAsstTableName(getAsstOtherObjID(asst), kit.SupplierAccount)] = struct{}{}
+	}
+
+	for table := range tableMap {
+		if err := mongodb.Client().Table(table).Delete(kit.Ctx, cond); err != nil {
+			blog.Errorf("delete dropped host associations in %s failed, err: %v, cond: %+v, rid: %s", table, err,
+				cond, kit.Rid)
+			return kit.CCError.CCError(common.CCErrCommDBDeleteFailed)
+		}
+	}
+	return nil
+}
+
+// getAsstOtherObjID get the object on the other side of the host in the instance association
+func getAsstOtherObjID(asst metadata.InstAsst) string {
+	if asst.ObjectID == common.BKInnerObjIDHost {
+		return asst.AsstObjectID
+	}
+	return asst.ObjectID
+}
+
+// saveMergeHostAudit save the merge host audit log, which records the deleted duplicate hosts and the data moved
+func (hm *hostManager) saveMergeHostAudit(kit *rest.Kit, hosts []metadata.HostMapStr,
+	result *metadata.MergeHostResult) errors.CCErrorCoder {
+
+	var keptHost metadata.HostMapStr
+	duplicateHosts := make([]metadata.HostMapStr, 0)
+	for _, host := range hosts {
+		hostID, err := util.GetInt64ByInterface(host[common.BKHostIDField])
+		if err != nil {
+			blog.Errorf("parse host id failed, err: %v, host: %+v, rid: %s", err, host, kit.Rid)
+			return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKHostIDField)
+		}
+
+		if hostID == result.HostID {
+			keptHost = host
+			continue
+		}
+		duplicateHosts = append(duplicateHosts, host)
+	}
+
+	audit := metadata.AuditLog{
+		AuditType:    metadata.HostType,
+		ResourceType: metadata.HostRes,
+		Action:       metadata.AuditMergeHost,
+		BusinessID:   result.BizID,
+		ResourceID:   result.HostID,
+		ResourceName: util.GetStrByInterface(keptHost[common.BKHostInnerIPField]),
+		OperationDetail: &metadata.InstanceOpDetail{
+			BasicOpDetail: metadata.BasicOpDetail{
+				Details: &metadata.BasicContent{
+					PreData: mapstr.MapStr{"duplicate_hosts": duplicateHosts},
+					CurData: mapstr.MapStr{"merge_result": result},
+				},
+			},
+			ModelID: common.BKInnerObjIDHost,
+		},
+	}
+
+	if err := hm.dependent.CreateAuditLogDependence(kit, audit); err != nil {
+		blog.Errorf("save merge host audit log failed, err: %v, rid: %s", err, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBInsertFailed)
+	}
+	return nil
+}
@@ -107,6 +107,10 @@ func (s *Searcher) ListHosts(ctx context.Context, option metadata.ListHosts) (se
 		finalFilter[common.BKDBAND] = filters
 	}
 
+	if option.Page.IsCursorPage() {
+		return s.listHostsWithCursor(ctx, option.Fields, option.Page, finalFilter, len(filters) == 0, rid)
+	}
+
 	if needHostIDFilter && len(filters) == 1 && option.BizID != 0 {
 		sort := strings.TrimLeft(option.Page.Sort, "+-")
 		if len(option.Page.Sort) == 0 || sort == common.BKHostIDField || strings.Contains(sort, ",") == false &&
@@ -173,7 +177,7 @@ func (s *Searcher) ListHostsWithCache(ctx context.Context, fields []string, page
 		Fields: fields,
 		Page:   page,
 	}
-	total, infos, err := s.CacheHost.ListHostsWithPage(ctx, opt)
+	total, infos, nextCursor, err := s.CacheHost.ListHostsWithPage(ctx, opt)
 	if err != nil {
 		blog.ErrorJSON("list host from redis error, filter: %s, err: %s, rid: %s", opt, err.Error(), rid)
 		// HOOK: 缓存出现错误从db中获取数据
@@ -181,6 +185,7 @@ func (s *Searcher) ListHostsWithCache(ctx context.Context, fields []string, page
 	} else {
 		searchResult = &metadata.ListHostResult{}
 		searchResult.Count = int(total)
+		searchResult.NextCursor = nextCursor
 
 		searchResult.Info = make([]map[string]interface{}, 0)
 		if err := json.UnmarshalArray(infos, &searchResult.Info); err != nil {
@@ -235,3 +240,61 @@ func (s *Searcher) listAllBizHostsPage(ctx context.Context, fields []string, pag
 	}
 	return searchResult, nil
 }
+
+// listHostsWithCursor list hosts with keyset pagination that sorts on the host id, use cache if there is no filter.
+func (s *Searcher) listHostsWithCursor(ctx context.Context, fields []string, page metadata.BasePage,
+	filter map[string]interface{}, useCache bool, rid string) (*metadata.ListHostResult, error) {
+
+	cursor, err := page.ParsePageCursor(common.BKHostIDField)
+	if err != nil {
+		blog.Errorf("list hosts with cursor failed, invalid page: %+v, err: %v, rid: %s", page, err, rid)
+		return nil, err
+	}
+
+	if useCache {
+		searchResult, skip, err := s.ListHostsWithCache(ctx, fields, page, rid)
+		if err != nil {
+			return nil, err
+		}
+		if !skip {
+			return searchResult, nil
+		}
+	}
+
+	total, err := mongodb.Client().Table(common.BKTableNameBaseHost).Find(filter).Count(ctx)
+	if err != nil {
+		blog.Errorf("list hosts with cursor failed, db count failed, filter: %+v, err: %v, rid: %s", filter, err, rid)
+		return nil, err
+	}
+
+	if len(fields) != 0 && !util.InStrArr(fields, common.BKHostIDField) {
+		fields = append(fields, common.BKHostIDField)
+	}
+
+	// get one more host to check if there are more hosts.
+	cursorFilter := cursor.MergeCondition(filter)
+	hosts := make([]metadata.HostMapStr, 0)
+	if err := mongodb.Client().Table(common.BKTableNameBaseHost).Find(cursorFilter).Limit(uint64(page.Limit+1)).
+		Sort(cursor.Sort()).Fields(fields...).All(ctx, &hosts); err != nil {
+		blog.Errorf("list hosts with cursor failed, db select failed, filter: %+v, err: %v, rid: %s", cursorFilter,
+			err, rid)
+		return nil, err
+	}
+
+	searchResult := &metadata.ListHostResult{Count: int(total)}
+	if len(hosts) > page.Limit {
+		hosts = hosts[:page.Limit]
+		lastID, err := util.GetInt64ByInterface(hosts[len(hosts)-1][common.BKHostIDField])
+		if err != nil {
+			blog.Errorf("list hosts with cursor failed, parse host id failed, err: %v, rid: %s", err, rid)
+			return nil, err
+		}
+		searchResult.NextCursor = cursor.Next(lastID)
+	}
+
+	searchResult.Info = make([]map[string]interface{}, len(hosts))
+	for index, host := range hosts {
+		searchResult.Info[index] = host
+	}
+	return searchResult, nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// SearchHostSnapHistory search the downsampled snapshot history of a host, sorted by time in ascending order
+// by default.
+func (hm *hostManager) SearchHostSnapHistory(kit *rest.Kit, option *metadata.SearchHostSnapHistoryOption) (
+	*metadata.HostSnapHistoryResult, errors.CCErrorCoder) {
+
+	cond := metadata.HostSnapTimeCond(option.HostID, option.StartTime, option.EndTime)
+	cond = util.SetQueryOwner(cond, kit.SupplierAccount)
+
+	if option.Page.EnableCount {
+		count, err := mongodb.Client().Table(common.BKTableNameHostSnapHistory).Find(cond).Count(kit.Ctx)
+		if err != nil {
+			blog.Errorf("count host snapshot history failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+			return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+		}
+		return &metadata.HostSnapHistoryResult{Count: count}, nil
+	}
+
+	sort := option.Page.Sort
+	if len(sort) == 0 {
+		sort = common.HostSnapTimeField
+	}
+
+	histories := make([]metadata.HostSnapHistory, 0)
+	err := mongodb.Client().Table(common.BKTableNameHostSnapHistory).Find(cond).Fields(option.GetFields()...).
+		Start(uint64(option.Page.Start)).Limit(uint64(option.Page.Limit)).Sort(sort).All(kit.Ctx, &histories)
+	if err != nil {
+		blog.Errorf("search host snapshot history failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	for idx := range histories {
+		histories[idx].CalcAvg()
+	}
+
+	return &metadata.HostSnapHistoryResult{Info: histories}, nil
+}
+
+// SearchHostSnapChangeLog search the hardware and os field change logs of a host reported by snapshot,
+// sorted by time in descending order by default.
+func (hm *hostManager) SearchHostSnapChangeLog(kit *rest.Kit, option *metadata.SearchHostSnapChangeLogOption) (
+	*metadata.HostSnapChangeLogResult, errors.CCErrorCoder) {
+
+	cond := metadata.HostSnapTimeCond(option.HostID, option.StartTime, option.EndTime)
+	if len(option.PropertyIDs) > 0 {
+		cond[common.BKPropertyIDField] = map[string]interface{}{common.BKDBIN: option.PropertyIDs}
+	}
+	cond = util.SetQueryOwner(cond, kit.SupplierAccount)
+
+	if option.Page.EnableCount {
+		count, err := mongodb.Client().Table(common.BKTableNameHostSnapChangeLog).Find(cond).Count(kit.Ctx)
+		if err != nil {
+			blog.Errorf("count host snapshot change log failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+			return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+		}
+		return &metadata.HostSnapChangeLogResult{Count: count}, nil
+	}
+
+	sort := option.Page.Sort
+	if len(sort) == 0 {
+		sort = "-" + common.HostSnapTimeField
+	}
+
+	changeLogs := make([]metadata.HostSnapChangeLog, 0)
+	err := mongodb.Client().Table(common.BKTableNameHostSnapChangeLog).Find(cond).
+		Start(uint64(option.Page.Start)).Limit(uint64(option.Page.Limit)).Sort(sort).All(kit.Ctx, &changeLogs)
+	if err != nil {
+		blog.Errorf("search host snapshot change log failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	return &metadata.HostSnapChangeLogResult{Info: changeLogs}, nil
+}
+
+// SearchHostSnapDrift search the drifts between the snapshot and the host in cmdb of the specified hosts
+func (hm *hostManager) SearchHostSnapDrift(kit *rest.Kit, option *metadata.SearchHostSnapDriftOption) (
+	*metadata.HostSnapDriftResult, errors.CCErrorCoder) {
+
+	cond := map[string]interface{}{
+		common.BKHostIDField: map[string]interface{}{common.BKDBIN: option.HostIDs},
+	}
+	if len(option.PropertyIDs) > 0 {
+		cond[common.BKPropertyIDField] = map[string]interface{}{common.BKDBIN: option.PropertyIDs}
+	}
+	cond = util.SetQueryOwner(cond, kit.SupplierAccount)
+
+	if option.Page.EnableCount {
+		count, err := mongodb.Client().Table(common.BKTableNameHostSnapDrift).Find(cond).Count(kit.Ctx)
+		if err != nil {
+			blog.Errorf("count host snapshot drift failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+			return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+		}
+		return &metadata.HostSnapDriftResult{Count: count}, nil
+	}
+
+	sort := option.Page.Sort
+	if len(sort) == 0 {
+		sort = common.BKHostIDField
+	}
+
+	drifts := make([]metadata.HostSnapDrift, 0)
+	err := mongodb.Client().Table(common.BKTableNameHostSnapDrift).Find(cond).
+		Start(uint64(option.Page.Start)).Limit(uint64(option.Page.Limit)).Sort(sort).All(kit.Ctx, &drifts)
+	if err != nil {
+		blog.Errorf("search host snapshot drift failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	return &metadata.HostSnapDriftResult{Info: drifts}, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// SearchHostSnapStatus search the last snapshot time and the stale flag of the specified hosts, sorted by the last
+// snapshot time in ascending order by default.
+func (hm *hostManager) SearchHostSnapStatus(kit *rest.Kit, option *metadata.SearchHostSnapStatusOption) (
+	*metadata.HostSnapStatusResult, errors.CCErrorCoder) {
+
+	cond := mapstr.MapStr{
+		common.BKHostIDField: mapstr.MapStr{common.BKDBIN: option.HostIDs},
+	}
+	if option.Stale != nil {
+		cond[common.HostSnapStaleField] = *option.Stale
+	}
+	cond = util.SetQueryOwner(cond, kit.SupplierAccount)
+
+	if option.Page.EnableCount {
+		count, err := mongodb.Client().Table(common.BKTableNameHostSnapStatus).Find(cond).Count(kit.Ctx)
+		if err != nil {
+			blog.Errorf("count host snapshot status failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+			return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+		}
+		return &metadata.HostSnapStatusResult{Count: count}, nil
+	}
+
+	sort := option.Page.Sort
+	if len(sort) == 0 {
+		sort = common.HostSnapLastTimeField
+	}
+
+	statuses := make([]metadata.HostSnapStatus, 0)
+	err := mongodb.Client().Table(common.BKTableNameHostSnapStatus).Find(cond).
+		Start(uint64(option.Page.Start)).Limit(uint64(option.Page.Limit)).Sort(sort).All(kit.Ctx, &statuses)
+	if err != nil {
+		blog.Errorf("search host snapshot status failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	return &metadata.HostSnapStatusResult{Info: statuses}, nil
+}
+
+// SaveStaleHostPolicy save the stale host policy of the supplier account
+func (hm *hostManager) SaveStaleHostPolicy(kit *rest.Kit, policy *metadata.StaleHostPolicy) errors.CCErrorCoder {
+	policy.SupplierAccount = kit.SupplierAccount
+	policy.Modifier = kit.User
+	policy.LastTime = time.Now()
+
+	cond := mapstr.MapStr{common.BkSupplierAccount: kit.SupplierAccount}
+	if err := mongodb.Client().Table(common.BKTableNameStaleHostPolicy).Upsert(kit.Ctx, cond, policy); err != nil {
+		blog.Errorf("save stale host policy failed, err: %v, policy: %+v, rid: %s", err, policy, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
+	}
+	return nil
+}
+
+// GetStaleHostPolicy get the stale host policy of the supplier account, returns a disabled policy if not configured
+func (hm *hostManager) GetStaleHostPolicy(kit *rest.Kit) (*metadata.StaleHostPolicy, errors.CCErrorCoder) {
+	cond := mapstr.MapStr{common.BkSupplierAccount: kit.SupplierAccount}
+
+	policy := new(metadata.StaleHostPolicy)
+	err := mongodb.Client().Table(common.BKTableNameStaleHostPolicy).Find(cond).One(kit.Ctx, policy)
+	if err != nil {
+		if mongodb.Client().IsNotFoundError(err) {
+			return &metadata.StaleHostPolicy{
+				Action:          metadata.StaleHostActionNone,
+				ExcludeBizIDs:   make([]int64, 0),
+				SupplierAccount: kit.SupplierAccount,
+			}, nil
+		}
+		blog.Errorf("get stale host policy failed, err: %v, cond: %+v, rid: %s", err, cond, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+	return policy, nil
+}
@@ -0,0 +1,142 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// CreateHostTransferSchedule create a waiting host transfer schedule, the creator is the original requester
+func (hm *hostManager) CreateHostTransferSchedule(kit *rest.Kit, schedule *metadata.HostTransferSchedule) (
+	*metadata.HostTransferSchedule, errors.CCErrorCoder) {
+
+	id, err := mongodb.Client().NextSequence(kit.Ctx, common.BKTableNameHostTransferSchedule)
+	if err != nil {
+		blog.Errorf("generate host transfer schedule id failed, err: %v, rid: %s", err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommGenerateRecordIDFailed)
+	}
+
+	now := time.Now()
+	schedule.ID = int64(id)
+	schedule.Status = metadata.HostTransferScheduleWaiting
+	schedule.TaskID = ""
+	schedule.Message = ""
+	schedule.Creator = kit.User
+	schedule.LastEditor = kit.User
+	schedule.OwnerID = kit.SupplierAccount
+	schedule.CreateTime = now
+	schedule.LastTime = now
+
+	if err := mongodb.Client().Table(common.BKTableNameHostTransferSchedule).Insert(kit.Ctx, schedule); err != nil {
+		blog.Errorf("create host transfer schedule failed, err: %v, schedule: %+v, rid: %s", err, schedule, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBInsertFailed)
+	}
+
+	return schedule, nil
+}
+
+// ListHostTransferSchedule list the host transfer schedules, the latest execute schedules are returned first
+func (hm *hostManager) ListHostTransferSchedule(kit *rest.Kit, option *metadata.ListHostTransferScheduleOption) (
+	*metadata.MultipleHostTransferSchedule, errors.CCErrorCoder) {
+
+	cond := mapstr.MapStr{}
+	if option.BizID > 0 {
+		cond[common.BKAppIDField] = option.BizID
+	}
+
+	if len(option.IDs) > 0 {
+		cond[common.BKFieldID] = mapstr.MapStr{common.BKDBIN: option.IDs}
+	}
+
+	if len(option.Statuses) > 0 {
+		cond[common.BKStatusField] = mapstr.MapStr{common.BKDBIN: option.Statuses}
+	}
+	cond = util.SetQueryOwner(cond, kit.SupplierAccount)
+
+	count, err := mongodb.Client().Table(common.BKTableNameHostTransferSchedule).Find(cond).Count(kit.Ctx)
+	if err != nil {
+		blog.Errorf("count host transfer schedules failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	sort := option.Page.Sort
+	if len(sort) == 0 {
+		sort = "-" + common.BKExecuteTimeField
+	}
+
+	schedules := make([]metadata.HostTransferSchedule, 0)
+	err = mongodb.Client().Table(common.BKTableNameHostTransferSchedule).Find(cond).Sort(sort).
+		Start(uint64(option.Page.Start)).Limit(uint64(option.Page.Limit)).All(kit.Ctx, &schedules)
+	if err != nil {
+		blog.Errorf("list host transfer schedules failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	return &metadata.MultipleHostTransferSchedule{Count: int64(count), Info: schedules}, nil
+}
+
+// UpdateHostTransferScheduleStatus change the status of the schedule if its current status is one of the from
+// statuses, returns error if the schedule's status is changed by others, e.g. a schedule is canceled and executed.
+func (hm *hostManager) UpdateHostTransferScheduleStatus(kit *rest.Kit,
+	option *metadata.UpdateHostTransferScheduleStatusOption) errors.CCErrorCoder {
+
+	cond := mapstr.MapStr{
+		common.BKFieldID:     option.ID,
+		common.BKStatusField: mapstr.MapStr{common.BKDBIN: option.From},
+	}
+	cond = util.SetModOwner(cond, kit.SupplierAccount)
+
+	data := mapstr.MapStr{
+		common.BKStatusField: option.To,
+		common.BKLastEditor:  kit.User,
+		common.LastTimeField: time.Now(),
+		"message":            option.Message,
+	}
+
+	if len(option.TaskID) > 0 {
+		data[common.BKTaskIDField] = option.TaskID
+	}
+
+	updated, err := mongodb.Client().Table(common.BKTableNameHostTransferSchedule).UpdateMany(kit.Ctx, cond, data)
+	if err != nil {
+		blog.Errorf("update host transfer schedule status failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
+	}
+
+	if updated > 0 {
+		return nil
+	}
+
+	schedule := new(metadata.HostTransferSchedule)
+	cond = util.SetQueryOwner(mapstr.MapStr{common.BKFieldID: option.ID}, kit.SupplierAccount)
+	if err := mongodb.Client().Table(common.BKTableNameHostTransferSchedule).Find(cond).One(kit.Ctx,
+		schedule); err != nil {
+
+		if mongodb.Client().IsNotFoundError(err) {
+			return kit.CCError.CCErrorf(common.CCErrCommNotFound)
+		}
+		blog.Errorf("get host transfer schedule failed, cond: %v, err: %v, rid: %s", cond, err, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	return kit.CCError.CCErrorf(common.CCErrCoreServiceHostTransferScheduleStatusInvalid, option.ID, schedule.Status)
+}
@@ -21,6 +21,7 @@ import (
 	"configcenter/src/common/blog"
 	"configcenter/src/common/errors"
 	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
 	"configcenter/src/common/mapstruct"
 	"configcenter/src/common/metadata"
 	"configcenter/src/common/util"
@@ -66,6 +67,13 @@ func (p *hostApplyRule) GenerateApplyPlan(kit *rest.Kit, bizID int64, option met
 	for _, attr := range attributes {
 		fields = append(fields, attr.PropertyID)
 	}
+	// conditional rules need the host fields in their conditions to check if the host matches them
+	for _, rule := range option.Rules {
+		if rule.IsConditional() {
+			fields = append(fields, rule.Condition.RuleFields()...)
+		}
+	}
+	fields = util.StrArrayUnique(fields)
 
 	hosts := make([]metadata.HostMapStr, 0)
 	if err := mongodb.Client().Table(common.BKTableNameBaseHost).Find(hostFilter).Fields(fields...).All(kit.Ctx, &hosts); err != nil {
@@ -259,6 +267,51 @@ func preCheckRules(targetRules []metadata.HostApplyRule, attributeID int64, attr
 	return attribute, true
 }
 
+// getHostEffectiveRules returns the rules of one attribute that take effect on the host. if any conditional rule
+// matches the host, the matched conditional rules with the highest priority take effect, otherwise the unconditional
+// rules take effect. returns true if the effective conditional rules have different values, which is a conflict.
+func getHostEffectiveRules(host mapstr.MapStr, rules []metadata.HostApplyRule) ([]metadata.HostApplyRule, bool,
+	error) {
+
+	unconditionalRules := make([]metadata.HostApplyRule, 0)
+	matchedRules := make([]metadata.HostApplyRule, 0)
+	for _, rule := range rules {
+		if !rule.IsConditional() {
+			unconditionalRules = append(unconditionalRules, rule)
+			continue
+		}
+
+		matched, err := rule.Condition.Match(host)
+		if err != nil {
+			return nil, false, fmt.Errorf("match rule %d condition failed, err: %v", rule.ID, err)
+		}
+		if !matched {
+			continue
+		}
+
+		if len(matchedRules) > 0 {
+			if rule.Priority < matchedRules[0].Priority {
+				continue
+			}
+			if rule.Priority > matchedRules[0].Priority {
+				matchedRules = matchedRules[:0]
+			}
+		}
+		matchedRules = append(matchedRules, rule)
+	}
+
+	if len(matchedRules) == 0 {
+		return unconditionalRules, false, nil
+	}
+
+	for _, rule := range matchedRules[1:] {
+		if !cmp.Equal(rule.PropertyValue, matchedRules[0].PropertyValue) {
+			return matchedRules, true, nil
+		}
+	}
+	return matchedRules[:1], false, nil
+}
+
 func getOneHostApplyPlan(kit *rest.Kit, attrRules map[int64][]metadata.HostApplyRule,
 	attrMap map[int64]metadata.Attribute, hostID int64, host map[string]interface{}, moduleIDs []int64,
 	resolverMap map[int64]interface{}) (metadata.OneHostApplyPlan, errors.CCErrorCoder) {
@@ -272,9 +325,12 @@ func getOneHostApplyPlan(kit *rest.Kit, attrRules map[int64][]metadata.HostApply
 		UpdateFields:   make([]metadata.HostApplyUpdateField, 0),
 	}
 
-	for attributeID, targetRules := range attrRules {
+	// rule conditions are matched with the original host, since the plan's expect host changes during the loop
+	originHost := mapstr.MapStr(host).Clone()
 
-		attribute, need := preCheckRules(targetRules, attributeID, attrMap, rid)
+	for attributeID, attrTargetRules := range attrRules {
+
+		attribute, need := preCheckRules(attrTargetRules, attributeID, attrMap, rid)
 		if !need {
 			continue
 		}
@@ -284,10 +340,47 @@ func getOneHostApplyPlan(kit *rest.Kit, attrRules map[int64][]metadata.HostApply
 			originalValue = nil
 		}
 
+		targetRules, conditionConflict, err := getHostEffectiveRules(originHost, attrTargetRules)
+		if err != nil {
+			blog.Errorf("get host %d effective rules failed, err: %v, rid: %s", hostID, err, rid)
+			return metadata.OneHostApplyPlan{}, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid,
+				common.HostApplyConditionField)
+		}
+
 		expectValue := originalValue
 
 		// check conflicts and if needChange
 		conflictedStillExist, needChange := false, false
+
+		// multiple conditional rules with different values match the host, the field can only be updated by resolver
+		if conditionConflict {
+			resolverValue, resolved := resolverMap[attribute.ID]
+			plan.ConflictFields = append(plan.ConflictFields, metadata.HostApplyConflictField{
+				AttributeID:             attributeID,
+				PropertyID:              propertyIDField,
+				PropertyValue:           originalValue,
+				Rules:                   targetRules,
+				UnresolvedConflictExist: !resolved,
+				ConditionConflict:       true,
+			})
+
+			if !resolved {
+				plan.UnresolvedConflictCount += 1
+				continue
+			}
+
+			isEqual, err := isRuleEqualOrNot(attribute.PropertyType, originalValue, resolverValue)
+			if err != nil {
+				blog.Errorf("compare resolver value failed, err: %v, rid: %s", err, rid)
+				return metadata.OneHostApplyPlan{}, err
+			}
+			if isEqual {
+				continue
+			}
+			targetRules = nil
+			needChange, expectValue = true, resolverValue
+		}
+
 		// check if host needs to be changed by the host apply rules, if not, do not append the field to the update
 		// fields
 		for _, rule := range targetRules {
@@ -329,7 +422,9 @@ func getOneHostApplyPlan(kit *rest.Kit, attrRules map[int64][]metadata.HostApply
 		// validate property value before update to host
 		if value, ok := expectValue.(string); ok {
 			expectValue = strings.TrimSpace(value)
-			targetRules[0].PropertyValue = expectValue
+			if len(targetRules) > 0 {
+				targetRules[0].PropertyValue = expectValue
+			}
 		}
 		rawErr := attribute.Validate(kit.Ctx, expectValue, propertyIDField)
 		if rawErr.ErrCode != 0 {
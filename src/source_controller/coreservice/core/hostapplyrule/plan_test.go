@@ -0,0 +1,102 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hostapplyrule
+
+import (
+	"testing"
+
+	"configcenter/pkg/filter"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+)
+
+func TestGetHostEffectiveRules(t *testing.T) {
+	windowsCond := &filter.Expression{
+		RuleFactory: &filter.AtomRule{Field: "bk_os_type", Operator: filter.Equal.Factory(), Value: "2"},
+	}
+	bigCpuCond := &filter.Expression{
+		RuleFactory: &filter.AtomRule{Field: "bk_cpu", Operator: filter.Greater.Factory(), Value: 8},
+	}
+
+	rules := []metadata.HostApplyRule{
+		{ID: 1, PropertyValue: "default"},
+		{ID: 2, PropertyValue: "windows", Condition: windowsCond, Priority: 10},
+		{ID: 3, PropertyValue: "big", Condition: bigCpuCond, Priority: 5},
+	}
+
+	tests := []struct {
+		name     string
+		host     mapstr.MapStr
+		rules    []metadata.HostApplyRule
+		ruleIDs  []int64
+		conflict bool
+	}{
+		{
+			name:    "no conditional rule matches",
+			host:    mapstr.MapStr{"bk_os_type": "1", "bk_cpu": 4},
+			rules:   rules,
+			ruleIDs: []int64{1},
+		},
+		{
+			name:    "highest priority conditional rule wins",
+			host:    mapstr.MapStr{"bk_os_type": "2", "bk_cpu": 16},
+			rules:   rules,
+			ruleIDs: []int64{2},
+		},
+		{
+			name:    "lower priority conditional rule matches",
+			host:    mapstr.MapStr{"bk_os_type": "1", "bk_cpu": 16},
+			rules:   rules,
+			ruleIDs: []int64{3},
+		},
+		{
+			name: "same priority conditional rules with same value",
+			host: mapstr.MapStr{"bk_os_type": "2", "bk_cpu": 16},
+			rules: append(rules[:2:2], metadata.HostApplyRule{ID: 4, PropertyValue: "windows",
+				Condition: bigCpuCond, Priority: 10}),
+			ruleIDs: []int64{2},
+		},
+		{
+			name: "same priority conditional rules with different values",
+			host: mapstr.MapStr{"bk_os_type": "2", "bk_cpu": 16},
+			rules: append(rules[:2:2], metadata.HostApplyRule{ID: 4, PropertyValue: "big",
+				Condition: bigCpuCond, Priority: 10}),
+			ruleIDs:  []int64{2, 4},
+			conflict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			effectiveRules, conflict, err := getHostEffectiveRules(tt.host, tt.rules)
+			if err != nil {
+				t.Errorf("get host effective rules failed, err: %v", err)
+				return
+			}
+
+			if conflict != tt.conflict {
+				t.Errorf("conflict %v is not as expected %v", conflict, tt.conflict)
+			}
+
+			if len(effectiveRules) != len(tt.ruleIDs) {
+				t.Errorf("effective rules %+v are not as expected %v", effectiveRules, tt.ruleIDs)
+				return
+			}
+			for idx, rule := range effectiveRules {
+				if rule.ID != tt.ruleIDs[idx] {
+					t.Errorf("effective rules %+v are not as expected %v", effectiveRules, tt.ruleIDs)
+				}
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"strings"
 	"time"
 
+	"configcenter/pkg/filter"
 	"configcenter/src/common"
 	"configcenter/src/common/blog"
 	"configcenter/src/common/errors"
@@ -142,6 +143,33 @@ func (p *hostApplyRule) getHostAttribute(kit *rest.Kit, bizID int64, hostAttribu
 	return attributes[0], nil
 }
 
+// validateRuleCondition validate the host apply rule's condition and priority by the host attributes of the biz
+func (p *hostApplyRule) validateRuleCondition(kit *rest.Kit, bizID int64, condition *filter.Expression,
+	priority int64) errors.CCErrorCoder {
+
+	hostAttrs := make([]metadata.Attribute, 0)
+	if condition != nil && condition.RuleFactory != nil {
+		attrFilter := map[string]interface{}{
+			common.BKObjIDField: common.BKInnerObjIDHost,
+			common.BKDBOR: []map[string]interface{}{
+				{common.BKAppIDField: bizID},
+				{common.BKAppIDField: 0},
+			},
+		}
+		err := mongodb.Client().Table(common.BKTableNameObjAttDes).Find(attrFilter).All(kit.Ctx, &hostAttrs)
+		if err != nil {
+			blog.Errorf("get host attributes failed, filter: %+v, err: %v, rid: %s", attrFilter, err, kit.Rid)
+			return kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+		}
+	}
+
+	if key, err := metadata.ValidateHostApplyRuleCondition(condition, priority, hostAttrs); err != nil {
+		blog.Errorf("host apply rule condition is invalid, key: %s, err: %v, rid: %s", key, err, kit.Rid)
+		return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, key)
+	}
+	return nil
+}
+
 // CreateHostApplyRule TODO
 func (p *hostApplyRule) CreateHostApplyRule(kit *rest.Kit, bizID int64, option metadata.CreateHostApplyRuleOption) (metadata.HostApplyRule, errors.CCErrorCoder) {
 	now := time.Now()
@@ -152,6 +180,8 @@ func (p *hostApplyRule) CreateHostApplyRule(kit *rest.Kit, bizID int64, option m
 		ModuleID:          option.ModuleID,
 		ServiceTemplateID: option.ServiceTemplateID,
 		PropertyValue:     option.PropertyValue,
+		Condition:         option.Condition,
+		Priority:          option.Priority,
 		Creator:           kit.User,
 		Modifier:          kit.User,
 		CreateTime:        now,
@@ -163,6 +193,10 @@ func (p *hostApplyRule) CreateHostApplyRule(kit *rest.Kit, bizID int64, option m
 		return rule, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, key)
 	}
 
+	if err := p.validateRuleCondition(kit, bizID, rule.Condition, rule.Priority); err != nil {
+		return rule, err
+	}
+
 	// validate relation id
 	if err := p.validateID(kit, bizID, rule.ModuleID, rule.ServiceTemplateID); err != nil {
 		blog.Errorf("validate relation id failed, bizID: %d, err: %s, rid: %s", bizID, err, kit.Rid)
@@ -228,18 +262,39 @@ func (p *hostApplyRule) UpdateHostApplyRule(kit *rest.Kit, bizID int64, ruleID i
 		return rule, ccErr
 	}
 
+	if err := p.validateRuleCondition(kit, bizID, option.Condition, option.Priority); err != nil {
+		return rule, err
+	}
+
+	removeCondition := rule.IsConditional() && option.Condition == nil
 	rule.LastTime = time.Now()
 	rule.Modifier = kit.User
 	rule.PropertyValue = option.PropertyValue
+	rule.Condition = option.Condition
+	rule.Priority = option.Priority
 
 	filter := map[string]interface{}{
 		common.BKFieldID: ruleID,
 	}
 	if err := mongodb.Client().Table(common.BKTableNameHostApplyRule).Update(kit.Ctx, filter, rule); err != nil {
+		if mongodb.Client().IsDuplicatedError(err) {
+			blog.Errorf("UpdateHostApplyRule failed, duplicated error, doc: %+v, err: %v, rid: %s", rule, err, kit.Rid)
+			return rule, kit.CCError.CCErrorf(common.CCErrCommDuplicateItem, "priority")
+		}
 		blog.ErrorJSON("UpdateHostApplyRule failed, db update failed, filter: %s, doc: %s, err: %s, rid: %s", filter, rule, err, kit.Rid)
 		return rule, kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
 	}
 
+	// the rule is changed to an unconditional rule, remove its condition
+	if removeCondition {
+		err := mongodb.Client().Table(common.BKTableNameHostApplyRule).DropDocsColumn(kit.Ctx,
+			common.HostApplyConditionField, filter)
+		if err != nil {
+			blog.Errorf("remove host apply rule condition failed, filter: %+v, err: %v, rid: %s", filter, err, kit.Rid)
+			return rule, kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
+		}
+	}
+
 	return rule, nil
 }
 
@@ -295,14 +350,17 @@ func (p *hostApplyRule) GetHostApplyRule(kit *rest.Kit, bizID int64, ruleID int6
 	return rule, nil
 }
 
-// GetHostApplyRuleByAttributeID TODO
-func (p *hostApplyRule) GetHostApplyRuleByAttributeID(kit *rest.Kit, bizID, moduleID, attributeID int64) (metadata.HostApplyRule, errors.CCErrorCoder) {
+// GetHostApplyRuleByAttributeID get the host apply rule of the attribute with the priority
+func (p *hostApplyRule) GetHostApplyRuleByAttributeID(kit *rest.Kit, bizID, moduleID, attributeID, priority int64) (
+	metadata.HostApplyRule, errors.CCErrorCoder) {
+
 	rule := metadata.HostApplyRule{}
 	filter := map[string]interface{}{
-		common.BkSupplierAccount:  kit.SupplierAccount,
-		common.BKAppIDField:       bizID,
-		common.BKModuleIDField:    moduleID,
-		common.BKAttributeIDField: attributeID,
+		common.BkSupplierAccount:      kit.SupplierAccount,
+		common.BKAppIDField:           bizID,
+		common.BKModuleIDField:        moduleID,
+		common.BKAttributeIDField:     attributeID,
+		common.HostApplyPriorityField: priority,
 	}
 	if err := mongodb.Client().Table(common.BKTableNameHostApplyRule).Find(filter).One(kit.Ctx, &rule); err != nil {
 		if mongodb.Client().IsNotFoundError(err) {
@@ -578,6 +636,7 @@ func (p *hostApplyRule) BatchUpdateHostApplyRule(kit *rest.Kit, bizID int64, opt
 			common.BKAttributeIDField:       item.AttributeID,
 			common.BKModuleIDField:          item.ModuleID,
 			common.BKServiceTemplateIDField: item.ServiceTemplateID,
+			common.HostApplyPriorityField:   item.Priority,
 		}
 		count, err := mongodb.Client().Table(common.BKTableNameHostApplyRule).Find(ruleFilter).Count(kit.Ctx)
 		if err != nil {
@@ -608,6 +667,12 @@ func (p *hostApplyRule) BatchUpdateHostApplyRule(kit *rest.Kit, bizID int64, opt
 			continue
 		}
 
+		if ccErr := p.validateRuleCondition(kit, bizID, item.Condition, item.Priority); ccErr != nil {
+			itemResult.SetError(ccErr)
+			batchResult.Items = append(batchResult.Items, itemResult)
+			continue
+		}
+
 		// update rule
 		if count > 0 {
 			updateData := map[string]interface{}{
@@ -615,6 +680,9 @@ func (p *hostApplyRule) BatchUpdateHostApplyRule(kit *rest.Kit, bizID int64, opt
 				common.LastTimeField:        now,
 				common.ModifierField:        kit.User,
 			}
+			if item.Condition != nil {
+				updateData[common.HostApplyConditionField] = item.Condition
+			}
 			if err := mongodb.Client().Table(common.BKTableNameHostApplyRule).Update(kit.Ctx, ruleFilter, updateData); err != nil {
 				blog.ErrorJSON("BatchUpdateHostApplyRule failed, update rule failed, filter: %s, doc: %s, err: %s, rid: %s", ruleFilter, updateData, err.Error(), rid)
 				ccErr := kit.CCError.CCError(common.CCErrCommDBUpdateFailed)
@@ -640,6 +708,8 @@ func (p *hostApplyRule) BatchUpdateHostApplyRule(kit *rest.Kit, bizID int64, opt
 			ServiceTemplateID: item.ServiceTemplateID,
 			AttributeID:       item.AttributeID,
 			PropertyValue:     item.PropertyValue,
+			Condition:         item.Condition,
+			Priority:          item.Priority,
 			Creator:           kit.User,
 			Modifier:          kit.User,
 			CreateTime:        now,
@@ -657,7 +727,7 @@ func (p *hostApplyRule) BatchUpdateHostApplyRule(kit *rest.Kit, bizID int64, opt
 	}
 
 	for index, item := range option.Rules {
-		rule, ccErr := p.GetHostApplyRuleByAttributeID(kit, bizID, item.ModuleID, item.AttributeID)
+		rule, ccErr := p.GetHostApplyRuleByAttributeID(kit, bizID, item.ModuleID, item.AttributeID, item.Priority)
 		if ccErr != nil {
 			blog.Errorf("GetHostApplyRuleByAttributeID failed, bizID: %d, moduleID: %d, attribute: %d, err: %s, rid: %s", bizID, item.ModuleID, item.AttributeID, ccErr.Error(), rid)
 			if err := batchResult.Items[index].GetError(); err == nil {
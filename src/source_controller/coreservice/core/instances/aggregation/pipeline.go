@@ -0,0 +1,292 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package aggregation generates the mongodb aggregation pipeline of the model instance aggregation.
+package aggregation
+
+import (
+	"fmt"
+	"strings"
+
+	"configcenter/src/common"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// hostRelationField is the field that the host's topology relations are joined into in the aggregation pipeline.
+	hostRelationField = "_host_relations"
+	// inputCountField is the field that counts the instances of each group, it is used to check the input limit.
+	inputCountField = "_inputs"
+)
+
+// Facet is the result of the instance aggregation pipeline's facet stage.
+type Facet struct {
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+	Inputs []struct {
+		Count int64 `bson:"count"`
+	} `bson:"inputs"`
+	Groups []metadata.InstAggregateGroup `bson:"groups"`
+}
+
+// GenPipeline generates the instance aggregation pipeline, which matches the instances, joins the host's topology
+// relations if needed, groups the instances and sorts and limits the groups. the instances are limited before they
+// are grouped, so that mongodb never groups more than MaxInstAggregateInputs instances for an aggregation.
+func GenPipeline(objID, supplierAccount string, input *metadata.InstAggregateOption) ([]mapstr.MapStr, error) {
+	cond := make(mapstr.MapStr)
+	if input.Filter != nil {
+		mgoCond, err := input.Filter.ToMgo()
+		if err != nil {
+			return nil, fmt.Errorf("parse aggregate instances filter failed, err: %v", err)
+		}
+		cond = mgoCond
+	}
+
+	baseCond := make(mapstr.MapStr)
+	if common.IsObjectInstShardingTable(common.GetInstTableName(objID, supplierAccount)) {
+		baseCond[common.BKObjIDField] = objID
+	}
+	baseCond = util.SetQueryOwner(baseCond, supplierAccount)
+
+	relationFields, unwindRelation := getHostRelationAggregateFields(objID, input)
+
+	pipeline := make([]mapstr.MapStr, 0)
+	filterRelation := false
+	if input.Filter != nil {
+		for _, field := range input.Filter.RuleFields() {
+			if _, exists := relationFields[field]; exists {
+				filterRelation = true
+				break
+			}
+		}
+	}
+
+	// match the instances before joining the host relations if the relation fields are not used in the filter
+	if filterRelation {
+		pipeline = append(pipeline, mapstr.MapStr{common.BKDBMatch: baseCond})
+	} else {
+		pipeline = append(pipeline, mapstr.MapStr{common.BKDBMatch: mapstr.MapStr{
+			common.BKDBAND: []mapstr.MapStr{baseCond, cond},
+		}})
+	}
+
+	if len(relationFields) > 0 {
+		pipeline = append(pipeline, genHostRelationStages(unwindRelation)...)
+	}
+
+	if filterRelation {
+		pipeline = append(pipeline, mapstr.MapStr{common.BKDBMatch: cond})
+	}
+
+	groupID := make(mapstr.MapStr)
+	keys := make(mapstr.MapStr)
+	for idx, key := range input.GroupBy {
+		groupKey := fmt.Sprintf("k%d", idx)
+		if len(key.TimeUnit) == 0 {
+			groupID[groupKey] = "$" + key.Field
+		} else {
+			groupID[groupKey] = genTimeBucketExpr(key.Field, key.TimeUnit, input.GetTimeZone())
+		}
+		keys[key.Field] = "$_id." + groupKey
+	}
+
+	group := mapstr.MapStr{"_id": groupID, inputCountField: mapstr.MapStr{common.BKDBSum: 1}}
+	metrics := make(mapstr.MapStr)
+	for idx, metric := range input.Metrics {
+		metricKey := fmt.Sprintf("m%d", idx)
+		switch metric.Type {
+		case metadata.AggregateCount:
+			group[metricKey] = mapstr.MapStr{common.BKDBSum: 1}
+			metrics[metric.Name()] = "$" + metricKey
+		case metadata.AggregateSum:
+			group[metricKey] = mapstr.MapStr{common.BKDBSum: "$" + metric.Field}
+			metrics[metric.Name()] = "$" + metricKey
+		case metadata.AggregateMin:
+			group[metricKey] = mapstr.MapStr{common.BKDBMin: "$" + metric.Field}
+			metrics[metric.Name()] = "$" + metricKey
+		case metadata.AggregateMax:
+			group[metricKey] = mapstr.MapStr{common.BKDBMax: "$" + metric.Field}
+			metrics[metric.Name()] = "$" + metricKey
+		case metadata.AggregateDistinctCount:
+			group[metricKey] = mapstr.MapStr{common.BKDBAddToSet: "$" + metric.Field}
+			metrics[metric.Name()] = mapstr.MapStr{common.BKDBSize: "$" + metricKey}
+
+			// multiple host relations are arrays when they are not unwound, merge these arrays before counting
+			if _, exists := relationFields[metric.Field]; exists && !unwindRelation &&
+				metric.Field != common.BKAppIDField {
+
+				metrics[metric.Name()] = mapstr.MapStr{common.BKDBSize: mapstr.MapStr{"$reduce": mapstr.MapStr{
+					"input":        "$" + metricKey,
+					"initialValue": []interface{}{},
+					"in":           mapstr.MapStr{"$setUnion": []string{"$$value", "$$this"}},
+				}}}
+			}
+		}
+	}
+
+	sortField := input.Sort
+	if len(sortField) == 0 {
+		sortField = "-" + input.Metrics[0].Name()
+	}
+
+	sortOrder := 1
+	if strings.HasPrefix(sortField, "-") {
+		sortOrder = -1
+		sortField = strings.TrimPrefix(sortField, "-")
+	}
+
+	if _, exists := metrics[sortField]; exists {
+		sortField = "metrics." + sortField
+	} else {
+		sortField = "keys." + sortField
+	}
+
+	// sort by the group keys too so that the groups with the same sort field value are returned in a stable order
+	sort := bson.D{{Key: sortField, Value: sortOrder}}
+	for _, key := range input.GroupBy {
+		if "keys."+key.Field != sortField {
+			sort = append(sort, bson.E{Key: "keys." + key.Field, Value: 1})
+		}
+	}
+
+	pipeline = append(pipeline,
+		// the instances that exceed the limit are not grouped, the aggregation fails if the instances exceed the
+		// limit, which is checked by the sum of the groups' input counts.
+		mapstr.MapStr{common.BKDBLimit: metadata.MaxInstAggregateInputs + 1},
+		mapstr.MapStr{common.BKDBGroup: group},
+		mapstr.MapStr{common.BKDBProject: mapstr.MapStr{
+			"_id": 0, "keys": keys, "metrics": metrics, inputCountField: 1,
+		}},
+		// the groups that exceed the limit are not processed, the aggregation fails if the groups exceed the limit
+		mapstr.MapStr{common.BKDBLimit: metadata.MaxInstAggregateGroups + 1},
+		mapstr.MapStr{common.BKDBFacet: mapstr.MapStr{
+			"total": []mapstr.MapStr{{common.BKDBCount: "count"}},
+			"inputs": []mapstr.MapStr{{common.BKDBGroup: mapstr.MapStr{
+				"_id": nil, "count": mapstr.MapStr{common.BKDBSum: "$" + inputCountField},
+			}}},
+			"groups": []interface{}{
+				mapstr.MapStr{common.BKDBSort: sort},
+				mapstr.MapStr{common.BKDBLimit: input.Limit},
+				mapstr.MapStr{common.BKDBProject: mapstr.MapStr{inputCountField: 0}},
+			},
+		}},
+	)
+
+	return pipeline, nil
+}
+
+// getHostRelationAggregateFields returns the host relation fields that are used in the host aggregation, and whether
+// the host relations need to be unwound, which is needed when the hosts are grouped by sets or modules.
+func getHostRelationAggregateFields(objID string, input *metadata.InstAggregateOption) (map[string]struct{}, bool) {
+	relationFields := make(map[string]struct{})
+	if objID != common.BKInnerObjIDHost {
+		return relationFields, false
+	}
+
+	usedFields := make([]string, 0)
+	if input.Filter != nil {
+		usedFields = append(usedFields, input.Filter.RuleFields()...)
+	}
+	for _, metric := range input.Metrics {
+		usedFields = append(usedFields, metric.Field)
+	}
+
+	groupFields := make(map[string]struct{})
+	for _, key := range input.GroupBy {
+		usedFields = append(usedFields, key.Field)
+		groupFields[key.Field] = struct{}{}
+	}
+
+	unwind := false
+	for _, field := range usedFields {
+		if !util.InStrArr(metadata.HostRelationAggregateFields, field) {
+			continue
+		}
+		relationFields[field] = struct{}{}
+
+		if _, exists := groupFields[field]; exists && field != common.BKAppIDField {
+			unwind = true
+		}
+	}
+
+	return relationFields, unwind
+}
+
+// genHostRelationStages generates the stages that join the host's topology relations into the host relation fields.
+// a host belongs to only one biz, but may belong to multiple sets and modules, so these fields are arrays unless the
+// relations are unwound, in which case each host relation is aggregated separately.
+func genHostRelationStages(unwind bool) []mapstr.MapStr {
+	stages := []mapstr.MapStr{{
+		common.BKDBLookUp: mapstr.MapStr{
+			"from":         common.BKTableNameModuleHostConfig,
+			"localField":   common.BKHostIDField,
+			"foreignField": common.BKHostIDField,
+			"as":           hostRelationField,
+		},
+	}}
+
+	relationPrefix := "$" + hostRelationField + "."
+	if unwind {
+		stages = append(stages,
+			mapstr.MapStr{common.BKDBUnwind: "$" + hostRelationField},
+			mapstr.MapStr{common.BKDBAddFields: mapstr.MapStr{
+				common.BKAppIDField:    relationPrefix + common.BKAppIDField,
+				common.BKSetIDField:    relationPrefix + common.BKSetIDField,
+				common.BKModuleIDField: relationPrefix + common.BKModuleIDField,
+			}},
+		)
+		return stages
+	}
+
+	stages = append(stages, mapstr.MapStr{common.BKDBAddFields: mapstr.MapStr{
+		common.BKAppIDField:    mapstr.MapStr{"$arrayElemAt": []interface{}{relationPrefix + common.BKAppIDField, 0}},
+		common.BKSetIDField:    relationPrefix + common.BKSetIDField,
+		common.BKModuleIDField: relationPrefix + common.BKModuleIDField,
+	}})
+	return stages
+}
+
+// genTimeBucketExpr generates the expression that buckets the time or date field value by the time unit, time values
+// are formatted in the IANA time zone, date values are stored as strings and are truncated to the time unit.
+func genTimeBucketExpr(field string, unit metadata.AggregateTimeUnit, timeZone string) mapstr.MapStr {
+	format, length := "%Y-%m-%d", len("2006-01-02")
+	switch unit {
+	case metadata.AggregateByMonth:
+		format, length = "%Y-%m", len("2006-01")
+	case metadata.AggregateByYear:
+		format, length = "%Y", len("2006")
+	}
+
+	fieldType := mapstr.MapStr{common.BKDBType: "$" + field}
+	return mapstr.MapStr{"$switch": mapstr.MapStr{
+		"branches": []mapstr.MapStr{
+			{
+				"case": mapstr.MapStr{common.BKDBEQ: []interface{}{fieldType, "date"}},
+				"then": mapstr.MapStr{"$dateToString": mapstr.MapStr{
+					"format":   format,
+					"date":     "$" + field,
+					"timezone": timeZone,
+				}},
+			},
+			{
+				"case": mapstr.MapStr{common.BKDBEQ: []interface{}{fieldType, "string"}},
+				"then": mapstr.MapStr{"$substrCP": []interface{}{"$" + field, 0, length}},
+			},
+		},
+		"default": nil,
+	}}
+}
@@ -0,0 +1,137 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aggregation
+
+import (
+	"testing"
+
+	"configcenter/pkg/filter"
+	"configcenter/src/common"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+)
+
+func stageIndex(pipeline []mapstr.MapStr, stage string) []int {
+	indexes := make([]int, 0)
+	for idx, s := range pipeline {
+		if _, exists := s[stage]; exists {
+			indexes = append(indexes, idx)
+		}
+	}
+	return indexes
+}
+
+func TestGenPipelineLimitsInputs(t *testing.T) {
+	tests := []struct {
+		name  string
+		objID string
+		input *metadata.InstAggregateOption
+	}{
+		{
+			name:  "group hosts by module",
+			objID: common.BKInnerObjIDHost,
+			input: &metadata.InstAggregateOption{
+				Filter: &filter.Expression{RuleFactory: &filter.AtomRule{Field: common.BKSetIDField,
+					Operator: filter.Equal.Factory(), Value: 1}},
+				GroupBy: []metadata.AggregateGroupKey{{Field: common.BKModuleIDField}},
+				Metrics: []metadata.AggregateMetric{{Type: metadata.AggregateCount}},
+				Limit:   10,
+			},
+		},
+		{
+			name:  "group instances by create time",
+			objID: "test",
+			input: &metadata.InstAggregateOption{
+				GroupBy: []metadata.AggregateGroupKey{{Field: common.CreateTimeField,
+					TimeUnit: metadata.AggregateByMonth}},
+				Metrics: []metadata.AggregateMetric{{Type: metadata.AggregateDistinctCount, Field: "name"}},
+				Limit:   10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, err := GenPipeline(tt.objID, common.BKDefaultOwnerID, tt.input)
+			if err != nil {
+				t.Fatalf("generate pipeline failed, err: %v", err)
+			}
+
+			groups := stageIndex(pipeline, common.BKDBGroup)
+			limits := stageIndex(pipeline, common.BKDBLimit)
+			if len(groups) != 1 || len(limits) != 2 {
+				t.Fatalf("pipeline should have 1 group stage and 2 limit stages, pipeline: %v", pipeline)
+			}
+
+			// the input instances must be limited right before they are grouped
+			if limits[0] != groups[0]-1 {
+				t.Errorf("limit stage %d is not right before group stage %d", limits[0], groups[0])
+			}
+			if pipeline[limits[0]][common.BKDBLimit] != metadata.MaxInstAggregateInputs+1 {
+				t.Errorf("input limit is %v, expected %d", pipeline[limits[0]][common.BKDBLimit],
+					metadata.MaxInstAggregateInputs+1)
+			}
+
+			for idx := groups[0] + 1; idx < len(pipeline); idx++ {
+				for stage := range pipeline[idx] {
+					if stage == common.BKDBLookUp || stage == common.BKDBUnwind || stage == common.BKDBMatch {
+						t.Errorf("stage %s after the group stage changes the inputs", stage)
+					}
+				}
+			}
+
+			if pipeline[limits[1]][common.BKDBLimit] != metadata.MaxInstAggregateGroups+1 {
+				t.Errorf("group limit is %v, expected %d", pipeline[limits[1]][common.BKDBLimit],
+					metadata.MaxInstAggregateGroups+1)
+			}
+
+			group := pipeline[groups[0]][common.BKDBGroup].(mapstr.MapStr)
+			if _, exists := group[inputCountField]; !exists {
+				t.Errorf("group stage does not count the inputs, group: %v", group)
+			}
+
+			facet, ok := pipeline[len(pipeline)-1][common.BKDBFacet].(mapstr.MapStr)
+			if !ok {
+				t.Fatalf("the last stage is not facet, pipeline: %v", pipeline)
+			}
+			for _, key := range []string{"total", "inputs", "groups"} {
+				if _, exists := facet[key]; !exists {
+					t.Errorf("facet stage has no %s, facet: %v", key, facet)
+				}
+			}
+		})
+	}
+}
+
+func TestGenTimeBucketExprTimeZone(t *testing.T) {
+	input := &metadata.InstAggregateOption{
+		GroupBy: []metadata.AggregateGroupKey{{Field: common.CreateTimeField, TimeUnit: metadata.AggregateByDay}},
+		Metrics: []metadata.AggregateMetric{{Type: metadata.AggregateCount}},
+		Limit:   10,
+	}
+
+	for _, timeZone := range []string{"", "Asia/Shanghai"} {
+		input.TimeZone = timeZone
+		pipeline, err := GenPipeline("test", common.BKDefaultOwnerID, input)
+		if err != nil {
+			t.Fatalf("generate pipeline failed, err: %v", err)
+		}
+
+		group := pipeline[stageIndex(pipeline, common.BKDBGroup)[0]][common.BKDBGroup].(mapstr.MapStr)
+		bucket := group["_id"].(mapstr.MapStr)["k0"].(mapstr.MapStr)["$switch"].(mapstr.MapStr)
+		dateExpr := bucket["branches"].([]mapstr.MapStr)[0]["then"].(mapstr.MapStr)["$dateToString"].(mapstr.MapStr)
+		if dateExpr["timezone"] != input.GetTimeZone() {
+			t.Errorf("time zone is %v, expected %s", dateExpr["timezone"], input.GetTimeZone())
+		}
+	}
+}
@@ -13,7 +13,9 @@
 package instances
 
 import (
+	"configcenter/src/common/errors"
 	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
 	"configcenter/src/common/metadata"
 )
 
@@ -35,4 +37,17 @@ type OperationDependences interface {
 
 	// SearchUnique search unique attribute
 	SearchUnique(kit *rest.Kit, objID string) (uniqueAttr []metadata.ObjectUnique, err error)
+
+	// CheckHostLockDependence check if the hosts are locked by other users
+	CheckHostLockDependence(kit *rest.Kit, hostIDs []int64) errors.CCErrorCoder
+
+	// ValidateHostCreateStateDependence check if the states of the hosts to be created are allowed by the host lifecycle
+	ValidateHostCreateStateDependence(kit *rest.Kit, hosts []mapstr.MapStr) errors.CCErrorCoder
+
+	// ValidateHostStateTransitionDependence check if the host state changes are allowed by the host lifecycle
+	ValidateHostStateTransitionDependence(kit *rest.Kit, origins []mapstr.MapStr, data mapstr.MapStr) (
+		[]metadata.HostStateTransition, errors.CCErrorCoder)
+
+	// ApplyHostStateTransitionDependence run the hooks of the host state changes
+	ApplyHostStateTransitionDependence(kit *rest.Kit, transitions []metadata.HostStateTransition) errors.CCErrorCoder
 }
@@ -77,6 +77,13 @@ func (m *instanceManager) CreateModelInstance(kit *rest.Kit, objID string, input
 		return nil, err
 	}
 
+	if objID == common.BKInnerObjIDHost {
+		err := m.dependent.ValidateHostCreateStateDependence(kit, []mapstr.MapStr{inputParam.Data})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	id, err := m.save(kit, objID, inputParam.Data)
 	if err != nil {
 		blog.ErrorJSON("CreateModelInstance failed, save error:%v, objID:%s, data:%s, rid:%s",
@@ -102,6 +109,12 @@ func (m *instanceManager) CreateManyModelInstance(kit *rest.Kit, objID string,
 		return nil, err
 	}
 
+	if objID == common.BKInnerObjIDHost {
+		if err := m.dependent.ValidateHostCreateStateDependence(kit, inputParam.Datas); err != nil {
+			return nil, err
+		}
+	}
+
 	for index, item := range inputParam.Datas {
 		if item == nil {
 			blog.ErrorJSON("the model instance data can't be empty, input data: %s rid: %s", inputParam.Datas, kit.Rid)
@@ -188,6 +201,18 @@ func (m *instanceManager) UpdateModelInstance(kit *rest.Kit, objID string, input
 		return nil, kit.CCError.Error(common.CCErrCommNotFound)
 	}
 
+	if err := m.checkHostLock(kit, objID, origins); err != nil {
+		return nil, err
+	}
+
+	var stateTransitions []metadata.HostStateTransition
+	if objID == common.BKInnerObjIDHost {
+		stateTransitions, err = m.dependent.ValidateHostStateTransitionDependence(kit, origins, inputParam.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	instValidators, err := m.getValidatorsFromInstances(kit, objID, origins, common.ValidUpdate)
 	if err != nil {
 		blog.Errorf("get inst validators failed, err: %v, objID: %s, data: %#v, rid:%s", err, objID, origins, kit.Rid)
@@ -245,11 +270,34 @@ func (m *instanceManager) UpdateModelInstance(kit *rest.Kit, objID string, input
 		if err := m.updateHostProcessBindIP(kit, inputParam.Data, origins); err != nil {
 			return nil, err
 		}
+
+		if err := m.dependent.ApplyHostStateTransitionDependence(kit, stateTransitions); err != nil {
+			return nil, err
+		}
 	}
 
 	return &metadata.UpdatedCount{Count: uint64(len(origins))}, nil
 }
 
+// checkHostLock check if the hosts to be updated or deleted are locked by other users
+func (m *instanceManager) checkHostLock(kit *rest.Kit, objID string, origins []mapstr.MapStr) error {
+	if objID != common.BKInnerObjIDHost {
+		return nil
+	}
+
+	hostIDs := make([]int64, len(origins))
+	for idx, origin := range origins {
+		hostID, err := util.GetInt64ByInterface(origin[common.BKHostIDField])
+		if err != nil {
+			blog.Errorf("host ID invalid, err: %v, host: %+v, rid: %s", err, origin, kit.Rid)
+			return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKHostIDField)
+		}
+		hostIDs[idx] = hostID
+	}
+
+	return m.dependent.CheckHostLockDependence(kit, hostIDs)
+}
+
 // updateHostProcessBindIP if hosts' ips are updated, update processes which binds the changed ip
 func (m *instanceManager) updateHostProcessBindIP(kit *rest.Kit, updateData mapstr.MapStr, origins []mapstr.MapStr) error {
 	innerIP, innerIPExist := updateData[common.BKHostInnerIPField]
@@ -439,6 +487,27 @@ func (m *instanceManager) SearchModelInstance(kit *rest.Kit, objID string, input
 		Limit(uint64(inputParam.Page.Limit)).
 		Sort(inputParam.Page.Sort).
 		Fields(fields...)
+
+	// use keyset pagination that sorts on the instance id field, and get one more instance to check if has next page
+	var cursor *metadata.PageCursor
+	idField := metadata.GetInstIDFieldByObjID(objID)
+	if inputParam.Page.IsCursorPage() {
+		var err error
+		cursor, err = inputParam.Page.ParsePageCursor(idField)
+		if err != nil {
+			blog.Errorf("parse page cursor failed, err: %v, page: %+v, rid: %s", err, inputParam.Page, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "page")
+		}
+
+		if len(fields) > 0 && !util.InStrArr(fields, idField) {
+			fields = append(fields, idField)
+		}
+
+		query = mongodb.Client().Table(tableName).Find(cursor.MergeCondition(inputParam.Condition)).
+			Limit(uint64(inputParam.Page.Limit + 1)).
+			Sort(cursor.Sort()).
+			Fields(fields...)
+	}
 	var instErr error
 	if objID == common.BKInnerObjIDHost {
 		hosts := make([]metadata.HostMapStr, 0)
@@ -454,6 +523,19 @@ func (m *instanceManager) SearchModelInstance(kit *rest.Kit, objID string, input
 		return nil, instErr
 	}
 
+	var nextCursor string
+	if cursor != nil && len(instItems) > inputParam.Page.Limit {
+		instItems = instItems[:inputParam.Page.Limit]
+		lastID, err := instItems[len(instItems)-1].Int64(idField)
+		if err != nil {
+			blog.Errorf("parse instance id failed, err: %v, inst: %+v, rid: %s", err, instItems[len(instItems)-1],
+				kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommInstFieldConvertFail, objID, idField, "int",
+				err.Error())
+		}
+		nextCursor = cursor.Next(lastID)
+	}
+
 	var finalCount uint64
 
 	if !inputParam.DisableCounter {
@@ -472,8 +554,9 @@ func (m *instanceManager) SearchModelInstance(kit *rest.Kit, objID string, input
 	}
 
 	dataResult := &metadata.QueryResult{
-		Count: finalCount,
-		Info:  instItems,
+		Count:      finalCount,
+		Info:       instItems,
+		NextCursor: nextCursor,
 	}
 
 	return dataResult, nil
@@ -520,6 +603,10 @@ func (m *instanceManager) DeleteModelInstance(kit *rest.Kit, objID string, input
 		return &metadata.DeletedCount{}, err
 	}
 
+	if err := m.checkHostLock(kit, objID, origins); err != nil {
+		return &metadata.DeletedCount{}, err
+	}
+
 	for _, origin := range origins {
 		instID, err := util.GetInt64ByInterface(origin[instIDFieldName])
 		if nil != err {
@@ -0,0 +1,72 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package instances
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/source_controller/coreservice/core/instances/aggregation"
+	"configcenter/src/storage/dal/types"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// AggregateModelInstances groups the model instances by the group by keys and calculates the metrics of each group.
+func (m *instanceManager) AggregateModelInstances(kit *rest.Kit, objID string, input *metadata.InstAggregateOption) (
+	*metadata.InstAggregateResult, error) {
+
+	if len(objID) == 0 {
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsNeedSet, common.BKObjIDField)
+	}
+
+	if invalidKey, err := input.Validate(); err != nil {
+		blog.Errorf("aggregate instances option is invalid, err: %v, input: %#v, rid: %s", err, input, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, invalidKey)
+	}
+
+	pipeline, err := aggregation.GenPipeline(objID, kit.SupplierAccount, input)
+	if err != nil {
+		blog.Errorf("generate aggregate instances pipeline failed, err: %v, rid: %s", err, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, "filter")
+	}
+
+	// the input instances are limited before they are grouped, the groups may need to be sorted on disk though
+	facets := make([]aggregation.Facet, 0)
+	tableName := common.GetInstTableName(objID, kit.SupplierAccount)
+	opt := types.NewAggregateOpts().SetAllowDiskUse(true)
+	if err := mongodb.Client().Table(tableName).AggregateAll(kit.Ctx, pipeline, &facets, opt); err != nil {
+		blog.Errorf("aggregate %s instances failed, err: %v, pipeline: %#v, rid: %s", objID, err, pipeline, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	result := &metadata.InstAggregateResult{Groups: make([]metadata.InstAggregateGroup, 0)}
+	if len(facets) == 0 || len(facets[0].Total) == 0 {
+		return result, nil
+	}
+
+	if len(facets[0].Inputs) > 0 && facets[0].Inputs[0].Count > metadata.MaxInstAggregateInputs {
+		blog.Errorf("aggregate %s instances exceeds limit, input: %#v, rid: %s", objID, input, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommXXExceedLimit, "filter", metadata.MaxInstAggregateInputs)
+	}
+
+	result.Total = facets[0].Total[0].Count
+	if result.Total > metadata.MaxInstAggregateGroups {
+		blog.Errorf("aggregate %s instances groups exceeds limit, input: %#v, rid: %s", objID, input, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommXXExceedLimit, "group_by",
+			metadata.MaxInstAggregateGroups)
+	}
+
+	result.Groups = facets[0].Groups
+	return result, nil
+}
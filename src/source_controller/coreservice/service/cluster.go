@@ -153,5 +153,21 @@ func (s *coreService) BatchDeleteCluster(ctx *rest.Contexts) {
 		ctx.RespAutoError(err)
 		return
 	}
+
+	// the sync status of the deleted clusters is useless, remove it along with the clusters
+	if len(option.IDs) > 0 {
+		statusFilter := map[string]interface{}{
+			common.BKAppIDField:    bizID,
+			common.BKOwnerIDField:  ctx.Kit.SupplierAccount,
+			types.BKClusterIDFiled: map[string]interface{}{common.BKDBIN: option.IDs},
+		}
+		err := mongodb.Client().Table(types.BKTableNameClusterSyncStatus).Delete(ctx.Kit.Ctx, statusFilter)
+		if err != nil {
+			blog.Errorf("delete cluster sync status failed, filter: %+v, err: %v, rid: %s", statusFilter, err,
+				ctx.Kit.Rid)
+			ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBDeleteFailed))
+			return
+		}
+	}
 	ctx.RespEntity(nil)
 }
@@ -0,0 +1,90 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// SaveClusterSyncStatus create or update the sync status of a cluster collected by the kube collector
+func (s *coreService) SaveClusterSyncStatus(ctx *rest.Contexts) {
+	status := new(types.ClusterSyncStatus)
+	if err := ctx.DecodeInto(status); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if status.ClusterID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.BKClusterIDFiled))
+		return
+	}
+
+	if status.BizID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	status.SupplierAccount = ctx.Kit.SupplierAccount
+	if status.LastSyncTime.IsZero() {
+		status.LastSyncTime = time.Now()
+	}
+
+	cond := map[string]interface{}{types.BKClusterIDFiled: status.ClusterID}
+	cond = util.SetModOwner(cond, ctx.Kit.SupplierAccount)
+
+	if err := mongodb.Client().Table(types.BKTableNameClusterSyncStatus).Upsert(ctx.Kit.Ctx, cond,
+		status); err != nil {
+		blog.Errorf("save cluster sync status failed, status: %+v, err: %v, rid: %s", status, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBUpdateFailed))
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// ListClusterSyncStatus list the sync status of the clusters collected by the kube collector
+func (s *coreService) ListClusterSyncStatus(ctx *rest.Contexts) {
+	input := new(metadata.QueryCondition)
+	if err := ctx.DecodeInto(input); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	input.Condition = util.SetQueryOwner(input.Condition, ctx.Kit.SupplierAccount)
+	statuses := make([]types.ClusterSyncStatus, 0)
+	err := mongodb.Client().Table(types.BKTableNameClusterSyncStatus).Find(input.Condition).
+		Start(uint64(input.Page.Start)).
+		Limit(uint64(input.Page.Limit)).
+		Sort(input.Page.Sort).
+		Fields(input.Fields...).All(ctx.Kit.Ctx, &statuses)
+	if err != nil {
+		blog.Errorf("list cluster sync status failed, cond: %+v, err: %v, rid: %s", input, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBSelectFailed))
+		return
+	}
+
+	ctx.RespEntity(&types.ClusterSyncStatusData{Info: statuses})
+}
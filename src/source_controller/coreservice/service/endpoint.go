@@ -0,0 +1,185 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// endpointResource returns the definition of kube endpoint for the common kube resource handlers, the namespace
+// and service name of the endpoint is filled by its service and the pod name is filled by its pod, the pod must be
+// in the same namespace with the service.
+func (s *coreService) endpointResource() *kubeResource {
+	return &kubeResource{
+		name:            "endpoint",
+		table:           types.BKTableNameBaseEndpoint,
+		newCreateOption: func() kubeResOption { return new(types.EndpointCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.EndpointUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.EndpointDeleteOption) },
+		fillCreateData: func(kit *rest.Kit, bizID int64, opt kubeResOption) (int, error) {
+			req := opt.(*types.EndpointCreateOption)
+			return len(req.Data), s.fillEndpointCreateData(kit, bizID, req.Data)
+		},
+		setCreateBase: func(opt kubeResOption, ids []int64, supplierAccount string, rev table.Revision) interface{} {
+			req := opt.(*types.EndpointCreateOption)
+			for idx := range req.Data {
+				req.Data[idx].ID = ids[idx]
+				req.Data[idx].SupplierAccount = supplierAccount
+				req.Data[idx].Revision = rev
+			}
+			return req.Data
+		},
+		updateInfo: func(opt kubeResOption) ([]int64, kubeResUpdateData) {
+			req := opt.(*types.EndpointUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.EndpointDeleteOption).IDs
+		},
+		newListResult: func() (interface{}, interface{}) {
+			resp := &types.EndpointDataResp{Data: make([]types.Endpoint, 0)}
+			return &resp.Data, resp
+		},
+	}
+}
+
+// CreateEndpoint create kube endpoint
+func (s *coreService) CreateEndpoint(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.endpointResource())
+}
+
+// UpdateEndpoint update kube endpoint
+func (s *coreService) UpdateEndpoint(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.endpointResource())
+}
+
+// DeleteEndpoint delete kube endpoint
+func (s *coreService) DeleteEndpoint(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.endpointResource())
+}
+
+// ListEndpoint list kube endpoint
+func (s *coreService) ListEndpoint(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.endpointResource())
+}
+
+// fillEndpointCreateData fill the namespace spec and service name of the endpoints by their services and the pod
+// names by their pods, the pods must be in the same namespace with the services.
+func (s *coreService) fillEndpointCreateData(kit *rest.Kit, bizID int64, endpoints []types.Endpoint) error {
+	svcIDs, podIDs := make([]int64, 0), make([]int64, 0)
+	for _, data := range endpoints {
+		svcIDs = append(svcIDs, data.ServiceID)
+		podIDs = append(podIDs, data.PodID)
+	}
+
+	services, err := s.getEndpointServices(kit, bizID, svcIDs)
+	if err != nil {
+		return err
+	}
+
+	pods, err := s.getEndpointPods(kit, bizID, podIDs)
+	if err != nil {
+		return err
+	}
+
+	for idx := range endpoints {
+		data := &endpoints[idx]
+		svc := services[data.ServiceID]
+		pod := pods[data.PodID]
+		if pod.NamespaceID != svc.NamespaceID {
+			blog.Errorf("pod %d is not in the namespace of service %d, rid: %s", pod.ID, svc.ID, kit.Rid)
+			return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.BKPodIDField)
+		}
+
+		data.NamespaceSpec = svc.NamespaceSpec
+		data.ServiceName = svc.Name
+		if pod.Name != nil {
+			data.PodName = *pod.Name
+		}
+	}
+	return nil
+}
+
+// getEndpointServices get the services that the endpoints belong to, returns the map of service id to service
+func (s *coreService) getEndpointServices(kit *rest.Kit, bizID int64, svcIDs []int64) (map[int64]types.Service,
+	error) {
+
+	svcIDs = util.IntArrayUnique(svcIDs)
+	filter := mapstr.MapStr{
+		common.BKAppIDField: bizID,
+		common.BKFieldID:    mapstr.MapStr{common.BKDBIN: svcIDs},
+	}
+	filter = util.SetQueryOwner(filter, kit.SupplierAccount)
+
+	fields := []string{common.BKFieldID, common.BKFieldName, common.BKAppIDField, types.BKClusterIDFiled,
+		types.ClusterUIDField, types.BKNamespaceIDField, types.NamespaceField}
+	services := make([]types.Service, 0)
+	err := mongodb.Client().Table(types.BKTableNameBaseService).Find(filter).Fields(fields...).All(kit.Ctx,
+		&services)
+	if err != nil {
+		blog.Errorf("find services failed, filter: %v, err: %v, rid: %s", filter, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	if len(services) != len(svcIDs) {
+		blog.Errorf("can not find all services, ids: %v, rid: %s", svcIDs, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.BKServiceIDField)
+	}
+
+	serviceMap := make(map[int64]types.Service, len(services))
+	for _, svc := range services {
+		serviceMap[svc.ID] = svc
+	}
+	return serviceMap, nil
+}
+
+// getEndpointPods get the pods of the endpoints, returns the map of pod id to pod
+func (s *coreService) getEndpointPods(kit *rest.Kit, bizID int64, podIDs []int64) (map[int64]types.Pod, error) {
+	podIDs = util.IntArrayUnique(podIDs)
+	filter := mapstr.MapStr{
+		common.BKAppIDField: bizID,
+		common.BKFieldID:    mapstr.MapStr{common.BKDBIN: podIDs},
+	}
+	filter = util.SetQueryOwner(filter, kit.SupplierAccount)
+
+	fields := []string{common.BKFieldID, common.BKFieldName, types.BKNamespaceIDField}
+	pods := make([]types.Pod, 0)
+	err := mongodb.Client().Table(types.BKTableNameBasePod).Find(filter).Fields(fields...).All(kit.Ctx, &pods)
+	if err != nil {
+		blog.Errorf("find pods failed, filter: %v, err: %v, rid: %s", filter, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	if len(pods) != len(podIDs) {
+		blog.Errorf("can not find all pods, ids: %v, rid: %s", podIDs, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.BKPodIDField)
+	}
+
+	podMap := make(map[int64]types.Pod, len(pods))
+	for _, pod := range pods {
+		podMap[pod.ID] = pod
+	}
+	return podMap, nil
+}
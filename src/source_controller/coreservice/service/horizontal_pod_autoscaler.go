@@ -0,0 +1,153 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// hpaResource returns the definition of kube horizontal pod autoscaler for the common kube resource handlers,
+// the workload that the horizontal pod autoscaler scales is filled by its namespace and name.
+func (s *coreService) hpaResource() *kubeResource {
+	return &kubeResource{
+		name:            "horizontal pod autoscaler",
+		table:           types.BKTableNameBaseHorizontalPodAutoscaler,
+		newCreateOption: func() kubeResOption { return new(types.HorizontalPodAutoscalerCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.HorizontalPodAutoscalerUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.HorizontalPodAutoscalerDeleteOption) },
+		fillCreateData: func(kit *rest.Kit, bizID int64, opt kubeResOption) (int, error) {
+			req := opt.(*types.HorizontalPodAutoscalerCreateOption)
+			nsIDs := make([]int64, 0)
+			for _, data := range req.Data {
+				nsIDs = append(nsIDs, data.NamespaceID)
+			}
+			nsSpecs, err := s.getNamespaceSpecs(kit, bizID, nsIDs)
+			if err != nil {
+				return 0, err
+			}
+
+			for idx := range req.Data {
+				req.Data[idx].NamespaceSpec = nsSpecs[req.Data[idx].NamespaceID]
+			}
+
+			if err := s.fillHpaWorkloadRef(kit, req.Data); err != nil {
+				return 0, err
+			}
+			return len(req.Data), nil
+		},
+		setCreateBase: func(opt kubeResOption, ids []int64, supplierAccount string, rev table.Revision) interface{} {
+			req := opt.(*types.HorizontalPodAutoscalerCreateOption)
+			for idx := range req.Data {
+				req.Data[idx].ID = ids[idx]
+				req.Data[idx].SupplierAccount = supplierAccount
+				req.Data[idx].Revision = rev
+			}
+			return req.Data
+		},
+		updateInfo: func(opt kubeResOption) ([]int64, kubeResUpdateData) {
+			req := opt.(*types.HorizontalPodAutoscalerUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.HorizontalPodAutoscalerDeleteOption).IDs
+		},
+		newListResult: func() (interface{}, interface{}) {
+			resp := &types.HorizontalPodAutoscalerDataResp{Data: make([]types.HorizontalPodAutoscaler, 0)}
+			return &resp.Data, resp
+		},
+	}
+}
+
+// CreateHorizontalPodAutoscaler create kube horizontal pod autoscaler
+func (s *coreService) CreateHorizontalPodAutoscaler(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.hpaResource())
+}
+
+// UpdateHorizontalPodAutoscaler update kube horizontal pod autoscaler
+func (s *coreService) UpdateHorizontalPodAutoscaler(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.hpaResource())
+}
+
+// DeleteHorizontalPodAutoscaler delete kube horizontal pod autoscaler
+func (s *coreService) DeleteHorizontalPodAutoscaler(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.hpaResource())
+}
+
+// ListHorizontalPodAutoscaler list kube horizontal pod autoscaler
+func (s *coreService) ListHorizontalPodAutoscaler(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.hpaResource())
+}
+
+// fillHpaWorkloadRef fill the id of the workloads that the horizontal pod autoscalers scale by their namespace and
+// name, the id is left as zero if the workload is not found, because the workload may be synchronized later.
+func (s *coreService) fillHpaWorkloadRef(kit *rest.Kit, hpas []types.HorizontalPodAutoscaler) error {
+	kindHpaIdx := make(map[types.WorkloadType][]int)
+	for idx, hpa := range hpas {
+		kindHpaIdx[hpa.Ref.Kind] = append(kindHpaIdx[hpa.Ref.Kind], idx)
+	}
+
+	for kind, indexes := range kindHpaIdx {
+		tableName, err := kind.Table()
+		if err != nil {
+			blog.Errorf("get workload table name failed, kind: %s, err: %v, rid: %s", kind, err, kit.Rid)
+			return kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.RefKindField)
+		}
+
+		nsIDs := make([]int64, 0)
+		names := make([]string, 0)
+		for _, idx := range indexes {
+			nsIDs = append(nsIDs, hpas[idx].NamespaceID)
+			names = append(names, hpas[idx].Ref.Name)
+		}
+
+		filter := map[string]interface{}{
+			types.BKNamespaceIDField: mapstr.MapStr{common.BKDBIN: util.IntArrayUnique(nsIDs)},
+			common.BKFieldName:       mapstr.MapStr{common.BKDBIN: util.StrArrayUnique(names)},
+		}
+		filter = util.SetQueryOwner(filter, kit.SupplierAccount)
+
+		workloads := make([]types.WorkloadBase, 0)
+		err = mongodb.Client().Table(tableName).Find(filter).
+			Fields(common.BKFieldID, common.BKFieldName, types.BKNamespaceIDField).All(kit.Ctx, &workloads)
+		if err != nil {
+			blog.Errorf("find workloads failed, filter: %v, err: %v, rid: %s", filter, err, kit.Rid)
+			return kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+		}
+
+		workloadIDMap := make(map[int64]map[string]int64)
+		for _, workload := range workloads {
+			if _, exists := workloadIDMap[workload.NamespaceID]; !exists {
+				workloadIDMap[workload.NamespaceID] = make(map[string]int64)
+			}
+			workloadIDMap[workload.NamespaceID][workload.Name] = workload.ID
+		}
+
+		for _, idx := range indexes {
+			hpas[idx].Ref.ID = workloadIDMap[hpas[idx].NamespaceID][hpas[idx].Ref.Name]
+		}
+	}
+
+	return nil
+}
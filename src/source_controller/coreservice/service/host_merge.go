@@ -0,0 +1,75 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// FindDuplicateHosts find the hosts that share the same value of the matching fields
+func (s *coreService) FindDuplicateHosts(ctx *rest.Contexts) {
+	option := new(metadata.FindDuplicateHostOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	groups, err := s.core.HostOperation().FindDuplicateHosts(ctx.Kit, option)
+	if err != nil {
+		blog.Errorf("find duplicate hosts failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(groups)
+}
+
+// PreviewMergeHost preview the data that would be moved from the duplicate hosts to the kept host
+func (s *coreService) PreviewMergeHost(ctx *rest.Contexts) {
+	s.mergeHost(ctx, true)
+}
+
+// MergeHost merge the duplicate hosts into the kept host
+func (s *coreService) MergeHost(ctx *rest.Contexts) {
+	s.mergeHost(ctx, false)
+}
+
+func (s *coreService) mergeHost(ctx *rest.Contexts, preview bool) {
+	option := new(metadata.MergeHostOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.core.HostOperation().MergeHost(ctx.Kit, option, preview)
+	if err != nil {
+		blog.Errorf("merge host failed, option: %+v, preview: %v, err: %v, rid: %s", option, preview, err,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
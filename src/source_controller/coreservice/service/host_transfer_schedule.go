@@ -0,0 +1,84 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// CreateHostTransferSchedule create a waiting host transfer schedule
+func (s *coreService) CreateHostTransferSchedule(ctx *rest.Contexts) {
+	schedule := new(metadata.HostTransferSchedule)
+	if err := ctx.DecodeInto(schedule); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result, err := s.core.HostOperation().CreateHostTransferSchedule(ctx.Kit, schedule)
+	if err != nil {
+		blog.Errorf("create host transfer schedule failed, schedule: %+v, err: %v, rid: %s", schedule, err,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// ListHostTransferSchedule list the host transfer schedules
+func (s *coreService) ListHostTransferSchedule(ctx *rest.Contexts) {
+	option := new(metadata.ListHostTransferScheduleOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.core.HostOperation().ListHostTransferSchedule(ctx.Kit, option)
+	if err != nil {
+		blog.Errorf("list host transfer schedules failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// UpdateHostTransferScheduleStatus change the status of the host transfer schedule
+func (s *coreService) UpdateHostTransferScheduleStatus(ctx *rest.Contexts) {
+	option := new(metadata.UpdateHostTransferScheduleStatusOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	if err := s.core.HostOperation().UpdateHostTransferScheduleStatus(ctx.Kit, option); err != nil {
+		blog.Errorf("update host transfer schedule status failed, option: %+v, err: %v, rid: %s", option, err,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
@@ -0,0 +1,91 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+)
+
+// ingressResource returns the definition of kube ingress for the common kube resource handlers
+func (s *coreService) ingressResource() *kubeResource {
+	return &kubeResource{
+		name:            "ingress",
+		table:           types.BKTableNameBaseIngress,
+		newCreateOption: func() kubeResOption { return new(types.IngressCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.IngressUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.IngressDeleteOption) },
+		fillCreateData: func(kit *rest.Kit, bizID int64, opt kubeResOption) (int, error) {
+			req := opt.(*types.IngressCreateOption)
+			nsIDs := make([]int64, 0)
+			for _, data := range req.Data {
+				nsIDs = append(nsIDs, data.NamespaceID)
+			}
+			nsSpecs, err := s.getNamespaceSpecs(kit, bizID, nsIDs)
+			if err != nil {
+				return 0, err
+			}
+
+			for idx := range req.Data {
+				req.Data[idx].NamespaceSpec = nsSpecs[req.Data[idx].NamespaceID]
+			}
+			return len(req.Data), nil
+		},
+		setCreateBase: func(opt kubeResOption, ids []int64, supplierAccount string, rev table.Revision) interface{} {
+			req := opt.(*types.IngressCreateOption)
+			for idx := range req.Data {
+				req.Data[idx].ID = ids[idx]
+				req.Data[idx].SupplierAccount = supplierAccount
+				req.Data[idx].Revision = rev
+			}
+			return req.Data
+		},
+		updateInfo: func(opt kubeResOption) ([]int64, kubeResUpdateData) {
+			req := opt.(*types.IngressUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.IngressDeleteOption).IDs
+		},
+		newListResult: func() (interface{}, interface{}) {
+			resp := &types.IngressDataResp{Data: make([]types.Ingress, 0)}
+			return &resp.Data, resp
+		},
+	}
+}
+
+// CreateIngress create kube ingress
+func (s *coreService) CreateIngress(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.ingressResource())
+}
+
+// UpdateIngress update kube ingress
+func (s *coreService) UpdateIngress(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.ingressResource())
+}
+
+// DeleteIngress delete kube ingress
+func (s *coreService) DeleteIngress(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.ingressResource())
+}
+
+// ListIngress list kube ingress
+func (s *coreService) ListIngress(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.ingressResource())
+}
@@ -104,6 +104,25 @@ func (s *coreService) CountModelInstances(ctx *rest.Contexts) {
 	ctx.RespEntity(result)
 }
 
+// AggregateModelInstances groups target model instances and calculates the metrics of each group.
+func (s *coreService) AggregateModelInstances(ctx *rest.Contexts) {
+	objID := ctx.Request.PathParameter("bk_obj_id")
+
+	input := new(metadata.InstAggregateOption)
+	if err := ctx.DecodeInto(input); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result, err := s.core.InstanceOperation().AggregateModelInstances(ctx.Kit, objID, input)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
 // DeleteModelInstances TODO
 func (s *coreService) DeleteModelInstances(ctx *rest.Contexts) {
 	inputData := metadata.DeleteOption{}
@@ -17,6 +17,7 @@ import (
 
 	"configcenter/src/common"
 	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
 	"configcenter/src/common/http/rest"
 	"configcenter/src/common/mapstr"
 	"configcenter/src/common/metadata"
@@ -155,6 +156,28 @@ func (s *coreService) SearchUnique(kit *rest.Kit, objID string) (uniqueAttr []me
 	return result.Info, err
 }
 
+// CheckHostLockDependence check if the hosts are locked by other users
+func (s *coreService) CheckHostLockDependence(kit *rest.Kit, hostIDs []int64) errors.CCErrorCoder {
+	return s.core.HostOperation().CheckHostLock(kit, hostIDs)
+}
+
+// ValidateHostCreateStateDependence check if the states of the hosts to be created are allowed by the host lifecycle
+func (s *coreService) ValidateHostCreateStateDependence(kit *rest.Kit, hosts []mapstr.MapStr) errors.CCErrorCoder {
+	return s.core.HostOperation().ValidateHostCreateState(kit, hosts)
+}
+
+// ValidateHostStateTransitionDependence check if the host state changes are allowed by the host lifecycle
+func (s *coreService) ValidateHostStateTransitionDependence(kit *rest.Kit, origins []mapstr.MapStr,
+	data mapstr.MapStr) ([]metadata.HostStateTransition, errors.CCErrorCoder) {
+	return s.core.HostOperation().ValidateHostStateTransition(kit, origins, data)
+}
+
+// ApplyHostStateTransitionDependence run the hooks of the host state changes
+func (s *coreService) ApplyHostStateTransitionDependence(kit *rest.Kit,
+	transitions []metadata.HostStateTransition) errors.CCErrorCoder {
+	return s.core.HostOperation().ApplyHostStateTransition(kit, transitions)
+}
+
 // UpdateModelInstance TODO
 func (s *coreService) UpdateModelInstance(kit *rest.Kit, objID string, param metadata.UpdateOption) (*metadata.UpdatedCount, error) {
 	return s.core.InstanceOperation().UpdateModelInstance(kit, objID, param)
@@ -0,0 +1,261 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"strconv"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/errors"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// kubeResOption is the create, update or delete request option of a kind of kube resource
+type kubeResOption interface {
+	Validate() errors.RawErrorInfo
+}
+
+// kubeResUpdateData is the update data of a kind of kube resource
+type kubeResUpdateData interface {
+	BuildUpdateData(user string) (map[string]interface{}, error)
+}
+
+// kubeResource defines a kind of kube resource whose create, update, delete and list flows are the same, it wraps
+// the typed request options and the table of the kind, so that these flows are handled by the common kube resource
+// handlers below.
+type kubeResource struct {
+	// name is the name of the kube resource kind used in the logs
+	name  string
+	table string
+
+	newCreateOption func() kubeResOption
+	newUpdateOption func() kubeResOption
+	newDeleteOption func() kubeResOption
+
+	// fillCreateData fills the fields of the data to create that are derived from other resources, like the
+	// namespace spec, then returns the number of the data to create
+	fillCreateData func(kit *rest.Kit, bizID int64, opt kubeResOption) (int, error)
+	// setCreateBase sets the id, supplier account and revision of the data to create, returns the data to insert
+	setCreateBase func(opt kubeResOption, ids []int64, supplierAccount string, rev table.Revision) interface{}
+	// updateInfo returns the ids and the update data of the update option
+	updateInfo func(opt kubeResOption) ([]int64, kubeResUpdateData)
+	// deleteIDs returns the ids of the delete option
+	deleteIDs func(opt kubeResOption) []int64
+	// beforeDelete deletes the resources that depend on the resources to be deleted, it is optional
+	beforeDelete func(kit *rest.Kit, bizID int64, ids []int64) error
+	// newListResult returns the pointer of the typed data slice to find into and the response that holds the slice
+	newListResult func() (interface{}, interface{})
+}
+
+// createKubeResource create kube resources of the kind
+func (s *coreService) createKubeResource(ctx *rest.Contexts, res *kubeResource) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := res.newCreateOption()
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	count, err := res.fillCreateData(ctx.Kit, bizID, req)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	seqs, err := mongodb.Client().NextSequences(ctx.Kit.Ctx, res.table, count)
+	if err != nil {
+		blog.Errorf("get %s ids failed, err: %v, rid: %s", res.name, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBSelectFailed))
+		return
+	}
+
+	ids := make([]int64, len(seqs))
+	for idx, seq := range seqs {
+		ids[idx] = int64(seq)
+	}
+
+	now := time.Now().Unix()
+	rev := table.Revision{
+		Creator:    ctx.Kit.User,
+		Modifier:   ctx.Kit.User,
+		CreateTime: now,
+		LastTime:   now,
+	}
+	data := res.setCreateBase(req, ids, ctx.Kit.SupplierAccount, rev)
+
+	if err := mongodb.Client().Table(res.table).Insert(ctx.Kit.Ctx, data); err != nil {
+		blog.Errorf("add %s failed, data: %v, err: %v, rid: %s", res.name, data, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBInsertFailed))
+		return
+	}
+
+	ctx.RespEntity(metadata.RspIDs{IDs: ids})
+}
+
+// updateKubeResource update kube resources of the kind
+func (s *coreService) updateKubeResource(ctx *rest.Contexts, res *kubeResource) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := res.newUpdateOption()
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	ids, data := res.updateInfo(req)
+	filter := mapstr.MapStr{
+		common.BKFieldID:    mapstr.MapStr{common.BKDBIN: ids},
+		common.BKAppIDField: bizID,
+	}
+	filter = util.SetModOwner(filter, ctx.Kit.SupplierAccount)
+
+	updateData, err := data.BuildUpdateData(ctx.Kit.User)
+	if err != nil {
+		blog.Errorf("get %s update data failed, data: %v, err: %v, rid: %s", res.name, data, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBUpdateFailed))
+		return
+	}
+
+	err = mongodb.Client().Table(res.table).Update(ctx.Kit.Ctx, filter, updateData)
+	if err != nil {
+		blog.Errorf("update %s failed, filter: %v, updateData: %v, err: %v, rid: %s", res.name, filter, updateData,
+			err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBUpdateFailed))
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// deleteKubeResource delete kube resources of the kind
+func (s *coreService) deleteKubeResource(ctx *rest.Contexts, res *kubeResource) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := res.newDeleteOption()
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	ids := res.deleteIDs(req)
+	if res.beforeDelete != nil {
+		if err := res.beforeDelete(ctx.Kit, bizID, ids); err != nil {
+			ctx.RespAutoError(err)
+			return
+		}
+	}
+
+	filter := mapstr.MapStr{
+		common.BKFieldID:    mapstr.MapStr{common.BKDBIN: ids},
+		common.BKAppIDField: bizID,
+	}
+	filter = util.SetModOwner(filter, ctx.Kit.SupplierAccount)
+	if err := mongodb.Client().Table(res.table).Delete(ctx.Kit.Ctx, filter); err != nil {
+		blog.Errorf("delete %s failed, filter: %v, err: %v, rid: %s", res.name, filter, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBDeleteFailed))
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// listKubeResource list kube resources of the kind
+func (s *coreService) listKubeResource(ctx *rest.Contexts, res *kubeResource) {
+	input := new(metadata.QueryCondition)
+	if err := ctx.DecodeInto(input); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	util.SetQueryOwner(input.Condition, ctx.Kit.SupplierAccount)
+	data, resp := res.newListResult()
+	err := mongodb.Client().Table(res.table).Find(input.Condition).
+		Start(uint64(input.Page.Start)).
+		Limit(uint64(input.Page.Limit)).
+		Sort(input.Page.Sort).
+		Fields(input.Fields...).All(ctx.Kit.Ctx, data)
+	if err != nil {
+		blog.Errorf("search %s failed, cond: %v, err: %v, rid: %s", res.name, input, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBSelectFailed))
+		return
+	}
+
+	ctx.RespEntity(resp)
+}
+
+// getNamespaceSpecs get the namespace specs of the kube resources to create, returns namespace id to spec map
+func (s *coreService) getNamespaceSpecs(kit *rest.Kit, bizID int64, nsIDs []int64) (map[int64]types.NamespaceSpec,
+	error) {
+
+	nsSpecs, err := s.GetNamespaceSpec(kit, bizID, nsIDs)
+	if err != nil {
+		blog.Errorf("get namespace spec failed, bizID: %d, namespaceIDs: %v, err: %v, rid: %s", bizID, nsIDs, err,
+			kit.Rid)
+		return nil, err
+	}
+	return nsSpecs, nil
+}
+
+// getClusterSpecs get the cluster specs of the kube resources to create, returns cluster id to spec map
+func (s *coreService) getClusterSpecs(kit *rest.Kit, bizID int64, clusterIDs []int64) (map[int64]types.ClusterSpec,
+	error) {
+
+	clusterSpecs, err := s.GetClusterSpec(kit, bizID, clusterIDs)
+	if err != nil {
+		blog.Errorf("get cluster spec failed, bizID: %d, clusterIDs: %v, err: %v, rid: %s", bizID, clusterIDs, err,
+			kit.Rid)
+		return nil, err
+	}
+	return clusterSpecs, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// serviceResource returns the definition of kube service for the common kube resource handlers
+func (s *coreService) serviceResource() *kubeResource {
+	return &kubeResource{
+		name:            "service",
+		table:           types.BKTableNameBaseService,
+		newCreateOption: func() kubeResOption { return new(types.ServiceCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.ServiceUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.ServiceDeleteOption) },
+		fillCreateData: func(kit *rest.Kit, bizID int64, opt kubeResOption) (int, error) {
+			req := opt.(*types.ServiceCreateOption)
+			nsIDs := make([]int64, 0)
+			for _, data := range req.Data {
+				nsIDs = append(nsIDs, data.NamespaceID)
+			}
+			nsSpecs, err := s.getNamespaceSpecs(kit, bizID, nsIDs)
+			if err != nil {
+				return 0, err
+			}
+
+			for idx := range req.Data {
+				req.Data[idx].NamespaceSpec = nsSpecs[req.Data[idx].NamespaceID]
+			}
+			return len(req.Data), nil
+		},
+		setCreateBase: func(opt kubeResOption, ids []int64, supplierAccount string, rev table.Revision) interface{} {
+			req := opt.(*types.ServiceCreateOption)
+			for idx := range req.Data {
+				req.Data[idx].ID = ids[idx]
+				req.Data[idx].SupplierAccount = supplierAccount
+				req.Data[idx].Revision = rev
+			}
+			return req.Data
+		},
+		updateInfo: func(opt kubeResOption) ([]int64, kubeResUpdateData) {
+			req := opt.(*types.ServiceUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.ServiceDeleteOption).IDs
+		},
+		beforeDelete: s.deleteServiceEndpoints,
+		newListResult: func() (interface{}, interface{}) {
+			resp := &types.ServiceDataResp{Data: make([]types.Service, 0)}
+			return &resp.Data, resp
+		},
+	}
+}
+
+// deleteServiceEndpoints delete the endpoints of the kube services that are to be deleted
+func (s *coreService) deleteServiceEndpoints(kit *rest.Kit, bizID int64, svcIDs []int64) error {
+	epFilter := mapstr.MapStr{
+		types.BKServiceIDField: mapstr.MapStr{common.BKDBIN: svcIDs},
+		common.BKAppIDField:    bizID,
+	}
+	epFilter = util.SetModOwner(epFilter, kit.SupplierAccount)
+	if err := mongodb.Client().Table(types.BKTableNameBaseEndpoint).Delete(kit.Ctx, epFilter); err != nil {
+		blog.Errorf("delete service endpoints failed, filter: %v, err: %v, rid: %s", epFilter, err, kit.Rid)
+		return kit.CCError.CCError(common.CCErrCommDBDeleteFailed)
+	}
+	return nil
+}
+
+// CreateService create kube service
+func (s *coreService) CreateService(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.serviceResource())
+}
+
+// UpdateService update kube service
+func (s *coreService) UpdateService(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.serviceResource())
+}
+
+// DeleteService delete kube service, the endpoints of the services are deleted too.
+func (s *coreService) DeleteService(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.serviceResource())
+}
+
+// ListService list kube service
+func (s *coreService) ListService(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.serviceResource())
+}
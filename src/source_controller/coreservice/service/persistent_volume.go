@@ -0,0 +1,164 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// persistentVolumeResource returns the definition of kube persistent volume for the common kube resource
+// handlers
+func (s *coreService) persistentVolumeResource() *kubeResource {
+	return &kubeResource{
+		name:            "persistent volume",
+		table:           types.BKTableNameBasePersistentVolume,
+		newCreateOption: func() kubeResOption { return new(types.PersistentVolumeCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.PersistentVolumeUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.PersistentVolumeDeleteOption) },
+		fillCreateData: func(kit *rest.Kit, bizID int64, opt kubeResOption) (int, error) {
+			req := opt.(*types.PersistentVolumeCreateOption)
+			clusterIDs := make([]int64, 0)
+			for _, data := range req.Data {
+				clusterIDs = append(clusterIDs, data.ClusterID)
+			}
+			clusterSpecs, err := s.getClusterSpecs(kit, bizID, clusterIDs)
+			if err != nil {
+				return 0, err
+			}
+
+			nodeNames, err := s.getPersistentVolumeNodeNames(kit, bizID, req.Data)
+			if err != nil {
+				return 0, err
+			}
+
+			for idx := range req.Data {
+				req.Data[idx].ClusterSpec = clusterSpecs[req.Data[idx].ClusterID]
+				req.Data[idx].NodeName = nodeNames[req.Data[idx].NodeID]
+			}
+			return len(req.Data), nil
+		},
+		setCreateBase: func(opt kubeResOption, ids []int64, supplierAccount string, rev table.Revision) interface{} {
+			req := opt.(*types.PersistentVolumeCreateOption)
+			for idx := range req.Data {
+				req.Data[idx].ID = ids[idx]
+				req.Data[idx].SupplierAccount = supplierAccount
+				req.Data[idx].Revision = rev
+			}
+			return req.Data
+		},
+		updateInfo: func(opt kubeResOption) ([]int64, kubeResUpdateData) {
+			req := opt.(*types.PersistentVolumeUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.PersistentVolumeDeleteOption).IDs
+		},
+		newListResult: func() (interface{}, interface{}) {
+			resp := &types.PersistentVolumeDataResp{Data: make([]types.PersistentVolume, 0)}
+			return &resp.Data, resp
+		},
+	}
+}
+
+// CreatePersistentVolume create kube persistent volume
+func (s *coreService) CreatePersistentVolume(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.persistentVolumeResource())
+}
+
+// UpdatePersistentVolume update kube persistent volume
+func (s *coreService) UpdatePersistentVolume(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.persistentVolumeResource())
+}
+
+// DeletePersistentVolume delete kube persistent volume
+func (s *coreService) DeletePersistentVolume(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.persistentVolumeResource())
+}
+
+// ListPersistentVolume list kube persistent volume
+func (s *coreService) ListPersistentVolume(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.persistentVolumeResource())
+}
+
+// getPersistentVolumeNodeNames get the names of the nodes that the local persistent volumes are attached to, the
+// nodes must be in the same cluster with the persistent volumes. returns node id to node name map.
+func (s *coreService) getPersistentVolumeNodeNames(kit *rest.Kit, bizID int64, pvs []types.PersistentVolume) (
+	map[int64]string, error) {
+
+	nodeClusterMap := make(map[int64]int64)
+	for _, pv := range pvs {
+		if pv.NodeID == 0 {
+			continue
+		}
+
+		if clusterID, exists := nodeClusterMap[pv.NodeID]; exists && clusterID != pv.ClusterID {
+			blog.Errorf("node %d is used by persistent volumes in different clusters, rid: %s", pv.NodeID, kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.BKNodeIDField)
+		}
+		nodeClusterMap[pv.NodeID] = pv.ClusterID
+	}
+
+	nodeNames := make(map[int64]string)
+	if len(nodeClusterMap) == 0 {
+		return nodeNames, nil
+	}
+
+	nodeIDs := make([]int64, 0, len(nodeClusterMap))
+	for nodeID := range nodeClusterMap {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	filter := mapstr.MapStr{
+		common.BKAppIDField: bizID,
+		common.BKFieldID:    mapstr.MapStr{common.BKDBIN: nodeIDs},
+	}
+	filter = util.SetQueryOwner(filter, kit.SupplierAccount)
+
+	nodes := make([]types.Node, 0)
+	err := mongodb.Client().Table(types.BKTableNameBaseNode).Find(filter).
+		Fields(common.BKFieldID, common.BKFieldName, types.BKClusterIDFiled).All(kit.Ctx, &nodes)
+	if err != nil {
+		blog.Errorf("find nodes failed, filter: %v, err: %v, rid: %s", filter, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	for _, node := range nodes {
+		if node.ClusterID != nodeClusterMap[node.ID] {
+			blog.Errorf("node %d is not in cluster %d, rid: %s", node.ID, nodeClusterMap[node.ID], kit.Rid)
+			return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.BKNodeIDField)
+		}
+
+		if node.Name != nil {
+			nodeNames[node.ID] = *node.Name
+		}
+	}
+
+	if len(nodes) != len(nodeIDs) {
+		blog.Errorf("can not find all nodes, ids: %v, rid: %s", nodeIDs, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.BKNodeIDField)
+	}
+
+	return nodeNames, nil
+}
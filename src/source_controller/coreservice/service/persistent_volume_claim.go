@@ -0,0 +1,92 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+)
+
+// persistentVolumeClaimResource returns the definition of kube persistent volume claim for the common kube
+// resource handlers
+func (s *coreService) persistentVolumeClaimResource() *kubeResource {
+	return &kubeResource{
+		name:            "persistent volume claim",
+		table:           types.BKTableNameBasePersistentVolumeClaim,
+		newCreateOption: func() kubeResOption { return new(types.PersistentVolumeClaimCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.PersistentVolumeClaimUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.PersistentVolumeClaimDeleteOption) },
+		fillCreateData: func(kit *rest.Kit, bizID int64, opt kubeResOption) (int, error) {
+			req := opt.(*types.PersistentVolumeClaimCreateOption)
+			nsIDs := make([]int64, 0)
+			for _, data := range req.Data {
+				nsIDs = append(nsIDs, data.NamespaceID)
+			}
+			nsSpecs, err := s.getNamespaceSpecs(kit, bizID, nsIDs)
+			if err != nil {
+				return 0, err
+			}
+
+			for idx := range req.Data {
+				req.Data[idx].NamespaceSpec = nsSpecs[req.Data[idx].NamespaceID]
+			}
+			return len(req.Data), nil
+		},
+		setCreateBase: func(opt kubeResOption, ids []int64, supplierAccount string, rev table.Revision) interface{} {
+			req := opt.(*types.PersistentVolumeClaimCreateOption)
+			for idx := range req.Data {
+				req.Data[idx].ID = ids[idx]
+				req.Data[idx].SupplierAccount = supplierAccount
+				req.Data[idx].Revision = rev
+			}
+			return req.Data
+		},
+		updateInfo: func(opt kubeResOption) ([]int64, kubeResUpdateData) {
+			req := opt.(*types.PersistentVolumeClaimUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.PersistentVolumeClaimDeleteOption).IDs
+		},
+		newListResult: func() (interface{}, interface{}) {
+			resp := &types.PersistentVolumeClaimDataResp{Data: make([]types.PersistentVolumeClaim, 0)}
+			return &resp.Data, resp
+		},
+	}
+}
+
+// CreatePersistentVolumeClaim create kube persistent volume claim
+func (s *coreService) CreatePersistentVolumeClaim(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.persistentVolumeClaimResource())
+}
+
+// UpdatePersistentVolumeClaim update kube persistent volume claim
+func (s *coreService) UpdatePersistentVolumeClaim(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.persistentVolumeClaimResource())
+}
+
+// DeletePersistentVolumeClaim delete kube persistent volume claim
+func (s *coreService) DeletePersistentVolumeClaim(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.persistentVolumeClaimResource())
+}
+
+// ListPersistentVolumeClaim list kube persistent volume claim
+func (s *coreService) ListPersistentVolumeClaim(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.persistentVolumeClaimResource())
+}
@@ -136,6 +136,123 @@ func (s *coreService) BatchCreatePod(ctx *rest.Contexts) {
 	ctx.RespEntity(pods)
 }
 
+// UpdatePods update the pods by ids in place, the containers of the pods are replaced by the new ones
+func (s *coreService) UpdatePods(ctx *rest.Contexts) {
+	opt := new(types.UpdatePodsOption)
+	if err := ctx.DecodeInto(opt); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	podIDs := make([]int64, len(opt.Data))
+	for idx, data := range opt.Data {
+		podIDs[idx] = data.ID
+	}
+
+	// the creator and create time of the pods are kept
+	cond := mapstr.MapStr{
+		common.BKAppIDField: opt.BizID,
+		types.BKIDField:     mapstr.MapStr{common.BKDBIN: podIDs},
+	}
+	cond = util.SetModOwner(cond, ctx.Kit.SupplierAccount)
+
+	existPods := make([]types.Pod, 0)
+	err := mongodb.Client().Table(types.BKTableNameBasePod).Find(cond).
+		Fields(types.BKIDField, common.CreatorField, common.CreateTimeField).All(ctx.Kit.Ctx, &existPods)
+	if err != nil {
+		blog.Errorf("find pods failed, cond: %v, err: %v, rid: %s", cond, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBSelectFailed))
+		return
+	}
+
+	revisions := make(map[int64]table.Revision, len(existPods))
+	for _, pod := range existPods {
+		revisions[pod.ID] = pod.Revision
+	}
+
+	now := time.Now().Unix()
+	nodeIDMap := make(map[int64]struct{})
+	containers := make([]types.Container, 0)
+	for _, data := range opt.Data {
+		revision, exists := revisions[data.ID]
+		if !exists {
+			blog.Errorf("pod %d is not in business %d, rid: %s", data.ID, opt.BizID, ctx.Kit.Rid)
+			ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsIsInvalid, types.BKIDField))
+			return
+		}
+
+		pod, nodeID, err := s.combinationPodsInfo(ctx.Kit, data.Info, opt.BizID, now, data.ID)
+		if err != nil {
+			ctx.RespAutoError(err)
+			return
+		}
+		pod.Creator, pod.CreateTime = revision.Creator, revision.CreateTime
+		if nodeID != 0 {
+			nodeIDMap[nodeID] = struct{}{}
+		}
+
+		podCond := mapstr.MapStr{common.BKAppIDField: opt.BizID, types.BKIDField: data.ID}
+		podCond = util.SetModOwner(podCond, ctx.Kit.SupplierAccount)
+		if err := mongodb.Client().Table(types.BKTableNameBasePod).Update(ctx.Kit.Ctx, podCond, pod); err != nil {
+			blog.Errorf("update pod failed, cond: %v, err: %v, rid: %s", podCond, err, ctx.Kit.Rid)
+			ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBUpdateFailed))
+			return
+		}
+
+		if len(data.Info.Containers) == 0 {
+			continue
+		}
+
+		ids, err := mongodb.Client().NextSequences(ctx.Kit.Ctx, types.BKTableNameBaseContainer,
+			len(data.Info.Containers))
+		if err != nil {
+			blog.Errorf("update pod failed, generate container ids failed, err: %v, rid: %s", err, ctx.Kit.Rid)
+			ctx.RespAutoError(err)
+			return
+		}
+
+		for idx, container := range data.Info.Containers {
+			info, err := s.combinationContainerInfo(ctx.Kit, int64(ids[idx]), pod.ID, now, container)
+			if err != nil {
+				ctx.RespAutoError(err)
+				return
+			}
+			containers = append(containers, info)
+		}
+	}
+
+	// replace the containers of the pods
+	delContainerCond := mapstr.MapStr{
+		types.BKPodIDField: mapstr.MapStr{common.BKDBIN: podIDs},
+	}
+	err = mongodb.Client().Table(types.BKTableNameBaseContainer).Delete(ctx.Kit.Ctx, delContainerCond)
+	if err != nil {
+		blog.Errorf("delete containers failed, cond: %v, err: %v, rid: %s", delContainerCond, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBDeleteFailed))
+		return
+	}
+
+	if len(containers) != 0 {
+		err = mongodb.Client().Table(types.BKTableNameBaseContainer).Insert(ctx.Kit.Ctx, containers)
+		if err != nil {
+			blog.Errorf("create containers failed, data: %v, err: %v, rid: %s", containers, err, ctx.Kit.Rid)
+			ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBInsertFailed))
+			return
+		}
+	}
+
+	if err := s.updateNodeField(ctx.Kit, nodeIDMap); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(nil)
+}
+
 func (s *coreService) combinationPodsInfo(kit *rest.Kit, pod types.PodsInfo, bizID int64, now, id int64) (
 	types.Pod, int64, error) {
 
@@ -249,6 +366,18 @@ func (s *coreService) DeletePods(ctx *rest.Contexts) {
 		return
 	}
 
+	// delete the endpoints of the pods, since the pods are no longer members of any service
+	delEndpointCond := mapstr.MapStr{
+		types.BKPodIDField: mapstr.MapStr{common.BKDBIN: opt.PodIDs},
+	}
+
+	err = mongodb.Client().Table(types.BKTableNameBaseEndpoint).Delete(ctx.Kit.Ctx, delEndpointCond)
+	if err != nil {
+		blog.Errorf("delete endpoints failed, cond: %+v, err: %v, rid: %s", delEndpointCond, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
 	// delete the pods
 	delPodCond := mapstr.MapStr{
 		types.BKIDField: mapstr.MapStr{common.BKDBIN: opt.PodIDs},
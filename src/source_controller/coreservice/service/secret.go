@@ -0,0 +1,91 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+)
+
+// secretResource returns the definition of kube secret for the common kube resource handlers
+func (s *coreService) secretResource() *kubeResource {
+	return &kubeResource{
+		name:            "secret",
+		table:           types.BKTableNameBaseSecret,
+		newCreateOption: func() kubeResOption { return new(types.SecretCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.SecretUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.SecretDeleteOption) },
+		fillCreateData: func(kit *rest.Kit, bizID int64, opt kubeResOption) (int, error) {
+			req := opt.(*types.SecretCreateOption)
+			nsIDs := make([]int64, 0)
+			for _, data := range req.Data {
+				nsIDs = append(nsIDs, data.NamespaceID)
+			}
+			nsSpecs, err := s.getNamespaceSpecs(kit, bizID, nsIDs)
+			if err != nil {
+				return 0, err
+			}
+
+			for idx := range req.Data {
+				req.Data[idx].NamespaceSpec = nsSpecs[req.Data[idx].NamespaceID]
+			}
+			return len(req.Data), nil
+		},
+		setCreateBase: func(opt kubeResOption, ids []int64, supplierAccount string, rev table.Revision) interface{} {
+			req := opt.(*types.SecretCreateOption)
+			for idx := range req.Data {
+				req.Data[idx].ID = ids[idx]
+				req.Data[idx].SupplierAccount = supplierAccount
+				req.Data[idx].Revision = rev
+			}
+			return req.Data
+		},
+		updateInfo: func(opt kubeResOption) ([]int64, kubeResUpdateData) {
+			req := opt.(*types.SecretUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.SecretDeleteOption).IDs
+		},
+		newListResult: func() (interface{}, interface{}) {
+			resp := &types.SecretDataResp{Data: make([]types.Secret, 0)}
+			return &resp.Data, resp
+		},
+	}
+}
+
+// CreateSecret create kube secret
+func (s *coreService) CreateSecret(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.secretResource())
+}
+
+// UpdateSecret update kube secret
+func (s *coreService) UpdateSecret(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.secretResource())
+}
+
+// DeleteSecret delete kube secret
+func (s *coreService) DeleteSecret(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.secretResource())
+}
+
+// ListSecret list kube secret
+func (s *coreService) ListSecret(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.secretResource())
+}
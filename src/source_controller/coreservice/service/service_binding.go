@@ -0,0 +1,243 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"strconv"
+	"time"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/metadata"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// CreateServiceBinding bind kube workloads to the service template of a module
+func (s *coreService) CreateServiceBinding(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := new(types.ServiceBindingCreateOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	module, err := s.getServiceBindingModule(ctx.Kit, bizID, req.ModuleID)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	workloads, err := s.getServiceBindingWorkloads(ctx.Kit, bizID, req.Kind, req.WorkloadIDs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	// a workload can only be bound to one service template, its pods can not be the instances of two modules
+	existCond := mapstr.MapStr{
+		types.RefKindField: req.Kind,
+		types.RefIDField:   mapstr.MapStr{common.BKDBIN: req.WorkloadIDs},
+	}
+	existCond = util.SetQueryOwner(existCond, ctx.Kit.SupplierAccount)
+	existCnt, err := mongodb.Client().Table(types.BKTableNameWorkloadServiceBinding).Find(existCond).
+		Count(ctx.Kit.Ctx)
+	if err != nil {
+		blog.Errorf("count workload service bindings failed, cond: %v, err: %v, rid: %s", existCond, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBSelectFailed))
+		return
+	}
+
+	if existCnt > 0 {
+		blog.Errorf("workloads have already been bound, kind: %s, ids: %v, rid: %s", req.Kind, req.WorkloadIDs,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommDuplicateItem, "workload_ids"))
+		return
+	}
+
+	ids, err := mongodb.Client().NextSequences(ctx.Kit.Ctx, types.BKTableNameWorkloadServiceBinding, len(workloads))
+	if err != nil {
+		blog.Errorf("get workload service binding ids failed, err: %v, rid: %s", err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBSelectFailed))
+		return
+	}
+
+	now := time.Now().Unix()
+	respData := metadata.RspIDs{IDs: make([]int64, len(ids))}
+	bindings := make([]types.WorkloadServiceBinding, len(workloads))
+	for idx, workload := range workloads {
+		id := int64(ids[idx])
+		respData.IDs[idx] = id
+		bindings[idx] = types.WorkloadServiceBinding{
+			WorkloadSpec: types.WorkloadSpec{
+				NamespaceSpec: workload.NamespaceSpec,
+				Ref:           types.Reference{Kind: req.Kind, Name: workload.Name, ID: workload.ID},
+			},
+			ID:                id,
+			ServiceTemplateID: module.ServiceTemplateID,
+			ModuleID:          module.ModuleID,
+			SupplierAccount:   ctx.Kit.SupplierAccount,
+			Revision: table.Revision{
+				Creator:    ctx.Kit.User,
+				Modifier:   ctx.Kit.User,
+				CreateTime: now,
+				LastTime:   now,
+			},
+		}
+	}
+
+	if err := mongodb.Client().Table(types.BKTableNameWorkloadServiceBinding).Insert(ctx.Kit.Ctx,
+		bindings); err != nil {
+		blog.Errorf("add workload service bindings failed, data: %v, err: %v, rid: %s", bindings, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBInsertFailed))
+		return
+	}
+
+	ctx.RespEntity(respData)
+}
+
+// getServiceBindingModule get the module that the workloads are bound to, it must be bound with a service template
+func (s *coreService) getServiceBindingModule(kit *rest.Kit, bizID, moduleID int64) (*metadata.ModuleInst, error) {
+	filter := map[string]interface{}{
+		common.BKAppIDField:    bizID,
+		common.BKModuleIDField: moduleID,
+	}
+	filter = util.SetQueryOwner(filter, kit.SupplierAccount)
+
+	modules := make([]metadata.ModuleInst, 0)
+	err := mongodb.Client().Table(common.BKTableNameBaseModule).Find(filter).
+		Fields(common.BKModuleIDField, common.BKServiceTemplateIDField).All(kit.Ctx, &modules)
+	if err != nil {
+		blog.Errorf("find module failed, filter: %v, err: %v, rid: %s", filter, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	if len(modules) == 0 {
+		blog.Errorf("can not find module, filter: %v, rid: %s", filter, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKModuleIDField)
+	}
+
+	if modules[0].ServiceTemplateID == common.ServiceTemplateIDNotSet {
+		blog.Errorf("module %d is not bound with service template, rid: %s", moduleID, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCoreServiceModuleNotBoundWithTemplate)
+	}
+
+	return &modules[0], nil
+}
+
+// getServiceBindingWorkloads get the workloads to be bound, all of them must exist in the business
+func (s *coreService) getServiceBindingWorkloads(kit *rest.Kit, bizID int64, kind types.WorkloadType,
+	ids []int64) ([]types.WorkloadBase, error) {
+
+	tableName, err := kind.Table()
+	if err != nil {
+		blog.Errorf("get workload table name failed, kind: %s, err: %v, rid: %s", kind, err, kit.Rid)
+		return nil, kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, types.KindField)
+	}
+
+	ids = util.IntArrayUnique(ids)
+	filter := map[string]interface{}{
+		common.BKAppIDField: bizID,
+		common.BKFieldID:    mapstr.MapStr{common.BKDBIN: ids},
+	}
+	filter = util.SetQueryOwner(filter, kit.SupplierAccount)
+
+	workloads := make([]types.WorkloadBase, 0)
+	if err := mongodb.Client().Table(tableName).Find(filter).All(kit.Ctx, &workloads); err != nil {
+		blog.Errorf("find workloads failed, filter: %v, err: %v, rid: %s", filter, err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	if len(workloads) != len(ids) {
+		blog.Errorf("can not find all workloads, filter: %v, rid: %s", filter, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommNotFound)
+	}
+
+	return workloads, nil
+}
+
+// DeleteServiceBinding delete kube workload service bindings
+func (s *coreService) DeleteServiceBinding(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	req := new(types.ServiceBindingDeleteOption)
+	if err := ctx.DecodeInto(req); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := req.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	filter := mapstr.MapStr{
+		common.BKFieldID:    mapstr.MapStr{common.BKDBIN: req.IDs},
+		common.BKAppIDField: bizID,
+	}
+	filter = util.SetModOwner(filter, ctx.Kit.SupplierAccount)
+	if err := mongodb.Client().Table(types.BKTableNameWorkloadServiceBinding).Delete(ctx.Kit.Ctx, filter); err != nil {
+		blog.Errorf("delete workload service bindings failed, filter: %v, err: %v, rid: %s", filter, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBDeleteFailed))
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// ListServiceBinding list kube workload service bindings
+func (s *coreService) ListServiceBinding(ctx *rest.Contexts) {
+	input := new(metadata.QueryCondition)
+	if err := ctx.DecodeInto(input); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	util.SetQueryOwner(input.Condition, ctx.Kit.SupplierAccount)
+	bindings := make([]types.WorkloadServiceBinding, 0)
+	err := mongodb.Client().Table(types.BKTableNameWorkloadServiceBinding).Find(input.Condition).
+		Start(uint64(input.Page.Start)).
+		Limit(uint64(input.Page.Limit)).
+		Sort(input.Page.Sort).
+		Fields(input.Fields...).All(ctx.Kit.Ctx, &bindings)
+	if err != nil {
+		blog.Errorf("search workload service bindings failed, cond: %v, err: %v, rid: %s", input, err, ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBSelectFailed))
+		return
+	}
+
+	ctx.RespEntity(&types.ServiceBindingDataResp{Data: bindings})
+}
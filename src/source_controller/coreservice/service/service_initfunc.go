@@ -101,6 +101,8 @@ func (s *coreService) initModelInstances(web *restful.WebService) {
 	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/update/model/{bk_obj_id}/instance", Handler: s.UpdateModelInstances})
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/read/model/{bk_obj_id}/instances", Handler: s.SearchModelInstances})
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/count/model/{bk_obj_id}/instances", Handler: s.CountModelInstances})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/aggregate/model/{bk_obj_id}/instances",
+		Handler: s.AggregateModelInstances})
 	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/delete/model/{bk_obj_id}/instance", Handler: s.DeleteModelInstances})
 	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/delete/model/{bk_obj_id}/instance/cascade", Handler: s.CascadeDeleteModelInstances})
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/get/instance/object/mapping", Handler: s.GetInstanceObjectMapping})
@@ -193,6 +195,35 @@ func (s *coreService) host(web *restful.WebService) {
 	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/delete/host/lock", Handler: s.UnlockHost})
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/host/lock/search", Handler: s.QueryLockHost})
 
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/host/snapshot/history",
+		Handler: s.SearchHostSnapHistory})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/host/snapshot/change_log",
+		Handler: s.SearchHostSnapChangeLog})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/host/snapshot/drift",
+		Handler: s.SearchHostSnapDrift})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/host/snapshot/status",
+		Handler: s.SearchHostSnapStatus})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/update/host/stale_policy",
+		Handler: s.SaveStaleHostPolicy})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/find/host/stale_policy",
+		Handler: s.GetStaleHostPolicy})
+
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/update/host/lifecycle", Handler: s.SaveHostLifecycle})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/find/host/lifecycle", Handler: s.GetHostLifecycle})
+
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/create/host/transfer_schedule",
+		Handler: s.CreateHostTransferSchedule})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/host/transfer_schedule",
+		Handler: s.ListHostTransferSchedule})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/update/host/transfer_schedule/status",
+		Handler: s.UpdateHostTransferScheduleStatus})
+
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/host/duplicate",
+		Handler: s.FindDuplicateHosts})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/find/host/merge/preview",
+		Handler: s.PreviewMergeHost})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/update/host/merge", Handler: s.MergeHost})
+
 	// dynamic grouping handlers.
 	utility.AddHandler(rest.Action{
 		Verb:    http.MethodPost,
@@ -364,6 +395,28 @@ func (s *coreService) initCloudSync(web *restful.WebService) {
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloud/sync/history", Handler: s.SearchSyncHistory})
 	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/delete/cloud/sync/destroyed_host_related", Handler: s.DeleteDestroyedHostRelated})
 
+	// cloud area ip address management
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/create/cloud/subnet", Handler: s.CreateSubnet})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloud/subnet", Handler: s.SearchSubnet})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/update/cloud/subnet/{bk_subnet_id}",
+		Handler: s.UpdateSubnet})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/delete/cloud/subnet/{bk_subnet_id}",
+		Handler: s.DeleteSubnet})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloud/subnet/{bk_subnet_id}/hosts",
+		Handler: s.FindSubnetHosts})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloud/subnet/utilization",
+		Handler: s.GetSubnetUtilization})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloud/subnet/{bk_subnet_id}/ip/reservation",
+		Handler: s.SearchSubnetIPReservation})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost,
+		Path: "/createmany/cloud/subnet/{bk_subnet_id}/ip/reservation", Handler: s.ReserveSubnetIP})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/cloud/subnet/{bk_subnet_id}/ip/allocation",
+		Handler: s.AllocateSubnetIP})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete,
+		Path: "/deletemany/cloud/subnet/{bk_subnet_id}/ip/reservation", Handler: s.ReleaseSubnetIP})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/cloud/ip/conflict",
+		Handler: s.FindIPConflicts})
+
 	utility.AddToRestfulWebService(web)
 }
 
@@ -46,6 +46,11 @@ func (s *coreService) initKube(web *restful.WebService) {
 		Path:    "/findmany/kube/cluster",
 		Handler: s.SearchClusters})
 
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/update/kube/cluster/sync_status",
+		Handler: s.SaveClusterSyncStatus})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/kube/cluster/sync_status",
+		Handler: s.ListClusterSyncStatus})
+
 	utility.AddHandler(rest.Action{Verb: http.MethodPut,
 		Path:    "/updatemany/kube/node/{bk_biz_id}",
 		Handler: s.BatchUpdateNode})
@@ -87,8 +92,108 @@ func (s *coreService) initKube(web *restful.WebService) {
 	// pod
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/pod", Handler: s.ListPod})
 	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/pod", Handler: s.DeletePods})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/kube/pod", Handler: s.UpdatePods})
 
 	// container
 	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/container", Handler: s.ListContainer})
+
+	// service
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/service/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateService})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/service/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateService})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/service/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteService})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/service", Handler: s.ListService})
+
+	// ingress
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/ingress/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateIngress})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/ingress/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateIngress})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/ingress/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteIngress})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/ingress", Handler: s.ListIngress})
+
+	// endpoint
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/endpoint/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateEndpoint})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/endpoint/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateEndpoint})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/endpoint/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteEndpoint})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/endpoint", Handler: s.ListEndpoint})
+
+	// pvc
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/pvc/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreatePersistentVolumeClaim})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/pvc/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdatePersistentVolumeClaim})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/pvc/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeletePersistentVolumeClaim})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/pvc", Handler: s.ListPersistentVolumeClaim})
+
+	// pv
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/pv/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreatePersistentVolume})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/pv/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdatePersistentVolume})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/pv/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeletePersistentVolume})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/pv", Handler: s.ListPersistentVolume})
+
+	// storage class
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/storage_class/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateStorageClass})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/storage_class/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateStorageClass})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/storage_class/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteStorageClass})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/storage_class", Handler: s.ListStorageClass})
+
+	// storage capacity
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/find/storage_capacity/bk_biz_id/{bk_biz_id}",
+		Handler: s.AggregateStorageCapacity})
+
+	// workload service binding
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/service_binding/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateServiceBinding})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/service_binding/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteServiceBinding})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/service_binding",
+		Handler: s.ListServiceBinding})
+
+	// horizontal pod autoscaler
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/hpa/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateHorizontalPodAutoscaler})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/hpa/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateHorizontalPodAutoscaler})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/hpa/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteHorizontalPodAutoscaler})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/hpa",
+		Handler: s.ListHorizontalPodAutoscaler})
+
+	// workload replica history
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/replica_history",
+		Handler: s.ListReplicaHistory})
+
+	// config map
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/config_map/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateConfigMap})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/config_map/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateConfigMap})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/config_map/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteConfigMap})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/config_map", Handler: s.ListConfigMap})
+
+	// secret
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/createmany/secret/bk_biz_id/{bk_biz_id}",
+		Handler: s.CreateSecret})
+	utility.AddHandler(rest.Action{Verb: http.MethodPut, Path: "/updatemany/secret/bk_biz_id/{bk_biz_id}",
+		Handler: s.UpdateSecret})
+	utility.AddHandler(rest.Action{Verb: http.MethodDelete, Path: "/deletemany/secret/bk_biz_id/{bk_biz_id}",
+		Handler: s.DeleteSecret})
+	utility.AddHandler(rest.Action{Verb: http.MethodPost, Path: "/findmany/secret", Handler: s.ListSecret})
+
 	utility.AddToRestfulWebService(web)
 }
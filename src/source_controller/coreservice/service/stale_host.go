@@ -0,0 +1,76 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// SearchHostSnapStatus search the last snapshot time and the stale flag of the specified hosts
+func (s *coreService) SearchHostSnapStatus(ctx *rest.Contexts) {
+	option := new(metadata.SearchHostSnapStatusOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.core.HostOperation().SearchHostSnapStatus(ctx.Kit, option)
+	if err != nil {
+		blog.Errorf("search host snapshot status failed, option: %+v, err: %v, rid: %s", option, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(result)
+}
+
+// SaveStaleHostPolicy save the stale host policy of the supplier account
+func (s *coreService) SaveStaleHostPolicy(ctx *rest.Contexts) {
+	policy := new(metadata.StaleHostPolicy)
+	if err := ctx.DecodeInto(policy); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := policy.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	if err := s.core.HostOperation().SaveStaleHostPolicy(ctx.Kit, policy); err != nil {
+		blog.Errorf("save stale host policy failed, policy: %+v, err: %v, rid: %s", policy, err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(nil)
+}
+
+// GetStaleHostPolicy get the stale host policy of the supplier account
+func (s *coreService) GetStaleHostPolicy(ctx *rest.Contexts) {
+	policy, err := s.core.HostOperation().GetStaleHostPolicy(ctx.Kit)
+	if err != nil {
+		blog.Errorf("get stale host policy failed, err: %v, rid: %s", err, ctx.Kit.Rid)
+		ctx.RespAutoError(err)
+		return
+	}
+
+	ctx.RespEntity(policy)
+}
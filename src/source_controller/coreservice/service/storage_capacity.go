@@ -0,0 +1,127 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/blog"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/mapstr"
+	"configcenter/src/common/util"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/driver/mongodb"
+)
+
+// storageStat the count and total capacity of the storage resources grouped by namespace or cluster
+type storageStat struct {
+	ID       int64 `bson:"_id"`
+	Count    int64 `bson:"count"`
+	Capacity int64 `bson:"capacity"`
+}
+
+// AggregateStorageCapacity aggregate the storage capacity of the persistent volume claims and persistent volumes
+// by namespace or cluster
+func (s *coreService) AggregateStorageCapacity(ctx *rest.Contexts) {
+	bizID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKAppIDField), 10, 64)
+	if err != nil {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKAppIDField))
+		return
+	}
+
+	opt := new(types.StorageCapacityOption)
+	if err := ctx.DecodeInto(opt); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := opt.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	groupField := types.BKNamespaceIDField
+	if opt.Kind == types.KubeCluster {
+		groupField = types.BKClusterIDFiled
+	}
+
+	pvcStats, err := s.aggregateStorageStat(ctx.Kit, types.BKTableNameBasePersistentVolumeClaim, bizID, groupField,
+		opt.IDs)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	pvStats := make(map[int64]storageStat)
+	if opt.Kind == types.KubeCluster {
+		pvStats, err = s.aggregateStorageStat(ctx.Kit, types.BKTableNameBasePersistentVolume, bizID, groupField,
+			opt.IDs)
+		if err != nil {
+			ctx.RespAutoError(err)
+			return
+		}
+	}
+
+	ids := util.IntArrayUnique(opt.IDs)
+	result := make([]types.StorageCapacity, len(ids))
+	for idx, id := range ids {
+		result[idx] = types.StorageCapacity{
+			ID:          id,
+			PVCCount:    pvcStats[id].Count,
+			PVCCapacity: pvcStats[id].Capacity,
+			PVCount:     pvStats[id].Count,
+			PVCapacity:  pvStats[id].Capacity,
+		}
+	}
+
+	ctx.RespEntity(result)
+}
+
+// aggregateStorageStat aggregate the count and total capacity of the storage resources in the table by group field
+func (s *coreService) aggregateStorageStat(kit *rest.Kit, table string, bizID int64, groupField string,
+	ids []int64) (map[int64]storageStat, error) {
+
+	filter := mapstr.MapStr{
+		common.BKAppIDField: bizID,
+		groupField:          mapstr.MapStr{common.BKDBIN: ids},
+	}
+	filter = util.SetQueryOwner(filter, kit.SupplierAccount)
+
+	pipeline := []map[string]interface{}{
+		{common.BKDBMatch: filter},
+		{common.BKDBGroup: map[string]interface{}{
+			"_id":               "$" + groupField,
+			"count":             map[string]interface{}{common.BKDBSum: 1},
+			types.CapacityField: map[string]interface{}{common.BKDBSum: "$" + types.CapacityField},
+		}},
+	}
+
+	stats := make([]storageStat, 0)
+	if err := mongodb.Client().Table(table).AggregateAll(kit.Ctx, pipeline, &stats); err != nil {
+		blog.Errorf("aggregate storage capacity failed, table: %s, pipeline: %v, err: %v, rid: %s", table, pipeline,
+			err, kit.Rid)
+		return nil, kit.CCError.CCError(common.CCErrCommDBSelectFailed)
+	}
+
+	statMap := make(map[int64]storageStat, len(stats))
+	for _, stat := range stats {
+		statMap[stat.ID] = stat
+	}
+	return statMap, nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Tencent is pleased to support the open source community by making
+ * 蓝鲸智云 - 配置平台 (BlueKing - Configuration System) available.
+ * Copyright (C) 2017 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ * We undertake not to change the open source license (MIT license) applicable
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"configcenter/src/common/http/rest"
+	"configcenter/src/kube/types"
+	"configcenter/src/storage/dal/table"
+)
+
+// storageClassResource returns the definition of kube storage class for the common kube resource handlers
+func (s *coreService) storageClassResource() *kubeResource {
+	return &kubeResource{
+		name:            "storage class",
+		table:           types.BKTableNameBaseStorageClass,
+		newCreateOption: func() kubeResOption { return new(types.StorageClassCreateOption) },
+		newUpdateOption: func() kubeResOption { return new(types.StorageClassUpdateOption) },
+		newDeleteOption: func() kubeResOption { return new(types.StorageClassDeleteOption) },
+		fillCreateData: func(kit *rest.Kit, bizID int64, opt kubeResOption) (int, error) {
+			req := opt.(*types.StorageClassCreateOption)
+			clusterIDs := make([]int64, 0)
+			for _, data := range req.Data {
+				clusterIDs = append(clusterIDs, data.ClusterID)
+			}
+			clusterSpecs, err := s.getClusterSpecs(kit, bizID, clusterIDs)
+			if err != nil {
+				return 0, err
+			}
+
+			for idx := range req.Data {
+				req.Data[idx].ClusterSpec = clusterSpecs[req.Data[idx].ClusterID]
+			}
+			return len(req.Data), nil
+		},
+		setCreateBase: func(opt kubeResOption, ids []int64, supplierAccount string, rev table.Revision) interface{} {
+			req := opt.(*types.StorageClassCreateOption)
+			for idx := range req.Data {
+				req.Data[idx].ID = ids[idx]
+				req.Data[idx].SupplierAccount = supplierAccount
+				req.Data[idx].Revision = rev
+			}
+			return req.Data
+		},
+		updateInfo: func(opt kubeResOption) ([]int64, kubeResUpdateData) {
+			req := opt.(*types.StorageClassUpdateOption)
+			return req.IDs, req.Data
+		},
+		deleteIDs: func(opt kubeResOption) []int64 {
+			return opt.(*types.StorageClassDeleteOption).IDs
+		},
+		newListResult: func() (interface{}, interface{}) {
+			resp := &types.StorageClassDataResp{Data: make([]types.StorageClass, 0)}
+			return &resp.Data, resp
+		},
+	}
+}
+
+// CreateStorageClass create kube storage class
+func (s *coreService) CreateStorageClass(ctx *rest.Contexts) {
+	s.createKubeResource(ctx, s.storageClassResource())
+}
+
+// UpdateStorageClass update kube storage class
+func (s *coreService) UpdateStorageClass(ctx *rest.Contexts) {
+	s.updateKubeResource(ctx, s.storageClassResource())
+}
+
+// DeleteStorageClass delete kube storage class
+func (s *coreService) DeleteStorageClass(ctx *rest.Contexts) {
+	s.deleteKubeResource(ctx, s.storageClassResource())
+}
+
+// ListStorageClass list kube storage class
+func (s *coreService) ListStorageClass(ctx *rest.Contexts) {
+	s.listKubeResource(ctx, s.storageClassResource())
+}
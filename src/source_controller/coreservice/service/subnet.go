@@ -0,0 +1,242 @@
+/*
+ * Tencent is pleased to support the open source community by making 蓝鲸 available.
+ * Copyright (C) 2017-2018 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"strconv"
+
+	"configcenter/src/common"
+	"configcenter/src/common/http/rest"
+	"configcenter/src/common/metadata"
+)
+
+// CreateSubnet create a subnet in the cloud area
+func (s *coreService) CreateSubnet(ctx *rest.Contexts) {
+	subnet := new(metadata.Subnet)
+	if err := ctx.DecodeInto(subnet); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result, err := s.core.CloudOperation().CreateSubnet(ctx.Kit, subnet)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(result)
+}
+
+// SearchSubnet search the subnets
+func (s *coreService) SearchSubnet(ctx *rest.Contexts) {
+	option := new(metadata.SearchSubnetOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.core.CloudOperation().SearchSubnet(ctx.Kit, option)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(result)
+}
+
+// UpdateSubnet update the subnet
+func (s *coreService) UpdateSubnet(ctx *rest.Contexts) {
+	subnetID, ok := parseSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.UpdateSubnetOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if err := s.core.CloudOperation().UpdateSubnet(ctx.Kit, subnetID, option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(nil)
+}
+
+// DeleteSubnet delete the subnet and its ip reservations
+func (s *coreService) DeleteSubnet(ctx *rest.Contexts) {
+	subnetID, ok := parseSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := s.core.CloudOperation().DeleteSubnet(ctx.Kit, subnetID); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(nil)
+}
+
+// FindSubnetHosts find the hosts whose inner ips are in the subnet
+func (s *coreService) FindSubnetHosts(ctx *rest.Contexts) {
+	subnetID, ok := parseSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.FindSubnetHostOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.core.CloudOperation().FindSubnetHosts(ctx.Kit, subnetID, option.Page)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(result)
+}
+
+// GetSubnetUtilization get the ip utilization of the subnets in a cloud area
+func (s *coreService) GetSubnetUtilization(ctx *rest.Contexts) {
+	option := new(metadata.SubnetUtilizationOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result, err := s.core.CloudOperation().GetSubnetUtilization(ctx.Kit, option)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(result)
+}
+
+// SearchSubnetIPReservation search the reserved ips of the subnet
+func (s *coreService) SearchSubnetIPReservation(ctx *rest.Contexts) {
+	subnetID, ok := parseSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := s.core.CloudOperation().SearchSubnetIPReservation(ctx.Kit, subnetID)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(result)
+}
+
+// ReserveSubnetIP reserve the specified ips of the subnet
+func (s *coreService) ReserveSubnetIP(ctx *rest.Contexts) {
+	subnetID, ok := parseSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.ReserveSubnetIPOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result, err := s.core.CloudOperation().ReserveSubnetIP(ctx.Kit, subnetID, option)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(result)
+}
+
+// AllocateSubnetIP allocate and reserve the next free ips of the subnet
+func (s *coreService) AllocateSubnetIP(ctx *rest.Contexts) {
+	subnetID, ok := parseSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.AllocateSubnetIPOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	result, err := s.core.CloudOperation().AllocateSubnetIP(ctx.Kit, subnetID, option)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(result)
+}
+
+// ReleaseSubnetIP release the reserved ips of the subnet
+func (s *coreService) ReleaseSubnetIP(ctx *rest.Contexts) {
+	subnetID, ok := parseSubnetID(ctx)
+	if !ok {
+		return
+	}
+
+	option := new(metadata.ReserveSubnetIPOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if err := s.core.CloudOperation().ReleaseSubnetIP(ctx.Kit, subnetID, option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(nil)
+}
+
+// FindIPConflicts find the ips that are claimed by more than one host in the same cloud area
+func (s *coreService) FindIPConflicts(ctx *rest.Contexts) {
+	option := new(metadata.FindIPConflictOption)
+	if err := ctx.DecodeInto(option); err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+
+	if rawErr := option.Validate(); rawErr.ErrCode != 0 {
+		ctx.RespAutoError(rawErr.ToCCError(ctx.Kit.CCError))
+		return
+	}
+
+	result, err := s.core.CloudOperation().FindIPConflicts(ctx.Kit, option)
+	if err != nil {
+		ctx.RespAutoError(err)
+		return
+	}
+	ctx.RespEntity(result)
+}
+
+// parseSubnetID parse the subnet id from the request path
+func parseSubnetID(ctx *rest.Contexts) (int64, bool) {
+	subnetID, err := strconv.ParseInt(ctx.Request.PathParameter(common.BKSubnetIDField), 10, 64)
+	if err != nil || subnetID <= 0 {
+		ctx.RespAutoError(ctx.Kit.CCError.CCErrorf(common.CCErrCommParamsInvalid, common.BKSubnetIDField))
+		return 0, false
+	}
+	return subnetID, true
+}
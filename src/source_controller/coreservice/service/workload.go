@@ -247,6 +247,32 @@ func (s *coreService) DeleteWorkload(ctx *rest.Contexts) {
 		return
 	}
 
+	// delete the service template bindings and the replica history of the workloads
+	refFilter := mapstr.MapStr{
+		types.RefKindField:  kind,
+		types.RefIDField:    mapstr.MapStr{common.BKDBIN: req.IDs},
+		common.BKAppIDField: bizID,
+	}
+	util.SetModOwner(refFilter, ctx.Kit.SupplierAccount)
+	err = mongodb.Client().Table(types.BKTableNameWorkloadServiceBinding).Delete(ctx.Kit.Ctx, refFilter)
+	if err != nil {
+		blog.Errorf("delete workload service bindings failed, filter: %v, err: %v, rid: %s", refFilter, err,
+			ctx.Kit.Rid)
+		ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBDeleteFailed))
+		return
+	}
+
+	// only the workloads whose replica count can be scaled have replica history
+	if types.IsReplicaHistoryKind(kind) {
+		err = mongodb.Client().Table(types.BKTableNameWorkloadReplicaHistory).Delete(ctx.Kit.Ctx, refFilter)
+		if err != nil {
+			blog.Errorf("delete workload replica history failed, filter: %v, err: %v, rid: %s", refFilter, err,
+				ctx.Kit.Rid)
+			ctx.RespAutoError(ctx.Kit.CCError.CCError(common.CCErrCommDBDeleteFailed))
+			return
+		}
+	}
+
 	ctx.RespEntity(nil)
 }
 
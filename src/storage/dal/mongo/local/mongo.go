@@ -597,6 +597,40 @@ func (c *Collection) UpdateMultiModel(ctx context.Context, filter types.Filter,
 
 }
 
+// UpsertMultiModel update data based on operators, or insert it if no data matches the filter.
+func (c *Collection) UpsertMultiModel(ctx context.Context, filter types.Filter,
+	updateModel ...types.ModeUpdate) error {
+
+	mtc.collectOperCount(c.collName, upsertOper)
+
+	start := time.Now()
+	defer func() {
+		mtc.collectOperDuration(c.collName, upsertOper, time.Since(start))
+	}()
+
+	data := bson.M{}
+	for _, item := range updateModel {
+		op := "$" + item.Op
+		if _, ok := data[op]; ok {
+			return errors.New(item.Op + " appear multiple times")
+		}
+		data[op] = item.Doc
+	}
+
+	doUpsert := true
+	upsertOpt := &options.UpdateOptions{
+		Upsert: &doUpsert,
+	}
+	return c.tm.AutoRunWithTxn(ctx, c.dbc, func(ctx context.Context) error {
+		_, err := c.dbc.Database(c.dbname).Collection(c.collName).UpdateOne(ctx, filter, data, upsertOpt)
+		if err != nil {
+			mtc.collectErrorCount(c.collName, upsertOper)
+			return err
+		}
+		return nil
+	})
+}
+
 // Delete 删除数据
 func (c *Collection) Delete(ctx context.Context, filter types.Filter) error {
 	_, err := c.DeleteMany(ctx, filter)
@@ -663,6 +697,16 @@ func (c *Collection) tryArchiveDeletedDoc(ctx context.Context, filter types.Filt
 	case kubetypes.BKTableNameBaseCustom:
 	case kubetypes.BKTableNameBasePod:
 	case kubetypes.BKTableNameBaseContainer:
+	case kubetypes.BKTableNameBaseService:
+	case kubetypes.BKTableNameBaseIngress:
+	case kubetypes.BKTableNameBaseEndpoint:
+	case kubetypes.BKTableNameBasePersistentVolumeClaim:
+	case kubetypes.BKTableNameBasePersistentVolume:
+	case kubetypes.BKTableNameBaseStorageClass:
+	case kubetypes.BKTableNameWorkloadServiceBinding:
+	case kubetypes.BKTableNameBaseHorizontalPodAutoscaler:
+	case kubetypes.BKTableNameBaseConfigMap:
+	case kubetypes.BKTableNameBaseSecret:
 		// NOTE: should not use the table name for archive, the object instance and association
 		// was saved in sharding tables, we still case the BKTableNameBaseInst here for the archive
 		// error message in order to find the wrong table name used in logics level.
@@ -30,6 +30,10 @@ var (
 
 	UpdateOpAddToSet = "addToSet"
 	UpdateOpPull     = "pull"
+	UpdateOpSet      = "set"
+	UpdateOpInc      = "inc"
+	UpdateOpMin      = "min"
+	UpdateOpMax      = "max"
 )
 
 // Filter condition alias name
@@ -51,6 +55,8 @@ type Table interface {
 	Upsert(ctx context.Context, filter Filter, doc interface{}) error
 	// UpdateMultiModel  data based on operators.
 	UpdateMultiModel(ctx context.Context, filter Filter, updateModel ...ModeUpdate) error
+	// UpsertMultiModel update data based on operators, or insert it if no data matches the filter.
+	UpsertMultiModel(ctx context.Context, filter Filter, updateModel ...ModeUpdate) error
 
 	// Delete 删除数据
 	Delete(ctx context.Context, filter Filter) error
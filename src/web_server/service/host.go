@@ -71,6 +71,8 @@ type excelImportUpdateHostInput struct {
 	// 用来限定当前操作对象导出数据的时候，需要使用的唯一校验关系，
 	// 自关联的时候，规定左边对象使用到的唯一索引
 	ObjectUniqueID int64 `json:"object_unique_id"`
+	// LockOverrideReason 强制更新被其他用户锁定的主机的原因，为空时不更新被其他用户锁定的主机
+	LockOverrideReason string `json:"lock_override_reason"`
 }
 
 // ImportHost import host
@@ -485,6 +487,9 @@ func (s *Service) UpdateHosts(c *gin.Context) {
 		c.String(http.StatusOK, string(msg))
 		return
 	}
+	if len(inputJSON.LockOverrideReason) > 0 {
+		c.Request.Header.Set(common.BKHTTPHostLockOverride, inputJSON.LockOverrideReason)
+	}
 	result := s.Logics.UpdateHosts(ctx, f, c.Request.Header, defLang, inputJSON.BizID, inputJSON.OpType,
 		inputJSON.AssociationCond, inputJSON.ObjectUniqueID)
 